@@ -80,7 +80,10 @@ func generateSpec(apiVersion string) *huma.OpenAPI {
 		GitCommit: version.GitCommit,
 		BuildTime: version.BuildDate,
 	}, &router.RouteOptions{
-		Stores: v1alpha1store.NewStores(nil, pkgdb.OSSSchemaRegistry()),
+		Stores:          v1alpha1store.NewStores(nil, pkgdb.OSSSchemaRegistry()),
+		SkillArchives:   v1alpha1store.NewSkillArchiveStore(nil, pkgdb.MustNewSchema(pkgdb.OSSSchema)),
+		APITokens:       v1alpha1store.NewAPITokenStore(nil, pkgdb.MustNewSchema(pkgdb.OSSSchema)),
+		NamespaceOwners: v1alpha1store.NewNamespaceOwnerStore(nil, pkgdb.MustNewSchema(pkgdb.OSSSchema)),
 	}); err != nil {
 		panic(fmt.Sprintf("router.RegisterRoutes: %v", err))
 	}
@@ -119,6 +119,12 @@ func runApply(cmd *cobra.Command, deps cliruntime.Deps, dryRun bool) error {
 // Multi-document YAML files are supported: each document is patched
 // independently, then re-emitted as a single multi-doc stream.
 func InjectArctlLabels(yamlPath string) ([]byte, error) {
+	return injectArctlLabels(yamlPath, os.Stdout)
+}
+
+// injectArctlLabels is InjectArctlLabels with the injection notice written
+// to log, so commands that print YAML on stdout can keep it clean.
+func injectArctlLabels(yamlPath string, log io.Writer) ([]byte, error) {
 	data, err := os.ReadFile(yamlPath)
 	if err != nil {
 		return nil, err
@@ -167,7 +173,7 @@ func InjectArctlLabels(yamlPath string) ([]byte, error) {
 		return data, nil
 	}
 
-	fmt.Printf("→ Injecting labels from arctl.yaml: arctl.dev/framework=%s, arctl.dev/language=%s\n",
+	fmt.Fprintf(log, "→ Injecting labels from arctl.yaml: arctl.dev/framework=%s, arctl.dev/language=%s\n",
 		cfg.Framework, cfg.Language)
 	return marshalYAMLDocs(docs)
 }
@@ -267,5 +273,8 @@ func printResults(out io.Writer, results []arv0.ApplyResult, dryRun bool) {
 			fmt.Fprintf(out, ": %s", r.Error)
 		}
 		fmt.Fprintln(out)
+		for _, w := range r.Warnings {
+			fmt.Fprintf(out, "  warning: %s\n", w)
+		}
 	}
 }
@@ -94,7 +94,7 @@ func TestApplyPostsToBatchEndpoint(t *testing.T) {
 // TestApplyPrintsPerResourceStatus verifies stdout contains per-resource lines.
 func TestApplyPrintsPerResourceStatus(t *testing.T) {
 	results := []arv0.ApplyResult{
-		{Kind: "agent", Name: "a", Tag: "1.0", Status: arv0.ApplyStatusConfigured},
+		{Kind: "agent", Name: "a", Tag: "1.0", Status: arv0.ApplyStatusConfigured, Warnings: []string{"MCPServer default/fetch:1.0.0 is deprecated"}},
 		{Kind: "deployment", Name: "x", Status: arv0.ApplyStatusFailed, Error: "drift detected"},
 	}
 	srv, _ := newApplyTestServer(t, results)
@@ -110,6 +110,7 @@ func TestApplyPrintsPerResourceStatus(t *testing.T) {
 
 	output := out.String()
 	assert.Contains(t, output, "✓ agent/a")
+	assert.Contains(t, output, "  warning: MCPServer default/fetch:1.0.0 is deprecated")
 	assert.Contains(t, output, "✗ deployment/x")
 }
 
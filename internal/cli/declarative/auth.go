@@ -0,0 +1,75 @@
+package declarative
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+)
+
+// NewAuthCmd returns the "auth" command group.
+func NewAuthCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cliruntime.CommandAuth,
+		Short: "Inspect registry authorization",
+	}
+	cmd.AddCommand(newCanICmd(deps))
+	return cmd
+}
+
+func newCanICmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "can-i VERB KIND [NAME]",
+		Short: "Check whether you may perform a verb",
+		Long: `Check whether the server would let you perform VERB on KIND, answered by
+the same authorization the resource endpoints run.
+
+VERB is get, list, apply or delete. Without NAME the question is about the
+kind as a whole; list with --namespace all asks about every namespace. A
+denial prints "no" and the server's reason.`,
+		Example: `  arctl auth can-i apply agent support-bot --namespace team-a
+  arctl auth can-i list mcp --namespace all
+  arctl auth can-i delete rolebinding publishers`,
+		Args:         cobra.RangeArgs(2, 3),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, _ := cmd.Flags().GetString("namespace")
+			k, err := kindRegistry(deps).Lookup(args[1])
+			if err != nil {
+				return err
+			}
+			kind, ok := canonicalKindName(k)
+			if !ok {
+				return fmt.Errorf("authorization checks not supported for kind %q", k.Kind)
+			}
+			var name string
+			if len(args) == 3 {
+				name = args[2]
+			}
+			if deps.Runtime == nil {
+				return errRegistryRuntimeNotConfigured
+			}
+			c, err := deps.Runtime.RegistryClient(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("resolving registry client: %w", err)
+			}
+			review, err := c.CanI(cmd.Context(), args[0], kind, namespace, name)
+			if err != nil {
+				return fmt.Errorf("access review failed: %w", err)
+			}
+			if review.Allowed {
+				fmt.Fprintln(cmd.OutOrStdout(), "yes")
+				return nil
+			}
+			if review.Reason == "" {
+				fmt.Fprintln(cmd.OutOrStdout(), "no")
+				return nil
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "no - %s\n", review.Reason)
+			return nil
+		},
+	}
+	cmd.Flags().String("namespace", "", "Namespace to check. Defaults to default; \"all\" checks every namespace.")
+	return cmd
+}
@@ -0,0 +1,60 @@
+package declarative_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+)
+
+func runAuthCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	out := &bytes.Buffer{}
+	cmd := declarative.NewAuthCmd(declarativeTestDeps(nil))
+	cmd.SetOut(out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestAuthCanI(t *testing.T) {
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v0/auth/can-i" {
+			http.NotFound(w, r)
+			return
+		}
+		got = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		if got.Get("name") == "billing-bot" {
+			_, _ = w.Write([]byte(`{"verb":"apply","kind":"Agent","namespace":"team-a","name":"billing-bot","allowed":false,"reason":"forbidden: \"alice\" cannot apply Agent team-a/billing-bot"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"verb":"list","kind":"MCPServer","allowed":true}`))
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	out, err := runAuthCmd(t, "can-i", "list", "mcp", "--namespace", "all")
+	require.NoError(t, err)
+	assert.Equal(t, "yes\n", out)
+	assert.Equal(t, "list", got.Get("verb"))
+	assert.Equal(t, "MCPServer", got.Get("kind"))
+	assert.Equal(t, "all", got.Get("namespace"))
+	assert.False(t, got.Has("name"))
+
+	out, err = runAuthCmd(t, "can-i", "apply", "agent", "billing-bot", "--namespace", "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, "no - forbidden: \"alice\" cannot apply Agent team-a/billing-bot\n", out)
+	assert.Equal(t, "billing-bot", got.Get("name"))
+
+	_, err = runAuthCmd(t, "can-i", "get", "bogus")
+	require.Error(t, err)
+}
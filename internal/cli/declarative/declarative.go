@@ -9,6 +9,7 @@ import (
 	cliCommon "github.com/agentregistry-dev/agentregistry/internal/cli/common"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/scheme"
 	"github.com/agentregistry-dev/agentregistry/internal/client"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
 )
@@ -50,7 +51,7 @@ func init() {
 	scheme.Register(typedKind(
 		"agent", "agents", []string{"Agent"},
 		[]scheme.Column{
-			{Header: "NAME"}, {Header: "TAG"}, {Header: "MODE"}, {Header: "DESCRIPTION"},
+			{Header: "NAME"}, {Header: "TAG"}, {Header: "STATE"}, {Header: "MODE"}, {Header: "DESCRIPTION"},
 		},
 		v1alpha1.KindAgent,
 		func() *v1alpha1.Agent { return &v1alpha1.Agent{} },
@@ -59,7 +60,7 @@ func init() {
 
 	scheme.Register(typedKind(
 		"mcp", "mcps", []string{"MCPServer", "mcpserver", "mcp-server", "mcpservers"},
-		[]scheme.Column{{Header: "NAME"}, {Header: "TAG"}, {Header: "DESCRIPTION"}},
+		[]scheme.Column{{Header: "NAME"}, {Header: "TAG"}, {Header: "STATE"}, {Header: "TOOLS"}, {Header: "DESCRIPTION"}},
 		v1alpha1.KindMCPServer,
 		func() *v1alpha1.MCPServer { return &v1alpha1.MCPServer{} },
 		mcpRow,
@@ -68,7 +69,7 @@ func init() {
 	scheme.Register(typedKind(
 		"skill", "skills", []string{"Skill"},
 		[]scheme.Column{
-			{Header: "NAME"}, {Header: "TAG"}, {Header: "DESCRIPTION"},
+			{Header: "NAME"}, {Header: "TAG"}, {Header: "STATE"}, {Header: "DESCRIPTION"},
 		},
 		v1alpha1.KindSkill,
 		func() *v1alpha1.Skill { return &v1alpha1.Skill{} },
@@ -77,7 +78,7 @@ func init() {
 
 	scheme.Register(typedKind(
 		"prompt", "prompts", []string{"Prompt"},
-		[]scheme.Column{{Header: "NAME"}, {Header: "TAG"}, {Header: "DESCRIPTION"}},
+		[]scheme.Column{{Header: "NAME"}, {Header: "TAG"}, {Header: "STATE"}, {Header: "DESCRIPTION"}},
 		v1alpha1.KindPrompt,
 		func() *v1alpha1.Prompt { return &v1alpha1.Prompt{} },
 		promptRow,
@@ -85,7 +86,7 @@ func init() {
 
 	scheme.Register(typedKind(
 		"plugin", "plugins", []string{"Plugin"},
-		[]scheme.Column{{Header: "NAME"}, {Header: "TAG"}, {Header: "DESCRIPTION"}},
+		[]scheme.Column{{Header: "NAME"}, {Header: "TAG"}, {Header: "STATE"}, {Header: "DESCRIPTION"}},
 		v1alpha1.KindPlugin,
 		func() *v1alpha1.Plugin { return &v1alpha1.Plugin{} },
 		pluginRow,
@@ -111,7 +112,7 @@ func init() {
 	scheme.Register(typedKind(
 		"model", "models", []string{"Model"},
 		[]scheme.Column{
-			{Header: "NAME"}, {Header: "TAG"}, {Header: "PROVIDER"},
+			{Header: "NAME"}, {Header: "TAG"}, {Header: "STATE"}, {Header: "PROVIDER"},
 			{Header: "MODEL"}, {Header: "AUTH"},
 		},
 		v1alpha1.KindModel,
@@ -119,6 +120,48 @@ func init() {
 		modelRow,
 	))
 
+	// Secret is a mutable namespace/name object like Runtime. The server
+	// redacts values on every read, so the table only lists key names.
+	scheme.Register(
+		mutableTypedKind(
+			"secret", "secrets", []string{"Secret"},
+			[]scheme.Column{{Header: "NAME"}, {Header: "KEYS"}},
+			v1alpha1.KindSecret,
+			func() *v1alpha1.Secret { return &v1alpha1.Secret{} },
+			secretRow,
+		),
+	)
+
+	// Role, ClusterRole and RoleBinding are mutable namespace/name objects
+	// evaluated by the server's RBAC authz mode.
+	scheme.Register(
+		mutableTypedKind(
+			"role", "roles", []string{"Role"},
+			[]scheme.Column{{Header: "NAME"}, {Header: "RULES"}, {Header: "DESCRIPTION"}},
+			v1alpha1.KindRole,
+			func() *v1alpha1.Role { return &v1alpha1.Role{} },
+			roleRow,
+		),
+	)
+	scheme.Register(
+		mutableTypedKind(
+			"clusterrole", "clusterroles", []string{"ClusterRole"},
+			[]scheme.Column{{Header: "NAME"}, {Header: "RULES"}, {Header: "DESCRIPTION"}},
+			v1alpha1.KindClusterRole,
+			func() *v1alpha1.ClusterRole { return &v1alpha1.ClusterRole{} },
+			clusterRoleRow,
+		),
+	)
+	scheme.Register(
+		mutableTypedKind(
+			"rolebinding", "rolebindings", []string{"RoleBinding"},
+			[]scheme.Column{{Header: "NAME"}, {Header: "ROLE"}, {Header: "SUBJECTS"}},
+			v1alpha1.KindRoleBinding,
+			func() *v1alpha1.RoleBinding { return &v1alpha1.RoleBinding{} },
+			roleBindingRow,
+		),
+	)
+
 	// Deployment is registered manually because it is a mutable namespace/name
 	// object: the server's deployment store does not expose /tags or
 	// DeleteAllTags endpoints. Explicit get/delete accept either NAME or
@@ -137,6 +180,7 @@ func init() {
 				return deploymentRow(cliCommon.DeploymentRecordFromObject(deployment))
 			},
 			withMutableListFunc(listDeploymentResources),
+			withMutableWatchFunc(watchDeploymentResources),
 		),
 	)
 }
@@ -179,14 +223,52 @@ func typedKind[T v1alpha1.Object](
 		ListFunc: func(ctx context.Context, c *client.Client, opts scheme.ListOpts) ([]any, error) {
 			return listAny(ctx, c, canonicalKind, opts, newObj)
 		},
-		Delete: func(ctx context.Context, c *client.Client, name, tag string) error {
-			return deleteAny(ctx, c, canonicalKind, name, tag, newObj)
+		Watch: func(ctx context.Context, c *client.Client, opts scheme.ListOpts, handle func(string, any) error) error {
+			return watchAny(ctx, c, canonicalKind, opts, newObj, handle)
+		},
+		Delete: func(ctx context.Context, c *client.Client, name, tag string, opts client.DeleteOpts) error {
+			return deleteAny(ctx, c, canonicalKind, name, tag, opts, newObj)
 		},
 		ListTags: func(ctx context.Context, c *client.Client, name string) ([]any, error) {
 			return listTagsAny(ctx, c, canonicalKind, name, newObj)
 		},
-		DeleteAllTags: func(ctx context.Context, c *client.Client, name string) error {
-			return deleteAllTagsAny(ctx, c, canonicalKind, name, newObj)
+		DeleteAllTags: func(ctx context.Context, c *client.Client, name string, opts client.DeleteOpts) error {
+			return deleteAllTagsAny(ctx, c, canonicalKind, name, opts, newObj)
+		},
+		PromoteTag: func(ctx context.Context, c *client.Client, name, from, to string) (arv0.PromoteTagResponse, error) {
+			ref, err := parseResourceLookupRef(name)
+			if err != nil {
+				return arv0.PromoteTagResponse{}, err
+			}
+			return c.PromoteTag(ctx, canonicalKind, ref.Namespace, ref.Name, from, to)
+		},
+		ListTagRevisions: func(ctx context.Context, c *client.Client, name, tag string) ([]arv0.TagRevision, error) {
+			ref, err := parseResourceLookupRef(name)
+			if err != nil {
+				return nil, err
+			}
+			return c.ListTagRevisions(ctx, canonicalKind, ref.Namespace, ref.Name, tag)
+		},
+		RollbackTag: func(ctx context.Context, c *client.Client, name, tag string, generation int64) (arv0.RollbackTagResponse, error) {
+			ref, err := parseResourceLookupRef(name)
+			if err != nil {
+				return arv0.RollbackTagResponse{}, err
+			}
+			return c.RollbackTag(ctx, canonicalKind, ref.Namespace, ref.Name, tag, generation)
+		},
+		SetTagLifecycle: func(ctx context.Context, c *client.Client, name, tag string, lifecycle arv0.SetTagLifecycleRequest) (arv0.TagLifecycleResponse, error) {
+			ref, err := parseResourceLookupRef(name)
+			if err != nil {
+				return arv0.TagLifecycleResponse{}, err
+			}
+			return c.SetTagLifecycle(ctx, canonicalKind, ref.Namespace, ref.Name, tag, lifecycle)
+		},
+		ListReferrers: func(ctx context.Context, c *client.Client, name, tag string) (arv0.ReferrerListResponse, error) {
+			ref, err := parseResourceLookupRef(name)
+			if err != nil {
+				return arv0.ReferrerListResponse{}, err
+			}
+			return c.ListReferrers(ctx, canonicalKind, ref.Namespace, ref.Name, tag)
 		},
 	}
 }
@@ -204,6 +286,14 @@ func withMutableListFunc(fn scheme.ListFunc) mutableTypedKindOption {
 	}
 }
 
+// withMutableWatchFunc overrides the default Watch for a mutableTypedKind,
+// alongside a withMutableListFunc override.
+func withMutableWatchFunc(fn scheme.WatchFunc) mutableTypedKindOption {
+	return func(k *scheme.Kind) {
+		k.Watch = fn
+	}
+}
+
 // mutableTypedKind builds a scheme.Kind for mutable namespace/name resources which
 // do not support tagging.
 func mutableTypedKind[T v1alpha1.Object](
@@ -238,8 +328,18 @@ func mutableTypedKind[T v1alpha1.Object](
 		ListFunc: func(ctx context.Context, c *client.Client, opts scheme.ListOpts) ([]any, error) {
 			return listAny(ctx, c, canonicalKind, opts, newObj)
 		},
-		Delete: func(ctx context.Context, c *client.Client, name, tag string) error {
-			return deleteAny(ctx, c, canonicalKind, name, tag, newObj)
+		Watch: func(ctx context.Context, c *client.Client, opts scheme.ListOpts, handle func(string, any) error) error {
+			return watchAny(ctx, c, canonicalKind, opts, newObj, handle)
+		},
+		Delete: func(ctx context.Context, c *client.Client, name, tag string, opts client.DeleteOpts) error {
+			return deleteAny(ctx, c, canonicalKind, name, tag, opts, newObj)
+		},
+		ListReferrers: func(ctx context.Context, c *client.Client, name, _ string) (arv0.ReferrerListResponse, error) {
+			ref, err := parseResourceLookupRef(name)
+			if err != nil {
+				return arv0.ReferrerListResponse{}, err
+			}
+			return c.ListReferrers(ctx, canonicalKind, ref.Namespace, ref.Name, "")
 		},
 	}
 	for _, opt := range opts {
@@ -27,13 +27,19 @@ Explicit mode: specify type and name. For taggable artifacts, --tag selects an
 exact tag and defaults to latest.
   arctl delete TYPE NAME [--tag TAG]
 
+The registry refuses to delete a resource that other objects still reference
+(an Agent using an MCP server, a Deployment running an Agent) and lists them.
+Pass --force to delete it anyway, leaving those references dangling, or
+--cascade to delete the referencing objects first. "arctl graph" shows them
+ahead of time.
+
 TYPE must be one of: agent, mcp, skill, prompt, deployment
 (plural and uppercase forms also accepted)`,
 		Example: `  arctl delete -f my-agent/agent.yaml
   arctl delete -f my-server/mcp.yaml
   arctl delete agent acme-summarizer --tag stable
   arctl delete agent acme-summarizer --all-tags
-  arctl delete mcp acme-fetch --tag stable
+  arctl delete mcp acme-fetch --tag stable --cascade
   arctl delete deployment team-a/my-agent`,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -43,6 +49,9 @@ TYPE must be one of: agent, mcp, skill, prompt, deployment
 	cmd.Flags().StringP("filename", "f", "", "YAML file to read resources from")
 	cmd.Flags().String("tag", "", "Specific tag to delete (taggable artifact kinds only; defaults to latest)")
 	cmd.Flags().Bool("all-tags", false, "Delete every tag of NAME (taggable artifact kinds only)")
+	cmd.Flags().Bool("force", false, "Delete even if other resources still reference it")
+	cmd.Flags().Bool("cascade", false, "Also delete every resource that references it, dependents first")
+	cmd.MarkFlagsMutuallyExclusive("force", "cascade")
 	return cmd
 }
 
@@ -51,6 +60,9 @@ func runDeclarativeDelete(cmd *cobra.Command, deps cliruntime.Deps, args []strin
 	filename, _ := cmd.Flags().GetString("filename")
 	allTags, _ := cmd.Flags().GetBool("all-tags")
 	tag, _ := cmd.Flags().GetString("tag")
+	force, _ := cmd.Flags().GetBool("force")
+	cascade, _ := cmd.Flags().GetBool("cascade")
+	opts := client.DeleteOpts{Force: force, Cascade: cascade}
 	allTagsFlag := "--all-tags"
 	tagFlag := "--tag"
 
@@ -66,7 +78,7 @@ func runDeclarativeDelete(cmd *cobra.Command, deps cliruntime.Deps, args []strin
 		if allTags {
 			return fmt.Errorf("%s cannot be used with -f", allTagsFlag)
 		}
-		return deleteFromFile(cmd, c, filename)
+		return deleteFromFile(cmd, c, filename, opts)
 	}
 
 	// Explicit mode: TYPE NAME [--tag TAG | --all-tags]
@@ -77,22 +89,22 @@ func runDeclarativeDelete(cmd *cobra.Command, deps cliruntime.Deps, args []strin
 		if tag != "" {
 			return fmt.Errorf("%s and %s are mutually exclusive", tagFlag, allTagsFlag)
 		}
-		return deleteAllTagsResource(cmd, kinds, c, args[0], args[1])
+		return deleteAllTagsResource(cmd, kinds, c, args[0], args[1], opts)
 	}
 
-	return deleteResource(cmd, kinds, c, args[0], args[1], tag)
+	return deleteResource(cmd, kinds, c, args[0], args[1], tag, opts)
 }
 
 // deleteAllTagsResource removes every live tag of (kind, name).
 // Errors cleanly when the kind is not a taggable artifact.
-func deleteAllTagsResource(cmd *cobra.Command, kinds *scheme.Registry, c *client.Client, typeName, name string) error {
+func deleteAllTagsResource(cmd *cobra.Command, kinds *scheme.Registry, c *client.Client, typeName, name string, opts client.DeleteOpts) error {
 	k, err := kinds.Lookup(typeName)
 	if err != nil {
 		return err
 	}
 
 	fmt.Fprintf(cmd.OutOrStdout(), "Deleting all tags of %s %s...\n", k.Kind, name)
-	if err := deleteAllTags(cmd.Context(), c, k, name); err != nil {
+	if err := deleteAllTags(cmd.Context(), c, k, name, opts); err != nil {
 		return fmt.Errorf("failed to delete all tags of %s %q: %w", k.Kind, name, err)
 	}
 	fmt.Fprintf(cmd.OutOrStdout(), "Deleted: %s/%s (all tags)\n", strings.ToLower(k.Kind), name)
@@ -101,7 +113,7 @@ func deleteAllTagsResource(cmd *cobra.Command, kinds *scheme.Registry, c *client
 
 // deleteFromFile reads a YAML file and sends a single DELETE /v0/apply request.
 // Per-resource results are printed; non-zero exit if any failed.
-func deleteFromFile(cmd *cobra.Command, c *client.Client, filename string) error {
+func deleteFromFile(cmd *cobra.Command, c *client.Client, filename string, opts client.DeleteOpts) error {
 	var data []byte
 	var err error
 	if filename == "-" {
@@ -121,7 +133,7 @@ func deleteFromFile(cmd *cobra.Command, c *client.Client, filename string) error
 		return fmt.Errorf("parsing %s: %w", filename, err)
 	}
 
-	results, err := c.DeleteViaApply(cmd.Context(), data)
+	results, err := c.DeleteViaApply(cmd.Context(), data, opts)
 	if err != nil {
 		return fmt.Errorf("DELETE /v0/apply: %w", err)
 	}
@@ -137,7 +149,7 @@ func deleteFromFile(cmd *cobra.Command, c *client.Client, filename string) error
 }
 
 // deleteResource performs an explicit per-kind delete using the registry to resolve the kind.
-func deleteResource(cmd *cobra.Command, kinds *scheme.Registry, c *client.Client, typeName, name, tag string) error {
+func deleteResource(cmd *cobra.Command, kinds *scheme.Registry, c *client.Client, typeName, name, tag string, opts client.DeleteOpts) error {
 	k, err := kinds.Lookup(typeName)
 	if err != nil {
 		return err
@@ -155,7 +167,7 @@ func deleteResource(cmd *cobra.Command, kinds *scheme.Registry, c *client.Client
 	} else {
 		fmt.Fprintf(cmd.OutOrStdout(), "Deleting %s %s...\n", k.Kind, name)
 	}
-	if err := deleteItem(cmd.Context(), c, k, name, tag); err != nil {
+	if err := deleteItem(cmd.Context(), c, k, name, tag, opts); err != nil {
 		if tag != "" {
 			return fmt.Errorf("failed to delete %s %q tag %s: %w", k.Kind, name, tag, err)
 		}
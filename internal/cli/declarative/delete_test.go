@@ -139,3 +139,30 @@ func TestDeleteExplicitModeRequiresTwoArgs(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "TYPE and NAME")
 }
+
+// TestDeleteFileModeForwardsCascade verifies that --cascade reaches DELETE /v0/apply.
+func TestDeleteFileModeForwardsCascade(t *testing.T) {
+	results := []arv0.ApplyResult{
+		{Kind: "agent", Name: "acme-bot", Tag: "1.0.0", Status: arv0.ApplyStatusDeleted},
+	}
+	srv, captured := newDeleteTestServer(t, results)
+	setupDeleteClient(t, srv)
+
+	cmd := declarative.NewDeleteCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"-f", writeTempYAML(t, agentYAML), "--cascade"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "cascade=true", captured.URL.RawQuery)
+}
+
+// TestDeleteForceAndCascadeAreExclusive verifies the flags cannot be combined.
+func TestDeleteForceAndCascadeAreExclusive(t *testing.T) {
+	cmd := declarative.NewDeleteCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"agent", "acme-bot", "--force", "--cascade"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "none of the others can be")
+}
@@ -183,3 +183,18 @@ func TestDeploymentDelete_OmitsLegacyForceQueryParam(t *testing.T) {
 	require.Len(t, *capturedQuery, 1)
 	assert.Empty(t, (*capturedQuery)[0], "no query params should be sent")
 }
+
+// (6) --force is appended after the namespace selector.
+func TestDeploymentDelete_ForwardsForceWithNamespace(t *testing.T) {
+	deployment := deploymentFixture("aws-v1", "summarizer", "1.0.0", "my-aws", "agent", "deployed")
+	deployment.Metadata.Namespace = "team-a"
+	srv, _, capturedQuery := deploymentTestServer(t, []v1alpha1.Deployment{deployment}, nil)
+	setupClientForServer(t, srv)
+
+	cmd := declarative.NewDeleteCmd(declarativeTestDeps(nil))
+	cmd.SetArgs([]string{"deployment", "team-a/aws-v1", "--force"})
+	require.NoError(t, cmd.Execute())
+
+	require.Len(t, *capturedQuery, 1)
+	assert.Equal(t, "namespace=team-a&force=true", (*capturedQuery)[0])
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/agentregistry-dev/agentregistry/internal/cli/scheme"
 	"github.com/agentregistry-dev/agentregistry/internal/client"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 )
 
@@ -71,8 +72,18 @@ func NewExtensionKind(k ExtensionKind) *scheme.Kind {
 		ListFunc: func(ctx context.Context, c *client.Client, opts scheme.ListOpts) ([]any, error) {
 			return listAny(ctx, c, k.CanonicalKind, opts, k.NewObject)
 		},
-		Delete: func(ctx context.Context, c *client.Client, name, tag string) error {
-			return deleteAny(ctx, c, k.CanonicalKind, name, tag, k.NewObject)
+		Watch: func(ctx context.Context, c *client.Client, opts scheme.ListOpts, handle func(string, any) error) error {
+			return watchAny(ctx, c, k.CanonicalKind, opts, k.NewObject, handle)
+		},
+		Delete: func(ctx context.Context, c *client.Client, name, tag string, opts client.DeleteOpts) error {
+			return deleteAny(ctx, c, k.CanonicalKind, name, tag, opts, k.NewObject)
+		},
+		ListReferrers: func(ctx context.Context, c *client.Client, name, _ string) (arv0.ReferrerListResponse, error) {
+			ref, err := parseResourceLookupRef(name)
+			if err != nil {
+				return arv0.ReferrerListResponse{}, err
+			}
+			return c.ListReferrers(ctx, k.CanonicalKind, ref.Namespace, ref.Name, "")
 		},
 	}
 }
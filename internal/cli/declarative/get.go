@@ -1,6 +1,7 @@
 package declarative
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/agentregistry-dev/agentregistry/internal/cli/scheme"
 	"github.com/agentregistry-dev/agentregistry/internal/client"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
 	"github.com/agentregistry-dev/agentregistry/pkg/printer"
@@ -39,7 +41,10 @@ Examples:
   arctl get deployment team-a/acme-summarizer
   arctl get deployments --origin discovered  # list discovered (unmanaged) deployments
   arctl get deployments --origin all         # list managed and discovered
-  arctl get skills -o json`,
+  arctl get agents -l 'team in (a,b),!experimental'
+  arctl get deployments --field-selector 'spec.runtimeRef.name=prod,status.conditions[Ready]=False'
+  arctl get skills -o json
+  arctl get deployments -w               # keep streaming changes`,
 		Args:         cobra.RangeArgs(1, 2),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -51,6 +56,9 @@ Examples:
 	cmd.Flags().Bool("latest", false, "List mode only: restrict to rows pinned to the literal 'latest' tag (equivalent to --tag latest).")
 	cmd.Flags().Bool("all-tags", false, "List every tag of NAME (tagged content kinds only)")
 	cmd.Flags().String("origin", "", "Deployments only: filter by provenance — managed, discovered, or all (defaults to managed when unset).")
+	cmd.Flags().StringP("selector", "l", "", "List mode only: label selector (key=value, key!=value, key in (a,b), key notin (a,b), key, !key; comma-separated terms must all match).")
+	cmd.Flags().BoolP("watch", "w", false, "List mode only: after listing, keep printing changes (ADDED, MODIFIED, DELETED) until interrupted.")
+	cmd.Flags().String("field-selector", "", "List mode only: field selector (path=value or path!=value over metadata.name, metadata.namespace, metadata.tag, status.conditions[Type], spec.* and status.* paths).")
 	return cmd
 }
 
@@ -61,6 +69,9 @@ func runGet(cmd *cobra.Command, deps cliruntime.Deps, args []string) error {
 	latest, _ := cmd.Flags().GetBool("latest")
 	tag, _ := cmd.Flags().GetString("tag")
 	origin, _ := cmd.Flags().GetString("origin")
+	labelSelector, _ := cmd.Flags().GetString("selector")
+	fieldSelector, _ := cmd.Flags().GetString("field-selector")
+	watch, _ := cmd.Flags().GetBool("watch")
 	allTagsFlag := "--all-tags"
 	tagFlag := "--tag"
 	latestFlag := "--latest"
@@ -81,13 +92,18 @@ func runGet(cmd *cobra.Command, deps cliruntime.Deps, args []string) error {
 	}
 
 	if args[0] == "all" {
+		if watch {
+			return fmt.Errorf("--watch cannot be used with `get all`")
+		}
 		if origin != "" {
 			return fmt.Errorf("--origin cannot be used with `get all`")
 		}
 		return runGetAllArg(cmd, deps, kinds, outputFormat, getFlags{
-			allTags: allTags,
-			latest:  latest,
-			tag:     tag,
+			allTags:       allTags,
+			latest:        latest,
+			tag:           tag,
+			labelSelector: labelSelector,
+			fieldSelector: fieldSelector,
 		})
 	}
 
@@ -98,6 +114,14 @@ func runGet(cmd *cobra.Command, deps cliruntime.Deps, args []string) error {
 		return err
 	}
 
+	if (labelSelector != "" || fieldSelector != "") && (allTags || len(args) == 2) {
+		return fmt.Errorf("--selector and --field-selector are list filters and cannot be combined with a resource NAME")
+	}
+
+	if watch && (allTags || len(args) == 2) {
+		return fmt.Errorf("--watch watches a list and cannot be combined with a resource NAME or --all-tags")
+	}
+
 	if allTags {
 		return runGetAllTags(cmd, deps, k, args, outputFormat)
 	}
@@ -141,7 +165,16 @@ func runGet(cmd *cobra.Command, deps cliruntime.Deps, args []string) error {
 		return printItem(cmd, k, item, outputFormat)
 	}
 
-	listOpts := scheme.ListOpts{Tag: tag, LatestOnly: latest, Origin: originOpt}
+	listOpts := scheme.ListOpts{
+		Tag:           tag,
+		LatestOnly:    latest,
+		Origin:        originOpt,
+		LabelSelector: labelSelector,
+		FieldSelector: fieldSelector,
+	}
+	if watch {
+		return runGetWatch(cmd, c, k, listOpts, outputFormat)
+	}
 	items, err := listItems(cmd.Context(), c, k, listOpts)
 	if err != nil {
 		return fmt.Errorf("listing %s: %w", kindPlural(k), err)
@@ -154,9 +187,11 @@ func runGet(cmd *cobra.Command, deps cliruntime.Deps, args []string) error {
 }
 
 type getFlags struct {
-	allTags bool
-	latest  bool
-	tag     string
+	allTags       bool
+	latest        bool
+	tag           string
+	labelSelector string
+	fieldSelector string
 }
 
 // resolveOrigin validates the CLI --origin selector and normalizes it into
@@ -192,7 +227,10 @@ func runGetAllArg(cmd *cobra.Command, deps cliruntime.Deps, kinds *scheme.Regist
 	if err != nil {
 		return err
 	}
-	return runGetAll(cmd, kinds, c, outputFormat)
+	return runGetAll(cmd, kinds, c, outputFormat, scheme.ListOpts{
+		LabelSelector: flags.labelSelector,
+		FieldSelector: flags.fieldSelector,
+	})
 }
 
 func runGetAllTags(cmd *cobra.Command, deps cliruntime.Deps, k *scheme.Kind, args []string, outputFormat string) error {
@@ -229,11 +267,11 @@ func registryClient(cmd *cobra.Command, deps cliruntime.Deps) (*client.Client, e
 	return c, nil
 }
 
-func runGetAll(cmd *cobra.Command, kinds *scheme.Registry, c *client.Client, outputFormat string) error {
+func runGetAll(cmd *cobra.Command, kinds *scheme.Registry, c *client.Client, outputFormat string, base scheme.ListOpts) error {
 	allKinds := kinds.All()
 	first := true
 	for _, k := range allKinds {
-		opts := scheme.ListOpts{}
+		opts := base
 		if strings.EqualFold(k.Kind, v1alpha1.KindDeployment) {
 			opts.Origin = v1alpha1.DeploymentOriginManaged
 		}
@@ -263,6 +301,78 @@ func runGetAll(cmd *cobra.Command, kinds *scheme.Registry, c *client.Client, out
 	return nil
 }
 
+// runGetWatch prints the list, then each change to it as it streams in.
+// Tables gain a leading EVENT column; yaml and json print one
+// {type, object} document per event.
+func runGetWatch(cmd *cobra.Command, c *client.Client, k *scheme.Kind, opts scheme.ListOpts, outputFormat string) error {
+	if k.Watch == nil {
+		return fmt.Errorf("--watch not supported for kind %q", k.Kind)
+	}
+	var initial []watchedItem
+	listed := false
+	err := k.Watch(cmd.Context(), c, opts, func(eventType string, item any) error {
+		if eventType == arv0.WatchEventBookmark {
+			if listed {
+				return nil
+			}
+			listed = true
+			return printWatchedItems(cmd, k, initial, outputFormat, true)
+		}
+		if !listed {
+			initial = append(initial, watchedItem{eventType: eventType, item: item})
+			return nil
+		}
+		return printWatchedItems(cmd, k, []watchedItem{{eventType: eventType, item: item}}, outputFormat, false)
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("watching %s: %w", kindPlural(k), err)
+	}
+	return nil
+}
+
+type watchedItem struct {
+	eventType string
+	item      any
+}
+
+// printWatchedItems renders one batch of watch events. headers prints the
+// table header, for the initial listing.
+func printWatchedItems(cmd *cobra.Command, k *scheme.Kind, events []watchedItem, outputFormat string, headers bool) error {
+	switch outputFormat {
+	case "yaml", "json":
+		for _, e := range events {
+			doc := map[string]any{"type": e.eventType, "object": toYAMLValue(k, e.item)}
+			if outputFormat == "json" {
+				b, err := json.Marshal(doc)
+				if err != nil {
+					return fmt.Errorf("encoding JSON: %w", err)
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(b))
+				continue
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "---")
+			if err := marshalYAML(cmd, doc); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		var opts []printer.Option
+		if !headers {
+			opts = append(opts, printer.WithNoHeaders())
+		}
+		t := printer.NewTablePrinter(cmd.OutOrStdout(), opts...)
+		t.SetHeaders(append([]string{"Event"}, tableColumns(k)...)...)
+		for _, e := range events {
+			t.AddRow(stringsToAny(append([]string{e.eventType}, tableRow(k, e.item)...))...)
+		}
+		return t.Render()
+	}
+}
+
 // printItem renders a single item.
 func printItem(cmd *cobra.Command, k *scheme.Kind, item any, outputFormat string) error {
 	switch outputFormat {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"sync"
 	"testing"
@@ -50,7 +51,7 @@ func TestGetCmd_RegistryDrivenColumnLookup(t *testing.T) {
 	k, err := scheme.Lookup("agents")
 	require.NoError(t, err, "agents alias should resolve via declarative's init() registration")
 	assert.Equal(t, []scheme.Column{
-		{Header: "NAME"}, {Header: "TAG"}, {Header: "MODE"}, {Header: "DESCRIPTION"},
+		{Header: "NAME"}, {Header: "TAG"}, {Header: "STATE"}, {Header: "MODE"}, {Header: "DESCRIPTION"},
 	}, k.TableColumns)
 
 	// Looking up a valid kind should get past kind validation and fail
@@ -75,6 +76,14 @@ func TestProvider_NoAllTagsSupport(t *testing.T) {
 	require.Nil(t, k.DeleteAllTags, "Runtime should not expose DeleteAllTags (mutable object kind)")
 }
 
+func TestSecret_NoAllTagsSupport(t *testing.T) {
+	k, err := scheme.Lookup("secrets")
+	require.NoError(t, err)
+	require.Equal(t, "secret", k.Kind)
+	require.Nil(t, k.ListTags, "Secret should not expose ListTags (mutable object kind)")
+	require.Nil(t, k.DeleteAllTags, "Secret should not expose DeleteAllTags (mutable object kind)")
+}
+
 func TestModel_AllTagsSupport(t *testing.T) {
 	k, err := scheme.Lookup("model")
 	require.NoError(t, err)
@@ -397,3 +406,101 @@ func TestGet_Latest_RejectsGetAll(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "--latest cannot be used with `get all`")
 }
+
+// TestGet_Selectors_ListModeForwardsSelectors verifies -l and
+// --field-selector flow through as ?labels= and ?fieldSelector=.
+func TestGet_Selectors_ListModeForwardsSelectors(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		captured []url.Values
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		captured = append(captured, r.URL.Query())
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[]}`))
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	cmd := declarative.NewGetCmd(declarativeTestDeps(nil))
+	cmd.SetArgs([]string{"deployments", "-l", "app in (web,api),!legacy", "--field-selector", "status.conditions[Ready]=False"})
+	require.NoError(t, cmd.Execute())
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, captured, "expected at least one server call")
+	assert.Equal(t, "app in (web,api),!legacy", captured[0].Get("labels"))
+	assert.Equal(t, "status.conditions[Ready]=False", captured[0].Get("fieldSelector"))
+}
+
+// TestGet_Selectors_RejectNamedGet pins that selectors are list-only.
+func TestGet_Selectors_RejectNamedGet(t *testing.T) {
+	cmd := declarative.NewGetCmd(declarativeTestDeps(nil))
+	cmd.SetArgs([]string{"agent", "acme", "-l", "app=web"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be combined with a resource NAME")
+}
+
+// TestGet_Watch_PrintsListThenChanges pins that --watch prints the initial
+// listing as one table, then a row per change, and surfaces an expired
+// stream as an error.
+func TestGet_Watch_PrintsListThenChanges(t *testing.T) {
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range []string{
+			`{"type":"ADDED","resourceVersion":7,"object":{"apiVersion":"ar.dev/v1alpha1","kind":"Agent","metadata":{"namespace":"default","name":"acme-bot","tag":"1"},"spec":{"description":"first"}}}`,
+			`{"type":"BOOKMARK","resourceVersion":7}`,
+			`{"type":"MODIFIED","resourceVersion":8,"object":{"apiVersion":"ar.dev/v1alpha1","kind":"Agent","metadata":{"namespace":"default","name":"acme-bot","tag":"1"},"spec":{"description":"second"}}}`,
+			`{"type":"DELETED","resourceVersion":9,"object":{"apiVersion":"ar.dev/v1alpha1","kind":"Agent","metadata":{"namespace":"default","name":"acme-bot","tag":"1"}}}`,
+			`{"type":"ERROR","resourceVersion":9,"status":410,"message":"too old"}`,
+		} {
+			_, _ = w.Write([]byte(": heartbeat\n\nevent: x\ndata: " + event + "\n\n"))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	out := &bytes.Buffer{}
+	cmd := declarative.NewGetCmd(declarativeTestDeps(nil))
+	cmd.SetOut(out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"agents", "-w", "-l", "team=a"})
+	err := cmd.Execute()
+	require.ErrorIs(t, err, client.ErrWatchExpired)
+	assert.Equal(t, "true", got.Get("watch"))
+	assert.Equal(t, "team=a", got.Get("labels"))
+	assert.Empty(t, got.Get("limit"))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], "EVENT"), lines[0])
+	assert.Contains(t, lines[1], "ADDED")
+	assert.Contains(t, lines[1], "first")
+	assert.Contains(t, lines[2], "MODIFIED")
+	assert.Contains(t, lines[2], "second")
+	assert.Contains(t, lines[3], "DELETED")
+}
+
+// TestGet_Watch_RejectsNamedGet pins that --watch is list-only.
+func TestGet_Watch_RejectsNamedGet(t *testing.T) {
+	for _, args := range [][]string{{"agent", "acme", "-w"}, {"all", "-w"}} {
+		cmd := declarative.NewGetCmd(declarativeTestDeps(nil))
+		cmd.SetArgs(args)
+		require.Error(t, cmd.Execute(), args)
+	}
+}
+
+func TestRBACKinds_NoAllTagsSupport(t *testing.T) {
+	for alias, want := range map[string]string{"roles": "role", "clusterrole": "clusterrole", "RoleBinding": "rolebinding"} {
+		k, err := scheme.Lookup(alias)
+		require.NoError(t, err, alias)
+		require.Equal(t, want, k.Kind)
+		require.Nil(t, k.ListTags, "%s should not expose ListTags (mutable object kind)", want)
+		require.Nil(t, k.DeleteAllTags, "%s should not expose DeleteAllTags (mutable object kind)", want)
+	}
+}
@@ -0,0 +1,308 @@
+package declarative
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+)
+
+// NewGraphCmd returns the "graph" command, which renders the dependency
+// tree around one registry resource.
+func NewGraphCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cliruntime.CommandGraph + " TYPE NAME",
+		Short: "Show what a resource depends on and what depends on it",
+		Long: `Show what a resource depends on and what depends on it.
+
+The upstream tree follows the resource's own references (an Agent's MCP
+servers, skills, plugins and instructions; a Deployment's target, runtime,
+model and deployment refs) and theirs in turn. The downstream tree lists
+every object that references the resource, and what references those,
+so you can judge the blast radius of changing or deleting it. Semver
+ranges and blank tags are shown as the tag they currently resolve to.
+
+Use -o dot to emit a Graphviz digraph; edges point from the referencing
+object to the object it references.`,
+		Example: `  arctl graph mcp acme-fetch --tag 1.0.0
+  arctl graph agent team-a/acme-bot
+  arctl graph runtime local -o dot | dot -Tsvg > graph.svg`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat, _ := cmd.Flags().GetString("output")
+			if outputFormat != "text" && outputFormat != "dot" {
+				return fmt.Errorf("unsupported output format %q (expected text or dot)", outputFormat)
+			}
+			k, err := kindRegistry(deps).Lookup(args[0])
+			if err != nil {
+				return err
+			}
+			if k.ListReferrers == nil {
+				return fmt.Errorf("graph not supported for kind %q", k.Kind)
+			}
+			if deps.Runtime == nil {
+				return errRegistryRuntimeNotConfigured
+			}
+			c, err := deps.Runtime.RegistryClient(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("resolving registry client: %w", err)
+			}
+			tag, _ := cmd.Flags().GetString("tag")
+			if k.ListTags == nil {
+				tag = ""
+			}
+			// The referrers response names the canonical kind and the
+			// concrete tag, which anchor both walks.
+			resp, err := k.ListReferrers(cmd.Context(), c, args[1], tag)
+			if err != nil {
+				return fmt.Errorf("failed to load %s %q: %w", k.Kind, args[1], err)
+			}
+			root := v1alpha1.ResourceRef{Kind: resp.Kind, Namespace: resp.Namespace, Name: resp.Name, Tag: resp.Tag}
+			g, err := buildDependencyGraph(cmd.Context(), c, root)
+			if err != nil {
+				return err
+			}
+			if outputFormat == "dot" {
+				g.writeDOT(cmd.OutOrStdout())
+				return nil
+			}
+			g.writeText(cmd.OutOrStdout())
+			return nil
+		},
+	}
+	cmd.Flags().StringP("output", "o", "text", "Output format: text, dot")
+	cmd.Flags().String("tag", "latest", "Tag of the resource (taggable kinds only)")
+	return cmd
+}
+
+// graphNode is one object in a dependency tree. Path is the spec field
+// linking it to its parent: the parent's field for upstream nodes, the
+// node's own field for downstream ones.
+type graphNode struct {
+	Ref      v1alpha1.ResourceRef
+	Path     string
+	Missing  bool
+	Repeated bool
+	Children []*graphNode
+}
+
+// dependencyGraph is the upstream and downstream trees around Root.
+type dependencyGraph struct {
+	Root       v1alpha1.ResourceRef
+	Upstream   []*graphNode
+	Downstream []*graphNode
+}
+
+func buildDependencyGraph(ctx context.Context, c *client.Client, root v1alpha1.ResourceRef) (*dependencyGraph, error) {
+	g := &dependencyGraph{Root: root}
+	var err error
+	if g.Upstream, err = expandGraph(ctx, root, map[v1alpha1.ResourceRef]bool{root: true}, func(ctx context.Context, ref v1alpha1.ResourceRef) ([]*graphNode, error) {
+		return graphDependencies(ctx, c, ref)
+	}); err != nil {
+		return nil, err
+	}
+	if g.Downstream, err = expandGraph(ctx, root, map[v1alpha1.ResourceRef]bool{root: true}, func(ctx context.Context, ref v1alpha1.ResourceRef) ([]*graphNode, error) {
+		return graphReferrers(ctx, c, ref)
+	}); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// expandGraph walks children depth-first. A node already expanded
+// elsewhere in the same tree is kept but marked Repeated rather than
+// walked again, which also breaks reference cycles.
+func expandGraph(ctx context.Context, ref v1alpha1.ResourceRef, seen map[v1alpha1.ResourceRef]bool, children func(context.Context, v1alpha1.ResourceRef) ([]*graphNode, error)) ([]*graphNode, error) {
+	nodes, err := children(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		if n.Missing {
+			continue
+		}
+		if seen[n.Ref] {
+			n.Repeated = true
+			continue
+		}
+		seen[n.Ref] = true
+		if n.Children, err = expandGraph(ctx, n.Ref, seen, children); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// graphDependencies fetches ref and returns the objects its spec
+// references, resolved to concrete tags. Dangling refs come back Missing.
+func graphDependencies(ctx context.Context, c *client.Client, ref v1alpha1.ResourceRef) ([]*graphNode, error) {
+	obj, err := getGraphObject(ctx, c, ref)
+	if err != nil {
+		return nil, err
+	}
+	var nodes []*graphNode
+	for _, fr := range v1alpha1.ObjectRefs(ctx, obj) {
+		node := &graphNode{Ref: fr.Ref, Path: fr.Path}
+		dep, err := getGraphObject(ctx, c, fr.Ref)
+		switch {
+		case errors.Is(err, client.ErrNotFound):
+			node.Missing = true
+		case err != nil:
+			return nil, err
+		default:
+			node.Ref.Tag = dep.GetMetadata().Tag
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// graphReferrers returns the objects whose spec references ref.
+func graphReferrers(ctx context.Context, c *client.Client, ref v1alpha1.ResourceRef) ([]*graphNode, error) {
+	resp, err := c.ListReferrers(ctx, ref.Kind, ref.Namespace, ref.Name, ref.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("list referrers of %s: %w", graphLabel(ref), err)
+	}
+	nodes := make([]*graphNode, 0, len(resp.Items))
+	for _, r := range resp.Items {
+		nodes = append(nodes, &graphNode{
+			Ref:  v1alpha1.ResourceRef{Kind: r.Kind, Namespace: r.Namespace, Name: r.Name, Tag: r.Tag},
+			Path: r.Path,
+		})
+	}
+	return nodes, nil
+}
+
+// getGraphObject fetches ref as a typed envelope. A blank tag reads the
+// latest tag of taggable kinds and the live row of mutable ones.
+func getGraphObject(ctx context.Context, c *client.Client, ref v1alpha1.ResourceRef) (v1alpha1.Object, error) {
+	var (
+		raw *v1alpha1.RawObject
+		err error
+	)
+	if ref.Tag == "" {
+		raw, err = c.GetLatest(ctx, ref.Kind, ref.Namespace, ref.Name)
+	} else {
+		raw, err = c.Get(ctx, ref.Kind, ref.Namespace, ref.Name, ref.Tag)
+	}
+	if err != nil {
+		return nil, err
+	}
+	_, newObj, ok := v1alpha1.Default.Lookup(ref.Kind)
+	if !ok {
+		return nil, fmt.Errorf("unknown kind %q", ref.Kind)
+	}
+	obj, ok := newObj().(v1alpha1.Object)
+	if !ok {
+		return nil, fmt.Errorf("kind %q does not decode to a v1alpha1 object", ref.Kind)
+	}
+	obj.SetMetadata(raw.Metadata)
+	if err := obj.UnmarshalSpec(raw.Spec); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", graphLabel(ref), err)
+	}
+	return obj, nil
+}
+
+// graphLabel renders ref as "Kind namespace/name:tag", omitting the tag
+// for mutable kinds.
+func graphLabel(ref v1alpha1.ResourceRef) string {
+	label := ref.Kind + " " + ref.Namespace + "/" + ref.Name
+	if ref.Tag != "" {
+		label += ":" + ref.Tag
+	}
+	return label
+}
+
+func (g *dependencyGraph) writeText(w io.Writer) {
+	fmt.Fprintln(w, graphLabel(g.Root))
+	for _, section := range []struct {
+		title string
+		nodes []*graphNode
+	}{
+		{"Depends on:", g.Upstream},
+		{"Used by:", g.Downstream},
+	} {
+		fmt.Fprintln(w, section.title)
+		if len(section.nodes) == 0 {
+			fmt.Fprintln(w, "  (none)")
+			continue
+		}
+		writeTextNodes(w, section.nodes, "  ")
+	}
+}
+
+func writeTextNodes(w io.Writer, nodes []*graphNode, indent string) {
+	for i, n := range nodes {
+		branch, next := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, next = "└── ", "    "
+		}
+		line := graphLabel(n.Ref)
+		if n.Path != "" {
+			line += " (" + n.Path + ")"
+		}
+		switch {
+		case n.Missing:
+			line += " [missing]"
+		case n.Repeated:
+			line += " [see above]"
+		}
+		fmt.Fprintln(w, indent+branch+line)
+		writeTextNodes(w, n.Children, indent+next)
+	}
+}
+
+func (g *dependencyGraph) writeDOT(w io.Writer) {
+	fmt.Fprintln(w, "digraph dependencies {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintf(w, "  %s [style=bold];\n", dotQuote(graphLabel(g.Root)))
+	written := map[string]bool{}
+	edge := func(from, to v1alpha1.ResourceRef, path string, missing bool) {
+		line := fmt.Sprintf("  %s -> %s", dotQuote(graphLabel(from)), dotQuote(graphLabel(to)))
+		var attrs []string
+		if path != "" {
+			attrs = append(attrs, "label="+dotQuote(path))
+		}
+		if missing {
+			attrs = append(attrs, "style=dashed")
+		}
+		if len(attrs) > 0 {
+			line += " [" + strings.Join(attrs, ", ") + "]"
+		}
+		line += ";"
+		if !written[line] {
+			written[line] = true
+			fmt.Fprintln(w, line)
+		}
+	}
+	var up func(parent v1alpha1.ResourceRef, nodes []*graphNode)
+	up = func(parent v1alpha1.ResourceRef, nodes []*graphNode) {
+		for _, n := range nodes {
+			edge(parent, n.Ref, n.Path, n.Missing)
+			up(n.Ref, n.Children)
+		}
+	}
+	var down func(parent v1alpha1.ResourceRef, nodes []*graphNode)
+	down = func(parent v1alpha1.ResourceRef, nodes []*graphNode) {
+		for _, n := range nodes {
+			edge(n.Ref, parent, n.Path, false)
+			down(n.Ref, n.Children)
+		}
+	}
+	up(g.Root, g.Upstream)
+	down(g.Root, g.Downstream)
+	fmt.Fprintln(w, "}")
+}
+
+// dotQuote renders s as a DOT double-quoted ID.
+func dotQuote(s string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), `"`, `\"`) + `"`
+}
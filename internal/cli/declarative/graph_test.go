@@ -0,0 +1,86 @@
+package declarative_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+)
+
+// newGraphTestServer serves a small registry: Agent pinned:1.0.0 uses MCP
+// server tools through a semver range plus a skill that no longer exists,
+// and Deployment bot runs the agent.
+func newGraphTestServer(t *testing.T) {
+	t.Helper()
+	responses := map[string]string{
+		"/v0/agents/pinned/1.0.0/referrers": `{"kind":"Agent","namespace":"default","name":"pinned","tag":"1.0.0",
+			"items":[{"kind":"Deployment","namespace":"default","name":"bot","path":"spec.targetRef"}]}`,
+		"/v0/agents/pinned/1.0.0": `{"apiVersion":"ar.dev/v1alpha1","kind":"Agent",
+			"metadata":{"namespace":"default","name":"pinned","tag":"1.0.0"},
+			"spec":{"mcpServers":[{"kind":"MCPServer","name":"tools","tag":"^1.0"}],"skills":[{"kind":"Skill","name":"gone"}]}}`,
+		"/v0/mcpservers/tools/%5E1.0": `{"apiVersion":"ar.dev/v1alpha1","kind":"MCPServer",
+			"metadata":{"namespace":"default","name":"tools","tag":"1.1.0"},"spec":{}}`,
+		"/v0/mcpservers/tools/1.1.0": `{"apiVersion":"ar.dev/v1alpha1","kind":"MCPServer",
+			"metadata":{"namespace":"default","name":"tools","tag":"1.1.0"},"spec":{}}`,
+		"/v0/deployments/bot/referrers": `{"kind":"Deployment","namespace":"default","name":"bot","items":[]}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.EscapedPath()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+}
+
+func runGraphCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	out := &bytes.Buffer{}
+	cmd := declarative.NewGraphCmd(declarativeTestDeps(nil))
+	cmd.SetOut(out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestGraph_Text(t *testing.T) {
+	newGraphTestServer(t)
+
+	out, err := runGraphCmd(t, "agent", "pinned", "--tag", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, `Agent default/pinned:1.0.0
+Depends on:
+  ├── MCPServer default/tools:1.1.0 (spec.mcpServers[0])
+  └── Skill default/gone (spec.skills[0]) [missing]
+Used by:
+  └── Deployment default/bot (spec.targetRef)
+`, out)
+}
+
+func TestGraph_DOT(t *testing.T) {
+	newGraphTestServer(t)
+
+	out, err := runGraphCmd(t, "agent", "pinned", "--tag", "1.0.0", "-o", "dot")
+	require.NoError(t, err)
+	assert.Equal(t, `digraph dependencies {
+  rankdir=LR;
+  "Agent default/pinned:1.0.0" [style=bold];
+  "Agent default/pinned:1.0.0" -> "MCPServer default/tools:1.1.0" [label="spec.mcpServers[0]"];
+  "Agent default/pinned:1.0.0" -> "Skill default/gone" [label="spec.skills[0]", style=dashed];
+  "Deployment default/bot" -> "Agent default/pinned:1.0.0" [label="spec.targetRef"];
+}
+`, out)
+
+	_, err = runGraphCmd(t, "agent", "pinned", "-o", "yaml")
+	require.ErrorContains(t, err, "unsupported output format")
+}
@@ -0,0 +1,129 @@
+package declarative
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+)
+
+// NewHistoryCmd returns the "history" command, which lists the revisions
+// of one tag of a registry resource.
+func NewHistoryCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cliruntime.CommandHistory + " TYPE NAME",
+		Short: "List the revisions of a resource tag",
+		Long: `List the revisions of a resource tag, newest first.
+
+Re-applying a tag with different content keeps the content it replaced as
+a revision; the marked row is the current content. Restore an earlier
+revision with "arctl rollback".
+
+TYPE must be a taggable kind: agent, mcp, skill, prompt, plugin, model
+(plural and uppercase forms also accepted)`,
+		Example: `  arctl history agent acme-summarizer
+  arctl history mcp team-a/acme-fetch --tag 1.2.0 -o json`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k, err := kindRegistry(deps).Lookup(args[0])
+			if err != nil {
+				return err
+			}
+			if deps.Runtime == nil {
+				return errRegistryRuntimeNotConfigured
+			}
+			c, err := deps.Runtime.RegistryClient(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("resolving registry client: %w", err)
+			}
+			tag, _ := cmd.Flags().GetString("tag")
+			outputFormat, _ := cmd.Flags().GetString("output")
+			revisions, err := listTagRevisions(cmd.Context(), c, k, args[1], tag)
+			if err != nil {
+				return fmt.Errorf("failed to list revisions of %s %q tag %q: %w", k.Kind, args[1], tag, err)
+			}
+			return printRevisions(cmd, revisions, outputFormat)
+		},
+	}
+	cmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
+	cmd.Flags().String("tag", "latest", "Tag whose revisions to list")
+	return cmd
+}
+
+// NewRollbackCmd returns the "rollback" command, which restores an earlier
+// revision of one tag as its current content.
+func NewRollbackCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cliruntime.CommandRollback + " TYPE NAME GENERATION",
+		Short: "Restore an earlier revision of a resource tag",
+		Long: `Restore an earlier revision of a resource tag as its current content.
+
+GENERATION is a revision listed by "arctl history". The rollback is applied
+like any other change: the tag's generation bumps, the content it replaces
+is kept as a revision, and Deployments that reference the tag roll out.
+
+TYPE must be a taggable kind: agent, mcp, skill, prompt, plugin, model
+(plural and uppercase forms also accepted)`,
+		Example: `  arctl rollback agent acme-summarizer 3
+  arctl rollback mcp team-a/acme-fetch 1 --tag 1.2.0`,
+		Args:         cobra.ExactArgs(3),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			generation, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil || generation < 1 {
+				return fmt.Errorf("GENERATION must be a positive integer, got %q", args[2])
+			}
+			k, err := kindRegistry(deps).Lookup(args[0])
+			if err != nil {
+				return err
+			}
+			if deps.Runtime == nil {
+				return errRegistryRuntimeNotConfigured
+			}
+			c, err := deps.Runtime.RegistryClient(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("resolving registry client: %w", err)
+			}
+			tag, _ := cmd.Flags().GetString("tag")
+			res, err := rollbackTag(cmd.Context(), c, k, args[1], tag, generation)
+			if err != nil {
+				return fmt.Errorf("failed to roll back %s %q tag %q to generation %d: %w", k.Kind, args[1], tag, generation, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s/%s:%s rolled back to generation %d (now generation %d) %s\n",
+				strings.ToLower(k.Kind), res.Name, res.Tag, generation, res.Generation, res.Status)
+			return nil
+		},
+	}
+	cmd.Flags().String("tag", "latest", "Tag to roll back")
+	return cmd
+}
+
+func printRevisions(cmd *cobra.Command, revisions []arv0.TagRevision, outputFormat string) error {
+	switch outputFormat {
+	case "yaml":
+		return marshalYAML(cmd, revisions)
+	case "json":
+		return marshalJSON(cmd, revisions)
+	}
+	t := printer.NewTablePrinter(cmd.OutOrStdout())
+	t.SetHeaders("Generation", "Content Hash", "Applied", "Current")
+	for _, r := range revisions {
+		current := ""
+		if r.Current {
+			current = "*"
+		}
+		hash := r.ContentHash
+		if len(hash) > 12 {
+			hash = hash[:12]
+		}
+		t.AddRow(r.Generation, hash, r.AppliedAt.UTC().Format(time.RFC3339), current)
+	}
+	return t.Render()
+}
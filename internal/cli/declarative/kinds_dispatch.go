@@ -13,6 +13,7 @@ import (
 
 	"github.com/agentregistry-dev/agentregistry/internal/cli/scheme"
 	"github.com/agentregistry-dev/agentregistry/internal/client"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
 )
@@ -66,11 +67,11 @@ func getItem(ctx context.Context, c *client.Client, k *scheme.Kind, name, tag st
 }
 
 // deleteItem deletes a single item by (name, tag) for the given kind.
-func deleteItem(ctx context.Context, c *client.Client, k *scheme.Kind, name, tag string) error {
+func deleteItem(ctx context.Context, c *client.Client, k *scheme.Kind, name, tag string, opts client.DeleteOpts) error {
 	if k.Delete == nil {
 		return fmt.Errorf("delete not supported for kind %q", k.Kind)
 	}
-	return k.Delete(ctx, c, name, tag)
+	return k.Delete(ctx, c, name, tag, opts)
 }
 
 // listTags returns every live tag for (kind, name). Errors when the kind is not
@@ -84,11 +85,47 @@ func listTags(ctx context.Context, c *client.Client, k *scheme.Kind, name string
 
 // deleteAllTags soft-deletes every live tag for (kind, name). Errors when the
 // kind is not a taggable artifact.
-func deleteAllTags(ctx context.Context, c *client.Client, k *scheme.Kind, name string) error {
+func deleteAllTags(ctx context.Context, c *client.Client, k *scheme.Kind, name string, opts client.DeleteOpts) error {
 	if k.DeleteAllTags == nil {
 		return fmt.Errorf("--all-tags not supported for kind %q (resource is not taggable)", k.Kind)
 	}
-	return k.DeleteAllTags(ctx, c, name)
+	return k.DeleteAllTags(ctx, c, name, opts)
+}
+
+// promoteTag points alias to of (kind, name) at from. Errors when the kind is
+// not a taggable artifact.
+func promoteTag(ctx context.Context, c *client.Client, k *scheme.Kind, name, from, to string) (arv0.PromoteTagResponse, error) {
+	if k.PromoteTag == nil {
+		return arv0.PromoteTagResponse{}, fmt.Errorf("tag promotion not supported for kind %q (resource is not taggable)", k.Kind)
+	}
+	return k.PromoteTag(ctx, c, name, from, to)
+}
+
+// listTagRevisions returns the revisions of (kind, name, tag). Errors when
+// the kind is not a taggable artifact.
+func listTagRevisions(ctx context.Context, c *client.Client, k *scheme.Kind, name, tag string) ([]arv0.TagRevision, error) {
+	if k.ListTagRevisions == nil {
+		return nil, fmt.Errorf("history not supported for kind %q (resource is not taggable)", k.Kind)
+	}
+	return k.ListTagRevisions(ctx, c, name, tag)
+}
+
+// rollbackTag restores generation of (kind, name, tag). Errors when the
+// kind is not a taggable artifact.
+func rollbackTag(ctx context.Context, c *client.Client, k *scheme.Kind, name, tag string, generation int64) (arv0.RollbackTagResponse, error) {
+	if k.RollbackTag == nil {
+		return arv0.RollbackTagResponse{}, fmt.Errorf("rollback not supported for kind %q (resource is not taggable)", k.Kind)
+	}
+	return k.RollbackTag(ctx, c, name, tag, generation)
+}
+
+// setTagLifecycle sets the lifecycle of (kind, name, tag). Errors when the
+// kind is not a taggable artifact.
+func setTagLifecycle(ctx context.Context, c *client.Client, k *scheme.Kind, name, tag string, lifecycle arv0.SetTagLifecycleRequest) (arv0.TagLifecycleResponse, error) {
+	if k.SetTagLifecycle == nil {
+		return arv0.TagLifecycleResponse{}, fmt.Errorf("lifecycle not supported for kind %q (resource is not taggable)", k.Kind)
+	}
+	return k.SetTagLifecycle(ctx, c, name, tag, lifecycle)
 }
 
 // tableRow returns a []string row for the given item, matching the TableColumns
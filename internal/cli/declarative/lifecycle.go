@@ -0,0 +1,94 @@
+package declarative
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+)
+
+// NewDeprecateCmd returns the "deprecate" command, which marks one tag of a
+// registry resource as deprecated.
+func NewDeprecateCmd(deps cliruntime.Deps) *cobra.Command {
+	return newLifecycleCmd(deps, v1alpha1.LifecycleDeprecated, cliruntime.CommandDeprecate,
+		"Mark a resource tag as deprecated",
+		`Mark a resource tag as deprecated.
+
+A deprecated tag keeps resolving, but "arctl apply" warns about manifests
+that reference it, and Deployments running it report a TargetDeprecated
+condition. Pass --undo to reactivate the tag.
+
+TYPE must be a taggable kind: agent, mcp, skill, prompt, plugin, model
+(plural and uppercase forms also accepted)`,
+		`  arctl deprecate mcp acme-fetch --tag 1.0.0 --reason "superseded" --replacement 1.1.0
+  arctl deprecate agent team-a/acme-bot --tag 2.0.0 --undo`)
+}
+
+// NewYankCmd returns the "yank" command, which withdraws one tag of a
+// registry resource.
+func NewYankCmd(deps cliruntime.Deps) *cobra.Command {
+	return newLifecycleCmd(deps, v1alpha1.LifecycleYanked, cliruntime.CommandYank,
+		"Withdraw a resource tag",
+		`Withdraw a resource tag.
+
+A yanked tag stays readable and keeps running wherever it is already
+deployed, but new references to it are rejected at apply time and semver
+ranges no longer select it. Manifests that already referenced the tag can
+still be re-applied. Pass --undo to reactivate the tag.
+
+TYPE must be a taggable kind: agent, mcp, skill, prompt, plugin, model
+(plural and uppercase forms also accepted)`,
+		`  arctl yank mcp acme-fetch --tag 1.0.0 --reason "CVE-2026-1234" --replacement 1.0.1
+  arctl yank mcp acme-fetch --tag 1.0.0 --undo`)
+}
+
+func newLifecycleCmd(deps cliruntime.Deps, state v1alpha1.LifecycleState, use, short, long, example string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          use + " TYPE NAME",
+		Short:        short,
+		Long:         long,
+		Example:      example,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tag, _ := cmd.Flags().GetString("tag")
+			reason, _ := cmd.Flags().GetString("reason")
+			replacement, _ := cmd.Flags().GetString("replacement")
+			undo, _ := cmd.Flags().GetBool("undo")
+			req := arv0.SetTagLifecycleRequest{State: string(state), Reason: reason, Replacement: replacement}
+			if undo {
+				if reason != "" || replacement != "" {
+					return fmt.Errorf("--undo cannot be combined with --reason or --replacement")
+				}
+				req = arv0.SetTagLifecycleRequest{State: string(v1alpha1.LifecycleActive)}
+			}
+			k, err := kindRegistry(deps).Lookup(args[0])
+			if err != nil {
+				return err
+			}
+			if deps.Runtime == nil {
+				return errRegistryRuntimeNotConfigured
+			}
+			c, err := deps.Runtime.RegistryClient(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("resolving registry client: %w", err)
+			}
+			res, err := setTagLifecycle(cmd.Context(), c, k, args[1], tag, req)
+			if err != nil {
+				return fmt.Errorf("failed to %s %s %q tag %q: %w", use, k.Kind, args[1], tag, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s/%s:%s %s\n",
+				strings.ToLower(k.Kind), res.Name, res.Tag, strings.ToLower(res.State))
+			return nil
+		},
+	}
+	cmd.Flags().String("tag", "latest", "Tag to update")
+	cmd.Flags().String("reason", "", "Why the tag is "+strings.ToLower(string(state)))
+	cmd.Flags().String("replacement", "", "Tag consumers should move to")
+	cmd.Flags().Bool("undo", false, "Reactivate the tag")
+	return cmd
+}
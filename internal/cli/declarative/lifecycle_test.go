@@ -0,0 +1,73 @@
+package declarative_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+)
+
+func TestYank_PutsLifecycle(t *testing.T) {
+	var (
+		gotPath string
+		gotBody arv0.SetTagLifecycleRequest
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.Method + " " + r.URL.RequestURI()
+		gotBody = arv0.SetTagLifecycleRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(arv0.TagLifecycleResponse{
+			Namespace: "team-a", Name: "acme-fetch", Tag: "1.0.0",
+			State: gotBody.State, Reason: gotBody.Reason, Replacement: gotBody.Replacement,
+		})
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	out := &bytes.Buffer{}
+	cmd := declarative.NewYankCmd(declarativeTestDeps(nil))
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs([]string{"mcp", "team-a/acme-fetch", "--tag", "1.0.0", "--reason", "CVE-2026-1234", "--replacement", "1.0.1"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "PUT /v0/mcpservers/acme-fetch/1.0.0/lifecycle?namespace=team-a", gotPath)
+	assert.Equal(t, arv0.SetTagLifecycleRequest{State: "Yanked", Reason: "CVE-2026-1234", Replacement: "1.0.1"}, gotBody)
+	assert.Contains(t, out.String(), "mcp/acme-fetch:1.0.0 yanked")
+
+	out.Reset()
+	cmd = declarative.NewDeprecateCmd(declarativeTestDeps(nil))
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs([]string{"mcp", "team-a/acme-fetch", "--tag", "1.0.0", "--undo"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, arv0.SetTagLifecycleRequest{State: "Active"}, gotBody)
+	assert.Contains(t, out.String(), "mcp/acme-fetch:1.0.0 active")
+}
+
+func TestDeprecate_RejectsUndoWithReason(t *testing.T) {
+	setDeclarativeTestClient(t, client.NewClient("http://127.0.0.1:1", ""))
+
+	cmd := declarative.NewDeprecateCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"agent", "acme-bot", "--undo", "--reason", "oops"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--undo")
+
+	cmd = declarative.NewDeprecateCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"deployment", "acme-bot"})
+	require.ErrorContains(t, cmd.Execute(), "not taggable")
+}
@@ -0,0 +1,191 @@
+package declarative
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+)
+
+// NewNamespaceCmd returns the "namespace" command group, which claims
+// namespaces by proving control of a domain.
+func NewNamespaceCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cliruntime.CommandNamespace,
+		Short: "Claim namespaces with domain proofs",
+		Long: `Claim the namespace of a domain you control, e.g. com.acme for acme.com.
+
+Publish the public half of an Ed25519 key for the domain, in a DNS TXT
+record or at https://DOMAIN/.well-known/mcp-registry-auth, then prove you
+hold the private half with "arctl namespace claim". Once claimed, tagged
+artifacts applied in the namespace must be signed with that key
+("arctl sign --key").`,
+	}
+	cmd.AddCommand(newNamespaceRecordCmd(), newNamespaceClaimCmd(deps), newNamespaceListCmd(deps), newNamespaceReleaseCmd(deps))
+	return cmd
+}
+
+func newNamespaceRecordCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "record --key KEY",
+		Short: "Print the proof record to publish for a key",
+		Long: `Print the record that publishes KEY's public key, to serve as a DNS TXT
+record on the domain or as the body of
+https://DOMAIN/.well-known/mcp-registry-auth. KEY is a PEM Ed25519
+private key, such as one written by "openssl genpkey -algorithm ed25519".`,
+		Example:      `  arctl namespace record --key acme.key`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keyPath, _ := cmd.Flags().GetString("key")
+			key, err := loadProofKey(keyPath)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), auth.FormatDomainProofRecord(key.Public().(ed25519.PublicKey)))
+			return nil
+		},
+	}
+	cmd.Flags().String("key", "", "Path to the PEM Ed25519 private key")
+	_ = cmd.MarkFlagRequired("key")
+	return cmd
+}
+
+func newNamespaceClaimCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "claim DOMAIN --key KEY",
+		Short: "Claim the namespace of a domain",
+		Long: `Prove control of DOMAIN by signing the domain, its namespace and the
+current time with KEY, whose proof record the domain publishes, and claim
+the namespace. Claiming requires signing in to the registry. Claiming
+again with another published key replaces the namespace's key.`,
+		Example: `  arctl namespace claim acme.com --key acme.key
+  arctl namespace claim tools.acme.com --key tools.key --method http`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keyPath, _ := cmd.Flags().GetString("key")
+			method, _ := cmd.Flags().GetString("method")
+			if method != string(auth.MethodDNS) && method != string(auth.MethodHTTP) {
+				return fmt.Errorf("--method must be %s or %s, got %q", auth.MethodDNS, auth.MethodHTTP, method)
+			}
+			key, err := loadProofKey(keyPath)
+			if err != nil {
+				return err
+			}
+			if deps.Runtime == nil {
+				return errRegistryRuntimeNotConfigured
+			}
+			c, err := deps.Runtime.RegistryClient(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("resolving registry client: %w", err)
+			}
+			timestamp := time.Now().UTC().Format(time.RFC3339)
+			message, err := auth.DomainProofMessage(args[0], timestamp)
+			if err != nil {
+				return err
+			}
+			sig, err := key.Sign(nil, message, crypto.Hash(0))
+			if err != nil {
+				return fmt.Errorf("signing proof: %w", err)
+			}
+			owner, err := c.ClaimNamespace(cmd.Context(), arv0.ClaimNamespaceRequest{
+				Method:    method,
+				Domain:    args[0],
+				Timestamp: timestamp,
+				Signature: hex.EncodeToString(sig),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to claim the namespace of %s: %w", args[0], err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "namespace %s claimed through %s (%s)\n", owner.Namespace, owner.Domain, owner.Method)
+			return nil
+		},
+	}
+	cmd.Flags().String("key", "", "Path to the PEM Ed25519 private key the domain publishes")
+	cmd.Flags().String("method", string(auth.MethodDNS), "Where the domain publishes the key: dns or http")
+	_ = cmd.MarkFlagRequired("key")
+	return cmd
+}
+
+func newNamespaceListCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List claimed namespaces",
+		Example:      "  arctl namespace list\n  arctl namespace list -o json",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat, _ := cmd.Flags().GetString("output")
+			if deps.Runtime == nil {
+				return errRegistryRuntimeNotConfigured
+			}
+			c, err := deps.Runtime.RegistryClient(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("resolving registry client: %w", err)
+			}
+			owners, err := c.ListNamespaceOwners(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to list namespace owners: %w", err)
+			}
+			switch outputFormat {
+			case "yaml":
+				return marshalYAML(cmd, owners)
+			case "json":
+				return marshalJSON(cmd, owners)
+			}
+			t := printer.NewTablePrinter(cmd.OutOrStdout())
+			t.SetHeaders("Namespace", "Domain", "Method", "Verified")
+			for _, owner := range owners {
+				t.AddRow(owner.Namespace, owner.Domain, owner.Method, owner.VerifiedAt.UTC().Format(time.RFC3339))
+			}
+			return t.Render()
+		},
+	}
+	cmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
+	return cmd
+}
+
+func newNamespaceReleaseCmd(deps cliruntime.Deps) *cobra.Command {
+	return &cobra.Command{
+		Use:          "release NAMESPACE",
+		Short:        "Release a claimed namespace (registry admins only)",
+		Example:      "  arctl namespace release com.acme",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.Runtime == nil {
+				return errRegistryRuntimeNotConfigured
+			}
+			c, err := deps.Runtime.RegistryClient(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("resolving registry client: %w", err)
+			}
+			if err := c.ReleaseNamespace(cmd.Context(), args[0]); err != nil {
+				return fmt.Errorf("failed to release namespace %q: %w", args[0], err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "namespace %s released\n", args[0])
+			return nil
+		},
+	}
+}
+
+// loadProofKey loads the Ed25519 private key a domain proof is made with.
+func loadProofKey(path string) (crypto.Signer, error) {
+	key, err := loadSigningKey(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := key.Public().(ed25519.PublicKey); !ok {
+		return nil, fmt.Errorf("%s is not an Ed25519 key; domain proofs need one", path)
+	}
+	return key, nil
+}
@@ -0,0 +1,90 @@
+package declarative_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+)
+
+func runNamespaceCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	out := &bytes.Buffer{}
+	cmd := declarative.NewNamespaceCmd(declarativeTestDeps(nil))
+	cmd.SetOut(out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+// writeProofKey writes a PEM Ed25519 private key and returns its path and
+// public key.
+func writeProofKey(t *testing.T) (string, ed25519.PublicKey) {
+	t.Helper()
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "acme.key")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	return path, pub
+}
+
+func TestNamespaceRecord(t *testing.T) {
+	keyPath, pub := writeProofKey(t)
+	out, err := runNamespaceCmd(t, "record", "--key", keyPath)
+	require.NoError(t, err)
+	got, err := auth.ParseDomainProofRecord(strings.TrimSpace(out))
+	require.NoError(t, err)
+	assert.Equal(t, pub, got)
+}
+
+func TestNamespaceClaim(t *testing.T) {
+	keyPath, pub := writeProofKey(t)
+	var got arv0.ClaimNamespaceRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v0/namespace-owners" {
+			http.NotFound(w, r)
+			return
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"namespace":"com.acme","domain":"acme.com","method":"` + got.Method + `","publicKey":"","verifiedAt":"2026-01-01T00:00:00Z","createdAt":"2026-01-01T00:00:00Z"}`))
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	out, err := runNamespaceCmd(t, "claim", "acme.com", "--key", keyPath, "--method", "http")
+	require.NoError(t, err)
+	assert.Equal(t, "namespace com.acme claimed through acme.com (http)\n", out)
+	assert.Equal(t, "acme.com", got.Domain)
+	ts, err := time.Parse(time.RFC3339, got.Timestamp)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), ts, time.Minute)
+	sig, err := hex.DecodeString(got.Signature)
+	require.NoError(t, err)
+	message, err := auth.DomainProofMessage("acme.com", got.Timestamp)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(pub, message, sig), "the domain and timestamp are signed with the key")
+
+	_, err = runNamespaceCmd(t, "claim", "acme.com", "--key", keyPath, "--method", "txt")
+	require.ErrorContains(t, err, "--method")
+}
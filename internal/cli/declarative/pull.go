@@ -10,6 +10,7 @@ import (
 
 	"github.com/agentregistry-dev/agentregistry/internal/cli/common/gitutil"
 	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/skills"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
 )
@@ -23,7 +24,8 @@ func NewPullCmd(deps cliruntime.Deps) *cobra.Command {
 
 Supported types: agent, mcp, skill. Reads the resource's
 Spec.Source.Repository.URL from the registry and clones it into DIRECTORY
-(defaults to NAME if omitted).`,
+(defaults to NAME if omitted). Skills with an OCI or uploaded-archive source
+are unpacked at the digest the registry pinned instead.`,
 		Example: `  arctl pull agent myagent
   arctl pull mcp myserver ./vendor/myserver
   arctl pull skill myskill --tag stable`,
@@ -89,6 +91,9 @@ func pullResource(ctx context.Context, deps cliruntime.Deps, typ, name, tag, out
 		if err != nil || obj == nil {
 			return fmt.Errorf("fetch skill %q: %w", name, err)
 		}
+		if skills.HasDigestSource(obj) {
+			return pullSkillContent(ctx, c, obj, outDir)
+		}
 		if obj.Spec.Source == nil || obj.Spec.Source.Repository == nil || obj.Spec.Source.Repository.URL == "" {
 			return fmt.Errorf("skill %q has no source repository URL set", name)
 		}
@@ -115,3 +120,20 @@ func pullResource(ctx context.Context, deps cliruntime.Deps, typ, name, tag, out
 	fmt.Printf("Pulled %s\n", name)
 	return nil
 }
+
+// pullSkillContent writes an OCI- or archive-sourced skill into outDir,
+// materialized at the digest the registry pinned.
+func pullSkillContent(ctx context.Context, c *client.Client, skill *v1alpha1.Skill, outDir string) error {
+	b, err := skills.NewMaterializer(c.GetSkillArchive).Materialize(ctx, skill)
+	if err != nil {
+		return fmt.Errorf("materialize skill %q: %w", skill.Metadata.Name, err)
+	}
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return err
+	}
+	if err := skills.WriteDir(b, outDir); err != nil {
+		return err
+	}
+	fmt.Printf("Pulled %s @ %s into %s\n", skill.Metadata.Name, skill.Status.ResolvedSource.Digest, outDir)
+	return nil
+}
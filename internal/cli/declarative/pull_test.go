@@ -1,11 +1,23 @@
 package declarative_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 )
 
 func TestPull_RejectsUnknownType(t *testing.T) {
@@ -13,3 +25,47 @@ func TestPull_RejectsUnknownType(t *testing.T) {
 	cmd.SetArgs([]string{"unknown", "foo"})
 	require.Error(t, cmd.Execute())
 }
+
+func TestPull_SkillFromUploadedArchive(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	content := "# summarize\n"
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "SKILL.md", Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	archive := buf.Bytes()
+	sum := sha256.Sum256(archive)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/v0/skills/summarize":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(v1alpha1.Skill{
+				TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindSkill},
+				Metadata: v1alpha1.ObjectMeta{Namespace: v1alpha1.DefaultNamespace, Name: "summarize", Tag: "latest"},
+				Spec:     v1alpha1.SkillSpec{Source: &v1alpha1.SkillSource{Archive: &v1alpha1.SkillSourceArchive{Digest: digest}}},
+				Status:   v1alpha1.SkillStatus{ResolvedSource: &v1alpha1.SkillResolvedSource{Digest: digest}},
+			})
+		case "/v0/skill-archives/" + url.PathEscape(digest):
+			w.Header().Set("Content-Type", "application/gzip")
+			_, _ = w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	setupClientForServer(t, srv)
+
+	dir := filepath.Join(t.TempDir(), "summarize")
+	cmd := declarative.NewPullCmd(declarativeTestDeps(nil))
+	cmd.SetArgs([]string{"skill", "summarize", dir})
+	require.NoError(t, cmd.Execute())
+
+	got, err := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+	require.NoError(t, err)
+	require.Equal(t, content, string(got))
+}
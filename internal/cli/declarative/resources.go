@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	cliCommon "github.com/agentregistry-dev/agentregistry/internal/cli/common"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/scheme"
 	"github.com/agentregistry-dev/agentregistry/internal/client"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	"github.com/agentregistry-dev/agentregistry/pkg/printer"
 )
@@ -23,18 +26,7 @@ import (
 // though they existed in the registry. List now matches the natural
 // "show me what's there" expectation.
 func listAny[T v1alpha1.Object](ctx context.Context, c *client.Client, kind string, opts scheme.ListOpts, newObj func() T) ([]any, error) {
-	items, err := client.ListAllTyped(
-		ctx,
-		c,
-		kind,
-		client.ListOpts{
-			Namespace:  v1alpha1.DefaultNamespace,
-			Tag:        opts.Tag,
-			LatestOnly: opts.LatestOnly,
-			Limit:      200,
-		},
-		newObj,
-	)
+	items, err := client.ListAllTyped(ctx, c, kind, listClientOpts(opts), newObj)
 	if err != nil {
 		return nil, err
 	}
@@ -46,6 +38,35 @@ func listAny[T v1alpha1.Object](ctx context.Context, c *client.Client, kind stri
 	return out, nil
 }
 
+// listClientOpts maps the CLI list filters onto the registry client's.
+func listClientOpts(opts scheme.ListOpts) client.ListOpts {
+	return client.ListOpts{
+		Namespace:     v1alpha1.DefaultNamespace,
+		Labels:        opts.LabelSelector,
+		FieldSelector: opts.FieldSelector,
+		Tag:           opts.Tag,
+		LatestOnly:    opts.LatestOnly,
+		Limit:         200,
+	}
+}
+
+// watchAny streams changes to the list listAny returns for opts; see
+// scheme.WatchFunc.
+func watchAny[T v1alpha1.Object](ctx context.Context, c *client.Client, kind string, opts scheme.ListOpts, newObj func() T, handle func(string, any) error) error {
+	return watchItems(ctx, c, kind, listClientOpts(opts), newObj, handle)
+}
+
+// watchItems erases the typed envelope of each watch event, passing nil
+// for events without an object.
+func watchItems[T v1alpha1.Object](ctx context.Context, c *client.Client, kind string, opts client.ListOpts, newObj func() T, handle func(string, any) error) error {
+	return client.WatchTyped(ctx, c, kind, opts, 0, newObj, func(eventType string, obj T) error {
+		if eventType == arv0.WatchEventBookmark {
+			return handle(eventType, nil)
+		}
+		return handle(eventType, obj)
+	})
+}
+
 // listTagsAny lists artifact tags and erases the concrete envelope type so the
 // table printer can format the rows.
 func listTagsAny[T v1alpha1.Object](ctx context.Context, c *client.Client, kind, name string, newObj func() T) ([]any, error) {
@@ -67,7 +88,7 @@ func listTagsAny[T v1alpha1.Object](ctx context.Context, c *client.Client, kind,
 // deleteAllTagsAny lists every live tag and deletes each exact tag so the
 // imperative command can report tag-scoped failures while preserving the
 // declarative DELETE /v0/apply contract for file input.
-func deleteAllTagsAny[T v1alpha1.Object](ctx context.Context, c *client.Client, kind, name string, newObj func() T) error {
+func deleteAllTagsAny[T v1alpha1.Object](ctx context.Context, c *client.Client, kind, name string, opts client.DeleteOpts, newObj func() T) error {
 	ref, err := parseResourceLookupRef(name)
 	if err != nil {
 		return err
@@ -83,14 +104,14 @@ func deleteAllTagsAny[T v1alpha1.Object](ctx context.Context, c *client.Client,
 			errs = append(errs, fmt.Errorf("%s/%s: listed tag row has empty metadata.tag", kind, name))
 			continue
 		}
-		if err := c.Delete(ctx, kind, ref.Namespace, ref.Name, tag); err != nil {
+		if err := c.Delete(ctx, kind, ref.Namespace, ref.Name, tag, opts); err != nil {
 			errs = append(errs, fmt.Errorf("%s/%s@%s: %w", kind, name, tag, err))
 		}
 	}
 	return errorsJoin(errs)
 }
 
-func deleteAny[T v1alpha1.Object](ctx context.Context, c *client.Client, kind, name, tag string, newObj func() T) error {
+func deleteAny[T v1alpha1.Object](ctx context.Context, c *client.Client, kind, name, tag string, opts client.DeleteOpts, newObj func() T) error {
 	ref, err := parseResourceLookupRef(name)
 	if err != nil {
 		return err
@@ -103,7 +124,7 @@ func deleteAny[T v1alpha1.Object](ctx context.Context, c *client.Client, kind, n
 		}
 		targetTag = obj.GetMetadata().Tag
 	}
-	return c.Delete(ctx, kind, ref.Namespace, ref.Name, targetTag)
+	return c.Delete(ctx, kind, ref.Namespace, ref.Name, targetTag, opts)
 }
 
 func listDeploymentResources(ctx context.Context, c *client.Client, opts scheme.ListOpts) ([]any, error) {
@@ -114,12 +135,7 @@ func listDeploymentResources(ctx context.Context, c *client.Client, opts scheme.
 		ctx,
 		c,
 		v1alpha1.KindDeployment,
-		client.ListOpts{
-			Namespace:          v1alpha1.DefaultNamespace,
-			Limit:              200,
-			Origin:             opts.Origin,
-			IncludeTerminating: true,
-		},
+		deploymentListOpts(opts),
 		func() *v1alpha1.Deployment { return &v1alpha1.Deployment{} },
 	)
 	if err != nil {
@@ -133,6 +149,23 @@ func listDeploymentResources(ctx context.Context, c *client.Client, opts scheme.
 	return out, nil
 }
 
+func deploymentListOpts(opts scheme.ListOpts) client.ListOpts {
+	return client.ListOpts{
+		Namespace:          v1alpha1.DefaultNamespace,
+		Labels:             opts.LabelSelector,
+		FieldSelector:      opts.FieldSelector,
+		Limit:              200,
+		Origin:             opts.Origin,
+		IncludeTerminating: true,
+	}
+}
+
+// watchDeploymentResources is the watch counterpart of
+// listDeploymentResources.
+func watchDeploymentResources(ctx context.Context, c *client.Client, opts scheme.ListOpts, handle func(string, any) error) error {
+	return watchItems(ctx, c, v1alpha1.KindDeployment, deploymentListOpts(opts), func() *v1alpha1.Deployment { return &v1alpha1.Deployment{} }, handle)
+}
+
 func agentRow(agent *v1alpha1.Agent) []string {
 	if agent == nil {
 		return []string{"<invalid>"}
@@ -140,6 +173,7 @@ func agentRow(agent *v1alpha1.Agent) []string {
 	return []string{
 		printer.TruncateString(agent.Metadata.Name, 40),
 		agent.Metadata.Tag,
+		string(agent.Status.LifecycleState()),
 		agentDisplayMode(agent.Spec),
 		printer.TruncateString(printer.EmptyValueOrDefault(agent.Spec.Description, "<none>"), 60),
 	}
@@ -167,10 +201,22 @@ func mcpRow(server *v1alpha1.MCPServer) []string {
 	return []string{
 		printer.TruncateString(server.Metadata.Name, 40),
 		server.Metadata.Tag,
+		string(server.Status.LifecycleState()),
+		mcpToolCount(server.Status.Capabilities),
 		printer.TruncateString(printer.EmptyValueOrDefault(server.Spec.Description, "<none>"), 60),
 	}
 }
 
+// mcpToolCount is the TOOLS column: the number of tools the server reported
+// when introspected, or "-" when it has not been (introspection is off, or
+// has not succeeded for this tag).
+func mcpToolCount(caps *v1alpha1.MCPServerCapabilities) string {
+	if caps == nil {
+		return "-"
+	}
+	return strconv.Itoa(len(caps.Tools))
+}
+
 func skillRow(skill *v1alpha1.Skill) []string {
 	if skill == nil {
 		return []string{"<invalid>"}
@@ -178,6 +224,7 @@ func skillRow(skill *v1alpha1.Skill) []string {
 	return []string{
 		printer.TruncateString(skill.Metadata.Name, 40),
 		skill.Metadata.Tag,
+		string(skill.Status.LifecycleState()),
 		printer.TruncateString(printer.EmptyValueOrDefault(skill.Spec.Description, "<none>"), 60),
 	}
 }
@@ -189,6 +236,7 @@ func promptRow(prompt *v1alpha1.Prompt) []string {
 	return []string{
 		printer.TruncateString(prompt.Metadata.Name, 40),
 		prompt.Metadata.Tag,
+		string(prompt.Status.LifecycleState()),
 		printer.TruncateString(printer.EmptyValueOrDefault(prompt.Spec.Description, "<none>"), 60),
 	}
 }
@@ -200,6 +248,7 @@ func pluginRow(plugin *v1alpha1.Plugin) []string {
 	return []string{
 		printer.TruncateString(plugin.Metadata.Name, 40),
 		plugin.Metadata.Tag,
+		string(plugin.Status.LifecycleState()),
 		printer.TruncateString(printer.EmptyValueOrDefault(plugin.Spec.Description, "<none>"), 60),
 	}
 }
@@ -222,12 +271,61 @@ func modelRow(model *v1alpha1.Model) []string {
 	return []string{
 		printer.TruncateString(model.Metadata.Name, 40),
 		model.Metadata.Tag,
+		string(model.Status.LifecycleState()),
 		model.Spec.Provider,
 		printer.TruncateString(model.Spec.Model, 50),
 		printer.EmptyValueOrDefault(auth, "<provider default>"),
 	}
 }
 
+func secretRow(secret *v1alpha1.Secret) []string {
+	if secret == nil {
+		return []string{"<invalid>"}
+	}
+	return []string{
+		printer.TruncateString(secret.Metadata.Name, 40),
+		printer.EmptyValueOrDefault(strings.Join(secret.Spec.Keys(), ","), "<none>"),
+	}
+}
+
+func roleRow(role *v1alpha1.Role) []string {
+	if role == nil {
+		return []string{"<invalid>"}
+	}
+	return roleSpecRow(role.Metadata.Name, role.Spec)
+}
+
+func clusterRoleRow(role *v1alpha1.ClusterRole) []string {
+	if role == nil {
+		return []string{"<invalid>"}
+	}
+	return roleSpecRow(role.Metadata.Name, role.Spec)
+}
+
+// roleSpecRow renders the RoleSpec Role and ClusterRole share.
+func roleSpecRow(name string, spec v1alpha1.RoleSpec) []string {
+	return []string{
+		printer.TruncateString(name, 40),
+		strconv.Itoa(len(spec.Rules)),
+		printer.TruncateString(printer.EmptyValueOrDefault(spec.Description, "<none>"), 60),
+	}
+}
+
+func roleBindingRow(binding *v1alpha1.RoleBinding) []string {
+	if binding == nil {
+		return []string{"<invalid>"}
+	}
+	subjects := make([]string, 0, len(binding.Spec.Subjects))
+	for _, subject := range binding.Spec.Subjects {
+		subjects = append(subjects, subject.Kind+":"+subject.Name)
+	}
+	return []string{
+		printer.TruncateString(binding.Metadata.Name, 40),
+		binding.Spec.RoleRef.Kind + "/" + binding.Spec.RoleRef.Name,
+		printer.TruncateString(strings.Join(subjects, ","), 60),
+	}
+}
+
 func deploymentRow(dep *cliCommon.DeploymentRecord) []string {
 	if dep == nil {
 		return []string{"<invalid>"}
@@ -74,8 +74,28 @@ func TestAgentRowIncludesModeAndDescription(t *testing.T) {
 		},
 	}
 
-	want := []string{"reviewer", "stable", "source+harness", "Reviews pull requests"}
+	want := []string{"reviewer", "stable", "Active", "source+harness", "Reviews pull requests"}
 	if got := agentRow(agent); !reflect.DeepEqual(got, want) {
 		t.Fatalf("agentRow() = %#v, want %#v", got, want)
 	}
 }
+
+func TestMCPRowIncludesIntrospectedToolCount(t *testing.T) {
+	server := &v1alpha1.MCPServer{
+		Metadata: v1alpha1.ObjectMeta{Name: "acme/echo", Tag: "v1"},
+		Spec:     v1alpha1.MCPServerSpec{Description: "Echoes"},
+	}
+	if got, want := mcpRow(server), []string{"acme/echo", "v1", "Active", "-", "Echoes"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("mcpRow() before introspection = %#v, want %#v", got, want)
+	}
+
+	server.Status.Capabilities = &v1alpha1.MCPServerCapabilities{Tools: []v1alpha1.MCPToolInfo{{Name: "echo"}, {Name: "shout"}}}
+	if got, want := mcpRow(server), []string{"acme/echo", "v1", "Active", "2", "Echoes"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("mcpRow() after introspection = %#v, want %#v", got, want)
+	}
+
+	server.Status.Lifecycle = &v1alpha1.TagLifecycle{State: v1alpha1.LifecycleYanked, Reason: "CVE-2026-1"}
+	if got, want := mcpRow(server), []string{"acme/echo", "v1", "Yanked", "2", "Echoes"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("mcpRow() after yank = %#v, want %#v", got, want)
+	}
+}
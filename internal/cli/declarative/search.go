@@ -0,0 +1,104 @@
+package declarative
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/scheme"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+)
+
+// NewSearchCmd returns the "search" command, which runs a ranked
+// full-text search across registry kinds.
+func NewSearchCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cliruntime.CommandSearch + " QUERY...",
+		Short: "Search the registry across kinds",
+		Long: `Search the registry across kinds, best match first.
+
+Matches names and titles first, then descriptions, then labels, the tools
+an MCP server reports and the skills, commands and servers a plugin
+bundles. Words are all required; "quoted phrases" match in order, "or"
+separates alternatives and a leading "-" excludes a word. The MATCH column
+shows where the words were found, wrapped in **; for a name-only match it
+shows the description.
+
+Tagged kinds report the best-matching tag of each name. Kinds you are not
+allowed to list are left out unless named with --kind.`,
+		Example: `  arctl search jira
+  arctl search "pull request" --kind mcp,agent
+  arctl search postgres -o json`,
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kinds, _ := cmd.Flags().GetStringSlice("kind")
+			namespace, _ := cmd.Flags().GetString("namespace")
+			limit, _ := cmd.Flags().GetInt("limit")
+			outputFormat, _ := cmd.Flags().GetString("output")
+			opts := client.SearchOpts{Namespace: namespace, Limit: limit}
+			for _, name := range kinds {
+				k, err := kindRegistry(deps).Lookup(name)
+				if err != nil {
+					return err
+				}
+				kind, ok := canonicalKindName(k)
+				if !ok {
+					return fmt.Errorf("search not supported for kind %q", k.Kind)
+				}
+				opts.Kinds = append(opts.Kinds, kind)
+			}
+			if deps.Runtime == nil {
+				return errRegistryRuntimeNotConfigured
+			}
+			c, err := deps.Runtime.RegistryClient(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("resolving registry client: %w", err)
+			}
+			resp, err := c.Search(cmd.Context(), strings.Join(args, " "), opts)
+			if err != nil {
+				return fmt.Errorf("search failed: %w", err)
+			}
+			switch outputFormat {
+			case "yaml":
+				return marshalYAML(cmd, resp.Items)
+			case "json":
+				return marshalJSON(cmd, resp.Items)
+			}
+			if len(resp.Items) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No matches found.")
+				return nil
+			}
+			t := printer.NewTablePrinter(cmd.OutOrStdout())
+			t.SetHeaders("Kind", "Name", "Tag", "Match")
+			for _, r := range resp.Items {
+				match := r.Highlight
+				if match == "" {
+					match = r.Description
+				}
+				t.AddRow(r.Kind, r.Namespace+"/"+r.Name, r.Tag, match)
+			}
+			return t.Render()
+		},
+	}
+	cmd.Flags().StringSlice("kind", nil, "Kinds to search, comma-separated (e.g. mcp,agent). Defaults to every kind.")
+	cmd.Flags().String("namespace", "", "Only return results from this namespace. Defaults to every namespace.")
+	cmd.Flags().Int("limit", 0, "Max results to return (default 20, max 100)")
+	cmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
+	return cmd
+}
+
+// canonicalKindName maps a CLI kind (e.g. "mcp") to the v1alpha1 Kind the
+// server knows it by, via its name or aliases.
+func canonicalKindName(k *scheme.Kind) (string, bool) {
+	for _, name := range append([]string{k.Kind}, k.Aliases...) {
+		if descriptor, ok := v1alpha1.KindDescriptorFor(name); ok {
+			return descriptor.Kind, true
+		}
+	}
+	return "", false
+}
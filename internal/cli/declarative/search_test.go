@@ -0,0 +1,55 @@
+package declarative_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+)
+
+func runSearchCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	out := &bytes.Buffer{}
+	cmd := declarative.NewSearchCmd(declarativeTestDeps(nil))
+	cmd.SetOut(out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestSearch_ForwardsQueryAndPrintsTable(t *testing.T) {
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v0/search" {
+			http.NotFound(w, r)
+			return
+		}
+		got = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"query":"jira issues","items":[
+			{"kind":"MCPServer","namespace":"default","name":"tickets","tag":"1.0.0","description":"Issue tracker access","highlight":"Create a **Jira** **issue**","score":0.4},
+			{"kind":"Agent","namespace":"team-a","name":"jira-triage","tag":"latest","description":"Triages tickets","score":0.2}]}`))
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	out, err := runSearchCmd(t, "jira", "issues", "--kind", "mcp,agents", "--namespace", "team-a", "--limit", "5")
+	require.NoError(t, err)
+	assert.Equal(t, "jira issues", got.Get("q"))
+	assert.Equal(t, "MCPServer,Agent", got.Get("kinds"))
+	assert.Equal(t, "team-a", got.Get("namespace"))
+	assert.Equal(t, "5", got.Get("limit"))
+	assert.Contains(t, out, "default/tickets")
+	assert.Contains(t, out, "Create a **Jira** **issue**")
+	assert.Contains(t, out, "Triages tickets", "name-only matches fall back to the description")
+
+	_, err = runSearchCmd(t, "jira", "--kind", "bogus")
+	require.Error(t, err)
+}
@@ -0,0 +1,317 @@
+package declarative
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/scheme"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/signing"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// cosignPasswordEnv holds the password of an encrypted cosign private key,
+// the same variable cosign itself reads.
+const cosignPasswordEnv = "COSIGN_PASSWORD"
+
+// NewSignCmd returns the "sign" command, which signs the taggable
+// resources in a YAML file.
+func NewSignCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cliruntime.CommandSign + " -f FILE --key KEY",
+		Short: "Sign the taggable resources in a YAML file",
+		Long: `Sign the taggable resources (agent, mcp, skill, prompt, plugin, model)
+in a YAML file with a private key, recording the signature in each
+resource's metadata.annotations. Other documents pass through unchanged.
+
+The signature covers the resource's spec, labels and annotations, so any
+later edit invalidates it; sign as the last step before "arctl apply".
+KEY is a PEM private key, such as one written by "cosign generate-key-pair";
+an encrypted cosign key is decrypted with $COSIGN_PASSWORD.
+
+The signed YAML is written to stdout, or back to FILE with --in-place.`,
+		Example: `  arctl sign -f agent.yaml --key cosign.key --in-place
+  arctl sign -f stack.yaml --key release.key --key-name release > signed.yaml`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			path, _ := cmd.Flags().GetString("filename")
+			keyPath, _ := cmd.Flags().GetString("key")
+			keyName, _ := cmd.Flags().GetString("key-name")
+			inPlace, _ := cmd.Flags().GetBool("in-place")
+			if inPlace && path == "-" {
+				return fmt.Errorf("--in-place cannot be used with stdin")
+			}
+			key, err := loadSigningKey(keyPath)
+			if err != nil {
+				return err
+			}
+			return runSign(cmd, path, key, keyName, inPlace)
+		},
+	}
+	cmd.Flags().StringP("filename", "f", "", "YAML file to sign (use - for stdin)")
+	cmd.Flags().String("key", "", "Path to the PEM private key to sign with")
+	cmd.Flags().String("key-name", "", "Name of the key, recorded so the registry can pick the matching trusted key")
+	cmd.Flags().BoolP("in-place", "i", false, "Write the signed YAML back to FILE instead of stdout")
+	_ = cmd.MarkFlagRequired("filename")
+	_ = cmd.MarkFlagRequired("key")
+	return cmd
+}
+
+func loadSigningKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading key: %w", err)
+	}
+	key, err := signing.ParsePrivateKey(data, []byte(os.Getenv(cosignPasswordEnv)))
+	if err != nil {
+		if errors.Is(err, signing.ErrPasswordRequired) {
+			return nil, fmt.Errorf("%s is encrypted; set %s", path, cosignPasswordEnv)
+		}
+		return nil, err
+	}
+	return key, nil
+}
+
+func runSign(cmd *cobra.Command, path string, key crypto.Signer, keyName string, inPlace bool) error {
+	log := cmd.ErrOrStderr()
+	if inPlace {
+		log = cmd.OutOrStdout()
+	}
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		// Sign what "arctl apply" will send: labels it injects from a
+		// sibling arctl.yaml are part of the signed content.
+		data, err = injectArctlLabels(path, log)
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	docs, err := splitYAMLDocs(data)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	var signed int
+	for _, doc := range docs {
+		if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+			continue
+		}
+		obj, err := decodeYAMLDoc(doc)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+		if !v1alpha1.IsTaggedArtifactKind(obj.GetKind()) {
+			continue
+		}
+		if err := signing.Sign(obj, key, keyName); err != nil {
+			return fmt.Errorf("signing %s: %w", artifactRef(obj), err)
+		}
+		meta := findOrCreateMappingChild(doc.Content[0], "metadata")
+		annotations := findOrCreateMappingChild(meta, "annotations")
+		for _, k := range []string{signing.SignatureAnnotation, signing.SignatureKeyAnnotation} {
+			if v, ok := obj.GetMetadata().Annotations[k]; ok {
+				upsertLabel(annotations, k, v)
+			} else {
+				removeMappingKey(annotations, k)
+			}
+		}
+		fmt.Fprintf(log, "signed %s\n", artifactRef(obj))
+		signed++
+	}
+	if signed == 0 {
+		return fmt.Errorf("no taggable resources to sign in %s", path)
+	}
+
+	out, err := marshalYAMLDocs(docs)
+	if err != nil {
+		return err
+	}
+	if !inPlace {
+		_, err = cmd.OutOrStdout().Write(out)
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, out, info.Mode().Perm())
+}
+
+// NewVerifyCmd returns the "verify" command, which checks resource
+// signatures against a public key.
+func NewVerifyCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cliruntime.CommandVerify + " (TYPE NAME | -f FILE) --key KEY",
+		Short: "Verify resource signatures against a public key",
+		Long: `Verify the signature of a taggable resource against a public key.
+
+With TYPE NAME the resource is fetched from the registry (--tag selects
+the tag); with -f every taggable resource in a local YAML file is checked.
+KEY is a PEM public key, such as the cosign.pub written by
+"cosign generate-key-pair". Fails if any resource is unsigned or its
+signature does not match.
+
+TYPE must be a taggable kind: agent, mcp, skill, prompt, plugin, model
+(plural and uppercase forms also accepted)`,
+		Example: `  arctl verify agent acme-summarizer --key cosign.pub
+  arctl verify mcp team-a/acme-fetch --tag 1.2.0 --key release.pub
+  arctl verify -f signed.yaml --key cosign.pub`,
+		Args:         cobra.MaximumNArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, _ := cmd.Flags().GetString("filename")
+			switch {
+			case path != "" && len(args) > 0:
+				return fmt.Errorf("specify either TYPE NAME or -f FILE, not both")
+			case path == "" && len(args) != 2:
+				return fmt.Errorf("TYPE NAME or -f FILE is required")
+			}
+			keyPath, _ := cmd.Flags().GetString("key")
+			data, err := os.ReadFile(keyPath)
+			if err != nil {
+				return fmt.Errorf("reading key: %w", err)
+			}
+			key, err := signing.ParsePublicKey(data)
+			if err != nil {
+				return err
+			}
+
+			var objs []v1alpha1.Object
+			if path != "" {
+				if objs, err = decodeSignedFile(cmd, path); err != nil {
+					return err
+				}
+			} else {
+				obj, err := fetchSignedObject(cmd, deps, args[0], args[1])
+				if err != nil {
+					return err
+				}
+				objs = []v1alpha1.Object{obj}
+			}
+
+			for _, obj := range objs {
+				if err := signing.Verify(obj, key); err != nil {
+					return fmt.Errorf("%s: %w", artifactRef(obj), err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s: signature verified OK\n", artifactRef(obj))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringP("filename", "f", "", "YAML file to verify (use - for stdin)")
+	cmd.Flags().String("key", "", "Path to the PEM public key to verify with")
+	cmd.Flags().String("tag", "latest", "Tag to verify when fetching from the registry")
+	_ = cmd.MarkFlagRequired("key")
+	return cmd
+}
+
+func decodeSignedFile(cmd *cobra.Command, path string) ([]v1alpha1.Object, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	decoded, err := scheme.DecodeBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	var objs []v1alpha1.Object
+	for _, obj := range decoded {
+		if v1alpha1.IsTaggedArtifactKind(obj.GetKind()) {
+			objs = append(objs, obj)
+		}
+	}
+	if len(objs) == 0 {
+		return nil, fmt.Errorf("no taggable resources to verify in %s", path)
+	}
+	return objs, nil
+}
+
+func fetchSignedObject(cmd *cobra.Command, deps cliruntime.Deps, typ, name string) (v1alpha1.Object, error) {
+	k, err := kindRegistry(deps).Lookup(typ)
+	if err != nil {
+		return nil, err
+	}
+	if !v1alpha1.IsTaggedArtifactKind(k.Kind) {
+		return nil, fmt.Errorf("verify not supported for kind %q (resource is not taggable)", k.Kind)
+	}
+	if deps.Runtime == nil {
+		return nil, errRegistryRuntimeNotConfigured
+	}
+	c, err := deps.Runtime.RegistryClient(cmd.Context())
+	if err != nil {
+		return nil, fmt.Errorf("resolving registry client: %w", err)
+	}
+	tag, _ := cmd.Flags().GetString("tag")
+	item, err := getItem(cmd.Context(), c, k, name, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %q tag %q: %w", k.Kind, name, tag, err)
+	}
+	obj, ok := item.(v1alpha1.Object)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s response type %T", k.Kind, item)
+	}
+	return obj, nil
+}
+
+// decodeYAMLDoc decodes one document of a multi-doc stream into its typed
+// envelope.
+func decodeYAMLDoc(doc *yaml.Node) (v1alpha1.Object, error) {
+	b, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	objs, err := scheme.DecodeBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(objs) != 1 {
+		return nil, fmt.Errorf("expected one resource per document, got %d", len(objs))
+	}
+	return objs[0], nil
+}
+
+// removeMappingKey deletes key from a mapping node, if present.
+func removeMappingKey(mapping *yaml.Node, key string) {
+	for i := 0; i < len(mapping.Content)-1; i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// artifactRef renders obj as kind/[namespace/]name:tag for progress output.
+func artifactRef(obj v1alpha1.Object) string {
+	meta := obj.GetMetadata()
+	name := meta.Name
+	if meta.Namespace != "" && meta.Namespace != v1alpha1.DefaultNamespace {
+		name = meta.Namespace + "/" + name
+	}
+	tag := meta.Tag
+	if tag == "" {
+		tag = v1alpha1store.DefaultTag()
+	}
+	return fmt.Sprintf("%s/%s:%s", strings.ToLower(obj.GetKind()), name, tag)
+}
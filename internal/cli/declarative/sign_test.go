@@ -0,0 +1,155 @@
+package declarative_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+	"github.com/agentregistry-dev/agentregistry/internal/cli/scheme"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/signing"
+)
+
+const signTestYAML = `apiVersion: ar.dev/v1alpha1
+kind: Agent
+metadata:
+  name: acme-bot
+  tag: 1.0.0
+  annotations:
+    owner: team-a
+spec:
+  title: Acme bot
+---
+apiVersion: ar.dev/v1alpha1
+kind: Runtime
+metadata:
+  name: local
+spec:
+  type: Local
+`
+
+// writeSigningKeys writes a fresh ECDSA key pair into dir and returns the
+// private and public key paths.
+func writeSigningKeys(t *testing.T, dir string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	priv, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	pub, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	privPath, pubPath := filepath.Join(dir, "cosign.key"), filepath.Join(dir, "cosign.pub")
+	require.NoError(t, os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: priv}), 0o600))
+	require.NoError(t, os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub}), 0o600))
+	return privPath, pubPath
+}
+
+func runSignCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	out := &bytes.Buffer{}
+	cmd := declarative.NewSignCmd(declarativeTestDeps(nil))
+	cmd.SetOut(out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func runVerifyCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	out := &bytes.Buffer{}
+	cmd := declarative.NewVerifyCmd(declarativeTestDeps(nil))
+	cmd.SetOut(out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestSign_InPlaceThenVerifyFile(t *testing.T) {
+	dir := t.TempDir()
+	privPath, pubPath := writeSigningKeys(t, dir)
+	path := filepath.Join(dir, "stack.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(signTestYAML), 0o644))
+
+	out, err := runSignCmd(t, "-f", path, "--key", privPath, "--key-name", "release", "--in-place")
+	require.NoError(t, err)
+	assert.Contains(t, out, "signed agent/acme-bot:1.0.0")
+
+	objs, err := scheme.DecodeFile(path)
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+	annotations := objs[0].GetMetadata().Annotations
+	assert.Equal(t, "team-a", annotations["owner"])
+	assert.Equal(t, "release", annotations[signing.SignatureKeyAnnotation])
+	assert.NotEmpty(t, annotations[signing.SignatureAnnotation])
+	assert.Empty(t, objs[1].GetMetadata().Annotations, "non-taggable kinds pass through unsigned")
+
+	out, err = runVerifyCmd(t, "-f", path, "--key", pubPath)
+	require.NoError(t, err)
+	assert.Contains(t, out, "agent/acme-bot:1.0.0: signature verified OK")
+
+	signed, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(string(signed), "Acme bot", "Evil bot", 1)), 0o644))
+	_, err = runVerifyCmd(t, "-f", path, "--key", pubPath)
+	require.ErrorIs(t, err, signing.ErrInvalidSignature)
+}
+
+func TestSign_StdoutLeavesFileUntouched(t *testing.T) {
+	dir := t.TempDir()
+	privPath, _ := writeSigningKeys(t, dir)
+	path := filepath.Join(dir, "agent.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(signTestYAML), 0o644))
+
+	out, err := runSignCmd(t, "-f", path, "--key", privPath)
+	require.NoError(t, err)
+	assert.Contains(t, out, signing.SignatureAnnotation)
+	assert.NotContains(t, out, "signed agent/", "progress goes to stderr when YAML is on stdout")
+
+	unchanged, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, signTestYAML, string(unchanged))
+}
+
+func TestVerify_FetchesFromRegistry(t *testing.T) {
+	dir := t.TempDir()
+	privPath, pubPath := writeSigningKeys(t, dir)
+	path := filepath.Join(dir, "agent.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(signTestYAML), 0o644))
+	_, err := runSignCmd(t, "-f", path, "--key", privPath, "--in-place")
+	require.NoError(t, err)
+	objs, err := scheme.DecodeFile(path)
+	require.NoError(t, err)
+	agent := objs[0]
+
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.Method + " " + r.URL.RequestURI()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(agent)
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	out, err := runVerifyCmd(t, "agent", "acme-bot", "--tag", "1.0.0", "--key", pubPath)
+	require.NoError(t, err)
+	assert.Equal(t, "GET /v0/agents/acme-bot/1.0.0", gotPath)
+	assert.Contains(t, out, "signature verified OK")
+
+	_, err = runVerifyCmd(t, "deployment", "acme-bot", "--key", pubPath)
+	require.ErrorContains(t, err, "not taggable")
+}
@@ -0,0 +1,64 @@
+package declarative
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+)
+
+// NewTagCmd returns the "tag" command group for managing tag aliases.
+func NewTagCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cliruntime.CommandTag,
+		Short: "Manage movable tag aliases of registry resources",
+		Long: `Manage movable tag aliases of registry resources.
+
+A tag alias (e.g. stable, prod) points at a concrete tag of a taggable
+artifact. References that name the alias resolve to its target, and moving
+the alias rolls out every Deployment that references it.`,
+		SilenceUsage: true,
+	}
+	cmd.AddCommand(newTagPromoteCmd(deps))
+	return cmd
+}
+
+func newTagPromoteCmd(deps cliruntime.Deps) *cobra.Command {
+	return &cobra.Command{
+		Use:   "promote TYPE NAME FROM TO",
+		Short: "Point the alias TO at the tag FROM",
+		Long: `Point the alias TO at the tag FROM, creating or moving the alias.
+
+FROM is a concrete tag or an existing alias; promoting from an alias copies
+its current target. TO must not already be a concrete tag of NAME.
+
+TYPE must be a taggable kind: agent, mcp, skill, prompt, plugin, model
+(plural and uppercase forms also accepted)`,
+		Example: `  arctl tag promote agent acme-summarizer 1.4.0 stable
+  arctl tag promote mcp team-a/acme-fetch stable prod`,
+		Args:         cobra.ExactArgs(4),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k, err := kindRegistry(deps).Lookup(args[0])
+			if err != nil {
+				return err
+			}
+			if deps.Runtime == nil {
+				return errRegistryRuntimeNotConfigured
+			}
+			c, err := deps.Runtime.RegistryClient(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("resolving registry client: %w", err)
+			}
+			name, from, to := args[1], args[2], args[3]
+			res, err := promoteTag(cmd.Context(), c, k, name, from, to)
+			if err != nil {
+				return fmt.Errorf("failed to promote %s %q tag %q to %q: %w", k.Kind, name, from, to, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s/%s:%s -> %s %s\n", strings.ToLower(k.Kind), res.Name, res.Alias, res.Target, res.Status)
+			return nil
+		},
+	}
+}
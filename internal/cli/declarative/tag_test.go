@@ -0,0 +1,141 @@
+package declarative_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+)
+
+func TestTagPromote_PostsToPromoteEndpoint(t *testing.T) {
+	var (
+		gotPath string
+		gotBody arv0.PromoteTagRequest
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.Method + " " + r.URL.RequestURI()
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(arv0.PromoteTagResponse{
+			TagAlias: arv0.TagAlias{Namespace: "team-a", Name: "acme-bot", Alias: gotBody.To, Target: gotBody.From},
+			Status:   arv0.ApplyStatusConfigured,
+		})
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	out := &bytes.Buffer{}
+	cmd := declarative.NewTagCmd(declarativeTestDeps(nil))
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs([]string{"promote", "agent", "team-a/acme-bot", "1.4.0", "stable"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "POST /v0/agents/acme-bot/promote?namespace=team-a", gotPath)
+	assert.Equal(t, arv0.PromoteTagRequest{From: "1.4.0", To: "stable"}, gotBody)
+	assert.Contains(t, out.String(), "agent/acme-bot:stable -> 1.4.0 configured")
+}
+
+func TestTagPromote_ConflictSurfacesError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"status":409,"detail":"default/acme-bot already has a concrete tag \"1.0.0\""}`))
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	cmd := declarative.NewTagCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"promote", "agent", "acme-bot", "0.9.0", "1.0.0"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already has a concrete tag")
+}
+
+func TestTagPromote_DeploymentRejected(t *testing.T) {
+	setDeclarativeTestClient(t, client.NewClient("http://127.0.0.1:1", ""))
+
+	cmd := declarative.NewTagCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"promote", "deployment", "my-dep", "a", "b"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not taggable")
+}
+
+func TestHistory_ListsRevisions(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.Method + " " + r.URL.RequestURI()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(arv0.TagRevisionListResponse{Items: []arv0.TagRevision{
+			{Name: "acme-bot", Tag: "1.0.0", Generation: 2, ContentHash: "bbbbbbbbbbbbbbbbbbbb", Current: true},
+			{Name: "acme-bot", Tag: "1.0.0", Generation: 1, ContentHash: "aaaaaaaaaaaaaaaaaaaa"},
+		}})
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	out := &bytes.Buffer{}
+	cmd := declarative.NewHistoryCmd(declarativeTestDeps(nil))
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs([]string{"agent", "team-a/acme-bot", "--tag", "1.0.0"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "GET /v0/agents/acme-bot/1.0.0/revisions?namespace=team-a", gotPath)
+	assert.Contains(t, out.String(), "bbbbbbbbbbbb")
+	assert.NotContains(t, out.String(), "bbbbbbbbbbbbb", "content hashes are shortened")
+	assert.Contains(t, out.String(), "aaaaaaaaaaaa")
+}
+
+func TestRollback_PostsGeneration(t *testing.T) {
+	var (
+		gotPath string
+		gotBody arv0.RollbackTagRequest
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.Method + " " + r.URL.RequestURI()
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(arv0.RollbackTagResponse{
+			Namespace: "default", Name: "acme-bot", Tag: "latest", Generation: 4, Status: arv0.ApplyStatusConfigured,
+		})
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	out := &bytes.Buffer{}
+	cmd := declarative.NewRollbackCmd(declarativeTestDeps(nil))
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs([]string{"agent", "acme-bot", "2"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "POST /v0/agents/acme-bot/latest/rollback", gotPath)
+	assert.Equal(t, int64(2), gotBody.Generation)
+	assert.Contains(t, out.String(), "agent/acme-bot:latest rolled back to generation 2 (now generation 4) configured")
+}
+
+func TestRollback_RejectsInvalidGeneration(t *testing.T) {
+	setDeclarativeTestClient(t, client.NewClient("http://127.0.0.1:1", ""))
+
+	cmd := declarative.NewRollbackCmd(declarativeTestDeps(nil))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"agent", "acme-bot", "zero"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "positive integer")
+}
@@ -0,0 +1,183 @@
+package declarative
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+)
+
+// NewTokenCmd returns the "token" command group, which manages API tokens.
+func NewTokenCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cliruntime.CommandToken,
+		Short: "Manage API tokens",
+		Long: `Manage scoped API tokens for CI jobs and automation.
+
+An API token authenticates as the user who created it, or as a service
+account when a registry admin issues it to one, and is limited to its
+scopes on top of what that identity is granted.`,
+	}
+	cmd.AddCommand(newTokenCreateCmd(deps), newTokenListCmd(deps), newTokenRevokeCmd(deps))
+	return cmd
+}
+
+func newTokenCreateCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Issue an API token",
+		Long: `Issue an API token and print it. The token is shown only once.
+
+Each --scope is ACTION:RESOURCE. ACTION is read, publish, edit, deploy or
+delete; RESOURCE is "*" or NAMESPACE/NAME, where NAMESPACE may be "*" and
+NAME may end in "*".`,
+		Example: `  arctl token create ci-publish --scope read:* --scope publish:team-a/*
+  arctl token create nightly --scope read:* --expires-in 8760h
+  arctl token create release --scope publish:*/* --service-account release-bot`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rawScopes, _ := cmd.Flags().GetStringArray("scope")
+			expiresIn, _ := cmd.Flags().GetDuration("expires-in")
+			serviceAccount, _ := cmd.Flags().GetString("service-account")
+			req := arv0.CreateAPITokenRequest{Name: args[0], ServiceAccount: serviceAccount}
+			for _, raw := range rawScopes {
+				scope, err := parseTokenScope(raw)
+				if err != nil {
+					return err
+				}
+				req.Scopes = append(req.Scopes, scope)
+			}
+			if len(req.Scopes) == 0 {
+				return fmt.Errorf("at least one --scope is required")
+			}
+			if expiresIn <= 0 {
+				return fmt.Errorf("--expires-in must be positive")
+			}
+			req.ExpiresAt = time.Now().Add(expiresIn).UTC().Truncate(time.Second)
+
+			if deps.Runtime == nil {
+				return errRegistryRuntimeNotConfigured
+			}
+			c, err := deps.Runtime.RegistryClient(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("resolving registry client: %w", err)
+			}
+			created, err := c.CreateToken(cmd.Context(), req)
+			if err != nil {
+				return fmt.Errorf("failed to create token %q: %w", args[0], err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), created.Token)
+			fmt.Fprintf(cmd.ErrOrStderr(), "Created token %s (%s) for %s. Store it now; it will not be shown again.\n",
+				created.APIToken.ID, created.APIToken.Name, created.APIToken.Subject)
+			return nil
+		},
+	}
+	cmd.Flags().StringArray("scope", nil, "Scope as ACTION:RESOURCE (repeatable)")
+	cmd.Flags().Duration("expires-in", 90*24*time.Hour, "How long the token stays valid, at most a year (8760h)")
+	cmd.Flags().String("service-account", "", "Issue the token to this service account (registry admins only)")
+	return cmd
+}
+
+func newTokenListCmd(deps cliruntime.Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List API tokens",
+		Example:      "  arctl token list\n  arctl token list --all -o json",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			all, _ := cmd.Flags().GetBool("all")
+			outputFormat, _ := cmd.Flags().GetString("output")
+			if deps.Runtime == nil {
+				return errRegistryRuntimeNotConfigured
+			}
+			c, err := deps.Runtime.RegistryClient(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("resolving registry client: %w", err)
+			}
+			tokens, err := c.ListTokens(cmd.Context(), all)
+			if err != nil {
+				return fmt.Errorf("failed to list tokens: %w", err)
+			}
+			return printTokens(cmd, tokens, outputFormat)
+		},
+	}
+	cmd.Flags().Bool("all", false, "List every user's tokens (registry admins only)")
+	cmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
+	return cmd
+}
+
+func newTokenRevokeCmd(deps cliruntime.Deps) *cobra.Command {
+	return &cobra.Command{
+		Use:          "revoke ID",
+		Short:        "Revoke an API token",
+		Example:      "  arctl token revoke 3f9c2a7d81e04b56",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.Runtime == nil {
+				return errRegistryRuntimeNotConfigured
+			}
+			c, err := deps.Runtime.RegistryClient(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("resolving registry client: %w", err)
+			}
+			if err := c.RevokeToken(cmd.Context(), args[0]); err != nil {
+				return fmt.Errorf("failed to revoke token %q: %w", args[0], err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "token %s revoked\n", args[0])
+			return nil
+		},
+	}
+}
+
+// parseTokenScope parses an ACTION:RESOURCE scope flag. The server
+// validates the action and resource pattern.
+func parseTokenScope(raw string) (arv0.TokenScope, error) {
+	action, resource, ok := strings.Cut(raw, ":")
+	if !ok || action == "" || resource == "" {
+		return arv0.TokenScope{}, fmt.Errorf("invalid scope %q: want ACTION:RESOURCE", raw)
+	}
+	return arv0.TokenScope{Action: action, Resource: resource}, nil
+}
+
+func printTokens(cmd *cobra.Command, tokens []arv0.APIToken, outputFormat string) error {
+	switch outputFormat {
+	case "yaml":
+		return marshalYAML(cmd, tokens)
+	case "json":
+		return marshalJSON(cmd, tokens)
+	}
+	t := printer.NewTablePrinter(cmd.OutOrStdout())
+	t.SetHeaders("ID", "Name", "Subject", "Scopes", "Expires", "Last Used", "Status")
+	now := time.Now()
+	for _, token := range tokens {
+		scopes := make([]string, 0, len(token.Scopes))
+		for _, scope := range token.Scopes {
+			scopes = append(scopes, scope.Action+":"+scope.Resource)
+		}
+		status := "active"
+		switch {
+		case token.RevokedAt != nil:
+			status = "revoked"
+		case !token.ExpiresAt.After(now):
+			status = "expired"
+		}
+		t.AddRow(token.ID, token.Name, token.Subject, strings.Join(scopes, ","),
+			token.ExpiresAt.UTC().Format(time.RFC3339), formatTokenTime(token.LastUsedAt, "-"), status)
+	}
+	return t.Render()
+}
+
+func formatTokenTime(t *time.Time, unset string) string {
+	if t == nil {
+		return unset
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,101 @@
+package declarative_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+)
+
+func runTokenCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	out := &bytes.Buffer{}
+	cmd := declarative.NewTokenCmd(declarativeTestDeps(nil))
+	cmd.SetOut(out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestTokenCreate(t *testing.T) {
+	var got arv0.CreateAPITokenRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v0/tokens" {
+			http.NotFound(w, r)
+			return
+		}
+		got = arv0.CreateAPITokenRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"token":"arpat_secret","apiToken":{"id":"abc123","name":"ci","owner":"alice","subject":"alice","scopes":[],"expiresAt":"2026-01-02T00:00:00Z","createdAt":"2026-01-01T00:00:00Z"}}`))
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	before := time.Now()
+	out, err := runTokenCmd(t, "create", "ci", "--scope", "read:*", "--scope", "publish:team-a/*", "--expires-in", "24h")
+	require.NoError(t, err)
+	assert.Equal(t, "arpat_secret\n", out, "only the token goes to stdout")
+	assert.Equal(t, "ci", got.Name)
+	assert.Equal(t, []arv0.TokenScope{{Action: "read", Resource: "*"}, {Action: "publish", Resource: "team-a/*"}}, got.Scopes)
+	assert.WithinDuration(t, before.Add(24*time.Hour), got.ExpiresAt, time.Minute)
+
+	_, err = runTokenCmd(t, "create", "release", "--scope", "read:*", "--service-account", "release-bot")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(90*24*time.Hour), got.ExpiresAt, time.Minute, "tokens expire in 90 days by default")
+	assert.Equal(t, "release-bot", got.ServiceAccount)
+
+	_, err = runTokenCmd(t, "create", "forever", "--scope", "read:*", "--expires-in", "0")
+	require.ErrorContains(t, err, "--expires-in")
+
+	_, err = runTokenCmd(t, "create", "ci")
+	require.ErrorContains(t, err, "--scope")
+	_, err = runTokenCmd(t, "create", "ci", "--scope", "team-a/*")
+	require.ErrorContains(t, err, "ACTION:RESOURCE")
+}
+
+func TestTokenListAndRevoke(t *testing.T) {
+	var revoked, query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v0/tokens":
+			query = r.URL.RawQuery
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"tokens":[
+				{"id":"abc123","name":"ci","owner":"alice","subject":"alice","scopes":[{"action":"read","resource":"*"},{"action":"publish","resource":"team-a/*"}],"expiresAt":"2099-01-01T00:00:00Z","createdAt":"2026-01-01T00:00:00Z"},
+				{"id":"def456","name":"old","owner":"alice","subject":"alice","scopes":[{"action":"read","resource":"*"}],"expiresAt":"2099-01-01T00:00:00Z","revokedAt":"2026-02-01T00:00:00Z","createdAt":"2026-01-01T00:00:00Z"}]}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v0/tokens/abc123":
+			revoked = "abc123"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	setupClientForServer(t, srv)
+
+	out, err := runTokenCmd(t, "list", "--all")
+	require.NoError(t, err)
+	assert.Equal(t, "all=true", query)
+	assert.Contains(t, out, "read:*,publish:team-a/*")
+	assert.Contains(t, out, "2099-01-01T00:00:00Z")
+	assert.Contains(t, out, "revoked")
+
+	out, err = runTokenCmd(t, "revoke", "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", revoked)
+	assert.Equal(t, "token abc123 revoked\n", out)
+
+	_, err = runTokenCmd(t, "revoke", "missing")
+	require.Error(t, err)
+}
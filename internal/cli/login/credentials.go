@@ -0,0 +1,175 @@
+// Package login implements `arctl login` and `arctl logout`, and the
+// AuthProvider that hands the stored, transparently refreshed session to
+// every other command.
+package login
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+)
+
+// CredentialsFileEnv overrides where credentials are stored.
+const CredentialsFileEnv = "ARCTL_CREDENTIALS_FILE"
+
+// Credential is the session `arctl login` stored for one registry.
+type Credential struct {
+	Issuer   string `json:"issuer"`
+	ClientID string `json:"clientId"`
+	// TokenURL is the issuer's token endpoint, kept so refreshing needs no
+	// discovery round trip.
+	TokenURL     string    `json:"tokenUrl"`
+	IDToken      string    `json:"idToken,omitempty"`
+	AccessToken  string    `json:"accessToken,omitempty"`
+	RefreshToken string    `json:"refreshToken,omitempty"`
+	Expiry       time.Time `json:"expiry,omitzero"`
+}
+
+// Bearer is the token sent to the registry: the ID token, whose audience
+// is the CLI client the registry accepts, or the access token when the
+// issuer returned none.
+func (c Credential) Bearer() string {
+	if c.IDToken != "" {
+		return c.IDToken
+	}
+	return c.AccessToken
+}
+
+// credentialsFile is the on-disk layout, keyed by RegistryKey.
+type credentialsFile struct {
+	Registries map[string]Credential `json:"registries"`
+}
+
+// Store keeps one Credential per registry in a JSON file readable only by
+// the user.
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewStore returns a Store backed by path. An empty path, from a system
+// without a config directory, stores nothing.
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// DefaultStorePath is $ARCTL_CREDENTIALS_FILE, or credentials.json in the
+// user's arctl config directory. It is empty when neither is available.
+func DefaultStorePath(env cliruntime.Env) string {
+	if path := env.Getenv(CredentialsFileEnv); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "arctl", "credentials.json")
+}
+
+// RegistryKey normalizes a registry base URL the way the API client does,
+// so flag, env and default spellings of one registry share credentials.
+func RegistryKey(baseURL string) string {
+	return client.NewClient(baseURL, "").BaseURL
+}
+
+// Get returns the credential stored for registry.
+func (s *Store) Get(registry string) (Credential, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := s.read()
+	if err != nil {
+		return Credential{}, false, err
+	}
+	cred, ok := file.Registries[RegistryKey(registry)]
+	return cred, ok, nil
+}
+
+// Put stores cred for registry, replacing any earlier one.
+func (s *Store) Put(registry string, cred Credential) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.path == "" {
+		return fmt.Errorf("no config directory to store credentials in; set %s", CredentialsFileEnv)
+	}
+	file, err := s.read()
+	if err != nil {
+		return err
+	}
+	file.Registries[RegistryKey(registry)] = cred
+	return s.write(file)
+}
+
+// Delete removes the credential stored for registry and reports whether
+// there was one.
+func (s *Store) Delete(registry string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := s.read()
+	if err != nil {
+		return false, err
+	}
+	key := RegistryKey(registry)
+	if _, ok := file.Registries[key]; !ok {
+		return false, nil
+	}
+	delete(file.Registries, key)
+	return true, s.write(file)
+}
+
+func (s *Store) read() (credentialsFile, error) {
+	file := credentialsFile{Registries: map[string]Credential{}}
+	if s.path == "" {
+		return file, nil
+	}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return file, nil
+	}
+	if err != nil {
+		return file, fmt.Errorf("reading credentials: %w", err)
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return file, fmt.Errorf("parsing credentials %s: %w", s.path, err)
+	}
+	if file.Registries == nil {
+		file.Registries = map[string]Credential{}
+	}
+	return file, nil
+}
+
+// write replaces the file atomically, so a concurrent arctl never reads
+// half a file.
+func (s *Store) write(file credentialsFile) error {
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("creating credentials directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".credentials-*")
+	if err != nil {
+		return fmt.Errorf("writing credentials: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing credentials: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing credentials: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("writing credentials: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package login
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "arctl", "credentials.json")
+	store := NewStore(path)
+
+	require.NoError(t, store.Put("https://a.example.com", Credential{AccessToken: "a"}))
+	require.NoError(t, store.Put("https://b.example.com/v0/", Credential{AccessToken: "b"}))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "credentials are readable only by the user")
+
+	a, ok, err := store.Get("https://a.example.com/v0")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "a", a.Bearer())
+	b, _, _ := NewStore(path).Get("https://b.example.com")
+	assert.Equal(t, "b", b.Bearer(), "credentials persist")
+
+	removed, err := store.Delete("https://a.example.com")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	_, ok, _ = store.Get("https://a.example.com")
+	assert.False(t, ok)
+
+	_, ok, err = NewStore("").Get("https://a.example.com")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	require.Error(t, NewStore("").Put("https://a.example.com", Credential{}))
+}
+
+func TestDefaultStorePath(t *testing.T) {
+	env := mapEnv{CredentialsFileEnv: "/tmp/creds.json"}
+	assert.Equal(t, "/tmp/creds.json", DefaultStorePath(env))
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir, err := os.UserConfigDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "arctl", "credentials.json"), DefaultStorePath(mapEnv{}))
+}
+
+type mapEnv map[string]string
+
+func (e mapEnv) Getenv(key string) string { return e[key] }
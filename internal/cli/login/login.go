@@ -0,0 +1,316 @@
+package login
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/oauth2"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+)
+
+// loginTimeout bounds how long login waits for the user to finish
+// signing in.
+const loginTimeout = 5 * time.Minute
+
+// Sign-in flows --flow accepts.
+const (
+	flowAuto    = "auto"
+	flowDevice  = "device"
+	flowBrowser = "browser"
+)
+
+var errRegistryRuntimeNotConfigured = errors.New("registry runtime not configured")
+
+// openBrowser opens url in the user's browser. Tests replace it.
+var openBrowser = func(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
+
+// NewLoginCmd returns the "login" command, which signs in to the
+// registry's OIDC issuer and stores the session in store.
+func NewLoginCmd(deps cliruntime.Deps, store *Store) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   cliruntime.CommandLogin,
+		Short: "Sign in to the registry",
+		Long: `Sign in to the registry through the OpenID Connect issuer it advertises,
+and store the session for later commands. Sessions are kept per registry
+and refreshed automatically while the issuer allows.
+
+The device flow prints a code to enter in a browser on any machine. The
+browser flow opens a browser on this machine and receives the result on a
+loopback address. --flow auto uses the device flow when the issuer
+supports it.
+
+--registry-token and ARCTL_API_TOKEN take precedence over a stored
+session.`,
+		Example: `  arctl login
+  arctl login --registry-url https://registry.example.com --flow browser`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flow, _ := cmd.Flags().GetString("flow")
+			switch flow {
+			case flowAuto, flowDevice, flowBrowser:
+			default:
+				return fmt.Errorf("--flow must be %s, %s or %s, got %q", flowAuto, flowDevice, flowBrowser, flow)
+			}
+			if deps.Runtime == nil {
+				return errRegistryRuntimeNotConfigured
+			}
+			registry := deps.Runtime.RegistryTarget().BaseURL
+			ctx, cancel := context.WithTimeout(cmd.Context(), loginTimeout)
+			defer cancel()
+
+			authConfig, err := client.NewClient(registry, "").GetAuthConfig(ctx)
+			if err != nil {
+				return fmt.Errorf("reading sign-in configuration from %s: %w", RegistryKey(registry), err)
+			}
+			if authConfig.OIDC == nil {
+				return fmt.Errorf("registry %s does not offer interactive sign-in; use --registry-token or an API token", RegistryKey(registry))
+			}
+			l := &loginer{
+				httpClient: &http.Client{Timeout: 30 * time.Second},
+				out:        cmd.ErrOrStderr(),
+			}
+			cred, err := l.login(ctx, authConfig.OIDC.Issuer, authConfig.OIDC.ClientID, authConfig.OIDC.Scopes, flow)
+			if err != nil {
+				return err
+			}
+			if err := store.Put(registry, cred); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Logged in to %s%s\n", RegistryKey(registry), identityOf(cred))
+			return nil
+		},
+	}
+	cmd.Flags().String("flow", flowAuto, "Sign-in flow: auto, device or browser")
+	return cmd
+}
+
+// NewLogoutCmd returns the "logout" command, which forgets the session
+// stored for the registry.
+func NewLogoutCmd(deps cliruntime.Deps, store *Store) *cobra.Command {
+	return &cobra.Command{
+		Use:   cliruntime.CommandLogout,
+		Short: "Forget the stored registry session",
+		Long: `Forget the session "arctl login" stored for the registry. The session
+stays valid at the issuer until it expires; sign out there to end it
+everywhere.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.Runtime == nil {
+				return errRegistryRuntimeNotConfigured
+			}
+			registry := RegistryKey(deps.Runtime.RegistryTarget().BaseURL)
+			removed, err := store.Delete(registry)
+			if err != nil {
+				return err
+			}
+			if !removed {
+				fmt.Fprintf(cmd.OutOrStdout(), "Not logged in to %s\n", registry)
+				return nil
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Logged out of %s\n", registry)
+			return nil
+		},
+	}
+}
+
+// identityOf names the signed-in user for the login message, from the
+// ID token's email or subject.
+func identityOf(cred Credential) string {
+	claims := idTokenClaims(cred.IDToken)
+	for _, claim := range []string{"email", "preferred_username", "sub"} {
+		if value, _ := claims[claim].(string); value != "" {
+			return " as " + value
+		}
+	}
+	return ""
+}
+
+// issuerMetadata is the part of the OpenID Connect discovery document
+// login needs.
+type issuerMetadata struct {
+	Issuer                      string `json:"issuer"`
+	AuthorizationEndpoint       string `json:"authorization_endpoint"`
+	TokenEndpoint               string `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+}
+
+type loginer struct {
+	httpClient *http.Client
+	out        io.Writer
+}
+
+func (l *loginer) login(ctx context.Context, issuer, clientID string, scopes []string, flow string) (Credential, error) {
+	meta, err := l.discover(ctx, issuer)
+	if err != nil {
+		return Credential{}, err
+	}
+	if flow == flowAuto {
+		flow = flowBrowser
+		if meta.DeviceAuthorizationEndpoint != "" {
+			flow = flowDevice
+		}
+	}
+	cfg := oauthConfig(clientID, oauth2.Endpoint{
+		AuthURL:       meta.AuthorizationEndpoint,
+		DeviceAuthURL: meta.DeviceAuthorizationEndpoint,
+		TokenURL:      meta.TokenEndpoint,
+	}, scopes)
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, l.httpClient)
+
+	var token *oauth2.Token
+	switch flow {
+	case flowDevice:
+		if meta.DeviceAuthorizationEndpoint == "" {
+			return Credential{}, fmt.Errorf("issuer %s does not support the device flow; use --flow browser", issuer)
+		}
+		token, err = l.deviceFlow(ctx, cfg)
+	default:
+		if meta.AuthorizationEndpoint == "" {
+			return Credential{}, fmt.Errorf("issuer %s has no authorization endpoint; use --flow device", issuer)
+		}
+		token, err = l.browserFlow(ctx, cfg)
+	}
+	if err != nil {
+		return Credential{}, err
+	}
+	return withToken(Credential{Issuer: issuer, ClientID: clientID, TokenURL: meta.TokenEndpoint}, token), nil
+}
+
+func (l *loginer) discover(ctx context.Context, issuer string) (issuerMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return issuerMetadata{}, err
+	}
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return issuerMetadata{}, fmt.Errorf("discovering issuer %s: %w", issuer, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return issuerMetadata{}, fmt.Errorf("discovering issuer %s: %s", issuer, resp.Status)
+	}
+	var meta issuerMetadata
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&meta); err != nil {
+		return issuerMetadata{}, fmt.Errorf("discovering issuer %s: %w", issuer, err)
+	}
+	if meta.Issuer != issuer {
+		return issuerMetadata{}, fmt.Errorf("discovering issuer %s: metadata names issuer %q", issuer, meta.Issuer)
+	}
+	if meta.TokenEndpoint == "" {
+		return issuerMetadata{}, fmt.Errorf("discovering issuer %s: no token endpoint", issuer)
+	}
+	return meta, nil
+}
+
+// deviceFlow runs the OAuth 2.0 device authorization grant (RFC 8628).
+func (l *loginer) deviceFlow(ctx context.Context, cfg *oauth2.Config) (*oauth2.Token, error) {
+	auth, err := cfg.DeviceAuth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("starting device sign-in: %w", err)
+	}
+	if auth.VerificationURIComplete != "" {
+		fmt.Fprintf(l.out, "To sign in, open %s\nand confirm the code %s\n", auth.VerificationURIComplete, auth.UserCode)
+	} else {
+		fmt.Fprintf(l.out, "To sign in, open %s\nand enter the code %s\n", auth.VerificationURI, auth.UserCode)
+	}
+	token, err := cfg.DeviceAccessToken(ctx, auth)
+	if err != nil {
+		return nil, fmt.Errorf("device sign-in: %w", err)
+	}
+	return token, nil
+}
+
+// browserFlow runs the authorization code grant with PKCE, receiving the
+// code on a loopback redirect (RFC 8252).
+func (l *loginer) browserFlow(ctx context.Context, cfg *oauth2.Config) (*oauth2.Token, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("listening for the sign-in redirect: %w", err)
+	}
+	cfg.RedirectURL = fmt.Sprintf("http://%s/callback", listener.Addr())
+	state := rand.Text()
+	verifier := oauth2.GenerateVerifier()
+
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+	server := &http.Server{
+		ReadHeaderTimeout: 10 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/callback" {
+				http.NotFound(w, r)
+				return
+			}
+			q := r.URL.Query()
+			var res result
+			switch {
+			case q.Get("state") != state:
+				res.err = errors.New("sign-in redirect carried the wrong state")
+			case q.Get("error") != "":
+				res.err = fmt.Errorf("sign-in failed: %s %s", q.Get("error"), q.Get("error_description"))
+			case q.Get("code") == "":
+				res.err = errors.New("sign-in redirect carried no code")
+			default:
+				res.code = q.Get("code")
+			}
+			if res.err != nil {
+				http.Error(w, res.err.Error(), http.StatusBadRequest)
+			} else {
+				_, _ = io.WriteString(w, "Signed in to arctl. You can close this window.\n")
+			}
+			select {
+			case results <- res:
+			default:
+			}
+		}),
+	}
+	go func() { _ = server.Serve(listener) }()
+	defer func() { _ = server.Close() }()
+
+	authURL := cfg.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier))
+	fmt.Fprintf(l.out, "Opening a browser to sign in. If it does not open, visit:\n%s\n", authURL)
+	_ = openBrowser(authURL)
+
+	var res result
+	select {
+	case res = <-results:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for browser sign-in: %w", ctx.Err())
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+	token, err := cfg.Exchange(ctx, res.code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("completing browser sign-in: %w", err)
+	}
+	return token, nil
+}
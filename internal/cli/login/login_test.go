@@ -0,0 +1,235 @@
+package login
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
+)
+
+// fakeIssuer is an OpenID Connect issuer supporting the device, PKCE
+// authorization code and refresh token grants for the public client
+// "arctl".
+type fakeIssuer struct {
+	*httptest.Server
+	noDevice bool
+
+	mu        sync.Mutex
+	challenge string
+	grants    []string
+	refreshes int
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	t.Helper()
+	f := &fakeIssuer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		meta := map[string]string{
+			"issuer":                 f.URL,
+			"authorization_endpoint": f.URL + "/auth",
+			"token_endpoint":         f.URL + "/token",
+		}
+		if !f.noDevice {
+			meta["device_authorization_endpoint"] = f.URL + "/device"
+		}
+		_ = json.NewEncoder(w).Encode(meta)
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"device_code":      "device-123",
+			"user_code":        "ABCD-EFGH",
+			"verification_uri": f.URL + "/activate",
+			"expires_in":       60,
+			"interval":         1,
+		})
+	})
+	// The authorization endpoint signs the user in at once and redirects
+	// back with a code.
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		f.mu.Lock()
+		f.challenge = q.Get("code_challenge")
+		f.mu.Unlock()
+		redirect, _ := url.Parse(q.Get("redirect_uri"))
+		redirect.RawQuery = url.Values{"code": {"code-123"}, "state": {q.Get("state")}}.Encode()
+		http.Redirect(w, r, redirect.String(), http.StatusFound)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		grant := r.PostForm.Get("grant_type")
+		f.grants = append(f.grants, grant)
+		if r.PostForm.Get("client_id") != "arctl" {
+			http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+			return
+		}
+		switch grant {
+		case "urn:ietf:params:oauth:grant-type:device_code":
+			if r.PostForm.Get("device_code") != "device-123" {
+				http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+				return
+			}
+		case "authorization_code":
+			sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+			if r.PostForm.Get("code") != "code-123" || base64.RawURLEncoding.EncodeToString(sum[:]) != f.challenge {
+				http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+				return
+			}
+		case "refresh_token":
+			if r.PostForm.Get("refresh_token") != "refresh-1" {
+				http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+				return
+			}
+			f.refreshes++
+		default:
+			http.Error(w, `{"error":"unsupported_grant_type"}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "access",
+			"token_type":    "Bearer",
+			"refresh_token": "refresh-1",
+			"expires_in":    3600,
+			"id_token":      f.idToken(t, time.Now().Add(time.Hour)),
+		})
+	})
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeIssuer) idToken(t *testing.T, exp time.Time) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss":   f.URL,
+		"aud":   "arctl",
+		"sub":   "alice",
+		"email": "alice@example.com",
+		"exp":   exp.Unix(),
+	}).SignedString([]byte("test"))
+	require.NoError(t, err)
+	return token
+}
+
+// newFakeRegistry serves the sign-in configuration naming issuer, or none
+// when issuer is nil.
+func newFakeRegistry(t *testing.T, issuer *fakeIssuer) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v0/auth/config" {
+			http.NotFound(w, r)
+			return
+		}
+		var body arv0.AuthConfig
+		if issuer != nil {
+			body.OIDC = &arv0.OIDCLoginConfig{Issuer: issuer.URL, ClientID: "arctl", Scopes: []string{"openid", "offline_access"}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func runLoginCmd(t *testing.T, registry string, store *Store, args ...string) (string, error) {
+	t.Helper()
+	deps := cliruntime.Deps{Runtime: cliruntime.New(cliruntime.Config{RegistryURL: &registry})}
+	root := &cobra.Command{Use: "arctl", SilenceErrors: true}
+	root.AddCommand(NewLoginCmd(deps, store), NewLogoutCmd(deps, store))
+	out := &bytes.Buffer{}
+	root.SetOut(out)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs(args)
+	err := root.Execute()
+	return out.String(), err
+}
+
+func TestLogin_DeviceFlow(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	registry := newFakeRegistry(t, issuer)
+	store := NewStore(filepath.Join(t.TempDir(), "credentials.json"))
+
+	out, err := runLoginCmd(t, registry.URL, store, "login")
+	require.NoError(t, err)
+	assert.Equal(t, "Logged in to "+registry.URL+"/v0 as alice@example.com\n", out)
+	assert.Equal(t, []string{"urn:ietf:params:oauth:grant-type:device_code"}, issuer.grants)
+
+	cred, ok, err := store.Get(registry.URL)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, issuer.URL, cred.Issuer)
+	assert.Equal(t, issuer.URL+"/token", cred.TokenURL)
+	assert.Equal(t, "refresh-1", cred.RefreshToken)
+	assert.Equal(t, cred.IDToken, cred.Bearer())
+	assert.WithinDuration(t, time.Now().Add(time.Hour), cred.Expiry, time.Minute)
+
+	out, err = runLoginCmd(t, registry.URL, store, "logout")
+	require.NoError(t, err)
+	assert.Equal(t, "Logged out of "+registry.URL+"/v0\n", out)
+	_, ok, _ = store.Get(registry.URL)
+	assert.False(t, ok)
+	out, err = runLoginCmd(t, registry.URL, store, "logout")
+	require.NoError(t, err)
+	assert.Equal(t, "Not logged in to "+registry.URL+"/v0\n", out)
+}
+
+func TestLogin_BrowserFlow(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	issuer.noDevice = true
+	registry := newFakeRegistry(t, issuer)
+	store := NewStore(filepath.Join(t.TempDir(), "credentials.json"))
+
+	// The "browser" follows the authorization URL, which redirects to the
+	// loopback callback.
+	opened := make(chan error, 1)
+	restore := openBrowser
+	openBrowser = func(authURL string) error {
+		go func() {
+			resp, err := http.Get(authURL)
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+			opened <- err
+		}()
+		return nil
+	}
+	t.Cleanup(func() { openBrowser = restore })
+
+	_, err := runLoginCmd(t, registry.URL, store, "login")
+	require.NoError(t, err)
+	require.NoError(t, <-opened)
+	assert.Equal(t, []string{"authorization_code"}, issuer.grants, "auto falls back to the browser flow without a device endpoint")
+	_, ok, _ := store.Get(registry.URL)
+	assert.True(t, ok)
+
+	_, err = runLoginCmd(t, registry.URL, store, "login", "--flow", "device")
+	require.ErrorContains(t, err, "does not support the device flow")
+}
+
+func TestLogin_Errors(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "credentials.json"))
+	registry := newFakeRegistry(t, nil)
+
+	_, err := runLoginCmd(t, registry.URL, store, "login")
+	require.ErrorContains(t, err, "does not offer interactive sign-in")
+	_, err = runLoginCmd(t, registry.URL, store, "login", "--flow", "password")
+	require.ErrorContains(t, err, "--flow")
+}
@@ -0,0 +1,125 @@
+package login
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+// expiryLeeway refreshes a session this long before it expires, so a
+// token does not lapse between being read and reaching the registry.
+const expiryLeeway = 30 * time.Second
+
+// TokenProvider is the cliruntime.AuthProvider for sessions stored by
+// `arctl login`. It refreshes an expiring session with its refresh token
+// and stores the result.
+type TokenProvider struct {
+	store       *Store
+	registryURL func() string
+	httpClient  *http.Client
+	now         func() time.Time
+}
+
+var _ types.CLITokenProvider = (*TokenProvider)(nil)
+
+// NewTokenProvider returns a TokenProvider reading store. registryURL
+// resolves the registry a command targets; it is called per Token, after
+// flags are parsed.
+func NewTokenProvider(store *Store, registryURL func() string) *TokenProvider {
+	return &TokenProvider{
+		store:       store,
+		registryURL: registryURL,
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		now:         time.Now,
+	}
+}
+
+// Token returns the stored session's bearer token, refreshing it first
+// when it is about to expire. It returns types.ErrCLINoStoredToken when
+// nobody is logged in to the registry.
+func (p *TokenProvider) Token(ctx context.Context) (string, error) {
+	registry := p.registryURL()
+	cred, ok, err := p.store.Get(registry)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", types.ErrCLINoStoredToken
+	}
+	if cred.Expiry.IsZero() || p.now().Add(expiryLeeway).Before(cred.Expiry) {
+		return cred.Bearer(), nil
+	}
+	if cred.RefreshToken == "" {
+		return "", fmt.Errorf("session for %s expired; run arctl login", RegistryKey(registry))
+	}
+
+	cfg := oauthConfig(cred.ClientID, oauth2.Endpoint{TokenURL: cred.TokenURL}, nil)
+	token, err := cfg.TokenSource(p.oauthContext(ctx), &oauth2.Token{RefreshToken: cred.RefreshToken}).Token()
+	if err != nil {
+		return "", fmt.Errorf("refreshing session for %s; run arctl login: %w", RegistryKey(registry), err)
+	}
+	cred = withToken(cred, token)
+	if err := p.store.Put(registry, cred); err != nil {
+		return "", err
+	}
+	return cred.Bearer(), nil
+}
+
+func (p *TokenProvider) oauthContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+}
+
+// oauthConfig is the public-client configuration arctl uses against an
+// issuer: no client secret, so the client ID travels in the request body.
+func oauthConfig(clientID string, endpoint oauth2.Endpoint, scopes []string) *oauth2.Config {
+	endpoint.AuthStyle = oauth2.AuthStyleInParams
+	return &oauth2.Config{ClientID: clientID, Endpoint: endpoint, Scopes: scopes}
+}
+
+// withToken updates cred with a token response. A refresh that returns no
+// ID token leaves the access token as the bearer, since the old ID token
+// has expired.
+func withToken(cred Credential, token *oauth2.Token) Credential {
+	cred.AccessToken = token.AccessToken
+	if token.RefreshToken != "" {
+		cred.RefreshToken = token.RefreshToken
+	}
+	cred.IDToken, _ = token.Extra("id_token").(string)
+	cred.Expiry = token.Expiry
+	if exp := idTokenExpiry(cred.IDToken); !exp.IsZero() && (cred.Expiry.IsZero() || exp.Before(cred.Expiry)) {
+		cred.Expiry = exp
+	}
+	return cred
+}
+
+// idTokenExpiry reads the exp claim of an ID token. The signature is not
+// checked: the token came straight from the issuer, and the registry
+// verifies it on every request.
+func idTokenExpiry(idToken string) time.Time {
+	claims := idTokenClaims(idToken)
+	if claims == nil {
+		return time.Time{}
+	}
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return time.Time{}
+	}
+	return exp.Time
+}
+
+func idTokenClaims(idToken string) jwt.MapClaims {
+	if idToken == "" {
+		return nil
+	}
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(idToken, claims); err != nil {
+		return nil
+	}
+	return claims
+}
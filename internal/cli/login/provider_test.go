@@ -0,0 +1,47 @@
+package login
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+func TestTokenProvider(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	store := NewStore(filepath.Join(t.TempDir(), "credentials.json"))
+	registry := "http://localhost:12121"
+	provider := NewTokenProvider(store, func() string { return registry })
+
+	_, err := provider.Token(t.Context())
+	require.ErrorIs(t, err, types.ErrCLINoStoredToken)
+
+	now := time.Now()
+	fresh := Credential{Issuer: issuer.URL, ClientID: "arctl", TokenURL: issuer.URL + "/token", IDToken: "id-1", RefreshToken: "refresh-1", Expiry: now.Add(time.Hour)}
+	require.NoError(t, store.Put("http://localhost:12121/v0/", fresh))
+	token, err := provider.Token(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "id-1", token, "flag, env and default spellings share one session")
+	assert.Zero(t, issuer.refreshes)
+
+	provider.now = func() time.Time { return now.Add(time.Hour - 10*time.Second) }
+	token, err = provider.Token(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, issuer.refreshes, "a session about to expire is refreshed")
+	refreshed, _, err := store.Get(registry)
+	require.NoError(t, err)
+	assert.Equal(t, refreshed.IDToken, token)
+	assert.NotEqual(t, "id-1", token)
+
+	expired := fresh
+	expired.RefreshToken = ""
+	expired.Expiry = now.Add(-time.Minute)
+	require.NoError(t, store.Put(registry, expired))
+	provider.now = time.Now
+	_, err = provider.Token(t.Context())
+	require.ErrorContains(t, err, "run arctl login")
+}
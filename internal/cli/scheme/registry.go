@@ -17,6 +17,7 @@ import (
 	"fmt"
 
 	"github.com/agentregistry-dev/agentregistry/internal/client"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 )
 
 type Column struct {
@@ -39,15 +40,26 @@ type ListOpts struct {
 	// Deployment ListFunc translates these to the server filter; only the
 	// Deployment kind honors this — other kinds ignore it.
 	Origin string
+	// LabelSelector and FieldSelector are forwarded verbatim as the
+	// server's labels / fieldSelector query parameters.
+	LabelSelector string
+	FieldSelector string
 }
 
 type ListFunc func(context.Context, *client.Client, ListOpts) ([]any, error)
+
+// WatchFunc streams changes to the list ListFunc returns for the same
+// opts: handle sees every current item as ADDED, then a BOOKMARK with a
+// nil item, then each change as it happens. Items are the ListFunc item
+// type.
+type WatchFunc func(ctx context.Context, c *client.Client, opts ListOpts, handle func(eventType string, item any) error) error
 type RowFunc func(any) []string
 type ToYAMLFunc func(any) any
 type GetFunc func(ctx context.Context, c *client.Client, name, tag string) (any, error)
 
-// DeleteFunc deletes a single (name, tag) of the kind.
-type DeleteFunc func(ctx context.Context, c *client.Client, name, tag string) error
+// DeleteFunc deletes a single (name, tag) of the kind. opts carries the
+// --force / --cascade flags through to the server.
+type DeleteFunc func(ctx context.Context, c *client.Client, name, tag string, opts client.DeleteOpts) error
 
 // ListTagsFunc returns every live tag row for a single (name).
 // Set only on taggable artifact kinds (Agent, MCPServer, Skill, etc.).
@@ -58,19 +70,46 @@ type ListTagsFunc func(ctx context.Context, c *client.Client, name string) ([]an
 // DeleteAllTagsFunc soft-deletes every live tag of a single (name) in one
 // server round-trip. Set only on taggable artifact kinds. Nil for kinds whose
 // identity is not tagged.
-type DeleteAllTagsFunc func(ctx context.Context, c *client.Client, name string) error
+type DeleteAllTagsFunc func(ctx context.Context, c *client.Client, name string, opts client.DeleteOpts) error
+
+// PromoteTagFunc points the alias to of a single (name) at the concrete tag
+// from resolves to. Set only on taggable artifact kinds.
+type PromoteTagFunc func(ctx context.Context, c *client.Client, name, from, to string) (arv0.PromoteTagResponse, error)
+
+// ListTagRevisionsFunc returns every revision of one (name, tag), newest
+// first. Set only on taggable artifact kinds.
+type ListTagRevisionsFunc func(ctx context.Context, c *client.Client, name, tag string) ([]arv0.TagRevision, error)
+
+// RollbackTagFunc restores generation as the current content of one
+// (name, tag). Set only on taggable artifact kinds.
+type RollbackTagFunc func(ctx context.Context, c *client.Client, name, tag string, generation int64) (arv0.RollbackTagResponse, error)
+
+// SetTagLifecycleFunc deprecates, yanks or reactivates one (name, tag).
+// Set only on taggable artifact kinds.
+type SetTagLifecycleFunc func(ctx context.Context, c *client.Client, name, tag string, lifecycle arv0.SetTagLifecycleRequest) (arv0.TagLifecycleResponse, error)
+
+// ListReferrersFunc returns the objects referencing one (name, tag). Tag is
+// ignored by mutable kinds.
+type ListReferrersFunc func(ctx context.Context, c *client.Client, name, tag string) (arv0.ReferrerListResponse, error)
 
 type Kind struct {
 	Kind          string
 	Plural        string
 	Aliases       []string
 	ListFunc      ListFunc
+	Watch         WatchFunc
 	RowFunc       RowFunc
 	ToYAMLFunc    ToYAMLFunc
 	Get           GetFunc
 	Delete        DeleteFunc
 	ListTags      ListTagsFunc
 	DeleteAllTags DeleteAllTagsFunc
+	PromoteTag    PromoteTagFunc
+
+	ListTagRevisions ListTagRevisionsFunc
+	RollbackTag      RollbackTagFunc
+	SetTagLifecycle  SetTagLifecycleFunc
+	ListReferrers    ListReferrersFunc
 
 	TableColumns []Column
 }
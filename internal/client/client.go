@@ -174,6 +174,21 @@ func (c *Client) GetVersion() (*VersionBody, error) {
 	return &resp, nil
 }
 
+// GetAuthConfig returns how the server lets clients sign in. It needs no
+// credentials.
+func (c *Client) GetAuthConfig(ctx context.Context) (arv0.AuthConfig, error) {
+	req, err := c.newRequest(http.MethodGet, "/auth/config")
+	if err != nil {
+		return arv0.AuthConfig{}, err
+	}
+	req = req.WithContext(ctx)
+	var out arv0.AuthConfig
+	if err := c.doJSON(req, &out); err != nil {
+		return arv0.AuthConfig{}, err
+	}
+	return out, nil
+}
+
 // =============================================================================
 // Generic resource methods — v1alpha1
 // =============================================================================
@@ -184,8 +199,11 @@ func (c *Client) GetVersion() (*VersionBody, error) {
 type ListOpts struct {
 	Namespace string
 	Labels    string
-	Limit     int
-	Cursor    string
+	// FieldSelector filters on metadata, spec and status fields, e.g.
+	// "spec.runtimeRef.name=prod,status.conditions[Ready]=False".
+	FieldSelector string
+	Limit         int
+	Cursor        string
 	// Origin, when set, forwards the Deployment origin filter
 	// ("managed" or "discovered"). Empty leaves the server default intact.
 	Origin string
@@ -270,38 +288,321 @@ func (c *Client) ListTags(ctx context.Context, kind, namespace, name string) ([]
 	return resp.Items, nil
 }
 
-// List returns rows of kind, paginated. opts.Namespace="" (empty) lists
-// the default namespace; opts.Namespace="all" widens to every
-// namespace. The returned string is the nextCursor; empty means no
-// more pages.
-func (c *Client) List(ctx context.Context, kind string, opts ListOpts) ([]v1alpha1.RawObject, string, error) {
-	base := "/" + v1alpha1.PluralFor(kind)
+// PromoteTag points the alias to at the concrete tag from resolves to
+// (from may itself be an alias), creating or moving the alias. Returns the
+// alias after the move; Status is created, configured or unchanged.
+func (c *Client) PromoteTag(ctx context.Context, kind, namespace, name, from, to string) (arv0.PromoteTagResponse, error) {
+	path := fmt.Sprintf("/%s/%s/promote%s",
+		v1alpha1.PluralFor(kind),
+		url.PathEscape(name),
+		namespaceQuery(namespace))
+	body, err := json.Marshal(arv0.PromoteTagRequest{From: from, To: to})
+	if err != nil {
+		return arv0.PromoteTagResponse{}, err
+	}
+	req, err := c.newRequestWithBody(http.MethodPost, path, bytes.NewReader(body), "application/json")
+	if err != nil {
+		return arv0.PromoteTagResponse{}, err
+	}
+	req = req.WithContext(ctx)
+	var out arv0.PromoteTagResponse
+	if err := c.doJSON(req, &out); err != nil {
+		return arv0.PromoteTagResponse{}, err
+	}
+	return out, nil
+}
+
+// ListTagAliases returns every tag alias of (namespace, name).
+func (c *Client) ListTagAliases(ctx context.Context, kind, namespace, name string) ([]arv0.TagAlias, error) {
+	path := fmt.Sprintf("/%s/%s/aliases%s",
+		v1alpha1.PluralFor(kind),
+		url.PathEscape(name),
+		namespaceQuery(namespace))
+	req, err := c.newRequest(http.MethodGet, path)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var out arv0.TagAliasListResponse
+	if err := c.doJSON(req, &out); err != nil {
+		return nil, err
+	}
+	return out.Items, nil
+}
+
+// ListTagRevisions returns every revision of one tag of (namespace, name),
+// newest first; the first entry is the current content.
+func (c *Client) ListTagRevisions(ctx context.Context, kind, namespace, name, tag string) ([]arv0.TagRevision, error) {
+	path := fmt.Sprintf("/%s/%s/%s/revisions%s",
+		v1alpha1.PluralFor(kind),
+		url.PathEscape(name),
+		url.PathEscape(tag),
+		namespaceQuery(namespace))
+	req, err := c.newRequest(http.MethodGet, path)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var out arv0.TagRevisionListResponse
+	if err := c.doJSON(req, &out); err != nil {
+		return nil, err
+	}
+	return out.Items, nil
+}
+
+// RollbackTag restores the content of generation as the current content of
+// one tag. Status is configured, or unchanged when the revision already
+// matches the current content.
+func (c *Client) RollbackTag(ctx context.Context, kind, namespace, name, tag string, generation int64) (arv0.RollbackTagResponse, error) {
+	path := fmt.Sprintf("/%s/%s/%s/rollback%s",
+		v1alpha1.PluralFor(kind),
+		url.PathEscape(name),
+		url.PathEscape(tag),
+		namespaceQuery(namespace))
+	body, err := json.Marshal(arv0.RollbackTagRequest{Generation: generation})
+	if err != nil {
+		return arv0.RollbackTagResponse{}, err
+	}
+	req, err := c.newRequestWithBody(http.MethodPost, path, bytes.NewReader(body), "application/json")
+	if err != nil {
+		return arv0.RollbackTagResponse{}, err
+	}
+	req = req.WithContext(ctx)
+	var out arv0.RollbackTagResponse
+	if err := c.doJSON(req, &out); err != nil {
+		return arv0.RollbackTagResponse{}, err
+	}
+	return out, nil
+}
+
+// SetTagLifecycle deprecates, yanks or (with state Active) reactivates one
+// tag of (namespace, name).
+func (c *Client) SetTagLifecycle(ctx context.Context, kind, namespace, name, tag string, lifecycle arv0.SetTagLifecycleRequest) (arv0.TagLifecycleResponse, error) {
+	path := fmt.Sprintf("/%s/%s/%s/lifecycle%s",
+		v1alpha1.PluralFor(kind),
+		url.PathEscape(name),
+		url.PathEscape(tag),
+		namespaceQuery(namespace))
+	body, err := json.Marshal(lifecycle)
+	if err != nil {
+		return arv0.TagLifecycleResponse{}, err
+	}
+	req, err := c.newRequestWithBody(http.MethodPut, path, bytes.NewReader(body), "application/json")
+	if err != nil {
+		return arv0.TagLifecycleResponse{}, err
+	}
+	req = req.WithContext(ctx)
+	var out arv0.TagLifecycleResponse
+	if err := c.doJSON(req, &out); err != nil {
+		return arv0.TagLifecycleResponse{}, err
+	}
+	return out, nil
+}
+
+// ListReferrers returns the objects whose spec references (kind, namespace,
+// name, tag). Tag may be an alias or semver range; the response carries the
+// concrete tag it resolved to. Pass an empty tag for mutable kinds.
+func (c *Client) ListReferrers(ctx context.Context, kind, namespace, name, tag string) (arv0.ReferrerListResponse, error) {
+	path := fmt.Sprintf("/%s/%s", v1alpha1.PluralFor(kind), url.PathEscape(name))
+	if tag != "" {
+		path += "/" + url.PathEscape(tag)
+	}
+	path += "/referrers" + namespaceQuery(namespace)
+	req, err := c.newRequest(http.MethodGet, path)
+	if err != nil {
+		return arv0.ReferrerListResponse{}, err
+	}
+	req = req.WithContext(ctx)
+	var out arv0.ReferrerListResponse
+	if err := c.doJSON(req, &out); err != nil {
+		return arv0.ReferrerListResponse{}, err
+	}
+	return out, nil
+}
+
+// SearchOpts narrows Client.Search. Kinds are kind names or plurals;
+// empty searches every kind. An empty Namespace searches every namespace.
+type SearchOpts struct {
+	Kinds     []string
+	Namespace string
+	Limit     int
+}
+
+// Search runs a ranked full-text query across kinds.
+func (c *Client) Search(ctx context.Context, query string, opts SearchOpts) (arv0.SearchResponse, error) {
 	q := url.Values{}
+	q.Set("q", query)
+	if len(opts.Kinds) > 0 {
+		q.Set("kinds", strings.Join(opts.Kinds, ","))
+	}
 	if opts.Namespace != "" {
 		q.Set("namespace", opts.Namespace)
 	}
 	if opts.Limit > 0 {
 		q.Set("limit", fmt.Sprintf("%d", opts.Limit))
 	}
-	if opts.Cursor != "" {
-		q.Set("cursor", opts.Cursor)
+	req, err := c.newRequest(http.MethodGet, "/search?"+q.Encode())
+	if err != nil {
+		return arv0.SearchResponse{}, err
 	}
-	if opts.Labels != "" {
-		q.Set("labels", opts.Labels)
+	req = req.WithContext(ctx)
+	var out arv0.SearchResponse
+	if err := c.doJSON(req, &out); err != nil {
+		return arv0.SearchResponse{}, err
 	}
-	if opts.Origin != "" {
-		q.Set("origin", opts.Origin)
+	return out, nil
+}
+
+// CanI asks the server whether the caller may perform verb on kind.
+// Namespace "" is the default namespace and "all" every namespace; an
+// empty name asks about the kind as a whole.
+func (c *Client) CanI(ctx context.Context, verb, kind, namespace, name string) (arv0.AccessReview, error) {
+	q := url.Values{}
+	q.Set("verb", verb)
+	q.Set("kind", kind)
+	if namespace != "" {
+		q.Set("namespace", namespace)
 	}
-	if opts.Tag != "" {
-		q.Set("tag", opts.Tag)
+	if name != "" {
+		q.Set("name", name)
 	}
-	if opts.LatestOnly {
-		q.Set("latestOnly", "true")
+	req, err := c.newRequest(http.MethodGet, "/auth/can-i?"+q.Encode())
+	if err != nil {
+		return arv0.AccessReview{}, err
 	}
-	if opts.IncludeTerminating {
-		q.Set("includeTerminating", "true")
+	req = req.WithContext(ctx)
+	var out arv0.AccessReview
+	if err := c.doJSON(req, &out); err != nil {
+		return arv0.AccessReview{}, err
 	}
-	if enc := q.Encode(); enc != "" {
+	return out, nil
+}
+
+// CreateToken issues an API token. The secret is only ever returned here.
+func (c *Client) CreateToken(ctx context.Context, in arv0.CreateAPITokenRequest) (arv0.CreateAPITokenResponse, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return arv0.CreateAPITokenResponse{}, err
+	}
+	req, err := c.newRequestWithBody(http.MethodPost, "/tokens", bytes.NewReader(body), "application/json")
+	if err != nil {
+		return arv0.CreateAPITokenResponse{}, err
+	}
+	req = req.WithContext(ctx)
+	var out arv0.CreateAPITokenResponse
+	if err := c.doJSON(req, &out); err != nil {
+		return arv0.CreateAPITokenResponse{}, err
+	}
+	return out, nil
+}
+
+// ListTokens returns the caller's API tokens, or every user's when all is
+// set (registry admins only).
+func (c *Client) ListTokens(ctx context.Context, all bool) ([]arv0.APIToken, error) {
+	path := "/tokens"
+	if all {
+		path += "?all=true"
+	}
+	req, err := c.newRequest(http.MethodGet, path)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var out arv0.APITokenList
+	if err := c.doJSON(req, &out); err != nil {
+		return nil, err
+	}
+	return out.Tokens, nil
+}
+
+// RevokeToken revokes the API token with id. Revoking an already revoked
+// token succeeds.
+func (c *Client) RevokeToken(ctx context.Context, id string) error {
+	req, err := c.newRequest(http.MethodDelete, "/tokens/"+url.PathEscape(id))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	return c.doJSON(req, nil)
+}
+
+// GetSkillArchive downloads the uploaded skill tarball stored under
+// digest.
+func (c *Client) GetSkillArchive(ctx context.Context, digest string) ([]byte, error) {
+	req, err := c.newRequest(http.MethodGet, "/skill-archives/"+url.PathEscape(digest))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		if msg := extractAPIErrorMessage(errBody); msg != "" {
+			return nil, fmt.Errorf("%s: %s", resp.Status, msg)
+		}
+		return nil, fmt.Errorf("unexpected status: %s, %s", resp.Status, string(errBody))
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// ClaimNamespace submits a domain proof, claiming the domain's namespace,
+// and returns the recorded owner.
+func (c *Client) ClaimNamespace(ctx context.Context, in arv0.ClaimNamespaceRequest) (arv0.NamespaceOwner, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return arv0.NamespaceOwner{}, err
+	}
+	req, err := c.newRequestWithBody(http.MethodPost, "/namespace-owners", bytes.NewReader(body), "application/json")
+	if err != nil {
+		return arv0.NamespaceOwner{}, err
+	}
+	req = req.WithContext(ctx)
+	var out arv0.NamespaceOwner
+	if err := c.doJSON(req, &out); err != nil {
+		return arv0.NamespaceOwner{}, err
+	}
+	return out, nil
+}
+
+// ListNamespaceOwners returns the verified owner of every claimed
+// namespace.
+func (c *Client) ListNamespaceOwners(ctx context.Context) ([]arv0.NamespaceOwner, error) {
+	req, err := c.newRequest(http.MethodGet, "/namespace-owners")
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	var out arv0.NamespaceOwnerList
+	if err := c.doJSON(req, &out); err != nil {
+		return nil, err
+	}
+	return out.Owners, nil
+}
+
+// ReleaseNamespace forgets the verified owner of namespace (registry
+// admins only).
+func (c *Client) ReleaseNamespace(ctx context.Context, namespace string) error {
+	req, err := c.newRequest(http.MethodDelete, "/namespace-owners/"+url.PathEscape(namespace))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	return c.doJSON(req, nil)
+}
+
+// List returns rows of kind, paginated. opts.Namespace="" (empty) lists
+// the default namespace; opts.Namespace="all" widens to every
+// namespace. The returned string is the nextCursor; empty means no
+// more pages.
+func (c *Client) List(ctx context.Context, kind string, opts ListOpts) ([]v1alpha1.RawObject, string, error) {
+	base := "/" + v1alpha1.PluralFor(kind)
+	if enc := opts.query().Encode(); enc != "" {
 		base += "?" + enc
 	}
 	req, err := c.newRequest(http.MethodGet, base)
@@ -316,13 +617,74 @@ func (c *Client) List(ctx context.Context, kind string, opts ListOpts) ([]v1alph
 	return resp.Items, resp.NextCursor, nil
 }
 
+// query returns opts as URL query values.
+func (o ListOpts) query() url.Values {
+	q := url.Values{}
+	if o.Namespace != "" {
+		q.Set("namespace", o.Namespace)
+	}
+	if o.Limit > 0 {
+		q.Set("limit", fmt.Sprintf("%d", o.Limit))
+	}
+	if o.Cursor != "" {
+		q.Set("cursor", o.Cursor)
+	}
+	if o.Labels != "" {
+		q.Set("labels", o.Labels)
+	}
+	if o.FieldSelector != "" {
+		q.Set("fieldSelector", o.FieldSelector)
+	}
+	if o.Origin != "" {
+		q.Set("origin", o.Origin)
+	}
+	if o.Tag != "" {
+		q.Set("tag", o.Tag)
+	}
+	if o.LatestOnly {
+		q.Set("latestOnly", "true")
+	}
+	if o.IncludeTerminating {
+		q.Set("includeTerminating", "true")
+	}
+	return q
+}
+
+// DeleteOpts carries the referential-integrity flags of a delete. By
+// default the server refuses to delete a resource other objects still
+// reference; Force deletes it anyway and Cascade deletes the referencing
+// objects first. At most one may be set.
+type DeleteOpts struct {
+	Force   bool
+	Cascade bool
+}
+
+// query returns opts as URL query values.
+func (o DeleteOpts) query() url.Values {
+	q := url.Values{}
+	if o.Force {
+		q.Set("force", "true")
+	}
+	if o.Cascade {
+		q.Set("cascade", "true")
+	}
+	return q
+}
+
 // Delete soft-deletes a row. When tag is empty it uses the name-only
 // mutable-object route; otherwise it deletes the exact tag route. Returns
 // ErrNotFound when the row doesn't exist. See Store.Delete for the
 // soft-delete semantics (the row stays visible with DeletionTimestamp
 // set until the GC pass purges it).
-func (c *Client) Delete(ctx context.Context, kind, namespace, name, tag string) error {
+func (c *Client) Delete(ctx context.Context, kind, namespace, name, tag string, opts DeleteOpts) error {
 	q := namespaceQuery(namespace)
+	if extra := opts.query().Encode(); extra != "" {
+		if q == "" {
+			q = "?" + extra
+		} else {
+			q += "&" + extra
+		}
+	}
 	path := fmt.Sprintf("/%s/%s%s",
 		v1alpha1.PluralFor(kind),
 		url.PathEscape(name),
@@ -355,18 +717,17 @@ type ApplyOpts struct {
 // Returns an error only on request-level failures (network, 4xx from server).
 // Per-resource errors are encoded in the returned results.
 func (c *Client) Apply(ctx context.Context, body []byte, opts ApplyOpts) ([]arv0.ApplyResult, error) {
-	return c.applyBatch(ctx, http.MethodPost, body, opts)
+	return c.applyBatch(ctx, http.MethodPost, body, url.Values{}, opts)
 }
 
 // DeleteViaApply sends a DELETE /v0/apply with a YAML body and returns per-resource results.
 // Mirrors Apply but uses the DELETE HTTP method.
-func (c *Client) DeleteViaApply(ctx context.Context, body []byte) ([]arv0.ApplyResult, error) {
-	return c.applyBatch(ctx, http.MethodDelete, body, ApplyOpts{})
+func (c *Client) DeleteViaApply(ctx context.Context, body []byte, opts DeleteOpts) ([]arv0.ApplyResult, error) {
+	return c.applyBatch(ctx, http.MethodDelete, body, opts.query(), ApplyOpts{})
 }
 
-func (c *Client) applyBatch(ctx context.Context, method string, body []byte, opts ApplyOpts) ([]arv0.ApplyResult, error) {
+func (c *Client) applyBatch(ctx context.Context, method string, body []byte, q url.Values, opts ApplyOpts) ([]arv0.ApplyResult, error) {
 	path := "/apply"
-	q := url.Values{}
 	if opts.DryRun {
 		q.Set("dryRun", "true")
 	}
@@ -31,7 +31,7 @@ func TestClient_V1Alpha1RoundTrip(t *testing.T) {
 
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("test", "v1"))
-	crud.Register(api, "/v0", stores, nil, nil, crud.PerKindHooks{}, nil)
+	crud.Register(api, "/v0", stores, nil, nil, nil, crud.PerKindHooks{}, nil, nil, nil)
 	resource.RegisterApply(api, resource.ApplyConfig{
 		BasePrefix: "/v0",
 		Stores:     stores,
@@ -91,7 +91,7 @@ spec:
 	// Delete → finalizer-free Agent hard-deletes immediately. Both
 	// GetLatest and the exact-tag Get return ErrNotFound; the row is
 	// gone, not soft-deleted.
-	require.NoError(t, c.Delete(ctx, v1alpha1.KindAgent, "default", "acme-planner", "latest"))
+	require.NoError(t, c.Delete(ctx, v1alpha1.KindAgent, "default", "acme-planner", "latest", client.DeleteOpts{}))
 
 	_, err = c.Get(ctx, v1alpha1.KindAgent, "default", "acme-planner", "latest")
 	require.ErrorIs(t, err, client.ErrNotFound)
@@ -143,7 +143,7 @@ func TestClient_V1Alpha1_NotFound(t *testing.T) {
 
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("test", "v1"))
-	crud.Register(api, "/v0", stores, nil, nil, crud.PerKindHooks{}, nil)
+	crud.Register(api, "/v0", stores, nil, nil, nil, crud.PerKindHooks{}, nil, nil, nil)
 
 	ts := httptest.NewServer(mux)
 	defer ts.Close()
@@ -2,8 +2,10 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 )
 
@@ -114,3 +116,34 @@ func ListTagsOfName[T v1alpha1.Object](
 	}
 	return out, nil
 }
+
+// WatchTyped runs Watch and materializes each event's object into a typed
+// envelope. handle receives the zero T for events without an object
+// (BOOKMARK).
+func WatchTyped[T v1alpha1.Object](
+	ctx context.Context,
+	c *Client,
+	kind string,
+	opts ListOpts,
+	resourceVersion int64,
+	newObj func() T,
+	handle func(eventType string, obj T) error,
+) error {
+	if c == nil {
+		return fmt.Errorf("client is nil")
+	}
+	return c.Watch(ctx, kind, opts, resourceVersion, func(event arv0.WatchEvent) error {
+		var obj T
+		if len(event.Object) > 0 {
+			var raw v1alpha1.RawObject
+			if err := json.Unmarshal(event.Object, &raw); err != nil {
+				return fmt.Errorf("decode %s watch event %d: %w", kind, event.ResourceVersion, err)
+			}
+			var err error
+			if obj, err = v1alpha1.EnvelopeFromRaw(newObj, &raw, kind); err != nil {
+				return fmt.Errorf("decode %s watch event %d: %w", kind, event.ResourceVersion, err)
+			}
+		}
+		return handle(event.Type, obj)
+	})
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// ErrWatchExpired is returned by Watch when the server no longer retains
+// the history after the requested resourceVersion (HTTP 410 Gone).
+// Restart the watch without a resourceVersion to list current state.
+var ErrWatchExpired = errors.New("watch resourceVersion expired")
+
+// maxWatchEventSize bounds one SSE event, i.e. one encoded object.
+const maxWatchEventSize = 16 << 20
+
+// Watch streams changes to the list of kind selected by opts until ctx
+// is done, handle returns an error or the stream ends. resourceVersion 0
+// first reports every current object as ADDED, followed by a BOOKMARK;
+// otherwise the stream resumes after that revision. Limit and Cursor are
+// ignored. A stream the server ends with an ERROR event returns an error,
+// wrapping ErrWatchExpired for 410.
+func (c *Client) Watch(ctx context.Context, kind string, opts ListOpts, resourceVersion int64, handle func(arv0.WatchEvent) error) error {
+	q := opts.query()
+	q.Del("limit")
+	q.Del("cursor")
+	q.Set("watch", "true")
+	if resourceVersion > 0 {
+		q.Set("resourceVersion", strconv.FormatInt(resourceVersion, 10))
+	}
+	req, err := c.newRequest(http.MethodGet, "/"+v1alpha1.PluralFor(kind)+"?"+q.Encode())
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "text/event-stream")
+	// The shared client's overall timeout would cut the stream; ctx ends it
+	// instead.
+	resp, err := (&http.Client{Transport: c.httpClient.Transport}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		msg := extractAPIErrorMessage(errBody)
+		if msg == "" {
+			msg = string(errBody)
+		}
+		if resp.StatusCode == http.StatusGone {
+			return fmt.Errorf("%w: %s", ErrWatchExpired, msg)
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			return ErrNotFound
+		}
+		return fmt.Errorf("%s: %s", resp.Status, msg)
+	}
+	err = readWatchEvents(resp.Body, handle)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// readWatchEvents decodes a Server-Sent Events stream of arv0.WatchEvent
+// data payloads. Comments, event names and ids are ignored: the payload
+// repeats the type and resourceVersion.
+func readWatchEvents(body io.Reader, handle func(arv0.WatchEvent) error) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxWatchEventSize)
+	var data bytes.Buffer
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) > 0 {
+			if value, ok := bytes.CutPrefix(line, []byte("data:")); ok {
+				if data.Len() > 0 {
+					data.WriteByte('\n')
+				}
+				data.Write(bytes.TrimPrefix(value, []byte(" ")))
+			}
+			continue
+		}
+		if data.Len() == 0 {
+			continue
+		}
+		var event arv0.WatchEvent
+		if err := json.Unmarshal(data.Bytes(), &event); err != nil {
+			return fmt.Errorf("decode watch event: %w", err)
+		}
+		data.Reset()
+		if event.Type == arv0.WatchEventError {
+			if event.Status == http.StatusGone {
+				return fmt.Errorf("%w: %s", ErrWatchExpired, event.Message)
+			}
+			return fmt.Errorf("watch failed: %d %s", event.Status, event.Message)
+		}
+		if err := handle(event); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read watch stream: %w", err)
+	}
+	return io.ErrUnexpectedEOF
+}
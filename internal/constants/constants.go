@@ -23,6 +23,39 @@ const (
 	// EnvModelName is the model identifier within the provider (e.g. "gpt-4o").
 	EnvModelName = "MODEL_NAME"
 
+	// EnvModelAPIKey is the provider credential resolved from the Model's
+	// auth.secretRef. Adapters deliver it out of band of the plain env map
+	// (a Kubernetes Secret, not an inline value) where the runtime allows.
+	EnvModelAPIKey = "MODEL_API_KEY"
+
+	// EnvModelCACert is the PEM CA bundle resolved from the Model's
+	// endpoint.tls.caCertSecretRef.
+	EnvModelCACert = "MODEL_CA_CERT"
+
+	// EnvModelAuthStrategy is the Model's auth.strategy ("runtime",
+	// "secretRef", "passthrough"); unset when the Model omits auth.
+	EnvModelAuthStrategy = "MODEL_AUTH_STRATEGY"
+
+	// EnvModelBaseURL is the Model's endpoint.baseUrl (Azure resource
+	// endpoint, OpenAI-compatible server, or gateway override).
+	EnvModelBaseURL = "MODEL_BASE_URL"
+
+	// EnvModelRegion is the Model's endpoint.region (Bedrock, Vertex).
+	EnvModelRegion = "MODEL_REGION"
+
+	// EnvModelDeployment is the Azure OpenAI deployment name.
+	EnvModelDeployment = "MODEL_DEPLOYMENT"
+
+	// EnvModelAPIVersion is the Azure OpenAI REST API version.
+	EnvModelAPIVersion = "MODEL_API_VERSION"
+
+	// EnvModelProject is the Google Cloud project for Vertex models.
+	EnvModelProject = "MODEL_PROJECT"
+
+	// EnvModelGatewayURL is the agentgateway LLM route for the agent's Model,
+	// set by runtimes that front the provider with agentgateway.
+	EnvModelGatewayURL = "MODEL_GATEWAY_URL"
+
 	// EnvMCPServersConfig is a JSON-encoded array of resolved MCP server
 	// configurations injected into the agent container at deploy time.
 	EnvMCPServersConfig = "MCP_SERVERS_CONFIG"
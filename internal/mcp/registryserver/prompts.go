@@ -0,0 +1,189 @@
+package registryserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// registryCursorPrefix marks a prompts/list or resources/list cursor as
+// paging through registry rows. Pages without it belong to the SDK's static
+// list (the built-in prompts), which always comes first.
+const registryCursorPrefix = "registry:"
+
+// registryPromptName is the MCP prompt name for a registry Prompt. The
+// namespace is always included so prompts from different namespaces never
+// collide with each other or with the built-in prompts, which have no slash.
+func registryPromptName(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// promptsMiddleware serves the latest tag of every registry Prompt through
+// prompts/list and prompts/get next to the static prompts registered by
+// addServerPrompts. The SDK's prompt table is fixed at registration time, so
+// registry rows are read per request instead of mirrored into it.
+func promptsMiddleware(store *v1alpha1store.Store, authorize Authorizer, listFilter ListFilter) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			switch r := req.(type) {
+			case *mcp.ListPromptsRequest:
+				var cursor string
+				if r.Params != nil {
+					cursor = r.Params.Cursor
+				}
+				return listRegistryPrompts(ctx, store, authorize, listFilter, cursor, func() (*mcp.ListPromptsResult, error) {
+					res, err := next(ctx, method, req)
+					if err != nil {
+						return nil, err
+					}
+					return res.(*mcp.ListPromptsResult), nil
+				})
+			case *mcp.GetPromptRequest:
+				if r.Params == nil {
+					break
+				}
+				namespace, name, ok := strings.Cut(r.Params.Name, "/")
+				if !ok {
+					break
+				}
+				return getRegistryPrompt(ctx, store, authorize, namespace, name, r.Params.Arguments)
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// listRegistryPrompts pages the static prompt list first, then the latest
+// registry Prompts. static is only consulted while the cursor is not a
+// registry cursor.
+func listRegistryPrompts(
+	ctx context.Context,
+	store *v1alpha1store.Store,
+	authorize Authorizer,
+	listFilter ListFilter,
+	cursor string,
+	static func() (*mcp.ListPromptsResult, error),
+) (*mcp.ListPromptsResult, error) {
+	storeCursor, registryPage := strings.CutPrefix(cursor, registryCursorPrefix)
+	out := &mcp.ListPromptsResult{Prompts: []*mcp.Prompt{}}
+	if !registryPage {
+		res, err := static()
+		if err != nil || res.NextCursor != "" {
+			return res, err
+		}
+		out = res
+	}
+	raws, next, err := runList(ctx, store, v1alpha1.KindPrompt, authorize, listFilter, listInput{
+		Cursor: storeCursor,
+		Limit:  maxPageLimit,
+		Tag:    "latest",
+	})
+	if err != nil {
+		return nil, err
+	}
+	prompts, err := envelopesFromRows(raws, v1alpha1.KindPrompt, newPrompt, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range prompts {
+		out.Prompts = append(out.Prompts, mcpPrompt(p))
+	}
+	out.NextCursor = ""
+	if next != "" {
+		out.NextCursor = registryCursorPrefix + next
+	}
+	return out, nil
+}
+
+// getRegistryPrompt renders the latest tag of namespace/name with args.
+// Argument errors map to invalid params so clients can surface them next to
+// the argument form.
+func getRegistryPrompt(
+	ctx context.Context,
+	store *v1alpha1store.Store,
+	authorize Authorizer,
+	namespace, name string,
+	args map[string]string,
+) (*mcp.GetPromptResult, error) {
+	if authorize != nil {
+		if err := authorize(ctx, resource.AuthorizeInput{Verb: "get", Kind: v1alpha1.KindPrompt, Namespace: namespace, Name: name}); err != nil {
+			return nil, err
+		}
+	}
+	raw, err := store.GetLatest(ctx, namespace, name)
+	if err != nil {
+		if errors.Is(err, pkgdb.ErrNotFound) {
+			return nil, &jsonrpc.Error{
+				Code:    jsonrpc.CodeInvalidParams,
+				Message: fmt.Sprintf("unknown prompt %q", registryPromptName(namespace, name)),
+			}
+		}
+		return nil, fmt.Errorf("fetch %s: %w", v1alpha1.KindPrompt, err)
+	}
+	prompt, err := v1alpha1.EnvelopeFromRaw(newPrompt, raw, v1alpha1.KindPrompt)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", v1alpha1.KindPrompt, err)
+	}
+	messages, err := prompt.Spec.Render(args)
+	if err != nil {
+		var fieldErrs v1alpha1.FieldErrors
+		if errors.As(err, &fieldErrs) {
+			return nil, &jsonrpc.Error{Code: jsonrpc.CodeInvalidParams, Message: err.Error()}
+		}
+		return nil, fmt.Errorf("render %s: %w", registryPromptName(namespace, name), err)
+	}
+	out := &mcp.GetPromptResult{
+		Description: prompt.Spec.Description,
+		Messages:    make([]*mcp.PromptMessage, 0, len(messages)),
+	}
+	for _, m := range messages {
+		out.Messages = append(out.Messages, &mcp.PromptMessage{
+			Role:    mcpRole(m.Role),
+			Content: &mcp.TextContent{Text: m.Content},
+		})
+	}
+	return out, nil
+}
+
+// mcpPrompt describes a registry Prompt for prompts/list. An argument with a
+// default is never required: Render fills it in when the client omits it.
+func mcpPrompt(p *v1alpha1.Prompt) *mcp.Prompt {
+	out := &mcp.Prompt{
+		Name:        registryPromptName(p.Metadata.NamespaceOrDefault(), p.Metadata.Name),
+		Title:       p.Metadata.Name,
+		Description: p.Spec.Description,
+	}
+	for _, arg := range p.Spec.Arguments {
+		description := arg.Description
+		if arg.Type != "" && arg.Type != v1alpha1.PromptArgumentTypeString {
+			description = strings.TrimSpace(description + " (" + arg.Type + ")")
+		}
+		out.Arguments = append(out.Arguments, &mcp.PromptArgument{
+			Name:        arg.Name,
+			Description: description,
+			Required:    arg.Required && arg.Default == "",
+		})
+	}
+	return out
+}
+
+// mcpRole maps a PromptMessage role onto MCP's, which has no system role:
+// system messages are delivered as user messages, which is how MCP clients
+// already treat server-provided prompts.
+func mcpRole(role string) mcp.Role {
+	if role == v1alpha1.PromptRoleAssistant {
+		return "assistant"
+	}
+	return "user"
+}
+
+func newPrompt() *v1alpha1.Prompt { return &v1alpha1.Prompt{} }
@@ -0,0 +1,266 @@
+package registryserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/common/gitutil"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/skills"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+const (
+	// resourceURIScheme prefixes every registry resource URI:
+	// agentregistry://{kind}/{namespace}/{name}@{tag}, with kind lowercased
+	// ("skill", "mcpserver"). Tag "latest" reads the latest tag.
+	resourceURIScheme   = "agentregistry://"
+	resourceURITemplate = resourceURIScheme + "{kind}/{namespace}/{name}@{tag}"
+
+	skillContentFile     = skills.ManifestFile
+	skillContentMIMEType = "text/markdown"
+	manifestMIMEType     = "application/yaml"
+	skillFetchTimeout    = 2 * time.Minute
+)
+
+// cloneSkillContent returns a git-sourced Skill's SKILL.md by cloning its
+// repository; tests swap in a fake.
+var cloneSkillContent = gitSkillContent
+
+// resourceURI formats the registry resource URI for one row.
+func resourceURI(kind, namespace, name, tag string) string {
+	return resourceURIScheme + strings.ToLower(kind) + "/" + namespace + "/" + name + "@" + tag
+}
+
+// parseResourceURI splits a registry resource URI into its parts. kind is
+// returned as written; callers match it case-insensitively.
+func parseResourceURI(uri string) (kind, namespace, name, tag string, err error) {
+	rest, ok := strings.CutPrefix(uri, resourceURIScheme)
+	if !ok {
+		return "", "", "", "", fmt.Errorf("resource URI %q must start with %s", uri, resourceURIScheme)
+	}
+	parts := strings.Split(rest, "/")
+	if len(parts) != 3 {
+		return "", "", "", "", fmt.Errorf("resource URI %q must be %s", uri, resourceURITemplate)
+	}
+	name, tag, ok = strings.Cut(parts[2], "@")
+	if !ok || parts[0] == "" || parts[1] == "" || name == "" || tag == "" {
+		return "", "", "", "", fmt.Errorf("resource URI %q must be %s", uri, resourceURITemplate)
+	}
+	return parts[0], parts[1], name, tag, nil
+}
+
+// addRegistryResources exposes every stored kind as a manifest resource
+// through one URI template, and lists the latest tag of each Skill as a
+// concrete resource whose contents lead with its SKILL.md.
+func addRegistryResources(
+	server *mcp.Server,
+	stores map[string]*v1alpha1store.Store,
+	authorizers map[string]Authorizer,
+	listFilters map[string]ListFilter,
+	materializer *skills.Materializer,
+) {
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "registry-resource",
+		Title:       "Agent registry resource",
+		Description: "A registry resource manifest as YAML. Skills also return their SKILL.md. Use tag 'latest' for the latest tag.",
+		URITemplate: resourceURITemplate,
+		MIMEType:    manifestMIMEType,
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		return readRegistryResource(ctx, stores, authorizers, materializer, req.Params.URI)
+	})
+	if store := stores[v1alpha1.KindSkill]; store != nil {
+		server.AddReceivingMiddleware(skillResourcesMiddleware(store, authorizers[v1alpha1.KindSkill], listFilters[v1alpha1.KindSkill]))
+	}
+}
+
+// skillResourcesMiddleware appends the latest Skills to resources/list after
+// any statically registered resources, using the same cursor scheme as
+// promptsMiddleware.
+func skillResourcesMiddleware(store *v1alpha1store.Store, authorize Authorizer, listFilter ListFilter) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			r, ok := req.(*mcp.ListResourcesRequest)
+			if !ok {
+				return next(ctx, method, req)
+			}
+			var cursor string
+			if r.Params != nil {
+				cursor = r.Params.Cursor
+			}
+			storeCursor, registryPage := strings.CutPrefix(cursor, registryCursorPrefix)
+			out := &mcp.ListResourcesResult{Resources: []*mcp.Resource{}}
+			if !registryPage {
+				res, err := next(ctx, method, req)
+				if err != nil {
+					return nil, err
+				}
+				out = res.(*mcp.ListResourcesResult)
+				if out.NextCursor != "" {
+					return out, nil
+				}
+			}
+			raws, nextCursor, err := runList(ctx, store, v1alpha1.KindSkill, authorize, listFilter, listInput{
+				Cursor: storeCursor,
+				Limit:  maxPageLimit,
+				Tag:    "latest",
+			})
+			if err != nil {
+				return nil, err
+			}
+			skills, err := envelopesFromRows(raws, v1alpha1.KindSkill, func() *v1alpha1.Skill { return &v1alpha1.Skill{} }, "")
+			if err != nil {
+				return nil, err
+			}
+			for _, s := range skills {
+				namespace := s.Metadata.NamespaceOrDefault()
+				out.Resources = append(out.Resources, &mcp.Resource{
+					URI:         resourceURI(v1alpha1.KindSkill, namespace, s.Metadata.Name, s.Metadata.Tag),
+					Name:        namespace + "/" + s.Metadata.Name,
+					Title:       s.Spec.Title,
+					Description: s.Spec.Description,
+					MIMEType:    skillContentMIMEType,
+				})
+			}
+			out.NextCursor = ""
+			if nextCursor != "" {
+				out.NextCursor = registryCursorPrefix + nextCursor
+			}
+			return out, nil
+		}
+	}
+}
+
+// readRegistryResource serves resources/read for a registry URI. Every
+// kind returns its redacted manifest; a Skill returns its SKILL.md first.
+func readRegistryResource(
+	ctx context.Context,
+	stores map[string]*v1alpha1store.Store,
+	authorizers map[string]Authorizer,
+	materializer *skills.Materializer,
+	uri string,
+) (*mcp.ReadResourceResult, error) {
+	kindSegment, namespace, name, tag, err := parseResourceURI(uri)
+	if err != nil {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	kind, store := lookupKindStore(stores, kindSegment)
+	if store == nil {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	latest := strings.EqualFold(tag, "latest")
+	if authorize := authorizers[kind]; authorize != nil {
+		authzTag := tag
+		if latest {
+			authzTag = ""
+		}
+		if err := authorize(ctx, resource.AuthorizeInput{Verb: "get", Kind: kind, Namespace: namespace, Name: name, Tag: authzTag}); err != nil {
+			return nil, err
+		}
+	}
+	var raw *v1alpha1.RawObject
+	if latest {
+		raw, err = store.GetLatest(ctx, namespace, name)
+	} else {
+		raw, err = store.Get(ctx, namespace, name, tag)
+	}
+	if err != nil {
+		if errors.Is(err, pkgdb.ErrNotFound) {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+		return nil, fmt.Errorf("fetch %s: %w", kind, err)
+	}
+	descriptor, ok := v1alpha1.DefaultKindRegistry.Lookup(kind)
+	if !ok {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	obj, err := v1alpha1.EnvelopeFromRaw(func() v1alpha1.Object {
+		return descriptor.NewObject().(v1alpha1.Object)
+	}, raw, kind)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", kind, err)
+	}
+	manifest, err := v1alpha1.Encode(obj)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s: %w", kind, err)
+	}
+
+	var contents []*mcp.ResourceContents
+	if skill, ok := obj.(*v1alpha1.Skill); ok {
+		body, err := fetchSkillContent(ctx, materializer, skill)
+		if err != nil {
+			return nil, fmt.Errorf("read %s for %s %s/%s: %w", skillContentFile, kind, namespace, name, err)
+		}
+		contents = append(contents, &mcp.ResourceContents{URI: uri, MIMEType: skillContentMIMEType, Text: string(body)})
+	}
+	contents = append(contents, &mcp.ResourceContents{URI: uri, MIMEType: manifestMIMEType, Text: string(manifest)})
+	return &mcp.ReadResourceResult{Contents: contents}, nil
+}
+
+// lookupKindStore resolves a URI kind segment to its canonical Kind and
+// Store. Iteration is sorted so the match is deterministic.
+func lookupKindStore(stores map[string]*v1alpha1store.Store, segment string) (string, *v1alpha1store.Store) {
+	kinds := make([]string, 0, len(stores))
+	for kind := range stores {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+	for _, kind := range kinds {
+		if strings.EqualFold(kind, segment) && stores[kind] != nil {
+			return kind, stores[kind]
+		}
+	}
+	return "", nil
+}
+
+// fetchSkillContent returns a Skill's SKILL.md from its pinned source. The
+// registry stores no git skill content, so git sources are cloned; OCI and
+// uploaded-archive sources are materialized at status.resolvedSource.digest.
+func fetchSkillContent(ctx context.Context, materializer *skills.Materializer, skill *v1alpha1.Skill) ([]byte, error) {
+	if !skills.HasDigestSource(skill) {
+		return cloneSkillContent(ctx, skill)
+	}
+	ctx, cancel := context.WithTimeout(ctx, skillFetchTimeout)
+	defer cancel()
+	b, err := materializer.Materialize(ctx, skill)
+	if err != nil {
+		return nil, err
+	}
+	return b.Files[skills.ManifestFile], nil
+}
+
+// gitSkillContent clones the skill's repository at its pinned commit (the
+// declared commit or branch until the Skill controller has pinned one) and
+// reads SKILL.md from the skill's subfolder.
+func gitSkillContent(ctx context.Context, skill *v1alpha1.Skill) ([]byte, error) {
+	if skill.Spec.Source == nil || skill.Spec.Source.Repository == nil || skill.Spec.Source.Repository.URL == "" {
+		return nil, errors.New("skill has no repository source")
+	}
+	repo := skill.Spec.Source.Repository
+	commit := repo.Commit
+	if pinned := skill.Status.ResolvedSource; pinned != nil && pinned.Commit != "" {
+		commit = pinned.Commit
+	}
+	dir, err := os.MkdirTemp("", "agentregistry-mcp-skill-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temp directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	ctx, cancel := context.WithTimeout(ctx, skillFetchTimeout)
+	defer cancel()
+	if err := gitutil.CloneAndCopyContext(ctx, repo.URL, repo.Branch, commit, repo.Subfolder, dir, false); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(filepath.Join(dir, skillContentFile))
+}
@@ -0,0 +1,30 @@
+package registryserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+func TestParseResourceURI(t *testing.T) {
+	uri := resourceURI(v1alpha1.KindMCPServer, "team-a", "echo.tools", "1.2.0")
+	assert.Equal(t, "agentregistry://mcpserver/team-a/echo.tools@1.2.0", uri)
+
+	kind, namespace, name, tag, err := parseResourceURI(uri)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mcpserver", "team-a", "echo.tools", "1.2.0"}, []string{kind, namespace, name, tag})
+
+	for _, bad := range []string{
+		"https://example.com/skill/default/x@1",
+		"agentregistry://skill/default/x",
+		"agentregistry://skill/default/x@",
+		"agentregistry://skill/x@1",
+		"agentregistry://skill/default/nested/x@1",
+	} {
+		_, _, _, _, err := parseResourceURI(bad)
+		assert.Error(t, err, bad)
+	}
+}
@@ -3,6 +3,11 @@
 //
 // Every tool reads through the v1alpha1 generic Store. Structured
 // outputs are v1alpha1 envelopes (apiVersion/kind/metadata/spec/status).
+//
+// Registry Prompts are also served as MCP prompts (prompts/list,
+// prompts/get), and every resource is readable as an MCP resource at
+// agentregistry://{kind}/{namespace}/{name}@{tag}, so IDEs can pull prompts
+// and skills without going through the tools.
 package registryserver
 
 import (
@@ -13,7 +18,9 @@ import (
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
+	"github.com/agentregistry-dev/agentregistry/internal/registry/skills"
 	"github.com/agentregistry-dev/agentregistry/internal/version"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
@@ -43,17 +50,22 @@ const (
 //
 // Tool names are preserved across builds (`list_servers` not
 // `list_mcpservers`) so saved Claude MCP configs keep working.
+//
+// skillArchives reads uploaded skill archives for resources/read of Skills
+// with an archive source; nil leaves those unreadable.
 func NewServer(
 	stores map[string]*v1alpha1store.Store,
 	authorizers map[string]Authorizer,
 	listFilters map[string]ListFilter,
+	skillArchives skills.ArchiveFetcher,
 ) *mcp.Server {
 	server := mcp.NewServer(&mcp.Implementation{
 		Name:    "agentregistry-mcp",
 		Version: version.Version,
 	}, &mcp.ServerOptions{
-		HasTools:   true,
-		HasPrompts: true,
+		HasTools:     true,
+		HasPrompts:   true,
+		HasResources: true,
 	})
 
 	addKindTools(server, stores[v1alpha1.KindAgent], kindTools[*v1alpha1.Agent]{
@@ -136,8 +148,23 @@ func NewServer(
 		Authorize:  authorizers[v1alpha1.KindRuntime],
 		ListFilter: listFilters[v1alpha1.KindRuntime],
 	})
+	addKindTools(server, stores[v1alpha1.KindSecret], kindTools[*v1alpha1.Secret]{
+		Kind:       v1alpha1.KindSecret,
+		ListName:   "list_secrets",
+		GetName:    "get_secret",
+		ListDesc:   "List secrets as v1alpha1 envelopes with optional namespace and substring-name filters. Values are always redacted.",
+		GetDesc:    "Fetch a secret as a v1alpha1 envelope by namespace/name. Values are always redacted; only key names are returned.",
+		NewObj:     func() *v1alpha1.Secret { return &v1alpha1.Secret{} },
+		Authorize:  authorizers[v1alpha1.KindSecret],
+		ListFilter: listFilters[v1alpha1.KindSecret],
+	})
+	addSearchTool(server, stores, authorizers, listFilters)
 	addMetaTools(server)
 	addServerPrompts(server)
+	if store := stores[v1alpha1.KindPrompt]; store != nil {
+		server.AddReceivingMiddleware(promptsMiddleware(store, authorizers[v1alpha1.KindPrompt], listFilters[v1alpha1.KindPrompt]))
+	}
+	addRegistryResources(server, stores, authorizers, listFilters, skills.NewMaterializer(skillArchives))
 
 	return server
 }
@@ -212,6 +239,42 @@ type listOutput[T v1alpha1.Object] struct {
 	Count      int    `json:"count"`
 }
 
+// searchInput is the input of the search tool. Kinds accepts kind names
+// or plurals (e.g. "MCPServer", "agents").
+type searchInput struct {
+	Query     string   `json:"query"               doc:"Search text; words are ANDed, \"quoted phrases\" match in order, 'or' separates alternatives" required:"true"`
+	Kinds     []string `json:"kinds,omitempty"     doc:"Kinds to search (empty = every kind)"`
+	Namespace string   `json:"namespace,omitempty" doc:"Filter by namespace (empty = all namespaces)"`
+	Limit     int      `json:"limit,omitempty"     doc:"Max results (1-100, default 20)"`
+}
+
+type searchOutput struct {
+	Items []arv0.SearchResult `json:"items"`
+	Count int                 `json:"count"`
+}
+
+// addSearchTool registers the cross-kind full-text search tool. It runs
+// the same query as GET /v0/search, so each kind is scoped by its list
+// authorizer and filter; kinds the caller may not list are skipped.
+func addSearchTool(server *mcp.Server, stores map[string]*v1alpha1store.Store, authorizers map[string]Authorizer, listFilters map[string]ListFilter) {
+	cfg := resource.SearchConfig{Stores: stores, Authorizers: authorizers, ListFilters: listFilters}
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "search",
+		Description: "Full-text search across registry kinds by name, title, description, labels, MCP tool names and plugin contents. Returns ranked matches with highlighted excerpts; fetch one with the matching get_* tool.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, args searchInput) (*mcp.CallToolResult, searchOutput, error) {
+		items, err := resource.Search(ctx, cfg, resource.SearchRequest{
+			Query:     args.Query,
+			Kinds:     args.Kinds,
+			Namespace: strings.TrimSpace(args.Namespace),
+			Limit:     args.Limit,
+		})
+		if err != nil {
+			return nil, searchOutput{}, err
+		}
+		return nil, searchOutput{Items: items, Count: len(items)}, nil
+	})
+}
+
 // Deployment note: only read tools (list + get) are exposed via MCP.
 // Create + delete equivalents live on the v1alpha1 apply surface at
 // /v0/deployments/{name}?namespace={ns} — MCP clients that need to
@@ -380,7 +443,7 @@ func addServerPrompts(server *mcp.Server) {
 		if resourceType != "" {
 			instruction += " (filter to " + resourceType + " only)"
 		}
-		instruction += ". Use the appropriate list tool (list_servers, list_agents, list_skills, list_prompts, list_plugins, list_deployments, list_runtimes, list_models) with the search parameter. Summarize what you find including names, descriptions, and tags."
+		instruction += ". Use the search tool (pass kinds to narrow by type), then fetch promising matches with the matching get tool. Summarize what you find including names, descriptions, and tags."
 
 		return &mcp.GetPromptResult{
 			Description: "Search the registry for resources matching a query",
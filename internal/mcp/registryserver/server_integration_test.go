@@ -3,6 +3,9 @@
 package registryserver
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
@@ -48,7 +51,7 @@ func TestMCPListServers_HappyPath(t *testing.T) {
 	require.NoError(t, err, "seed server")
 
 	// Wire up MCP server + client over in-memory transports.
-	server := NewServer(stores, nil, nil)
+	server := NewServer(stores, nil, nil, nil)
 	clientTransport, serverTransport := mcp.NewInMemoryTransports()
 
 	serverSession, err := server.Connect(ctx, serverTransport, nil)
@@ -220,6 +223,45 @@ func TestGetEnvelope_Authz(t *testing.T) {
 	})
 }
 
+// TestMCPSearchTool checks the search tool ranks across kinds and applies
+// the per-kind list authorizers: denied kinds are skipped unless named.
+func TestMCPSearchTool(t *testing.T) {
+	ctx := context.Background()
+	pool := v1alpha1store.NewTestPool(t)
+	stores := v1alpha1store.NewStores(pool, v1alpha1store.TestSchemaRegistry())
+	seedMCPServer(ctx, t, stores)
+	_, err := stores[v1alpha1.KindAgent].Upsert(ctx, &v1alpha1.Agent{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "echo-bot"},
+		Spec:     v1alpha1.AgentSpec{Description: "Talks to the echo server"},
+	})
+	require.NoError(t, err, "seed agent")
+
+	denyAgents := map[string]Authorizer{
+		v1alpha1.KindAgent: func(context.Context, resource.AuthorizeInput) error { return errors.New("denied") },
+	}
+	session := connectTestClient(ctx, t, NewServer(stores, denyAgents, nil, nil))
+
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "search",
+		Arguments: map[string]any{"query": "echo"},
+	})
+	require.NoError(t, err, "call search")
+	require.False(t, res.IsError)
+	var out searchOutput
+	decodeStructured(t, res.StructuredContent, &out)
+	require.Equal(t, 1, out.Count, "denied kinds are skipped")
+	assert.Equal(t, v1alpha1.KindMCPServer, out.Items[0].Kind)
+	assert.Equal(t, "echo", out.Items[0].Name)
+	assert.Contains(t, out.Items[0].Highlight, "**Echo**")
+
+	res, err = session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "search",
+		Arguments: map[string]any{"query": "echo", "kinds": []string{"agents"}},
+	})
+	require.NoError(t, err)
+	assert.True(t, res.IsError, "an explicitly requested denied kind fails the call")
+}
+
 // envelopeMeta decodes just the identity of a v1alpha1 envelope, so one helper
 // can assert every kind's list_X/get_X output without a typed decode per kind.
 type envelopeMeta struct {
@@ -366,9 +408,24 @@ func TestMCPCatalogTools(t *testing.T) {
 				require.NoError(t, err, "seed runtime")
 			},
 		},
+		{
+			kind: v1alpha1.KindSecret, listTool: "list_secrets", getTool: "get_secret",
+			name: "test-secret", expectedTag: "",
+			seed: func(t *testing.T, name string) {
+				_, err := stores[v1alpha1.KindSecret].Upsert(ctx, &v1alpha1.Secret{
+					Metadata: v1alpha1.ObjectMeta{Namespace: namespace, Name: name},
+					Spec: v1alpha1.SecretSpec{Encrypted: &v1alpha1.SecretEnvelope{
+						KeyID:   "test",
+						DataKey: "wrapped",
+						Values:  map[string]string{"apiKey": "ciphertext"},
+					}},
+				})
+				require.NoError(t, err, "seed secret")
+			},
+		},
 	}
 
-	server := NewServer(stores, nil, nil)
+	server := NewServer(stores, nil, nil, nil)
 	clientTransport, serverTransport := mcp.NewInMemoryTransports()
 
 	serverSession, err := server.Connect(ctx, serverTransport, nil)
@@ -424,3 +481,219 @@ func TestMCPCatalogTools(t *testing.T) {
 		})
 	}
 }
+
+func TestMCPGetSecretRedactsValues(t *testing.T) {
+	ctx := context.Background()
+	pool := v1alpha1store.NewTestPool(t)
+	stores := v1alpha1store.NewStores(pool, v1alpha1store.TestSchemaRegistry())
+
+	_, err := stores[v1alpha1.KindSecret].Upsert(ctx, &v1alpha1.Secret{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "provider-key"},
+		Spec: v1alpha1.SecretSpec{Encrypted: &v1alpha1.SecretEnvelope{
+			KeyID:   "test",
+			DataKey: "wrapped-data-key",
+			Values:  map[string]string{"apiKey": "sealed-value"},
+		}},
+	})
+	require.NoError(t, err, "seed secret")
+
+	server := NewServer(stores, nil, nil, nil)
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err, "connect MCP server")
+	defer func() {
+		err := serverSession.Wait()
+		if err != nil && !errors.Is(err, io.ErrClosedPipe) && !errors.Is(err, io.EOF) {
+			require.NoError(t, err)
+		}
+	}()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v0.0.1"}, nil)
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err, "connect MCP client")
+	defer func() { _ = clientSession.Close() }()
+
+	for _, call := range []*mcp.CallToolParams{
+		{Name: "get_secret", Arguments: map[string]any{"name": "provider-key"}},
+		{Name: "list_secrets", Arguments: map[string]any{"namespace": "default"}},
+	} {
+		res, err := clientSession.CallTool(ctx, call)
+		require.NoError(t, err, "call %s", call.Name)
+		raw, err := json.Marshal(res.StructuredContent)
+		require.NoError(t, err)
+		assert.NotContains(t, string(raw), "sealed-value", "%s leaks ciphertext", call.Name)
+		assert.NotContains(t, string(raw), "wrapped-data-key", "%s leaks the data key", call.Name)
+		assert.Contains(t, string(raw), v1alpha1.RedactedSecretValue, "%s redacts values", call.Name)
+	}
+}
+
+// connectTestClient wires server to an in-memory MCP client session.
+func connectTestClient(ctx context.Context, t *testing.T, server *mcp.Server) *mcp.ClientSession {
+	t.Helper()
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err, "connect MCP server")
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v0.0.1"}, nil)
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err, "connect MCP client")
+	t.Cleanup(func() {
+		_ = clientSession.Close()
+		err := serverSession.Wait()
+		if err != nil && !errors.Is(err, io.ErrClosedPipe) && !errors.Is(err, io.EOF) {
+			require.NoError(t, err)
+		}
+	})
+	return clientSession
+}
+
+func TestMCPRegistryPrompts(t *testing.T) {
+	ctx := context.Background()
+	pool := v1alpha1store.NewTestPool(t)
+	stores := v1alpha1store.NewStores(pool, v1alpha1store.TestSchemaRegistry())
+
+	_, err := stores[v1alpha1.KindPrompt].Upsert(ctx, &v1alpha1.Prompt{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "summarizer"},
+		Spec: v1alpha1.PromptSpec{
+			Description: "Summarize a document",
+			Arguments: []v1alpha1.PromptArgument{
+				{Name: "audience", Required: true},
+				{Name: "maxWords", Type: v1alpha1.PromptArgumentTypeInteger, Default: "200"},
+			},
+			Messages: []v1alpha1.PromptMessage{
+				{Role: v1alpha1.PromptRoleSystem, Content: "You write for {{ .audience }}."},
+				{Role: v1alpha1.PromptRoleUser, Content: "Summarize in at most {{ .maxWords }} words."},
+			},
+		},
+	})
+	require.NoError(t, err, "seed prompt")
+
+	session := connectTestClient(ctx, t, NewServer(stores, nil, nil, nil))
+
+	list, err := session.ListPrompts(ctx, nil)
+	require.NoError(t, err)
+	byName := map[string]*mcp.Prompt{}
+	for _, p := range list.Prompts {
+		byName[p.Name] = p
+	}
+	assert.Contains(t, byName, "search_registry", "built-in prompts are still listed")
+	got, ok := byName["default/summarizer"]
+	require.True(t, ok, "registry prompt is listed")
+	assert.Equal(t, "Summarize a document", got.Description)
+	require.Len(t, got.Arguments, 2)
+	assert.True(t, got.Arguments[0].Required)
+	assert.False(t, got.Arguments[1].Required, "an argument with a default is optional")
+
+	res, err := session.GetPrompt(ctx, &mcp.GetPromptParams{
+		Name:      "default/summarizer",
+		Arguments: map[string]string{"audience": "executives"},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Messages, 2)
+	assert.Equal(t, mcp.Role("user"), res.Messages[0].Role, "system messages are sent as user messages")
+	assert.Equal(t, "You write for executives.", res.Messages[0].Content.(*mcp.TextContent).Text)
+	assert.Equal(t, "Summarize in at most 200 words.", res.Messages[1].Content.(*mcp.TextContent).Text)
+
+	_, err = session.GetPrompt(ctx, &mcp.GetPromptParams{Name: "default/summarizer"})
+	require.Error(t, err, "missing required argument")
+	assert.Contains(t, err.Error(), "audience")
+
+	_, err = session.GetPrompt(ctx, &mcp.GetPromptParams{Name: "default/missing"})
+	require.Error(t, err)
+}
+
+func TestMCPRegistryResources(t *testing.T) {
+	ctx := context.Background()
+	pool := v1alpha1store.NewTestPool(t)
+	stores := v1alpha1store.NewStores(pool, v1alpha1store.TestSchemaRegistry())
+
+	orig := cloneSkillContent
+	t.Cleanup(func() { cloneSkillContent = orig })
+	cloneSkillContent = func(_ context.Context, skill *v1alpha1.Skill) ([]byte, error) {
+		return []byte("# " + skill.Metadata.Name + "\n"), nil
+	}
+
+	_, err := stores[v1alpha1.KindSkill].Upsert(ctx, &v1alpha1.Skill{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "pdf-tools"},
+		Spec: v1alpha1.SkillSpec{
+			Title:  "PDF tools",
+			Source: &v1alpha1.SkillSource{Repository: &v1alpha1.Repository{URL: "https://github.com/example/skills"}},
+		},
+	})
+	require.NoError(t, err, "seed skill")
+	namespace, name := seedMCPServer(ctx, t, stores)
+
+	session := connectTestClient(ctx, t, NewServer(stores, nil, nil, nil))
+
+	templates, err := session.ListResourceTemplates(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, templates.ResourceTemplates, 1)
+	assert.Equal(t, resourceURITemplate, templates.ResourceTemplates[0].URITemplate)
+
+	list, err := session.ListResources(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, list.Resources, 1)
+	skillURI := resourceURI(v1alpha1.KindSkill, "default", "pdf-tools", v1alpha1store.DefaultTag())
+	assert.Equal(t, skillURI, list.Resources[0].URI)
+	assert.Equal(t, "PDF tools", list.Resources[0].Title)
+
+	read, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: skillURI})
+	require.NoError(t, err)
+	require.Len(t, read.Contents, 2)
+	assert.Equal(t, "# pdf-tools\n", read.Contents[0].Text)
+	assert.Equal(t, skillContentMIMEType, read.Contents[0].MIMEType)
+	assert.Contains(t, read.Contents[1].Text, "kind: Skill")
+
+	read, err = session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "agentregistry://mcpserver/" + namespace + "/" + name + "@latest"})
+	require.NoError(t, err)
+	require.Len(t, read.Contents, 1)
+	assert.Equal(t, manifestMIMEType, read.Contents[0].MIMEType)
+	assert.Contains(t, read.Contents[0].Text, "kind: MCPServer")
+
+	_, err = session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "agentregistry://mcpserver/default/missing@latest"})
+	require.Error(t, err)
+}
+
+func TestMCPRegistryResources_ArchiveSkill(t *testing.T) {
+	ctx := context.Background()
+	pool := v1alpha1store.NewTestPool(t)
+	stores := v1alpha1store.NewStores(pool, v1alpha1store.TestSchemaRegistry())
+	archives := v1alpha1store.NewSkillArchiveStore(pool, v1alpha1store.TestSchema())
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	content := "# summarize\n"
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "SKILL.md", Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	stored, err := archives.Put(ctx, buf.Bytes())
+	require.NoError(t, err)
+
+	_, err = stores[v1alpha1.KindSkill].Upsert(ctx, &v1alpha1.Skill{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "summarize"},
+		Spec:     v1alpha1.SkillSpec{Source: &v1alpha1.SkillSource{Archive: &v1alpha1.SkillSourceArchive{Digest: stored.Digest}}},
+	})
+	require.NoError(t, err, "seed skill")
+
+	session := connectTestClient(ctx, t, NewServer(stores, nil, nil, func(ctx context.Context, digest string) ([]byte, error) {
+		archive, err := archives.Get(ctx, digest)
+		return archive.Content, err
+	}))
+	skillURI := resourceURI(v1alpha1.KindSkill, "default", "summarize", v1alpha1store.DefaultTag())
+
+	// Until the controller pins the digest the content is not served.
+	_, err = session.ReadResource(ctx, &mcp.ReadResourceParams{URI: skillURI})
+	require.Error(t, err)
+
+	require.NoError(t, stores[v1alpha1.KindSkill].ApplyPatch(ctx, "default", "summarize", v1alpha1store.DefaultTag(), v1alpha1store.PatchOpts{
+		Status: func(json.RawMessage) (json.RawMessage, error) {
+			skill := &v1alpha1.Skill{Status: v1alpha1.SkillStatus{ResolvedSource: &v1alpha1.SkillResolvedSource{Digest: stored.Digest}}}
+			return skill.MarshalStatus()
+		},
+	}))
+	read, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: skillURI})
+	require.NoError(t, err)
+	require.Len(t, read.Contents, 2)
+	assert.Equal(t, content, read.Contents[0].Text)
+}
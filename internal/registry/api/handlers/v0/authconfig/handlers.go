@@ -0,0 +1,30 @@
+// Package authconfig serves GET /v0/auth/config, which tells clients
+// how to sign in to the registry. It is reachable without credentials.
+package authconfig
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+// Path is the route relative to the API path prefix.
+const Path = "/auth/config"
+
+// RegisterAuthConfigEndpoint serves authConfig at pathPrefix + Path.
+func RegisterAuthConfigEndpoint(api huma.API, pathPrefix string, authConfig arv0.AuthConfig) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-auth-config",
+		Method:      http.MethodGet,
+		Path:        pathPrefix + Path,
+		Summary:     "Get sign-in configuration",
+		Description: "Returns the OpenID Connect issuer and client `arctl login` signs in with, if any",
+		Tags:        []string{"auth"},
+	}, func(_ context.Context, _ *struct{}) (*types.Response[arv0.AuthConfig], error) {
+		return &types.Response[arv0.AuthConfig]{Body: authConfig}, nil
+	})
+}
@@ -0,0 +1,55 @@
+package authconfig_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/authconfig"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+)
+
+func TestAuthConfigEndpoint(t *testing.T) {
+	testCases := []struct {
+		name       string
+		authConfig arv0.AuthConfig
+		wantBody   string
+	}{
+		{
+			name: "advertises the OIDC client",
+			authConfig: arv0.AuthConfig{OIDC: &arv0.OIDCLoginConfig{
+				Issuer:   "https://dex.example.com",
+				ClientID: "arctl",
+				Scopes:   []string{"openid", "offline_access"},
+			}},
+			wantBody: `{"oidc":{"issuer":"https://dex.example.com","clientId":"arctl","scopes":["openid","offline_access"]}}`,
+		},
+		{
+			name:     "no interactive sign-in",
+			wantBody: `{}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			config := huma.DefaultConfig("Test API", "1.0.0")
+			config.CreateHooks = nil
+			api := humago.New(mux, config)
+
+			authconfig.RegisterAuthConfigEndpoint(api, "/v0", tc.authConfig)
+
+			req := httptest.NewRequest(http.MethodGet, "/v0/auth/config", nil)
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, tc.wantBody, w.Body.String())
+		})
+	}
+}
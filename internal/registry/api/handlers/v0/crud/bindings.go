@@ -40,5 +40,9 @@ func init() {
 	register(v1alpha1.KindPrompt, func() *v1alpha1.Prompt { return &v1alpha1.Prompt{} })
 	register(v1alpha1.KindRuntime, func() *v1alpha1.Runtime { return &v1alpha1.Runtime{} })
 	register(v1alpha1.KindModel, func() *v1alpha1.Model { return &v1alpha1.Model{} })
+	register(v1alpha1.KindSecret, func() *v1alpha1.Secret { return &v1alpha1.Secret{} })
+	register(v1alpha1.KindRole, func() *v1alpha1.Role { return &v1alpha1.Role{} })
+	register(v1alpha1.KindClusterRole, func() *v1alpha1.ClusterRole { return &v1alpha1.ClusterRole{} })
+	register(v1alpha1.KindRoleBinding, func() *v1alpha1.RoleBinding { return &v1alpha1.RoleBinding{} })
 	register(v1alpha1.KindDeployment, func() *v1alpha1.Deployment { return &v1alpha1.Deployment{} })
 }
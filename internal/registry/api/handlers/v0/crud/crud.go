@@ -1,6 +1,6 @@
 // Package v1alpha1crud wires the generic CRUD HTTP handlers for every
 // first-party v1alpha1 Kind shipped by this repo (Agent, MCPServer,
-// Skill, Prompt, Runtime, Model, Deployment). Per-kind registration is a
+// Skill, Prompt, Runtime, Model, Secret, Deployment). Per-kind registration is a
 // single `register(...)` call in bindings.go's init(); resource.Register
 // handles every per-kind quirk internally (per-kind authz / list
 // filtering / post-upsert / post-delete threaded through PerKindHooks).
@@ -59,8 +59,11 @@ type PerKindHooks struct {
 
 // Register wires the namespace-scoped + cross-namespace list endpoints for
 // registered v1alpha1 kinds against the supplied Stores map (as produced by
-// v1alpha1store.NewStores). Each kind shares the same BasePrefix and cross-kind
-// Resolver.
+// v1alpha1store.NewStores). Each kind shares the same BasePrefix, cross-kind
+// Resolver and Referrers; a nil referrers skips the .../referrers routes,
+// and a nil watch leaves ?watch=true unsupported on the list routes.
+// admission owns the write of tag rollbacks, as it owns /v0/apply writes;
+// nil uses resource.ProductionAdmission.
 //
 // Kinds with no Store entry or no registered typed binding are silently
 // skipped; callers that want strict behavior should validate the maps ahead of
@@ -72,29 +75,44 @@ func Register(
 	basePrefix string,
 	stores map[string]*v1alpha1store.Store,
 	resolver v1alpha1.ResolverFunc,
+	referrers v1alpha1.ReferrersFunc,
 	registryValidator v1alpha1.RegistryValidatorFunc,
 	perKind PerKindHooks,
+	admission types.Admission,
 	deleteAdmission types.DeleteAdmission,
+	watch *resource.WatchSource,
 ) {
-	cfgFor := func(kind string) (resource.Config, bool) {
+	// cfgFor is declared ahead of its body so cascading deletes can look
+	// up each dependent's own config.
+	var cfgFor func(kind string) (resource.Config, bool)
+	cfgFor = func(kind string) (resource.Config, bool) {
 		store, ok := stores[kind]
 		if !ok {
 			return resource.Config{}, false
 		}
+		var deleteDependent func(context.Context, v1alpha1.ResourceRef, bool) error
+		if referrers != nil {
+			deleteDependent = resource.DependentDeleter(cfgFor)
+		}
 		return resource.Config{
-			Kind:               kind,
-			BasePrefix:         basePrefix,
-			Store:              store,
-			Resolver:           resolver,
-			RegistryValidator:  registryValidator,
-			Authorize:          perKind.Authorizers[kind],
-			ListFilter:         perKind.ListFilters[kind],
-			EnableOriginFilter: kind == v1alpha1.KindDeployment,
-			PostUpsert:         perKind.PostUpserts[kind],
-			PostDelete:         perKind.PostDeletes[kind],
-			Prepare:            perKind.Prepares[kind],
-			DeleteAdmission:    deleteAdmission,
-			InitialFinalizers:  perKind.InitialFinalizers[kind],
+			Kind:                kind,
+			BasePrefix:          basePrefix,
+			Store:               store,
+			Resolver:            resolver,
+			Referrers:           referrers,
+			RegistryValidator:   registryValidator,
+			Authorize:           perKind.Authorizers[kind],
+			ListFilter:          perKind.ListFilters[kind],
+			EnableOriginFilter:  kind == v1alpha1.KindDeployment,
+			PostUpsert:          perKind.PostUpserts[kind],
+			PostDelete:          perKind.PostDeletes[kind],
+			Prepare:             perKind.Prepares[kind],
+			Admission:           admission,
+			DeleteAdmission:     deleteAdmission,
+			InitialFinalizers:   perKind.InitialFinalizers[kind],
+			DeleteDependent:     deleteDependent,
+			ReferrerAuthorizers: perKind.Authorizers,
+			Watch:               watch,
 		}, true
 	}
 
@@ -63,6 +63,7 @@ func seedDeploymentFixtures(t *testing.T) (humatest.TestAPI, map[string]*v1alpha
 	crud.Register(
 		api, "/v0", stores,
 		database.NewResolver(stores),
+		nil, // referrers
 		nil, // registryValidator
 		crud.PerKindHooks{
 			InitialFinalizers: map[string]func(v1alpha1.Object) []string{
@@ -71,7 +72,9 @@ func seedDeploymentFixtures(t *testing.T) (humatest.TestAPI, map[string]*v1alpha
 				},
 			},
 		},
-		nil,
+		nil, // admission
+		nil, // deleteAdmission
+		nil, // watch
 	)
 	deploymentlogs.Register(api, deploymentlogs.Config{
 		BasePrefix:  "/v0",
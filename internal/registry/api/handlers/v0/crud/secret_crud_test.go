@@ -0,0 +1,93 @@
+//go:build integration
+
+package crud_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/crud"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/secrets"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+func TestSecretCRUD(t *testing.T) {
+	pool := v1alpha1store.NewTestPool(t)
+	stores := v1alpha1store.NewStores(pool, v1alpha1store.TestSchemaRegistry())
+	keyring, err := secrets.NewKeyring(bytes.Repeat([]byte{7}, secrets.KeySize))
+	require.NoError(t, err)
+	store := stores[v1alpha1.KindSecret]
+	_, api := humatest.New(t)
+	crud.Register(api, "/v0", stores, nil, nil, nil, crud.PerKindHooks{
+		Prepares: map[string]func(ctx context.Context, obj v1alpha1.Object) error{
+			v1alpha1.KindSecret: secrets.NewPrepare(store, keyring),
+		},
+	}, nil, nil, nil)
+
+	put := func(data map[string]string) v1alpha1.Secret {
+		t.Helper()
+		resp := api.Put("/v0/secrets/provider-key", v1alpha1.Secret{
+			TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindSecret},
+			Metadata: v1alpha1.ObjectMeta{Name: "provider-key"},
+			Spec:     v1alpha1.SecretSpec{Data: data},
+		})
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+		require.NotContains(t, resp.Body.String(), "sk-live", "PUT response leaks plaintext")
+		var out v1alpha1.Secret
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+		return out
+	}
+
+	created := put(map[string]string{"apiKey": "sk-live"})
+	require.Equal(t, map[string]string{"apiKey": v1alpha1.RedactedSecretValue}, created.Spec.Data)
+	require.Nil(t, created.Spec.Encrypted)
+
+	// At rest: no plaintext, only the sealed envelope.
+	raw, err := store.GetLatest(t.Context(), "default", "provider-key")
+	require.NoError(t, err)
+	require.False(t, strings.Contains(string(raw.Spec), "sk-live"), "stored spec holds plaintext: %s", raw.Spec)
+	require.Contains(t, string(raw.Spec), keyring.ID())
+
+	for _, path := range []string{"/v0/secrets/provider-key", "/v0/secrets"} {
+		resp := api.Get(path)
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+		require.NotContains(t, resp.Body.String(), "sk-live", "%s leaks plaintext", path)
+		require.NotContains(t, resp.Body.String(), "dataKey", "%s leaks the envelope", path)
+	}
+
+	// Re-applying what GET returned is a no-op, not a wipe.
+	again := put(created.Spec.Data)
+	require.Equal(t, created.Metadata.Generation, again.Metadata.Generation)
+
+	resolve := secrets.NewResolver(store, keyring)
+	value, err := resolve(t.Context(), "default", v1alpha1.SecretKeyRef{Name: "provider-key"})
+	require.NoError(t, err)
+	require.Equal(t, "sk-live", value)
+
+	_, err = resolve(t.Context(), "default", v1alpha1.SecretKeyRef{Name: "provider-key", Key: "missing"})
+	require.ErrorIs(t, err, v1alpha1.ErrDanglingRef)
+	_, err = resolve(t.Context(), "default", v1alpha1.SecretKeyRef{Name: "absent"})
+	require.ErrorIs(t, err, v1alpha1.ErrDanglingRef)
+
+	// A client cannot smuggle in its own envelope.
+	resp := api.Put("/v0/secrets/forged", v1alpha1.Secret{
+		Metadata: v1alpha1.ObjectMeta{Name: "forged"},
+		Spec:     v1alpha1.SecretSpec{Encrypted: &v1alpha1.SecretEnvelope{KeyID: keyring.ID()}},
+	})
+	require.Equal(t, http.StatusBadRequest, resp.Code, resp.Body.String())
+
+	// A placeholder with no stored value behind it is a client error.
+	resp = api.Put("/v0/secrets/provider-key", v1alpha1.Secret{
+		Metadata: v1alpha1.ObjectMeta{Name: "provider-key"},
+		Spec:     v1alpha1.SecretSpec{Data: map[string]string{"other": v1alpha1.RedactedSecretValue}},
+	})
+	require.Equal(t, http.StatusBadRequest, resp.Code, resp.Body.String())
+}
@@ -0,0 +1,176 @@
+// Package namespaceowners owns the namespace ownership surface:
+// `POST /v0/namespace-owners` claims the namespace of a domain with a DNS
+// or HTTP proof, `GET /v0/namespace-owners[/{namespace}]` shows verified
+// owners and `DELETE /v0/namespace-owners/{namespace}` releases one.
+//
+// Claiming needs a signed-in caller, recorded as the verifier, as well as
+// the proof. Proving control of acme.com claims the namespace com.acme,
+// after which tagged artifacts applied there must be signed with the key
+// that proved it (see signing.OwnershipAdmission).
+package namespaceowners
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// Config bundles the inputs for Register.
+type Config struct {
+	BasePrefix string
+	Store      *v1alpha1store.NamespaceOwnerStore
+	// Verifier checks proofs. nil uses one that reaches DNS and the
+	// network directly.
+	Verifier *auth.DomainProofVerifier
+	// IsRegistryAdmin gates releasing a namespace. nil means nobody is an
+	// admin.
+	IsRegistryAdmin func(ctx context.Context) bool
+}
+
+type claimInput struct {
+	Body arv0.ClaimNamespaceRequest
+}
+
+type ownerOutput struct {
+	Body arv0.NamespaceOwner
+}
+
+type listOutput struct {
+	Body arv0.NamespaceOwnerList
+}
+
+type namespaceInput struct {
+	Namespace string `path:"namespace" doc:"Owned namespace, e.g. com.acme."`
+}
+
+// Register wires the claim, list, get and release routes.
+func Register(api huma.API, cfg Config) {
+	verifier := cfg.Verifier
+	if verifier == nil {
+		verifier = auth.NewDomainProofVerifier(auth.DomainProofConfig{})
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "claim-namespace",
+		Method:      http.MethodPost,
+		Path:        cfg.BasePrefix + "/namespace-owners",
+		Summary:     "Claim a namespace with a domain proof",
+	}, func(ctx context.Context, in *claimInput) (*ownerOutput, error) {
+		subject, err := claimantOf(ctx)
+		if err != nil {
+			return nil, err
+		}
+		namespace, err := auth.NamespaceForDomain(in.Body.Domain)
+		if err != nil {
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		key, err := verifier.Verify(ctx, auth.DomainProof{
+			Method:    auth.Method(in.Body.Method),
+			Domain:    in.Body.Domain,
+			Timestamp: in.Body.Timestamp,
+			Signature: in.Body.Signature,
+		})
+		if err != nil {
+			return nil, huma.Error403Forbidden(err.Error())
+		}
+		stored, err := cfg.Store.Put(ctx, v1alpha1store.NamespaceOwner{
+			Namespace:  namespace,
+			Domain:     in.Body.Domain,
+			Method:     auth.Method(in.Body.Method),
+			PublicKey:  key,
+			VerifiedBy: subject,
+		})
+		if err != nil {
+			return nil, huma.Error500InternalServerError("store namespace owner", err)
+		}
+		return &ownerOutput{Body: toAPI(stored)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-namespace-owners",
+		Method:      http.MethodGet,
+		Path:        cfg.BasePrefix + "/namespace-owners",
+		Summary:     "List verified namespace owners",
+	}, func(ctx context.Context, _ *struct{}) (*listOutput, error) {
+		stored, err := cfg.Store.List(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("list namespace owners", err)
+		}
+		out := arv0.NamespaceOwnerList{Owners: make([]arv0.NamespaceOwner, 0, len(stored))}
+		for _, owner := range stored {
+			out.Owners = append(out.Owners, toAPI(owner))
+		}
+		return &listOutput{Body: out}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-namespace-owner",
+		Method:      http.MethodGet,
+		Path:        cfg.BasePrefix + "/namespace-owners/{namespace}",
+		Summary:     "Get the verified owner of a namespace",
+	}, func(ctx context.Context, in *namespaceInput) (*ownerOutput, error) {
+		owner, err := cfg.Store.Get(ctx, in.Namespace)
+		if errors.Is(err, pkgdb.ErrNotFound) {
+			return nil, huma.Error404NotFound(fmt.Sprintf("namespace %q has no verified owner", in.Namespace))
+		}
+		if err != nil {
+			return nil, huma.Error500InternalServerError("load namespace owner", err)
+		}
+		return &ownerOutput{Body: toAPI(owner)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "release-namespace",
+		Method:        http.MethodDelete,
+		Path:          cfg.BasePrefix + "/namespace-owners/{namespace}",
+		Summary:       "Release a claimed namespace",
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, in *namespaceInput) (*struct{}, error) {
+		if cfg.IsRegistryAdmin == nil || !cfg.IsRegistryAdmin(ctx) {
+			return nil, huma.Error403Forbidden("only registry admins may release a namespace")
+		}
+		err := cfg.Store.Delete(ctx, in.Namespace)
+		if errors.Is(err, pkgdb.ErrNotFound) {
+			return nil, huma.Error404NotFound(fmt.Sprintf("namespace %q has no verified owner", in.Namespace))
+		}
+		if err != nil {
+			return nil, huma.Error500InternalServerError("release namespace", err)
+		}
+		return nil, nil
+	})
+}
+
+// claimantOf returns the subject of the signed-in caller claiming a
+// namespace. Anonymous callers cannot claim.
+func claimantOf(ctx context.Context) (string, error) {
+	session, ok := auth.AuthSessionFrom(ctx)
+	if !ok || session == nil || auth.IsPublicSession(session) {
+		return "", huma.Error401Unauthorized("sign in to claim a namespace")
+	}
+	subject := session.Principal().User.Subject
+	if subject == "" {
+		return "", huma.Error401Unauthorized("sign in to claim a namespace")
+	}
+	return subject, nil
+}
+
+func toAPI(owner v1alpha1store.NamespaceOwner) arv0.NamespaceOwner {
+	return arv0.NamespaceOwner{
+		Namespace:  owner.Namespace,
+		Domain:     owner.Domain,
+		Method:     string(owner.Method),
+		PublicKey:  base64.StdEncoding.EncodeToString(owner.PublicKey),
+		VerifiedBy: owner.VerifiedBy,
+		VerifiedAt: owner.VerifiedAt,
+		CreatedAt:  owner.CreatedAt,
+	}
+}
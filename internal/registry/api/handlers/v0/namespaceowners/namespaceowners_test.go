@@ -0,0 +1,129 @@
+//go:build integration
+
+package namespaceowners
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/require"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// fakeTXTResolver serves TXT records from a map, so proofs verify offline.
+type fakeTXTResolver map[string][]string
+
+func (r fakeTXTResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	records, ok := r[name]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return records, nil
+}
+
+// userAuthn signs in "Authorization: Bearer user:NAME" as NAME; other
+// requests are anonymous.
+type userAuthn struct{}
+
+type userSession string
+
+func (s userSession) Principal() auth.Principal {
+	return auth.Principal{User: auth.User{Subject: string(s)}}
+}
+
+func (userAuthn) Authenticate(_ context.Context, headers func(string) string, _ url.Values) (auth.Session, error) {
+	name, ok := strings.CutPrefix(headers("Authorization"), "Bearer user:")
+	if !ok {
+		return nil, nil
+	}
+	return userSession(name), nil
+}
+
+const asAlice = "Authorization: Bearer user:alice"
+
+func newOwnerAPI(t *testing.T, records fakeTXTResolver, admin bool) humatest.TestAPI {
+	t.Helper()
+	pool := v1alpha1store.NewTestPool(t)
+	_, api := humatest.New(t)
+	api.UseMiddleware(auth.AuthnMiddleware(userAuthn{}))
+	Register(api, Config{
+		BasePrefix:      "/v0",
+		Store:           v1alpha1store.NewNamespaceOwnerStore(pool, v1alpha1store.TestSchema()),
+		Verifier:        auth.NewDomainProofVerifier(auth.DomainProofConfig{Resolver: records}),
+		IsRegistryAdmin: func(context.Context) bool { return admin },
+	})
+	return api
+}
+
+func claim(key ed25519.PrivateKey, domain string) arv0.ClaimNamespaceRequest {
+	ts := time.Now().UTC().Format(time.RFC3339)
+	req := arv0.ClaimNamespaceRequest{Method: string(auth.MethodDNS), Domain: domain, Timestamp: ts}
+	if message, err := auth.DomainProofMessage(domain, ts); err == nil {
+		req.Signature = hex.EncodeToString(ed25519.Sign(key, message))
+	}
+	return req
+}
+
+func TestNamespaceOwnerLifecycle(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	api := newOwnerAPI(t, fakeTXTResolver{"acme.com": {auth.FormatDomainProofRecord(pub)}}, true)
+
+	resp := api.Post("/v0/namespace-owners", asAlice, claim(key, "acme.com"))
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var owner arv0.NamespaceOwner
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &owner))
+	require.Equal(t, "com.acme", owner.Namespace)
+	require.Equal(t, "alice", owner.VerifiedBy)
+	require.Equal(t, "dns", owner.Method)
+	require.Equal(t, base64.StdEncoding.EncodeToString(pub), owner.PublicKey)
+
+	resp = api.Get("/v0/namespace-owners/com.acme")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	resp = api.Get("/v0/namespace-owners")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var list arv0.NamespaceOwnerList
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &list))
+	require.Len(t, list.Owners, 1)
+
+	resp = api.Delete("/v0/namespace-owners/com.acme")
+	require.Equal(t, http.StatusNoContent, resp.Code, resp.Body.String())
+	resp = api.Get("/v0/namespace-owners/com.acme")
+	require.Equal(t, http.StatusNotFound, resp.Code, resp.Body.String())
+}
+
+func TestClaimNamespace_Rejected(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	api := newOwnerAPI(t, fakeTXTResolver{"acme.com": {auth.FormatDomainProofRecord(pub)}}, false)
+
+	resp := api.Post("/v0/namespace-owners", claim(key, "acme.com"))
+	require.Equal(t, http.StatusUnauthorized, resp.Code, "anonymous callers cannot claim")
+	resp = api.Post("/v0/namespace-owners", asAlice, claim(otherKey, "acme.com"))
+	require.Equal(t, http.StatusForbidden, resp.Code, resp.Body.String())
+	resp = api.Post("/v0/namespace-owners", asAlice, claim(key, "unpublished.com"))
+	require.Equal(t, http.StatusForbidden, resp.Code, resp.Body.String())
+	resp = api.Post("/v0/namespace-owners", asAlice, claim(key, "localhost"))
+	require.Equal(t, http.StatusBadRequest, resp.Code, resp.Body.String())
+
+	resp = api.Post("/v0/namespace-owners", asAlice, claim(key, "acme.com"))
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	resp = api.Delete("/v0/namespace-owners/com.acme")
+	require.Equal(t, http.StatusForbidden, resp.Code, "only admins release namespaces")
+}
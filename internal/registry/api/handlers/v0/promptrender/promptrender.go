@@ -0,0 +1,109 @@
+// Package promptrender owns the Prompt render subresource:
+// `POST /v0/prompts/{name}/{tag}/render`. It validates caller-supplied
+// arguments against the Prompt's declared Arguments and returns the rendered
+// messages. The endpoint is bound to one specific kind (Prompt); the rest of
+// the v1alpha1 CRUD surface lives in crud.
+package promptrender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// Config bundles the inputs for Register.
+type Config struct {
+	BasePrefix string
+	Store      *v1alpha1store.Store
+	// Authorize gates the request the same way the regular Prompt GET
+	// handler does: rendering reveals the Prompt's content. nil means no
+	// gate. Wire from PerKindHooks.Authorizers[KindPrompt] at router boot.
+	Authorize func(ctx context.Context, in resource.AuthorizeInput) error
+}
+
+type renderPromptInput struct {
+	Namespace string `query:"namespace" doc:"Namespace (internal; defaults to 'default')."`
+	Name      string `path:"name"`
+	Tag       string `path:"tag"`
+	Body      struct {
+		Arguments map[string]string `json:"arguments,omitempty" doc:"Argument values keyed by declared argument name, in string form."`
+	}
+}
+
+type renderPromptOutput struct {
+	Body struct {
+		Messages []v1alpha1.PromptMessage `json:"messages"`
+	}
+}
+
+// Register wires POST {basePrefix}/prompts/{name}/{tag}/render?namespace=default.
+// {tag} resolves like the Prompt GET route: a literal tag, a tag alias, or a
+// semver range. Undeclared, missing required, and mistyped arguments return
+// 400 naming each offending argument.
+func Register(api huma.API, cfg Config) {
+	huma.Register(api, huma.Operation{
+		OperationID: "render-prompt",
+		Method:      http.MethodPost,
+		Path:        cfg.BasePrefix + "/prompts/{name}/{tag}/render",
+		Summary:     "Render a Prompt with arguments",
+	}, func(ctx context.Context, in *renderPromptInput) (*renderPromptOutput, error) {
+		ns := in.Namespace
+		if ns == "" {
+			ns = v1alpha1.DefaultNamespace
+		}
+		// Names allow `/` so callers must `%2F`-escape them on the wire;
+		// Huma keeps the captures raw, so unescape before consulting
+		// the Store.
+		name, err := url.PathUnescape(in.Name)
+		if err != nil {
+			return nil, huma.Error400BadRequest(fmt.Sprintf("invalid name path segment: %v", err))
+		}
+		tag, err := url.PathUnescape(in.Tag)
+		if err != nil {
+			return nil, huma.Error400BadRequest(fmt.Sprintf("invalid tag path segment: %v", err))
+		}
+		if cfg.Authorize != nil {
+			if err := cfg.Authorize(ctx, resource.AuthorizeInput{
+				Verb: "get", Kind: v1alpha1.KindPrompt,
+				Namespace: ns, Name: name, Tag: tag,
+			}); err != nil {
+				return nil, err
+			}
+		}
+		row, err := cfg.Store.GetByRef(ctx, ns, name, tag)
+		if errors.Is(err, pkgdb.ErrInvalidInput) {
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		if err != nil {
+			if errors.Is(err, pkgdb.ErrNotFound) {
+				return nil, huma.Error404NotFound(fmt.Sprintf("Prompt %q/%q@%q not found", ns, name, tag))
+			}
+			return nil, huma.Error500InternalServerError("fetch Prompt", err)
+		}
+		prompt := &v1alpha1.Prompt{}
+		if err := prompt.UnmarshalSpec(row.Spec); err != nil {
+			return nil, huma.Error500InternalServerError("decode Prompt spec", err)
+		}
+
+		messages, err := prompt.Spec.Render(in.Body.Arguments)
+		if err != nil {
+			var fieldErrs v1alpha1.FieldErrors
+			if errors.As(err, &fieldErrs) {
+				return nil, huma.Error400BadRequest("arguments: " + err.Error())
+			}
+			return nil, huma.Error422UnprocessableEntity("render: " + err.Error())
+		}
+		out := &renderPromptOutput{}
+		out.Body.Messages = messages
+		return out, nil
+	})
+}
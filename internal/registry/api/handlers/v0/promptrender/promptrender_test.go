@@ -0,0 +1,111 @@
+//go:build integration
+
+package promptrender_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/promptrender"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+func newRenderAPI(t *testing.T, authorize func(ctx context.Context, in resource.AuthorizeInput) error) humatest.TestAPI {
+	t.Helper()
+	pool := v1alpha1store.NewTestPool(t)
+	stores := v1alpha1store.NewStores(pool, v1alpha1store.TestSchemaRegistry())
+	_, err := stores[v1alpha1.KindPrompt].Upsert(t.Context(), &v1alpha1.Prompt{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "summarize", Tag: "v1"},
+		Spec: v1alpha1.PromptSpec{
+			Arguments: []v1alpha1.PromptArgument{
+				{Name: "topic", Required: true},
+				{Name: "words", Type: v1alpha1.PromptArgumentTypeInteger, Default: "200"},
+			},
+			Content: "Summarize {{ .topic }} in under {{ .words }} words.",
+		},
+	})
+	require.NoError(t, err)
+
+	_, api := humatest.New(t)
+	promptrender.Register(api, promptrender.Config{
+		BasePrefix: "/v0",
+		Store:      stores[v1alpha1.KindPrompt],
+		Authorize:  authorize,
+	})
+	return api
+}
+
+func TestRenderPrompt(t *testing.T) {
+	api := newRenderAPI(t, nil)
+
+	resp := api.Post("/v0/prompts/summarize/v1/render", map[string]any{
+		"arguments": map[string]string{"topic": "the Q3 report", "words": "50"},
+	})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var out struct {
+		Messages []v1alpha1.PromptMessage `json:"messages"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+	require.Equal(t, []v1alpha1.PromptMessage{{Role: "user", Content: "Summarize the Q3 report in under 50 words."}}, out.Messages)
+
+	// Missing required, mistyped, and undeclared arguments each name the
+	// offending argument.
+	resp = api.Post("/v0/prompts/summarize/v1/render", map[string]any{
+		"arguments": map[string]string{"words": "many", "tone": "dry"},
+	})
+	require.Equal(t, http.StatusBadRequest, resp.Code, resp.Body.String())
+	require.Contains(t, resp.Body.String(), "arguments.topic")
+	require.Contains(t, resp.Body.String(), "arguments.words")
+	require.Contains(t, resp.Body.String(), "arguments.tone")
+
+	resp = api.Post("/v0/prompts/summarize/v2/render", map[string]any{})
+	require.Equal(t, http.StatusNotFound, resp.Code, resp.Body.String())
+}
+
+func TestRenderPrompt_ResolvesAliasesAndRanges(t *testing.T) {
+	pool := v1alpha1store.NewTestPool(t)
+	store := v1alpha1store.NewStores(pool, v1alpha1store.TestSchemaRegistry())[v1alpha1.KindPrompt]
+	for _, tag := range []string{"1.0.0", "1.1.0"} {
+		_, err := store.Upsert(t.Context(), &v1alpha1.Prompt{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "greet", Tag: tag},
+			Spec:     v1alpha1.PromptSpec{Content: "Hello from " + tag + "."},
+		})
+		require.NoError(t, err)
+	}
+	_, _, err := store.PromoteTag(t.Context(), "default", "greet", "1.0.0", "stable")
+	require.NoError(t, err)
+
+	_, api := humatest.New(t)
+	promptrender.Register(api, promptrender.Config{BasePrefix: "/v0", Store: store})
+
+	for tag, want := range map[string]string{"stable": "Hello from 1.0.0.", "%5E1": "Hello from 1.1.0."} {
+		resp := api.Post("/v0/prompts/greet/"+tag+"/render", map[string]any{})
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+		var out struct {
+			Messages []v1alpha1.PromptMessage `json:"messages"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+		require.Equal(t, want, out.Messages[0].Content, tag)
+	}
+}
+
+func TestRenderPrompt_RespectsAuthorize(t *testing.T) {
+	api := newRenderAPI(t, func(ctx context.Context, in resource.AuthorizeInput) error {
+		require.Equal(t, v1alpha1.KindPrompt, in.Kind)
+		require.Equal(t, "get", in.Verb)
+		return huma.Error403Forbidden("denied")
+	})
+
+	resp := api.Post("/v0/prompts/summarize/v1/render", map[string]any{
+		"arguments": map[string]string{"topic": "x"},
+	})
+	require.Equal(t, http.StatusForbidden, resp.Code, resp.Body.String())
+}
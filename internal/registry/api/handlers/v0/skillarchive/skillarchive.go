@@ -0,0 +1,180 @@
+// Package skillarchive owns the skill tarball upload surface:
+// `POST /v0/skill-archives` stores a gzip-compressed tarball under its
+// sha256 digest and `GET /v0/skill-archives/{digest}` returns it. A Skill
+// references an upload through spec.source.archive.digest; the Skill
+// controller pins that digest in status.resolvedSource once the archive
+// exists.
+//
+// Archives are content-addressed and unnamespaced. Authorization runs
+// against kind Skill with no namespace or name: uploading is an "apply",
+// downloading a "get".
+package skillarchive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+const (
+	// MaxArchiveBytes caps an upload's compressed size.
+	MaxArchiveBytes = 32 << 20
+	// maxExpandedBytes caps the summed size of the tarball's files so a
+	// small, highly compressed upload cannot expand without bound.
+	maxExpandedBytes = 256 << 20
+
+	archiveContentType = "application/gzip"
+	skillManifestFile  = "SKILL.md"
+)
+
+// Config bundles the inputs for Register.
+type Config struct {
+	BasePrefix string
+	Archives   *v1alpha1store.SkillArchiveStore
+	// Authorize gates uploads (Verb "apply") and downloads (Verb "get") for
+	// kind Skill. nil means no gate. Wire from
+	// PerKindHooks.Authorizers[KindSkill] at router boot.
+	Authorize func(ctx context.Context, in resource.AuthorizeInput) error
+}
+
+type uploadInput struct {
+	RawBody []byte `contentType:"application/gzip" doc:"gzip-compressed tar of the skill directory, with SKILL.md at its root."`
+}
+
+type uploadOutput struct {
+	Body ArchiveInfo
+}
+
+// ArchiveInfo describes a stored archive.
+type ArchiveInfo struct {
+	Digest string `json:"digest" doc:"sha256 digest to reference from spec.source.archive.digest."`
+	Size   int64  `json:"size" doc:"Compressed size in bytes."`
+}
+
+type downloadInput struct {
+	Digest string `path:"digest" doc:"Archive digest (sha256:<hex>)."`
+}
+
+type downloadOutput struct {
+	ContentType string `header:"Content-Type"`
+	Body        []byte
+}
+
+// Register wires the upload and download routes.
+func Register(api huma.API, cfg Config) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "upload-skill-archive",
+		Method:        http.MethodPost,
+		Path:          cfg.BasePrefix + "/skill-archives",
+		Summary:       "Upload a skill tarball",
+		DefaultStatus: http.StatusCreated,
+		MaxBodyBytes:  MaxArchiveBytes,
+	}, func(ctx context.Context, in *uploadInput) (*uploadOutput, error) {
+		if cfg.Authorize != nil {
+			if err := cfg.Authorize(ctx, resource.AuthorizeInput{Verb: "apply", Kind: v1alpha1.KindSkill}); err != nil {
+				return nil, err
+			}
+		}
+		if err := ValidateArchive(in.RawBody); err != nil {
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		stored, err := cfg.Archives.Put(ctx, in.RawBody)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("store skill archive", err)
+		}
+		return &uploadOutput{Body: ArchiveInfo{Digest: stored.Digest, Size: stored.Size}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-skill-archive",
+		Method:      http.MethodGet,
+		Path:        cfg.BasePrefix + "/skill-archives/{digest}",
+		Summary:     "Download an uploaded skill tarball",
+	}, func(ctx context.Context, in *downloadInput) (*downloadOutput, error) {
+		digest, err := url.PathUnescape(in.Digest)
+		if err != nil {
+			return nil, huma.Error400BadRequest(fmt.Sprintf("invalid digest path segment: %v", err))
+		}
+		if cfg.Authorize != nil {
+			if err := cfg.Authorize(ctx, resource.AuthorizeInput{Verb: "get", Kind: v1alpha1.KindSkill}); err != nil {
+				return nil, err
+			}
+		}
+		archive, err := cfg.Archives.Get(ctx, digest)
+		if err != nil {
+			if errors.Is(err, pkgdb.ErrNotFound) {
+				return nil, huma.Error404NotFound(fmt.Sprintf("skill archive %q not found", digest))
+			}
+			return nil, huma.Error500InternalServerError("fetch skill archive", err)
+		}
+		return &downloadOutput{ContentType: archiveContentType, Body: archive.Content}, nil
+	})
+}
+
+// ValidateArchive checks that content is a gzip-compressed tar holding only
+// regular files and directories at relative, non-escaping paths, with a
+// SKILL.md at its root. Links are rejected so extracting an archive can
+// never write outside the target directory.
+func ValidateArchive(content []byte) error {
+	if len(content) == 0 {
+		return errors.New("archive is empty")
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("archive is not gzip-compressed: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	tr := tar.NewReader(gz)
+	var (
+		expanded    int64
+		hasManifest bool
+	)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read archive: %w", err)
+		}
+		name := strings.TrimPrefix(hdr.Name, "./")
+		clean := path.Clean(name)
+		if path.IsAbs(name) || clean == ".." || strings.HasPrefix(clean, "../") {
+			return fmt.Errorf("archive entry %q escapes the archive root", hdr.Name)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			continue
+		case tar.TypeReg:
+		default:
+			return fmt.Errorf("archive entry %q: only regular files and directories are allowed", hdr.Name)
+		}
+		expanded += hdr.Size
+		if expanded > maxExpandedBytes {
+			return fmt.Errorf("archive expands past %d bytes", maxExpandedBytes)
+		}
+		if clean == skillManifestFile {
+			hasManifest = true
+		}
+	}
+	if !hasManifest {
+		return fmt.Errorf("archive has no %s at its root", skillManifestFile)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+//go:build integration
+
+package skillarchive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+func newArchiveAPI(t *testing.T, authorize func(ctx context.Context, in resource.AuthorizeInput) error) humatest.TestAPI {
+	t.Helper()
+	pool := v1alpha1store.NewTestPool(t)
+	_, api := humatest.New(t)
+	Register(api, Config{
+		BasePrefix: "/v0",
+		Archives:   v1alpha1store.NewSkillArchiveStore(pool, v1alpha1store.TestSchema()),
+		Authorize:  authorize,
+	})
+	return api
+}
+
+func TestUploadAndDownloadSkillArchive(t *testing.T) {
+	api := newArchiveAPI(t, nil)
+	content := buildArchive(t, map[string][]byte{"SKILL.md": []byte("# pdf tools")})
+
+	resp := api.Post("/v0/skill-archives", "Content-Type: application/gzip", bytes.NewReader(content))
+	require.Equal(t, http.StatusCreated, resp.Code, resp.Body.String())
+	var info ArchiveInfo
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &info))
+	require.Equal(t, v1alpha1store.SkillArchiveDigest(content), info.Digest)
+	require.Equal(t, int64(len(content)), info.Size)
+
+	resp = api.Get("/v0/skill-archives/" + info.Digest)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	require.Equal(t, content, resp.Body.Bytes())
+
+	resp = api.Get("/v0/skill-archives/" + v1alpha1store.SkillArchiveDigest([]byte("missing")))
+	require.Equal(t, http.StatusNotFound, resp.Code, resp.Body.String())
+
+	resp = api.Post("/v0/skill-archives", "Content-Type: application/gzip",
+		bytes.NewReader(buildArchive(t, map[string][]byte{"README.md": []byte("x")})))
+	require.Equal(t, http.StatusBadRequest, resp.Code, resp.Body.String())
+	require.Contains(t, resp.Body.String(), "SKILL.md")
+}
+
+func TestUploadSkillArchive_RespectsAuthorize(t *testing.T) {
+	api := newArchiveAPI(t, func(ctx context.Context, in resource.AuthorizeInput) error {
+		require.Equal(t, v1alpha1.KindSkill, in.Kind)
+		require.Equal(t, "apply", in.Verb)
+		return huma.Error403Forbidden("denied")
+	})
+	content := buildArchive(t, map[string][]byte{"SKILL.md": []byte("# pdf tools")})
+	resp := api.Post("/v0/skill-archives", "Content-Type: application/gzip", bytes.NewReader(content))
+	require.Equal(t, http.StatusForbidden, resp.Code, resp.Body.String())
+}
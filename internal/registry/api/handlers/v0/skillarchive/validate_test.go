@@ -0,0 +1,55 @@
+package skillarchive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// buildArchive returns a gzip tarball of entries. A nil value writes a
+// symlink to "/etc/passwd" instead of a regular file.
+func buildArchive(t *testing.T, entries map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range entries {
+		hdr := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(body)), Typeflag: tar.TypeReg}
+		if body == nil {
+			hdr = &tar.Header{Name: name, Linkname: "/etc/passwd", Typeflag: tar.TypeSymlink}
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		if body != nil {
+			_, err := tw.Write(body)
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestValidateArchive(t *testing.T) {
+	require.NoError(t, ValidateArchive(buildArchive(t, map[string][]byte{
+		"SKILL.md":          []byte("# skill"),
+		"./scripts/run.sh":  []byte("echo hi"),
+		"references/api.md": []byte("docs"),
+	})))
+
+	for name, content := range map[string][]byte{
+		"empty":          nil,
+		"not gzip":       []byte("plain text"),
+		"no SKILL.md":    buildArchive(t, map[string][]byte{"README.md": []byte("x")}),
+		"nested SKILL":   buildArchive(t, map[string][]byte{"skill/SKILL.md": []byte("x")}),
+		"path traversal": buildArchive(t, map[string][]byte{"SKILL.md": []byte("x"), "../evil": []byte("x")}),
+		"absolute path":  buildArchive(t, map[string][]byte{"SKILL.md": []byte("x"), "/etc/evil": []byte("x")}),
+		"symlink":        buildArchive(t, map[string][]byte{"SKILL.md": []byte("x"), "link": nil}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Error(t, ValidateArchive(content))
+		})
+	}
+}
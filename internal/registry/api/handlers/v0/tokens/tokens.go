@@ -0,0 +1,224 @@
+// Package tokens owns the API token surface: `POST /v0/tokens` issues a
+// scoped, expiring token, `GET /v0/tokens` lists the caller's
+// tokens and `DELETE /v0/tokens/{id}` revokes one.
+//
+// Tokens are managed by signed-in users and act as their owner, or as a
+// service account when a registry admin issues them to one. A request
+// authenticated with an API token may list and revoke tokens but not
+// create them, so a leaked token cannot mint longer-lived ones. Every token
+// expires within MaxLifetime.
+package tokens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// MaxLifetime is the longest an API token may stay valid.
+const MaxLifetime = 365 * 24 * time.Hour
+
+// Config bundles the inputs for Register.
+type Config struct {
+	BasePrefix string
+	Store      *v1alpha1store.APITokenStore
+	// IsRegistryAdmin gates issuing service-account tokens and listing
+	// every user's tokens. nil means nobody is an admin.
+	IsRegistryAdmin func(ctx context.Context) bool
+}
+
+type createInput struct {
+	Body arv0.CreateAPITokenRequest
+}
+
+type createOutput struct {
+	Body arv0.CreateAPITokenResponse
+}
+
+type listInput struct {
+	All bool `query:"all" doc:"List every user's tokens. Requires registry admin."`
+}
+
+type listOutput struct {
+	Body arv0.APITokenList
+}
+
+type revokeInput struct {
+	ID string `path:"id" doc:"Token ID."`
+}
+
+// Register wires the create, list and revoke routes.
+func Register(api huma.API, cfg Config) {
+	isAdmin := func(ctx context.Context) bool {
+		return cfg.IsRegistryAdmin != nil && cfg.IsRegistryAdmin(ctx)
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-token",
+		Method:        http.MethodPost,
+		Path:          cfg.BasePrefix + "/tokens",
+		Summary:       "Issue an API token",
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, in *createInput) (*createOutput, error) {
+		caller, err := callerOf(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if auth.IsAPITokenSession(caller.session) {
+			return nil, huma.Error403Forbidden("API tokens cannot issue API tokens; sign in to create one")
+		}
+		scopes, err := toScopes(in.Body.Scopes)
+		if err != nil {
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		now := time.Now()
+		if !in.Body.ExpiresAt.After(now) {
+			return nil, huma.Error400BadRequest("expiresAt must be in the future")
+		}
+		if in.Body.ExpiresAt.After(now.Add(MaxLifetime)) {
+			return nil, huma.Error400BadRequest("expiresAt must be at most a year away")
+		}
+		token := v1alpha1store.APIToken{
+			Name:      in.Body.Name,
+			Owner:     caller.subject,
+			Subject:   caller.subject,
+			Scopes:    scopes,
+			ExpiresAt: in.Body.ExpiresAt,
+		}
+		if sa := in.Body.ServiceAccount; sa != "" {
+			if !v1alpha1.DNSSubdomainRegex.MatchString(sa) || len(sa) > v1alpha1.DNSSubdomainMaxLen {
+				return nil, huma.Error400BadRequest(fmt.Sprintf("service account %q must be a DNS-1123 subdomain", sa))
+			}
+			if !isAdmin(ctx) {
+				return nil, huma.Error403Forbidden("only registry admins may issue service-account tokens")
+			}
+			token.Subject = auth.ServiceAccountSubjectPrefix + sa
+		}
+
+		secret, hash := auth.NewAPIToken()
+		stored, err := cfg.Store.Create(ctx, token, hash)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("store API token", err)
+		}
+		return &createOutput{Body: arv0.CreateAPITokenResponse{Token: secret, APIToken: toAPI(stored)}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-tokens",
+		Method:      http.MethodGet,
+		Path:        cfg.BasePrefix + "/tokens",
+		Summary:     "List API tokens",
+	}, func(ctx context.Context, in *listInput) (*listOutput, error) {
+		caller, err := callerOf(ctx)
+		if err != nil {
+			return nil, err
+		}
+		owner := caller.subject
+		if in.All {
+			if !isAdmin(ctx) {
+				return nil, huma.Error403Forbidden("only registry admins may list every user's tokens")
+			}
+			owner = ""
+		}
+		stored, err := cfg.Store.List(ctx, owner)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("list API tokens", err)
+		}
+		out := arv0.APITokenList{Tokens: make([]arv0.APIToken, 0, len(stored))}
+		for _, token := range stored {
+			out.Tokens = append(out.Tokens, toAPI(token))
+		}
+		return &listOutput{Body: out}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "revoke-token",
+		Method:        http.MethodDelete,
+		Path:          cfg.BasePrefix + "/tokens/{id}",
+		Summary:       "Revoke an API token",
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, in *revokeInput) (*struct{}, error) {
+		caller, err := callerOf(ctx)
+		if err != nil {
+			return nil, err
+		}
+		notFound := huma.Error404NotFound(fmt.Sprintf("API token %q not found", in.ID))
+		token, err := cfg.Store.Get(ctx, in.ID)
+		if errors.Is(err, pkgdb.ErrNotFound) {
+			return nil, notFound
+		}
+		if err != nil {
+			return nil, huma.Error500InternalServerError("load API token", err)
+		}
+		// Tokens of other users are reported missing rather than forbidden,
+		// so their IDs cannot be probed.
+		if token.Owner != caller.subject && token.Subject != caller.subject && !isAdmin(ctx) {
+			return nil, notFound
+		}
+		if _, err := cfg.Store.Revoke(ctx, in.ID); err != nil {
+			return nil, huma.Error500InternalServerError("revoke API token", err)
+		}
+		return nil, nil
+	})
+}
+
+// caller is the signed-in identity managing tokens.
+type caller struct {
+	session auth.Session
+	subject string
+}
+
+func callerOf(ctx context.Context) (caller, error) {
+	session, ok := auth.AuthSessionFrom(ctx)
+	if !ok || auth.IsPublicSession(session) {
+		return caller{}, huma.Error401Unauthorized("sign in to manage API tokens")
+	}
+	user := session.Principal().User
+	if user.Subject == "" {
+		return caller{}, huma.Error401Unauthorized("sign in to manage API tokens")
+	}
+	return caller{session: session, subject: user.Subject}, nil
+}
+
+func toScopes(in []arv0.TokenScope) ([]auth.Permission, error) {
+	if len(in) == 0 {
+		return nil, errors.New("at least one scope is required")
+	}
+	out := make([]auth.Permission, 0, len(in))
+	for _, scope := range in {
+		permission := auth.Permission{Action: auth.PermissionAction(scope.Action), ResourcePattern: scope.Resource}
+		if err := auth.ValidateScope(permission); err != nil {
+			return nil, err
+		}
+		out = append(out, permission)
+	}
+	return out, nil
+}
+
+func toAPI(token v1alpha1store.APIToken) arv0.APIToken {
+	scopes := make([]arv0.TokenScope, 0, len(token.Scopes))
+	for _, scope := range token.Scopes {
+		scopes = append(scopes, arv0.TokenScope{Action: string(scope.Action), Resource: scope.ResourcePattern})
+	}
+	return arv0.APIToken{
+		ID:         token.ID,
+		Name:       token.Name,
+		Owner:      token.Owner,
+		Subject:    token.Subject,
+		Scopes:     scopes,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		RevokedAt:  token.RevokedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
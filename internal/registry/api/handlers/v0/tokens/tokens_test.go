@@ -0,0 +1,156 @@
+//go:build integration
+
+package tokens
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/apitokens"
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// userAuthn signs in "Bearer user:NAME" as NAME; "root" is the only
+// registry admin.
+type userAuthn struct{}
+
+type userSession string
+
+func (s userSession) Principal() auth.Principal {
+	return auth.Principal{User: auth.User{Subject: string(s), Groups: []string{"ops"}}}
+}
+
+func (userAuthn) Authenticate(_ context.Context, headers func(string) string, _ url.Values) (auth.Session, error) {
+	name, ok := strings.CutPrefix(headers("Authorization"), "Bearer user:")
+	if !ok {
+		return nil, errors.New("not a test user")
+	}
+	return userSession(name), nil
+}
+
+func newTokenAPI(t *testing.T) humatest.TestAPI {
+	t.Helper()
+	pool := v1alpha1store.NewTestPool(t)
+	store := v1alpha1store.NewAPITokenStore(pool, v1alpha1store.TestSchema())
+	_, api := humatest.New(t)
+	api.UseMiddleware(auth.AuthnMiddleware(auth.ChainAuthnProviders(apitokens.NewAuthn(store), userAuthn{})))
+	Register(api, Config{
+		BasePrefix: "/v0",
+		Store:      store,
+		IsRegistryAdmin: func(ctx context.Context) bool {
+			s, _ := auth.AuthSessionFrom(ctx)
+			return s != nil && !auth.IsAPITokenSession(s) && s.Principal().User.Subject == "root"
+		},
+	})
+	return api
+}
+
+// createToken issues req as the user as, expiring in an hour unless req
+// says otherwise.
+func createToken(t *testing.T, api humatest.TestAPI, as string, req arv0.CreateAPITokenRequest) arv0.CreateAPITokenResponse {
+	t.Helper()
+	if req.ExpiresAt.IsZero() {
+		req.ExpiresAt = time.Now().Add(time.Hour)
+	}
+	resp := api.Post("/v0/tokens", "Authorization: Bearer user:"+as, req)
+	require.Equal(t, http.StatusCreated, resp.Code, resp.Body.String())
+	var out arv0.CreateAPITokenResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+	return out
+}
+
+func listTokens(t *testing.T, api humatest.TestAPI, authz, query string) []arv0.APIToken {
+	t.Helper()
+	resp := api.Get("/v0/tokens"+query, "Authorization: "+authz)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var out arv0.APITokenList
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+	return out.Tokens
+}
+
+func TestTokenLifecycle(t *testing.T) {
+	api := newTokenAPI(t)
+	readAll := []arv0.TokenScope{{Action: "read", Resource: "*"}}
+
+	created := createToken(t, api, "alice", arv0.CreateAPITokenRequest{Name: "ci", Scopes: readAll})
+	require.True(t, strings.HasPrefix(created.Token, auth.APITokenPrefix))
+	require.Equal(t, "alice", created.APIToken.Owner)
+	require.Equal(t, "alice", created.APIToken.Subject)
+	createToken(t, api, "bob", arv0.CreateAPITokenRequest{Name: "bob-ci", Scopes: readAll})
+
+	mine := listTokens(t, api, "Bearer "+created.Token, "")
+	require.Len(t, mine, 1, "a token authenticates as its owner")
+	require.Equal(t, created.APIToken.ID, mine[0].ID)
+	require.NotNil(t, mine[0].LastUsedAt, "use is recorded")
+
+	resp := api.Post("/v0/tokens", "Authorization: Bearer "+created.Token, arv0.CreateAPITokenRequest{Name: "more", Scopes: readAll, ExpiresAt: time.Now().Add(time.Hour)})
+	require.Equal(t, http.StatusForbidden, resp.Code, "tokens cannot mint tokens")
+
+	resp = api.Delete("/v0/tokens/"+created.APIToken.ID, "Authorization: Bearer user:bob")
+	require.Equal(t, http.StatusNotFound, resp.Code, "other users' tokens are hidden")
+
+	resp = api.Delete("/v0/tokens/"+created.APIToken.ID, "Authorization: Bearer user:alice")
+	require.Equal(t, http.StatusNoContent, resp.Code, resp.Body.String())
+	resp = api.Get("/v0/tokens", "Authorization: Bearer "+created.Token)
+	require.Equal(t, http.StatusUnauthorized, resp.Code, "a revoked token no longer authenticates")
+	revoked := listTokens(t, api, "Bearer user:alice", "")
+	require.NotNil(t, revoked[0].RevokedAt)
+
+	resp = api.Get("/v0/tokens?all=true", "Authorization: Bearer user:alice")
+	require.Equal(t, http.StatusForbidden, resp.Code)
+	require.Len(t, listTokens(t, api, "Bearer user:root", "?all=true"), 2)
+}
+
+func TestCreateToken_Validation(t *testing.T) {
+	api := newTokenAPI(t)
+	past, soon, tooLate := time.Now().Add(-time.Hour), time.Now().Add(time.Hour), time.Now().Add(MaxLifetime+time.Hour)
+
+	for name, tc := range map[string]struct {
+		as   string
+		req  arv0.CreateAPITokenRequest
+		want int
+	}{
+		"bad scope resource":  {as: "alice", req: arv0.CreateAPITokenRequest{Name: "x", Scopes: []arv0.TokenScope{{Action: "read", Resource: "team-a"}}, ExpiresAt: soon}, want: http.StatusBadRequest},
+		"no expiry":           {as: "alice", req: arv0.CreateAPITokenRequest{Name: "x", Scopes: []arv0.TokenScope{{Action: "read", Resource: "*"}}}, want: http.StatusBadRequest},
+		"expiry in the past":  {as: "alice", req: arv0.CreateAPITokenRequest{Name: "x", Scopes: []arv0.TokenScope{{Action: "read", Resource: "*"}}, ExpiresAt: past}, want: http.StatusBadRequest},
+		"expiry over a year":  {as: "alice", req: arv0.CreateAPITokenRequest{Name: "x", Scopes: []arv0.TokenScope{{Action: "read", Resource: "*"}}, ExpiresAt: tooLate}, want: http.StatusBadRequest},
+		"service account":     {as: "alice", req: arv0.CreateAPITokenRequest{Name: "x", Scopes: []arv0.TokenScope{{Action: "read", Resource: "*"}}, ExpiresAt: soon, ServiceAccount: "ci"}, want: http.StatusForbidden},
+		"bad service account": {as: "root", req: arv0.CreateAPITokenRequest{Name: "x", Scopes: []arv0.TokenScope{{Action: "read", Resource: "*"}}, ExpiresAt: soon, ServiceAccount: "CI Bot"}, want: http.StatusBadRequest},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := api.Post("/v0/tokens", "Authorization: Bearer user:"+tc.as, tc.req)
+			require.Equal(t, tc.want, resp.Code, resp.Body.String())
+		})
+	}
+
+	resp := api.Get("/v0/tokens")
+	require.Equal(t, http.StatusUnauthorized, resp.Code)
+}
+
+func TestServiceAccountToken(t *testing.T) {
+	api := newTokenAPI(t)
+	expires := time.Now().Add(time.Hour)
+	created := createToken(t, api, "root", arv0.CreateAPITokenRequest{
+		Name:           "publisher",
+		Scopes:         []arv0.TokenScope{{Action: "publish", Resource: "team-a/*"}},
+		ExpiresAt:      expires,
+		ServiceAccount: "ci",
+	})
+	require.Equal(t, "root", created.APIToken.Owner)
+	require.Equal(t, auth.ServiceAccountSubjectPrefix+"ci", created.APIToken.Subject)
+	require.WithinDuration(t, expires, created.APIToken.ExpiresAt, time.Second)
+
+	resp := api.Delete("/v0/tokens/"+created.APIToken.ID, "Authorization: Bearer "+created.Token)
+	require.Equal(t, http.StatusNoContent, resp.Code, "a service account may revoke its own token")
+}
@@ -15,6 +15,7 @@ import (
 	"go.opentelemetry.io/otel/metric"
 
 	mcpregistrycompat "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/mcpregistry"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/authconfig"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
 	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
@@ -163,6 +164,8 @@ func NewHumaAPI(
 		middlewareOpts := []auth.MiddlewareOption{
 			// don't authenticate on public paths which require no authorization
 			auth.WithSkipPaths("/health", "/metrics", "/ping", "/docs", "/version"),
+			// clients read how to sign in before they have credentials
+			auth.WithSkipPaths("/v0" + authconfig.Path),
 		}
 		if cfg.MCPRegistryCompatEnabled {
 			// The /v0.1 compatibility API is a public catalogue that still goes through
@@ -4,14 +4,22 @@ package router
 import (
 	"context"
 	"errors"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
 
 	mcpregistrycompat "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/mcpregistry"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/authconfig"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/crud"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/deploymentlogs"
 	v0health "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/health"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/namespaceowners"
 	v0ping "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/ping"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/promptrender"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/skillarchive"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/tokens"
 	v0version "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/version"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
 	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
@@ -19,6 +27,7 @@ import (
 	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1/registries"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
 	"github.com/agentregistry-dev/agentregistry/pkg/types"
@@ -52,6 +61,32 @@ type RouteOptions struct {
 	// CRUD hook wiring.
 	DeploymentLogResolver deploymentlogs.LogResolver
 
+	// SkillArchives backs the skill tarball upload endpoints
+	// (`/v0/skill-archives`). Nil leaves them unregistered, so Skills can
+	// only be sourced from git or OCI.
+	SkillArchives *v1alpha1store.SkillArchiveStore
+
+	// APITokens backs the API token endpoints (`/v0/tokens`). Nil leaves
+	// them unregistered.
+	APITokens *v1alpha1store.APITokenStore
+
+	// NamespaceOwners backs the namespace ownership endpoints
+	// (`/v0/namespace-owners`). Nil leaves them unregistered.
+	NamespaceOwners *v1alpha1store.NamespaceOwnerStore
+
+	// DomainProofs checks the DNS and HTTP proofs namespace claims carry.
+	// Nil uses one that reaches DNS and the network directly.
+	DomainProofs *auth.DomainProofVerifier
+
+	// IsRegistryAdmin gates the admin-only operations: issuing
+	// service-account tokens, listing every user's tokens and releasing
+	// a claimed namespace. Nil denies them.
+	IsRegistryAdmin func(ctx context.Context) bool
+
+	// Watch backs ?watch=true streams on the list routes. Nil leaves
+	// watching unsupported, as on the noop/gen-openapi path.
+	Watch *resource.WatchSource
+
 	// PerKindHooks injects per-kind Authorize + ListFilter
 	// callbacks into the generic resource handler. Downstream integrations
 	// thread their RBAC engine through here so reader / publisher /
@@ -117,6 +152,7 @@ func RegisterRoutes(
 	v0health.RegisterHealthEndpoint(api, pathPrefix, cfg, metrics)
 	v0ping.RegisterPingEndpoint(api, pathPrefix)
 	v0version.RegisterVersionEndpoint(api, pathPrefix, versionInfo)
+	authconfig.RegisterAuthConfigEndpoint(api, pathPrefix, authConfigOf(cfg))
 
 	// v1alpha1 generic routes. Cross-kind dangling-ref detection uses
 	// a Store-backed resolver. Deployment side effects are handled by
@@ -132,8 +168,34 @@ func RegisterRoutes(
 		opts.DeleteAdmission,
 		opts.ResolverWrapper,
 		opts.ExtraResourceRoutes,
+		opts.Watch,
 	)
 
+	if opts.SkillArchives != nil {
+		skillarchive.Register(api, skillarchive.Config{
+			BasePrefix: pathPrefix,
+			Archives:   opts.SkillArchives,
+			Authorize:  opts.PerKindHooks.Authorizers[v1alpha1.KindSkill],
+		})
+	}
+
+	if opts.APITokens != nil {
+		tokens.Register(api, tokens.Config{
+			BasePrefix:      pathPrefix,
+			Store:           opts.APITokens,
+			IsRegistryAdmin: opts.IsRegistryAdmin,
+		})
+	}
+
+	if opts.NamespaceOwners != nil {
+		namespaceowners.Register(api, namespaceowners.Config{
+			BasePrefix:      pathPrefix,
+			Store:           opts.NamespaceOwners,
+			Verifier:        opts.DomainProofs,
+			IsRegistryAdmin: opts.IsRegistryAdmin,
+		})
+	}
+
 	if opts.ExtraRoutes != nil {
 		opts.ExtraRoutes(api, pathPrefix)
 	}
@@ -165,6 +227,25 @@ func RegisterRoutes(
 	return nil
 }
 
+// authConfigOf advertises the OIDC client `arctl login` signs in with,
+// when one is configured.
+func authConfigOf(cfg *config.Config) arv0.AuthConfig {
+	if cfg.OIDCIssuerURL == "" || cfg.OIDCCLIClientID == "" {
+		return arv0.AuthConfig{}
+	}
+	scopes := []string{}
+	for scope := range strings.SplitSeq(cfg.OIDCCLIScopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return arv0.AuthConfig{OIDC: &arv0.OIDCLoginConfig{
+		Issuer:   cfg.OIDCIssuerURL,
+		ClientID: cfg.OIDCCLIClientID,
+		Scopes:   scopes,
+	}}
+}
+
 // registerKindRoutes wires the generic resource handler for every
 // built-in kind. Tagged artifacts use
 // `{basePrefix}/{plural}/{name}/{tag}`; mutable objects use
@@ -172,7 +253,8 @@ func RegisterRoutes(
 // query param defaulting to "default"; `?namespace=all` on list
 // widens scope across every namespace. The multi-doc apply endpoint
 // lives at `{basePrefix}/apply`. Cross-kind ResourceRef existence
-// dispatches through the shared internaldb.NewResolver.
+// dispatches through the shared internaldb.NewResolver, and reverse
+// lookups (.../referrers) through internaldb.NewReferrers.
 func registerKindRoutes(
 	api huma.API,
 	basePrefix string,
@@ -184,6 +266,7 @@ func registerKindRoutes(
 	deleteAdmission types.DeleteAdmission,
 	resolverWrapper func(v1alpha1.ResolverFunc) v1alpha1.ResolverFunc,
 	extraResourceRoutes func(api huma.API, pathPrefix string, ctx types.ResourceRouteContext),
+	watch *resource.WatchSource,
 ) resource.ApplyConfig {
 	resolver := internaldb.NewResolver(stores)
 	if resolverWrapper != nil {
@@ -194,7 +277,8 @@ func registerKindRoutes(
 	}
 	// Per-kind CRUD endpoints — one call per built-in kind, hidden
 	// inside crud.Register.
-	crud.Register(api, basePrefix, stores, resolver, registryValidator, perKind, deleteAdmission)
+	referrers := internaldb.NewReferrers(stores)
+	crud.Register(api, basePrefix, stores, resolver, referrers, registryValidator, perKind, admission, deleteAdmission, watch)
 
 	// Deployment-specific endpoints: logs stream (cancel is subsumed
 	// by DesiredState=undeployed + DELETE in the v1alpha1 lifecycle).
@@ -207,6 +291,16 @@ func registerKindRoutes(
 		})
 	}
 
+	// Prompt render: argument validation + template rendering against a
+	// pinned Prompt tag.
+	if store := stores[v1alpha1.KindPrompt]; store != nil {
+		promptrender.Register(api, promptrender.Config{
+			BasePrefix: basePrefix,
+			Store:      store,
+			Authorize:  perKind.Authorizers[v1alpha1.KindPrompt],
+		})
+	}
+
 	// Multi-doc YAML batch apply at POST {basePrefix}/apply shares the
 	// same per-kind hook table populated above, so Deployment reconciliation
 	// and any caller-supplied PostUpsert/PostDelete fire identically on
@@ -228,6 +322,7 @@ func registerKindRoutes(
 		BasePrefix:        basePrefix,
 		Stores:            stores,
 		Resolver:          resolver,
+		Referrers:         referrers,
 		RegistryValidator: registryValidator,
 		Authorizers:       perKind.Authorizers,
 		PostUpserts:       perKind.PostUpserts,
@@ -243,6 +338,23 @@ func registerKindRoutes(
 	productionDeleteCfg.DeleteAdmission = resource.ProductionDeleteAdmission
 	resource.RegisterApply(api, applyCfg)
 
+	// Cross-kind full-text search at GET {basePrefix}/search, scoped per
+	// kind by the same list authorizers and filters as the list routes.
+	resource.RegisterSearch(api, resource.SearchConfig{
+		BasePrefix:  basePrefix,
+		Stores:      stores,
+		Authorizers: perKind.Authorizers,
+		ListFilters: perKind.ListFilters,
+	})
+
+	// Access review at GET {basePrefix}/auth/can-i answers through the
+	// same per-kind authorizers the routes above consult.
+	resource.RegisterAccessReview(api, resource.AccessReviewConfig{
+		BasePrefix:  basePrefix,
+		Kinds:       slices.Sorted(maps.Keys(stores)),
+		Authorizers: perKind.Authorizers,
+	})
+
 	if extraResourceRoutes != nil {
 		opaqueStores := make(map[string]any, len(stores))
 		for kind, store := range stores {
@@ -251,6 +363,7 @@ func registerKindRoutes(
 		extraResourceRoutes(api, basePrefix, types.ResourceRouteContext{
 			Stores:            opaqueStores,
 			Resolver:          resolver,
+			Referrers:         referrers,
 			RegistryValidator: registryValidator,
 			Apply: func(ctx context.Context, obj v1alpha1.Object, dryRun bool) arv0.ApplyResult {
 				return resource.ApplyObject(ctx, productionApplyCfg, obj, dryRun)
@@ -0,0 +1,117 @@
+// Package apitokens wires API tokens into the registry: it authenticates
+// bearer tokens against the APITokenStore, and adapts token scopes into
+// per-kind Authorize and ListFilter hooks so a token can never do more
+// than its scopes allow, whatever its identity is granted.
+package apitokens
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/rbac"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// Authn authenticates API tokens. Bearer tokens without the
+// auth.APITokenPrefix are left to other providers.
+type Authn struct {
+	store *v1alpha1store.APITokenStore
+	now   func() time.Time
+}
+
+var _ auth.AuthnProvider = &Authn{}
+
+// NewAuthn returns an Authn backed by store.
+func NewAuthn(store *v1alpha1store.APITokenStore) *Authn {
+	return &Authn{store: store, now: time.Now}
+}
+
+// Authenticate resolves the request's API token to an
+// auth.APITokenSession, and records its use.
+func (a *Authn) Authenticate(ctx context.Context, reqHeaders func(name string) string, _ url.Values) (auth.Session, error) {
+	const bearerPrefix = "Bearer "
+	authHeader := reqHeaders("Authorization")
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return nil, nil
+	}
+	token := authHeader[len(bearerPrefix):]
+	if !strings.HasPrefix(token, auth.APITokenPrefix) {
+		return nil, nil
+	}
+
+	stored, err := a.store.GetByHash(ctx, auth.HashAPIToken(token))
+	if errors.Is(err, pkgdb.ErrNotFound) {
+		return nil, huma.Error401Unauthorized("Invalid API token")
+	}
+	if err != nil {
+		return nil, huma.Error401Unauthorized("Invalid API token", err)
+	}
+	if !stored.Active(a.now()) {
+		return nil, huma.Error401Unauthorized("API token is revoked or expired")
+	}
+	if err := a.store.Touch(ctx, stored.ID); err != nil {
+		slog.Warn("failed to record API token use", "id", stored.ID, "error", err)
+	}
+	return &auth.APITokenSession{
+		TokenID:     stored.ID,
+		Subject:     stored.Subject,
+		Groups:      auth.APITokenGroups(stored.Subject),
+		TokenScopes: stored.Scopes,
+	}, nil
+}
+
+// Authorizer returns a resource.Config.Authorize hook that refuses what a
+// scoped session's scopes do not allow, run ahead of next (nil for none).
+func Authorizer(next func(ctx context.Context, in resource.AuthorizeInput) error) func(ctx context.Context, in resource.AuthorizeInput) error {
+	return func(ctx context.Context, in resource.AuthorizeInput) error {
+		session, _ := auth.AuthSessionFrom(ctx)
+		if err := auth.AuthorizeScopes(session, auth.AccessRequest{Verb: in.Verb, Kind: in.Kind, Namespace: in.Namespace, Name: in.Name}); err != nil {
+			return rbac.StatusError(err)
+		}
+		if next != nil {
+			return next(ctx, in)
+		}
+		return nil
+	}
+}
+
+// ListFilter returns a resource.Config.ListFilter hook that narrows list
+// queries to the rows a scoped session's scopes cover, ANDed with the
+// predicate of next (nil for none).
+func ListFilter(next func(ctx context.Context, in resource.AuthorizeInput) (string, []any, error)) func(ctx context.Context, in resource.AuthorizeInput) (string, []any, error) {
+	return func(ctx context.Context, in resource.AuthorizeInput) (string, []any, error) {
+		var (
+			where string
+			args  []any
+		)
+		if next != nil {
+			var err error
+			if where, args, err = next(ctx, in); err != nil {
+				return "", nil, err
+			}
+		}
+		session, _ := auth.AuthSessionFrom(ctx)
+		scope, scoped := auth.ScopeListScope(session, v1alpha1.RBACVerbList, in.Kind, in.Namespace)
+		if !scoped {
+			return where, args, nil
+		}
+		scopeWhere, scopeArgs := rbac.CompileListScope(scope, len(args))
+		switch {
+		case scopeWhere == "":
+			return where, args, nil
+		case where == "":
+			return scopeWhere, scopeArgs, nil
+		}
+		return "(" + where + ") AND " + scopeWhere, append(args, scopeArgs...), nil
+	}
+}
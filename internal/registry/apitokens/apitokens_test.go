@@ -0,0 +1,71 @@
+package apitokens_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/apitokens"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+)
+
+func scoped(scopes ...auth.Permission) context.Context {
+	return auth.AuthSessionTo(context.Background(), &auth.APITokenSession{Subject: "alice", TokenScopes: scopes})
+}
+
+func TestAuthorizer(t *testing.T) {
+	var downstream int
+	authorize := apitokens.Authorizer(func(context.Context, resource.AuthorizeInput) error {
+		downstream++
+		return nil
+	})
+	ctx := scoped(auth.Permission{Action: auth.PermissionActionRead, ResourcePattern: "team-a/*"})
+
+	require.NoError(t, authorize(ctx, resource.AuthorizeInput{Verb: "get", Kind: v1alpha1.KindAgent, Namespace: "team-a", Name: "x"}))
+	err := authorize(ctx, resource.AuthorizeInput{Verb: "apply", Kind: v1alpha1.KindAgent, Namespace: "team-a", Name: "x"})
+	require.ErrorIs(t, err, auth.ErrForbidden)
+	require.Equal(t, 1, downstream, "a request outside the scopes never reaches downstream hooks")
+
+	require.NoError(t, authorize(context.Background(), resource.AuthorizeInput{Verb: "delete", Kind: v1alpha1.KindAgent, Namespace: "team-b", Name: "x"}))
+	require.Equal(t, 2, downstream)
+}
+
+func TestListFilter(t *testing.T) {
+	filter := apitokens.ListFilter(func(context.Context, resource.AuthorizeInput) (string, []any, error) {
+		return "name <> $1", []any{"hidden"}, nil
+	})
+	in := resource.AuthorizeInput{Verb: "list", Kind: v1alpha1.KindAgent}
+
+	where, args, err := filter(scoped(auth.Permission{Action: auth.PermissionActionRead, ResourcePattern: "team-a/*"}), in)
+	require.NoError(t, err)
+	require.Equal(t, "(name <> $1) AND ((namespace = ANY($2::text[])))", where)
+	require.Equal(t, []any{"hidden", []string{"team-a"}}, args)
+
+	where, _, err = filter(scoped(auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "*"}), in)
+	require.NoError(t, err)
+	require.Equal(t, "(name <> $1) AND FALSE", where, "a token without a read scope lists nothing")
+
+	where, args, err = filter(scoped(auth.Permission{Action: auth.PermissionActionRead, ResourcePattern: "*"}), in)
+	require.NoError(t, err)
+	require.Equal(t, "name <> $1", where)
+	require.Equal(t, []any{"hidden"}, args)
+
+	where, _, err = filter(context.Background(), in)
+	require.NoError(t, err)
+	require.Equal(t, "name <> $1", where, "unscoped sessions are not narrowed")
+}
+
+func TestAuthn_SkipsOtherBearers(t *testing.T) {
+	authn := apitokens.NewAuthn(nil)
+	for _, header := range []string{"", "Basic abc", "Bearer eyJhbGciOiJFZERTQSJ9.e30.sig"} {
+		session, err := authn.Authenticate(context.Background(), func(string) string { return header }, url.Values{})
+		require.NoError(t, err)
+		require.Nil(t, session)
+	}
+	_, err := authn.Authenticate(context.Background(), func(string) string { return "Bearer " + auth.APITokenPrefix + "x" }, url.Values{})
+	require.Error(t, err, "an API token fails when the store is unavailable")
+}
@@ -21,6 +21,79 @@ type Config struct {
 	JWTPrivateKey string `env:"JWT_PRIVATE_KEY" envDefault:""`
 	LogLevel      string `env:"LOG_LEVEL" envDefault:"info"`
 
+	// SecretEncryptionKey is the hex-encoded 32-byte AES-256 key that wraps
+	// the per-Secret data keys. Empty leaves the Secret kind read-only:
+	// writes and runtime resolution fail until a key is configured.
+	SecretEncryptionKey string `env:"SECRET_ENCRYPTION_KEY" envDefault:""`
+
+	// AuthzMode selects the built-in authorization model: "public" lets
+	// every caller read and write everything; "rbac" evaluates Role,
+	// ClusterRole and RoleBinding objects. AppOptions.AuthzProvider, when
+	// set, replaces either.
+	AuthzMode string `env:"AUTHZ_MODE" envDefault:"public"`
+	// RBACAdmins is a comma-separated list of user subjects, or
+	// "group:NAME" entries, allowed everything in rbac mode regardless of
+	// RoleBindings. It bootstraps the first Roles and RoleBindings.
+	RBACAdmins string `env:"RBAC_ADMINS" envDefault:""`
+
+	// OIDCIssuerURL enables authentication with bearer tokens from an
+	// OpenID Connect issuer, alongside the registry's own JWTs. Signing
+	// keys are discovered from the issuer. Empty disables OIDC.
+	OIDCIssuerURL string `env:"OIDC_ISSUER_URL" envDefault:""`
+	// OIDCAudiences is a comma-separated list of accepted token audiences,
+	// typically client IDs. Required with OIDCIssuerURL.
+	OIDCAudiences string `env:"OIDC_AUDIENCES" envDefault:""`
+	// OIDCUsernameClaim is the token claim that becomes the user subject
+	// RoleBindings match.
+	OIDCUsernameClaim string `env:"OIDC_USERNAME_CLAIM" envDefault:"sub"`
+	// OIDCGroupsClaim is the token claim listing the user's groups; dots
+	// descend into nested objects (e.g. "realm_access.roles").
+	OIDCGroupsClaim string `env:"OIDC_GROUPS_CLAIM" envDefault:"groups"`
+	// OIDCUsernamePrefix is prepended to the subject from
+	// OIDCUsernameClaim. "-" leaves it unprefixed.
+	OIDCUsernamePrefix string `env:"OIDC_USERNAME_PREFIX" envDefault:"oidc:"`
+	// OIDCGroupsPrefix is prepended to every group from OIDCGroupsClaim.
+	// "-" leaves groups unprefixed.
+	OIDCGroupsPrefix string `env:"OIDC_GROUPS_PREFIX" envDefault:"oidc:"`
+	// OIDCCLIClientID is the public OAuth client `arctl login` signs in
+	// with. It is advertised at /v0/auth/config and must be one of
+	// OIDCAudiences, since the CLI sends the ID token it is issued.
+	// Empty leaves `arctl login` unavailable.
+	OIDCCLIClientID string `env:"OIDC_CLI_CLIENT_ID" envDefault:""`
+	// OIDCCLIScopes is the comma-separated list of scopes `arctl login`
+	// requests; offline_access lets the CLI refresh its session.
+	OIDCCLIScopes string `env:"OIDC_CLI_SCOPES" envDefault:"openid,profile,email,offline_access"`
+
+	// ImmutableTags makes matching tags write-once: re-applying one with
+	// different content fails with 409 instead of replacing it. A
+	// comma-separated list of [NAMESPACE/][KIND:]PATTERN rules, where
+	// PATTERN is "semver" (full MAJOR.MINOR.PATCH tags) or a glob such as
+	// "release-*" (e.g. "semver" or "prod/MCPServer:semver"). Empty leaves
+	// every tag replaceable; "latest" is never protected.
+	ImmutableTags string `env:"IMMUTABLE_TAGS" envDefault:""`
+
+	// SigningTrustPolicy is the path to a YAML file listing the public keys
+	// trusted to sign tagged artifacts, per namespace. When set, signed
+	// applies are verified and the outcome is recorded in the artifact's
+	// SignatureVerified status condition; a signature that no trusted key
+	// verifies is rejected. Empty disables signature verification.
+	SigningTrustPolicy string `env:"SIGNING_TRUST_POLICY" envDefault:""`
+
+	// OCIVerificationKeys is a comma-separated list of PEM public key files
+	// trusted to sign MCP server OCI images with cosign, each named after
+	// its file. When set, applying an MCPServer with an OCI package looks
+	// up the image's signatures and SLSA provenance attestations in its
+	// registry and records the resolved digest, signer and builder in
+	// status.image. Empty disables image verification. Unless one of the
+	// OCIRequire* flags is set, problems are reported as apply warnings.
+	OCIVerificationKeys string `env:"OCI_VERIFICATION_KEYS" envDefault:""`
+	// OCIRequireSignature rejects OCI packages whose image has no signature
+	// from one of OCIVerificationKeys.
+	OCIRequireSignature bool `env:"OCI_REQUIRE_SIGNATURE" envDefault:"false"`
+	// OCIRequireProvenance rejects OCI packages whose image has no SLSA
+	// provenance attestation signed by one of OCIVerificationKeys.
+	OCIRequireProvenance bool `env:"OCI_REQUIRE_PROVENANCE" envDefault:"false"`
+
 	// Platform mode: "docker" or "kubernetes". Controls which deployment
 	// provider IDs are available in the UI. Defaults to "kubernetes" so
 	// Helm/K8s deployments work without extra config; docker-compose.yml
@@ -52,6 +125,24 @@ type Config struct {
 	// configured base.
 	MCPRegistryCompatPathPrefix string `env:"MCP_REGISTRY_COMPAT_PATH_PREFIX" envDefault:""`
 
+	// MCP server introspection
+	//
+	// MCPIntrospectionEnabled starts the MCPServer controller, which connects
+	// to every MCPServer tag and records the tools, prompts and resources it
+	// exposes in status. Remote servers are dialed at their URL; bundled
+	// servers are RUN on this host with the docker CLI, in a throwaway
+	// container with dropped capabilities and resource limits. Executing
+	// published packages is a trust decision, so it is OFF by default.
+	MCPIntrospectionEnabled bool `env:"MCP_INTROSPECTION_ENABLED" envDefault:"false"`
+	// MCPIntrospectionNetwork is the docker network introspection sandboxes
+	// join. Package runners (npx, uvx, go run) need egress to fetch the
+	// package; point this at a filtered network to restrict what else they
+	// can reach.
+	MCPIntrospectionNetwork string `env:"MCP_INTROSPECTION_NETWORK" envDefault:"bridge"`
+	// MCPIntrospectionTimeout bounds one introspection, including image pull
+	// and server start.
+	MCPIntrospectionTimeout time.Duration `env:"MCP_INTROSPECTION_TIMEOUT" envDefault:"2m"`
+
 	// ControllerEventRetention is how long handled control-plane events remain
 	// available for checkpoint replay. Set to 0 to disable event pruning.
 	ControllerEventRetention time.Duration `env:"CONTROLLER_EVENT_RETENTION" envDefault:"24h"`
@@ -103,3 +103,70 @@ func TestNewConfig_SkipMigrationsEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate_SecretEncryptionKey(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{name: "unset", key: ""},
+		{name: "32 byte hex", key: strings.Repeat("ab", 32)},
+		{name: "not hex", key: strings.Repeat("zz", 32), wantErr: true},
+		{name: "wrong length", key: strings.Repeat("ab", 16), wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(&Config{SecretEncryptionKey: tc.key})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_AuthzMode(t *testing.T) {
+	for _, tc := range []struct {
+		mode    string
+		wantErr bool
+	}{
+		{mode: ""},
+		{mode: AuthzModePublic},
+		{mode: AuthzModeRBAC},
+		{mode: "oidc", wantErr: true},
+	} {
+		t.Run(tc.mode, func(t *testing.T) {
+			err := Validate(&Config{AuthzMode: tc.mode})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_OIDC(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		issuer    string
+		audiences string
+		cliClient string
+		wantErr   bool
+	}{
+		{name: "disabled"},
+		{name: "https issuer", issuer: "https://accounts.example.com", audiences: "registry"},
+		{name: "loopback http issuer", issuer: "http://127.0.0.1:5556/dex", audiences: "registry"},
+		{name: "localhost http issuer", issuer: "http://localhost:8180/realms/dev", audiences: "registry,cli"},
+		{name: "remote http issuer", issuer: "http://accounts.example.com", audiences: "registry", wantErr: true},
+		{name: "relative issuer", issuer: "accounts.example.com", audiences: "registry", wantErr: true},
+		{name: "missing audiences", issuer: "https://accounts.example.com", audiences: " , ", wantErr: true},
+		{name: "cli client among audiences", issuer: "https://accounts.example.com", audiences: "registry, arctl", cliClient: "arctl"},
+		{name: "cli client not an audience", issuer: "https://accounts.example.com", audiences: "registry", cliClient: "arctl", wantErr: true},
+		{name: "cli client without issuer", audiences: "arctl", cliClient: "arctl", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(&Config{OIDCIssuerURL: tc.issuer, OIDCAudiences: tc.audiences, OIDCCLIClientID: tc.cliClient})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
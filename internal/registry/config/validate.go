@@ -1,6 +1,22 @@
 package config
 
-import "fmt"
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// secretEncryptionKeyLen is the AES-256 key length SecretEncryptionKey must
+// decode to.
+const secretEncryptionKeyLen = 32
+
+// Authorization modes AuthzMode accepts.
+const (
+	AuthzModePublic = "public"
+	AuthzModeRBAC   = "rbac"
+)
 
 // Validate performs runtime validations on the loaded configuration.
 func Validate(cfg *Config) error {
@@ -16,5 +32,65 @@ func Validate(cfg *Config) error {
 	if cfg.ControllerRetentionPruneBatchLimit < 0 {
 		return fmt.Errorf("controller retention prune batch limit must be non-negative")
 	}
+	switch cfg.AuthzMode {
+	case "", AuthzModePublic, AuthzModeRBAC:
+	default:
+		return fmt.Errorf("authz mode must be %q or %q, got %q", AuthzModePublic, AuthzModeRBAC, cfg.AuthzMode)
+	}
+	if cfg.OIDCIssuerURL != "" {
+		if err := validateOIDCIssuer(cfg.OIDCIssuerURL); err != nil {
+			return err
+		}
+		if strings.Trim(cfg.OIDCAudiences, ", ") == "" {
+			return fmt.Errorf("oidc audiences are required with an oidc issuer")
+		}
+	}
+	if cfg.OIDCCLIClientID != "" {
+		if cfg.OIDCIssuerURL == "" {
+			return fmt.Errorf("oidc cli client id requires an oidc issuer")
+		}
+		if !containsListItem(cfg.OIDCAudiences, cfg.OIDCCLIClientID) {
+			return fmt.Errorf("oidc cli client id %q must be one of the oidc audiences", cfg.OIDCCLIClientID)
+		}
+	}
+	if cfg.SecretEncryptionKey != "" {
+		key, err := hex.DecodeString(cfg.SecretEncryptionKey)
+		if err != nil {
+			return fmt.Errorf("secret encryption key must be hex-encoded: %w", err)
+		}
+		if len(key) != secretEncryptionKeyLen {
+			return fmt.Errorf("secret encryption key must be %d bytes, got %d", secretEncryptionKeyLen, len(key))
+		}
+	}
+	return nil
+}
+
+// validateOIDCIssuer requires an absolute https issuer URL; plain http is
+// accepted for loopback hosts, for local development.
+func validateOIDCIssuer(issuer string) error {
+	u, err := url.Parse(issuer)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("oidc issuer must be an absolute URL, got %q", issuer)
+	}
+	switch u.Scheme {
+	case "https":
+	case "http":
+		host := u.Hostname()
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return fmt.Errorf("oidc issuer must use https, got %q", issuer)
+		}
+	default:
+		return fmt.Errorf("oidc issuer must use https, got %q", issuer)
+	}
 	return nil
 }
+
+// containsListItem reports whether the comma-separated list holds item.
+func containsListItem(list, item string) bool {
+	for entry := range strings.SplitSeq(list, ",") {
+		if strings.TrimSpace(entry) == item {
+			return true
+		}
+	}
+	return false
+}
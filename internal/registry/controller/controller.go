@@ -39,6 +39,7 @@ type DeploymentController struct {
 	Stores   map[string]*v1alpha1store.Store
 	Adapters map[string]types.DeploymentAdapter
 	Getter   v1alpha1.GetterFunc
+	Secrets  types.SecretResolverFunc
 	Events   ControlPlaneEventReader
 
 	BatchLimit int
@@ -118,14 +119,14 @@ func (c *DeploymentController) FullReconcile(ctx context.Context) (int, error) {
 
 // HandleEvent maps a source invalidation to Deployment work. Dependency changes
 // intentionally use a full Deployment scan for this first controller foundation.
-// Agent harness composition refs (Plugins, Skills, and Prompt instructions) and
-// Model selection are dependency events so changes requeue Deployments that may
-// depend on their resolved state.
+// Agent harness composition refs (Plugins, Skills, and Prompt instructions),
+// Model selection, and the Secrets a Model references are dependency events so
+// changes requeue Deployments that may depend on their resolved state.
 func (c *DeploymentController) HandleEvent(ctx context.Context, event v1alpha1store.ControlPlaneEvent) (int, error) {
 	switch event.Key.Kind {
 	case v1alpha1.KindDeployment:
 		return c.reconcileDeployment(ctx, event.Key)
-	case v1alpha1.KindRuntime, v1alpha1.KindAgent, v1alpha1.KindMCPServer, v1alpha1.KindPlugin, v1alpha1.KindSkill, v1alpha1.KindPrompt, v1alpha1.KindModel:
+	case v1alpha1.KindRuntime, v1alpha1.KindAgent, v1alpha1.KindMCPServer, v1alpha1.KindPlugin, v1alpha1.KindSkill, v1alpha1.KindPrompt, v1alpha1.KindModel, v1alpha1.KindSecret:
 		return c.FullReconcile(ctx)
 	default:
 		return 0, nil
@@ -71,6 +71,7 @@ func TestDeploymentControllerHandleDependencyEventsFullReconcileDeployments(t *t
 		v1alpha1.KindSkill,
 		v1alpha1.KindPrompt,
 		v1alpha1.KindModel,
+		v1alpha1.KindSecret,
 	} {
 		t.Run(kind, func(t *testing.T) {
 			stores := newControllerTestStores(t)
@@ -28,7 +28,7 @@ func TestDeploymentControllerSyncReplaysIgnoredEvents(t *testing.T) {
 func TestDeploymentControllerHandleDependencyEventsFullReconcile(t *testing.T) {
 	controller := &DeploymentController{}
 
-	for _, kind := range []string{v1alpha1.KindPlugin, v1alpha1.KindSkill, v1alpha1.KindPrompt, v1alpha1.KindModel} {
+	for _, kind := range []string{v1alpha1.KindPlugin, v1alpha1.KindSkill, v1alpha1.KindPrompt, v1alpha1.KindModel, v1alpha1.KindSecret} {
 		t.Run(kind, func(t *testing.T) {
 			_, err := controller.HandleEvent(context.Background(), v1alpha1store.ControlPlaneEvent{
 				Key: v1alpha1store.ResourceKey{Kind: kind, Namespace: "default", Name: "changed"},
@@ -0,0 +1,387 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/introspect"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// MCPServerIntrospectFunc connects to the MCP server spec describes and
+// returns what it exposes. It is the only I/O-bearing dependency of the
+// MCPServer controller, so tests inject a fake instead of running servers.
+// Errors wrapping introspect.ErrUnsupported or introspect.ErrProtocol are
+// terminal; any other error is retried.
+type MCPServerIntrospectFunc func(ctx context.Context, name string, spec v1alpha1.MCPServerSpec) (*v1alpha1.MCPServerCapabilities, error)
+
+// MCPServerControllerDeps are the MCPServer controller's dependencies.
+// Introspect defaults to an introspect.Introspector with default sandbox
+// options when nil.
+type MCPServerControllerDeps struct {
+	Introspect MCPServerIntrospectFunc
+}
+
+// mcpServerStore is the subset of *v1alpha1store.Store the controller uses,
+// expressed as an interface so reconcile/patchStatus can be tested with a
+// fake (no database). *v1alpha1store.Store satisfies it.
+type mcpServerStore interface {
+	Get(ctx context.Context, namespace, name, tag string) (*v1alpha1.RawObject, error)
+	List(ctx context.Context, opts v1alpha1store.ListOpts) ([]*v1alpha1.RawObject, string, error)
+	ApplyPatch(ctx context.Context, namespace, name, tag string, patch v1alpha1store.PatchOpts) error
+}
+
+type mcpServerQueueKey struct {
+	Namespace string
+	Name      string
+	Tag       string
+}
+
+// MCPServerController reconciles MCPServer resources out of band of the API
+// write: it introspects each tag — starting a bundled server in a sandboxed
+// container, or dialing a remote's URL — and records the tools, prompts and
+// resources it reports in MCPServerStatus.Capabilities. Reviewers approve
+// against that observed surface, and the UI and CLI show tool schemas
+// without anything being deployed.
+//
+// It is level-triggered on the same model as the Skill and Plugin
+// controllers: every control-plane wakeup (and the resync tick) re-lists
+// servers and enqueues those whose status is behind their generation. Each
+// generation is introspected once; re-apply the MCPServer to refresh it.
+type MCPServerController struct {
+	Store      mcpServerStore
+	Introspect MCPServerIntrospectFunc
+	Wakeups    <-chan struct{}
+
+	pool   *pgxpool.Pool
+	resync time.Duration
+
+	lifecycleMu sync.Mutex
+	cancel      context.CancelFunc
+	done        chan struct{}
+
+	queueMu sync.Mutex
+	queue   workqueue.TypedRateLimitingInterface[mcpServerQueueKey]
+}
+
+// NewMCPServerController wires the MCPServer controller without starting it.
+// Start owns the background goroutine and control-plane LISTEN subscription.
+func NewMCPServerController(
+	pool *pgxpool.Pool,
+	stores map[string]*v1alpha1store.Store,
+	deps MCPServerControllerDeps,
+) (*MCPServerController, error) {
+	if pool == nil {
+		return nil, nil
+	}
+	store := stores[v1alpha1.KindMCPServer]
+	if store == nil {
+		return nil, errors.New("mcpserver controller: MCPServer store is required")
+	}
+	introspectFn := deps.Introspect
+	if introspectFn == nil {
+		introspectFn = introspect.New(introspect.Options{}).Introspect
+	}
+	return &MCPServerController{
+		Store:      store,
+		Introspect: introspectFn,
+		pool:       pool,
+		resync:     defaultControllerResyncInterval,
+	}, nil
+}
+
+// Start begins the MCPServer controller's background reconcile loop. It owns
+// the goroutine and opens this controller's control-plane LISTEN
+// subscription.
+func (c *MCPServerController) Start(ctx context.Context) error {
+	if c == nil || c.Store == nil {
+		return errors.New("mcpserver controller: MCPServer store is required")
+	}
+	if c.Introspect == nil {
+		c.Introspect = introspect.New(introspect.Options{}).Introspect
+	}
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+	if c.done != nil {
+		return errors.New("mcpserver controller: already started")
+	}
+	runCtx, cancel := context.WithCancel(ctx)
+	c.cancel = cancel
+	c.done = make(chan struct{})
+	if c.pool != nil {
+		c.Wakeups = controlPlaneWakeups(runCtx, c.pool)
+	}
+	resync := c.resync
+	if resync == 0 {
+		resync = defaultControllerResyncInterval
+	}
+	done := c.done
+	go func() {
+		defer close(done)
+		defer cancel()
+		if err := c.Run(runCtx, resync); err != nil && !errors.Is(err, context.Canceled) {
+			logger.Error("mcpserver controller stopped", "error", err)
+		}
+	}()
+	return nil
+}
+
+// Stop requests the MCPServer controller's background loop to exit and waits
+// for it to stop. A controller is single-use; construct a new one to start
+// again.
+func (c *MCPServerController) Stop() {
+	if c == nil {
+		return
+	}
+	c.lifecycleMu.Lock()
+	cancel := c.cancel
+	done := c.done
+	c.lifecycleMu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+	if done != nil {
+		<-done
+	}
+}
+
+func (c *MCPServerController) workQueue() workqueue.TypedRateLimitingInterface[mcpServerQueueKey] {
+	c.queueMu.Lock()
+	defer c.queueMu.Unlock()
+	if c.queue == nil {
+		c.queue = workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[mcpServerQueueKey](),
+			workqueue.TypedRateLimitingQueueConfig[mcpServerQueueKey]{Name: "mcpserver-controller"},
+		)
+	}
+	return c.queue
+}
+
+// Run drives the controller loop until ctx is cancelled. A single worker
+// drains the queue so at most one sandbox runs at a time.
+func (c *MCPServerController) Run(ctx context.Context, resync time.Duration) error {
+	if c == nil || c.Store == nil {
+		return errors.New("mcpserver controller: MCPServer store is required")
+	}
+	if c.Introspect == nil {
+		c.Introspect = introspect.New(introspect.Options{}).Introspect
+	}
+	queue := c.workQueue()
+	defer queue.ShutDown()
+
+	workerErrs := make(chan error, 1)
+	go func() { workerErrs <- c.runWorker(ctx) }()
+
+	c.enqueueAllLogged(ctx)
+
+	var ticks <-chan time.Time
+	if resync > 0 {
+		ticker := time.NewTicker(resync)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-workerErrs:
+			return err
+		case <-c.Wakeups:
+			c.enqueueAllLogged(ctx)
+		case <-ticks:
+			c.enqueueAllLogged(ctx)
+		}
+	}
+}
+
+// enqueueAllLogged runs an enqueue pass, logging (not propagating) a failure
+// so a transient list/decode error cannot kill the controller — the next
+// wakeup/resync tick retries.
+func (c *MCPServerController) enqueueAllLogged(ctx context.Context) {
+	if err := c.enqueueAll(ctx); err != nil {
+		logger.Error("mcpserver controller: enqueue pass failed (will retry on next tick)", "error", err)
+	}
+}
+
+func (c *MCPServerController) runWorker(ctx context.Context) error {
+	queue := c.workQueue()
+	for {
+		key, shutdown := queue.Get()
+		if shutdown {
+			return nil
+		}
+		c.processQueueItem(ctx, queue, key)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+func (c *MCPServerController) processQueueItem(ctx context.Context, queue workqueue.TypedRateLimitingInterface[mcpServerQueueKey], key mcpServerQueueKey) {
+	defer queue.Done(key)
+	outcome, message, err := c.reconcileKey(ctx, key)
+	if err != nil {
+		// Retryable (server unreachable, docker unavailable): back off and retry.
+		logger.Error("mcpserver reconcile failed", "namespace", key.Namespace, "name", key.Name, "tag", key.Tag, "error", err)
+		queue.AddRateLimited(key)
+		return
+	}
+	queue.Forget(key)
+	if outcome != "" {
+		logger.Debug("mcpserver reconciled", "namespace", key.Namespace, "name", key.Name, "tag", key.Tag, "outcome", outcome, "message", message)
+	}
+}
+
+// enqueueAll lists MCP servers and enqueues those not yet reconciled for
+// their current generation. The workqueue coalesces duplicate keys.
+func (c *MCPServerController) enqueueAll(ctx context.Context) error {
+	queue := c.workQueue()
+	opts := v1alpha1store.ListOpts{Limit: defaultControllerListPageSize}
+	for {
+		rows, cursor, err := c.Store.List(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("mcpserver controller: list mcp servers: %w", err)
+		}
+		for _, raw := range rows {
+			srv, err := v1alpha1.EnvelopeFromRaw(func() *v1alpha1.MCPServer { return &v1alpha1.MCPServer{} }, raw, v1alpha1.KindMCPServer)
+			if err != nil {
+				// One unparseable row must not halt reconciliation of all the
+				// others; skip it (it cannot be acted on) and continue.
+				logger.Error("mcpserver controller: skipping undecodable mcp server row", "error", err)
+				continue
+			}
+			if mcpServerReconciled(srv) {
+				continue
+			}
+			queue.Add(mcpServerQueueKey{Namespace: srv.Metadata.NamespaceOrDefault(), Name: srv.Metadata.Name, Tag: srv.Metadata.Tag})
+		}
+		if cursor == "" {
+			return nil
+		}
+		opts.Cursor = cursor
+	}
+}
+
+const mcpServerIntrospectedCondition = "Introspected"
+
+// mcpServerReconciled reports whether the controller has already acted on
+// the server's current generation. Like skillReconciled it gates on
+// ObservedGeneration alone: success and terminal failure both advance it,
+// retryable failures leave it behind so they are re-enqueued.
+func mcpServerReconciled(srv *v1alpha1.MCPServer) bool {
+	return srv.Metadata.Generation > 0 && srv.Status.ObservedGeneration >= srv.Metadata.Generation
+}
+
+func (c *MCPServerController) reconcileKey(ctx context.Context, key mcpServerQueueKey) (outcome, message string, err error) {
+	if key.Tag == "" {
+		return "", "", fmt.Errorf("mcpserver controller: empty tag for %s/%s", key.Namespace, key.Name)
+	}
+	raw, err := c.Store.Get(ctx, key.Namespace, key.Name, key.Tag)
+	if errors.Is(err, pkgdb.ErrNotFound) {
+		return "missing", "mcp server row no longer exists", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("mcpserver controller: load %s/%s:%s: %w", key.Namespace, key.Name, key.Tag, err)
+	}
+	srv, err := v1alpha1.EnvelopeFromRaw(func() *v1alpha1.MCPServer { return &v1alpha1.MCPServer{} }, raw, v1alpha1.KindMCPServer)
+	if err != nil {
+		return "", "", fmt.Errorf("mcpserver controller: decode %s/%s:%s: %w", key.Namespace, key.Name, key.Tag, err)
+	}
+	if mcpServerReconciled(srv) {
+		return "skipped", "up to date", nil
+	}
+	return c.reconcile(ctx, srv)
+}
+
+// reconcile introspects the server and patches status. It returns a non-nil
+// error only for RETRYABLE failures so the queue applies rate-limited
+// backoff; terminal failures are surfaced as a status condition with a nil
+// error (Forget).
+func (c *MCPServerController) reconcile(ctx context.Context, srv *v1alpha1.MCPServer) (string, string, error) {
+	gen := srv.Metadata.Generation
+	ns, name, tag := srv.Metadata.NamespaceOrDefault(), srv.Metadata.Name, srv.Metadata.Tag
+
+	// First observe: announce Progressing (no observedGeneration bump, so a
+	// crash mid-reconcile re-runs). On retries the condition already carries
+	// the last failure reason; don't flap it back to Progressing.
+	if srv.Status.GetCondition(mcpServerIntrospectedCondition) == nil {
+		if err := c.patchStatus(ctx, ns, name, tag, 0, func(st *v1alpha1.MCPServerStatus) {
+			setMCPServerIntrospected(st, v1alpha1.ConditionFalse, "Progressing", "introspecting mcp server")
+		}); err != nil {
+			return "", "", err
+		}
+	}
+
+	caps, err := c.Introspect(ctx, name, srv.Spec)
+	if err != nil {
+		reason, terminal := classifyMCPServerIntrospectErr(err)
+		if !terminal {
+			patchErr := c.patchStatus(ctx, ns, name, tag, 0, func(st *v1alpha1.MCPServerStatus) {
+				setMCPServerIntrospected(st, v1alpha1.ConditionFalse, reason, err.Error())
+			})
+			if patchErr != nil {
+				return "", "", patchErr
+			}
+			return "", "", err // retryable
+		}
+		// Capabilities observed for an earlier generation no longer describe
+		// this spec; drop them rather than show a stale surface.
+		return "failed", reason, c.patchStatus(ctx, ns, name, tag, gen, func(st *v1alpha1.MCPServerStatus) {
+			st.Capabilities = nil
+			setMCPServerIntrospected(st, v1alpha1.ConditionFalse, reason, err.Error())
+		})
+	}
+
+	message := fmt.Sprintf("%d tools, %d prompts, %d resources", len(caps.Tools), len(caps.Prompts), len(caps.Resources)+len(caps.ResourceTemplates))
+	return "introspected", message, c.patchStatus(ctx, ns, name, tag, gen, func(st *v1alpha1.MCPServerStatus) {
+		st.Capabilities = caps
+		setMCPServerIntrospected(st, v1alpha1.ConditionTrue, "Introspected", message)
+	})
+}
+
+// classifyMCPServerIntrospectErr maps an introspection error to a status
+// reason and whether it is terminal (Forget) or retryable (rate-limited
+// requeue).
+func classifyMCPServerIntrospectErr(err error) (reason string, terminal bool) {
+	switch {
+	case errors.Is(err, introspect.ErrUnsupported):
+		return "IntrospectionUnsupported", true
+	case errors.Is(err, introspect.ErrProtocol):
+		return "ProtocolError", true
+	default:
+		return "ServerUnreachable", false
+	}
+}
+
+func setMCPServerIntrospected(st *v1alpha1.MCPServerStatus, status v1alpha1.ConditionStatus, reason, message string) {
+	st.SetCondition(v1alpha1.Condition{Type: mcpServerIntrospectedCondition, Status: status, Reason: reason, Message: message})
+}
+
+// patchStatus applies a status mutation out of band via the raw-JSON patch
+// callback. bumpGen>0 advances ObservedGeneration (success / terminal
+// paths); 0 leaves it unchanged (Progressing / retryable paths) so the
+// server re-reconciles.
+func (c *MCPServerController) patchStatus(ctx context.Context, ns, name, tag string, bumpGen int64, mutate func(*v1alpha1.MCPServerStatus)) error {
+	return c.Store.ApplyPatch(ctx, ns, name, tag, v1alpha1store.PatchOpts{
+		Status: func(current json.RawMessage) (json.RawMessage, error) {
+			tmp := &v1alpha1.MCPServer{}
+			if err := tmp.UnmarshalStatus(current); err != nil {
+				return nil, err
+			}
+			mutate(&tmp.Status)
+			if bumpGen > 0 && tmp.Status.ObservedGeneration < bumpGen {
+				tmp.Status.ObservedGeneration = bumpGen
+			}
+			return tmp.MarshalStatus()
+		},
+	})
+}
@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/introspect"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// fakeMCPServerStore captures status patches by replaying the raw-JSON
+// callback, so reconcile/patchStatus can be tested with no database.
+type fakeMCPServerStore struct {
+	status   map[string]json.RawMessage
+	reasons  []string // Introspected-condition reasons, in apply order
+	listRows []*v1alpha1.RawObject
+}
+
+func newFakeMCPServerStore() *fakeMCPServerStore {
+	return &fakeMCPServerStore{status: map[string]json.RawMessage{}}
+}
+
+func (f *fakeMCPServerStore) key(ns, name, tag string) string { return ns + "/" + name + ":" + tag }
+
+func (f *fakeMCPServerStore) Get(context.Context, string, string, string) (*v1alpha1.RawObject, error) {
+	return nil, pkgdb.ErrNotFound
+}
+
+func (f *fakeMCPServerStore) List(context.Context, v1alpha1store.ListOpts) ([]*v1alpha1.RawObject, string, error) {
+	return f.listRows, "", nil // single page
+}
+
+func (f *fakeMCPServerStore) ApplyPatch(_ context.Context, ns, name, tag string, patch v1alpha1store.PatchOpts) error {
+	k := f.key(ns, name, tag)
+	out, err := patch.Status(f.status[k])
+	if err != nil {
+		return err
+	}
+	f.status[k] = out
+	tmp := &v1alpha1.MCPServer{}
+	if err := tmp.UnmarshalStatus(out); err != nil {
+		return err
+	}
+	if cond := tmp.Status.GetCondition(mcpServerIntrospectedCondition); cond != nil {
+		f.reasons = append(f.reasons, cond.Reason)
+	}
+	return nil
+}
+
+func (f *fakeMCPServerStore) server(t *testing.T, ns, name, tag string) *v1alpha1.MCPServer {
+	t.Helper()
+	s := &v1alpha1.MCPServer{}
+	if err := s.UnmarshalStatus(f.status[f.key(ns, name, tag)]); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestClassifyMCPServerIntrospectErr(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantReason   string
+		wantTerminal bool
+	}{
+		{"unsupported", fmt.Errorf("wrap: %w", introspect.ErrUnsupported), "IntrospectionUnsupported", true},
+		{"protocol error", fmt.Errorf("wrap: %w", introspect.ErrProtocol), "ProtocolError", true},
+		{"transient", errors.New("connect: dial tcp: timeout"), "ServerUnreachable", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, terminal := classifyMCPServerIntrospectErr(tt.err)
+			if reason != tt.wantReason || terminal != tt.wantTerminal {
+				t.Fatalf("classifyMCPServerIntrospectErr = (%q, %v), want (%q, %v)", reason, terminal, tt.wantReason, tt.wantTerminal)
+			}
+		})
+	}
+}
+
+func TestMCPServerEnqueueAllSkipsReconciledAndUndecodableRows(t *testing.T) {
+	rawOf := func(name, spec, status string) *v1alpha1.RawObject {
+		return &v1alpha1.RawObject{
+			TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindMCPServer},
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: name, Tag: "v1", Generation: 1},
+			Spec:     json.RawMessage(spec),
+			Status:   json.RawMessage(status),
+		}
+	}
+	store := newFakeMCPServerStore()
+	store.listRows = []*v1alpha1.RawObject{
+		rawOf("bad", `not json`, ``),
+		rawOf("done", `{"remote":{"type":"streamable-http","url":"https://x"}}`, `{"observedGeneration":1}`),
+		rawOf("pending", `{"remote":{"type":"streamable-http","url":"https://x"}}`, ``),
+	}
+	c := &MCPServerController{Store: store}
+
+	if err := c.enqueueAll(context.Background()); err != nil {
+		t.Fatalf("enqueueAll: %v", err)
+	}
+	if n := c.workQueue().Len(); n != 1 {
+		t.Fatalf("expected only the pending row enqueued, queue len = %d", n)
+	}
+}
+
+func TestMCPServerReconcile(t *testing.T) {
+	const ns, name, tag = "default", "acme/echo", "v1"
+	newServer := func(gen int64) *v1alpha1.MCPServer {
+		s := &v1alpha1.MCPServer{Metadata: v1alpha1.ObjectMeta{Namespace: ns, Name: name, Tag: tag, Generation: gen}}
+		s.Spec.Remote = &v1alpha1.MCPRemote{Type: "streamable-http", URL: "https://mcp.example.com/mcp"}
+		return s
+	}
+	caps := &v1alpha1.MCPServerCapabilities{
+		ProtocolVersion: "2025-06-18",
+		ServerInfo:      &v1alpha1.MCPImplementation{Name: "echo", Version: "1.0.0"},
+		Tools:           []v1alpha1.MCPToolInfo{{Name: "echo", InputSchema: json.RawMessage(`{"type":"object"}`)}},
+	}
+	introspectTo := func(out *v1alpha1.MCPServerCapabilities, err error) MCPServerIntrospectFunc {
+		return func(context.Context, string, v1alpha1.MCPServerSpec) (*v1alpha1.MCPServerCapabilities, error) {
+			return out, err
+		}
+	}
+
+	t.Run("success records capabilities and bumps observedGeneration", func(t *testing.T) {
+		store := newFakeMCPServerStore()
+		var gotName string
+		c := &MCPServerController{Store: store, Introspect: func(_ context.Context, n string, _ v1alpha1.MCPServerSpec) (*v1alpha1.MCPServerCapabilities, error) {
+			gotName = n
+			return caps, nil
+		}}
+		outcome, _, err := c.reconcile(context.Background(), newServer(2))
+		if err != nil || outcome != "introspected" {
+			t.Fatalf("reconcile = (%q, %v), want (introspected, nil)", outcome, err)
+		}
+		if gotName != name {
+			t.Errorf("introspect name = %q, want %q", gotName, name)
+		}
+		if !reflect.DeepEqual(store.reasons, []string{"Progressing", "Introspected"}) {
+			t.Fatalf("reason sequence = %v, want [Progressing Introspected]", store.reasons)
+		}
+		got := store.server(t, ns, name, tag)
+		if !got.Status.IsConditionTrue(mcpServerIntrospectedCondition) || got.Status.ObservedGeneration != 2 {
+			t.Fatalf("status = %+v", got.Status.Status)
+		}
+		if !reflect.DeepEqual(got.Status.Capabilities, caps) {
+			t.Fatalf("capabilities = %+v, want %+v", got.Status.Capabilities, caps)
+		}
+	})
+
+	t.Run("terminal failure clears stale capabilities and bumps observedGeneration", func(t *testing.T) {
+		store := newFakeMCPServerStore()
+		c := &MCPServerController{Store: store, Introspect: introspectTo(caps, nil)}
+		if _, _, err := c.reconcile(context.Background(), newServer(1)); err != nil {
+			t.Fatal(err)
+		}
+		c.Introspect = introspectTo(nil, fmt.Errorf("%w: tools/list: boom", introspect.ErrProtocol))
+		outcome, reason, err := c.reconcile(context.Background(), newServer(2))
+		if err != nil {
+			t.Fatalf("terminal failure must return nil error (Forget), got %v", err)
+		}
+		if outcome != "failed" || reason != "ProtocolError" {
+			t.Fatalf("got (%q, %q), want (failed, ProtocolError)", outcome, reason)
+		}
+		got := store.server(t, ns, name, tag)
+		if got.Status.ObservedGeneration != 2 || got.Status.Capabilities != nil {
+			t.Fatalf("observedGeneration = %d, capabilities = %+v", got.Status.ObservedGeneration, got.Status.Capabilities)
+		}
+	})
+
+	t.Run("retryable failure requeues and leaves observedGeneration behind", func(t *testing.T) {
+		store := newFakeMCPServerStore()
+		c := &MCPServerController{Store: store, Introspect: introspectTo(nil, errors.New("connect: dial tcp: timeout"))}
+		if _, _, err := c.reconcile(context.Background(), newServer(4)); err == nil {
+			t.Fatal("retryable failure must return a non-nil error (requeue)")
+		}
+		got := store.server(t, ns, name, tag)
+		if got.Status.ObservedGeneration != 0 {
+			t.Errorf("retryable must NOT bump observedGeneration, got %d", got.Status.ObservedGeneration)
+		}
+		if c := got.Status.GetCondition(mcpServerIntrospectedCondition); c == nil || c.Reason != "ServerUnreachable" {
+			t.Errorf("condition = %+v, want ServerUnreachable", c)
+		}
+	})
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/signing"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
 	"github.com/agentregistry-dev/agentregistry/pkg/types"
 )
@@ -21,12 +22,21 @@ const (
 	DeploymentForceAnnotation     = "reconcile.agentregistry.dev/force"
 
 	deploymentControllerDetailsKey = "deploymentController"
+
+	// TargetDeprecatedCondition reports whether the deployment's target
+	// tag has been deprecated or yanked. It is informational: the
+	// deployment keeps running its target either way.
+	TargetDeprecatedCondition = "TargetDeprecated"
 )
 
 type deploymentControllerDetails struct {
 	LastAppliedFingerprint string                          `json:"lastAppliedFingerprint,omitempty"`
 	LastForceToken         string                          `json:"lastForceToken,omitempty"`
 	Dependencies           []types.ApplyDependencySnapshot `json:"dependencies,omitempty"`
+	// ResolvedTarget is spec.targetRef with its tag resolved to the concrete
+	// tag that was applied, so a semver-constrained targetRef ("^1.2")
+	// records which version is actually running.
+	ResolvedTarget *v1alpha1.ResourceRef `json:"resolvedTarget,omitempty"`
 }
 
 func (c *DeploymentController) processQueueItem(
@@ -81,6 +91,14 @@ func (c *DeploymentController) apply(ctx context.Context, deployment *v1alpha1.D
 		}
 		return "", "", err
 	}
+	if err := c.recordTargetLifecycle(ctx, deployment, target); err != nil {
+		return "", "", err
+	}
+	if deployment.Spec.RequireSignedTarget {
+		if err := signing.RequireVerified(target); err != nil {
+			return c.block(ctx, deployment, "TargetNotVerified", err.Error())
+		}
+	}
 	runtime, err := c.resolveRuntime(ctx, deployment)
 	if err != nil {
 		if errors.Is(err, v1alpha1.ErrDanglingRef) {
@@ -101,6 +119,7 @@ func (c *DeploymentController) apply(ctx context.Context, deployment *v1alpha1.D
 		Target:     target,
 		Runtime:    runtime,
 		Getter:     c.Getter,
+		Secrets:    c.Secrets,
 	}
 	fingerprintResult, err := desiredApplyFingerprint(ctx, adapter, input)
 	if err != nil {
@@ -123,7 +142,7 @@ func (c *DeploymentController) apply(ctx context.Context, deployment *v1alpha1.D
 		}
 		return "", "", fmt.Errorf("adapter %q apply: %w", adapter.Type(), err)
 	}
-	if err := c.persistApplyResult(ctx, deployment, result, fingerprint, forceToken, fingerprintResult.Dependencies); err != nil {
+	if err := c.persistApplyResult(ctx, deployment, result, fingerprint, forceToken, fingerprintResult.Dependencies, resolvedTargetRef(deployment, target)); err != nil {
 		return "", "", err
 	}
 	return "success", "deployment applied", nil
@@ -178,15 +197,21 @@ func (c *DeploymentController) blockReference(ctx context.Context, deployment *v
 	if cause != nil {
 		message = cause.Error()
 	}
+	return c.block(ctx, deployment, "ReferencePending", message)
+}
+
+// block records a not-Ready condition without applying, leaving the
+// Deployment to be retried when its inputs change.
+func (c *DeploymentController) block(ctx context.Context, deployment *v1alpha1.Deployment, reason, message string) (string, string, error) {
 	if err := c.persistApplyResult(ctx, deployment, &types.ApplyResult{
 		Conditions: []v1alpha1.Condition{{
 			Type:               "Ready",
 			Status:             v1alpha1.ConditionFalse,
-			Reason:             "ReferencePending",
+			Reason:             reason,
 			Message:            message,
 			ObservedGeneration: deployment.Metadata.Generation,
 		}},
-	}, "", "", nil); err != nil {
+	}, "", "", nil, nil); err != nil {
 		return "", "", err
 	}
 	return "blocked", message, nil
@@ -231,6 +256,56 @@ func (c *DeploymentController) resolveTarget(ctx context.Context, deployment *v1
 	return obj, nil
 }
 
+// recordTargetLifecycle sets the TargetDeprecated condition from the
+// target tag's lifecycle. Deployments whose target was never deprecated
+// get no condition at all; one that was gets it flipped back to False once
+// the tag is reactivated or the deployment moves to another tag.
+func (c *DeploymentController) recordTargetLifecycle(ctx context.Context, deployment *v1alpha1.Deployment, target v1alpha1.Object) error {
+	raw, err := target.MarshalStatus()
+	if err != nil {
+		return fmt.Errorf("encode target status: %w", err)
+	}
+	lifecycle, err := v1alpha1.LifecycleFromStorage(raw)
+	if err != nil {
+		return fmt.Errorf("decode target lifecycle: %w", err)
+	}
+	if lifecycle == nil && deployment.Status.GetCondition(TargetDeprecatedCondition) == nil {
+		return nil
+	}
+	cond := v1alpha1.Condition{
+		Type:               TargetDeprecatedCondition,
+		Status:             v1alpha1.ConditionFalse,
+		Reason:             string(v1alpha1.LifecycleActive),
+		ObservedGeneration: deployment.Metadata.Generation,
+	}
+	if lifecycle != nil {
+		meta := target.GetMetadata()
+		cond.Status = v1alpha1.ConditionTrue
+		cond.Reason = string(lifecycle.State)
+		cond.Message = lifecycle.Describe(fmt.Sprintf("%s %s/%s:%s", target.GetKind(), meta.NamespaceOrDefault(), meta.Name, meta.Tag))
+	}
+	err = c.deploymentStore().PatchStatus(ctx, deployment.Metadata.NamespaceOrDefault(), deployment.Metadata.Name, "", v1alpha1.StatusPatcher(func(s *v1alpha1.Status) {
+		s.SetCondition(cond)
+	}))
+	if err != nil && !errors.Is(err, pkgdb.ErrNotFound) {
+		return fmt.Errorf("record target lifecycle: %w", err)
+	}
+	return nil
+}
+
+// resolvedTargetRef returns the deployment's targetRef pinned to the tag the
+// getter resolved it to: blank and semver-constraint tags become the concrete
+// tag of the row that was loaded.
+func resolvedTargetRef(deployment *v1alpha1.Deployment, target v1alpha1.Object) *v1alpha1.ResourceRef {
+	meta := target.GetMetadata()
+	return &v1alpha1.ResourceRef{
+		Kind:      deployment.Spec.TargetRef.Kind,
+		Namespace: meta.NamespaceOrDefault(),
+		Name:      meta.Name,
+		Tag:       meta.Tag,
+	}
+}
+
 func (c *DeploymentController) resolveRuntime(ctx context.Context, deployment *v1alpha1.Deployment) (*v1alpha1.Runtime, error) {
 	if c.Getter == nil {
 		return nil, errors.New("deployment controller: getter is nil")
@@ -263,13 +338,14 @@ func (c *DeploymentController) persistApplyResult(
 	fingerprint string,
 	forceToken string,
 	dependencies []types.ApplyDependencySnapshot,
+	resolvedTarget *v1alpha1.ResourceRef,
 ) error {
 	patch := v1alpha1store.PatchOpts{
 		Finalizers: ensureFinalizer(DeploymentControllerFinalizer),
 	}
 	if result == nil {
 		if fingerprint != "" {
-			patch.Status = deploymentControllerStatusPatch(deployment, nil, fingerprint, forceToken, dependencies)
+			patch.Status = deploymentControllerStatusPatch(deployment, nil, fingerprint, forceToken, dependencies, resolvedTarget)
 		}
 		if err := c.deploymentStore().ApplyPatch(ctx, deployment.Metadata.NamespaceOrDefault(), deployment.Metadata.Name, "", patch); err != nil {
 			return fmt.Errorf("persist apply result: %w", err)
@@ -277,7 +353,7 @@ func (c *DeploymentController) persistApplyResult(
 		return nil
 	}
 	if len(result.Conditions) > 0 || len(result.Details) > 0 || fingerprint != "" {
-		patch.Status = deploymentControllerStatusPatch(deployment, result, fingerprint, forceToken, dependencies)
+		patch.Status = deploymentControllerStatusPatch(deployment, result, fingerprint, forceToken, dependencies, resolvedTarget)
 	}
 	if len(result.RuntimeMetadata) > 0 {
 		patch.Annotations = func(annotations map[string]string) map[string]string {
@@ -300,6 +376,7 @@ func deploymentControllerStatusPatch(
 	fingerprint string,
 	forceToken string,
 	dependencies []types.ApplyDependencySnapshot,
+	resolvedTarget *v1alpha1.ResourceRef,
 ) func(current json.RawMessage) (json.RawMessage, error) {
 	return v1alpha1.StatusPatcher(func(s *v1alpha1.Status) {
 		if s.ObservedGeneration < deployment.Metadata.Generation {
@@ -318,6 +395,7 @@ func deploymentControllerStatusPatch(
 				LastAppliedFingerprint: fingerprint,
 				LastForceToken:         forceToken,
 				Dependencies:           dependencies,
+				ResolvedTarget:         resolvedTarget,
 			})
 		}
 	})
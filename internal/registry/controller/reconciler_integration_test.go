@@ -14,6 +14,7 @@ import (
 	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/signing"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
 	"github.com/agentregistry-dev/agentregistry/pkg/types"
 )
@@ -151,6 +152,98 @@ func TestDeploymentController_ForceAnnotationBypassesUnchangedApplyOnce(t *testi
 	require.Equal(t, "manual-1", details.LastForceToken)
 }
 
+func TestDeploymentController_RecordsResolvedTargetTag(t *testing.T) {
+	ctx := context.Background()
+	stores := newControllerTestStores(t)
+	seedRuntime(t, stores, "local")
+	for _, tag := range []string{"1.0.0", "1.3.0", "2.0.0"} {
+		_, err := stores[v1alpha1.KindMCPServer].Upsert(ctx, &v1alpha1.MCPServer{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "weather", Tag: tag},
+			Spec:     v1alpha1.MCPServerSpec{Remote: &v1alpha1.MCPRemote{Type: "streamable-http", URL: "https://weather.example.com/mcp"}},
+		})
+		require.NoError(t, err)
+	}
+	deployment := &v1alpha1.Deployment{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "weather-range"},
+		Spec: v1alpha1.DeploymentSpec{
+			TargetRef:    v1alpha1.ResourceRef{Kind: v1alpha1.KindMCPServer, Name: "weather", Tag: "^1"},
+			RuntimeRef:   v1alpha1.ResourceRef{Kind: v1alpha1.KindRuntime, Name: "local"},
+			DesiredState: v1alpha1.DesiredStateDeployed,
+		},
+	}
+	_, err := stores[v1alpha1.KindDeployment].Upsert(ctx, deployment, v1alpha1store.UpsertOpts{
+		InitialFinalizers: []string{DeploymentControllerFinalizer},
+	})
+	require.NoError(t, err)
+
+	adapter := &recordingDeploymentAdapter{}
+	controller := newDeploymentTestController(stores, adapter)
+	_, err = controller.FullReconcile(ctx)
+	require.NoError(t, err)
+	processed, err := controller.RunOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, processed)
+	require.Equal(t, int32(1), adapter.applyCalls.Load())
+
+	got := loadDeployment(t, stores, "weather-range")
+	var details deploymentControllerDetails
+	ok, err := got.Status.GetDetailsKey(deploymentControllerDetailsKey, &details)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NotNil(t, details.ResolvedTarget)
+	require.Equal(t, "1.3.0", details.ResolvedTarget.Tag)
+	require.Equal(t, "^1", got.Spec.TargetRef.Tag, "spec keeps the constraint")
+}
+
+func TestDeploymentController_ReappliesWhenTargetAliasMoves(t *testing.T) {
+	ctx := context.Background()
+	stores := newControllerTestStores(t)
+	seedRuntime(t, stores, "local")
+	servers := stores[v1alpha1.KindMCPServer]
+	for _, tag := range []string{"1.0.0", "1.1.0"} {
+		_, err := servers.Upsert(ctx, &v1alpha1.MCPServer{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "weather", Tag: tag},
+			Spec:     v1alpha1.MCPServerSpec{Remote: &v1alpha1.MCPRemote{Type: "streamable-http", URL: "https://weather.example.com/" + tag}},
+		})
+		require.NoError(t, err)
+	}
+	_, _, err := servers.PromoteTag(ctx, "default", "weather", "1.0.0", "stable")
+	require.NoError(t, err)
+	_, err = stores[v1alpha1.KindDeployment].Upsert(ctx, &v1alpha1.Deployment{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "weather-stable"},
+		Spec: v1alpha1.DeploymentSpec{
+			TargetRef:    v1alpha1.ResourceRef{Kind: v1alpha1.KindMCPServer, Name: "weather", Tag: "stable"},
+			RuntimeRef:   v1alpha1.ResourceRef{Kind: v1alpha1.KindRuntime, Name: "local"},
+			DesiredState: v1alpha1.DesiredStateDeployed,
+		},
+	}, v1alpha1store.UpsertOpts{InitialFinalizers: []string{DeploymentControllerFinalizer}})
+	require.NoError(t, err)
+
+	adapter := &recordingDeploymentAdapter{}
+	controller := newDeploymentTestController(stores, adapter)
+	_, err = controller.FullReconcile(ctx)
+	require.NoError(t, err)
+	_, err = controller.RunOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), adapter.applyCalls.Load())
+
+	_, _, err = servers.PromoteTag(ctx, "default", "weather", "1.1.0", "stable")
+	require.NoError(t, err)
+	_, err = controller.FullReconcile(ctx)
+	require.NoError(t, err)
+	_, err = controller.RunOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), adapter.applyCalls.Load(), "moving the alias must roll the deployment")
+
+	got := loadDeployment(t, stores, "weather-stable")
+	var details deploymentControllerDetails
+	ok, err := got.Status.GetDetailsKey(deploymentControllerDetailsKey, &details)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NotNil(t, details.ResolvedTarget)
+	require.Equal(t, "1.1.0", details.ResolvedTarget.Tag)
+}
+
 func TestDeploymentController_BlocksMissingTargetWithoutAdapterCall(t *testing.T) {
 	ctx := context.Background()
 	stores := newControllerTestStores(t)
@@ -174,6 +267,92 @@ func TestDeploymentController_BlocksMissingTargetWithoutAdapterCall(t *testing.T
 	require.Equal(t, "ReferencePending", ready.Reason)
 }
 
+func TestDeploymentController_RequireSignedTargetBlocksUntilVerified(t *testing.T) {
+	ctx := context.Background()
+	stores := newControllerTestStores(t)
+	seedRuntime(t, stores, "local")
+	seedMCPServer(t, stores, "weather")
+	deployment := &v1alpha1.Deployment{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "signed-only"},
+		Spec: v1alpha1.DeploymentSpec{
+			TargetRef:           v1alpha1.ResourceRef{Kind: v1alpha1.KindMCPServer, Name: "weather", Tag: v1alpha1store.DefaultTag()},
+			RuntimeRef:          v1alpha1.ResourceRef{Kind: v1alpha1.KindRuntime, Name: "local"},
+			DesiredState:        v1alpha1.DesiredStateDeployed,
+			RequireSignedTarget: true,
+		},
+	}
+	_, err := stores[v1alpha1.KindDeployment].Upsert(ctx, deployment, v1alpha1store.UpsertOpts{
+		InitialFinalizers: []string{DeploymentControllerFinalizer},
+	})
+	require.NoError(t, err)
+
+	adapter := &recordingDeploymentAdapter{}
+	controller := newDeploymentTestController(stores, adapter)
+	_, err = controller.FullReconcile(ctx)
+	require.NoError(t, err)
+	_, err = controller.RunOnce(ctx)
+	require.NoError(t, err)
+	require.Zero(t, adapter.applyCalls.Load())
+
+	ready := loadDeployment(t, stores, "signed-only").Status.GetCondition("Ready")
+	require.NotNil(t, ready)
+	require.Equal(t, v1alpha1.ConditionFalse, ready.Status)
+	require.Equal(t, "TargetNotVerified", ready.Reason)
+
+	require.NoError(t, stores[v1alpha1.KindMCPServer].PatchStatus(ctx, "default", "weather", v1alpha1store.DefaultTag(),
+		v1alpha1.StatusPatcher(func(s *v1alpha1.Status) {
+			s.SetCondition(v1alpha1.Condition{
+				Type:               signing.VerifiedCondition,
+				Status:             v1alpha1.ConditionTrue,
+				Reason:             signing.ReasonVerified,
+				ObservedGeneration: 1,
+			})
+		})))
+	_, err = controller.FullReconcile(ctx)
+	require.NoError(t, err)
+	_, err = controller.RunOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), adapter.applyCalls.Load(), "a verified target unblocks the deployment")
+}
+
+func TestDeploymentController_RecordsTargetDeprecated(t *testing.T) {
+	ctx := context.Background()
+	stores := newControllerTestStores(t)
+	seedRuntime(t, stores, "local")
+	seedMCPServer(t, stores, "weather")
+	seedDeployment(t, stores, "api", v1alpha1.DesiredStateDeployed)
+
+	adapter := &recordingDeploymentAdapter{}
+	controller := newDeploymentTestController(stores, adapter)
+	reconcile := func() *v1alpha1.Condition {
+		t.Helper()
+		_, err := controller.FullReconcile(ctx)
+		require.NoError(t, err)
+		_, err = controller.RunOnce(ctx)
+		require.NoError(t, err)
+		return loadDeployment(t, stores, "api").Status.GetCondition(TargetDeprecatedCondition)
+	}
+	setLifecycle := func(lifecycle *v1alpha1.TagLifecycle) {
+		t.Helper()
+		require.NoError(t, stores[v1alpha1.KindMCPServer].SetTagLifecycle(ctx, "default", "weather", v1alpha1store.DefaultTag(), lifecycle))
+	}
+
+	require.Nil(t, reconcile(), "active targets leave no condition behind")
+
+	setLifecycle(&v1alpha1.TagLifecycle{State: v1alpha1.LifecycleYanked, Reason: "CVE-2026-1"})
+	cond := reconcile()
+	require.NotNil(t, cond)
+	require.Equal(t, v1alpha1.ConditionTrue, cond.Status)
+	require.Equal(t, string(v1alpha1.LifecycleYanked), cond.Reason)
+	require.Contains(t, cond.Message, "CVE-2026-1")
+	require.Equal(t, int32(1), adapter.applyCalls.Load(), "a yanked target keeps running")
+
+	setLifecycle(nil)
+	cond = reconcile()
+	require.NotNil(t, cond)
+	require.Equal(t, v1alpha1.ConditionFalse, cond.Status)
+}
+
 func TestDeploymentController_ReappliesWhenMissingTargetAppears(t *testing.T) {
 	ctx := context.Background()
 	stores := newControllerTestStores(t)
@@ -40,6 +40,9 @@ type ControllerConfig struct {
 	DiscoveryInterval          time.Duration
 	DiscoveryStaleAfterMisses  int
 	DiscoveryDeleteAfterMisses int
+	// Secrets resolves Model SecretKeyRefs for adapters at apply time. Nil
+	// leaves secret-backed Models unresolvable.
+	Secrets types.SecretResolverFunc
 }
 
 // StartDeploymentController constructs the Deployment controller, runs the
@@ -64,6 +67,7 @@ func StartDeploymentController(
 		Stores:   stores,
 		Adapters: adapters,
 		Getter:   internaldb.NewGetter(stores),
+		Secrets:  config.Secrets,
 		Events:   controlPlaneEventStore,
 	}
 	if _, err := controller.Refresh(ctx); err != nil {
@@ -8,10 +8,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"k8s.io/client-go/util/workqueue"
 
 	"github.com/agentregistry-dev/agentregistry/internal/cli/common/gitutil"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/plugins/bundle"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/plugins/source"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/skills"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
@@ -22,11 +26,61 @@ import (
 // a fake instead of touching the network.
 type SkillResolveFunc func(ctx context.Context, repo *v1alpha1.Repository) (commit string, err error)
 
+// SkillResolveOCIFunc confirms a digest-pinned OCI reference holds a skill
+// and returns its manifest digest.
+type SkillResolveOCIFunc func(ctx context.Context, reference string) (digest string, err error)
+
+// SkillStatArchiveFunc reports whether an uploaded skill archive exists. It
+// returns nil when it does and pkgdb.ErrNotFound when it does not.
+type SkillStatArchiveFunc func(ctx context.Context, digest string) error
+
 // SkillControllerDeps are the Skill controller's dependencies. Resolve pins a
 // skill's git source ref to a commit; it defaults to a git ls-remote resolver
-// when nil.
+// when nil. ResolveOCI pins an OCI source; it defaults to pulling the
+// artifact with the ambient docker keychain and checking for SKILL.md when
+// nil. StatArchive checks uploaded
+// archives; when nil, archive sources fail with SourceUnsupported.
 type SkillControllerDeps struct {
-	Resolve SkillResolveFunc
+	Resolve     SkillResolveFunc
+	ResolveOCI  SkillResolveOCIFunc
+	StatArchive SkillStatArchiveFunc
+}
+
+var (
+	// errSkillSourceUnsupported marks a source channel this build cannot
+	// resolve — TERMINAL.
+	errSkillSourceUnsupported = errors.New("skill source is not supported by this registry")
+	// errSkillSourceInvalid marks a source that can never resolve as
+	// written (e.g. an unparseable OCI reference) — TERMINAL.
+	errSkillSourceInvalid = errors.New("skill source is invalid")
+	// errSkillOCINotFound marks an OCI reference the registry reports as
+	// missing — TERMINAL.
+	errSkillOCINotFound = errors.New("oci artifact not found")
+	// errSkillArchiveNotFound marks an archive digest with no upload —
+	// TERMINAL; upload the archive and re-apply the Skill.
+	errSkillArchiveNotFound = errors.New("skill archive not found")
+)
+
+// defaultSkillResolveOCI pulls the digest-pinned artifact, authenticating
+// like `docker pull` would, and checks its layers hold a SKILL.md at the
+// root. Only an artifact that materializes as a skill is pinned.
+func defaultSkillResolveOCI(ctx context.Context, reference string) (string, error) {
+	ref, err := name.NewDigest(reference)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errSkillSourceInvalid, err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, skillResolveTimeout)
+	defer cancel()
+	if _, err := skills.NewMaterializer(nil).PullOCI(ctx, ref); err != nil {
+		switch {
+		case errors.Is(err, source.ErrSourceNotFound):
+			return "", fmt.Errorf("%w: %s", errSkillOCINotFound, reference)
+		case errors.Is(err, skills.ErrMissingManifest), errors.Is(err, bundle.ErrInvalidBundle):
+			return "", fmt.Errorf("%w: %s: %v", errSkillSourceInvalid, reference, err)
+		}
+		return "", fmt.Errorf("resolve %s: %w", reference, err)
+	}
+	return ref.DigestStr(), nil
 }
 
 // defaultSkillResolve pins a skill's git source by resolving its ref (an
@@ -62,9 +116,10 @@ type skillQueueKey struct {
 }
 
 // SkillController reconciles Skill resources out of band of the API write: it
-// resolves each skill's pinned git source ref to a concrete commit and records
-// it in SkillStatus.ResolvedSource. It stores NOTHING: the skill content stays
-// at its origin and is materialized from source at deploy time. It mirrors the
+// resolves each skill's source to a concrete git commit or content digest and
+// records it in SkillStatus.ResolvedSource. It stores NOTHING: the skill
+// content stays at its origin (git, an OCI registry, or the registry's
+// uploaded archives) and is materialized from source at deploy time. It mirrors the
 // Plugin controller's resolve-and-pin model, minus the manifest/inventory scan
 // (a skill has no bundle to enumerate).
 //
@@ -74,9 +129,11 @@ type skillQueueKey struct {
 // updates), so the controller does not wake itself. Each controller opens its
 // OWN control-plane LISTEN subscription.
 type SkillController struct {
-	Store   skillStore
-	Resolve SkillResolveFunc
-	Wakeups <-chan struct{}
+	Store       skillStore
+	Resolve     SkillResolveFunc
+	ResolveOCI  SkillResolveOCIFunc
+	StatArchive SkillStatArchiveFunc
+	Wakeups     <-chan struct{}
 
 	pool   *pgxpool.Pool
 	resync time.Duration
@@ -107,11 +164,17 @@ func NewSkillController(
 	if resolve == nil {
 		resolve = defaultSkillResolve
 	}
+	resolveOCI := deps.ResolveOCI
+	if resolveOCI == nil {
+		resolveOCI = defaultSkillResolveOCI
+	}
 	return &SkillController{
-		Store:   store,
-		Resolve: resolve,
-		pool:    pool,
-		resync:  defaultControllerResyncInterval,
+		Store:       store,
+		Resolve:     resolve,
+		ResolveOCI:  resolveOCI,
+		StatArchive: deps.StatArchive,
+		pool:        pool,
+		resync:      defaultControllerResyncInterval,
 	}, nil
 }
 
@@ -317,7 +380,7 @@ func (c *SkillController) reconcileKey(ctx context.Context, key skillQueueKey) (
 	return c.reconcile(ctx, sk)
 }
 
-// reconcile resolves+pins the skill's git source and patches status. It returns
+// reconcile resolves+pins the skill's source and patches status. It returns
 // a non-nil error only for RETRYABLE failures (origin outage) so the queue
 // applies rate-limited backoff; terminal failures (missing/unsupported source,
 // ref not found) are surfaced as a status condition with a nil error (Forget).
@@ -325,10 +388,10 @@ func (c *SkillController) reconcile(ctx context.Context, sk *v1alpha1.Skill) (st
 	gen := sk.Metadata.Generation
 	ns, name, tag := sk.Metadata.NamespaceOrDefault(), sk.Metadata.Name, sk.Metadata.Tag
 
-	// A skill with no git source has nothing to pin — terminal, no retry.
-	if sk.Spec.Source == nil || sk.Spec.Source.Repository == nil || sk.Spec.Source.Repository.URL == "" {
+	// A skill with no source has nothing to pin — terminal, no retry.
+	if !skillHasSource(sk.Spec.Source) {
 		return "failed", "SourceMissing", c.patchStatus(ctx, ns, name, tag, gen, func(st *v1alpha1.SkillStatus) {
-			setSkillReady(st, v1alpha1.ConditionFalse, "SourceMissing", "skill has no source.repository.url to resolve")
+			setSkillReady(st, v1alpha1.ConditionFalse, "SourceMissing", "skill has no source.repository.url, source.oci, or source.archive to resolve")
 		})
 	}
 
@@ -343,7 +406,7 @@ func (c *SkillController) reconcile(ctx context.Context, sk *v1alpha1.Skill) (st
 		}
 	}
 
-	commit, err := c.Resolve(ctx, sk.Spec.Source.Repository)
+	resolved, err := c.resolveSource(ctx, sk.Spec.Source)
 	if err != nil {
 		reason, terminal := classifySkillResolveErr(err)
 		bump := int64(0)
@@ -360,19 +423,66 @@ func (c *SkillController) reconcile(ctx context.Context, sk *v1alpha1.Skill) (st
 	}
 
 	return "resolved", "", c.patchStatus(ctx, ns, name, tag, gen, func(st *v1alpha1.SkillStatus) {
-		st.ResolvedSource = &v1alpha1.SkillResolvedSource{Commit: commit}
+		st.ResolvedSource = resolved
 		setSkillReady(st, v1alpha1.ConditionTrue, "Resolved", "")
 	})
 }
 
+// skillHasSource reports whether src names something the controller can pin.
+func skillHasSource(src *v1alpha1.SkillSource) bool {
+	if src == nil {
+		return false
+	}
+	return (src.Repository != nil && src.Repository.URL != "") || src.OCI != nil || src.Archive != nil
+}
+
+// resolveSource pins whichever channel src uses: a git ref to a commit, an
+// OCI reference to its manifest digest, or an archive to its (already
+// content-addressed) digest once the upload is confirmed to exist.
+func (c *SkillController) resolveSource(ctx context.Context, src *v1alpha1.SkillSource) (*v1alpha1.SkillResolvedSource, error) {
+	switch {
+	case src.OCI != nil:
+		resolveOCI := c.ResolveOCI
+		if resolveOCI == nil {
+			resolveOCI = defaultSkillResolveOCI
+		}
+		digest, err := resolveOCI(ctx, src.OCI.Reference)
+		if err != nil {
+			return nil, err
+		}
+		return &v1alpha1.SkillResolvedSource{Digest: digest}, nil
+	case src.Archive != nil:
+		if c.StatArchive == nil {
+			return nil, fmt.Errorf("%w: uploaded archives are not enabled", errSkillSourceUnsupported)
+		}
+		if err := c.StatArchive(ctx, src.Archive.Digest); err != nil {
+			if errors.Is(err, pkgdb.ErrNotFound) {
+				return nil, fmt.Errorf("%w: %s", errSkillArchiveNotFound, src.Archive.Digest)
+			}
+			return nil, err
+		}
+		return &v1alpha1.SkillResolvedSource{Digest: src.Archive.Digest}, nil
+	default:
+		commit, err := c.Resolve(ctx, src.Repository)
+		if err != nil {
+			return nil, err
+		}
+		return &v1alpha1.SkillResolvedSource{Commit: commit}, nil
+	}
+}
+
 // classifySkillResolveErr maps a resolve error to a status reason and whether it
 // is terminal (Forget) or retryable (rate-limited requeue).
 func classifySkillResolveErr(err error) (reason string, terminal bool) {
 	switch {
-	case errors.Is(err, gitutil.ErrUnsupportedHost):
+	case errors.Is(err, gitutil.ErrUnsupportedHost), errors.Is(err, errSkillSourceUnsupported):
 		return "SourceUnsupported", true
-	case errors.Is(err, gitutil.ErrRefNotFound):
+	case errors.Is(err, gitutil.ErrRefNotFound), errors.Is(err, errSkillOCINotFound):
 		return "RefNotFound", true
+	case errors.Is(err, errSkillArchiveNotFound):
+		return "ArchiveNotFound", true
+	case errors.Is(err, errSkillSourceInvalid):
+		return "SourceInvalid", true
 	default:
 		return "SourceUnresolvable", false
 	}
@@ -5,9 +5,18 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
 	"github.com/agentregistry-dev/agentregistry/internal/cli/common/gitutil"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
@@ -78,6 +87,10 @@ func TestClassifySkillResolveErr(t *testing.T) {
 	}{
 		{"unsupported host", fmt.Errorf("wrap: %w", gitutil.ErrUnsupportedHost), "SourceUnsupported", true},
 		{"ref not found", fmt.Errorf("wrap: %w", gitutil.ErrRefNotFound), "RefNotFound", true},
+		{"archives disabled", fmt.Errorf("wrap: %w", errSkillSourceUnsupported), "SourceUnsupported", true},
+		{"oci not found", fmt.Errorf("wrap: %w", errSkillOCINotFound), "RefNotFound", true},
+		{"archive not found", fmt.Errorf("wrap: %w", errSkillArchiveNotFound), "ArchiveNotFound", true},
+		{"invalid source", fmt.Errorf("wrap: %w", errSkillSourceInvalid), "SourceInvalid", true},
 		{"transient", errors.New("dial tcp: timeout"), "SourceUnresolvable", false},
 	}
 	for _, tt := range tests {
@@ -231,4 +244,94 @@ func TestSkillReconcile(t *testing.T) {
 			t.Errorf("missing-source must not pin a commit, got %+v", got.Status.ResolvedSource)
 		}
 	})
+
+	const digest = "sha256:0000000000000000000000000000000000000000000000000000000000000001"
+
+	t.Run("oci source pins the manifest digest", func(t *testing.T) {
+		store := newFakeSkillStore()
+		var gotRef string
+		c := &SkillController{Store: store, ResolveOCI: func(_ context.Context, ref string) (string, error) {
+			gotRef = ref
+			return digest, nil
+		}}
+		s := newSkill(7)
+		s.Spec.Source = &v1alpha1.SkillSource{OCI: &v1alpha1.SkillSourceOCI{Reference: "ghcr.io/o/skill@" + digest}}
+		outcome, _, err := c.reconcile(context.Background(), s)
+		if err != nil || outcome != "resolved" {
+			t.Fatalf("reconcile = (%q, %v), want (resolved, nil)", outcome, err)
+		}
+		if gotRef != "ghcr.io/o/skill@"+digest {
+			t.Errorf("ResolveOCI reference = %q", gotRef)
+		}
+		got := store.skill(t, ns, name, tag)
+		if got.Status.ResolvedSource == nil || got.Status.ResolvedSource.Digest != digest || got.Status.ResolvedSource.Commit != "" {
+			t.Errorf("resolvedSource = %+v", got.Status.ResolvedSource)
+		}
+	})
+
+	t.Run("archive source pins the uploaded digest", func(t *testing.T) {
+		store := newFakeSkillStore()
+		c := &SkillController{Store: store, StatArchive: func(context.Context, string) error { return nil }}
+		s := newSkill(8)
+		s.Spec.Source = &v1alpha1.SkillSource{Archive: &v1alpha1.SkillSourceArchive{Digest: digest}}
+		if outcome, _, err := c.reconcile(context.Background(), s); err != nil || outcome != "resolved" {
+			t.Fatalf("reconcile = (%q, %v), want (resolved, nil)", outcome, err)
+		}
+		got := store.skill(t, ns, name, tag)
+		if got.Status.ResolvedSource == nil || got.Status.ResolvedSource.Digest != digest {
+			t.Errorf("resolvedSource = %+v", got.Status.ResolvedSource)
+		}
+	})
+
+	t.Run("missing archive is terminal ArchiveNotFound", func(t *testing.T) {
+		store := newFakeSkillStore()
+		c := &SkillController{Store: store, StatArchive: func(context.Context, string) error { return pkgdb.ErrNotFound }}
+		s := newSkill(9)
+		s.Spec.Source = &v1alpha1.SkillSource{Archive: &v1alpha1.SkillSourceArchive{Digest: digest}}
+		outcome, reason, err := c.reconcile(context.Background(), s)
+		if err != nil {
+			t.Fatalf("terminal must Forget, got %v", err)
+		}
+		if outcome != "failed" || reason != "ArchiveNotFound" {
+			t.Fatalf("got (%q, %q), want (failed, ArchiveNotFound)", outcome, reason)
+		}
+		if got := store.skill(t, ns, name, tag); got.Status.ObservedGeneration != 9 {
+			t.Errorf("observedGeneration = %d, want 9", got.Status.ObservedGeneration)
+		}
+	})
+
+	t.Run("archive source without an archive store is SourceUnsupported", func(t *testing.T) {
+		store := newFakeSkillStore()
+		c := &SkillController{Store: store}
+		s := newSkill(10)
+		s.Spec.Source = &v1alpha1.SkillSource{Archive: &v1alpha1.SkillSourceArchive{Digest: digest}}
+		if _, reason, err := c.reconcile(context.Background(), s); err != nil || reason != "SourceUnsupported" {
+			t.Fatalf("got (%q, %v), want (SourceUnsupported, nil)", reason, err)
+		}
+	})
+}
+
+func TestDefaultSkillResolveOCIRequiresSkillManifest(t *testing.T) {
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer srv.Close()
+	img, err := random.Image(64, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tag, err := name.NewTag(strings.TrimPrefix(srv.URL, "http://") + "/acme/skill:v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(tag, img); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = defaultSkillResolveOCI(context.Background(), tag.Context().Digest(digest.String()).String())
+	if reason, terminal := classifySkillResolveErr(err); reason != "SourceInvalid" || !terminal {
+		t.Fatalf("resolve error = %v (reason %s), want terminal SourceInvalid", err, reason)
+	}
 }
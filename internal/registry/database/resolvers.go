@@ -1,9 +1,12 @@
 package database
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
@@ -16,20 +19,35 @@ import (
 //
 // Dangling references return v1alpha1.ErrDanglingRef so callers can
 // distinguish "row missing" from "database unavailable"; unknown
-// kinds return wrapped v1alpha1.ErrInvalidRef.
+// kinds return wrapped v1alpha1.ErrInvalidRef. References to a yanked
+// tag return wrapped v1alpha1.ErrYankedRef, and references to a
+// deprecated tag succeed with a warning recorded via
+// v1alpha1.AddWarning.
 func NewResolver(stores map[string]*v1alpha1store.Store) v1alpha1.ResolverFunc {
 	return func(ctx context.Context, ref v1alpha1.ResourceRef) error {
 		store, ok := stores[ref.Kind]
 		if !ok {
 			return fmt.Errorf("%w: unknown kind %q", v1alpha1.ErrInvalidRef, ref.Kind)
 		}
-		_, err := store.GetByRef(ctx, ref.Namespace, ref.Name, ref.Tag)
+		row, err := store.GetByRef(ctx, ref.Namespace, ref.Name, ref.Tag)
 		if err != nil {
 			if errors.Is(err, pkgdb.ErrNotFound) {
 				return v1alpha1.ErrDanglingRef
 			}
 			return err
 		}
+		lifecycle, err := v1alpha1.LifecycleFromStorage(row.Status)
+		if err != nil {
+			return fmt.Errorf("decode %s lifecycle: %w", ref.Kind, err)
+		}
+		if lifecycle == nil {
+			return nil
+		}
+		msg := lifecycle.Describe(fmt.Sprintf("%s %s/%s:%s", ref.Kind, ref.Namespace, ref.Name, row.Metadata.Tag))
+		if lifecycle.State == v1alpha1.LifecycleYanked {
+			return fmt.Errorf("%w: %s", v1alpha1.ErrYankedRef, msg)
+		}
+		v1alpha1.AddWarning(ctx, msg)
 		return nil
 	}
 }
@@ -82,3 +100,107 @@ func NewGetter(stores map[string]*v1alpha1store.Store) v1alpha1.GetterFunc {
 		return obj, nil
 	}
 }
+
+// NewReferrers returns a v1alpha1.ReferrersFunc that answers "what
+// references this object" against the supplied Stores map. Every kind
+// whose scheme type implements v1alpha1.RefResolver is scanned — Agent and
+// Deployment today, plus any extension kind that opts in — and each live
+// row's refs (via v1alpha1.ObjectRefs) are compared with the target.
+//
+// Refs to tagged kinds match on the concrete tag they resolve to now, so
+// a semver constraint or a blank tag counts against the tag it currently
+// selects; refs to mutable kinds match on namespace/name alone. The target
+// itself is not looked up: callers pass a concrete, existing tag. Results
+// are sorted by kind, namespace, name, tag, then path.
+//
+// The scan reads every live row of each referencing kind; there is no
+// reverse index. Unknown target kinds return wrapped
+// v1alpha1.ErrInvalidRef. Results are not filtered by authz: delete
+// protection must see every dependent. The HTTP handlers drop the ones a
+// caller may not read (see resource.Config.ReferrerAuthorizers).
+func NewReferrers(stores map[string]*v1alpha1store.Store) v1alpha1.ReferrersFunc {
+	return func(ctx context.Context, target v1alpha1.ResourceRef) ([]v1alpha1.Referrer, error) {
+		targetStore, ok := stores[target.Kind]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown kind %q", v1alpha1.ErrInvalidRef, target.Kind)
+		}
+		tagged := targetStore.Behavior() == v1alpha1store.TaggedArtifactStore
+		// Referrers frequently share a ref ("latest", "^1.0.0"); resolve
+		// each distinct tag expression once per call.
+		resolved := map[string]string{}
+		matches := func(ref v1alpha1.ResourceRef) (bool, error) {
+			if ref.Kind != target.Kind || ref.Namespace != target.Namespace || ref.Name != target.Name {
+				return false, nil
+			}
+			if !tagged {
+				return true, nil
+			}
+			tag, ok := resolved[ref.Tag]
+			if !ok {
+				row, err := targetStore.GetByRef(ctx, ref.Namespace, ref.Name, ref.Tag)
+				switch {
+				case errors.Is(err, pkgdb.ErrNotFound):
+				case err != nil:
+					return false, err
+				default:
+					tag = row.Metadata.Tag
+				}
+				resolved[ref.Tag] = tag
+			}
+			return tag == target.Tag, nil
+		}
+
+		var out []v1alpha1.Referrer
+		for kind, store := range stores {
+			_, newObj, ok := v1alpha1.Default.Lookup(kind)
+			if !ok {
+				continue
+			}
+			if _, ok := newObj().(v1alpha1.RefResolver); !ok {
+				continue
+			}
+			rows, err := store.FindReferrers(ctx, json.RawMessage(`{}`), v1alpha1store.FindReferrersOpts{})
+			if err != nil {
+				return nil, fmt.Errorf("scan %s referrers: %w", kind, err)
+			}
+			for _, row := range rows {
+				obj, ok := newObj().(v1alpha1.Object)
+				if !ok {
+					return nil, fmt.Errorf("scheme constructor for %q did not return v1alpha1.Object", kind)
+				}
+				obj.SetMetadata(row.Metadata)
+				if err := obj.UnmarshalSpec(row.Spec); err != nil {
+					return nil, fmt.Errorf("decode %s spec: %w", kind, err)
+				}
+				for _, fr := range v1alpha1.ObjectRefs(ctx, obj) {
+					ok, err := matches(fr.Ref)
+					if err != nil {
+						return nil, err
+					}
+					if !ok {
+						continue
+					}
+					out = append(out, v1alpha1.Referrer{
+						Object: v1alpha1.ResourceRef{
+							Kind:      kind,
+							Namespace: row.Metadata.Namespace,
+							Name:      row.Metadata.Name,
+							Tag:       row.Metadata.Tag,
+						},
+						Path: fr.Path,
+					})
+				}
+			}
+		}
+		slices.SortFunc(out, func(a, b v1alpha1.Referrer) int {
+			return cmp.Or(
+				cmp.Compare(a.Object.Kind, b.Object.Kind),
+				cmp.Compare(a.Object.Namespace, b.Object.Namespace),
+				cmp.Compare(a.Object.Name, b.Object.Name),
+				cmp.Compare(a.Object.Tag, b.Object.Tag),
+				cmp.Compare(a.Path, b.Path),
+			)
+		})
+		return out, nil
+	}
+}
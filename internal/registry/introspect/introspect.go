@@ -0,0 +1,387 @@
+// Package introspect connects to an MCP server the way a client would and
+// records what it exposes: the initialize handshake plus tools/list,
+// prompts/list, resources/list and resources/templates/list. Remote servers
+// are dialed at their declared URL; bundled servers are started in a
+// throwaway, resource-limited container that is removed afterwards.
+package introspect
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/runtimes/types"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/runtimes/utils"
+	"github.com/agentregistry-dev/agentregistry/internal/version"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+var (
+	// ErrUnsupported marks a server that can never be introspected as
+	// written (no package or remote, an unparseable URL, a required
+	// environment variable with no value) — TERMINAL.
+	ErrUnsupported = errors.New("mcp server cannot be introspected")
+	// ErrProtocol marks a server that completed the handshake but answered
+	// a list request with an error — TERMINAL; fix the server and publish a
+	// new tag.
+	ErrProtocol = errors.New("mcp server returned a protocol error")
+)
+
+// Options configures an Introspector. Zero values take the defaults noted
+// on each field.
+type Options struct {
+	// Docker is the docker CLI used to run bundled servers. Default "docker".
+	Docker string
+	// Network is the docker network the sandbox joins. Default "bridge";
+	// package runners (npx, uvx, go run) need egress to fetch the package.
+	Network string
+	// Memory, CPUs and PIDs bound the sandbox. Defaults "512m", "1", 256.
+	Memory string
+	CPUs   string
+	PIDs   int
+	// Timeout bounds one whole introspection, including image pull and
+	// server start. Default 2m.
+	Timeout time.Duration
+}
+
+// commandFactory matches exec.CommandContext so tests can substitute the
+// docker CLI.
+type commandFactory func(ctx context.Context, name string, args ...string) *exec.Cmd
+
+// Introspector runs MCP introspections. It is safe for concurrent use.
+type Introspector struct {
+	opts    Options
+	command commandFactory
+}
+
+// New returns an Introspector with opts' zero values defaulted.
+func New(opts Options) *Introspector {
+	if opts.Docker == "" {
+		opts.Docker = "docker"
+	}
+	if opts.Network == "" {
+		opts.Network = "bridge"
+	}
+	if opts.Memory == "" {
+		opts.Memory = "512m"
+	}
+	if opts.CPUs == "" {
+		opts.CPUs = "1"
+	}
+	if opts.PIDs == 0 {
+		opts.PIDs = 256
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = 2 * time.Minute
+	}
+	return &Introspector{opts: opts, command: exec.CommandContext}
+}
+
+// httpReadyInterval is how often an http-transport sandbox is re-dialed
+// while the server inside it boots.
+const httpReadyInterval = 500 * time.Millisecond
+
+// Introspect connects to the server spec describes and returns what it
+// exposes. Errors wrapping ErrUnsupported or ErrProtocol are terminal; any
+// other error (docker unavailable, network, timeout) is worth retrying.
+func (i *Introspector) Introspect(ctx context.Context, name string, spec v1alpha1.MCPServerSpec) (*v1alpha1.MCPServerCapabilities, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.opts.Timeout)
+	defer cancel()
+
+	rt, err := utils.TranslateMCPServer(ctx, &utils.MCPServerRunRequest{Name: name, Spec: spec, DeploymentID: "introspect"})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+	switch {
+	case rt.Remote != nil:
+		return i.introspectRemote(ctx, spec.Remote.Type, rt.Remote)
+	case rt.Local != nil && rt.Local.TransportType == types.TransportTypeStdio:
+		return i.introspectStdio(ctx, rt.Local)
+	case rt.Local != nil:
+		return i.introspectHTTP(ctx, rt.Local)
+	default:
+		return nil, fmt.Errorf("%w: no package or remote", ErrUnsupported)
+	}
+}
+
+func (i *Introspector) introspectRemote(ctx context.Context, remoteType string, remote *types.RemoteMCPTarget) (*v1alpha1.MCPServerCapabilities, error) {
+	headers := make(http.Header, len(remote.Headers))
+	for _, h := range remote.Headers {
+		headers.Set(h.Name, h.Value)
+	}
+	client := &http.Client{Transport: headerTransport{base: http.DefaultTransport, headers: headers}}
+	endpoint := utils.BuildRemoteMCPURL(remote)
+
+	var transport mcp.Transport = &mcp.StreamableClientTransport{Endpoint: endpoint, HTTPClient: client, MaxRetries: -1, DisableStandaloneSSE: true}
+	if remoteType == "sse" {
+		transport = &mcp.SSEClientTransport{Endpoint: endpoint, HTTPClient: client}
+	}
+	return list(ctx, transport)
+}
+
+// introspectStdio runs the server with `docker run -i` and speaks MCP over
+// the container's stdin/stdout.
+func (i *Introspector) introspectStdio(ctx context.Context, local *types.LocalMCPServer) (*v1alpha1.MCPServerCapabilities, error) {
+	container := containerName()
+	defer i.remove(container)
+
+	args := append([]string{"run", "--rm", "-i"}, i.sandboxArgs(container, local)...)
+	args = append(args, imageAndCommand(local)...)
+	cmd := i.command(ctx, i.opts.Docker, args...)
+	cmd.Env = sandboxEnv(local)
+	stderr := &tailBuffer{}
+	cmd.Stderr = stderr
+
+	caps, err := list(ctx, &mcp.CommandTransport{Command: cmd, TerminateDuration: time.Second})
+	if err != nil && !errors.Is(err, ErrProtocol) && stderr.Len() > 0 {
+		return nil, fmt.Errorf("%w (server stderr: %s)", err, strings.TrimSpace(stderr.String()))
+	}
+	return caps, err
+}
+
+// introspectHTTP starts the server detached with its port published on
+// loopback, then dials it until it answers or the timeout expires.
+func (i *Introspector) introspectHTTP(ctx context.Context, local *types.LocalMCPServer) (*v1alpha1.MCPServerCapabilities, error) {
+	if local.HTTP == nil || local.HTTP.Port == 0 {
+		return nil, fmt.Errorf("%w: http transport has no port", ErrUnsupported)
+	}
+	port := strconv.FormatUint(uint64(local.HTTP.Port), 10)
+	container := containerName()
+	defer i.remove(container)
+
+	args := append([]string{"run", "-d", "--rm", "-p", "127.0.0.1::" + port}, i.sandboxArgs(container, local)...)
+	args = append(args, imageAndCommand(local)...)
+	run := i.command(ctx, i.opts.Docker, args...)
+	run.Env = sandboxEnv(local)
+	if out, err := run.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("start sandbox: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	out, err := i.command(ctx, i.opts.Docker, "port", container, port+"/tcp").Output()
+	if err != nil {
+		return nil, fmt.Errorf("inspect sandbox port: %w", err)
+	}
+	hostPort, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	path := local.HTTP.Path
+	if path == "" {
+		path = "/mcp"
+	}
+	endpoint := "http://" + hostPort + path
+
+	for {
+		caps, err := list(ctx, &mcp.StreamableClientTransport{Endpoint: endpoint, MaxRetries: -1, DisableStandaloneSSE: true})
+		if err == nil || errors.Is(err, ErrProtocol) {
+			return caps, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("sandboxed server never answered on %s: %w", path, err)
+		case <-time.After(httpReadyInterval):
+		}
+	}
+}
+
+// sandboxArgs are the `docker run` flags every sandbox gets: a unique name
+// for cleanup, the configured network and resource limits, no capabilities,
+// and no privilege escalation. Environment values are passed by name and
+// read from the docker CLI's environment so they never appear in argv.
+func (i *Introspector) sandboxArgs(container string, local *types.LocalMCPServer) []string {
+	args := []string{
+		"--name", container,
+		"--network", i.opts.Network,
+		"--memory", i.opts.Memory,
+		"--cpus", i.opts.CPUs,
+		"--pids-limit", strconv.Itoa(i.opts.PIDs),
+		"--cap-drop", "ALL",
+		"--security-opt", "no-new-privileges",
+		"--label", "agentregistry.dev/introspect=true",
+	}
+	keys := make([]string, 0, len(local.Deployment.Env))
+	for k := range local.Deployment.Env {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		args = append(args, "-e", k)
+	}
+	return args
+}
+
+func imageAndCommand(local *types.LocalMCPServer) []string {
+	out := []string{local.Deployment.Image}
+	if local.Deployment.Cmd != "" {
+		out = append(out, local.Deployment.Cmd)
+		out = append(out, local.Deployment.Args...)
+	}
+	return out
+}
+
+func sandboxEnv(local *types.LocalMCPServer) []string {
+	env := os.Environ()
+	for k, v := range local.Deployment.Env {
+		env = append(env, k+"="+v)
+	}
+	return env
+}
+
+// remove force-removes the sandbox. `--rm` normally does this, but a killed
+// `docker run -i` client leaves its container running.
+func (i *Introspector) remove(container string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	_ = i.command(ctx, i.opts.Docker, "rm", "-f", container).Run()
+}
+
+func containerName() string {
+	b := make([]byte, 6)
+	_, _ = rand.Read(b)
+	return "arctl-introspect-" + hex.EncodeToString(b)
+}
+
+// list runs the handshake over transport and collects every list the server
+// advertises a capability for; servers answer unadvertised methods with
+// "method not found", so those are not asked.
+func list(ctx context.Context, transport mcp.Transport) (*v1alpha1.MCPServerCapabilities, error) {
+	client := mcp.NewClient(&mcp.Implementation{Name: "agentregistry-introspect", Version: version.Version}, nil)
+	session, err := client.Connect(ctx, transport, nil)
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	init := session.InitializeResult()
+	out := &v1alpha1.MCPServerCapabilities{ProtocolVersion: init.ProtocolVersion, Instructions: init.Instructions}
+	if si := init.ServerInfo; si != nil {
+		out.ServerInfo = &v1alpha1.MCPImplementation{Name: si.Name, Title: si.Title, Version: si.Version}
+	}
+	caps := init.Capabilities
+	if caps == nil {
+		return out, nil
+	}
+
+	if caps.Tools != nil {
+		for t, err := range session.Tools(ctx, nil) {
+			if err != nil {
+				return nil, listErr(ctx, "tools/list", err)
+			}
+			out.Tools = append(out.Tools, toolInfo(t))
+		}
+	}
+	if caps.Prompts != nil {
+		for p, err := range session.Prompts(ctx, nil) {
+			if err != nil {
+				return nil, listErr(ctx, "prompts/list", err)
+			}
+			info := v1alpha1.MCPPromptInfo{Name: p.Name, Title: p.Title, Description: p.Description}
+			for _, a := range p.Arguments {
+				info.Arguments = append(info.Arguments, v1alpha1.MCPPromptArgumentInfo{Name: a.Name, Description: a.Description, Required: a.Required})
+			}
+			out.Prompts = append(out.Prompts, info)
+		}
+	}
+	if caps.Resources != nil {
+		for r, err := range session.Resources(ctx, nil) {
+			if err != nil {
+				return nil, listErr(ctx, "resources/list", err)
+			}
+			out.Resources = append(out.Resources, v1alpha1.MCPResourceInfo{URI: r.URI, Name: r.Name, Title: r.Title, Description: r.Description, MIMEType: r.MIMEType})
+		}
+		for r, err := range session.ResourceTemplates(ctx, nil) {
+			if err != nil {
+				return nil, listErr(ctx, "resources/templates/list", err)
+			}
+			out.ResourceTemplates = append(out.ResourceTemplates, v1alpha1.MCPResourceTemplateInfo{URITemplate: r.URITemplate, Name: r.Name, Title: r.Title, Description: r.Description, MIMEType: r.MIMEType})
+		}
+	}
+	return out, nil
+}
+
+// listErr keeps a cancelled or timed-out list retryable; anything else the
+// server answered is a protocol error.
+func listErr(ctx context.Context, method string, err error) error {
+	if ctx.Err() != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	return fmt.Errorf("%w: %s: %v", ErrProtocol, method, err)
+}
+
+func toolInfo(t *mcp.Tool) v1alpha1.MCPToolInfo {
+	info := v1alpha1.MCPToolInfo{Name: t.Name, Title: t.Title, Description: t.Description}
+	if t.InputSchema != nil {
+		info.InputSchema, _ = json.Marshal(t.InputSchema)
+	}
+	if t.OutputSchema != nil {
+		info.OutputSchema, _ = json.Marshal(t.OutputSchema)
+	}
+	if a := t.Annotations; a != nil {
+		info.Annotations = &v1alpha1.MCPToolAnnotations{
+			ReadOnlyHint:    a.ReadOnlyHint,
+			DestructiveHint: a.DestructiveHint,
+			IdempotentHint:  a.IdempotentHint,
+			OpenWorldHint:   a.OpenWorldHint,
+		}
+		if info.Title == "" {
+			info.Title = a.Title
+		}
+	}
+	return info
+}
+
+// headerTransport adds a remote's declared headers to every request.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers http.Header
+}
+
+func (t headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for k, v := range t.headers {
+		req.Header[k] = v
+	}
+	return t.base.RoundTrip(req)
+}
+
+// maxStderrTail bounds how much of a failed server's stderr is kept for the
+// status message.
+const maxStderrTail = 2 << 10
+
+// tailBuffer keeps the last maxStderrTail bytes written to it.
+type tailBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf.Write(p)
+	if over := b.buf.Len() - maxStderrTail; over > 0 {
+		b.buf.Next(over)
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Len()
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
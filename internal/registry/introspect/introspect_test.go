@@ -0,0 +1,197 @@
+package introspect
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// fakeDockerEnv switches the test binary into a fake docker CLI: `run`
+// serves testServer over stdio and records its argv; anything else exits 0.
+const (
+	fakeDockerEnv  = "INTROSPECT_FAKE_DOCKER"
+	fakeDockerArgv = "INTROSPECT_FAKE_DOCKER_ARGV"
+)
+
+func TestMain(m *testing.M) {
+	if os.Getenv(fakeDockerEnv) == "1" {
+		os.Exit(runFakeDocker(os.Args[1:]))
+	}
+	os.Exit(m.Run())
+}
+
+func runFakeDocker(args []string) int {
+	if len(args) == 0 || args[0] != "run" {
+		return 0
+	}
+	if err := os.WriteFile(os.Getenv(fakeDockerArgv), []byte(strings.Join(args, "\n")+"\nAPI_KEY="+os.Getenv("API_KEY")), 0o600); err != nil {
+		return 1
+	}
+	if err := testServer().Run(context.Background(), &mcp.StdioTransport{}); err != nil {
+		return 1
+	}
+	return 0
+}
+
+type echoArgs struct {
+	Text string `json:"text" jsonschema:"text to echo"`
+}
+
+func testServer() *mcp.Server {
+	server := mcp.NewServer(&mcp.Implementation{Name: "echo", Version: "1.2.3"}, &mcp.ServerOptions{Instructions: "say things"})
+	readOnly := false
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "echo",
+		Description: "Echo text back",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true, DestructiveHint: &readOnly},
+	}, func(context.Context, *mcp.CallToolRequest, echoArgs) (*mcp.CallToolResult, any, error) {
+		return nil, nil, nil
+	})
+	server.AddPrompt(&mcp.Prompt{
+		Name:      "greet",
+		Arguments: []*mcp.PromptArgument{{Name: "who", Required: true}},
+	}, func(context.Context, *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return &mcp.GetPromptResult{}, nil
+	})
+	server.AddResource(&mcp.Resource{URI: "file:///readme", Name: "readme", MIMEType: "text/markdown"},
+		func(context.Context, *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+			return &mcp.ReadResourceResult{}, nil
+		})
+	server.AddResourceTemplate(&mcp.ResourceTemplate{URITemplate: "file:///docs/{name}", Name: "docs"},
+		func(context.Context, *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+			return &mcp.ReadResourceResult{}, nil
+		})
+	return server
+}
+
+func assertEchoCapabilities(t *testing.T, caps *v1alpha1.MCPServerCapabilities) {
+	t.Helper()
+	if caps.ServerInfo == nil || caps.ServerInfo.Name != "echo" || caps.ServerInfo.Version != "1.2.3" {
+		t.Fatalf("serverInfo = %+v", caps.ServerInfo)
+	}
+	if caps.ProtocolVersion == "" || caps.Instructions != "say things" {
+		t.Fatalf("protocolVersion=%q instructions=%q", caps.ProtocolVersion, caps.Instructions)
+	}
+	if len(caps.Tools) != 1 || caps.Tools[0].Name != "echo" || !strings.Contains(string(caps.Tools[0].InputSchema), `"text"`) {
+		t.Fatalf("tools = %+v", caps.Tools)
+	}
+	if a := caps.Tools[0].Annotations; a == nil || !a.ReadOnlyHint || a.DestructiveHint == nil || *a.DestructiveHint {
+		t.Fatalf("tool annotations = %+v", a)
+	}
+	if len(caps.Prompts) != 1 || caps.Prompts[0].Name != "greet" || len(caps.Prompts[0].Arguments) != 1 || !caps.Prompts[0].Arguments[0].Required {
+		t.Fatalf("prompts = %+v", caps.Prompts)
+	}
+	if len(caps.Resources) != 1 || caps.Resources[0].URI != "file:///readme" || caps.Resources[0].MIMEType != "text/markdown" {
+		t.Fatalf("resources = %+v", caps.Resources)
+	}
+	if len(caps.ResourceTemplates) != 1 || caps.ResourceTemplates[0].URITemplate != "file:///docs/{name}" {
+		t.Fatalf("resource templates = %+v", caps.ResourceTemplates)
+	}
+}
+
+func TestIntrospectRemote(t *testing.T) {
+	handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return testServer() }, nil)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "k" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	spec := v1alpha1.MCPServerSpec{Remote: &v1alpha1.MCPRemote{
+		Type:    "streamable-http",
+		URL:     srv.URL + "/mcp",
+		Headers: []v1alpha1.HTTPHeader{{Name: "X-Api-Key", Value: "k"}},
+	}}
+	caps, err := New(Options{}).Introspect(context.Background(), "acme/echo", spec)
+	if err != nil {
+		t.Fatalf("Introspect: %v", err)
+	}
+	assertEchoCapabilities(t, caps)
+
+	spec.Remote.Headers = nil
+	if _, err := New(Options{}).Introspect(context.Background(), "acme/echo", spec); err == nil || errors.Is(err, ErrUnsupported) || errors.Is(err, ErrProtocol) {
+		t.Fatalf("unauthorized remote: expected a retryable error, got %v", err)
+	}
+}
+
+func TestIntrospectStdioSandbox(t *testing.T) {
+	argvFile := t.TempDir() + "/argv"
+	t.Setenv(fakeDockerEnv, "1")
+	t.Setenv(fakeDockerArgv, argvFile)
+
+	var calls [][]string
+	i := New(Options{Network: "introspect-net"})
+	i.command = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		calls = append(calls, append([]string{name}, args...))
+		return exec.CommandContext(ctx, os.Args[0], args...)
+	}
+	spec := v1alpha1.MCPServerSpec{Source: &v1alpha1.MCPServerSource{Package: &v1alpha1.MCPPackage{
+		Origin: v1alpha1.MCPPackageOrigin{Type: v1alpha1.MCPPackageOriginTypeNPM, Identifier: "@acme/echo", NPM: &v1alpha1.MCPPackageOriginNPM{Version: "1.0.0"}},
+		Launch: &v1alpha1.MCPPackageLaunch{
+			Command: "npx",
+			Args:    []v1alpha1.MCPArgument{{Type: v1alpha1.MCPArgumentTypePositional, Value: "@acme/echo@1.0.0"}},
+			Env:     []v1alpha1.MCPKeyValueInput{{Name: "API_KEY", Value: "s3cret"}},
+		},
+		Transport: v1alpha1.MCPTransport{Type: "stdio"},
+	}}}
+
+	caps, err := i.Introspect(context.Background(), "acme/echo", spec)
+	if err != nil {
+		t.Fatalf("Introspect: %v", err)
+	}
+	assertEchoCapabilities(t, caps)
+
+	raw, err := os.ReadFile(argvFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	argv := strings.Split(string(raw), "\n")
+	for _, want := range [][]string{
+		{"--network", "introspect-net"},
+		{"--cap-drop", "ALL"},
+		{"--security-opt", "no-new-privileges"},
+		{"-e", "API_KEY"},
+		{"npx", "@acme/echo@1.0.0"},
+	} {
+		idx := slices.Index(argv, want[0])
+		if idx < 0 || idx+1 >= len(argv) || argv[idx+1] != want[1] {
+			t.Errorf("sandbox argv missing %v: %q", want, argv)
+		}
+	}
+	if slices.Contains(argv[:len(argv)-1], "s3cret") || argv[len(argv)-1] != "API_KEY=s3cret" {
+		t.Errorf("env value must reach the CLI environment, not argv: %q", argv)
+	}
+	if last := calls[len(calls)-1]; len(last) < 3 || last[1] != "rm" || last[2] != "-f" {
+		t.Errorf("expected the sandbox to be force-removed, calls = %q", calls)
+	}
+}
+
+func TestIntrospectUnsupported(t *testing.T) {
+	spec := v1alpha1.MCPServerSpec{Source: &v1alpha1.MCPServerSource{Package: &v1alpha1.MCPPackage{
+		Origin: v1alpha1.MCPPackageOrigin{Type: v1alpha1.MCPPackageOriginTypeNPM, Identifier: "@acme/echo", NPM: &v1alpha1.MCPPackageOriginNPM{Version: "1.0.0"}},
+		Launch: &v1alpha1.MCPPackageLaunch{
+			Command: "npx",
+			Env:     []v1alpha1.MCPKeyValueInput{{Name: "API_KEY", IsRequired: true}},
+		},
+		Transport: v1alpha1.MCPTransport{Type: "stdio"},
+	}}}
+	if _, err := New(Options{}).Introspect(context.Background(), "acme/echo", spec); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("missing required env: expected ErrUnsupported, got %v", err)
+	}
+	if _, err := New(Options{}).Introspect(context.Background(), "acme/none", v1alpha1.MCPServerSpec{}); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("empty spec: expected ErrUnsupported, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package bundle
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Builder accumulates files into a CanonicalBundle from a source that is not
+// a directory tree (e.g. OCI layers), enforcing the same path rules and
+// MaxBundleFiles/MaxBundleBytes ceilings as FromDir. Later writes to a path
+// replace earlier ones, so layered sources can be applied in order.
+type Builder struct {
+	files      map[string][]byte
+	totalBytes int64
+	maxFiles   int
+	maxBytes   int64
+}
+
+// NewBuilder returns an empty Builder with the default ceilings.
+func NewBuilder() *Builder {
+	return newBuilder(MaxBundleFiles, MaxBundleBytes)
+}
+
+// newBuilder is NewBuilder with explicit limits, so tests can exercise the
+// ceilings without materializing huge inputs.
+func newBuilder(maxFiles int, maxBytes int64) *Builder {
+	return &Builder{files: map[string][]byte{}, maxFiles: maxFiles, maxBytes: maxBytes}
+}
+
+// Add reads r into the bundle at path p. A leading "./" is tolerated; any
+// other non-canonical path is rejected. Reading stops as soon as the bundle
+// would exceed its byte ceiling, so an oversized entry is never buffered whole.
+func (b *Builder) Add(p string, r io.Reader) error {
+	p = strings.TrimPrefix(p, "./")
+	if err := validateBundlePath(p); err != nil {
+		return err
+	}
+	prev, replacing := b.files[p]
+	if !replacing && len(b.files) >= b.maxFiles {
+		return fmt.Errorf("%w: too many files (limit %d)", ErrInvalidBundle, b.maxFiles)
+	}
+	budget := b.maxBytes - b.totalBytes + int64(len(prev))
+	data, err := io.ReadAll(io.LimitReader(r, budget+1))
+	if err != nil {
+		return fmt.Errorf("read %s: %w", p, err)
+	}
+	if int64(len(data)) > budget {
+		return fmt.Errorf("%w: bundle exceeds %d bytes", ErrInvalidBundle, b.maxBytes)
+	}
+	b.totalBytes += int64(len(data)) - int64(len(prev))
+	b.files[p] = data
+	return nil
+}
+
+// Remove deletes p and, when p is a directory, everything beneath it. An
+// empty p clears the bundle.
+func (b *Builder) Remove(p string) {
+	p = strings.Trim(strings.TrimPrefix(p, "./"), "/")
+	for k, data := range b.files {
+		if p == "" || k == p || strings.HasPrefix(k, p+"/") {
+			b.totalBytes -= int64(len(data))
+			delete(b.files, k)
+		}
+	}
+}
+
+// Bundle returns the accumulated bundle. The Builder must not be used
+// afterwards.
+func (b *Builder) Bundle() *CanonicalBundle {
+	return &CanonicalBundle{Files: b.files}
+}
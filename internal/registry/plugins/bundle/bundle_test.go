@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -82,6 +83,37 @@ func TestFromDirRejectsTooManyBytes(t *testing.T) {
 	}
 }
 
+func TestBuilderLimits(t *testing.T) {
+	b := newBuilder(2, 5)
+	if err := b.Add("./a.txt", strings.NewReader("abc")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	// Replacing a path reuses its slot and its bytes.
+	if err := b.Add("a.txt", strings.NewReader("abcde")); err != nil {
+		t.Fatalf("replace: %v", err)
+	}
+	if err := b.Add("b.txt", strings.NewReader("x")); !errors.Is(err, ErrInvalidBundle) {
+		t.Fatalf("expected ErrInvalidBundle for byte overflow, got %v", err)
+	}
+	b.Remove("a.txt")
+	if err := b.Add("dir/b.txt", strings.NewReader("x")); err != nil {
+		t.Fatalf("Add after Remove: %v", err)
+	}
+	if err := b.Add("dir/c.txt", strings.NewReader("x")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := b.Add("d.txt", strings.NewReader("x")); !errors.Is(err, ErrInvalidBundle) {
+		t.Fatalf("expected ErrInvalidBundle for file-count overflow, got %v", err)
+	}
+	if err := b.Add("../evil", strings.NewReader("x")); !errors.Is(err, ErrInvalidBundle) {
+		t.Fatalf("expected ErrInvalidBundle for traversal, got %v", err)
+	}
+	b.Remove("dir")
+	if got := b.Bundle().Files; len(got) != 0 {
+		t.Fatalf("Remove(dir) left %v", got)
+	}
+}
+
 func writeFile(t *testing.T, root, rel, content string) {
 	t.Helper()
 	full := filepath.Join(root, filepath.FromSlash(rel))
@@ -0,0 +1,194 @@
+package source
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/plugins/bundle"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+const (
+	// titleAnnotation names the file a non-tar artifact layer holds (the
+	// convention `oras push` follows for each pushed file).
+	titleAnnotation = "org.opencontainers.image.title"
+	// unpackAnnotation marks a titled layer as a gzip-compressed tar of a
+	// directory rather than a single file (`oras push dir/`).
+	unpackAnnotation = "io.deis.oras.content.unpack"
+
+	whiteoutPrefix = ".wh."
+	whiteoutOpaque = ".wh..wh..opq"
+)
+
+// OCIResolver resolves OCI sources: it pulls the digest-pinned artifact and
+// unpacks its layers, in manifest order, into a bundle. Two layouts are
+// understood:
+//
+//   - image layers: each layer is a tar applied over the previous ones, with
+//     whiteout entries removing earlier files (a plugin built FROM scratch).
+//   - artifact layers: each layer carries an org.opencontainers.image.title
+//     annotation naming the single file it holds (`oras push`), or a
+//     directory tarball when io.deis.oras.content.unpack is "true".
+//
+// Links and other non-regular tar entries are skipped, as FromDir skips
+// symlinks.
+type OCIResolver struct {
+	options []remote.Option
+}
+
+// NewOCIResolver returns an OCI-backed Resolver. Registry requests
+// authenticate like `docker pull` unless opts override it.
+func NewOCIResolver(opts ...remote.Option) *OCIResolver {
+	return &OCIResolver{options: append([]remote.Option{remote.WithAuthFromKeychain(authn.DefaultKeychain)}, opts...)}
+}
+
+func (r *OCIResolver) Resolve(ctx context.Context, p *v1alpha1.Plugin) (*v1alpha1.PluginResolvedSource, *bundle.CanonicalBundle, error) {
+	if p == nil || p.Spec.Source == nil {
+		return nil, nil, fmt.Errorf("%w: plugin has no source", ErrUnsupportedSource)
+	}
+	if p.Spec.Source.Type != v1alpha1.PluginSourceTypeOCI {
+		return nil, nil, fmt.Errorf("%w: %q plugin source is not an oci source", ErrUnsupportedSource, p.Spec.Source.Type)
+	}
+	o := p.Spec.Source.OCI
+	if o == nil || o.Reference == "" {
+		return nil, nil, fmt.Errorf("%w: oci source missing reference", ErrUnsupportedSource)
+	}
+	// Admission already requires a digest; re-check so a stored row from
+	// before that rule can never resolve to a moving tag.
+	ref, err := name.NewDigest(o.Reference)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: oci reference must be digest-pinned: %v", ErrUnsupportedSource, err)
+	}
+
+	b, err := PullOCI(ctx, ref, r.options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &v1alpha1.PluginResolvedSource{Type: v1alpha1.PluginSourceTypeOCI, Digest: ref.DigestStr()}, b, nil
+}
+
+// PullOCI pulls the artifact at ref and unpacks its layers into a bundle,
+// understanding the same layouts as OCIResolver. opts are used as given, so
+// callers pass their own keychain. Skills share it to materialize OCI
+// sources. An unknown manifest or blob wraps ErrSourceNotFound.
+func PullOCI(ctx context.Context, ref name.Digest, opts ...remote.Option) (*bundle.CanonicalBundle, error) {
+	ctx, cancel := context.WithTimeout(ctx, cloneTimeout)
+	defer cancel()
+
+	img, err := remote.Image(ref, append([]remote.Option{remote.WithContext(ctx)}, opts...)...)
+	if err != nil {
+		return nil, classifyOCIErr(err, "pull "+ref.String())
+	}
+	return unpackImage(img)
+}
+
+// unpackImage applies img's layers in manifest order. Layer blobs are fetched
+// lazily, so a missing blob surfaces here as a retryable error.
+func unpackImage(img v1.Image) (*bundle.CanonicalBundle, error) {
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, classifyOCIErr(err, "read oci manifest")
+	}
+	b := bundle.NewBuilder()
+	for _, desc := range manifest.Layers {
+		layer, err := img.LayerByDigest(desc.Digest)
+		if err != nil {
+			return nil, classifyOCIErr(err, "read oci layer "+desc.Digest.String())
+		}
+		if err := unpackLayer(b, desc, layer); err != nil {
+			return nil, err
+		}
+	}
+	return b.Bundle(), nil
+}
+
+func unpackLayer(b *bundle.Builder, desc v1.Descriptor, layer v1.Layer) error {
+	title := desc.Annotations[titleAnnotation]
+	if title != "" && desc.Annotations[unpackAnnotation] != "true" {
+		// A single file: the blob is the file content, whatever its media type.
+		rc, err := layer.Compressed()
+		if err != nil {
+			return classifyOCIErr(err, "read oci layer "+desc.Digest.String())
+		}
+		defer func() { _ = rc.Close() }()
+		return wrapLayerErr(b.Add(title, rc), desc)
+	}
+	rc, err := layer.Uncompressed()
+	if err != nil {
+		return classifyOCIErr(err, "read oci layer "+desc.Digest.String())
+	}
+	defer func() { _ = rc.Close() }()
+	return wrapLayerErr(applyTar(b, rc), desc)
+}
+
+// applyTar adds tr's regular files to b, honoring OCI whiteouts.
+func applyTar(b *bundle.Builder, r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: read layer tar: %v", bundle.ErrInvalidBundle, err)
+		}
+		entry := strings.TrimPrefix(hdr.Name, "./")
+		dir, base := path.Split(entry)
+		switch {
+		case base == whiteoutOpaque:
+			b.Remove(dir)
+			continue
+		case strings.HasPrefix(base, whiteoutPrefix):
+			b.Remove(dir + strings.TrimPrefix(base, whiteoutPrefix))
+			continue
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := b.Add(entry, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// wrapLayerErr keeps bundle errors terminal while naming the layer; transient
+// read errors (a dropped blob stream) stay retryable.
+func wrapLayerErr(err error, desc v1.Descriptor) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, bundle.ErrInvalidBundle) {
+		return fmt.Errorf("layer %s: %w", desc.Digest, err)
+	}
+	return fmt.Errorf("read oci layer %s: %w", desc.Digest, err)
+}
+
+// classifyOCIErr maps a registry error to the resolver's terminal/retryable
+// contract: an unknown manifest or blob is terminal; anything else (network,
+// auth, 5xx) is retryable.
+func classifyOCIErr(err error, context string) error {
+	var terr *transport.Error
+	if errors.As(err, &terr) {
+		if terr.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("%w: %v", ErrSourceNotFound, err)
+		}
+		for _, d := range terr.Errors {
+			if d.Code == transport.ManifestUnknownErrorCode || d.Code == transport.BlobUnknownErrorCode || d.Code == transport.NameUnknownErrorCode {
+				return fmt.Errorf("%w: %v", ErrSourceNotFound, err)
+			}
+		}
+	}
+	return fmt.Errorf("%s: %w", context, err) // retryable
+}
@@ -0,0 +1,217 @@
+package source
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/plugins/bundle"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// tarLayer builds a gzip-compressed tar layer from path->content entries, in
+// order. An empty content for a path ending in "/" writes a directory entry.
+func tarLayer(t *testing.T, entries ...[2]string) v1.Layer {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e[0], Mode: 0o644, Size: int64(len(e[1])), Typeflag: tar.TypeReg}
+		if strings.HasSuffix(e[0], "/") {
+			hdr = &tar.Header{Name: e[0], Mode: 0o755, Typeflag: tar.TypeDir}
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e[1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return layer
+}
+
+// pushImage writes img to an in-process registry and returns its
+// digest-pinned reference.
+func pushImage(t *testing.T, img v1.Image) string {
+	t.Helper()
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(srv.Close)
+	tag, err := name.NewTag(strings.TrimPrefix(srv.URL, "http://") + "/acme/plugin:v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(tag, img); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tag.Context().Digest(digest.String()).String()
+}
+
+func ociPlugin(reference string) *v1alpha1.Plugin {
+	return &v1alpha1.Plugin{Spec: v1alpha1.PluginSpec{Source: &v1alpha1.PluginSource{
+		Type: v1alpha1.PluginSourceTypeOCI,
+		OCI:  &v1alpha1.PluginSourceOCI{Reference: reference},
+	}}}
+}
+
+func TestOCIResolverImageLayers(t *testing.T) {
+	img, err := mutate.AppendLayers(empty.Image,
+		tarLayer(t,
+			[2]string{"./.claude-plugin/", ""},
+			[2]string{"./.claude-plugin/plugin.json", `{"name":"deploy"}`},
+			[2]string{"./skills/deploy/SKILL.md", "---\nname: deploy\ndescription: Ship it\n---\n"},
+			[2]string{"./skills/old/SKILL.md", "---\nname: old\n---\n"},
+			[2]string{"./bin/tool", "v1"},
+		),
+		// The second layer overwrites bin/tool and whiteouts skills/old.
+		tarLayer(t,
+			[2]string{"bin/tool", "v2"},
+			[2]string{"skills/.wh.old", ""},
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref := pushImage(t, img)
+
+	resolved, b, err := NewOCIResolver().Resolve(context.Background(), ociPlugin(ref))
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	digest, _ := img.Digest()
+	if resolved.Type != v1alpha1.PluginSourceTypeOCI || resolved.Digest != digest.String() || resolved.Commit != "" {
+		t.Fatalf("resolved = %+v, want oci digest %s", resolved, digest)
+	}
+	want := map[string][]byte{
+		".claude-plugin/plugin.json": []byte(`{"name":"deploy"}`),
+		"skills/deploy/SKILL.md":     []byte("---\nname: deploy\ndescription: Ship it\n---\n"),
+		"bin/tool":                   []byte("v2"),
+	}
+	if !reflect.DeepEqual(b.Files, want) {
+		t.Fatalf("bundle files:\n got  %q\n want %q", b.Files, want)
+	}
+
+	manifest, err := bundle.ParseManifest(b)
+	if err != nil || manifest == nil || manifest.Name != "deploy" {
+		t.Fatalf("ParseManifest = (%+v, %v)", manifest, err)
+	}
+	inv := bundle.BuildInventory(b)
+	if len(inv.Skills) != 1 || inv.Skills[0].Name != "deploy" || !reflect.DeepEqual(inv.Executables, []string{"tool"}) {
+		t.Fatalf("inventory = %+v", inv)
+	}
+}
+
+func TestOCIResolverArtifactLayers(t *testing.T) {
+	file := func(title, content string) mutate.Addendum {
+		return mutate.Addendum{
+			Layer:       static.NewLayer([]byte(content), types.OCIUncompressedLayer),
+			Annotations: map[string]string{titleAnnotation: title},
+		}
+	}
+	dir := mutate.Addendum{
+		Layer:       tarLayer(t, [2]string{"skills/", ""}, [2]string{"skills/review/SKILL.md", "review"}),
+		Annotations: map[string]string{titleAnnotation: "skills", unpackAnnotation: "true"},
+	}
+	img, err := mutate.Append(mutate.MediaType(empty.Image, types.OCIManifestSchema1),
+		file(".claude-plugin/plugin.json", `{"name":"review"}`),
+		file("AGENTS.md", "# agents"),
+		dir,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref := pushImage(t, img)
+
+	_, b, err := NewOCIResolver().Resolve(context.Background(), ociPlugin(ref))
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	want := map[string][]byte{
+		".claude-plugin/plugin.json": []byte(`{"name":"review"}`),
+		"AGENTS.md":                  []byte("# agents"),
+		"skills/review/SKILL.md":     []byte("review"),
+	}
+	if !reflect.DeepEqual(b.Files, want) {
+		t.Fatalf("bundle files:\n got  %q\n want %q", b.Files, want)
+	}
+}
+
+func TestOCIResolverErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unknown digest is ErrSourceNotFound", func(t *testing.T) {
+		img, err := mutate.AppendLayers(empty.Image, tarLayer(t, [2]string{"a.md", "a"}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ref := pushImage(t, img)
+		missing := ref[:strings.LastIndex(ref, "@")] + "@sha256:" + strings.Repeat("0", 64)
+		if _, _, err := NewOCIResolver().Resolve(ctx, ociPlugin(missing)); !errors.Is(err, ErrSourceNotFound) {
+			t.Fatalf("expected ErrSourceNotFound, got %v", err)
+		}
+	})
+
+	t.Run("traversal path is ErrInvalidBundle", func(t *testing.T) {
+		img, err := mutate.AppendLayers(empty.Image, tarLayer(t, [2]string{"../evil", "x"}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := NewOCIResolver().Resolve(ctx, ociPlugin(pushImage(t, img))); !errors.Is(err, bundle.ErrInvalidBundle) {
+			t.Fatalf("expected ErrInvalidBundle, got %v", err)
+		}
+	})
+
+	t.Run("tag-only reference is ErrUnsupportedSource", func(t *testing.T) {
+		if _, _, err := NewOCIResolver().Resolve(ctx, ociPlugin("ghcr.io/acme/plugin:v1")); !errors.Is(err, ErrUnsupportedSource) {
+			t.Fatalf("expected ErrUnsupportedSource, got %v", err)
+		}
+	})
+}
+
+func TestTypeResolverDispatch(t *testing.T) {
+	oci := &recordingResolver{}
+	r := &TypeResolver{OCI: oci}
+	if _, _, err := r.Resolve(context.Background(), ociPlugin("ghcr.io/o/p@sha256:abc")); err != nil || oci.calls != 1 {
+		t.Fatalf("oci dispatch: calls=%d err=%v", oci.calls, err)
+	}
+	git := &v1alpha1.Plugin{Spec: v1alpha1.PluginSpec{Source: &v1alpha1.PluginSource{Type: v1alpha1.PluginSourceTypeGit}}}
+	if _, _, err := r.Resolve(context.Background(), git); !errors.Is(err, ErrUnsupportedSource) {
+		t.Fatalf("nil git resolver: expected ErrUnsupportedSource, got %v", err)
+	}
+}
+
+type recordingResolver struct{ calls int }
+
+func (r *recordingResolver) Resolve(context.Context, *v1alpha1.Plugin) (*v1alpha1.PluginResolvedSource, *bundle.CanonicalBundle, error) {
+	r.calls++
+	return &v1alpha1.PluginResolvedSource{}, &bundle.CanonicalBundle{}, nil
+}
@@ -23,12 +23,13 @@ const cloneTimeout = 2 * time.Minute
 
 var (
 	// ErrUnsupportedSource marks a source the resolver cannot handle — a
-	// TERMINAL condition (retrying will not help). OCI sources and non-GitHub
-	// git hosts are currently unsupported.
+	// TERMINAL condition (retrying will not help). Non-GitHub git hosts and
+	// OCI references without a digest are unsupported.
 	ErrUnsupportedSource = errors.New("source: unsupported plugin source")
-	// ErrSourceNotFound marks a ref that resolves to no commit on the remote
-	// (deleted/typo'd branch or tag, or a non-existent SHA) — TERMINAL.
-	ErrSourceNotFound = errors.New("source: git ref not found")
+	// ErrSourceNotFound marks a ref that resolves to nothing on the remote
+	// (deleted/typo'd branch or tag, a non-existent SHA, or an OCI manifest
+	// or blob the registry does not have) — TERMINAL.
+	ErrSourceNotFound = errors.New("source: ref not found")
 )
 
 // Resolver pins a plugin's source and loads its bundle. Transient failures
@@ -39,11 +40,40 @@ type Resolver interface {
 	Resolve(ctx context.Context, p *v1alpha1.Plugin) (*v1alpha1.PluginResolvedSource, *bundle.CanonicalBundle, error)
 }
 
+// TypeResolver dispatches on Plugin.Spec.Source.Type to a per-type Resolver.
+// A nil entry makes that source type unsupported.
+type TypeResolver struct {
+	Git Resolver
+	OCI Resolver
+}
+
+// NewResolver returns a Resolver for every supported source type.
+func NewResolver() *TypeResolver {
+	return &TypeResolver{Git: NewGitResolver(), OCI: NewOCIResolver()}
+}
+
+func (r *TypeResolver) Resolve(ctx context.Context, p *v1alpha1.Plugin) (*v1alpha1.PluginResolvedSource, *bundle.CanonicalBundle, error) {
+	if p == nil || p.Spec.Source == nil {
+		return nil, nil, fmt.Errorf("%w: plugin has no source", ErrUnsupportedSource)
+	}
+	var next Resolver
+	switch p.Spec.Source.Type {
+	case v1alpha1.PluginSourceTypeGit:
+		next = r.Git
+	case v1alpha1.PluginSourceTypeOCI:
+		next = r.OCI
+	}
+	if next == nil {
+		return nil, nil, fmt.Errorf("%w: unsupported plugin source type %q", ErrUnsupportedSource, p.Spec.Source.Type)
+	}
+	return next.Resolve(ctx, p)
+}
+
 // GitResolver resolves git sources: it resolves the ref to a commit SHA via
 // `git ls-remote` (no clone) and then shallow-clones that exact commit. It
 // shells out to system git with ambient credentials, and only github.com is
 // supported today (matching existing skill/agent source behavior). OCI sources
-// are not yet implemented.
+// are handled by OCIResolver.
 type GitResolver struct{}
 
 // NewGitResolver returns a git-backed Resolver.
@@ -58,7 +88,7 @@ func (r *GitResolver) Resolve(ctx context.Context, p *v1alpha1.Plugin) (*v1alpha
 	case v1alpha1.PluginSourceTypeGit:
 		return r.resolveGit(ctx, o.Git)
 	case v1alpha1.PluginSourceTypeOCI:
-		return nil, nil, fmt.Errorf("%w: oci plugin source not yet supported (use a git source)", ErrUnsupportedSource)
+		return nil, nil, fmt.Errorf("%w: oci plugin source needs an OCI resolver", ErrUnsupportedSource)
 	default:
 		return nil, nil, fmt.Errorf("%w: unknown plugin source type %q", ErrUnsupportedSource, o.Type)
 	}
@@ -9,7 +9,7 @@ import (
 )
 
 // TestGitResolverUnsupportedSources covers the terminal dispatch paths that do
-// not touch the network: nil source, OCI (not yet implemented), an unknown
+// not touch the network: nil source, OCI (handled by OCIResolver), an unknown
 // type, and a git source missing its repository URL. Each must wrap
 // ErrUnsupportedSource so the controller marks the plugin terminally failed
 // rather than retrying forever.
@@ -23,7 +23,7 @@ func TestGitResolverUnsupportedSources(t *testing.T) {
 	}{
 		{"nil source", &v1alpha1.Plugin{}},
 		{
-			name:   "oci source not yet supported",
+			name:   "oci source",
 			plugin: &v1alpha1.Plugin{Spec: v1alpha1.PluginSpec{Source: &v1alpha1.PluginSource{Type: v1alpha1.PluginSourceTypeOCI, OCI: &v1alpha1.PluginSourceOCI{Reference: "ghcr.io/o/p@sha256:abc"}}}},
 		},
 		{
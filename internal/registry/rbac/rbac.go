@@ -0,0 +1,257 @@
+// Package rbac wires auth.RBACAuthzProvider into the registry: it loads
+// Role, ClusterRole and RoleBinding objects from their Stores, adapts the
+// provider's decisions into the per-kind Authorize and ListFilter hooks
+// every read and write path consults, and drops the cached policy when an
+// RBAC object changes.
+package rbac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+const listPageSize = 500
+
+// Source loads RBAC objects from the Role, ClusterRole and RoleBinding
+// Stores. Stores may be assigned after the provider is built; until then
+// LoadRBAC fails and every non-admin request is denied.
+type Source struct {
+	Stores map[string]*v1alpha1store.Store
+}
+
+var _ auth.RBACSource = &Source{}
+
+// LoadRBAC lists every live Role, ClusterRole and RoleBinding.
+func (s *Source) LoadRBAC(ctx context.Context) (auth.RBACObjects, error) {
+	var (
+		objs auth.RBACObjects
+		err  error
+	)
+	if objs.Roles, err = listAll(ctx, s.Stores, v1alpha1.KindRole, func() *v1alpha1.Role { return &v1alpha1.Role{} }); err != nil {
+		return auth.RBACObjects{}, err
+	}
+	if objs.ClusterRoles, err = listAll(ctx, s.Stores, v1alpha1.KindClusterRole, func() *v1alpha1.ClusterRole { return &v1alpha1.ClusterRole{} }); err != nil {
+		return auth.RBACObjects{}, err
+	}
+	if objs.Bindings, err = listAll(ctx, s.Stores, v1alpha1.KindRoleBinding, func() *v1alpha1.RoleBinding { return &v1alpha1.RoleBinding{} }); err != nil {
+		return auth.RBACObjects{}, err
+	}
+	return objs, nil
+}
+
+func listAll[T v1alpha1.Object](ctx context.Context, stores map[string]*v1alpha1store.Store, kind string, newObj func() T) ([]T, error) {
+	store := stores[kind]
+	if store == nil {
+		return nil, fmt.Errorf("list %s: store is not configured", kind)
+	}
+	var out []T
+	opts := v1alpha1store.ListOpts{Limit: listPageSize}
+	for {
+		rows, cursor, err := store.List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %w", kind, err)
+		}
+		for _, raw := range rows {
+			obj, err := v1alpha1.EnvelopeFromRaw(newObj, raw, kind)
+			if err != nil {
+				// An undecodable row grants nothing; skip it rather than
+				// locking every caller out.
+				slog.Error("rbac: skipping undecodable row", "kind", kind, "namespace", raw.Metadata.Namespace, "name", raw.Metadata.Name, "error", err)
+				continue
+			}
+			out = append(out, obj)
+		}
+		if cursor == "" {
+			return out, nil
+		}
+		opts.Cursor = cursor
+	}
+}
+
+// Authorizer returns a resource.Config.Authorize hook backed by provider,
+// run ahead of next (nil for none). Applies of Role, ClusterRole and
+// RoleBinding are also checked for privilege escalation (see
+// auth.RBACAuthzProvider.AuthorizeGrant).
+func Authorizer(provider *auth.RBACAuthzProvider, next func(ctx context.Context, in resource.AuthorizeInput) error) func(ctx context.Context, in resource.AuthorizeInput) error {
+	return func(ctx context.Context, in resource.AuthorizeInput) error {
+		session, _ := auth.AuthSessionFrom(ctx)
+		err := provider.Authorize(ctx, session, auth.AccessRequest{Verb: in.Verb, Kind: in.Kind, Namespace: in.Namespace, Name: in.Name})
+		if err == nil && in.Verb == v1alpha1.RBACVerbApply && in.Object != nil {
+			err = provider.AuthorizeGrant(ctx, session, in.Object)
+		}
+		if err != nil {
+			return StatusError(err)
+		}
+		if next != nil {
+			return next(ctx, in)
+		}
+		return nil
+	}
+}
+
+// ListFilter returns a resource.Config.ListFilter hook that narrows list
+// queries to the rows provider lets the caller see, ANDed with the
+// predicate of next (nil for none).
+func ListFilter(provider *auth.RBACAuthzProvider, next func(ctx context.Context, in resource.AuthorizeInput) (string, []any, error)) func(ctx context.Context, in resource.AuthorizeInput) (string, []any, error) {
+	return func(ctx context.Context, in resource.AuthorizeInput) (string, []any, error) {
+		var (
+			where string
+			args  []any
+		)
+		if next != nil {
+			var err error
+			if where, args, err = next(ctx, in); err != nil {
+				return "", nil, err
+			}
+		}
+		session, _ := auth.AuthSessionFrom(ctx)
+		scope, err := provider.ListScope(ctx, session, v1alpha1.RBACVerbList, in.Kind, in.Namespace)
+		if err != nil {
+			return "", nil, huma.Error500InternalServerError("evaluate RBAC policy", err)
+		}
+		rbacWhere, rbacArgs := CompileListScope(scope, len(args))
+		switch {
+		case rbacWhere == "":
+			return where, args, nil
+		case where == "":
+			return rbacWhere, rbacArgs, nil
+		}
+		return "(" + where + ") AND " + rbacWhere, append(args, rbacArgs...), nil
+	}
+}
+
+// CompileListScope renders scope as a ListOpts.ExtraWhere predicate over
+// the namespace and name columns, numbering its placeholders after
+// argOffset existing ones. An unrestricted scope compiles to no predicate;
+// an empty one to FALSE.
+func CompileListScope(scope auth.ListScope, argOffset int) (string, []any) {
+	if scope.All {
+		return "", nil
+	}
+	if len(scope.Grants) == 0 {
+		return "FALSE", nil
+	}
+	var (
+		clauses []string
+		args    []any
+	)
+	bind := func(v []string) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d::text[]", argOffset+len(args))
+	}
+	for _, grant := range scope.Grants {
+		var terms []string
+		if grant.Namespaces != nil {
+			terms = append(terms, "namespace = ANY("+bind(grant.Namespaces)+")")
+		}
+		if names := grantNamePredicate(grant.Names, bind); names != "" {
+			terms = append(terms, names)
+		}
+		if len(terms) == 0 {
+			return "", nil
+		}
+		clauses = append(clauses, "("+strings.Join(terms, " AND ")+")")
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+// grantNamePredicate matches names against exact names and "prefix*"
+// patterns; it is empty when the patterns cover every name.
+func grantNamePredicate(patterns []string, bind func([]string) string) string {
+	var exact, like []string
+	for _, pattern := range patterns {
+		if pattern == v1alpha1.RBACWildcard {
+			return ""
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			like = append(like, likeEscaper.Replace(prefix)+"%")
+			continue
+		}
+		exact = append(exact, pattern)
+	}
+	var terms []string
+	if len(exact) > 0 {
+		terms = append(terms, "name = ANY("+bind(exact)+")")
+	}
+	if len(like) > 0 {
+		terms = append(terms, "name LIKE ANY("+bind(like)+")")
+	}
+	switch len(terms) {
+	case 0:
+		return ""
+	case 1:
+		return terms[0]
+	}
+	return "(" + strings.Join(terms, " OR ") + ")"
+}
+
+// likeEscaper escapes LIKE metacharacters with Postgres's default escape
+// character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// authzError is a huma status error that still unwraps to the
+// auth.ErrUnauthenticated or auth.ErrForbidden it reports, for callers
+// (like the MCP Registry compatibility API) that map denials themselves.
+type authzError struct {
+	*huma.ErrorModel
+	cause error
+}
+
+func (e *authzError) Unwrap() error { return e.cause }
+
+// StatusError maps an authorization error onto the HTTP status the handlers
+// return: 401 for anonymous denials, 403 for signed-in ones, 500 otherwise.
+func StatusError(err error) error {
+	var status int
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, auth.ErrUnauthenticated):
+		status = http.StatusUnauthorized
+	case errors.Is(err, auth.ErrForbidden):
+		status = http.StatusForbidden
+	default:
+		return huma.Error500InternalServerError("evaluate RBAC policy", err)
+	}
+	return &authzError{
+		ErrorModel: &huma.ErrorModel{Status: status, Title: http.StatusText(status), Detail: err.Error()},
+		cause:      err,
+	}
+}
+
+// WatchChanges invalidates provider's cached policy whenever notifier
+// reports a change to an RBAC Store, and after notifier reconnects. The
+// policy reloads lazily on the next decision. It returns when ctx is done.
+func WatchChanges(ctx context.Context, provider *auth.RBACAuthzProvider, notifier *v1alpha1store.ChangeNotifier, stores map[string]*v1alpha1store.Store) {
+	channels := map[string]bool{}
+	for _, kind := range []string{v1alpha1.KindRole, v1alpha1.KindClusterRole, v1alpha1.KindRoleBinding} {
+		if store := stores[kind]; store != nil {
+			channels[store.StatusChannel()] = true
+		}
+	}
+	notifications, unsubscribe := notifier.Subscribe()
+	defer unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case n := <-notifications:
+			// An empty control-plane payload is the notifier's reconnect
+			// signal: changes may have been missed.
+			if channels[n.Channel] || n.Channel == v1alpha1store.ControlPlaneNotifyChannel && n.Payload == "" {
+				provider.Invalidate()
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+//go:build integration
+
+package rbac_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/rbac"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+type userSession string
+
+func (s userSession) Principal() auth.Principal {
+	return auth.Principal{User: auth.User{Subject: string(s)}}
+}
+
+func TestListFilter_HidesUnauthorizedRows(t *testing.T) {
+	ctx := context.Background()
+	pool := v1alpha1store.NewTestPool(t)
+	stores := v1alpha1store.NewStores(pool, v1alpha1store.TestSchemaRegistry())
+
+	upsert := func(obj v1alpha1.Object) {
+		t.Helper()
+		_, err := stores[obj.GetKind()].Upsert(ctx, obj)
+		require.NoError(t, err)
+	}
+	for _, ref := range []struct{ namespace, name string }{
+		{"team-a", "support-bot"}, {"team-a", "billing-bot"}, {"team-b", "support-bot"},
+	} {
+		upsert(&v1alpha1.Agent{
+			TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindAgent},
+			Metadata: v1alpha1.ObjectMeta{Namespace: ref.namespace, Name: ref.name},
+			Spec:     v1alpha1.AgentSpec{Title: ref.name},
+		})
+	}
+	upsert(&v1alpha1.Role{
+		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindRole},
+		Metadata: v1alpha1.ObjectMeta{Namespace: "team-a", Name: "support"},
+		Spec:     v1alpha1.RoleSpec{Rules: []v1alpha1.PolicyRule{{Verbs: []string{"list"}, Kinds: []string{v1alpha1.KindAgent}, Names: []string{"support-*"}}}},
+	})
+	upsert(&v1alpha1.RoleBinding{
+		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindRoleBinding},
+		Metadata: v1alpha1.ObjectMeta{Namespace: "team-a", Name: "alice-support"},
+		Spec: v1alpha1.RoleBindingSpec{
+			RoleRef:  v1alpha1.RoleRef{Kind: v1alpha1.KindRole, Name: "support"},
+			Subjects: []v1alpha1.Subject{{Kind: v1alpha1.SubjectKindUser, Name: "alice"}},
+		},
+	})
+
+	provider := auth.NewRBACAuthzProvider(&rbac.Source{Stores: stores}, nil)
+	filter := rbac.ListFilter(provider, nil)
+	list := func(session auth.Session) []string {
+		t.Helper()
+		where, args, err := filter(auth.AuthSessionTo(ctx, session), resource.AuthorizeInput{Verb: "list", Kind: v1alpha1.KindAgent})
+		require.NoError(t, err)
+		rows, _, err := stores[v1alpha1.KindAgent].List(ctx, v1alpha1store.ListOpts{ExtraWhere: where, ExtraArgs: args})
+		require.NoError(t, err)
+		var names []string
+		for _, row := range rows {
+			names = append(names, row.Metadata.Namespace+"/"+row.Metadata.Name)
+		}
+		return names
+	}
+
+	require.Equal(t, []string{"team-a/support-bot"}, list(userSession("alice")))
+	require.Empty(t, list(userSession("bob")))
+	require.Empty(t, list(&auth.PublicSession{}))
+	require.Len(t, list(&auth.SystemSession{}), 3)
+}
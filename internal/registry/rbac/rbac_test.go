@@ -0,0 +1,78 @@
+package rbac
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+)
+
+func TestCompileListScope(t *testing.T) {
+	tests := []struct {
+		name      string
+		scope     auth.ListScope
+		wantWhere string
+		wantArgs  []any
+	}{
+		{name: "all", scope: auth.ListScope{All: true}},
+		{name: "nothing", scope: auth.ListScope{}, wantWhere: "FALSE"},
+		{
+			name:      "namespaces only",
+			scope:     auth.ListScope{Grants: []auth.Grant{{Namespaces: []string{"team-a", "team-b"}}}},
+			wantWhere: "((namespace = ANY($1::text[])))",
+			wantArgs:  []any{[]string{"team-a", "team-b"}},
+		},
+		{
+			name: "names and prefixes",
+			scope: auth.ListScope{Grants: []auth.Grant{
+				{Namespaces: []string{"team-a"}, Names: []string{"exact", "support_*"}},
+				{Names: []string{"100%*"}},
+			}},
+			wantWhere: "((namespace = ANY($1::text[]) AND (name = ANY($2::text[]) OR name LIKE ANY($3::text[]))) OR (name LIKE ANY($4::text[])))",
+			wantArgs:  []any{[]string{"team-a"}, []string{"exact"}, []string{`support\_%`}, []string{`100\%%`}},
+		},
+		{
+			name:  "unrestricted grant",
+			scope: auth.ListScope{Grants: []auth.Grant{{Namespaces: []string{"team-a"}}, {Names: []string{"*"}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args := CompileListScope(tt.scope, 0)
+			assert.Equal(t, tt.wantWhere, where)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestCompileListScope_ArgOffset(t *testing.T) {
+	where, args := CompileListScope(auth.ListScope{Grants: []auth.Grant{{Namespaces: []string{"team-a"}, Names: []string{"x"}}}}, 2)
+	assert.Equal(t, "((namespace = ANY($3::text[]) AND name = ANY($4::text[])))", where)
+	assert.Len(t, args, 2)
+}
+
+func TestStatusError(t *testing.T) {
+	require.NoError(t, StatusError(nil))
+
+	for _, tt := range []struct {
+		cause      error
+		wantStatus int
+	}{
+		{cause: auth.ErrUnauthenticated, wantStatus: http.StatusUnauthorized},
+		{cause: auth.ErrForbidden, wantStatus: http.StatusForbidden},
+		{cause: errors.New("database down"), wantStatus: http.StatusInternalServerError},
+	} {
+		err := StatusError(tt.cause)
+		var se huma.StatusError
+		require.ErrorAs(t, err, &se)
+		assert.Equal(t, tt.wantStatus, se.GetStatus())
+		if tt.wantStatus != http.StatusInternalServerError {
+			assert.ErrorIs(t, err, tt.cause, "denials still unwrap to the auth sentinel")
+		}
+	}
+}
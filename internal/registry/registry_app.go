@@ -24,21 +24,27 @@ import (
 	"github.com/agentregistry-dev/agentregistry/internal/registry/api"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/crud"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/api/router"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/apitokens"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
 	controller "github.com/agentregistry-dev/agentregistry/internal/registry/controller"
 	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/introspect"
 	pluginsource "github.com/agentregistry-dev/agentregistry/internal/registry/plugins/source"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/rbac"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/runtimes/kubernetes"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/runtimes/local"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/secrets"
 	deploymentsvc "github.com/agentregistry-dev/agentregistry/internal/registry/service/deployment"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
 	"github.com/agentregistry-dev/agentregistry/internal/version"
 	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1/registries"
 	"github.com/agentregistry-dev/agentregistry/pkg/logging"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/signing"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
 	"github.com/agentregistry-dev/agentregistry/pkg/types"
 )
@@ -66,15 +72,45 @@ func App(ctx context.Context, opts ...types.AppOptions) error {
 		jwtManager = auth.NewJWTManager(cfg)
 	}
 
-	// Resolve authn provider: use provided, or default to JWT-based if configured
-	authnProvider := options.AuthnProvider
-	if authnProvider == nil && jwtManager != nil {
-		authnProvider = jwtManager
+	// Built-in authn providers: the registry's own JWTs and OIDC tokens,
+	// whichever are configured. API tokens join them once the database is
+	// open; see resolveAuthn.
+	var authnProviders []auth.AuthnProvider
+	if jwtManager != nil {
+		authnProviders = append(authnProviders, jwtManager)
+	}
+	if cfg.OIDCIssuerURL != "" {
+		oidcProvider, err := auth.NewOIDCProvider(auth.OIDCConfig{
+			IssuerURL:      cfg.OIDCIssuerURL,
+			Audiences:      splitList(cfg.OIDCAudiences),
+			UsernameClaim:  cfg.OIDCUsernameClaim,
+			UsernamePrefix: cfg.OIDCUsernamePrefix,
+			GroupsClaim:    cfg.OIDCGroupsClaim,
+			GroupsPrefix:   cfg.OIDCGroupsPrefix,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create OIDC authn provider: %w", err)
+		}
+		slog.Info("using OIDC authn provider", "issuer", cfg.OIDCIssuerURL)
+		authnProviders = append(authnProviders, oidcProvider)
 	}
 
-	// Resolve authz provider: use provided, or default to public authz
+	// Resolve authz provider: use provided, else RBAC when configured, or
+	// default to public authz
 	authzProvider := options.AuthzProvider
-	if authzProvider == nil {
+	var (
+		rbacSource   *rbac.Source
+		rbacProvider *auth.RBACAuthzProvider
+	)
+	switch {
+	case authzProvider != nil:
+	case cfg.AuthzMode == config.AuthzModeRBAC:
+		slog.Info("using RBAC authz provider")
+		// The source reads the RBAC Stores, assigned once they are built.
+		rbacSource = &rbac.Source{}
+		rbacProvider = auth.NewRBACAuthzProvider(rbacSource, strings.Split(cfg.RBACAdmins, ","))
+		authzProvider = rbacProvider
+	default:
 		slog.Info("using public authz provider")
 		authzProvider = auth.NewPublicAuthzProvider(jwtManager)
 	}
@@ -111,14 +147,34 @@ func App(ctx context.Context, opts ...types.AppOptions) error {
 	}
 	maps.Copy(deploymentAdapters, options.DeploymentAdapters)
 	pool := db.Pool()
-	stores := buildStores(pool, options.V1Alpha1StoreTables, options.V1Alpha1MutableStoreKinds, options.Auditor)
-	if _, err := controller.StartDeploymentController(ctx, pool, stores, deploymentAdapters, deploymentControllerConfig(cfg)); err != nil {
+	immutableTags, err := v1alpha1store.ParseImmutableTagPolicy(cfg.ImmutableTags)
+	if err != nil {
+		return fmt.Errorf("immutable tags: %w", err)
+	}
+	stores := buildStores(pool, options.V1Alpha1StoreTables, options.V1Alpha1MutableStoreKinds, options.Auditor, immutableTags)
+	if rbacSource != nil {
+		rbacSource.Stores = stores
+	}
+	skillArchives := v1alpha1store.NewSkillArchiveStore(pool, pkgdb.MustNewSchema(pkgdb.OSSSchema))
+	apiTokens := v1alpha1store.NewAPITokenStore(pool, pkgdb.MustNewSchema(pkgdb.OSSSchema))
+	namespaceOwners := v1alpha1store.NewNamespaceOwnerStore(pool, pkgdb.MustNewSchema(pkgdb.OSSSchema))
+	authnProvider := resolveAuthn(options.AuthnProvider, authnProviders, apiTokens)
+	// Secret values are sealed under this key on write and opened only by
+	// the Deployment controller at apply time. A nil keyring (no key
+	// configured) leaves Secrets unwritable rather than stored in clear.
+	secretKeyring, err := secrets.ParseKeyring(cfg.SecretEncryptionKey)
+	if err != nil {
+		return fmt.Errorf("secret encryption key: %w", err)
+	}
+	controllerConfig := deploymentControllerConfig(cfg)
+	controllerConfig.Secrets = secrets.NewResolver(stores[v1alpha1.KindSecret], secretKeyring)
+	if _, err := controller.StartDeploymentController(ctx, pool, stores, deploymentAdapters, controllerConfig); err != nil {
 		return fmt.Errorf("start deployment controller: %w", err)
 	}
 	// The Plugin controller resolves each plugin's pinned source pointer to a
 	// concrete commit/digest and records the manifest/inventory in PluginStatus
 	// out of band of the API write — same pattern as the Deployment controller.
-	pluginController, err := controller.NewPluginController(pool, stores, controller.PluginControllerDeps{Resolver: pluginsource.NewGitResolver()})
+	pluginController, err := controller.NewPluginController(pool, stores, controller.PluginControllerDeps{Resolver: pluginsource.NewResolver()})
 	if err != nil {
 		return fmt.Errorf("create plugin controller: %w", err)
 	}
@@ -128,11 +184,17 @@ func App(ctx context.Context, opts ...types.AppOptions) error {
 		}
 		defer pluginController.Stop()
 	}
-	// The Skill controller resolves each skill's pinned git source ref to a
-	// concrete commit and records it in SkillStatus out of band of the API write
-	// — the resolve-and-pin counterpart to the Plugin controller, minus the
+	// The Skill controller resolves each skill's source (git ref, OCI
+	// reference, or uploaded archive) to a concrete commit or digest and
+	// records it in SkillStatus out of band of the API write — the
+	// resolve-and-pin counterpart to the Plugin controller, minus the
 	// manifest/inventory scan (a skill has no bundle to enumerate).
-	skillController, err := controller.NewSkillController(pool, stores, controller.SkillControllerDeps{})
+	skillController, err := controller.NewSkillController(pool, stores, controller.SkillControllerDeps{
+		StatArchive: func(ctx context.Context, digest string) error {
+			_, err := skillArchives.Stat(ctx, digest)
+			return err
+		},
+	})
 	if err != nil {
 		return fmt.Errorf("create skill controller: %w", err)
 	}
@@ -142,6 +204,25 @@ func App(ctx context.Context, opts ...types.AppOptions) error {
 		}
 		defer skillController.Stop()
 	}
+	// The MCPServer controller introspects each server tag and records the
+	// tools, prompts and resources it exposes in MCPServerStatus. Opt-in:
+	// bundled servers are executed (sandboxed) on this host.
+	if cfg.MCPIntrospectionEnabled {
+		introspector := introspect.New(introspect.Options{
+			Network: cfg.MCPIntrospectionNetwork,
+			Timeout: cfg.MCPIntrospectionTimeout,
+		})
+		mcpServerController, err := controller.NewMCPServerController(pool, stores, controller.MCPServerControllerDeps{Introspect: introspector.Introspect})
+		if err != nil {
+			return fmt.Errorf("create mcpserver controller: %w", err)
+		}
+		if mcpServerController != nil {
+			if err := mcpServerController.Start(ctx); err != nil {
+				return fmt.Errorf("start mcpserver controller: %w", err)
+			}
+			defer mcpServerController.Stop()
+		}
+	}
 
 	slog.Info("starting agentregistry", "version", version.Version, "commit", version.GitCommit)
 
@@ -163,8 +244,41 @@ func App(ctx context.Context, opts ...types.AppOptions) error {
 		}
 	}()
 
-	perKindHooks := crudPerKindHooks(options)
-	routeOpts := buildRouteOptions(options, stores, deploymentAdapters, perKindHooks)
+	perKindHooks := withSecretHooks(crudPerKindHooks(options), stores[v1alpha1.KindSecret], secretKeyring)
+	if rbacProvider != nil {
+		perKindHooks = withRBACHooks(perKindHooks, rbacProvider, stores)
+	}
+	perKindHooks = withAPITokenHooks(perKindHooks, stores)
+	trustPolicy, err := signing.LoadTrustPolicy(cfg.SigningTrustPolicy)
+	if err != nil {
+		return fmt.Errorf("signing trust policy: %w", err)
+	}
+	if trustPolicy != nil {
+		slog.Info("verifying artifact signatures", "namespaces", trustPolicy.Namespaces())
+	}
+	imageKeys, err := signing.LoadImageKeys(cfg.OCIVerificationKeys)
+	if err != nil {
+		return fmt.Errorf("OCI verification keys: %w", err)
+	}
+	var imageVerifier *signing.ImageVerifier
+	if len(imageKeys) > 0 {
+		imageVerifier = &signing.ImageVerifier{
+			Keys:              imageKeys,
+			RequireSignature:  cfg.OCIRequireSignature,
+			RequireProvenance: cfg.OCIRequireProvenance,
+		}
+		slog.Info("verifying OCI package images", "keys", len(imageKeys),
+			"requireSignature", cfg.OCIRequireSignature, "requireProvenance", cfg.OCIRequireProvenance)
+	}
+	routeOpts := buildRouteOptions(options, stores, deploymentAdapters, perKindHooks, trustPolicy, imageVerifier, namespaceOwners)
+	routeOpts.SkillArchives = skillArchives
+	routeOpts.APITokens = apiTokens
+	routeOpts.NamespaceOwners = namespaceOwners
+	routeOpts.IsRegistryAdmin = authz.IsRegistryAdmin
+	routeOpts.Watch = watchSource(pool, stores)
+	if rbacProvider != nil && routeOpts.Watch != nil {
+		go rbac.WatchChanges(ctx, rbacProvider, routeOpts.Watch.Notifier, stores)
+	}
 
 	// Initialize HTTP server
 	baseServer, err := api.NewServer(cfg, metrics, versionInfo, options.UIHandler, authnProvider, routeOpts, options.OpenAPISchemaNamer)
@@ -189,7 +303,7 @@ func App(ctx context.Context, opts ...types.AppOptions) error {
 	if mcpAuthnProvider == nil {
 		mcpAuthnProvider = authnProvider
 	}
-	mcpHTTPServer := startMCPServer(cfg, stores, mcpAuthnProvider, perKindHooks, options.MCPProtectedResourceMetadata, options.MCPResourceMetadataURL)
+	mcpHTTPServer := startMCPServer(cfg, stores, skillArchives, mcpAuthnProvider, perKindHooks, options.MCPProtectedResourceMetadata, options.MCPResourceMetadataURL)
 
 	// Start server in a goroutine so it doesn't block signal handling
 	go func() {
@@ -236,7 +350,7 @@ func resolveExtraStoreSchema(table string, ossSchema pkgdb.Schema) (pkgdb.Schema
 	return ossSchema, table
 }
 
-func buildStores(pool *pgxpool.Pool, extraStoreTables map[string]string, mutableExtraKinds map[string]bool, auditor types.Auditor) map[string]*v1alpha1store.Store {
+func buildStores(pool *pgxpool.Pool, extraStoreTables map[string]string, mutableExtraKinds map[string]bool, auditor types.Auditor, immutableTags *v1alpha1store.ImmutableTagPolicy) map[string]*v1alpha1store.Store {
 	if auditor == nil {
 		auditor = types.NoopAuditor
 	}
@@ -245,7 +359,7 @@ func buildStores(pool *pgxpool.Pool, extraStoreTables map[string]string, mutable
 	// search_path.
 	schemas := pkgdb.OSSSchemaRegistry()
 	ossSchema := schemas.MustGet(pkgdb.OSSSourceName)
-	stores := v1alpha1store.NewStores(pool, schemas, v1alpha1store.WithAuditor(auditor))
+	stores := v1alpha1store.NewStores(pool, schemas, v1alpha1store.WithAuditor(auditor), v1alpha1store.WithImmutableTags(immutableTags))
 	for kind, table := range extraStoreTables {
 		if kind == "" || table == "" {
 			slog.Warn("skipping v1alpha1 extra store with empty kind or table", "kind", kind, "table", table)
@@ -259,12 +373,14 @@ func buildStores(pool *pgxpool.Pool, extraStoreTables map[string]string, mutable
 			slog.Warn("skipping v1alpha1 extra store with empty table after schema qualifier", "kind", kind, "table", table)
 			continue
 		}
-		opts := []v1alpha1store.StoreOption{v1alpha1store.WithKind(kind), v1alpha1store.WithAuditor(auditor)}
+		opts := []v1alpha1store.StoreOption{v1alpha1store.WithKind(kind), v1alpha1store.WithAuditor(auditor), v1alpha1store.WithImmutableTags(immutableTags)}
 		if mutableExtraKinds[kind] {
 			stores[kind] = v1alpha1store.NewMutableObjectStore(pool, sch, tbl, opts...)
 			continue
 		}
-		stores[kind] = v1alpha1store.NewStore(pool, sch, tbl, opts...)
+		// tag_aliases and tag_revisions live in the OSS schema whichever
+		// schema the kind's own table is in.
+		stores[kind] = v1alpha1store.NewStore(pool, sch, tbl, append(opts, v1alpha1store.WithTagAliases(ossSchema), v1alpha1store.WithTagHistory(ossSchema))...)
 	}
 
 	// pool == nil is the noop/DatabaseFactory path used by gen-openapi
@@ -281,6 +397,24 @@ func buildStores(pool *pgxpool.Pool, extraStoreTables map[string]string, mutable
 	return stores
 }
 
+// watchSource backs ?watch=true list streams with the control-plane event
+// log and one shared LISTEN connection covering every kind's status
+// channel. A nil pool (the noop database path) leaves watching off.
+func watchSource(pool *pgxpool.Pool, stores map[string]*v1alpha1store.Store) *resource.WatchSource {
+	if pool == nil {
+		return nil
+	}
+	channels := make([]string, 0, len(stores))
+	for _, store := range stores {
+		channels = append(channels, store.StatusChannel())
+	}
+	slices.Sort(channels)
+	return &resource.WatchSource{
+		Events:   v1alpha1store.NewControlPlaneEventStore(pool, pkgdb.MustNewSchema(pkgdb.OSSSchema)),
+		Notifier: v1alpha1store.NewChangeNotifier(pool, slices.Compact(channels)...),
+	}
+}
+
 func deploymentControllerConfig(cfg *config.Config) controller.ControllerConfig {
 	return controller.ControllerConfig{
 		Retention: controller.RetentionPolicy{
@@ -294,18 +428,113 @@ func deploymentControllerConfig(cfg *config.Config) controller.ControllerConfig
 	}
 }
 
+// withSecretHooks chains the Secret sealing Prepare after any caller-supplied
+// Prepares[KindSecret], so downstream hooks still see the submitted plaintext
+// and nothing after this point does.
+func withSecretHooks(hooks crud.PerKindHooks, store *v1alpha1store.Store, keyring *secrets.Keyring) crud.PerKindHooks {
+	seal := secrets.NewPrepare(store, keyring)
+	previous := hooks.Prepares[v1alpha1.KindSecret]
+	prepares := make(map[string]func(ctx context.Context, obj v1alpha1.Object) error, len(hooks.Prepares)+1)
+	maps.Copy(prepares, hooks.Prepares)
+	prepares[v1alpha1.KindSecret] = func(ctx context.Context, obj v1alpha1.Object) error {
+		if previous != nil {
+			if err := previous(ctx, obj); err != nil {
+				return err
+			}
+		}
+		return seal(ctx, obj)
+	}
+	hooks.Prepares = prepares
+	return hooks
+}
+
+// withRBACHooks puts the RBAC Authorize and ListFilter hooks in front of
+// any caller-supplied ones for every kind with a Store, so RBAC gates each
+// request first and downstream hooks can only narrow further.
+func withRBACHooks(hooks crud.PerKindHooks, provider *auth.RBACAuthzProvider, stores map[string]*v1alpha1store.Store) crud.PerKindHooks {
+	authorizers := make(map[string]func(ctx context.Context, in resource.AuthorizeInput) error, len(stores))
+	listFilters := make(map[string]func(ctx context.Context, in resource.AuthorizeInput) (string, []any, error), len(stores))
+	for kind := range stores {
+		authorizers[kind] = rbac.Authorizer(provider, hooks.Authorizers[kind])
+		listFilters[kind] = rbac.ListFilter(provider, hooks.ListFilters[kind])
+	}
+	hooks.Authorizers = authorizers
+	hooks.ListFilters = listFilters
+	return hooks
+}
+
+// resolveAuthn chains API tokens ahead of custom when it is set, or else
+// ahead of the built-in providers. Tokens are issued to callers those
+// providers signed in, so with neither there is no authentication and
+// it returns nil.
+func resolveAuthn(custom auth.AuthnProvider, builtin []auth.AuthnProvider, apiTokens *v1alpha1store.APITokenStore) auth.AuthnProvider {
+	providers := builtin
+	if custom != nil {
+		providers = []auth.AuthnProvider{custom}
+	}
+	if len(providers) == 0 {
+		return nil
+	}
+	// API tokens go first: they are recognized by prefix, so other
+	// bearers pass through untouched.
+	return auth.ChainAuthnProviders(append([]auth.AuthnProvider{apitokens.NewAuthn(apiTokens)}, providers...)...)
+}
+
+// withAPITokenHooks runs every kind's Authorize and ListFilter hooks
+// behind the scope check for API token sessions, so a token never does
+// more than its scopes allow whatever its subject is granted.
+func withAPITokenHooks(hooks crud.PerKindHooks, stores map[string]*v1alpha1store.Store) crud.PerKindHooks {
+	authorizers := make(map[string]func(ctx context.Context, in resource.AuthorizeInput) error, len(stores))
+	listFilters := make(map[string]func(ctx context.Context, in resource.AuthorizeInput) (string, []any, error), len(stores))
+	for kind := range stores {
+		authorizers[kind] = apitokens.Authorizer(hooks.Authorizers[kind])
+		listFilters[kind] = apitokens.ListFilter(hooks.ListFilters[kind])
+	}
+	hooks.Authorizers = authorizers
+	hooks.ListFilters = listFilters
+	return hooks
+}
+
 func buildRouteOptions(
 	options types.AppOptions,
 	stores map[string]*v1alpha1store.Store,
 	adapters map[string]types.DeploymentAdapter,
 	perKindHooks crud.PerKindHooks,
+	trustPolicy *signing.TrustPolicy,
+	imageVerifier *signing.ImageVerifier,
+	namespaceOwners signing.NamespaceOwners,
 ) *router.RouteOptions {
+	// Signature verification wraps whichever admission owns the write, so
+	// a downstream admission still sees only verified (or unsigned) objects.
+	// Namespace ownership is always enforced, and checked first: an owned
+	// namespace admits only artifacts signed with its owner's key.
+	admission := options.Admission
+	if admission == nil {
+		admission = resource.ProductionAdmission
+	}
+	if imageVerifier != nil {
+		admission = signing.ImageAdmission(admission)
+	}
+	if trustPolicy != nil {
+		admission = signing.Admission(trustPolicy, admission)
+	}
+	admission = signing.OwnershipAdmission(namespaceOwners, admission)
+	// OCI images are verified by the registry validator, at the digest
+	// whose labels it read. A downstream validator replaces that check.
+	registryValidator := options.RegistryValidator
+	if imageVerifier != nil {
+		if registryValidator == nil {
+			registryValidator = registries.NewDispatcher(imageVerifier)
+		} else {
+			slog.Warn("custom registry validator set; OCI image verification is skipped")
+		}
+	}
 	routeOpts := &router.RouteOptions{
 		ExtraRoutes:         options.ExtraRoutes,
 		Stores:              stores,
 		PerKindHooks:        perKindHooks,
-		RegistryValidator:   options.RegistryValidator,
-		Admission:           options.Admission,
+		RegistryValidator:   registryValidator,
+		Admission:           admission,
 		DeleteAdmission:     options.DeleteAdmission,
 		ResolverWrapper:     options.ResolverWrapper,
 		ExtraResourceRoutes: options.ExtraResourceRoutes,
@@ -512,6 +741,7 @@ func openDatabase(
 func startMCPServer(
 	cfg *config.Config,
 	stores map[string]*v1alpha1store.Store,
+	skillArchives *v1alpha1store.SkillArchiveStore,
 	authnProvider auth.AuthnProvider,
 	hooks crud.PerKindHooks,
 	resourceMetadata *oauthex.ProtectedResourceMetadata,
@@ -520,7 +750,10 @@ func startMCPServer(
 	if cfg.MCPPort <= 0 {
 		return nil
 	}
-	mcpServer := mcpregistry.NewServer(stores, hooks.Authorizers, hooks.ListFilters)
+	mcpServer := mcpregistry.NewServer(stores, hooks.Authorizers, hooks.ListFilters, func(ctx context.Context, digest string) ([]byte, error) {
+		archive, err := skillArchives.Get(ctx, digest)
+		return archive.Content, err
+	})
 	var handler http.Handler = mcp.NewStreamableHTTPHandler(func(_ *http.Request) *mcp.Server {
 		return mcpServer
 	}, &mcp.StreamableHTTPOptions{})
@@ -596,3 +829,14 @@ func setupLogging(levelStr string) {
 	// set all loggers to the specified level
 	logging.Reset(level)
 }
+
+// splitList splits a comma-separated config value, dropping blank entries.
+func splitList(value string) []string {
+	var out []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
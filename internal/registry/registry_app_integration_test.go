@@ -3,16 +3,20 @@
 package registry
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2/humatest"
 	"github.com/stretchr/testify/require"
 
 	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
 	"github.com/agentregistry-dev/agentregistry/pkg/types"
@@ -51,7 +55,7 @@ func TestBuildStores_ExtensionKindAppliesThroughBatchEndpoint(t *testing.T) {
 	pool := v1alpha1store.NewTestPool(t)
 	stores := buildStores(pool, map[string]string{
 		extensionApplyKind: "agents",
-	}, nil, nil)
+	}, nil, nil, nil)
 	extensionStore := stores[extensionApplyKind]
 	require.NotNil(t, extensionStore)
 
@@ -99,7 +103,7 @@ spec:
 func TestBuildStores_PropagatesAuditor(t *testing.T) {
 	pool := v1alpha1store.NewTestPool(t)
 	auditor := &typestest.RecordingAuditor{}
-	stores := buildStores(pool, nil, nil, auditor)
+	stores := buildStores(pool, nil, nil, auditor, nil)
 
 	agentStore := stores[v1alpha1.KindAgent]
 	require.NotNil(t, agentStore)
@@ -120,7 +124,29 @@ func TestBuildStores_PropagatesAuditor(t *testing.T) {
 
 	// Sanity: nil auditor still works (NoopAuditor fallback) — guards the
 	// nil-check branch in buildStores.
-	stores2 := buildStores(pool, nil, nil, nil)
+	stores2 := buildStores(pool, nil, nil, nil, nil)
 	require.NotNil(t, stores2[v1alpha1.KindAgent])
 	_ = types.NoopAuditor
 }
+
+func TestResolveAuthn_CustomProviderAuthenticatesAPITokens(t *testing.T) {
+	ctx := context.Background()
+	pool := v1alpha1store.NewTestPool(t)
+	tokens := v1alpha1store.NewAPITokenStore(pool, v1alpha1store.TestSchema())
+	token, hash := auth.NewAPIToken()
+	_, err := tokens.Create(ctx, v1alpha1store.APIToken{
+		Name:      "ci",
+		Owner:     "alice",
+		Subject:   "alice",
+		Scopes:    []auth.Permission{{Action: auth.PermissionActionRead, ResourcePattern: "*"}},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, hash)
+	require.NoError(t, err)
+
+	custom := authnFunc(func(func(string) string) (auth.Session, error) { return nil, nil })
+	authn := resolveAuthn(custom, nil, tokens)
+	session, err := authn.Authenticate(ctx, func(string) string { return "Bearer " + token }, url.Values{})
+	require.NoError(t, err)
+	require.True(t, auth.IsAPITokenSession(session), "a custom provider keeps API tokens working")
+	require.Equal(t, "alice", session.Principal().User.Subject)
+}
@@ -14,9 +14,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0/crud"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
 )
 
 func TestDeploymentControllerConfigMapsRetentionSettings(t *testing.T) {
@@ -42,12 +46,121 @@ func TestDeploymentControllerConfigMapsRetentionSettings(t *testing.T) {
 func TestBuildStoresAddsExtraStoreTables(t *testing.T) {
 	stores := buildStores(nil, map[string]string{
 		"ExtensionOnly": "extension_only",
-	}, nil, nil)
+	}, nil, nil, nil)
 	if stores["ExtensionOnly"] == nil {
 		t.Fatalf("extra v1alpha1 store was not registered")
 	}
 }
 
+type staticRBACSource auth.RBACObjects
+
+func (s staticRBACSource) LoadRBAC(context.Context) (auth.RBACObjects, error) {
+	return auth.RBACObjects(s), nil
+}
+
+func TestWithRBACHooksRunsRBACFirst(t *testing.T) {
+	provider := auth.NewRBACAuthzProvider(staticRBACSource{}, []string{"root"})
+	var downstream []string
+	hooks := withRBACHooks(crud.PerKindHooks{
+		Authorizers: map[string]func(ctx context.Context, in resource.AuthorizeInput) error{
+			v1alpha1.KindAgent: func(_ context.Context, in resource.AuthorizeInput) error {
+				downstream = append(downstream, in.Verb)
+				return nil
+			},
+		},
+		ListFilters: map[string]func(ctx context.Context, in resource.AuthorizeInput) (string, []any, error){
+			v1alpha1.KindAgent: func(context.Context, resource.AuthorizeInput) (string, []any, error) {
+				return "name <> $1", []any{"hidden"}, nil
+			},
+		},
+	}, provider, map[string]*v1alpha1store.Store{v1alpha1.KindAgent: nil, v1alpha1.KindSkill: nil})
+
+	anonymous := auth.WithPublicContext(context.Background())
+	err := hooks.Authorizers[v1alpha1.KindAgent](anonymous, resource.AuthorizeInput{Verb: "get", Kind: v1alpha1.KindAgent, Namespace: "default", Name: "a"})
+	require.ErrorIs(t, err, auth.ErrUnauthenticated)
+	require.Empty(t, downstream, "a denied request never reaches downstream hooks")
+	require.Error(t, hooks.Authorizers[v1alpha1.KindSkill](anonymous, resource.AuthorizeInput{Verb: "list", Kind: v1alpha1.KindSkill}))
+
+	where, args, err := hooks.ListFilters[v1alpha1.KindAgent](anonymous, resource.AuthorizeInput{Verb: "list", Kind: v1alpha1.KindAgent})
+	require.NoError(t, err)
+	require.Equal(t, "(name <> $1) AND FALSE", where)
+	require.Equal(t, []any{"hidden"}, args)
+
+	admin := auth.AuthSessionTo(context.Background(), rbacTestSession{subject: "root"})
+	require.NoError(t, hooks.Authorizers[v1alpha1.KindAgent](admin, resource.AuthorizeInput{Verb: "get", Kind: v1alpha1.KindAgent, Namespace: "default", Name: "a"}))
+	require.Equal(t, []string{"get"}, downstream)
+}
+
+type rbacTestSession struct{ subject string }
+
+func (s rbacTestSession) Principal() auth.Principal {
+	return auth.Principal{User: auth.User{Subject: s.subject}}
+}
+
+func TestWithAPITokenHooksNarrowsAdmins(t *testing.T) {
+	provider := auth.NewRBACAuthzProvider(staticRBACSource{}, []string{"root"})
+	stores := map[string]*v1alpha1store.Store{v1alpha1.KindAgent: nil}
+	hooks := withAPITokenHooks(withRBACHooks(crud.PerKindHooks{}, provider, stores), stores)
+
+	token := auth.AuthSessionTo(context.Background(), &auth.APITokenSession{Subject: "root", TokenScopes: []auth.Permission{
+		{Action: auth.PermissionActionRead, ResourcePattern: "team-a/*"},
+	}})
+	require.NoError(t, hooks.Authorizers[v1alpha1.KindAgent](token, resource.AuthorizeInput{Verb: "get", Kind: v1alpha1.KindAgent, Namespace: "team-a", Name: "a"}))
+	err := hooks.Authorizers[v1alpha1.KindAgent](token, resource.AuthorizeInput{Verb: "get", Kind: v1alpha1.KindAgent, Namespace: "team-b", Name: "a"})
+	require.ErrorIs(t, err, auth.ErrForbidden, "an admin's token is limited to its scopes")
+
+	where, args, err := hooks.ListFilters[v1alpha1.KindAgent](token, resource.AuthorizeInput{Verb: "list", Kind: v1alpha1.KindAgent})
+	require.NoError(t, err)
+	require.Equal(t, "((namespace = ANY($1::text[])))", where)
+	require.Equal(t, []any{[]string{"team-a"}}, args)
+}
+
+func TestResolveAuthn(t *testing.T) {
+	require.Nil(t, resolveAuthn(nil, nil, nil), "no built-in provider leaves authn off")
+
+	var seen []string
+	builtin := authnFunc(func(headers func(string) string) (auth.Session, error) {
+		seen = append(seen, headers("Authorization"))
+		return nil, nil
+	})
+	authn := resolveAuthn(nil, []auth.AuthnProvider{builtin}, nil)
+	require.NotNil(t, authn)
+	session, err := authn.Authenticate(context.Background(), func(string) string { return "Bearer eyJ.jwt" }, url.Values{})
+	require.NoError(t, err)
+	require.Nil(t, session)
+	require.Equal(t, []string{"Bearer eyJ.jwt"}, seen, "non-token bearers reach the built-in providers")
+}
+
+func TestResolveAuthn_CustomProviderKeepsAPITokens(t *testing.T) {
+	var seen []string
+	custom := authnFunc(func(headers func(string) string) (auth.Session, error) {
+		seen = append(seen, headers("Authorization"))
+		return nil, nil
+	})
+	builtin := authnFunc(func(func(string) string) (auth.Session, error) {
+		t.Error("a custom provider replaces the built-in ones")
+		return nil, nil
+	})
+	authn := resolveAuthn(custom, []auth.AuthnProvider{builtin}, nil)
+	require.NotNil(t, authn)
+
+	_, err := authn.Authenticate(context.Background(), func(string) string { return "Bearer eyJ.jwt" }, url.Values{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Bearer eyJ.jwt"}, seen, "non-token bearers reach the custom provider")
+
+	// The token authenticator runs ahead of the custom provider. With no
+	// store behind it here, an API token fails rather than passing
+	// through unauthenticated.
+	_, err = authn.Authenticate(context.Background(), func(string) string { return "Bearer " + auth.APITokenPrefix + "x" }, url.Values{})
+	require.Error(t, err, "API tokens are checked with a custom provider")
+}
+
+type authnFunc func(headers func(string) string) (auth.Session, error)
+
+func (f authnFunc) Authenticate(_ context.Context, headers func(string) string, _ url.Values) (auth.Session, error) {
+	return f(headers)
+}
+
 func TestResolveExtraStoreSchema(t *testing.T) {
 	oss := pkgdb.MustNewSchema(pkgdb.OSSSchema)
 	tests := []struct {
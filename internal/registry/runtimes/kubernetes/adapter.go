@@ -147,6 +147,7 @@ func (a *kubernetesDeploymentAdapter) buildDesiredStateFromV1Alpha1(
 			EnvValues:    envValues,
 			ArgValues:    argValues,
 			HeaderValues: headerValues,
+			ImageDigest:  utils.VerifiedImageDigest(target),
 		})
 		if err != nil {
 			return nil, err
@@ -157,19 +158,31 @@ func (a *kubernetesDeploymentAdapter) buildDesiredStateFromV1Alpha1(
 		if in.Runtime != nil {
 			telemetryEndpoint = in.Runtime.Spec.TelemetryEndpoint
 		}
-		model, err := utils.ResolveDeploymentModelSpec(ctx, in.Deployment, in.Getter)
+		model, err := utils.ResolveDeploymentModel(ctx, in.Deployment, in.Getter)
 		if err != nil {
 			return nil, err
 		}
+		var (
+			modelSpec      *v1alpha1.ModelSpec
+			modelNamespace string
+		)
+		if model != nil {
+			modelSpec = &model.Spec
+			modelNamespace = model.Metadata.NamespaceOrDefault()
+		}
 		agent, servers, err := utils.SpecToRuntimeAgent(ctx, target.Metadata, target.Spec, utils.AgentTranslateOpts{
-			DeploymentID:      deploymentID,
-			Namespace:         namespace,
-			KagentURL:         "http://kagent-controller.kagent.svc.cluster.local",
-			DeploymentEnv:     envValues,
-			TelemetryEndpoint: telemetryEndpoint,
-			HeaderValues:      headerValues,
-			Model:             model,
-			Getter:            in.Getter,
+			DeploymentID:        deploymentID,
+			Namespace:           namespace,
+			KagentURL:           "http://kagent-controller.kagent.svc.cluster.local",
+			DeploymentEnv:       envValues,
+			TelemetryEndpoint:   telemetryEndpoint,
+			HeaderValues:        headerValues,
+			Model:               modelSpec,
+			ModelNamespace:      modelNamespace,
+			DeploymentNamespace: in.Deployment.Metadata.NamespaceOrDefault(),
+			Getter:              in.Getter,
+			Secrets:             in.Secrets,
+			PromptArguments:     in.Deployment.Spec.PromptArguments,
 		})
 		if err != nil {
 			return nil, err
@@ -140,6 +140,66 @@ func TestBuildDesiredState_AgentUsesResolvedModel(t *testing.T) {
 	}
 }
 
+func TestBuildDesiredState_AgentResolvesModelSecretRef(t *testing.T) {
+	adapter := NewKubernetesDeploymentAdapter()
+	deployment := &v1alpha1.Deployment{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "team-a", Name: "assistant-kube"},
+		Spec: v1alpha1.DeploymentSpec{
+			ModelRef: &v1alpha1.ModelRef{Name: "openai"},
+			Env:      map[string]string{"MODEL_API_KEY": "spoofed"},
+		},
+	}
+	target := &v1alpha1.Agent{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "team-a", Name: "assistant", Tag: "stable"},
+		Spec:     v1alpha1.AgentSpec{Source: &v1alpha1.AgentSource{Image: "ghcr.io/example/assistant:v1"}},
+	}
+	modelNamespace := "team-a"
+	var gotNamespace string
+	input := adapterpkgtypes.ApplyInput{
+		Deployment: deployment,
+		Target:     target,
+		Getter: func(_ context.Context, ref v1alpha1.ResourceRef) (v1alpha1.Object, error) {
+			return &v1alpha1.Model{
+				Metadata: v1alpha1.ObjectMeta{Namespace: modelNamespace, Name: "openai"},
+				Spec: v1alpha1.ModelSpec{
+					Provider: v1alpha1.ModelProviderBedrock,
+					Model:    "us.anthropic.claude-sonnet-4-6",
+					Auth: &v1alpha1.ModelAuthConfig{
+						Strategy:  v1alpha1.ModelAuthStrategySecretRef,
+						SecretRef: &v1alpha1.SecretKeyRef{Name: "provider-key"},
+					},
+				},
+			}, nil
+		},
+		Secrets: func(_ context.Context, namespace string, ref v1alpha1.SecretKeyRef) (string, error) {
+			gotNamespace = namespace
+			return "sk-live", nil
+		},
+	}
+	desired, err := adapter.buildDesiredStateFromV1Alpha1(t.Context(), input, "kagent")
+	if err != nil {
+		t.Fatalf("buildDesiredStateFromV1Alpha1: %v", err)
+	}
+	if gotNamespace != "team-a" {
+		t.Fatalf("secret namespace = %q, want the Model's namespace", gotNamespace)
+	}
+	agent := desired.Agents[0]
+	if _, ok := agent.Deployment.Env["MODEL_API_KEY"]; ok {
+		t.Fatalf("plain env carries MODEL_API_KEY: %+v", agent.Deployment.Env)
+	}
+	if agent.SecretEnv["MODEL_API_KEY"] != "sk-live" {
+		t.Fatalf("secret env = %+v", agent.SecretEnv)
+	}
+
+	modelNamespace, gotNamespace = "models", ""
+	if _, err := adapter.buildDesiredStateFromV1Alpha1(t.Context(), input, "kagent"); err == nil {
+		t.Fatal("a Deployment resolved the Secrets of a Model in another namespace")
+	}
+	if gotNamespace != "" {
+		t.Fatalf("secret resolved from namespace %q", gotNamespace)
+	}
+}
+
 func TestK8sV1Alpha1Remove_DeletesResourcesByDeploymentID(t *testing.T) {
 	// Seed the fake client with an Agent + MCPServer labeled for our deployment.
 	deploymentID := "weather-kube"
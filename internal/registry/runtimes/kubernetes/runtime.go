@@ -171,7 +171,7 @@ func kubernetesRuntimeNamespace(runtime *v1alpha1.Runtime) string {
 }
 
 func kubernetesApplyRuntimeConfig(ctx context.Context, runtime *v1alpha1.Runtime, cfg *runtimetypes.KubernetesRuntimeConfig, verbose bool) error {
-	if cfg == nil || (len(cfg.Agents) == 0 && len(cfg.RemoteMCPServers) == 0 && len(cfg.MCPServers) == 0 && len(cfg.ConfigMaps) == 0) {
+	if cfg == nil || (len(cfg.Agents) == 0 && len(cfg.RemoteMCPServers) == 0 && len(cfg.MCPServers) == 0 && len(cfg.ConfigMaps) == 0 && len(cfg.Secrets) == 0) {
 		return nil
 	}
 	c, err := kubernetesGetClient(runtime)
@@ -179,6 +179,12 @@ func kubernetesApplyRuntimeConfig(ctx context.Context, runtime *v1alpha1.Runtime
 		return err
 	}
 
+	for _, secret := range cfg.Secrets {
+		kubernetesEnsureNamespace(secret)
+		if err := kubernetesApplyResource(ctx, c, secret, verbose); err != nil {
+			return fmt.Errorf("Secret %s: %w", secret.Name, err)
+		}
+	}
 	for _, configMap := range cfg.ConfigMaps {
 		kubernetesEnsureNamespace(configMap)
 		if err := kubernetesApplyResource(ctx, c, configMap, verbose); err != nil {
@@ -229,6 +235,7 @@ func kubernetesTranslateRuntimeConfig(ctx context.Context, desired *runtimetypes
 
 	agents := make([]*v1alpha2.Agent, 0, len(desired.Agents))
 	configMaps := make([]*corev1.ConfigMap, 0)
+	secrets := make([]*corev1.Secret, 0)
 	for _, agent := range desired.Agents {
 		resource, err := kubernetesTranslateAgent(agent)
 		if err != nil {
@@ -236,6 +243,10 @@ func kubernetesTranslateRuntimeConfig(ctx context.Context, desired *runtimetypes
 		}
 		agents = append(agents, resource)
 
+		if len(agent.SecretEnv) > 0 {
+			secrets = append(secrets, kubernetesTranslateAgentSecret(agent))
+		}
+
 		// MCP server config is injected via MCP_SERVERS_CONFIG env var (set by ResolveAgent).
 		// ConfigMap is only needed for prompts.
 		if len(agent.ResolvedPrompts) > 0 {
@@ -277,6 +288,7 @@ func kubernetesTranslateRuntimeConfig(ctx context.Context, desired *runtimetypes
 		RemoteMCPServers: remoteMCPs,
 		MCPServers:       mcpServers,
 		ConfigMaps:       configMaps,
+		Secrets:          secrets,
 	}, nil
 }
 
@@ -298,6 +310,21 @@ func kubernetesTranslateAgent(agent *runtimetypes.Agent) (*v1alpha2.Agent, error
 			envVars = append(envVars, corev1.EnvVar{Name: key, Value: agent.Deployment.Env[key]})
 		}
 	}
+	// Secret-sourced values never appear inline on the Agent CR; they are
+	// projected from the deployment-scoped Secret built alongside it.
+	if len(agent.SecretEnv) > 0 {
+		secretName := kubernetesAgentSecretName(agent.Name, agent.Tag, agent.DeploymentID)
+		keys := slices.Sorted(maps.Keys(agent.SecretEnv))
+		for _, key := range keys {
+			envVars = append(envVars, corev1.EnvVar{
+				Name: key,
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					Key:                  key,
+				}},
+			})
+		}
+	}
 
 	sharedSpec := v1alpha2.SharedDeploymentSpec{Env: envVars}
 	// MCP server config is now injected via MCP_SERVERS_CONFIG env var (set by ResolveAgent).
@@ -531,6 +558,41 @@ func kubernetesTranslateAgentConfigMap(agent *runtimetypes.Agent) (*corev1.Confi
 	}, nil
 }
 
+func kubernetesTranslateAgentSecret(agent *runtimetypes.Agent) *corev1.Secret {
+	// Data rather than StringData: server-side apply owns data fields, and
+	// stringData is write-only, so it would never converge.
+	data := make(map[string][]byte, len(agent.SecretEnv))
+	for key, value := range agent.SecretEnv {
+		data[key] = []byte(value)
+	}
+	labels := map[string]string{
+		"app.kubernetes.io/managed-by": "agentregistry",
+		"app.kubernetes.io/component":  "agent-secret",
+		"agentregistry.dev/agent":      sanitizeKubernetesName(agent.Name),
+	}
+	maps.Copy(labels, kubernetesDeploymentManagedLabels(agent.DeploymentID))
+
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        kubernetesAgentSecretName(agent.Name, agent.Tag, agent.DeploymentID),
+			Namespace:   agent.Deployment.Env[constants.EnvKagentNamespace],
+			Labels:      labels,
+			Annotations: kubernetesDeploymentManagedAnnotations(agent.DeploymentID),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+}
+
+func kubernetesAgentSecretName(name, version, deploymentID string) string {
+	base := fmt.Sprintf("%s-agent-secret", name)
+	if version != "" {
+		base = fmt.Sprintf("%s-%s-agent-secret", name, version)
+	}
+	return kubernetesDeploymentScopedName(base, deploymentID)
+}
+
 func kubernetesAgentConfigMapName(name, version, deploymentID string) string {
 	base := fmt.Sprintf("%s-agent-config", name)
 	if version != "" {
@@ -713,6 +775,16 @@ func kubernetesDeleteAgentResourcesByDeploymentID(ctx context.Context, c client.
 		}
 	}
 
+	secretList := &corev1.SecretList{}
+	if err := c.List(ctx, secretList, opts...); err != nil {
+		return fmt.Errorf("failed to list secrets by deployment id %s: %w", deploymentID, err)
+	}
+	for i := range secretList.Items {
+		if err := kubernetesDeleteResource(ctx, c, &secretList.Items[i]); err != nil {
+			return fmt.Errorf("failed to delete secret %s: %w", secretList.Items[i].Name, err)
+		}
+	}
+
 	remoteMCPList := &v1alpha2.RemoteMCPServerList{}
 	if err := c.List(ctx, remoteMCPList, opts...); err != nil {
 		return fmt.Errorf("failed to list remote mcp servers by deployment id %s: %w", deploymentID, err)
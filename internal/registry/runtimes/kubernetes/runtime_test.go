@@ -51,6 +51,51 @@ func TestKubernetesTranslateRuntimeConfig_AgentOnly(t *testing.T) {
 	}
 }
 
+func TestKubernetesTranslateRuntimeConfig_AgentSecretEnvProjectsSecret(t *testing.T) {
+	desired := &runtimetypes.DesiredState{
+		Agents: []*runtimetypes.Agent{{
+			Name:         "test-agent",
+			Tag:          "v1",
+			DeploymentID: "deploy-1",
+			Deployment: runtimetypes.AgentDeployment{
+				Image: "agent-image:latest",
+				Env:   map[string]string{"KAGENT_NAMESPACE": "agents"},
+			},
+			SecretEnv: map[string]string{"MODEL_API_KEY": "sk-live"},
+		}},
+	}
+
+	config, err := kubernetesTranslateRuntimeConfig(context.Background(), desired)
+	if err != nil {
+		t.Fatalf("kubernetesTranslateRuntimeConfig failed: %v", err)
+	}
+	if len(config.Secrets) != 1 {
+		t.Fatalf("expected 1 Secret, got %d", len(config.Secrets))
+	}
+	secret := config.Secrets[0]
+	if secret.Namespace != "agents" || string(secret.Data["MODEL_API_KEY"]) != "sk-live" {
+		t.Fatalf("secret = %+v", secret)
+	}
+	if secret.Labels[kubernetesDeploymentIDLabelKey] != "deploy-1" {
+		t.Fatalf("secret labels = %+v, want deployment id label", secret.Labels)
+	}
+
+	var found bool
+	for _, env := range config.Agents[0].Spec.BYO.Deployment.Env {
+		if env.Name != "MODEL_API_KEY" {
+			continue
+		}
+		found = true
+		if env.Value != "" || env.ValueFrom == nil || env.ValueFrom.SecretKeyRef == nil ||
+			env.ValueFrom.SecretKeyRef.Name != secret.Name || env.ValueFrom.SecretKeyRef.Key != "MODEL_API_KEY" {
+			t.Fatalf("MODEL_API_KEY env = %+v, want a SecretKeyRef to %s", env, secret.Name)
+		}
+	}
+	if !found {
+		t.Fatal("agent env is missing MODEL_API_KEY")
+	}
+}
+
 func TestKubernetesTranslateRuntimeConfig_RemoteMCP(t *testing.T) {
 	ctx := context.Background()
 
@@ -48,9 +48,14 @@ func (a *localDeploymentAdapter) mergeAndApplyLocalRuntime(
 
 	mergeAgentGatewayConfig(gatewayCfg, config.AgentGateway, targetNames, routeNames, remove, a.agentGatewayPort)
 
+	var agentFiles map[string][]byte
+	if !remove {
+		agentFiles = config.AgentFiles
+	}
 	if err := WriteLocalRuntimeFiles(a.runtimeDir, &runtimetypes.LocalRuntimeConfig{
 		DockerCompose: composeCfg,
 		AgentGateway:  gatewayCfg,
+		AgentFiles:    agentFiles,
 	}, a.agentGatewayPort); err != nil {
 		return err
 	}
@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -15,15 +18,18 @@ import (
 	composetypes "github.com/compose-spec/compose-go/v2/types"
 	"go.yaml.in/yaml/v3"
 
+	"github.com/agentregistry-dev/agentregistry/internal/constants"
 	runtimetypes "github.com/agentregistry-dev/agentregistry/internal/registry/runtimes/types"
 	runtimeutils "github.com/agentregistry-dev/agentregistry/internal/registry/runtimes/utils"
 	"github.com/agentregistry-dev/agentregistry/internal/version"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 )
 
 const (
 	localMCPRouteName         = "mcp_route"
 	localComposeFileName      = "docker-compose.yaml"
 	localAgentGatewayFileName = "agent-gateway.yaml"
+	localAgentPromptsFileName = "prompts.json"
 	defaultLocalProjectName   = "agentregistry_runtime"
 	localOCIServerPort        = 3000
 )
@@ -68,13 +74,24 @@ func BuildLocalRuntimeConfig(
 		dockerComposeServices[serviceName] = *serviceConfig
 	}
 
+	agentFiles := map[string][]byte{}
 	for _, agent := range desired.Agents {
 		serviceName := localAgentServiceName(agent)
 		if _, exists := dockerComposeServices[serviceName]; exists {
 			return nil, fmt.Errorf("duplicate Agent name found: %s", agent.Name)
 		}
 
-		serviceConfig, err := translateLocalAgentToServiceConfig(runtimeDir, agent)
+		promptsPath := filepath.Join(localAgentConfigSubdir(agent), localAgentPromptsFileName)
+		agentFiles[promptsPath] = nil
+		if len(agent.ResolvedPrompts) > 0 {
+			promptsJSON, err := json.MarshalIndent(agent.ResolvedPrompts, "", "  ")
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal prompts for Agent %s: %w", agent.Name, err)
+			}
+			agentFiles[promptsPath] = promptsJSON
+		}
+
+		serviceConfig, err := translateLocalAgentToServiceConfig(runtimeDir, agentGatewayPort, agent)
 		if err != nil {
 			return nil, fmt.Errorf("failed to translate Agent %s to service config: %w", agent.Name, err)
 		}
@@ -95,6 +112,7 @@ func BuildLocalRuntimeConfig(
 	return &runtimetypes.LocalRuntimeConfig{
 		DockerCompose: dockerCompose,
 		AgentGateway:  gatewayConfig,
+		AgentFiles:    agentFiles,
 	}, nil
 }
 
@@ -108,6 +126,25 @@ func WriteLocalRuntimeFiles(runtimeDir string, cfg *runtimetypes.LocalRuntimeCon
 	if err := writeLocalAgentGatewayConfig(runtimeDir, cfg.AgentGateway, port); err != nil {
 		return err
 	}
+	return writeLocalAgentFiles(runtimeDir, cfg.AgentFiles)
+}
+
+func writeLocalAgentFiles(runtimeDir string, files map[string][]byte) error {
+	for rel, content := range files {
+		path := filepath.Join(runtimeDir, rel)
+		if content == nil {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("remove agent config %s: %w", rel, err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("create agent config directory: %w", err)
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return fmt.Errorf("write agent config %s: %w", rel, err)
+		}
+	}
 	return nil
 }
 
@@ -343,7 +380,7 @@ func translateLocalMCPServerToServiceConfig(server *runtimetypes.MCPServer) (*co
 	}, nil
 }
 
-func translateLocalAgentToServiceConfig(runtimeDir string, agent *runtimetypes.Agent) (*composetypes.ServiceConfig, error) {
+func translateLocalAgentToServiceConfig(runtimeDir string, agentGatewayPort uint16, agent *runtimetypes.Agent) (*composetypes.ServiceConfig, error) {
 	image := agent.Deployment.Image
 	if image == "" {
 		return nil, fmt.Errorf("image must be specified for Agent %s", agent.Name)
@@ -353,6 +390,15 @@ func translateLocalAgentToServiceConfig(runtimeDir string, agent *runtimetypes.A
 	for k, v := range agent.Deployment.Env {
 		envValues = append(envValues, fmt.Sprintf("%s=%s", k, v))
 	}
+	if agent.Model != nil {
+		envValues = append(envValues, fmt.Sprintf("%s=http://agent_gateway:%d%s",
+			constants.EnvModelGatewayURL, agentGatewayPort, localAgentLLMPathPrefix(agent)))
+	}
+	// The local daemon has no secret store of its own; resolved Secret
+	// values go into the container env alongside the plain overrides.
+	for k, v := range agent.SecretEnv {
+		envValues = append(envValues, fmt.Sprintf("%s=%s", k, v))
+	}
 	slices.SortStableFunc(envValues, func(a, b string) int { return cmp.Compare(a, b) })
 
 	port := agent.Deployment.Port
@@ -360,13 +406,7 @@ func translateLocalAgentToServiceConfig(runtimeDir string, agent *runtimetypes.A
 		port = runtimeutils.DefaultLocalAgentPort
 	}
 
-	var agentConfigDir string
-	if agent.Tag != "" {
-		sanitizedTag := sanitizeVersion(agent.Tag)
-		agentConfigDir = filepath.Join(runtimeDir, agent.Name, sanitizedTag)
-	} else {
-		agentConfigDir = filepath.Join(runtimeDir, agent.Name)
-	}
+	agentConfigDir := filepath.Join(runtimeDir, localAgentConfigSubdir(agent))
 
 	return &composetypes.ServiceConfig{
 		Name:        localAgentServiceName(agent),
@@ -385,6 +425,15 @@ func translateLocalAgentToServiceConfig(runtimeDir string, agent *runtimetypes.A
 	}, nil
 }
 
+// localAgentConfigSubdir is the agent's /config mount, relative to the
+// runtime directory.
+func localAgentConfigSubdir(agent *runtimetypes.Agent) string {
+	if agent.Tag != "" {
+		return filepath.Join(agent.Name, sanitizeVersion(agent.Tag))
+	}
+	return agent.Name
+}
+
 func sanitizeVersion(version string) string {
 	if version == "" {
 		return ""
@@ -472,6 +521,14 @@ func translateLocalAgentGatewayConfig(agentGatewayPort uint16, servers []*runtim
 			},
 		}
 		agentRoutes = append(agentRoutes, route)
+
+		llmRoute, err := translateLocalAgentLLMRoute(agent)
+		if err != nil {
+			return nil, fmt.Errorf("agent %s: %w", agent.Name, err)
+		}
+		if llmRoute != nil {
+			agentRoutes = append(agentRoutes, *llmRoute)
+		}
 	}
 
 	slices.SortStableFunc(agentRoutes, func(a, b runtimetypes.LocalRoute) int {
@@ -513,6 +570,139 @@ func translateLocalAgentGatewayConfig(agentGatewayPort uint16, servers []*runtim
 	}, nil
 }
 
+// localAgentLLMPathPrefix is the gateway path an agent's Model is served on.
+func localAgentLLMPathPrefix(agent *runtimetypes.Agent) string {
+	return fmt.Sprintf("/llm/%s", localAgentServiceName(agent))
+}
+
+// translateLocalAgentLLMRoute builds the agentgateway route that fronts the
+// agent's Model with an AI backend. Returns nil when the agent has no Model.
+func translateLocalAgentLLMRoute(agent *runtimetypes.Agent) (*runtimetypes.LocalRoute, error) {
+	if agent == nil || agent.Model == nil {
+		return nil, nil
+	}
+	serviceName := localAgentServiceName(agent)
+	backend, err := translateModelAIBackend(serviceName, agent.Model)
+	if err != nil {
+		return nil, err
+	}
+	policies := &runtimetypes.FilterOrPolicy{
+		BackendAuth: translateModelBackendAuth(agent.Model, agent.SecretEnv),
+	}
+	if ep := agent.Model.Endpoint; ep != nil && ep.TLS != nil && ep.TLS.DisableVerify {
+		policies.BackendTLS = &runtimetypes.BackendTLS{Insecure: true}
+	}
+	return &runtimetypes.LocalRoute{
+		RouteName: fmt.Sprintf("%s_llm_route", serviceName),
+		Matches: []runtimetypes.RouteMatch{{
+			Path: runtimetypes.PathMatch{PathPrefix: localAgentLLMPathPrefix(agent)},
+		}},
+		Backends: []runtimetypes.RouteBackend{{
+			Weight: 100,
+			AI:     backend,
+		}},
+		Policies: policies,
+	}, nil
+}
+
+// translateModelAIBackend maps a Model onto agentgateway's provider config.
+// endpoint.baseUrl becomes a host override (plus a path override when the
+// URL carries a path prefix, as OpenAI-compatible servers usually do).
+func translateModelAIBackend(name string, model *v1alpha1.ModelSpec) (*runtimetypes.AIBackend, error) {
+	backend := &runtimetypes.AIBackend{Name: name}
+	var endpoint v1alpha1.ModelEndpointConfig
+	if model.Endpoint != nil {
+		endpoint = *model.Endpoint
+	}
+	var base *url.URL
+	if endpoint.BaseURL != "" {
+		u, err := url.Parse(endpoint.BaseURL)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("model endpoint.baseUrl %q is not an absolute URL", endpoint.BaseURL)
+		}
+		base = u
+		backend.HostOverride = hostPort(u)
+	}
+
+	switch model.Provider {
+	case v1alpha1.ModelProviderOpenAI, v1alpha1.ModelProviderOpenAICompatible:
+		backend.Provider.OpenAI = &runtimetypes.AIOpenAIProvider{Model: model.Model}
+		if base != nil {
+			if prefix := strings.TrimSuffix(base.Path, "/"); prefix != "" {
+				backend.PathOverride = prefix + "/chat/completions"
+			}
+		}
+	case v1alpha1.ModelProviderAnthropic:
+		backend.Provider.Anthropic = &runtimetypes.AIAnthropicProvider{Model: model.Model}
+	case v1alpha1.ModelProviderAzureOpenAI:
+		if base == nil {
+			return nil, fmt.Errorf("model provider %q requires endpoint.baseUrl", model.Provider)
+		}
+		backend.Provider.AzureOpenAI = &runtimetypes.AIAzureOpenAIProvider{
+			Host:       base.Hostname(),
+			Model:      endpoint.Deployment,
+			APIVersion: endpoint.APIVersion,
+		}
+		// The Azure provider derives its own host; an override would be
+		// redundant.
+		backend.HostOverride = ""
+	case v1alpha1.ModelProviderVertex:
+		backend.Provider.Vertex = &runtimetypes.AIVertexProvider{
+			ProjectID: endpoint.Project,
+			Region:    endpoint.Region,
+			Model:     model.Model,
+		}
+	case v1alpha1.ModelProviderBedrock:
+		backend.Provider.Bedrock = &runtimetypes.AIBedrockProvider{
+			Region: endpoint.Region,
+			Model:  model.Model,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported model provider %q", model.Provider)
+	}
+	return backend, nil
+}
+
+// translateModelBackendAuth picks the gateway's upstream credential for the
+// Model's auth strategy. A secretRef key is written into the gateway config,
+// which lives in the same runtime directory as the compose file that
+// already carries it in the agent env.
+func translateModelBackendAuth(model *v1alpha1.ModelSpec, secretEnv map[string]string) *runtimetypes.BackendAuth {
+	strategy := ""
+	if model.Auth != nil {
+		strategy = model.Auth.Strategy
+	}
+	info := v1alpha1.KnownModelProviders[model.Provider]
+	if strategy == "" && info.AmbientIdentity {
+		strategy = v1alpha1.ModelAuthStrategyRuntime
+	}
+	switch strategy {
+	case v1alpha1.ModelAuthStrategySecretRef:
+		return &runtimetypes.BackendAuth{Key: secretEnv[constants.EnvModelAPIKey]}
+	case v1alpha1.ModelAuthStrategyPassthrough:
+		return &runtimetypes.BackendAuth{Passthrough: &struct{}{}}
+	case v1alpha1.ModelAuthStrategyRuntime:
+		switch model.Provider {
+		case v1alpha1.ModelProviderBedrock:
+			return &runtimetypes.BackendAuth{AWS: &struct{}{}}
+		case v1alpha1.ModelProviderVertex:
+			return &runtimetypes.BackendAuth{GCP: &struct{}{}}
+		}
+	}
+	return nil
+}
+
+func hostPort(u *url.URL) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	port := "443"
+	if u.Scheme == "http" {
+		port = "80"
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
 func defaultAgentPort(agent *runtimetypes.Agent) uint16 {
 	if agent == nil || agent.Deployment.Port == 0 {
 		return runtimeutils.DefaultLocalAgentPort
@@ -2,10 +2,13 @@ package local
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"testing"
 
 	runtimetypes "github.com/agentregistry-dev/agentregistry/internal/registry/runtimes/types"
 	runtimeutils "github.com/agentregistry-dev/agentregistry/internal/registry/runtimes/utils"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 )
 
 func TestBuildLocalRuntimeConfig_UsesDefaultAgentPortInGatewayRoute(t *testing.T) {
@@ -49,3 +52,153 @@ func TestDefaultAgentPort(t *testing.T) {
 		t.Fatalf("defaultAgentPort(custom) = %d, want 9090", got)
 	}
 }
+
+func TestBuildLocalRuntimeConfig_AgentModelAddsLLMRoute(t *testing.T) {
+	cfg, err := BuildLocalRuntimeConfig(context.Background(), "/tmp/test-runtime", 8081, "test-project", &runtimetypes.DesiredState{
+		Agents: []*runtimetypes.Agent{{
+			Name:       "demo-agent",
+			Deployment: runtimetypes.AgentDeployment{Image: "demo-agent:latest"},
+			Model: &v1alpha1.ModelSpec{
+				Provider: v1alpha1.ModelProviderOpenAICompatible,
+				Model:    "qwen2.5:7b",
+				Auth:     &v1alpha1.ModelAuthConfig{Strategy: v1alpha1.ModelAuthStrategySecretRef, SecretRef: &v1alpha1.SecretKeyRef{Name: "vllm"}},
+				Endpoint: &v1alpha1.ModelEndpointConfig{BaseURL: "http://vllm:8000/v1"},
+			},
+			SecretEnv: map[string]string{"MODEL_API_KEY": "sk-test"},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("BuildLocalRuntimeConfig() unexpected error: %v", err)
+	}
+
+	routes := cfg.AgentGateway.Binds[0].Listeners[0].Routes
+	idx := slices.IndexFunc(routes, func(r runtimetypes.LocalRoute) bool { return r.RouteName == "demo-agent_llm_route" })
+	if idx < 0 {
+		t.Fatalf("expected demo-agent_llm_route, got %+v", routes)
+	}
+	route := routes[idx]
+	if got := route.Matches[0].Path.PathPrefix; got != "/llm/demo-agent" {
+		t.Fatalf("path prefix = %q, want /llm/demo-agent", got)
+	}
+	ai := route.Backends[0].AI
+	if ai == nil || ai.Provider.OpenAI == nil || ai.Provider.OpenAI.Model != "qwen2.5:7b" {
+		t.Fatalf("AI backend = %+v, want openAI provider for qwen2.5:7b", ai)
+	}
+	if ai.HostOverride != "vllm:8000" || ai.PathOverride != "/v1/chat/completions" {
+		t.Fatalf("overrides = %q %q, want vllm:8000 /v1/chat/completions", ai.HostOverride, ai.PathOverride)
+	}
+	if route.Policies == nil || route.Policies.BackendAuth == nil || route.Policies.BackendAuth.Key != "sk-test" {
+		t.Fatalf("backend auth = %+v, want resolved key", route.Policies)
+	}
+
+	env := cfg.DockerCompose.Services["demo-agent"].Environment
+	if got := env["MODEL_GATEWAY_URL"]; got == nil || *got != "http://agent_gateway:8081/llm/demo-agent" {
+		t.Fatalf("MODEL_GATEWAY_URL = %v", got)
+	}
+}
+
+func TestTranslateModelAIBackend_Providers(t *testing.T) {
+	tests := []struct {
+		name  string
+		model v1alpha1.ModelSpec
+		check func(t *testing.T, b *runtimetypes.AIBackend, auth *runtimetypes.BackendAuth)
+	}{
+		{
+			name:  "bedrock defaults to ambient aws auth",
+			model: v1alpha1.ModelSpec{Provider: v1alpha1.ModelProviderBedrock, Model: "us.anthropic.claude-opus-4-8", Endpoint: &v1alpha1.ModelEndpointConfig{Region: "us-west-2"}},
+			check: func(t *testing.T, b *runtimetypes.AIBackend, auth *runtimetypes.BackendAuth) {
+				if b.Provider.Bedrock == nil || b.Provider.Bedrock.Region != "us-west-2" {
+					t.Fatalf("bedrock provider = %+v", b.Provider.Bedrock)
+				}
+				if auth == nil || auth.AWS == nil {
+					t.Fatalf("auth = %+v, want aws", auth)
+				}
+			},
+		},
+		{
+			name: "azure-openai uses deployment and resource host",
+			model: v1alpha1.ModelSpec{
+				Provider: v1alpha1.ModelProviderAzureOpenAI, Model: "gpt-4o",
+				Auth:     &v1alpha1.ModelAuthConfig{Strategy: v1alpha1.ModelAuthStrategyPassthrough},
+				Endpoint: &v1alpha1.ModelEndpointConfig{BaseURL: "https://acme.openai.azure.com", Deployment: "gpt-4o-prod", APIVersion: "2024-10-21"},
+			},
+			check: func(t *testing.T, b *runtimetypes.AIBackend, auth *runtimetypes.BackendAuth) {
+				az := b.Provider.AzureOpenAI
+				if az == nil || az.Host != "acme.openai.azure.com" || az.Model != "gpt-4o-prod" || az.APIVersion != "2024-10-21" {
+					t.Fatalf("azure provider = %+v", az)
+				}
+				if b.HostOverride != "" {
+					t.Fatalf("host override = %q, want none", b.HostOverride)
+				}
+				if auth == nil || auth.Passthrough == nil {
+					t.Fatalf("auth = %+v, want passthrough", auth)
+				}
+			},
+		},
+		{
+			name: "vertex defaults to ambient gcp auth",
+			model: v1alpha1.ModelSpec{
+				Provider: v1alpha1.ModelProviderVertex, Model: "gemini-2.5-pro",
+				Endpoint: &v1alpha1.ModelEndpointConfig{Project: "acme-ml", Region: "us-central1"},
+			},
+			check: func(t *testing.T, b *runtimetypes.AIBackend, auth *runtimetypes.BackendAuth) {
+				v := b.Provider.Vertex
+				if v == nil || v.ProjectID != "acme-ml" || v.Region != "us-central1" {
+					t.Fatalf("vertex provider = %+v", v)
+				}
+				if auth == nil || auth.GCP == nil {
+					t.Fatalf("auth = %+v, want gcp", auth)
+				}
+			},
+		},
+		{
+			name: "anthropic with gateway override",
+			model: v1alpha1.ModelSpec{
+				Provider: v1alpha1.ModelProviderAnthropic, Model: "claude-opus-4-8",
+				Auth:     &v1alpha1.ModelAuthConfig{Strategy: v1alpha1.ModelAuthStrategyPassthrough},
+				Endpoint: &v1alpha1.ModelEndpointConfig{BaseURL: "https://llm.internal"},
+			},
+			check: func(t *testing.T, b *runtimetypes.AIBackend, _ *runtimetypes.BackendAuth) {
+				if b.Provider.Anthropic == nil || b.HostOverride != "llm.internal:443" {
+					t.Fatalf("anthropic backend = %+v", b)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, err := translateModelAIBackend("demo", &tt.model)
+			if err != nil {
+				t.Fatalf("translateModelAIBackend: %v", err)
+			}
+			tt.check(t, backend, translateModelBackendAuth(&tt.model, nil))
+		})
+	}
+}
+
+func TestBuildLocalRuntimeConfig_WritesRenderedPrompts(t *testing.T) {
+	cfg, err := BuildLocalRuntimeConfig(context.Background(), "/tmp/test-runtime", 8081, "test-project", &runtimetypes.DesiredState{
+		Agents: []*runtimetypes.Agent{
+			{
+				Name:            "with-prompt",
+				Tag:             "1.0.0",
+				Deployment:      runtimetypes.AgentDeployment{Image: "a:latest"},
+				ResolvedPrompts: []runtimetypes.ResolvedPrompt{{Name: "system", Content: "Be brief."}},
+			},
+			{
+				Name:       "without-prompt",
+				Deployment: runtimetypes.AgentDeployment{Image: "b:latest"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("BuildLocalRuntimeConfig() unexpected error: %v", err)
+	}
+	got, ok := cfg.AgentFiles["with-prompt/1.0.0/prompts.json"]
+	if !ok || !strings.Contains(string(got), `"content": "Be brief."`) {
+		t.Fatalf("prompts.json = %q (present=%v)", got, ok)
+	}
+	if stale, ok := cfg.AgentFiles["without-prompt/prompts.json"]; !ok || stale != nil {
+		t.Fatalf("without-prompt should clear prompts.json, got %q (present=%v)", stale, ok)
+	}
+}
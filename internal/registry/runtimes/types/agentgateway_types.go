@@ -202,7 +202,47 @@ type OpenAPITargetSpec struct {
 }
 
 type AIBackend struct {
-	Name string `json:"name" yaml:"name"`
+	Name     string     `json:"name" yaml:"name"`
+	Provider AIProvider `json:"provider" yaml:"provider"`
+	// HostOverride sends traffic to host:port instead of the provider's
+	// public endpoint (Azure resource, self-hosted server, private gateway).
+	HostOverride string `json:"hostOverride,omitempty" yaml:"hostOverride,omitempty"`
+	// PathOverride replaces the provider's default request path.
+	PathOverride string `json:"pathOverride,omitempty" yaml:"pathOverride,omitempty"`
+}
+
+// AIProvider selects exactly one provider family.
+type AIProvider struct {
+	OpenAI      *AIOpenAIProvider      `json:"openAI,omitempty" yaml:"openAI,omitempty"`
+	Anthropic   *AIAnthropicProvider   `json:"anthropic,omitempty" yaml:"anthropic,omitempty"`
+	AzureOpenAI *AIAzureOpenAIProvider `json:"azureOpenAI,omitempty" yaml:"azureOpenAI,omitempty"`
+	Vertex      *AIVertexProvider      `json:"vertex,omitempty" yaml:"vertex,omitempty"`
+	Bedrock     *AIBedrockProvider     `json:"bedrock,omitempty" yaml:"bedrock,omitempty"`
+}
+
+type AIOpenAIProvider struct {
+	Model string `json:"model,omitempty" yaml:"model,omitempty"`
+}
+
+type AIAnthropicProvider struct {
+	Model string `json:"model,omitempty" yaml:"model,omitempty"`
+}
+
+type AIAzureOpenAIProvider struct {
+	Host       string `json:"host" yaml:"host"`
+	Model      string `json:"model,omitempty" yaml:"model,omitempty"`
+	APIVersion string `json:"apiVersion,omitempty" yaml:"apiVersion,omitempty"`
+}
+
+type AIVertexProvider struct {
+	ProjectID string `json:"projectId" yaml:"projectId"`
+	Region    string `json:"region,omitempty" yaml:"region,omitempty"`
+	Model     string `json:"model,omitempty" yaml:"model,omitempty"`
+}
+
+type AIBedrockProvider struct {
+	Region string `json:"region" yaml:"region"`
+	Model  string `json:"model,omitempty" yaml:"model,omitempty"`
 }
 
 type RouteFilter struct {
@@ -278,9 +318,17 @@ type BackendTLS struct {
 	Root         string `json:"root,omitempty" yaml:"root,omitempty"`
 }
 
+// BackendAuth sets how agentgateway authenticates to the backend. Exactly
+// one field is set.
 type BackendAuth struct {
-	Type   string `json:"type" yaml:"type"`
-	Config any    `json:"config,omitempty" yaml:"config,omitempty"`
+	// Key is a static credential sent in the provider's auth header.
+	Key string `json:"key,omitempty" yaml:"key,omitempty"`
+	// Passthrough forwards the caller's bearer token.
+	Passthrough *struct{} `json:"passthrough,omitempty" yaml:"passthrough,omitempty"`
+	// AWS signs requests with the gateway's ambient AWS credentials.
+	AWS *struct{} `json:"aws,omitempty" yaml:"aws,omitempty"`
+	// GCP uses the gateway's ambient Google credentials.
+	GCP *struct{} `json:"gcp,omitempty" yaml:"gcp,omitempty"`
 }
 
 type TimeoutPolicy struct {
@@ -5,6 +5,8 @@ import (
 	v1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
 	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
 )
 
 type DesiredState struct {
@@ -20,6 +22,14 @@ type Agent struct {
 	ResolvedMCPServers []ResolvedMCPServerConfig `json:"resolvedMCPServers,omitempty"`
 	ResolvedPrompts    []ResolvedPrompt          `json:"resolvedPrompts,omitempty"`
 	Skills             []AgentSkillRef           `json:"skills,omitempty"`
+	// Model is the Model the Deployment resolved to, for runtimes that
+	// front provider traffic with agentgateway. Nil when the Deployment
+	// selects no Model.
+	Model *v1alpha1.ModelSpec `json:"model,omitempty"`
+	// SecretEnv holds env values resolved from registry Secrets. It is kept
+	// apart from Deployment.Env so runtimes can project it through their own
+	// secret mechanism, and is never serialized.
+	SecretEnv map[string]string `json:"-"`
 }
 
 type AgentSkillRef struct {
@@ -111,6 +121,7 @@ type KubernetesRuntimeConfig struct {
 	RemoteMCPServers []*v1alpha2.RemoteMCPServer `json:"remoteMCPServers"`
 	MCPServers       []*kmcpv1alpha1.MCPServer   `json:"mcpServers"`
 	ConfigMaps       []*corev1.ConfigMap         `json:"configMaps,omitempty"`
+	Secrets          []*corev1.Secret            `json:"-"`
 }
 
 type DockerComposeConfig = composetypes.Project
@@ -118,4 +129,8 @@ type DockerComposeConfig = composetypes.Project
 type LocalRuntimeConfig struct {
 	DockerCompose *DockerComposeConfig
 	AgentGateway  *AgentGatewayConfig
+	// AgentFiles are per-agent config files keyed by path relative to the
+	// runtime directory (mounted into the agent at /config). A nil value
+	// removes the file.
+	AgentFiles map[string][]byte
 }
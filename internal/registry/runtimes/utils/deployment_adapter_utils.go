@@ -16,6 +16,7 @@ import (
 
 	runtimetypes "github.com/agentregistry-dev/agentregistry/internal/registry/runtimes/types"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1/registries"
 	"github.com/agentregistry-dev/agentregistry/pkg/types"
 )
 
@@ -48,6 +49,10 @@ type MCPServerRunRequest struct {
 	// HeaderValues are per-deployment header overrides resolved against
 	// Spec.Remote.Headers when the server is remote. Ignored for bundled.
 	HeaderValues map[string]string
+	// ImageDigest, when set, pins an OCI package's image to the digest the
+	// registry verified, so a tag moved after apply is not what runs.
+	// Ignored for other package types.
+	ImageDigest string
 }
 
 // TranslateMCPServer maps a v1alpha1 MCPServerSpec onto the runtime-internal
@@ -64,7 +69,7 @@ func TranslateMCPServer(ctx context.Context, req *MCPServerRunRequest) (*runtime
 	if req.Spec.Source == nil || req.Spec.Source.Package == nil {
 		return nil, fmt.Errorf("no valid deployment method found for server: %s (no package or remote)", req.Name)
 	}
-	return translateLocalMCPServer(ctx, req.Name, req.Spec, req.DeploymentID, req.EnvValues, req.ArgValues)
+	return translateLocalMCPServer(ctx, req.Name, req.Spec, req.DeploymentID, req.ImageDigest, req.EnvValues, req.ArgValues)
 }
 
 // translateRemoteMCPServer emits a runtimetypes.MCPServer for a
@@ -106,13 +111,16 @@ func translateRemoteMCPServer(name string, remote *v1alpha1.MCPRemote, deploymen
 // Launch is set, the manifest owns Cmd/Args verbatim (with override
 // merging by arg name); if Launch is nil, the resolver derives
 // per-type defaults (npm: "npx -y <id>@<ver>", pypi: "uvx <id>==<ver>",
-// oci: image entrypoint). The transport field controls whether the
-// runner speaks stdio or http on the far side.
+// oci: image entrypoint, go: "go run <id>@<ver>", github-release and
+// cargo: a short sh script that fetches and execs the binary). The
+// transport field controls whether the runner speaks stdio or http on the
+// far side.
 func translateLocalMCPServer(
 	_ context.Context,
 	serverName string,
 	spec v1alpha1.MCPServerSpec,
 	deploymentID string,
+	imageDigest string,
 	envValues map[string]string,
 	argValues map[string]string,
 ) (*runtimetypes.MCPServer, error) {
@@ -126,6 +134,14 @@ func translateLocalMCPServer(
 	if err != nil {
 		return nil, err
 	}
+	if config.IsOCI && imageDigest != "" {
+		config.Image = pinImageDigest(config.Image, imageDigest)
+	}
+	for k, v := range config.Env {
+		if _, ok := envValues[k]; !ok {
+			envValues[k] = v
+		}
+	}
 
 	var (
 		cmd  string
@@ -333,11 +349,14 @@ func GenerateInternalNameForDeployment(name, deploymentID string) string {
 
 // RegistryConfig captures what runtime image + default launch command a
 // package's Origin dispatches to. IsOCI toggles container-passthrough
-// (Command is a hint for the runner, Image IS the server).
+// (Command is a hint for the runner, Image IS the server). Env holds
+// variables the origin needs in the runner, e.g. GOPROXY for a Go mirror;
+// deployment env values win on conflict.
 type RegistryConfig struct {
 	Image   string
 	Command string
 	IsOCI   bool
+	Env     map[string]string
 }
 
 // processArguments appends a package's argument list onto the running
@@ -480,9 +499,81 @@ func GetRegistryConfig(origin v1alpha1.MCPPackageOrigin) (RegistryConfig, []stri
 			Image: origin.Identifier,
 			IsOCI: true,
 		}, nil, nil
+	case origin.GitHubRelease != nil:
+		return RegistryConfig{
+			Image:   types.DefaultGitHubReleaseRunnerImage,
+			Command: "sh",
+		}, []string{"-c", gitHubReleaseLaunchScript(origin.Identifier, origin.GitHubRelease)}, nil
+	case origin.Go != nil:
+		config := RegistryConfig{
+			Image:   types.DefaultGoRunnerImage,
+			Command: "go",
+		}
+		if mirror := strings.TrimSuffix(origin.Go.Mirror, "/"); mirror != "" && mirror != registries.DefaultURLGo {
+			config.Env = map[string]string{"GOPROXY": mirror}
+		}
+		return config, []string{"run", origin.Identifier + "@" + origin.Go.Version}, nil
+	case origin.Cargo != nil:
+		binary := origin.Cargo.Binary
+		if binary == "" {
+			binary = origin.Identifier
+		}
+		index := ""
+		if mirror := strings.TrimSuffix(origin.Cargo.Mirror, "/"); mirror != "" && mirror != registries.DefaultURLCargo {
+			index = " --index " + shellQuote("sparse+"+mirror+"/")
+		}
+		script := fmt.Sprintf("cargo install --quiet --locked --root /tmp/cargo%s %s --version %s && exec /tmp/cargo/bin/%s",
+			index, shellQuote(origin.Identifier), shellQuote(origin.Cargo.Version), shellQuote(binary))
+		return RegistryConfig{
+			Image:   types.DefaultCargoRunnerImage,
+			Command: "sh",
+		}, []string{"-c", script}, nil
 	default:
-		return RegistryConfig{}, nil, fmt.Errorf("unsupported MCPPackage origin: no sub-struct (NPM/PyPI/OCI) is set; Origin.Type=%q", origin.Type)
+		return RegistryConfig{}, nil, fmt.Errorf("unsupported MCPPackage origin: no sub-struct (NPM/PyPI/OCI/GitHubRelease/Go/Cargo) is set; Origin.Type=%q", origin.Type)
+	}
+}
+
+// pinImageDigest replaces any digest on image with digest, keeping the
+// tag for readability: "ghcr.io/acme/fetch:1.0@sha256:...". Runtimes pull
+// by the digest and ignore the tag.
+func pinImageDigest(image, digest string) string {
+	image, _, _ = strings.Cut(image, "@")
+	return image + "@" + digest
+}
+
+// gitHubReleaseLaunchScript downloads the release asset into a scratch
+// directory, verifies it when a sha256 is pinned, unpacks a tarball, and
+// execs the binary so it owns the container's stdio.
+func gitHubReleaseLaunchScript(repo string, g *v1alpha1.MCPPackageOriginGitHubRelease) string {
+	base := strings.TrimSuffix(g.Mirror, "/")
+	if base == "" {
+		base = registries.DefaultURLGitHub
+	}
+	assetURL := fmt.Sprintf("%s/%s/releases/download/%s/%s", base, repo, url.PathEscape(g.Version), url.PathEscape(g.Asset))
+	asset := shellQuote(g.Asset)
+
+	var b strings.Builder
+	b.WriteString(`set -e; cd "$(mktemp -d)"; `)
+	fmt.Fprintf(&b, "curl -fsSL -o %s %s; ", asset, shellQuote(assetURL))
+	if g.SHA256 != "" {
+		fmt.Fprintf(&b, "echo %s | sha256sum -c -; ", shellQuote(g.SHA256+"  "+g.Asset))
 	}
+	if strings.HasSuffix(g.Asset, ".tar.gz") || strings.HasSuffix(g.Asset, ".tgz") {
+		binary := g.Binary
+		if binary == "" {
+			binary = repo[strings.LastIndex(repo, "/")+1:]
+		}
+		fmt.Fprintf(&b, "tar -xzf %s; chmod +x ./%s; exec ./%s", asset, shellQuote(binary), shellQuote(binary))
+	} else {
+		fmt.Fprintf(&b, "chmod +x ./%s; exec ./%s", asset, asset)
+	}
+	return b.String()
+}
+
+// shellQuote single-quotes s for sh. Admission already bounds the
+// characters in these fields; quoting keeps the script correct regardless.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
 }
 
 // EnvMapToStringSlice renders an env map as a sorted ["K=V"] slice —
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"context"
+	"maps"
+	"strings"
 	"testing"
 
 	runtimetypes "github.com/agentregistry-dev/agentregistry/internal/registry/runtimes/types"
@@ -89,6 +91,186 @@ func TestTranslateMCPServer_LocalDerivesDefaultsWhenLaunchNil(t *testing.T) {
 	}
 }
 
+func TestGetRegistryConfig_BinaryOrigins(t *testing.T) {
+	const sum = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	tests := []struct {
+		name      string
+		origin    v1alpha1.MCPPackageOrigin
+		wantImage string
+		wantCmd   string
+		wantArgs  []string
+		wantEnv   map[string]string
+	}{
+		{
+			name: "github release tarball",
+			origin: v1alpha1.MCPPackageOrigin{
+				Type:       v1alpha1.MCPPackageOriginTypeGitHubRelease,
+				Identifier: "acme/mcp-server",
+				GitHubRelease: &v1alpha1.MCPPackageOriginGitHubRelease{
+					Version: "v1.2.0",
+					Asset:   "mcp-server_linux_amd64.tar.gz",
+					SHA256:  sum,
+				},
+			},
+			wantImage: types.DefaultGitHubReleaseRunnerImage,
+			wantCmd:   "sh",
+			wantArgs: []string{"-c", `set -e; cd "$(mktemp -d)"; ` +
+				`curl -fsSL -o 'mcp-server_linux_amd64.tar.gz' 'https://github.com/acme/mcp-server/releases/download/v1.2.0/mcp-server_linux_amd64.tar.gz'; ` +
+				`echo '` + sum + `  mcp-server_linux_amd64.tar.gz' | sha256sum -c -; ` +
+				`tar -xzf 'mcp-server_linux_amd64.tar.gz'; chmod +x ./'mcp-server'; exec ./'mcp-server'`},
+		},
+		{
+			name: "github release bare binary",
+			origin: v1alpha1.MCPPackageOrigin{
+				Type:          v1alpha1.MCPPackageOriginTypeGitHubRelease,
+				Identifier:    "acme/mcp-server",
+				GitHubRelease: &v1alpha1.MCPPackageOriginGitHubRelease{Version: "v1.2.0", Asset: "mcp-server-linux"},
+			},
+			wantImage: types.DefaultGitHubReleaseRunnerImage,
+			wantCmd:   "sh",
+			wantArgs: []string{"-c", `set -e; cd "$(mktemp -d)"; ` +
+				`curl -fsSL -o 'mcp-server-linux' 'https://github.com/acme/mcp-server/releases/download/v1.2.0/mcp-server-linux'; ` +
+				`chmod +x ./'mcp-server-linux'; exec ./'mcp-server-linux'`},
+		},
+		{
+			name: "go module",
+			origin: v1alpha1.MCPPackageOrigin{
+				Type:       v1alpha1.MCPPackageOriginTypeGo,
+				Identifier: "github.com/acme/mcp/cmd/server",
+				Go:         &v1alpha1.MCPPackageOriginGo{Version: "v0.4.1"},
+			},
+			wantImage: types.DefaultGoRunnerImage,
+			wantCmd:   "go",
+			wantArgs:  []string{"run", "github.com/acme/mcp/cmd/server@v0.4.1"},
+		},
+		{
+			name: "go module from a mirror",
+			origin: v1alpha1.MCPPackageOrigin{
+				Type:       v1alpha1.MCPPackageOriginTypeGo,
+				Identifier: "github.com/acme/mcp/cmd/server",
+				Go:         &v1alpha1.MCPPackageOriginGo{Version: "v0.4.1", Mirror: "https://goproxy.acme.internal/"},
+			},
+			wantImage: types.DefaultGoRunnerImage,
+			wantCmd:   "go",
+			wantArgs:  []string{"run", "github.com/acme/mcp/cmd/server@v0.4.1"},
+			wantEnv:   map[string]string{"GOPROXY": "https://goproxy.acme.internal"},
+		},
+		{
+			name: "cargo crate with binary override",
+			origin: v1alpha1.MCPPackageOrigin{
+				Type:       v1alpha1.MCPPackageOriginTypeCargo,
+				Identifier: "acme-mcp",
+				Cargo:      &v1alpha1.MCPPackageOriginCargo{Version: "0.3.0", Binary: "acme-mcp-server"},
+			},
+			wantImage: types.DefaultCargoRunnerImage,
+			wantCmd:   "sh",
+			wantArgs:  []string{"-c", "cargo install --quiet --locked --root /tmp/cargo 'acme-mcp' --version '0.3.0' && exec /tmp/cargo/bin/'acme-mcp-server'"},
+		},
+		{
+			name: "cargo crate from a mirror",
+			origin: v1alpha1.MCPPackageOrigin{
+				Type:       v1alpha1.MCPPackageOriginTypeCargo,
+				Identifier: "acme-mcp",
+				Cargo:      &v1alpha1.MCPPackageOriginCargo{Version: "0.3.0", Mirror: "https://crates.acme.internal"},
+			},
+			wantImage: types.DefaultCargoRunnerImage,
+			wantCmd:   "sh",
+			wantArgs:  []string{"-c", "cargo install --quiet --locked --root /tmp/cargo --index 'sparse+https://crates.acme.internal/' 'acme-mcp' --version '0.3.0' && exec /tmp/cargo/bin/'acme-mcp'"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, args, err := GetRegistryConfig(tt.origin)
+			if err != nil {
+				t.Fatalf("GetRegistryConfig() unexpected error: %v", err)
+			}
+			if config.Image != tt.wantImage || config.Command != tt.wantCmd || config.IsOCI {
+				t.Fatalf("config = %+v, want image %q cmd %q", config, tt.wantImage, tt.wantCmd)
+			}
+			if !slicesEqual(args, tt.wantArgs) {
+				t.Fatalf("args =\n %q\nwant\n %q", args, tt.wantArgs)
+			}
+			if !maps.Equal(config.Env, tt.wantEnv) {
+				t.Fatalf("env = %v, want %v", config.Env, tt.wantEnv)
+			}
+		})
+	}
+}
+
+func TestTranslateMCPServer_LocalGoMirrorSetsGOPROXY(t *testing.T) {
+	spec := v1alpha1.MCPServerSpec{
+		Source: &v1alpha1.MCPServerSource{
+			Package: &v1alpha1.MCPPackage{
+				Origin: v1alpha1.MCPPackageOrigin{
+					Type:       v1alpha1.MCPPackageOriginTypeGo,
+					Identifier: "github.com/acme/mcp/cmd/server",
+					Go:         &v1alpha1.MCPPackageOriginGo{Version: "v0.4.1", Mirror: "https://goproxy.acme.internal"},
+				},
+				Transport: v1alpha1.MCPTransport{Type: "stdio"},
+			},
+		},
+	}
+	server, err := TranslateMCPServer(context.Background(), &MCPServerRunRequest{Name: "test/server", Spec: spec})
+	if err != nil {
+		t.Fatalf("TranslateMCPServer() unexpected error: %v", err)
+	}
+	if got := server.Local.Deployment.Env["GOPROXY"]; got != "https://goproxy.acme.internal" {
+		t.Fatalf("GOPROXY = %q, want the mirror", got)
+	}
+
+	server, err = TranslateMCPServer(context.Background(), &MCPServerRunRequest{
+		Name:      "test/server",
+		Spec:      spec,
+		EnvValues: map[string]string{"GOPROXY": "https://override.example"},
+	})
+	if err != nil {
+		t.Fatalf("TranslateMCPServer() unexpected error: %v", err)
+	}
+	if got := server.Local.Deployment.Env["GOPROXY"]; got != "https://override.example" {
+		t.Fatalf("GOPROXY = %q, want the deployment override", got)
+	}
+}
+
+func TestTranslateMCPServer_LocalPinsVerifiedImageDigest(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	spec := v1alpha1.MCPServerSpec{
+		Source: &v1alpha1.MCPServerSource{
+			Package: &v1alpha1.MCPPackage{
+				Origin: v1alpha1.MCPPackageOrigin{
+					Type:       v1alpha1.MCPPackageOriginTypeOCI,
+					Identifier: "ghcr.io/acme/fetch:1.0.0",
+					OCI:        &v1alpha1.MCPPackageOriginOCI{},
+				},
+				Transport: v1alpha1.MCPTransport{Type: "stdio"},
+			},
+		},
+	}
+	server, err := TranslateMCPServer(context.Background(), &MCPServerRunRequest{Name: "test/server", Spec: spec, ImageDigest: digest})
+	if err != nil {
+		t.Fatalf("TranslateMCPServer() unexpected error: %v", err)
+	}
+	if got, want := server.Local.Deployment.Image, "ghcr.io/acme/fetch:1.0.0@"+digest; got != want {
+		t.Fatalf("Image = %q, want %q", got, want)
+	}
+
+	spec.Source.Package.Origin.Identifier = "ghcr.io/acme/fetch:1.0.0@sha256:" + strings.Repeat("b", 64)
+	server, err = TranslateMCPServer(context.Background(), &MCPServerRunRequest{Name: "test/server", Spec: spec, ImageDigest: digest})
+	if err != nil {
+		t.Fatalf("TranslateMCPServer() unexpected error: %v", err)
+	}
+	if got, want := server.Local.Deployment.Image, "ghcr.io/acme/fetch:1.0.0@"+digest; got != want {
+		t.Fatalf("Image = %q, want the verified digest %q", got, want)
+	}
+
+	server, err = TranslateMCPServer(context.Background(), &MCPServerRunRequest{Name: "test/server", Spec: spec})
+	if err != nil {
+		t.Fatalf("TranslateMCPServer() unexpected error: %v", err)
+	}
+	if got := server.Local.Deployment.Image; got != spec.Source.Package.Origin.Identifier {
+		t.Fatalf("Image = %q, want the identifier when nothing was verified", got)
+	}
+}
+
 func TestTranslateMCPServer_LocalHonorsLaunchAndOverrides(t *testing.T) {
 	server, err := TranslateMCPServer(context.Background(), &MCPServerRunRequest{
 		Name: "test/server",
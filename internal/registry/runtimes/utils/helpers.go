@@ -10,6 +10,7 @@ import (
 	"github.com/agentregistry-dev/agentregistry/internal/constants"
 	runtimetypes "github.com/agentregistry-dev/agentregistry/internal/registry/runtimes/types"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
 )
 
 // MCPServerTranslateOpts bundles knobs for SpecToRuntimeMCPServer that vary
@@ -23,6 +24,18 @@ type MCPServerTranslateOpts struct {
 	EnvValues    map[string]string
 	ArgValues    map[string]string
 	HeaderValues map[string]string
+	// ImageDigest pins an OCI package image; callers pass VerifiedImageDigest
+	// of the MCPServer being deployed.
+	ImageDigest string
+}
+
+// VerifiedImageDigest returns the digest the registry verified for the
+// server's OCI package image on apply, or "" when none was recorded.
+func VerifiedImageDigest(server *v1alpha1.MCPServer) string {
+	if server == nil || server.Status.Image == nil {
+		return ""
+	}
+	return server.Status.Image.Digest
 }
 
 // SpecToRuntimeMCPServer translates a v1alpha1 MCPServer envelope into the
@@ -42,6 +55,7 @@ func SpecToRuntimeMCPServer(
 		EnvValues:    nonNilStringMap(opts.EnvValues),
 		ArgValues:    nonNilStringMap(opts.ArgValues),
 		HeaderValues: nonNilStringMap(opts.HeaderValues),
+		ImageDigest:  opts.ImageDigest,
 	}
 	runtimeServer, err := TranslateMCPServer(ctx, req)
 	if err != nil {
@@ -84,8 +98,21 @@ type AgentTranslateOpts struct {
 	// SpecToRuntimeAgent omits model provider/name env rather than accepting
 	// those values from DeploymentEnv.
 	Model *v1alpha1.ModelSpec
+	// ModelNamespace is the namespace of the resolved Model; blank
+	// SecretKeyRef namespaces on Model inherit it.
+	ModelNamespace string
+	// DeploymentNamespace is the Deployment's namespace. A Model that
+	// references Secrets only deploys from its own namespace, so a
+	// Deployment cannot pull another namespace's credentials into its agent.
+	DeploymentNamespace string
 	// Getter resolves AgentSpec.MCPServers refs to v1alpha1.MCPServer objects.
 	Getter v1alpha1.GetterFunc
+	// Secrets resolves the Model's SecretKeyRefs into Agent.SecretEnv.
+	// Required only when Model references a Secret.
+	Secrets types.SecretResolverFunc
+	// PromptArguments is Deployment.Spec.PromptArguments; the agent's
+	// instructions Prompt is rendered with them into ResolvedPrompts.
+	PromptArguments map[string]string
 }
 
 // ResolveDeploymentModelSpec resolves the Deployment's effective ModelRef
@@ -99,6 +126,21 @@ func ResolveDeploymentModelSpec(
 	deployment *v1alpha1.Deployment,
 	getter v1alpha1.GetterFunc,
 ) (*v1alpha1.ModelSpec, error) {
+	model, err := ResolveDeploymentModel(ctx, deployment, getter)
+	if err != nil || model == nil {
+		return nil, err
+	}
+	return &model.Spec, nil
+}
+
+// ResolveDeploymentModel is ResolveDeploymentModelSpec returning the whole
+// Model, for callers that also need its metadata (for example, the namespace
+// its SecretKeyRefs inherit).
+func ResolveDeploymentModel(
+	ctx context.Context,
+	deployment *v1alpha1.Deployment,
+	getter v1alpha1.GetterFunc,
+) (*v1alpha1.Model, error) {
 	if deployment == nil {
 		return nil, nil
 	}
@@ -130,7 +172,7 @@ func ResolveDeploymentModelSpec(
 	if !ok || model == nil {
 		return nil, fmt.Errorf("spec.modelRef resolve %s: getter returned unexpected type %T", refName, obj)
 	}
-	return &model.Spec, nil
+	return model, nil
 }
 
 // SpecToRuntimeAgent translates a v1alpha1 Agent envelope + Deployment
@@ -165,11 +207,13 @@ func SpecToRuntimeAgent(
 	}
 	envValues[constants.EnvKagentName] = agentMeta.Name
 	envValues[constants.EnvAgentName] = agentMeta.Name
-	delete(envValues, constants.EnvModelProvider)
-	delete(envValues, constants.EnvModelName)
-	if opts.Model != nil {
-		envValues[constants.EnvModelProvider] = opts.Model.Provider
-		envValues[constants.EnvModelName] = opts.Model.Model
+	for _, key := range modelEnvKeys {
+		delete(envValues, key)
+	}
+	maps.Copy(envValues, modelEnv(opts.Model))
+	secretEnv, err := resolveModelSecretEnv(ctx, opts)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	var (
@@ -202,6 +246,7 @@ func SpecToRuntimeAgent(
 			DeploymentID: opts.DeploymentID,
 			Namespace:    opts.Namespace,
 			HeaderValues: opts.HeaderValues,
+			ImageDigest:  VerifiedImageDigest(mcp),
 		})
 		if err != nil {
 			return nil, nil, fmt.Errorf("spec.mcpServers[%d]: %w", i, err)
@@ -214,6 +259,11 @@ func SpecToRuntimeAgent(
 		}
 	}
 
+	resolvedPrompts, err := resolveAgentInstructions(ctx, agentMeta, agentSpec, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	if len(resolvedConfigs) > 0 {
 		encoded, err := json.Marshal(resolvedConfigs)
 		if err != nil {
@@ -236,10 +286,152 @@ func SpecToRuntimeAgent(
 			Port:  DefaultLocalAgentPort,
 		},
 		ResolvedMCPServers: resolvedConfigs,
+		ResolvedPrompts:    resolvedPrompts,
+		Model:              opts.Model,
+		SecretEnv:          secretEnv,
 	}
 	return agent, resolvedServers, nil
 }
 
+// resolveAgentInstructions fetches the Agent's instructions Prompt and
+// renders it with the Deployment's prompt arguments. Multi-message prompts
+// are flattened into one text block, messages separated by a blank line,
+// since runtimes consume a single instructions string.
+func resolveAgentInstructions(
+	ctx context.Context,
+	agentMeta v1alpha1.ObjectMeta,
+	agentSpec v1alpha1.AgentSpec,
+	opts AgentTranslateOpts,
+) ([]runtimetypes.ResolvedPrompt, error) {
+	if agentSpec.Instructions == nil {
+		if len(opts.PromptArguments) > 0 {
+			return nil, fmt.Errorf("spec.promptArguments: agent %s declares no instructions prompt", agentMeta.Name)
+		}
+		return nil, nil
+	}
+	ref := *agentSpec.Instructions
+	if ref.Kind == "" {
+		ref.Kind = v1alpha1.KindPrompt
+	}
+	if ref.Namespace == "" {
+		ref.Namespace = agentMeta.Namespace
+	}
+	if ref.Kind != v1alpha1.KindPrompt {
+		return nil, fmt.Errorf("spec.instructions: unsupported ref kind %q", ref.Kind)
+	}
+	if opts.Getter == nil {
+		return nil, fmt.Errorf("spec.instructions: getter required to resolve ref")
+	}
+	obj, err := opts.Getter(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("spec.instructions resolve %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	prompt, ok := obj.(*v1alpha1.Prompt)
+	if !ok || prompt == nil {
+		return nil, fmt.Errorf("spec.instructions: getter returned unexpected type for %s/%s", ref.Namespace, ref.Name)
+	}
+	messages, err := prompt.Spec.Render(opts.PromptArguments)
+	if err != nil {
+		return nil, fmt.Errorf("render prompt %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	parts := make([]string, 0, len(messages))
+	for _, m := range messages {
+		parts = append(parts, m.Content)
+	}
+	return []runtimetypes.ResolvedPrompt{{
+		Name:    prompt.Metadata.Name,
+		Content: strings.Join(parts, "\n\n"),
+	}}, nil
+}
+
+// modelEnvKeys lists every env var the Model owns. Deployment env overrides
+// for them are dropped so the Model stays the single source of truth.
+var modelEnvKeys = []string{
+	constants.EnvModelProvider,
+	constants.EnvModelName,
+	constants.EnvModelAPIKey,
+	constants.EnvModelCACert,
+	constants.EnvModelAuthStrategy,
+	constants.EnvModelBaseURL,
+	constants.EnvModelRegion,
+	constants.EnvModelDeployment,
+	constants.EnvModelAPIVersion,
+	constants.EnvModelProject,
+	constants.EnvModelGatewayURL,
+}
+
+// modelEnv translates the Model's provider identity and endpoint into the
+// plain env the agent process reads. Unset fields are omitted so the agent
+// falls back to its provider defaults. Secret material is handled separately
+// by resolveModelSecretEnv.
+func modelEnv(model *v1alpha1.ModelSpec) map[string]string {
+	if model == nil {
+		return nil
+	}
+	out := map[string]string{
+		constants.EnvModelProvider: model.Provider,
+		constants.EnvModelName:     model.Model,
+	}
+	set := func(key, value string) {
+		if value != "" {
+			out[key] = value
+		}
+	}
+	if model.Auth != nil {
+		set(constants.EnvModelAuthStrategy, model.Auth.Strategy)
+	}
+	if ep := model.Endpoint; ep != nil {
+		set(constants.EnvModelBaseURL, ep.BaseURL)
+		set(constants.EnvModelRegion, ep.Region)
+		set(constants.EnvModelDeployment, ep.Deployment)
+		set(constants.EnvModelAPIVersion, ep.APIVersion)
+		set(constants.EnvModelProject, ep.Project)
+	}
+	return out
+}
+
+// modelSecretEnvRef pairs a Model SecretKeyRef with the env var it lands in.
+type modelSecretEnvRef struct {
+	field string
+	env   string
+	ref   v1alpha1.SecretKeyRef
+}
+
+// resolveModelSecretEnv resolves the Model's SecretKeyRefs into the env the
+// agent process reads them from. Returns nil when the Model references no
+// Secret.
+func resolveModelSecretEnv(ctx context.Context, opts AgentTranslateOpts) (map[string]string, error) {
+	if opts.Model == nil {
+		return nil, nil
+	}
+	var refs []modelSecretEnvRef
+	if auth := opts.Model.Auth; auth != nil && auth.Strategy == v1alpha1.ModelAuthStrategySecretRef && auth.SecretRef != nil {
+		refs = append(refs, modelSecretEnvRef{"model spec.auth.secretRef", constants.EnvModelAPIKey, *auth.SecretRef})
+	}
+	if ep := opts.Model.Endpoint; ep != nil && ep.TLS != nil && ep.TLS.CACertSecretRef != nil {
+		refs = append(refs, modelSecretEnvRef{"model spec.endpoint.tls.caCertSecretRef", constants.EnvModelCACert, *ep.TLS.CACertSecretRef})
+	}
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	if opts.DeploymentNamespace != opts.ModelNamespace {
+		return nil, fmt.Errorf("%s: a Model that references Secrets can only be deployed from its own namespace %q",
+			refs[0].field, opts.ModelNamespace)
+	}
+	if opts.Secrets == nil {
+		return nil, fmt.Errorf("%s: secret resolver is not configured", refs[0].field)
+	}
+	out := make(map[string]string, len(refs))
+	for _, r := range refs {
+		value, err := opts.Secrets(ctx, opts.ModelNamespace, r.ref)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", r.field, err)
+		}
+		out[r.env] = value
+	}
+	return out, nil
+}
+
 // SplitDeploymentRuntimeInputs splits a Deployment.Spec.Env map into env /
 // arg / header buckets via the ARG_/HEADER_ prefix convention. Prefix-free
 // keys are plain env; ARG_<name> and HEADER_<name> route to arg and header
@@ -268,6 +268,118 @@ func TestSpecToRuntimeAgent_ModelEnvIsAuthoritative(t *testing.T) {
 	}
 }
 
+func TestSpecToRuntimeAgent_ModelEndpointEnvPerProvider(t *testing.T) {
+	tests := []struct {
+		name  string
+		model v1alpha1.ModelSpec
+		want  map[string]string
+	}{
+		{
+			name: "azure-openai",
+			model: v1alpha1.ModelSpec{
+				Provider: v1alpha1.ModelProviderAzureOpenAI, Model: "gpt-4o",
+				Auth:     &v1alpha1.ModelAuthConfig{Strategy: v1alpha1.ModelAuthStrategyPassthrough},
+				Endpoint: &v1alpha1.ModelEndpointConfig{BaseURL: "https://acme.openai.azure.com", Deployment: "gpt-4o-prod", APIVersion: "2024-10-21"},
+			},
+			want: map[string]string{
+				"MODEL_PROVIDER":      "azure-openai",
+				"MODEL_NAME":          "gpt-4o",
+				"MODEL_AUTH_STRATEGY": "passthrough",
+				"MODEL_BASE_URL":      "https://acme.openai.azure.com",
+				"MODEL_DEPLOYMENT":    "gpt-4o-prod",
+				"MODEL_API_VERSION":   "2024-10-21",
+			},
+		},
+		{
+			name: "vertex",
+			model: v1alpha1.ModelSpec{
+				Provider: v1alpha1.ModelProviderVertex, Model: "gemini-2.5-pro",
+				Endpoint: &v1alpha1.ModelEndpointConfig{Project: "acme-ml", Region: "us-central1"},
+			},
+			want: map[string]string{
+				"MODEL_PROVIDER": "vertex",
+				"MODEL_NAME":     "gemini-2.5-pro",
+				"MODEL_PROJECT":  "acme-ml",
+				"MODEL_REGION":   "us-central1",
+			},
+		},
+		{
+			name: "openai-compatible",
+			model: v1alpha1.ModelSpec{
+				Provider: v1alpha1.ModelProviderOpenAICompatible, Model: "qwen2.5:7b",
+				Endpoint: &v1alpha1.ModelEndpointConfig{BaseURL: "http://ollama:11434/v1"},
+			},
+			want: map[string]string{
+				"MODEL_PROVIDER": "openai-compatible",
+				"MODEL_NAME":     "qwen2.5:7b",
+				"MODEL_BASE_URL": "http://ollama:11434/v1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent, _, err := SpecToRuntimeAgent(
+				t.Context(),
+				v1alpha1.ObjectMeta{Namespace: "default", Name: "alice"},
+				v1alpha1.AgentSpec{},
+				AgentTranslateOpts{
+					DeploymentEnv: map[string]string{"MODEL_BASE_URL": "http://stale", "MODEL_REGION": "stale"},
+					Model:         &tt.model,
+				},
+			)
+			if err != nil {
+				t.Fatalf("SpecToRuntimeAgent: %v", err)
+			}
+			for _, key := range modelEnvKeys {
+				if got, want := agent.Deployment.Env[key], tt.want[key]; got != want {
+					t.Errorf("env %s = %q, want %q", key, got, want)
+				}
+			}
+			if agent.Model != &tt.model {
+				t.Errorf("agent.Model = %+v, want the resolved Model", agent.Model)
+			}
+		})
+	}
+}
+
+func TestSpecToRuntimeAgent_RendersInstructionsPrompt(t *testing.T) {
+	prompt := &v1alpha1.Prompt{
+		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindPrompt},
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "system", Tag: "v1"},
+		Spec: v1alpha1.PromptSpec{
+			Arguments: []v1alpha1.PromptArgument{{Name: "team", Required: true}},
+			Content:   "You support the {{ .team }} team.",
+		},
+	}
+	var gotRef v1alpha1.ResourceRef
+	getter := func(ctx context.Context, ref v1alpha1.ResourceRef) (v1alpha1.Object, error) {
+		gotRef = ref
+		return prompt, nil
+	}
+	agentMeta := v1alpha1.ObjectMeta{Namespace: "default", Name: "alice"}
+	agentSpec := v1alpha1.AgentSpec{Instructions: &v1alpha1.ResourceRef{Name: "system", Tag: "v1"}}
+
+	agent, _, err := SpecToRuntimeAgent(t.Context(), agentMeta, agentSpec, AgentTranslateOpts{
+		Getter:          getter,
+		PromptArguments: map[string]string{"team": "payments"},
+	})
+	if err != nil {
+		t.Fatalf("SpecToRuntimeAgent: %v", err)
+	}
+	if gotRef.Kind != v1alpha1.KindPrompt || gotRef.Namespace != "default" || gotRef.Name != "system" {
+		t.Fatalf("getter ref = %+v", gotRef)
+	}
+	want := []runtimetypes.ResolvedPrompt{{Name: "system", Content: "You support the payments team."}}
+	if len(agent.ResolvedPrompts) != 1 || agent.ResolvedPrompts[0] != want[0] {
+		t.Fatalf("ResolvedPrompts = %+v, want %+v", agent.ResolvedPrompts, want)
+	}
+
+	_, _, err = SpecToRuntimeAgent(t.Context(), agentMeta, agentSpec, AgentTranslateOpts{Getter: getter})
+	if err == nil || !strings.Contains(err.Error(), "arguments.team") {
+		t.Fatalf("missing required argument error = %v", err)
+	}
+}
+
 func TestSpecToRuntimeAgent_ResolvesMCPServerRefs(t *testing.T) {
 	mcp := &v1alpha1.MCPServer{
 		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindMCPServer},
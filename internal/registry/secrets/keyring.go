@@ -0,0 +1,182 @@
+// Package secrets owns at-rest encryption and runtime resolution for the
+// v1alpha1 Secret kind.
+//
+// Storage is envelope encryption: every Secret gets a fresh AES-256 data key,
+// each value is sealed with that data key, and the data key itself is sealed
+// with the server-held Keyring key. Both use AES-GCM with the Secret's
+// namespace and name as additional data, plus the value's key name for
+// values, so ciphertexts cannot be swapped between keys or between Secrets.
+// Only the sealed form ever reaches Postgres.
+//
+// Two seams consume this package: the apply pipeline's Prepare hook, which
+// seals SecretSpec.Data before persistence, and the Deployment controller's
+// types.SecretResolverFunc, which opens a SecretKeyRef for the runtime
+// adapters at apply time.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// KeySize is the length, in bytes, of both the server key and the per-Secret
+// data keys (AES-256).
+const KeySize = 32
+
+// ErrNoKeyring is returned when a Secret is written or resolved on a server
+// started without AGENT_REGISTRY_SECRET_ENCRYPTION_KEY.
+var ErrNoKeyring = errors.New("secret encryption key is not configured (set AGENT_REGISTRY_SECRET_ENCRYPTION_KEY)")
+
+// Keyring seals and opens SecretEnvelopes under one server-held key.
+type Keyring struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// NewKeyring builds a Keyring from a raw KeySize-byte key.
+func NewKeyring(key []byte) (*Keyring, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("secrets: key must be %d bytes, got %d", KeySize, len(key))
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(key)
+	return &Keyring{id: hex.EncodeToString(sum[:8]), aead: aead}, nil
+}
+
+// ParseKeyring builds a Keyring from its hex encoding. Empty input returns
+// (nil, nil): the server runs without a keyring and Secret writes fail with
+// ErrNoKeyring.
+func ParseKeyring(hexKey string) (*Keyring, error) {
+	if hexKey == "" {
+		return nil, nil
+	}
+	key, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return nil, fmt.Errorf("secrets: decode key: %w", err)
+	}
+	return NewKeyring(key)
+}
+
+// ID returns the stable identifier recorded on envelopes sealed by k. It is a
+// truncated SHA-256 of the key, so it reveals nothing usable.
+func (k *Keyring) ID() string {
+	if k == nil {
+		return ""
+	}
+	return k.id
+}
+
+// Seal encrypts data, the values of Secret namespace/name, under a fresh
+// data key. The envelope only opens for the same Secret.
+func (k *Keyring) Seal(namespace, name string, data map[string]string) (*v1alpha1.SecretEnvelope, error) {
+	if k == nil {
+		return nil, ErrNoKeyring
+	}
+	dataKey := make([]byte, KeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("secrets: generate data key: %w", err)
+	}
+	wrapped, err := seal(k.aead, dataKey, additionalData(k.id, namespace, name))
+	if err != nil {
+		return nil, fmt.Errorf("secrets: wrap data key: %w", err)
+	}
+	valueAEAD, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.SecretEnvelope{
+		KeyID:   k.id,
+		DataKey: wrapped,
+		Values:  make(map[string]string, len(data)),
+	}
+	for key, value := range data {
+		sealed, err := seal(valueAEAD, []byte(value), additionalData(namespace, name, key))
+		if err != nil {
+			return nil, fmt.Errorf("secrets: seal %q: %w", key, err)
+		}
+		out.Values[key] = sealed
+	}
+	return out, nil
+}
+
+// Open decrypts every value in env, which must have been sealed for Secret
+// namespace/name. A nil envelope opens to an empty map.
+func (k *Keyring) Open(namespace, name string, env *v1alpha1.SecretEnvelope) (map[string]string, error) {
+	if env == nil {
+		return map[string]string{}, nil
+	}
+	if k == nil {
+		return nil, ErrNoKeyring
+	}
+	if env.KeyID != k.id {
+		return nil, fmt.Errorf("secrets: envelope sealed with key %q, server key is %q", env.KeyID, k.id)
+	}
+	dataKey, err := open(k.aead, env.DataKey, additionalData(env.KeyID, namespace, name))
+	if err != nil {
+		return nil, fmt.Errorf("secrets: unwrap data key: %w", err)
+	}
+	valueAEAD, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]string, len(env.Values))
+	for key, sealed := range env.Values {
+		plain, err := open(valueAEAD, sealed, additionalData(namespace, name, key))
+		if err != nil {
+			return nil, fmt.Errorf("secrets: open %q: %w", key, err)
+		}
+		out[key] = string(plain)
+	}
+	return out, nil
+}
+
+// additionalData joins parts with NUL, which no key ID, namespace, name or
+// key name contains, so distinct part lists never encode alike.
+func additionalData(parts ...string) []byte {
+	return []byte(strings.Join(parts, "\x00"))
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("secrets: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("secrets: %w", err)
+	}
+	return aead, nil
+}
+
+// seal returns base64(nonce||ciphertext).
+func seal(aead cipher.AEAD, plaintext, additional []byte) (string, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, plaintext, additional)), nil
+}
+
+func open(aead cipher.AEAD, encoded string, additional []byte) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := raw[:aead.NonceSize()], raw[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additional)
+}
@@ -0,0 +1,159 @@
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+func testKeyring(t *testing.T, fill byte) *Keyring {
+	t.Helper()
+	k, err := NewKeyring(bytes.Repeat([]byte{fill}, KeySize))
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	return k
+}
+
+func TestKeyringSealOpenRoundTrip(t *testing.T) {
+	k := testKeyring(t, 1)
+	env, err := k.Seal("team-a", "provider", map[string]string{"apiKey": "sk-live", "org": "acme"})
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if env.KeyID != k.ID() {
+		t.Fatalf("KeyID = %q, want %q", env.KeyID, k.ID())
+	}
+	for name, sealed := range env.Values {
+		if sealed == "sk-live" || sealed == "acme" {
+			t.Fatalf("value %q stored in clear", name)
+		}
+	}
+	got, err := k.Open("team-a", "provider", env)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if got["apiKey"] != "sk-live" || got["org"] != "acme" {
+		t.Fatalf("Open = %+v", got)
+	}
+}
+
+func TestKeyringOpenRejectsWrongKey(t *testing.T) {
+	env, err := testKeyring(t, 1).Seal("team-a", "provider", map[string]string{"apiKey": "sk-live"})
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if _, err := testKeyring(t, 2).Open("team-a", "provider", env); err == nil {
+		t.Fatal("Open with a different key succeeded")
+	}
+}
+
+func TestKeyringOpenRejectsSwappedValues(t *testing.T) {
+	k := testKeyring(t, 1)
+	env, err := k.Seal("team-a", "provider", map[string]string{"a": "first", "b": "second"})
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	env.Values["a"], env.Values["b"] = env.Values["b"], env.Values["a"]
+	if _, err := k.Open("team-a", "provider", env); err == nil {
+		t.Fatal("Open accepted ciphertexts swapped between keys")
+	}
+}
+
+func TestKeyringOpenRejectsOtherSecrets(t *testing.T) {
+	k := testKeyring(t, 1)
+	env, err := k.Seal("team-a", "provider", map[string]string{"apiKey": "sk-live"})
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	for _, other := range [][2]string{{"team-b", "provider"}, {"team-a", "other"}} {
+		if _, err := k.Open(other[0], other[1], env); err == nil {
+			t.Fatalf("Open as %s/%s accepted an envelope sealed for team-a/provider", other[0], other[1])
+		}
+	}
+}
+
+func TestParseKeyring(t *testing.T) {
+	k, err := ParseKeyring("")
+	if err != nil || k != nil {
+		t.Fatalf("ParseKeyring(\"\") = %v, %v; want nil, nil", k, err)
+	}
+	if _, err := ParseKeyring("not-hex"); err == nil {
+		t.Fatal("ParseKeyring accepted non-hex input")
+	}
+	if _, err := ParseKeyring("abcd"); err == nil {
+		t.Fatal("ParseKeyring accepted a short key")
+	}
+}
+
+func TestNewPrepareWithoutKeyring(t *testing.T) {
+	prepare := NewPrepare(nil, nil)
+	err := prepare(t.Context(), &v1alpha1.Secret{Spec: v1alpha1.SecretSpec{Data: map[string]string{"k": "v"}}})
+	if !errors.Is(err, ErrNoKeyring) {
+		t.Fatalf("prepare error = %v, want ErrNoKeyring", err)
+	}
+	if err := prepare(t.Context(), &v1alpha1.Model{}); err != nil {
+		t.Fatalf("prepare on a non-Secret = %v, want nil", err)
+	}
+}
+
+func TestSealSpec(t *testing.T) {
+	k := testKeyring(t, 1)
+
+	spec := &v1alpha1.SecretSpec{Data: map[string]string{"apiKey": "sk-live"}}
+	if err := sealSpec(k, "team-a", "provider", spec, nil); err != nil {
+		t.Fatalf("sealSpec create: %v", err)
+	}
+	if spec.Data != nil || spec.Encrypted == nil {
+		t.Fatalf("sealed spec = %+v, want Data cleared and Encrypted set", spec)
+	}
+	stored := *spec
+
+	t.Run("identical re-apply reuses the envelope", func(t *testing.T) {
+		again := &v1alpha1.SecretSpec{Data: map[string]string{"apiKey": "sk-live"}}
+		if err := sealSpec(k, "team-a", "provider", again, &stored); err != nil {
+			t.Fatalf("sealSpec: %v", err)
+		}
+		if again.Encrypted != stored.Encrypted {
+			t.Fatal("identical values were re-sealed")
+		}
+	})
+
+	t.Run("redacted placeholder keeps the stored value", func(t *testing.T) {
+		next := &v1alpha1.SecretSpec{Data: map[string]string{
+			"apiKey": v1alpha1.RedactedSecretValue,
+			"org":    "acme",
+		}}
+		if err := sealSpec(k, "team-a", "provider", next, &stored); err != nil {
+			t.Fatalf("sealSpec: %v", err)
+		}
+		got, err := k.Open("team-a", "provider", next.Encrypted)
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		if got["apiKey"] != "sk-live" || got["org"] != "acme" {
+			t.Fatalf("values = %+v", got)
+		}
+	})
+
+	t.Run("placeholder without a stored value is rejected", func(t *testing.T) {
+		next := &v1alpha1.SecretSpec{Data: map[string]string{"missing": v1alpha1.RedactedSecretValue}}
+		err := sealSpec(k, "team-a", "provider", next, &stored)
+		if !errors.Is(err, v1alpha1.ErrInvalidFormat) {
+			t.Fatalf("sealSpec error = %v, want ErrInvalidFormat", err)
+		}
+	})
+
+	t.Run("full re-apply after key rotation re-seals", func(t *testing.T) {
+		rotated := testKeyring(t, 2)
+		next := &v1alpha1.SecretSpec{Data: map[string]string{"apiKey": "sk-live"}}
+		if err := sealSpec(rotated, "team-a", "provider", next, &stored); err != nil {
+			t.Fatalf("sealSpec: %v", err)
+		}
+		if next.Encrypted.KeyID != rotated.ID() {
+			t.Fatalf("KeyID = %q, want rotated key %q", next.Encrypted.KeyID, rotated.ID())
+		}
+	})
+}
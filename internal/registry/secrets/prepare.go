@@ -0,0 +1,96 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// NewPrepare returns the apply-pipeline Prepare hook for kind=Secret. It
+// replaces SecretSpec.Data with its sealed envelope so plaintext never
+// reaches the store.
+//
+// The hook reads the currently stored Secret so that:
+//   - a value still set to v1alpha1.RedactedSecretValue keeps its stored
+//     plaintext (round-tripping a redacted read is a no-op, not a wipe);
+//   - re-applying identical material reuses the stored envelope, so the
+//     mutable store sees an unchanged spec and does not bump generation or
+//     requeue every dependent Deployment.
+func NewPrepare(store *v1alpha1store.Store, keyring *Keyring) func(ctx context.Context, obj v1alpha1.Object) error {
+	return func(ctx context.Context, obj v1alpha1.Object) error {
+		secret, ok := obj.(*v1alpha1.Secret)
+		if !ok || secret == nil {
+			return nil
+		}
+		if keyring == nil {
+			return ErrNoKeyring
+		}
+		meta := secret.GetMetadata()
+		var stored *v1alpha1.SecretSpec
+		if store != nil {
+			raw, err := store.GetLatest(ctx, meta.NamespaceOrDefault(), meta.Name)
+			switch {
+			case errors.Is(err, pkgdb.ErrNotFound):
+			case err != nil:
+				return fmt.Errorf("load stored secret: %w", err)
+			default:
+				stored = &v1alpha1.SecretSpec{}
+				if err := json.Unmarshal(raw.Spec, stored); err != nil {
+					return fmt.Errorf("decode stored secret: %w", err)
+				}
+			}
+		}
+		return sealSpec(keyring, meta.NamespaceOrDefault(), meta.Name, &secret.Spec, stored)
+	}
+}
+
+// sealSpec rewrites spec, of Secret namespace/name, in place: Data is cleared
+// and Encrypted holds the sealed form of the effective values. stored is the
+// persisted spec, nil on create.
+func sealSpec(keyring *Keyring, namespace, name string, spec *v1alpha1.SecretSpec, stored *v1alpha1.SecretSpec) error {
+	// A stored envelope that no longer opens (sealed under a rotated server
+	// key) only matters when a placeholder needs its value; a full
+	// re-apply of plaintext re-seals under the current key.
+	var (
+		previous map[string]string
+		openErr  error
+	)
+	if stored != nil && stored.Encrypted != nil {
+		previous, openErr = keyring.Open(namespace, name, stored.Encrypted)
+	}
+	values := make(map[string]string, len(spec.Data))
+	for key, value := range spec.Data {
+		if value == v1alpha1.RedactedSecretValue {
+			if openErr != nil {
+				return fmt.Errorf("open stored secret: %w", openErr)
+			}
+			old, ok := previous[key]
+			if !ok {
+				return fmt.Errorf("spec.data.%s: %w: redacted placeholder has no stored value to keep", key, v1alpha1.ErrInvalidFormat)
+			}
+			value = old
+		}
+		values[key] = value
+	}
+	spec.Data = nil
+	if len(values) == 0 {
+		spec.Encrypted = nil
+		return nil
+	}
+	if previous != nil && maps.Equal(previous, values) {
+		spec.Encrypted = stored.Encrypted
+		return nil
+	}
+	sealed, err := keyring.Seal(namespace, name, values)
+	if err != nil {
+		return err
+	}
+	spec.Encrypted = sealed
+	return nil
+}
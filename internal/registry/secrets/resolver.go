@@ -0,0 +1,66 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+// NewResolver returns the types.SecretResolverFunc the Deployment controller
+// hands to runtime adapters. It reads the Secret row directly rather than
+// through v1alpha1.EnvelopeFromRaw, which redacts. Refs are confined to the
+// referencing object's namespace; validation rejects others, and rows stored
+// before it did are refused here.
+func NewResolver(store *v1alpha1store.Store, keyring *Keyring) types.SecretResolverFunc {
+	return func(ctx context.Context, namespace string, ref v1alpha1.SecretKeyRef) (string, error) {
+		ns := namespace
+		if ns == "" {
+			ns = v1alpha1.DefaultNamespace
+		}
+		if ref.Namespace != "" && ref.Namespace != ns {
+			return "", fmt.Errorf("resolve %s %s/%s: secrets are only readable from namespace %q",
+				v1alpha1.KindSecret, ref.Namespace, ref.Name, ref.Namespace)
+		}
+		name := fmt.Sprintf("%s %s/%s", v1alpha1.KindSecret, ns, ref.Name)
+		if store == nil {
+			return "", fmt.Errorf("resolve %s: secret store is not configured", name)
+		}
+		raw, err := store.GetLatest(ctx, ns, ref.Name)
+		if err != nil {
+			if errors.Is(err, pkgdb.ErrNotFound) {
+				return "", fmt.Errorf("resolve %s: %w", name, v1alpha1.ErrDanglingRef)
+			}
+			return "", fmt.Errorf("resolve %s: %w", name, err)
+		}
+		var spec v1alpha1.SecretSpec
+		if err := json.Unmarshal(raw.Spec, &spec); err != nil {
+			return "", fmt.Errorf("resolve %s: decode spec: %w", name, err)
+		}
+		values, err := keyring.Open(ns, ref.Name, spec.Encrypted)
+		if err != nil {
+			return "", fmt.Errorf("resolve %s: %w", name, err)
+		}
+		key := ref.Key
+		if key == "" {
+			if len(values) != 1 {
+				return "", fmt.Errorf("resolve %s: key is required when the secret has %d keys", name, len(values))
+			}
+			for k := range values {
+				key = k
+			}
+		}
+		value, ok := values[key]
+		if !ok {
+			return "", fmt.Errorf("resolve %s: key %q (have %s): %w",
+				name, key, strings.Join(spec.Keys(), ", "), v1alpha1.ErrDanglingRef)
+		}
+		return value, nil
+	}
+}
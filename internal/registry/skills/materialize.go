@@ -0,0 +1,181 @@
+// Package skills materializes a Skill's files from its content-addressed
+// source: an OCI artifact or a tarball uploaded to the registry. Both are
+// keyed on the digest the Skill controller pinned in
+// status.resolvedSource.digest, so every reader (the Skill controller, the
+// MCP resources surface, `arctl pull skill`) sees the same bytes. Git
+// sources are cloned at their pinned commit by the callers and are not
+// handled here.
+package skills
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/plugins/bundle"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/plugins/source"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// ManifestFile is the file every skill carries at its root.
+const ManifestFile = "SKILL.md"
+
+var (
+	// ErrNotResolved marks a content-addressed Skill the controller has not
+	// pinned yet; retry once status.resolvedSource.digest is set.
+	ErrNotResolved = errors.New("skill source is not resolved yet")
+	// ErrNoDigestSource marks a Skill whose source is not an OCI artifact or
+	// an uploaded archive.
+	ErrNoDigestSource = errors.New("skill source is not an oci artifact or uploaded archive")
+	// ErrMissingManifest marks content without a SKILL.md at its root.
+	ErrMissingManifest = errors.New("skill has no " + ManifestFile + " at its root")
+	// ErrDigestMismatch marks archive bytes that do not hash to the digest
+	// they were fetched by.
+	ErrDigestMismatch = errors.New("skill archive digest mismatch")
+)
+
+// ArchiveFetcher returns the bytes of the uploaded archive stored under
+// digest. The registry reads its skill_archives table; the CLI downloads
+// `GET /v0/skill-archives/{digest}`.
+type ArchiveFetcher func(ctx context.Context, digest string) ([]byte, error)
+
+// Materializer loads content-addressed Skills. FetchArchive serves archive
+// sources; when nil they are refused. OCIOptions configure registry pulls.
+type Materializer struct {
+	FetchArchive ArchiveFetcher
+	OCIOptions   []remote.Option
+}
+
+// NewMaterializer returns a Materializer whose OCI pulls authenticate like
+// `docker pull` unless opts override it.
+func NewMaterializer(fetchArchive ArchiveFetcher, opts ...remote.Option) *Materializer {
+	return &Materializer{
+		FetchArchive: fetchArchive,
+		OCIOptions:   append([]remote.Option{remote.WithAuthFromKeychain(authn.DefaultKeychain)}, opts...),
+	}
+}
+
+// HasDigestSource reports whether skill's source is an OCI artifact or an
+// uploaded archive, i.e. whether Materialize handles it.
+func HasDigestSource(skill *v1alpha1.Skill) bool {
+	src := skill.Spec.Source
+	return src != nil && (src.OCI != nil || src.Archive != nil)
+}
+
+// Materialize returns the files of skill at its pinned digest.
+func (m *Materializer) Materialize(ctx context.Context, skill *v1alpha1.Skill) (*bundle.CanonicalBundle, error) {
+	if !HasDigestSource(skill) {
+		return nil, ErrNoDigestSource
+	}
+	pinned := skill.Status.ResolvedSource
+	if pinned == nil || pinned.Digest == "" {
+		return nil, ErrNotResolved
+	}
+	if skill.Spec.Source.OCI != nil {
+		ref, err := name.NewDigest(skill.Spec.Source.OCI.Reference)
+		if err != nil {
+			return nil, fmt.Errorf("parse oci reference: %w", err)
+		}
+		return m.PullOCI(ctx, ref.Context().Digest(pinned.Digest))
+	}
+	if m.FetchArchive == nil {
+		return nil, errors.New("uploaded skill archives are not available")
+	}
+	content, err := m.FetchArchive(ctx, pinned.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("fetch skill archive %s: %w", pinned.Digest, err)
+	}
+	if got := ArchiveDigest(content); got != pinned.Digest {
+		return nil, fmt.Errorf("%w: fetched %s, want %s", ErrDigestMismatch, got, pinned.Digest)
+	}
+	return FromArchive(content)
+}
+
+// PullOCI pulls the skill artifact at ref and checks that it holds a
+// SKILL.md at its root. The Skill controller calls it before pinning, so a
+// pinned OCI digest is known to be a skill.
+func (m *Materializer) PullOCI(ctx context.Context, ref name.Digest) (*bundle.CanonicalBundle, error) {
+	b, err := source.PullOCI(ctx, ref, m.OCIOptions...)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := b.Files[ManifestFile]; !ok {
+		return nil, ErrMissingManifest
+	}
+	return b, nil
+}
+
+// ArchiveDigest returns the "sha256:<hex>" digest uploaded archives are
+// stored under.
+func ArchiveDigest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// FromArchive unpacks a gzip-compressed skill tarball. Directory entries
+// are implied by file paths; links and other special entries are refused,
+// as upload validation refuses them.
+func FromArchive(content []byte) (*bundle.CanonicalBundle, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: archive is not gzip-compressed: %v", bundle.ErrInvalidBundle, err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	b := bundle.NewBuilder()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: read archive: %v", bundle.ErrInvalidBundle, err)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			continue
+		case tar.TypeReg:
+		default:
+			return nil, fmt.Errorf("%w: archive entry %q is not a regular file or directory", bundle.ErrInvalidBundle, hdr.Name)
+		}
+		if err := b.Add(hdr.Name, tr); err != nil {
+			return nil, err
+		}
+	}
+	out := b.Bundle()
+	if _, ok := out.Files[ManifestFile]; !ok {
+		return nil, ErrMissingManifest
+	}
+	return out, nil
+}
+
+// WriteDir writes b's files beneath dir, creating directories as needed.
+// Paths that are not local to dir are refused.
+func WriteDir(b *bundle.CanonicalBundle, dir string) error {
+	for p, content := range b.Files {
+		if !filepath.IsLocal(filepath.FromSlash(p)) {
+			return fmt.Errorf("%w: %q escapes the skill directory", bundle.ErrInvalidBundle, p)
+		}
+		dst := filepath.Join(dir, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dst, content, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
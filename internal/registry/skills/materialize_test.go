@@ -0,0 +1,161 @@
+package skills
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/plugins/source"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// tarGz builds a gzip-compressed tar of path->content entries.
+func tarGz(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for p, content := range entries {
+		if err := tw.WriteHeader(&tar.Header{Name: p, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// pushSkillImage pushes a one-layer image holding entries to an in-process
+// registry and returns its digest-pinned reference.
+func pushSkillImage(t *testing.T, entries map[string]string) name.Digest {
+	t.Helper()
+	content := tarGz(t, entries)
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(content)), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := mutate.AppendLayers(empty.Image, layer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(srv.Close)
+	tag, err := name.NewTag(strings.TrimPrefix(srv.URL, "http://") + "/acme/skill:v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(tag, img); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tag.Context().Digest(digest.String())
+}
+
+func archiveSkill(digest string, pinned bool) *v1alpha1.Skill {
+	skill := &v1alpha1.Skill{Spec: v1alpha1.SkillSpec{Source: &v1alpha1.SkillSource{Archive: &v1alpha1.SkillSourceArchive{Digest: digest}}}}
+	if pinned {
+		skill.Status.ResolvedSource = &v1alpha1.SkillResolvedSource{Digest: digest}
+	}
+	return skill
+}
+
+func TestMaterializeArchive(t *testing.T) {
+	content := tarGz(t, map[string]string{"SKILL.md": "# summarize\n", "./scripts/run.sh": "echo hi\n"})
+	digest := ArchiveDigest(content)
+	fetch := func(_ context.Context, got string) ([]byte, error) {
+		if got != digest {
+			return nil, errors.New("not found")
+		}
+		return content, nil
+	}
+	m := NewMaterializer(fetch)
+
+	b, err := m.Materialize(context.Background(), archiveSkill(digest, true))
+	if err != nil {
+		t.Fatalf("Materialize: %v", err)
+	}
+	if got := string(b.Files["SKILL.md"]); got != "# summarize\n" {
+		t.Fatalf("SKILL.md = %q", got)
+	}
+
+	dir := t.TempDir()
+	if err := WriteDir(b, dir); err != nil {
+		t.Fatalf("WriteDir: %v", err)
+	}
+	if got, err := os.ReadFile(filepath.Join(dir, "scripts", "run.sh")); err != nil || string(got) != "echo hi\n" {
+		t.Fatalf("scripts/run.sh = %q, %v", got, err)
+	}
+
+	if _, err := m.Materialize(context.Background(), archiveSkill(digest, false)); !errors.Is(err, ErrNotResolved) {
+		t.Fatalf("unpinned Materialize error = %v, want ErrNotResolved", err)
+	}
+	other := "sha256:" + strings.Repeat("0", 64)
+	if _, err := m.Materialize(context.Background(), archiveSkill(other, true)); err == nil {
+		t.Fatal("Materialize of another digest succeeded")
+	}
+	tampered := NewMaterializer(func(context.Context, string) ([]byte, error) {
+		return tarGz(t, map[string]string{"SKILL.md": "# evil\n"}), nil
+	})
+	if _, err := tampered.Materialize(context.Background(), archiveSkill(digest, true)); !errors.Is(err, ErrDigestMismatch) {
+		t.Fatalf("tampered Materialize error = %v, want ErrDigestMismatch", err)
+	}
+}
+
+func TestMaterializeOCI(t *testing.T) {
+	ref := pushSkillImage(t, map[string]string{"SKILL.md": "# pdf\n"})
+	skill := &v1alpha1.Skill{
+		Spec:   v1alpha1.SkillSpec{Source: &v1alpha1.SkillSource{OCI: &v1alpha1.SkillSourceOCI{Reference: ref.String()}}},
+		Status: v1alpha1.SkillStatus{ResolvedSource: &v1alpha1.SkillResolvedSource{Digest: ref.DigestStr()}},
+	}
+	b, err := NewMaterializer(nil).Materialize(context.Background(), skill)
+	if err != nil {
+		t.Fatalf("Materialize: %v", err)
+	}
+	if got := string(b.Files["SKILL.md"]); got != "# pdf\n" {
+		t.Fatalf("SKILL.md = %q", got)
+	}
+
+	if _, err := NewMaterializer(nil).PullOCI(context.Background(), pushSkillImage(t, map[string]string{"README.md": "x"})); !errors.Is(err, ErrMissingManifest) {
+		t.Fatalf("PullOCI without SKILL.md error = %v, want ErrMissingManifest", err)
+	}
+	missing := ref.Context().Digest(v1.Hash{Algorithm: "sha256", Hex: strings.Repeat("0", 64)}.String())
+	if _, err := NewMaterializer(nil).PullOCI(context.Background(), missing); !errors.Is(err, source.ErrSourceNotFound) {
+		t.Fatalf("PullOCI of a missing digest error = %v, want ErrSourceNotFound", err)
+	}
+}
+
+func TestMaterializeRejectsGitSources(t *testing.T) {
+	skill := &v1alpha1.Skill{Spec: v1alpha1.SkillSpec{Source: &v1alpha1.SkillSource{Repository: &v1alpha1.Repository{URL: "https://github.com/acme/skills"}}}}
+	if _, err := NewMaterializer(nil).Materialize(context.Background(), skill); !errors.Is(err, ErrNoDigestSource) {
+		t.Fatalf("Materialize error = %v, want ErrNoDigestSource", err)
+	}
+}
@@ -0,0 +1,29 @@
+package v0
+
+// AccessReview is the body of GET /v0/auth/can-i: whether the caller may
+// perform Verb on the Kind object Namespace/Name. Name is empty for a
+// question about the whole kind (e.g. list), Namespace is empty across
+// every namespace. Reason explains a denial.
+type AccessReview struct {
+	Verb      string `json:"verb"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Allowed   bool   `json:"allowed"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// AuthConfig is the body of GET /v0/auth/config: how clients such as
+// `arctl login` sign in to this registry. OIDC is nil when the registry
+// offers no interactive sign-in.
+type AuthConfig struct {
+	OIDC *OIDCLoginConfig `json:"oidc,omitempty"`
+}
+
+// OIDCLoginConfig names the OpenID Connect issuer and the public client
+// a CLI signs in with.
+type OIDCLoginConfig struct {
+	Issuer   string   `json:"issuer" doc:"OpenID Connect issuer URL."`
+	ClientID string   `json:"clientId" doc:"Public OAuth client ID for CLI sign-in."`
+	Scopes   []string `json:"scopes" doc:"Scopes to request."`
+}
@@ -19,6 +19,9 @@ type ApplyResult struct {
 	Generation int64 `json:"-"`
 	// Error is the failure detail for Status=="failed".
 	Error string `json:"error,omitempty"`
+	// Warnings are non-fatal notes raised while applying, such as a
+	// reference to a deprecated tag.
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 // ApplyStatus* are the well-known Status values on ApplyResult.
@@ -0,0 +1,29 @@
+package v0
+
+import "time"
+
+// NamespaceOwner records that the holder of PublicKey proved control of
+// Domain, and so owns Namespace, the domain reversed.
+type NamespaceOwner struct {
+	Namespace  string    `json:"namespace" doc:"Owned namespace: the domain reversed, e.g. com.acme for acme.com."`
+	Domain     string    `json:"domain"`
+	Method     string    `json:"method" enum:"dns,http" doc:"How ownership was proven: a DNS TXT record on the domain, or https://DOMAIN/.well-known/mcp-registry-auth."`
+	PublicKey  string    `json:"publicKey" doc:"Base64 Ed25519 public key that proved ownership. Artifacts published in the namespace must be signed with it."`
+	VerifiedBy string    `json:"verifiedBy,omitempty" doc:"Subject of the caller who submitted the latest proof."`
+	VerifiedAt time.Time `json:"verifiedAt" doc:"When ownership was last proven."`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// ClaimNamespaceRequest is the body of POST /v0/namespace-owners: a
+// proof of control of Domain, made with a key published for it.
+type ClaimNamespaceRequest struct {
+	Method    string `json:"method" enum:"dns,http" doc:"Where the domain publishes its key: a TXT record (dns) or https://DOMAIN/.well-known/mcp-registry-auth (http). Either holds v=MCPv1; k=ed25519; p=BASE64_PUBLIC_KEY."`
+	Domain    string `json:"domain" minLength:"1" maxLength:"253" doc:"Domain to prove control of, e.g. acme.com. Proving it claims the namespace com.acme."`
+	Timestamp string `json:"timestamp" doc:"Current time in RFC 3339 format. Must be within 15 seconds of the registry's clock."`
+	Signature string `json:"signature" doc:"Hex Ed25519 signature, by the published key, of the lines 'agentregistry namespace claim v1', 'domain=DOMAIN', 'namespace=NAMESPACE' and 'timestamp=TIMESTAMP', each ending in a newline."`
+}
+
+// NamespaceOwnerList is the body of GET /v0/namespace-owners.
+type NamespaceOwnerList struct {
+	Owners []NamespaceOwner `json:"owners"`
+}
@@ -0,0 +1,23 @@
+package v0
+
+// Referrer is one object whose spec references another. Tag is empty for
+// mutable kinds (Deployment, Runtime, ...). Path is the spec field holding
+// the reference, e.g. "spec.mcpServers[0]".
+type Referrer struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Tag       string `json:"tag,omitempty"`
+	Path      string `json:"path,omitempty"`
+}
+
+// ReferrerListResponse is the body of GET /v0/{plural}/{name}/{tag}/referrers
+// (tagged kinds) and GET /v0/{plural}/{name}/referrers (mutable kinds).
+// Tag is the concrete tag the request resolved to.
+type ReferrerListResponse struct {
+	Kind      string     `json:"kind"`
+	Namespace string     `json:"namespace"`
+	Name      string     `json:"name"`
+	Tag       string     `json:"tag,omitempty"`
+	Items     []Referrer `json:"items"`
+}
@@ -0,0 +1,23 @@
+package v0
+
+// SearchResult is one ranked hit of GET /v0/search. Tag is the
+// best-matching tag for tagged kinds and empty for mutable ones.
+// Highlight is an excerpt with matched terms wrapped in ** markers; it is
+// empty when only the name matched. Score is relative to the other hits
+// of the same query.
+type SearchResult struct {
+	Kind        string  `json:"kind"`
+	Namespace   string  `json:"namespace"`
+	Name        string  `json:"name"`
+	Tag         string  `json:"tag,omitempty"`
+	Title       string  `json:"title,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Highlight   string  `json:"highlight,omitempty"`
+	Score       float32 `json:"score"`
+}
+
+// SearchResponse is the body of GET /v0/search.
+type SearchResponse struct {
+	Query string         `json:"query"`
+	Items []SearchResult `json:"items"`
+}
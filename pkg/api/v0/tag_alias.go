@@ -0,0 +1,34 @@
+package v0
+
+import "time"
+
+// TagAlias is a movable tag (e.g. "stable") that points at a concrete tag
+// of one tagged-artifact object. References that name the alias resolve to
+// Target. Returned by GET /v0/{plural}/{name}/aliases and the promote
+// endpoint.
+type TagAlias struct {
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Alias     string    `json:"alias"`
+	Target    string    `json:"target"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// PromoteTagRequest is the body of POST /v0/{plural}/{name}/promote. From is
+// a concrete tag or an existing alias; To is the alias to create or move.
+type PromoteTagRequest struct {
+	From string `json:"from" doc:"Concrete tag or existing alias to promote."`
+	To   string `json:"to" doc:"Alias to create or move (e.g. stable)."`
+}
+
+// PromoteTagResponse reports the alias after a promote. Status is created,
+// configured (the alias moved) or unchanged, matching ApplyResult.
+type PromoteTagResponse struct {
+	TagAlias
+	Status string `json:"status"`
+}
+
+// TagAliasListResponse is the body of GET /v0/{plural}/{name}/aliases.
+type TagAliasListResponse struct {
+	Items []TagAlias `json:"items"`
+}
@@ -0,0 +1,19 @@
+package v0
+
+// SetTagLifecycleRequest is the body of PUT /v0/{plural}/{name}/{tag}/lifecycle.
+// State Active clears a previous deprecation or yank.
+type SetTagLifecycleRequest struct {
+	State       string `json:"state" enum:"Active,Deprecated,Yanked" doc:"Lifecycle state of the tag."`
+	Reason      string `json:"reason,omitempty" doc:"Why the tag was deprecated or yanked."`
+	Replacement string `json:"replacement,omitempty" doc:"Existing tag consumers should move to."`
+}
+
+// TagLifecycleResponse reports a tag's lifecycle after an update.
+type TagLifecycleResponse struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	Tag         string `json:"tag"`
+	State       string `json:"state"`
+	Reason      string `json:"reason,omitempty"`
+	Replacement string `json:"replacement,omitempty"`
+}
@@ -0,0 +1,39 @@
+package v0
+
+import "time"
+
+// TagRevision is one generation of a tag's content. The current revision is
+// the live row; earlier ones were kept when an apply or rollback replaced
+// them. Returned by GET /v0/{plural}/{name}/{tag}/revisions, newest first.
+type TagRevision struct {
+	Namespace   string    `json:"namespace"`
+	Name        string    `json:"name"`
+	Tag         string    `json:"tag"`
+	UID         string    `json:"uid"`
+	Generation  int64     `json:"generation"`
+	ContentHash string    `json:"contentHash"`
+	Current     bool      `json:"current,omitempty"`
+	AppliedAt   time.Time `json:"appliedAt"`
+	ReplacedAt  time.Time `json:"replacedAt,omitzero"`
+}
+
+// TagRevisionListResponse is the body of GET /v0/{plural}/{name}/{tag}/revisions.
+type TagRevisionListResponse struct {
+	Items []TagRevision `json:"items"`
+}
+
+// RollbackTagRequest is the body of POST /v0/{plural}/{name}/{tag}/rollback.
+type RollbackTagRequest struct {
+	Generation int64 `json:"generation" minimum:"1" doc:"Generation whose content becomes current again."`
+}
+
+// RollbackTagResponse reports the tag after a rollback. Generation is the
+// tag's new generation; Status is configured, or unchanged when the
+// revision already matched the current content.
+type RollbackTagResponse struct {
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	Tag        string `json:"tag"`
+	Generation int64  `json:"generation"`
+	Status     string `json:"status"`
+}
@@ -0,0 +1,44 @@
+package v0
+
+import "time"
+
+// TokenScope limits what an API token may do: Action is one of read,
+// publish, edit, deploy or delete, and Resource is "*" or
+// NAMESPACE/NAME, where NAMESPACE may be "*" and NAME may end in "*".
+type TokenScope struct {
+	Action   string `json:"action" enum:"read,publish,edit,deploy,delete" doc:"Action the scope allows: read (get and list), publish, edit or deploy (apply), or delete."`
+	Resource string `json:"resource" doc:"Resources the scope covers: \"*\", or NAMESPACE/NAME where NAMESPACE may be \"*\" and NAME may end in \"*\" (e.g. team-a/support-*)."`
+}
+
+// APIToken describes an API token. The token itself is only returned
+// once, by CreateAPITokenResponse.
+type APIToken struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	Owner      string       `json:"owner" doc:"Subject of the user who created the token."`
+	Subject    string       `json:"subject" doc:"Identity the token authenticates as: the owner, or serviceaccount:NAME."`
+	Scopes     []TokenScope `json:"scopes"`
+	ExpiresAt  time.Time    `json:"expiresAt"`
+	LastUsedAt *time.Time   `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time   `json:"revokedAt,omitempty"`
+	CreatedAt  time.Time    `json:"createdAt"`
+}
+
+// CreateAPITokenRequest is the body of POST /v0/tokens.
+type CreateAPITokenRequest struct {
+	Name           string       `json:"name" minLength:"1" maxLength:"255" doc:"Name to recognize the token by."`
+	Scopes         []TokenScope `json:"scopes" minItems:"1" doc:"What the token may do. Scopes narrow what its subject is granted; they never widen it."`
+	ExpiresAt      time.Time    `json:"expiresAt" doc:"When the token stops working: in the future and at most a year away."`
+	ServiceAccount string       `json:"serviceAccount,omitempty" doc:"Issue the token to this service account instead of the caller. Requires registry admin."`
+}
+
+// CreateAPITokenResponse is the body returned by POST /v0/tokens.
+type CreateAPITokenResponse struct {
+	Token    string   `json:"token" doc:"The API token. It is shown only once."`
+	APIToken APIToken `json:"apiToken"`
+}
+
+// APITokenList is the body of GET /v0/tokens.
+type APITokenList struct {
+	Tokens []APIToken `json:"tokens"`
+}
@@ -0,0 +1,34 @@
+package v0
+
+import "encoding/json"
+
+// Watch event types, carried in WatchEvent.Type and as the SSE event name
+// of GET /v0/{plural}?watch=true.
+const (
+	// WatchEventAdded reports an object entering the watched list.
+	WatchEventAdded = "ADDED"
+	// WatchEventModified reports a change to an object already in the list.
+	WatchEventModified = "MODIFIED"
+	// WatchEventDeleted reports an object leaving the list, either deleted
+	// or no longer matching the list's filters. Its object carries
+	// identity only.
+	WatchEventDeleted = "DELETED"
+	// WatchEventBookmark marks the end of the initial listing of a watch
+	// started without a resourceVersion. It has no object.
+	WatchEventBookmark = "BOOKMARK"
+	// WatchEventError ends the stream. Status 410 means the stream fell
+	// behind the retained history; restart the watch without a
+	// resourceVersion.
+	WatchEventError = "ERROR"
+)
+
+// WatchEvent is one event of a ?watch=true list stream. ResourceVersion
+// is the control-plane revision the stream has reached; pass it back as
+// ?resourceVersion= to resume after a disconnect.
+type WatchEvent struct {
+	Type            string          `json:"type"`
+	ResourceVersion int64           `json:"resourceVersion"`
+	Object          json.RawMessage `json:"object,omitempty"`
+	Status          int             `json:"status,omitempty"`
+	Message         string          `json:"message,omitempty"`
+}
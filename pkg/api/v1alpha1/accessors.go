@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"context"
 	"encoding/json"
+	"errors"
 )
 
 func (tm *TypeMeta) GetAPIVersion() string { return tm.APIVersion }
@@ -63,6 +64,20 @@ type RegistryValidatable interface {
 	ValidateRegistries(ctx context.Context, v RegistryValidatorFunc) error
 }
 
+// Redactor strips sensitive material from an envelope before it leaves the
+// server. EnvelopeFromRaw applies it on every decode so read paths cannot
+// forget to.
+type Redactor interface {
+	Redact()
+}
+
+// RedactObject redacts obj when it carries sensitive material.
+func RedactObject(obj Object) {
+	if r, ok := any(obj).(Redactor); ok {
+		r.Redact()
+	}
+}
+
 // ValidateObject runs structural validation when obj opts into it.
 func ValidateObject(obj Object) error {
 	if v, ok := any(obj).(StructuralValidator); ok {
@@ -82,6 +97,48 @@ func ResolveObjectRefs(ctx context.Context, obj Object, resolver ResolverFunc) e
 	return nil
 }
 
+// FieldRef is one cross-resource reference held by an object, with the
+// spec path it was read from (e.g. "spec.mcpServers[0]"). Ref carries the
+// same Kind/Namespace defaulting the apply-time resolver sees.
+type FieldRef struct {
+	Path string
+	Ref  ResourceRef
+}
+
+// errRecordedRef is returned by ObjectRefs' probing resolver so each ref
+// surfaces as a FieldError carrying its spec path.
+var errRecordedRef = errors.New("recorded reference")
+
+// ObjectRefs returns every ResourceRef obj holds, in the order its
+// RefResolver visits them. Kinds without a RefResolver hold no refs. Path
+// is left blank when the kind's ResolveRefs does not report one FieldError
+// per failing ref.
+func ObjectRefs(ctx context.Context, obj Object) []FieldRef {
+	var refs []FieldRef
+	_ = ResolveObjectRefs(ctx, obj, func(_ context.Context, ref ResourceRef) error {
+		refs = append(refs, FieldRef{Ref: ref})
+		return nil
+	})
+	if len(refs) == 0 {
+		return nil
+	}
+	// Second pass: fail every lookup so the paths come back on the
+	// FieldErrors. Kinds that stop at the first failure fall out of the
+	// length check and keep blank paths.
+	err := ResolveObjectRefs(ctx, obj, func(context.Context, ResourceRef) error {
+		return errRecordedRef
+	})
+	var fieldErrs FieldErrors
+	if errors.As(err, &fieldErrs) && len(fieldErrs) == len(refs) {
+		for i, fe := range fieldErrs {
+			if errors.Is(fe.Cause, errRecordedRef) {
+				refs[i].Path = fe.Path
+			}
+		}
+	}
+	return refs
+}
+
 // ValidateObjectRegistries validates package registries when obj exposes them.
 func ValidateObjectRegistries(ctx context.Context, obj Object, v RegistryValidatorFunc) error {
 	if v == nil {
@@ -122,11 +179,50 @@ func (m *MCPServer) MarshalSpec() (json.RawMessage, error) { return json.Marshal
 func (m *MCPServer) UnmarshalSpec(data json.RawMessage) error {
 	return json.Unmarshal(data, &m.Spec)
 }
+
+// MarshalStatus serializes the typed MCPServerStatus: the embedded Status via
+// the storage codec, with the controller-observed Capabilities spliced onto
+// the same object. A nil Capabilities is omitted (no stray null) so the
+// store's patch-skip byte comparison stays stable.
 func (m *MCPServer) MarshalStatus() (json.RawMessage, error) {
-	return MarshalStatusForStorage(m.Status)
+	base, err := MarshalStatusForStorage(m.Status.Status)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]json.RawMessage{}
+	if err := json.Unmarshal(base, &out); err != nil {
+		return nil, err
+	}
+	if m.Status.Capabilities != nil {
+		if out["capabilities"], err = json.Marshal(m.Status.Capabilities); err != nil {
+			return nil, err
+		}
+	}
+	if m.Status.Image != nil {
+		if out["image"], err = json.Marshal(m.Status.Image); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(out)
 }
 func (m *MCPServer) UnmarshalStatus(data json.RawMessage) error {
-	return UnmarshalStatusFromStorage(data, &m.Status)
+	if len(data) == 0 {
+		m.Status = MCPServerStatus{}
+		return nil
+	}
+	if err := UnmarshalStatusFromStorage(data, &m.Status.Status); err != nil {
+		return err
+	}
+	var custom struct {
+		Capabilities *MCPServerCapabilities `json:"capabilities"`
+		Image        *MCPServerImage        `json:"image"`
+	}
+	if err := json.Unmarshal(data, &custom); err != nil {
+		return err
+	}
+	m.Status.Capabilities = custom.Capabilities
+	m.Status.Image = custom.Image
+	return nil
 }
 
 func (s *Skill) GetMetadata() *ObjectMeta { return &s.Metadata }
@@ -279,6 +375,66 @@ func (m *Model) UnmarshalStatus(data json.RawMessage) error {
 	return UnmarshalStatusFromStorage(data, &m.Status)
 }
 
+func (s *Secret) GetMetadata() *ObjectMeta { return &s.Metadata }
+func (s *Secret) SetMetadata(meta ObjectMeta) {
+	s.Metadata = meta
+}
+func (s *Secret) MarshalSpec() (json.RawMessage, error) { return json.Marshal(s.Spec) }
+func (s *Secret) UnmarshalSpec(data json.RawMessage) error {
+	return json.Unmarshal(data, &s.Spec)
+}
+func (s *Secret) MarshalStatus() (json.RawMessage, error) {
+	return MarshalStatusForStorage(s.Status)
+}
+func (s *Secret) UnmarshalStatus(data json.RawMessage) error {
+	return UnmarshalStatusFromStorage(data, &s.Status)
+}
+
+func (r *Role) GetMetadata() *ObjectMeta { return &r.Metadata }
+func (r *Role) SetMetadata(meta ObjectMeta) {
+	r.Metadata = meta
+}
+func (r *Role) MarshalSpec() (json.RawMessage, error) { return json.Marshal(r.Spec) }
+func (r *Role) UnmarshalSpec(data json.RawMessage) error {
+	return json.Unmarshal(data, &r.Spec)
+}
+func (r *Role) MarshalStatus() (json.RawMessage, error) {
+	return MarshalStatusForStorage(r.Status)
+}
+func (r *Role) UnmarshalStatus(data json.RawMessage) error {
+	return UnmarshalStatusFromStorage(data, &r.Status)
+}
+
+func (c *ClusterRole) GetMetadata() *ObjectMeta { return &c.Metadata }
+func (c *ClusterRole) SetMetadata(meta ObjectMeta) {
+	c.Metadata = meta
+}
+func (c *ClusterRole) MarshalSpec() (json.RawMessage, error) { return json.Marshal(c.Spec) }
+func (c *ClusterRole) UnmarshalSpec(data json.RawMessage) error {
+	return json.Unmarshal(data, &c.Spec)
+}
+func (c *ClusterRole) MarshalStatus() (json.RawMessage, error) {
+	return MarshalStatusForStorage(c.Status)
+}
+func (c *ClusterRole) UnmarshalStatus(data json.RawMessage) error {
+	return UnmarshalStatusFromStorage(data, &c.Status)
+}
+
+func (b *RoleBinding) GetMetadata() *ObjectMeta { return &b.Metadata }
+func (b *RoleBinding) SetMetadata(meta ObjectMeta) {
+	b.Metadata = meta
+}
+func (b *RoleBinding) MarshalSpec() (json.RawMessage, error) { return json.Marshal(b.Spec) }
+func (b *RoleBinding) UnmarshalSpec(data json.RawMessage) error {
+	return json.Unmarshal(data, &b.Spec)
+}
+func (b *RoleBinding) MarshalStatus() (json.RawMessage, error) {
+	return MarshalStatusForStorage(b.Status)
+}
+func (b *RoleBinding) UnmarshalStatus(data json.RawMessage) error {
+	return UnmarshalStatusFromStorage(data, &b.Status)
+}
+
 func (d *Deployment) GetMetadata() *ObjectMeta { return &d.Metadata }
 func (d *Deployment) SetMetadata(meta ObjectMeta) {
 	d.Metadata = meta
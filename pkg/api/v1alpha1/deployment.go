@@ -74,10 +74,18 @@ type DeploymentSpec struct {
 	DeploymentRefs []DeploymentRef   `json:"deploymentRefs,omitempty" yaml:"deploymentRefs,omitempty"`
 	Env            map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
 	RuntimeConfig  map[string]any    `json:"runtimeConfig,omitempty" yaml:"runtimeConfig,omitempty"`
+	// PromptArguments supplies values for the arguments declared by the
+	// target Agent's instructions Prompt. Runtime adapters render the
+	// Prompt with them; a missing required argument fails the apply.
+	PromptArguments map[string]string `json:"promptArguments,omitempty" yaml:"promptArguments,omitempty"`
 	// Harness selects a compatible harness for Agent deployments and configures
 	// rollout-specific harness policy. Omitted for BYO image/source Agent
 	// deployments and MCPServer deployments.
 	Harness *DeploymentHarness `json:"harness,omitempty" yaml:"harness,omitempty"`
+	// RequireSignedTarget holds the Deployment back until the resolved
+	// target tag carries a signature the registry verified against a
+	// trusted key on apply (its SignatureVerified status condition).
+	RequireSignedTarget bool `json:"requireSignedTarget,omitempty" yaml:"requireSignedTarget,omitempty"`
 }
 
 // EffectiveModelRef returns the explicit ModelRef or the conventional
@@ -88,11 +88,6 @@ func validateDeploymentSpec(s *DeploymentSpec) FieldErrors {
 		errs.Append("spec.runtimeRef."+e.Path, e.Cause)
 	}
 
-	if s.TargetRef.Tag != "" {
-		if err := validateTag(s.TargetRef.Tag); err != nil {
-			errs.Append("spec.targetRef.tag", err)
-		}
-	}
 	if s.Harness != nil {
 		if s.TargetRef.Kind != KindAgent {
 			errs.Append("spec.harness", fmt.Errorf("%w: harness selection is only valid for Agent deployments", ErrInvalidFormat))
@@ -123,6 +118,15 @@ func validateDeploymentSpec(s *DeploymentSpec) FieldErrors {
 				DesiredStateDeployed, DesiredStateUndeployed))
 	}
 
+	if len(s.PromptArguments) > 0 && s.TargetRef.Kind != KindAgent {
+		errs.Append("spec.promptArguments", fmt.Errorf("%w: prompt arguments are only valid for Agent deployments", ErrInvalidFormat))
+	}
+	for name := range s.PromptArguments {
+		if !promptArgumentNameRegex.MatchString(name) {
+			errs.Append("spec.promptArguments."+name, fmt.Errorf("%w: must match %s", ErrInvalidFormat, promptArgumentNameRegex.String()))
+		}
+	}
+
 	for i, ref := range s.DeploymentRefs {
 		path := fmt.Sprintf("spec.deploymentRefs[%d]", i)
 		if err := validateNameField(ref.Name); err != nil {
@@ -1,12 +1,12 @@
 // Package v1alpha1 defines the Kubernetes-style API types for all agentregistry
 // resources.
 //
-// Every resource — Agent, MCPServer, Skill, Prompt, Deployment, Runtime, Model —
-// uses the same envelope: apiVersion + kind + metadata + spec + status.
-// These types are the single wire/storage/API contract propagating from a YAML
-// manifest through the HTTP handler, Go client, service layer, and database
-// row (spec+status as JSONB; metadata columns promoted). No intermediate DTOs,
-// no translation functions.
+// Every resource — Agent, MCPServer, Skill, Prompt, Deployment, Runtime, Model,
+// Secret, Role, ClusterRole, RoleBinding — uses the same envelope: apiVersion +
+// kind + metadata + spec + status. These types are the single
+// wire/storage/API contract propagating from a YAML manifest through the HTTP
+// handler, Go client, service layer, and database row (spec+status as JSONB;
+// metadata columns promoted). No intermediate DTOs, no translation functions.
 //
 // Typed objects (Agent, MCPServer, etc.) are the preferred handle. RawObject
 // is the un-typed wire envelope used during apply dispatch when the kind is
@@ -24,14 +24,18 @@ const GroupVersion = "ar.dev/v1alpha1"
 
 // Canonical Kind names.
 const (
-	KindAgent      = "Agent"
-	KindMCPServer  = "MCPServer"
-	KindSkill      = "Skill"
-	KindPlugin     = "Plugin"
-	KindPrompt     = "Prompt"
-	KindDeployment = "Deployment"
-	KindRuntime    = "Runtime"
-	KindModel      = "Model"
+	KindAgent       = "Agent"
+	KindMCPServer   = "MCPServer"
+	KindSkill       = "Skill"
+	KindPlugin      = "Plugin"
+	KindPrompt      = "Prompt"
+	KindDeployment  = "Deployment"
+	KindRuntime     = "Runtime"
+	KindModel       = "Model"
+	KindSecret      = "Secret"
+	KindRole        = "Role"
+	KindClusterRole = "ClusterRole"
+	KindRoleBinding = "RoleBinding"
 )
 
 var (
@@ -1,11 +1,119 @@
 package v1alpha1
 
+import "encoding/json"
+
 // MCPServer is the typed envelope for kind=MCPServer resources.
 type MCPServer struct {
 	TypeMeta `json:",inline" yaml:",inline"`
-	Metadata ObjectMeta    `json:"metadata" yaml:"metadata"`
-	Spec     MCPServerSpec `json:"spec" yaml:"spec"`
-	Status   Status        `json:"status,omitzero" yaml:"status,omitempty"`
+	Metadata ObjectMeta      `json:"metadata" yaml:"metadata"`
+	Spec     MCPServerSpec   `json:"spec" yaml:"spec"`
+	Status   MCPServerStatus `json:"status,omitzero" yaml:"status,omitempty"`
+}
+
+// MCPServerStatus is the MCPServer's status: the generic conditions plus
+// what the MCPServer controller observed the server actually expose.
+type MCPServerStatus struct {
+	Status `json:",inline" yaml:",inline"`
+
+	// Capabilities is the result of the controller's last successful
+	// introspection (initialize + tools/prompts/resources list) of the
+	// server at this tag. Nil until introspection succeeds.
+	Capabilities *MCPServerCapabilities `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+
+	// Image is what the registry verified about an OCI package's image on
+	// apply. Nil unless OCI image verification is configured and the server
+	// ships an OCI package. Runtimes deploy the image at Image.Digest.
+	Image *MCPServerImage `json:"image,omitempty" yaml:"image,omitempty"`
+}
+
+// MCPServerImage records an OCI package image's resolved digest and the
+// cosign signature and SLSA provenance found for it in the same registry.
+type MCPServerImage struct {
+	// Digest is the manifest digest the package identifier resolved to.
+	Digest string `json:"digest" yaml:"digest"`
+	// Signer names the trusted key whose signature over Digest verified.
+	// Empty when the image carries no signature from a trusted key.
+	Signer string `json:"signer,omitempty" yaml:"signer,omitempty"`
+	// ProvenanceBuilder is the builder ID from a verified SLSA provenance
+	// attestation for Digest. Empty when there is none.
+	ProvenanceBuilder string `json:"provenanceBuilder,omitempty" yaml:"provenanceBuilder,omitempty"`
+}
+
+// MCPServerCapabilities is the surface an MCP server reported over the
+// protocol. Lists are in the order the server returned them.
+type MCPServerCapabilities struct {
+	// ProtocolVersion is the MCP protocol version negotiated on initialize.
+	ProtocolVersion string `json:"protocolVersion,omitempty" yaml:"protocolVersion,omitempty"`
+	// ServerInfo is the implementation the server identified itself as.
+	ServerInfo *MCPImplementation `json:"serverInfo,omitempty" yaml:"serverInfo,omitempty"`
+	// Instructions are the server's usage hints from initialize.
+	Instructions string `json:"instructions,omitempty" yaml:"instructions,omitempty"`
+
+	Tools             []MCPToolInfo             `json:"tools,omitempty" yaml:"tools,omitempty"`
+	Prompts           []MCPPromptInfo           `json:"prompts,omitempty" yaml:"prompts,omitempty"`
+	Resources         []MCPResourceInfo         `json:"resources,omitempty" yaml:"resources,omitempty"`
+	ResourceTemplates []MCPResourceTemplateInfo `json:"resourceTemplates,omitempty" yaml:"resourceTemplates,omitempty"`
+}
+
+// MCPImplementation names an MCP server implementation.
+type MCPImplementation struct {
+	Name    string `json:"name" yaml:"name"`
+	Title   string `json:"title,omitempty" yaml:"title,omitempty"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+// MCPToolInfo is one tool from tools/list. The schemas are the JSON Schema
+// documents the server published, stored verbatim.
+type MCPToolInfo struct {
+	Name         string              `json:"name" yaml:"name"`
+	Title        string              `json:"title,omitempty" yaml:"title,omitempty"`
+	Description  string              `json:"description,omitempty" yaml:"description,omitempty"`
+	InputSchema  json.RawMessage     `json:"inputSchema,omitempty" yaml:"inputSchema,omitempty"`
+	OutputSchema json.RawMessage     `json:"outputSchema,omitempty" yaml:"outputSchema,omitempty"`
+	Annotations  *MCPToolAnnotations `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+}
+
+// MCPToolAnnotations are the behavior hints a server declared for a tool.
+// They are self-reported and untrusted, but they are what reviewers and
+// clients act on, so they are recorded as given.
+type MCPToolAnnotations struct {
+	ReadOnlyHint    bool  `json:"readOnlyHint,omitempty" yaml:"readOnlyHint,omitempty"`
+	DestructiveHint *bool `json:"destructiveHint,omitempty" yaml:"destructiveHint,omitempty"`
+	IdempotentHint  bool  `json:"idempotentHint,omitempty" yaml:"idempotentHint,omitempty"`
+	OpenWorldHint   *bool `json:"openWorldHint,omitempty" yaml:"openWorldHint,omitempty"`
+}
+
+// MCPPromptInfo is one prompt from prompts/list.
+type MCPPromptInfo struct {
+	Name        string                  `json:"name" yaml:"name"`
+	Title       string                  `json:"title,omitempty" yaml:"title,omitempty"`
+	Description string                  `json:"description,omitempty" yaml:"description,omitempty"`
+	Arguments   []MCPPromptArgumentInfo `json:"arguments,omitempty" yaml:"arguments,omitempty"`
+}
+
+// MCPPromptArgumentInfo is one argument a server-side prompt accepts.
+type MCPPromptArgumentInfo struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool   `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// MCPResourceInfo is one resource from resources/list.
+type MCPResourceInfo struct {
+	URI         string `json:"uri" yaml:"uri"`
+	Name        string `json:"name" yaml:"name"`
+	Title       string `json:"title,omitempty" yaml:"title,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	MIMEType    string `json:"mimeType,omitempty" yaml:"mimeType,omitempty"`
+}
+
+// MCPResourceTemplateInfo is one template from resources/templates/list.
+type MCPResourceTemplateInfo struct {
+	URITemplate string `json:"uriTemplate" yaml:"uriTemplate"`
+	Name        string `json:"name" yaml:"name"`
+	Title       string `json:"title,omitempty" yaml:"title,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	MIMEType    string `json:"mimeType,omitempty" yaml:"mimeType,omitempty"`
 }
 
 func init() {
@@ -75,22 +183,28 @@ type MCPPackage struct {
 
 // MCPPackageOrigin identifies the package and where to fetch it. The Type
 // discriminator selects which per-type sub-struct must be set; exactly one
-// of NPM/PyPI/OCI is non-nil, matching Type.
+// of NPM/PyPI/OCI/GitHubRelease/Go/Cargo is non-nil, matching Type.
 type MCPPackageOrigin struct {
 	Type       MCPPackageOriginType `json:"type" yaml:"type"`
 	Identifier string               `json:"identifier" yaml:"identifier"`
 
-	NPM  *MCPPackageOriginNPM  `json:"npm,omitempty"  yaml:"npm,omitempty"`
-	PyPI *MCPPackageOriginPyPI `json:"pypi,omitempty" yaml:"pypi,omitempty"`
-	OCI  *MCPPackageOriginOCI  `json:"oci,omitempty"  yaml:"oci,omitempty"`
+	NPM           *MCPPackageOriginNPM           `json:"npm,omitempty"  yaml:"npm,omitempty"`
+	PyPI          *MCPPackageOriginPyPI          `json:"pypi,omitempty" yaml:"pypi,omitempty"`
+	OCI           *MCPPackageOriginOCI           `json:"oci,omitempty"  yaml:"oci,omitempty"`
+	GitHubRelease *MCPPackageOriginGitHubRelease `json:"githubRelease,omitempty" yaml:"githubRelease,omitempty"`
+	Go            *MCPPackageOriginGo            `json:"go,omitempty" yaml:"go,omitempty"`
+	Cargo         *MCPPackageOriginCargo         `json:"cargo,omitempty" yaml:"cargo,omitempty"`
 }
 
 type MCPPackageOriginType string
 
 const (
-	MCPPackageOriginTypeNPM  MCPPackageOriginType = "npm"
-	MCPPackageOriginTypePyPI MCPPackageOriginType = "pypi"
-	MCPPackageOriginTypeOCI  MCPPackageOriginType = "oci"
+	MCPPackageOriginTypeNPM           MCPPackageOriginType = "npm"
+	MCPPackageOriginTypePyPI          MCPPackageOriginType = "pypi"
+	MCPPackageOriginTypeOCI           MCPPackageOriginType = "oci"
+	MCPPackageOriginTypeGitHubRelease MCPPackageOriginType = "github-release"
+	MCPPackageOriginTypeGo            MCPPackageOriginType = "go"
+	MCPPackageOriginTypeCargo         MCPPackageOriginType = "cargo"
 )
 
 // MCPPackageOriginNPM holds npm-specific fetch inputs.
@@ -115,12 +229,57 @@ type MCPPackageOriginOCI struct {
 	ServerName string `json:"serverName" yaml:"serverName"`
 }
 
+// MCPPackageOriginGitHubRelease holds inputs for a static binary attached
+// to a GitHub release. Identifier is the "owner/repo" publishing it.
+type MCPPackageOriginGitHubRelease struct {
+	// Version is the release tag, e.g. "v1.2.0".
+	Version string `json:"version" yaml:"version"`
+	// Asset is the release asset file name. A ".tar.gz"/".tgz" asset is
+	// unpacked and Binary run from it; any other asset is the binary itself.
+	Asset string `json:"asset" yaml:"asset"`
+	// Binary is the executable's path inside an archive Asset. Defaults to
+	// the repository name.
+	Binary string `json:"binary,omitempty" yaml:"binary,omitempty"`
+	// SHA256 is the asset's hex sha256. When set, the download is verified
+	// before it runs.
+	SHA256 string `json:"sha256,omitempty" yaml:"sha256,omitempty"`
+	// Mirror is the GitHub web base URL, for GitHub Enterprise. Defaults to
+	// https://github.com.
+	Mirror     string `json:"mirror,omitempty" yaml:"mirror,omitempty"`
+	ServerName string `json:"serverName" yaml:"serverName"`
+}
+
+// MCPPackageOriginGo holds inputs for a server run with `go run`.
+// Identifier is the import path of its main package, e.g.
+// "github.com/acme/mcp/cmd/server".
+type MCPPackageOriginGo struct {
+	Version string `json:"version" yaml:"version"`
+	// Mirror is the module proxy base URL. Defaults to
+	// https://proxy.golang.org. The runner uses it as GOPROXY.
+	Mirror     string `json:"mirror,omitempty" yaml:"mirror,omitempty"`
+	ServerName string `json:"serverName" yaml:"serverName"`
+}
+
+// MCPPackageOriginCargo holds inputs for a server installed with
+// `cargo install`. Identifier is the crate name.
+type MCPPackageOriginCargo struct {
+	Version string `json:"version" yaml:"version"`
+	// Binary is the installed executable to run. Defaults to the crate name.
+	Binary string `json:"binary,omitempty" yaml:"binary,omitempty"`
+	// Mirror is the crates.io-compatible registry base URL. Defaults to
+	// https://crates.io. It must also serve the sparse index, which the
+	// runner passes to cargo install as --index sparse+<mirror>/.
+	Mirror     string `json:"mirror,omitempty" yaml:"mirror,omitempty"`
+	ServerName string `json:"serverName" yaml:"serverName"`
+}
+
 // MCPPackageLaunch declares how to start the fetched package. If Launch
 // is nil, the resolver derives Command and Args from Origin.Type defaults
 // (npm → "npx -y <id>@<ver>"; pypi → "uvx <id>==<ver>"; oci → image
-// entrypoint). If Launch is set, the manifest owns Command and Args
-// verbatim — no implicit identifier injection. Command may be empty only
-// for oci.
+// entrypoint; github-release → download and exec the asset; go →
+// "go run <id>@<ver>"; cargo → "cargo install" then exec the binary). If
+// Launch is set, the manifest owns Command and Args verbatim — no implicit
+// identifier injection. Command may be empty only for oci.
 type MCPPackageLaunch struct {
 	Command string             `json:"command,omitempty" yaml:"command,omitempty"`
 	Args    []MCPArgument      `json:"args,omitempty" yaml:"args,omitempty"`
@@ -1,6 +1,22 @@
 package v1alpha1
 
-import "fmt"
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	// githubRepoRegex matches a github-release Identifier ("owner/repo").
+	githubRepoRegex = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,38})/[A-Za-z0-9._-]{1,100}$`)
+	// crateNameRegex matches a crates.io crate name.
+	crateNameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]{0,63}$`)
+	// packageVersionRegex bounds the versions of origins whose default
+	// launch is a shell command, so a version can never carry shell syntax.
+	packageVersionRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._+-]{0,127}$`)
+	sha256HexRegex      = regexp.MustCompile(`^[a-f0-9]{64}$`)
+)
 
 // Validate runs structural validation on the MCPServer envelope.
 func (m *MCPServer) Validate() error {
@@ -103,31 +119,26 @@ func validateMCPServerSource(src *MCPServerSource) FieldErrors {
 }
 
 // validateMCPPackageOrigin enforces the discriminated-union invariant:
-// exactly one of NPM/PyPI/OCI sub-structs is non-nil, matches Origin.Type,
-// and carries a non-empty (and well-formed) ServerName. Per-type version
-// requirements (NPM/PyPI must have Version) are enforced here; OCI's
-// tag-or-digest invariant lives in the per-type validator since it
-// parses Identifier.
+// exactly one origin sub-struct is non-nil, matches Origin.Type, and
+// carries a non-empty (and well-formed) ServerName. Per-type version
+// requirements are enforced here; OCI's tag-or-digest invariant lives in
+// the per-type validator since it parses Identifier.
 func validateMCPPackageOrigin(o MCPPackageOrigin) FieldErrors {
 	var errs FieldErrors
 
 	// Count non-nil sub-structs — exactly one must be set.
 	set := 0
-	if o.NPM != nil {
-		set++
-	}
-	if o.PyPI != nil {
-		set++
-	}
-	if o.OCI != nil {
-		set++
+	for _, isSet := range []bool{o.NPM != nil, o.PyPI != nil, o.OCI != nil, o.GitHubRelease != nil, o.Go != nil, o.Cargo != nil} {
+		if isSet {
+			set++
+		}
 	}
 	if set == 0 {
-		errs.Append("spec.source.package.origin", fmt.Errorf("%w: one of origin.npm, origin.pypi, or origin.oci must be set", ErrRequiredField))
+		errs.Append("spec.source.package.origin", fmt.Errorf("%w: one of origin.npm, origin.pypi, origin.oci, origin.githubRelease, origin.go, or origin.cargo must be set", ErrRequiredField))
 		return errs
 	}
 	if set > 1 {
-		errs.Append("spec.source.package.origin", fmt.Errorf("%w: exactly one of origin.npm, origin.pypi, or origin.oci may be set", ErrInvalidRef))
+		errs.Append("spec.source.package.origin", fmt.Errorf("%w: exactly one of origin.npm, origin.pypi, origin.oci, origin.githubRelease, origin.go, or origin.cargo may be set", ErrInvalidRef))
 		return errs
 	}
 
@@ -141,12 +152,7 @@ func validateMCPPackageOrigin(o MCPPackageOrigin) FieldErrors {
 		if o.NPM.Version == "" {
 			errs.Append("spec.source.package.origin.npm.version", fmt.Errorf("%w", ErrRequiredField))
 		}
-		if o.NPM.ServerName == "" {
-			errs.Append("spec.source.package.origin.npm.serverName", fmt.Errorf("%w", ErrRequiredField))
-		}
-		if err := validateMCPPackageName(o.NPM.ServerName); err != nil {
-			errs.Append("spec.source.package.origin.npm.serverName", err)
-		}
+		errs = append(errs, validateOriginServerName("npm", o.NPM.ServerName)...)
 	case MCPPackageOriginTypePyPI:
 		if o.PyPI == nil {
 			errs.Append("spec.source.package.origin.pypi", fmt.Errorf("%w: required when origin.type is %q", ErrRequiredField, o.Type))
@@ -155,28 +161,102 @@ func validateMCPPackageOrigin(o MCPPackageOrigin) FieldErrors {
 		if o.PyPI.Version == "" {
 			errs.Append("spec.source.package.origin.pypi.version", fmt.Errorf("%w", ErrRequiredField))
 		}
-		if o.PyPI.ServerName == "" {
-			errs.Append("spec.source.package.origin.pypi.serverName", fmt.Errorf("%w", ErrRequiredField))
-		}
-		if err := validateMCPPackageName(o.PyPI.ServerName); err != nil {
-			errs.Append("spec.source.package.origin.pypi.serverName", err)
-		}
+		errs = append(errs, validateOriginServerName("pypi", o.PyPI.ServerName)...)
 	case MCPPackageOriginTypeOCI:
 		if o.OCI == nil {
 			errs.Append("spec.source.package.origin.oci", fmt.Errorf("%w: required when origin.type is %q", ErrRequiredField, o.Type))
 			return errs
 		}
-		if o.OCI.ServerName == "" {
-			errs.Append("spec.source.package.origin.oci.serverName", fmt.Errorf("%w", ErrRequiredField))
+		errs = append(errs, validateOriginServerName("oci", o.OCI.ServerName)...)
+	case MCPPackageOriginTypeGitHubRelease:
+		if o.GitHubRelease == nil {
+			errs.Append("spec.source.package.origin.githubRelease", fmt.Errorf("%w: required when origin.type is %q", ErrRequiredField, o.Type))
+			return errs
+		}
+		errs = append(errs, validateGitHubReleaseOrigin(o.Identifier, o.GitHubRelease)...)
+	case MCPPackageOriginTypeGo:
+		if o.Go == nil {
+			errs.Append("spec.source.package.origin.go", fmt.Errorf("%w: required when origin.type is %q", ErrRequiredField, o.Type))
+			return errs
+		}
+		if o.Identifier != "" && (strings.ContainsAny(o.Identifier, " @'\"\\") || path.Clean(o.Identifier) != o.Identifier) {
+			errs.Append("spec.source.package.origin.identifier", fmt.Errorf("%w: must be a Go import path, got %q", ErrInvalidFormat, o.Identifier))
+		}
+		errs = append(errs, validateOriginVersion("go", o.Go.Version)...)
+		errs = append(errs, validateOriginServerName("go", o.Go.ServerName)...)
+	case MCPPackageOriginTypeCargo:
+		if o.Cargo == nil {
+			errs.Append("spec.source.package.origin.cargo", fmt.Errorf("%w: required when origin.type is %q", ErrRequiredField, o.Type))
+			return errs
 		}
-		if err := validateMCPPackageName(o.OCI.ServerName); err != nil {
-			errs.Append("spec.source.package.origin.oci.serverName", err)
+		if o.Identifier != "" && !crateNameRegex.MatchString(o.Identifier) {
+			errs.Append("spec.source.package.origin.identifier", fmt.Errorf("%w: must be a crate name, got %q", ErrInvalidFormat, o.Identifier))
 		}
+		if o.Cargo.Binary != "" && !crateNameRegex.MatchString(o.Cargo.Binary) {
+			errs.Append("spec.source.package.origin.cargo.binary", fmt.Errorf("%w: must be an executable name, got %q", ErrInvalidFormat, o.Cargo.Binary))
+		}
+		errs = append(errs, validateOriginVersion("cargo", o.Cargo.Version)...)
+		errs = append(errs, validateOriginServerName("cargo", o.Cargo.ServerName)...)
 	case "":
 		// Already flagged as ErrRequiredField on origin.type — no further checks.
 	default:
-		errs.Append("spec.source.package.origin.type", fmt.Errorf("%w: unsupported origin type %q (expected one of: %q, %q, %q)", ErrInvalidRef, o.Type, MCPPackageOriginTypeNPM, MCPPackageOriginTypePyPI, MCPPackageOriginTypeOCI))
+		errs.Append("spec.source.package.origin.type", fmt.Errorf("%w: unsupported origin type %q (expected one of: %q, %q, %q, %q, %q, %q)", ErrInvalidRef, o.Type,
+			MCPPackageOriginTypeNPM, MCPPackageOriginTypePyPI, MCPPackageOriginTypeOCI,
+			MCPPackageOriginTypeGitHubRelease, MCPPackageOriginTypeGo, MCPPackageOriginTypeCargo))
 	}
 
 	return errs
 }
+
+// validateGitHubReleaseOrigin checks the release coordinates. Asset and
+// Binary are path segments the default launch downloads and executes, so
+// neither may escape the download directory.
+func validateGitHubReleaseOrigin(identifier string, g *MCPPackageOriginGitHubRelease) FieldErrors {
+	var errs FieldErrors
+	if identifier != "" && !githubRepoRegex.MatchString(identifier) {
+		errs.Append("spec.source.package.origin.identifier", fmt.Errorf("%w: must be \"owner/repo\", got %q", ErrInvalidFormat, identifier))
+	}
+	errs = append(errs, validateOriginVersion("githubRelease", g.Version)...)
+	switch {
+	case g.Asset == "":
+		errs.Append("spec.source.package.origin.githubRelease.asset", fmt.Errorf("%w", ErrRequiredField))
+	case strings.ContainsAny(g.Asset, "/\\'") || g.Asset == "." || g.Asset == "..":
+		errs.Append("spec.source.package.origin.githubRelease.asset", fmt.Errorf("%w: must be a file name, got %q", ErrInvalidFormat, g.Asset))
+	case strings.HasSuffix(g.Asset, ".zip"):
+		errs.Append("spec.source.package.origin.githubRelease.asset", fmt.Errorf("%w: zip assets are not supported; publish a .tar.gz or the bare binary", ErrInvalidFormat))
+	}
+	if b := g.Binary; b != "" && (path.IsAbs(b) || path.Clean(b) != b || b == ".." || strings.HasPrefix(b, "../") || strings.ContainsAny(b, "\\'")) {
+		errs.Append("spec.source.package.origin.githubRelease.binary", fmt.Errorf("%w: must be a relative path inside the asset, got %q", ErrInvalidFormat, b))
+	}
+	if g.SHA256 != "" && !sha256HexRegex.MatchString(g.SHA256) {
+		errs.Append("spec.source.package.origin.githubRelease.sha256", fmt.Errorf("%w: must be 64 lowercase hex characters", ErrInvalidFormat))
+	}
+	errs = append(errs, validateOriginServerName("githubRelease", g.ServerName)...)
+	return errs
+}
+
+// validateOriginVersion requires a version and bounds its characters.
+func validateOriginVersion(field, version string) FieldErrors {
+	var errs FieldErrors
+	fieldPath := "spec.source.package.origin." + field + ".version"
+	if version == "" {
+		errs.Append(fieldPath, fmt.Errorf("%w", ErrRequiredField))
+	} else if !packageVersionRegex.MatchString(version) {
+		errs.Append(fieldPath, fmt.Errorf("%w: invalid version %q", ErrInvalidFormat, version))
+	}
+	return errs
+}
+
+// validateOriginServerName requires the sub-struct's serverName and checks
+// its upstream format.
+func validateOriginServerName(field, serverName string) FieldErrors {
+	var errs FieldErrors
+	fieldPath := "spec.source.package.origin." + field + ".serverName"
+	if serverName == "" {
+		errs.Append(fieldPath, fmt.Errorf("%w", ErrRequiredField))
+	}
+	if err := validateMCPPackageName(serverName); err != nil {
+		errs.Append(fieldPath, err)
+	}
+	return errs
+}
@@ -16,9 +16,15 @@ func init() {
 }
 
 // Supported provider families. Expand this enum only when the provider has a
-// working runtime adapter and end-to-end coverage.
+// working runtime adapter and end-to-end coverage. Per-provider endpoint and
+// auth rules live in KnownModelProviders.
 const (
-	ModelProviderBedrock = "bedrock"
+	ModelProviderBedrock          = "bedrock"
+	ModelProviderOpenAI           = "openai"
+	ModelProviderAnthropic        = "anthropic"
+	ModelProviderAzureOpenAI      = "azure-openai"
+	ModelProviderVertex           = "vertex"
+	ModelProviderOpenAICompatible = "openai-compatible"
 )
 
 // Model auth strategies. See ModelAuthConfig.
@@ -39,15 +45,18 @@ type ModelSpec struct {
 	Title       string `json:"title,omitempty" yaml:"title,omitempty"`
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 
-	// Provider family. Currently only "bedrock" is supported.
-	Provider string `json:"provider" yaml:"provider" enum:"bedrock"`
+	// Provider family: "bedrock", "openai", "anthropic", "azure-openai",
+	// "vertex", or "openai-compatible" (any server speaking the OpenAI API,
+	// such as vLLM or Ollama, reached at endpoint.baseUrl).
+	Provider string `json:"provider" yaml:"provider" enum:"bedrock,openai,anthropic,azure-openai,vertex,openai-compatible"`
 
 	// Model is the provider-scoped model identifier, e.g.
 	// "us.anthropic.claude-opus-4-8".
 	Model string `json:"model" yaml:"model"`
 
 	// Auth is how the platform authenticates to the provider. Omitted means
-	// the provider default: ambient runtime identity for Bedrock.
+	// the provider default: ambient runtime identity for Bedrock and Vertex,
+	// no credentials for openai-compatible. Other providers must declare it.
 	Auth *ModelAuthConfig `json:"auth,omitempty" yaml:"auth,omitempty"`
 
 	// Endpoint overrides how the provider is reached. Omitted means
@@ -55,9 +64,9 @@ type ModelSpec struct {
 	Endpoint *ModelEndpointConfig `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
 }
 
-// ModelAuthConfig declares the auth posture for reaching the provider. OSS
-// stores SecretRef opaquely and never resolves it; resolution is owned by
-// distributions with a secret store.
+// ModelAuthConfig declares the auth posture for reaching the provider.
+// SecretRef names a registry Secret; the runtime adapters resolve it at
+// Deployment apply time.
 type ModelAuthConfig struct {
 	// Strategy is "runtime" (ambient cloud identity), "secretRef" (key
 	// material from a registry Secret), or "passthrough" (inbound bearer
@@ -67,13 +76,24 @@ type ModelAuthConfig struct {
 	SecretRef *SecretKeyRef `json:"secretRef,omitempty" yaml:"secretRef,omitempty"`
 }
 
-// ModelEndpointConfig overrides how the provider is reached.
+// ModelEndpointConfig overrides how the provider is reached. Which fields a
+// provider requires or accepts is recorded in KnownModelProviders.
 type ModelEndpointConfig struct {
+	// BaseURL is the provider endpoint. Required for azure-openai (the
+	// resource endpoint) and openai-compatible; an optional gateway override
+	// elsewhere.
 	BaseURL string `json:"baseUrl,omitempty" yaml:"baseUrl,omitempty"`
-	// Region overrides the model-endpoint region (bedrock); empty means the
-	// provider default.
-	Region string          `json:"region,omitempty" yaml:"region,omitempty"`
-	TLS    *ModelTLSConfig `json:"tls,omitempty" yaml:"tls,omitempty"`
+	// Region is the model-endpoint region. Optional for bedrock (provider
+	// default when empty); required for vertex.
+	Region string `json:"region,omitempty" yaml:"region,omitempty"`
+	// Deployment is the Azure OpenAI deployment name (azure-openai only).
+	Deployment string `json:"deployment,omitempty" yaml:"deployment,omitempty"`
+	// APIVersion pins the Azure OpenAI REST API version (azure-openai only);
+	// empty means the runtime default.
+	APIVersion string `json:"apiVersion,omitempty" yaml:"apiVersion,omitempty"`
+	// Project is the Google Cloud project hosting the model (vertex only).
+	Project string          `json:"project,omitempty" yaml:"project,omitempty"`
+	TLS     *ModelTLSConfig `json:"tls,omitempty" yaml:"tls,omitempty"`
 }
 
 // ModelTLSConfig carries TLS settings for private gateway endpoints.
@@ -84,11 +104,26 @@ type ModelTLSConfig struct {
 	DisableVerify bool `json:"disableVerify,omitempty" yaml:"disableVerify,omitempty"`
 }
 
-// SecretKeyRef names a key in a registry Secret. OSS stores and structurally
-// validates it but never resolves it. Secret values are not stored on Model
-// resources.
+// SecretKeyRef names a key in a registry Secret. The Secret must live in the
+// referencing object's namespace: blank Namespace inherits it, and any other
+// namespace is rejected. Blank Key selects the Secret's only key and is an
+// error when the Secret carries more than one. Secret values are never stored
+// on the referencing resource.
 type SecretKeyRef struct {
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 	Name      string `json:"name" yaml:"name"`
 	Key       string `json:"key,omitempty" yaml:"key,omitempty"`
 }
+
+// SecretKeyRefs returns the Secrets the Model reads at deploy time: the auth
+// secretRef (only under the secretRef strategy) followed by the endpoint CA.
+func (s *ModelSpec) SecretKeyRefs() []SecretKeyRef {
+	var refs []SecretKeyRef
+	if s.Auth != nil && s.Auth.Strategy == ModelAuthStrategySecretRef && s.Auth.SecretRef != nil {
+		refs = append(refs, *s.Auth.SecretRef)
+	}
+	if s.Endpoint != nil && s.Endpoint.TLS != nil && s.Endpoint.TLS.CACertSecretRef != nil {
+		refs = append(refs, *s.Endpoint.TLS.CACertSecretRef)
+	}
+	return refs
+}
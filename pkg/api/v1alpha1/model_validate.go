@@ -2,18 +2,65 @@ package v1alpha1
 
 import (
 	"fmt"
+	"maps"
+	"net/url"
 	"slices"
 	"strings"
 )
 
+// Endpoint field names, as they appear under spec.endpoint, used by
+// ModelProviderInfo to declare which fields a provider requires or accepts.
+const (
+	modelEndpointBaseURL    = "baseUrl"
+	modelEndpointRegion     = "region"
+	modelEndpointDeployment = "deployment"
+	modelEndpointAPIVersion = "apiVersion"
+	modelEndpointProject    = "project"
+)
+
+// ModelProviderInfo records the validation rules for one provider family.
+type ModelProviderInfo struct {
+	// AmbientIdentity allows auth strategy "runtime" and makes it the
+	// default when auth is omitted.
+	AmbientIdentity bool
+	// Anonymous means omitted auth is valid and sends no credentials
+	// (self-hosted servers that do not check keys).
+	Anonymous bool
+	// RequiredEndpoint lists spec.endpoint fields that must be set.
+	RequiredEndpoint []string
+	// OptionalEndpoint lists spec.endpoint fields the provider accepts
+	// but does not require. Fields in neither list are rejected. TLS is
+	// accepted for every provider.
+	OptionalEndpoint []string
+}
+
 // KnownModelProviders is the set of provider families the validator
-// recognizes. Keys are the canonical lowercase provider names. The value
-// records whether the provider supports ambient runtime identity. Add a
+// recognizes. Keys are the canonical lowercase provider names. Add a
 // provider only after its runtime adapter and end-to-end coverage exist.
-var KnownModelProviders = map[string]struct {
-	AmbientIdentity bool
-}{
-	ModelProviderBedrock: {AmbientIdentity: true},
+var KnownModelProviders = map[string]ModelProviderInfo{
+	ModelProviderBedrock: {
+		AmbientIdentity:  true,
+		OptionalEndpoint: []string{modelEndpointBaseURL, modelEndpointRegion},
+	},
+	ModelProviderOpenAI: {
+		OptionalEndpoint: []string{modelEndpointBaseURL},
+	},
+	ModelProviderAnthropic: {
+		OptionalEndpoint: []string{modelEndpointBaseURL},
+	},
+	ModelProviderAzureOpenAI: {
+		RequiredEndpoint: []string{modelEndpointBaseURL, modelEndpointDeployment},
+		OptionalEndpoint: []string{modelEndpointAPIVersion},
+	},
+	ModelProviderVertex: {
+		AmbientIdentity:  true,
+		RequiredEndpoint: []string{modelEndpointProject, modelEndpointRegion},
+		OptionalEndpoint: []string{modelEndpointBaseURL},
+	},
+	ModelProviderOpenAICompatible: {
+		Anonymous:        true,
+		RequiredEndpoint: []string{modelEndpointBaseURL},
+	},
 }
 
 // Validate runs Model's structural checks.
@@ -23,8 +70,12 @@ var KnownModelProviders = map[string]struct {
 //   - provider in the known set; model non-empty.
 //   - auth.strategy in {runtime, secretRef, passthrough}; secretRef present
 //     iff strategy is secretRef.
-//   - "runtime" only for ambient-identity providers (currently bedrock);
-//     key-based providers must declare secretRef or passthrough.
+//   - "runtime" only for ambient-identity providers (bedrock, vertex);
+//     other providers must declare secretRef or passthrough, except
+//     openai-compatible, where omitted auth means no credentials.
+//   - spec.endpoint carries exactly the fields the provider uses (for
+//     example azure-openai requires baseUrl and deployment, vertex requires
+//     project and region); baseUrl, when set, is an absolute http(s) URL.
 //
 // Model is versioned: identity is (namespace, name, tag). Auth/endpoint edits
 // publish a new configuration tag when callers need to preserve existing
@@ -32,14 +83,14 @@ var KnownModelProviders = map[string]struct {
 func (m *Model) Validate() error {
 	var errs FieldErrors
 	errs = append(errs, ValidateObjectMeta(m.Metadata)...)
-	errs = append(errs, validateModelSpec(&m.Spec)...)
+	errs = append(errs, validateModelSpec(&m.Spec, m.Metadata.NamespaceOrDefault())...)
 	if len(errs) == 0 {
 		return nil
 	}
 	return errs
 }
 
-func validateModelSpec(s *ModelSpec) FieldErrors {
+func validateModelSpec(s *ModelSpec, namespace string) FieldErrors {
 	var errs FieldErrors
 
 	provider := s.Provider
@@ -68,7 +119,7 @@ func validateModelSpec(s *ModelSpec) FieldErrors {
 			if s.Auth.SecretRef == nil {
 				errs.Append("spec.auth.secretRef", fmt.Errorf("%w: required for strategy %q", ErrRequiredField, ModelAuthStrategySecretRef))
 			} else {
-				errs = append(errs, validateSecretKeyRef(*s.Auth.SecretRef, "spec.auth.secretRef")...)
+				errs = append(errs, validateSecretKeyRef(*s.Auth.SecretRef, "spec.auth.secretRef", namespace)...)
 			}
 		case "":
 			errs.Append("spec.auth.strategy", fmt.Errorf("%w", ErrRequiredField))
@@ -84,36 +135,92 @@ func validateModelSpec(s *ModelSpec) FieldErrors {
 	// key-based providers must declare a strategy.
 	if providerKnown {
 		if s.Auth == nil {
-			if !providerInfo.AmbientIdentity {
+			if !providerInfo.AmbientIdentity && !providerInfo.Anonymous {
 				errs.Append("spec.auth",
 					fmt.Errorf("%w: provider %q requires an explicit auth strategy (%q or %q)",
 						ErrRequiredField, provider, ModelAuthStrategySecretRef, ModelAuthStrategyPassthrough))
 			}
 		} else if strategy == ModelAuthStrategyRuntime && !providerInfo.AmbientIdentity {
 			errs.Append("spec.auth.strategy",
-				fmt.Errorf("%w: strategy %q is only valid for ambient-identity providers (currently bedrock), not %q",
-					ErrInvalidFormat, ModelAuthStrategyRuntime, provider))
+				fmt.Errorf("%w: strategy %q is only valid for ambient-identity providers (%s), not %q",
+					ErrInvalidFormat, ModelAuthStrategyRuntime, strings.Join(ambientModelProviderNames(), ", "), provider))
 		}
+		errs = append(errs, validateModelEndpoint(provider, providerInfo, s.Endpoint)...)
 	}
 
 	if s.Endpoint != nil && s.Endpoint.TLS != nil && s.Endpoint.TLS.CACertSecretRef != nil {
-		errs = append(errs, validateSecretKeyRef(*s.Endpoint.TLS.CACertSecretRef, "spec.endpoint.tls.caCertSecretRef")...)
+		errs = append(errs, validateSecretKeyRef(*s.Endpoint.TLS.CACertSecretRef, "spec.endpoint.tls.caCertSecretRef", namespace)...)
 	}
 
 	return errs
 }
 
-func validateSecretKeyRef(ref SecretKeyRef, path string) FieldErrors {
+// validateModelEndpoint checks spec.endpoint against the provider's required
+// and accepted fields.
+func validateModelEndpoint(provider string, info ModelProviderInfo, ep *ModelEndpointConfig) FieldErrors {
+	var errs FieldErrors
+	values := map[string]string{}
+	if ep != nil {
+		values = map[string]string{
+			modelEndpointBaseURL:    ep.BaseURL,
+			modelEndpointRegion:     ep.Region,
+			modelEndpointDeployment: ep.Deployment,
+			modelEndpointAPIVersion: ep.APIVersion,
+			modelEndpointProject:    ep.Project,
+		}
+	}
+	for _, field := range info.RequiredEndpoint {
+		if strings.TrimSpace(values[field]) == "" {
+			errs.Append("spec.endpoint."+field,
+				fmt.Errorf("%w: provider %q requires endpoint.%s", ErrRequiredField, provider, field))
+		}
+	}
+	for _, field := range slices.Sorted(maps.Keys(values)) {
+		if values[field] == "" || slices.Contains(info.RequiredEndpoint, field) || slices.Contains(info.OptionalEndpoint, field) {
+			continue
+		}
+		errs.Append("spec.endpoint."+field,
+			fmt.Errorf("%w: not supported for provider %q", ErrInvalidFormat, provider))
+	}
+	if raw := values[modelEndpointBaseURL]; raw != "" {
+		if u, err := url.Parse(raw); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs.Append("spec.endpoint.baseUrl",
+				fmt.Errorf("%w: %q is not an absolute http(s) URL", ErrInvalidFormat, raw))
+		}
+	}
+	return errs
+}
+
+// validateSecretKeyRef checks ref as written on an object in namespace.
+// Secrets are only readable from their own namespace, so a ref naming
+// another one is rejected.
+func validateSecretKeyRef(ref SecretKeyRef, path, namespace string) FieldErrors {
 	var errs FieldErrors
 	if err := validateNameField(ref.Name); err != nil {
 		errs.Append(path+".name", err)
 	}
 	if ref.Namespace != "" && !namespaceRegex.MatchString(ref.Namespace) {
 		errs.Append(path+".namespace", fmt.Errorf("%w: %q", ErrInvalidFormat, ref.Namespace))
+	} else if ref.Namespace != "" && ref.Namespace != namespace {
+		errs.Append(path+".namespace", fmt.Errorf("%w: Secret must be in the referencing object's namespace %q", ErrInvalidFormat, namespace))
+	}
+	if ref.Key != "" && !secretKeyRegex.MatchString(ref.Key) {
+		errs.Append(path+".key", fmt.Errorf("%w: %q", ErrInvalidFormat, ref.Key))
 	}
 	return errs
 }
 
+func ambientModelProviderNames() []string {
+	var out []string
+	for k, info := range KnownModelProviders {
+		if info.AmbientIdentity {
+			out = append(out, k)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
 func knownModelProviderNames() []string {
 	out := make([]string, 0, len(KnownModelProviders))
 	for k := range KnownModelProviders {
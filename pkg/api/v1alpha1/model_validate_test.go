@@ -44,19 +44,89 @@ func TestModelValidate(t *testing.T) {
 			wantErr: "spec.provider",
 		},
 		{
-			name:    "unsupported anthropic provider",
-			spec:    ModelSpec{Provider: "anthropic", Model: "claude-opus-4-8", Auth: &ModelAuthConfig{Strategy: ModelAuthStrategySecretRef, SecretRef: secretRef}},
-			wantErr: "spec.provider",
+			name: "valid anthropic secretRef auth",
+			spec: ModelSpec{Provider: "anthropic", Model: "claude-opus-4-8", Auth: &ModelAuthConfig{Strategy: ModelAuthStrategySecretRef, SecretRef: secretRef}},
 		},
 		{
-			name:    "unsupported openai provider",
-			spec:    ModelSpec{Provider: "openai", Model: "gpt-5", Auth: &ModelAuthConfig{Strategy: ModelAuthStrategySecretRef, SecretRef: secretRef}},
-			wantErr: "spec.provider",
+			name: "valid openai passthrough auth",
+			spec: ModelSpec{Provider: "openai", Model: "gpt-5", Auth: &ModelAuthConfig{Strategy: ModelAuthStrategyPassthrough}},
 		},
 		{
-			name:    "unsupported vertex provider",
-			spec:    ModelSpec{Provider: "vertex", Model: "gemini-2.5-pro", Auth: &ModelAuthConfig{Strategy: ModelAuthStrategyRuntime}},
-			wantErr: "spec.provider",
+			name:    "openai requires explicit auth",
+			spec:    ModelSpec{Provider: "openai", Model: "gpt-5"},
+			wantErr: "spec.auth",
+		},
+		{
+			name:    "openai rejects runtime auth",
+			spec:    ModelSpec{Provider: "openai", Model: "gpt-5", Auth: &ModelAuthConfig{Strategy: ModelAuthStrategyRuntime}},
+			wantErr: "ambient-identity providers (bedrock, vertex)",
+		},
+		{
+			name: "valid azure-openai",
+			spec: ModelSpec{
+				Provider: "azure-openai", Model: "gpt-4o",
+				Auth:     &ModelAuthConfig{Strategy: ModelAuthStrategySecretRef, SecretRef: secretRef},
+				Endpoint: &ModelEndpointConfig{BaseURL: "https://acme.openai.azure.com", Deployment: "gpt-4o-prod", APIVersion: "2024-10-21"},
+			},
+		},
+		{
+			name: "azure-openai requires deployment",
+			spec: ModelSpec{
+				Provider: "azure-openai", Model: "gpt-4o",
+				Auth:     &ModelAuthConfig{Strategy: ModelAuthStrategySecretRef, SecretRef: secretRef},
+				Endpoint: &ModelEndpointConfig{BaseURL: "https://acme.openai.azure.com"},
+			},
+			wantErr: "spec.endpoint.deployment",
+		},
+		{
+			name: "azure-openai requires baseUrl",
+			spec: ModelSpec{
+				Provider: "azure-openai", Model: "gpt-4o",
+				Auth:     &ModelAuthConfig{Strategy: ModelAuthStrategySecretRef, SecretRef: secretRef},
+				Endpoint: &ModelEndpointConfig{Deployment: "gpt-4o-prod"},
+			},
+			wantErr: "spec.endpoint.baseUrl",
+		},
+		{
+			name: "valid vertex runtime auth",
+			spec: ModelSpec{
+				Provider: "vertex", Model: "gemini-2.5-pro",
+				Auth:     &ModelAuthConfig{Strategy: ModelAuthStrategyRuntime},
+				Endpoint: &ModelEndpointConfig{Project: "acme-ml", Region: "us-central1"},
+			},
+		},
+		{
+			name:    "vertex requires project and region",
+			spec:    ModelSpec{Provider: "vertex", Model: "gemini-2.5-pro"},
+			wantErr: "spec.endpoint.project",
+		},
+		{
+			name: "valid openai-compatible without auth",
+			spec: ModelSpec{
+				Provider: "openai-compatible", Model: "qwen2.5:7b",
+				Endpoint: &ModelEndpointConfig{BaseURL: "http://ollama:11434/v1"},
+			},
+		},
+		{
+			name:    "openai-compatible requires baseUrl",
+			spec:    ModelSpec{Provider: "openai-compatible", Model: "qwen2.5:7b"},
+			wantErr: "spec.endpoint.baseUrl",
+		},
+		{
+			name: "baseUrl must be an absolute http url",
+			spec: ModelSpec{
+				Provider: "openai-compatible", Model: "qwen2.5:7b",
+				Endpoint: &ModelEndpointConfig{BaseURL: "ollama:11434"},
+			},
+			wantErr: "not an absolute http(s) URL",
+		},
+		{
+			name: "endpoint field not used by provider",
+			spec: ModelSpec{
+				Provider: "bedrock", Model: "us.anthropic.claude-opus-4-8",
+				Endpoint: &ModelEndpointConfig{Deployment: "gpt-4o-prod"},
+			},
+			wantErr: "spec.endpoint.deployment",
 		},
 		{
 			name:    "unknown provider",
@@ -98,6 +168,15 @@ func TestModelValidate(t *testing.T) {
 			spec:    ModelSpec{Provider: "bedrock", Model: "m", Auth: &ModelAuthConfig{Strategy: ModelAuthStrategySecretRef, SecretRef: &SecretKeyRef{Name: "Not A Name!"}}},
 			wantErr: "spec.auth.secretRef.name",
 		},
+		{
+			name: "secretRef in its own namespace",
+			spec: ModelSpec{Provider: "bedrock", Model: "m", Auth: &ModelAuthConfig{Strategy: ModelAuthStrategySecretRef, SecretRef: &SecretKeyRef{Namespace: "default", Name: "key"}}},
+		},
+		{
+			name:    "secretRef in another namespace",
+			spec:    ModelSpec{Provider: "bedrock", Model: "m", Auth: &ModelAuthConfig{Strategy: ModelAuthStrategySecretRef, SecretRef: &SecretKeyRef{Namespace: "team-b", Name: "key"}}},
+			wantErr: "spec.auth.secretRef.namespace",
+		},
 		{
 			name: "tls caCert secretRef validated",
 			spec: ModelSpec{
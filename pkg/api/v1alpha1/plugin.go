@@ -5,7 +5,7 @@ package v1alpha1
 // A Plugin is a self-contained, versioned bundle of harness extensions —
 // skills, MCP servers, hooks, and sub-agents — modeled on the Claude Code
 // plugin format. The Spec is USER INTENT ONLY: a pinned pointer to an external
-// source (a git commit or — later — an OCI digest), the same source-based model
+// source (a git commit or an OCI digest), the same source-based model
 // agents and skills use. The registry hosts NOTHING; the Plugin controller
 // resolves the pointer to a concrete commit/digest and scans the source for its
 // manifest and inventory OUT OF BAND, recording that server-determined data in
@@ -72,7 +72,7 @@ type PluginResolvedSource struct {
 	Type PluginSourceType `json:"type" yaml:"type"`
 	// Commit is the resolved full git commit SHA (Type=git).
 	Commit string `json:"commit,omitempty" yaml:"commit,omitempty"`
-	// Digest is the resolved OCI digest, e.g. "sha256:…" (Type=oci; future).
+	// Digest is the resolved OCI manifest digest, e.g. "sha256:…" (Type=oci).
 	Digest string `json:"digest,omitempty" yaml:"digest,omitempty"`
 }
 
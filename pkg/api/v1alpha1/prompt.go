@@ -12,10 +12,62 @@ func init() {
 	MustRegisterKind[*Prompt, PromptSpec](KindPrompt)
 }
 
+// Prompt argument types. See PromptArgument.Type.
+const (
+	PromptArgumentTypeString  = "string"
+	PromptArgumentTypeNumber  = "number"
+	PromptArgumentTypeInteger = "integer"
+	PromptArgumentTypeBoolean = "boolean"
+)
+
+// Prompt message roles. See PromptMessage.Role.
+const (
+	PromptRoleSystem    = "system"
+	PromptRoleUser      = "user"
+	PromptRoleAssistant = "assistant"
+)
+
 // PromptSpec is the prompt resource's declarative body. Content holds the
 // prompt text inline; for large bodies or binary assets, use references via
 // a Skill resource instead.
+//
+// A prompt that declares Arguments opts into templating: Content and
+// Messages are Go text/template templates over them. `{{ .topic }}` inserts
+// the "topic" argument, and the standard actions (if, range, with) and
+// builtin functions are available. A literal "{{" is written `{{ "{{" }}`.
+// A prompt without Arguments is plain text and renders verbatim.
 type PromptSpec struct {
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
-	Content     string `json:"content,omitempty" yaml:"content,omitempty"`
+	// Arguments declares the values the templates may reference and turns
+	// templating on. Templates that reference anything else are rejected at
+	// apply time.
+	Arguments []PromptArgument `json:"arguments,omitempty" yaml:"arguments,omitempty"`
+	// Content is a single-message prompt, rendered as one "user" message.
+	// Mutually exclusive with Messages.
+	Content string `json:"content,omitempty" yaml:"content,omitempty"`
+	// Messages is a multi-message prompt, rendered in order.
+	Messages []PromptMessage `json:"messages,omitempty" yaml:"messages,omitempty"`
+}
+
+// PromptArgument declares one template argument. Values arrive as strings
+// (render requests, Deployment.Spec.PromptArguments, MCP prompts/get) and
+// are parsed according to Type before rendering.
+type PromptArgument struct {
+	// Name is referenced from templates as `{{ .<name> }}`.
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Required arguments must be supplied when no Default is declared.
+	Required bool `json:"required,omitempty" yaml:"required,omitempty"`
+	// Default is used when the caller omits the argument. Written in the
+	// argument's string form (e.g. "3", "true").
+	Default string `json:"default,omitempty" yaml:"default,omitempty"`
+	// Type is "string" (default), "number", "integer", or "boolean".
+	Type string `json:"type,omitempty" yaml:"type,omitempty" enum:"string,number,integer,boolean"`
+}
+
+// PromptMessage is one templated message in a multi-message prompt, and the
+// shape rendered prompts are returned in.
+type PromptMessage struct {
+	Role    string `json:"role" yaml:"role" enum:"system,user,assistant"`
+	Content string `json:"content" yaml:"content"`
 }
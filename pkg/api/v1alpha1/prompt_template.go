@@ -0,0 +1,340 @@
+package v1alpha1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+)
+
+// promptArgumentNameRegex keeps argument names addressable as `.name` in a
+// template (no dots, dashes, or leading digits).
+var promptArgumentNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,63}$`)
+
+// promptPrintfWideVerbRegex matches a printf verb whose width or precision
+// comes from an argument (`*`) or exceeds three digits, either of which can
+// allocate an arbitrarily large string before Render's output bound sees it.
+var promptPrintfWideVerbRegex = regexp.MustCompile(`%[-+# 0]*(\*|\d{4,}|\d*\.(\*|\d{4,}))`)
+
+// Render bounds: a rendered prompt stops at MaxPromptRenderBytes of output
+// across all its messages, or after PromptRenderTimeout. Templates may only
+// range over constants, unnested and at most maxPromptRangeIterations times.
+const (
+	MaxPromptRenderBytes     = 1 << 20
+	PromptRenderTimeout      = 2 * time.Second
+	maxPromptRangeIterations = 1000
+)
+
+var (
+	errPromptTooLarge = fmt.Errorf("rendered prompt exceeds %d bytes", MaxPromptRenderBytes)
+	errPromptTimeout  = fmt.Errorf("rendering took longer than %s", PromptRenderTimeout)
+)
+
+// promptWriter collects rendered output, failing the template execution
+// once the shared byte budget is spent or the deadline has passed.
+type promptWriter struct {
+	buf       bytes.Buffer
+	remaining *int
+	deadline  time.Time
+}
+
+func (w *promptWriter) Write(p []byte) (int, error) {
+	if time.Now().After(w.deadline) {
+		return 0, errPromptTimeout
+	}
+	if len(p) > *w.remaining {
+		return 0, errPromptTooLarge
+	}
+	*w.remaining -= len(p)
+	return w.buf.Write(p)
+}
+
+// parsePromptTemplate parses one Content/Messages template with the
+// options Render uses.
+func parsePromptTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Parse(text)
+}
+
+// promptTemplateRefs parses text and returns the top-level argument names it
+// references (`.name`, `$.name`). References inside range/with bodies,
+// where dot is rebound, are not arguments and are skipped. Template
+// invocation ({{ template }}/{{ block }}) is rejected: a Prompt is one
+// self-contained template. So is anything whose cost a caller could inflate:
+// a range over anything but a constant, a nested range, a range of more
+// than maxPromptRangeIterations, and a printf width or precision taken from
+// an argument or wider than three digits.
+func promptTemplateRefs(text string) ([]string, error) {
+	tmpl, err := parsePromptTemplate("prompt", text)
+	if err != nil {
+		return nil, err
+	}
+	if len(tmpl.Templates()) > 1 {
+		return nil, fmt.Errorf("nested template definitions are not supported")
+	}
+	var (
+		refs    []string
+		seen    = map[string]struct{}{}
+		inRange bool
+		walk    func(node parse.Node, dotIsRoot bool) error
+	)
+	add := func(name string) {
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			refs = append(refs, name)
+		}
+	}
+	walkPipe := func(pipe *parse.PipeNode, dotIsRoot bool) error {
+		if pipe == nil {
+			return nil
+		}
+		for _, cmd := range pipe.Cmds {
+			if err := checkPromptPrintf(cmd); err != nil {
+				return err
+			}
+			for _, arg := range cmd.Args {
+				if err := walk(arg, dotIsRoot); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	walk = func(node parse.Node, dotIsRoot bool) error {
+		switch n := node.(type) {
+		case nil:
+			return nil
+		case *parse.ListNode:
+			if n == nil {
+				return nil
+			}
+			for _, child := range n.Nodes {
+				if err := walk(child, dotIsRoot); err != nil {
+					return err
+				}
+			}
+		case *parse.ActionNode:
+			return walkPipe(n.Pipe, dotIsRoot)
+		case *parse.PipeNode:
+			return walkPipe(n, dotIsRoot)
+		case *parse.FieldNode:
+			if dotIsRoot {
+				add(n.Ident[0])
+			}
+		case *parse.VariableNode:
+			if len(n.Ident) > 1 && n.Ident[0] == "$" {
+				add(n.Ident[1])
+			}
+		case *parse.ChainNode:
+			return walk(n.Node, dotIsRoot)
+		case *parse.IfNode:
+			if err := walkPipe(n.Pipe, dotIsRoot); err != nil {
+				return err
+			}
+			if err := walk(n.List, dotIsRoot); err != nil {
+				return err
+			}
+			return walk(n.ElseList, dotIsRoot)
+		case *parse.RangeNode:
+			if inRange {
+				return errors.New("nested range is not supported")
+			}
+			if err := checkPromptRange(n.Pipe); err != nil {
+				return err
+			}
+			if err := walkPipe(n.Pipe, dotIsRoot); err != nil {
+				return err
+			}
+			inRange = true
+			err := walk(n.List, false)
+			inRange = false
+			if err != nil {
+				return err
+			}
+			return walk(n.ElseList, dotIsRoot)
+		case *parse.WithNode:
+			if err := walkPipe(n.Pipe, dotIsRoot); err != nil {
+				return err
+			}
+			if err := walk(n.List, false); err != nil {
+				return err
+			}
+			return walk(n.ElseList, dotIsRoot)
+		case *parse.TemplateNode:
+			return fmt.Errorf("{{ template %q }} is not supported", n.Name)
+		}
+		return nil
+	}
+	if err := walk(tmpl.Tree.Root, true); err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
+
+// checkPromptRange accepts a range only over a constant: literals and
+// function calls on literals, never an argument, dot, or variable, and never
+// more than maxPromptRangeIterations.
+func checkPromptRange(pipe *parse.PipeNode) error {
+	if !promptPipeIsConstant(pipe) {
+		return errors.New("range over prompt arguments or variables is not supported; range over a constant")
+	}
+	if len(pipe.Cmds) == 1 && len(pipe.Cmds[0].Args) == 1 {
+		if n, ok := pipe.Cmds[0].Args[0].(*parse.NumberNode); ok && n.IsInt && n.Int64 > maxPromptRangeIterations {
+			return fmt.Errorf("range over %d exceeds %d iterations", n.Int64, maxPromptRangeIterations)
+		}
+	}
+	return nil
+}
+
+func promptPipeIsConstant(pipe *parse.PipeNode) bool {
+	if pipe == nil || len(pipe.Decl) > 0 {
+		return false
+	}
+	for _, cmd := range pipe.Cmds {
+		for _, arg := range cmd.Args {
+			switch n := arg.(type) {
+			case *parse.NumberNode, *parse.StringNode, *parse.BoolNode, *parse.NilNode, *parse.IdentifierNode:
+			case *parse.PipeNode:
+				if !promptPipeIsConstant(n) {
+					return false
+				}
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// checkPromptPrintf rejects a printf whose format string could expand to
+// an unbounded width.
+func checkPromptPrintf(cmd *parse.CommandNode) error {
+	if len(cmd.Args) < 2 {
+		return nil
+	}
+	if fn, ok := cmd.Args[0].(*parse.IdentifierNode); !ok || fn.Ident != "printf" {
+		return nil
+	}
+	format, ok := cmd.Args[1].(*parse.StringNode)
+	if !ok {
+		return errors.New("printf format must be a string literal")
+	}
+	if promptPrintfWideVerbRegex.MatchString(format.Text) {
+		return fmt.Errorf("printf format %s: widths and precisions must be literal and at most 999", format.Quoted)
+	}
+	return nil
+}
+
+// parsePromptArgumentValue converts the string form of an argument into the
+// value templates see.
+func parsePromptArgumentValue(argType, raw string) (any, error) {
+	switch argType {
+	case "", PromptArgumentTypeString:
+		return raw, nil
+	case PromptArgumentTypeNumber:
+		v, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not a number", ErrInvalidFormat, raw)
+		}
+		return v, nil
+	case PromptArgumentTypeInteger:
+		v, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not an integer", ErrInvalidFormat, raw)
+		}
+		return v, nil
+	case PromptArgumentTypeBoolean:
+		v, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not a boolean", ErrInvalidFormat, raw)
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("%w: unknown argument type %q", ErrInvalidFormat, argType)
+}
+
+// promptArgumentZero is the value an optional argument without a default
+// takes when omitted, so `{{ if .flag }}` reads as false rather than failing.
+func promptArgumentZero(argType string) any {
+	switch argType {
+	case PromptArgumentTypeNumber:
+		return float64(0)
+	case PromptArgumentTypeInteger:
+		return int64(0)
+	case PromptArgumentTypeBoolean:
+		return false
+	}
+	return ""
+}
+
+// Render validates args against the declared Arguments and renders the
+// prompt into messages. Unknown arguments, missing required arguments, and
+// values that do not parse as the declared type are returned as FieldErrors
+// under "arguments.<name>". Content renders to a single "user" message. A
+// prompt without Arguments is not a template; its text is returned as is.
+// Rendering fails once its output passes MaxPromptRenderBytes or it runs
+// past PromptRenderTimeout.
+func (s *PromptSpec) Render(args map[string]string) ([]PromptMessage, error) {
+	var errs FieldErrors
+	declared := make(map[string]PromptArgument, len(s.Arguments))
+	for _, a := range s.Arguments {
+		declared[a.Name] = a
+	}
+	for name := range args {
+		if _, ok := declared[name]; !ok {
+			errs.Append("arguments."+name, fmt.Errorf("%w: argument is not declared by the prompt", ErrInvalidFormat))
+		}
+	}
+	data := make(map[string]any, len(s.Arguments))
+	for _, a := range s.Arguments {
+		raw, ok := args[a.Name]
+		switch {
+		case ok:
+		case a.Default != "":
+			raw = a.Default
+		case a.Required:
+			errs.Append("arguments."+a.Name, fmt.Errorf("%w", ErrRequiredField))
+			continue
+		default:
+			data[a.Name] = promptArgumentZero(a.Type)
+			continue
+		}
+		v, err := parsePromptArgumentValue(a.Type, raw)
+		if err != nil {
+			errs.Append("arguments."+a.Name, err)
+			continue
+		}
+		data[a.Name] = v
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	sources := s.Messages
+	if len(sources) == 0 {
+		sources = []PromptMessage{{Role: PromptRoleUser, Content: s.Content}}
+	}
+	if len(s.Arguments) == 0 {
+		return slices.Clone(sources), nil
+	}
+	out := make([]PromptMessage, 0, len(sources))
+	remaining := MaxPromptRenderBytes
+	deadline := time.Now().Add(PromptRenderTimeout)
+	for i, msg := range sources {
+		tmpl, err := parsePromptTemplate(fmt.Sprintf("messages[%d]", i), msg.Content)
+		if err != nil {
+			return nil, fmt.Errorf("parse prompt template: %w", err)
+		}
+		w := &promptWriter{remaining: &remaining, deadline: deadline}
+		if err := tmpl.Execute(w, data); err != nil {
+			return nil, fmt.Errorf("render prompt: %w", err)
+		}
+		out = append(out, PromptMessage{Role: msg.Role, Content: w.buf.String()})
+	}
+	return out, nil
+}
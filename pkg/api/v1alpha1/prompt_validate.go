@@ -1,13 +1,94 @@
 package v1alpha1
 
+import (
+	"fmt"
+	"strings"
+)
+
+// Validate runs Prompt's structural checks.
+//
+// Content MAY be empty (a prompt can be purely descriptive). When the
+// prompt declares Arguments, Content and every Messages entry must parse as
+// a template that only references them; argument names are unique and
+// addressable from a template, types are known, and defaults parse as their
+// type. Without Arguments the text is not a template and is not parsed.
 func (p *Prompt) Validate() error {
 	var errs FieldErrors
 	errs = append(errs, ValidateObjectMeta(p.Metadata)...)
-	// PromptSpec has minimal structure (Description + Content). Content
-	// MAY be empty (a prompt can be purely descriptive), so we don't
-	// require it here.
+	errs = append(errs, validatePromptSpec(&p.Spec)...)
 	if len(errs) == 0 {
 		return nil
 	}
 	return errs
 }
+
+func validatePromptSpec(s *PromptSpec) FieldErrors {
+	var errs FieldErrors
+
+	declared := make(map[string]struct{}, len(s.Arguments))
+	for i, a := range s.Arguments {
+		path := fmt.Sprintf("spec.arguments[%d]", i)
+		switch {
+		case a.Name == "":
+			errs.Append(path+".name", fmt.Errorf("%w", ErrRequiredField))
+		case !promptArgumentNameRegex.MatchString(a.Name):
+			errs.Append(path+".name", fmt.Errorf("%w: %q must match %s", ErrInvalidFormat, a.Name, promptArgumentNameRegex.String()))
+		default:
+			if _, dup := declared[a.Name]; dup {
+				errs.Append(path+".name", fmt.Errorf("%w: duplicate argument %q", ErrInvalidFormat, a.Name))
+			}
+			declared[a.Name] = struct{}{}
+		}
+		switch a.Type {
+		case "", PromptArgumentTypeString, PromptArgumentTypeNumber, PromptArgumentTypeInteger, PromptArgumentTypeBoolean:
+			if a.Default != "" {
+				if _, err := parsePromptArgumentValue(a.Type, a.Default); err != nil {
+					errs.Append(path+".default", err)
+				}
+			}
+		default:
+			errs.Append(path+".type", fmt.Errorf("%w: %q (expected %q, %q, %q, or %q)", ErrInvalidFormat, a.Type,
+				PromptArgumentTypeString, PromptArgumentTypeNumber, PromptArgumentTypeInteger, PromptArgumentTypeBoolean))
+		}
+	}
+
+	if s.Content != "" && len(s.Messages) > 0 {
+		errs.Append("spec.messages", fmt.Errorf("%w: content and messages are mutually exclusive", ErrInvalidFormat))
+	}
+	errs = append(errs, validatePromptTemplate("spec.content", s.Content, declared)...)
+	for i, m := range s.Messages {
+		path := fmt.Sprintf("spec.messages[%d]", i)
+		switch m.Role {
+		case PromptRoleSystem, PromptRoleUser, PromptRoleAssistant:
+		case "":
+			errs.Append(path+".role", fmt.Errorf("%w", ErrRequiredField))
+		default:
+			errs.Append(path+".role", fmt.Errorf("%w: %q (expected %q, %q, or %q)", ErrInvalidFormat, m.Role,
+				PromptRoleSystem, PromptRoleUser, PromptRoleAssistant))
+		}
+		if strings.TrimSpace(m.Content) == "" {
+			errs.Append(path+".content", fmt.Errorf("%w", ErrRequiredField))
+			continue
+		}
+		errs = append(errs, validatePromptTemplate(path+".content", m.Content, declared)...)
+	}
+	return errs
+}
+
+func validatePromptTemplate(path, text string, declared map[string]struct{}) FieldErrors {
+	var errs FieldErrors
+	if text == "" || len(declared) == 0 {
+		return nil
+	}
+	refs, err := promptTemplateRefs(text)
+	if err != nil {
+		errs.Append(path, fmt.Errorf("%w: %v", ErrInvalidFormat, err))
+		return errs
+	}
+	for _, ref := range refs {
+		if _, ok := declared[ref]; !ok {
+			errs.Append(path, fmt.Errorf("%w: template references undeclared argument %q", ErrInvalidFormat, ref))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,253 @@
+package v1alpha1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPromptValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    PromptSpec
+		wantErr string // substring; empty means valid
+	}{
+		{
+			name: "plain content without arguments",
+			spec: PromptSpec{Content: "You are a helpful assistant."},
+		},
+		{
+			name: "braces without arguments are plain text",
+			spec: PromptSpec{Content: "Fill in {{ name }} and {{ .topic }}."},
+		},
+		{
+			name: "empty content",
+			spec: PromptSpec{Description: "descriptive only"},
+		},
+		{
+			name: "content references declared arguments",
+			spec: PromptSpec{
+				Arguments: []PromptArgument{{Name: "topic", Required: true}, {Name: "verbose", Type: PromptArgumentTypeBoolean}},
+				Content:   "Summarize {{ .topic }}.{{ if .verbose }} Be thorough.{{ end }}",
+			},
+		},
+		{
+			name: "with body rebinds dot",
+			spec: PromptSpec{
+				Arguments: []PromptArgument{{Name: "items"}},
+				Content:   "{{ with .items }}{{ .whatever }}{{ end }}",
+			},
+		},
+		{
+			name: "range over a constant",
+			spec: PromptSpec{
+				Arguments: []PromptArgument{{Name: "topic"}},
+				Content:   `{{ range 3 }}- {{ $.topic }}{{ end }}`,
+			},
+		},
+		{
+			name:    "range over an argument",
+			spec:    PromptSpec{Arguments: []PromptArgument{{Name: "n", Type: PromptArgumentTypeInteger}}, Content: "{{ range .n }}x{{ end }}"},
+			wantErr: "range over prompt arguments",
+		},
+		{
+			name:    "range over a variable bound to an argument",
+			spec:    PromptSpec{Arguments: []PromptArgument{{Name: "n", Type: PromptArgumentTypeInteger}}, Content: "{{ $n := .n }}{{ range $n }}x{{ end }}"},
+			wantErr: "range over prompt arguments",
+		},
+		{
+			name:    "nested range",
+			spec:    PromptSpec{Arguments: []PromptArgument{{Name: "a"}}, Content: "{{ range 10 }}{{ range 10 }}{{ $.a }}{{ end }}{{ end }}"},
+			wantErr: "nested range",
+		},
+		{
+			name:    "range over a large constant",
+			spec:    PromptSpec{Arguments: []PromptArgument{{Name: "a"}}, Content: "{{ range 1000000 }}{{ $.a }}{{ end }}"},
+			wantErr: "exceeds 1000 iterations",
+		},
+		{
+			name:    "printf width from an argument",
+			spec:    PromptSpec{Arguments: []PromptArgument{{Name: "n", Type: PromptArgumentTypeInteger}}, Content: `{{ printf "%*d" .n 1 }}`},
+			wantErr: "printf format",
+		},
+		{
+			name:    "printf with a huge width",
+			spec:    PromptSpec{Arguments: []PromptArgument{{Name: "a"}}, Content: `{{ printf "%.100000s" .a }}`},
+			wantErr: "printf format",
+		},
+		{
+			name: "messages",
+			spec: PromptSpec{
+				Arguments: []PromptArgument{{Name: "lang", Default: "Go"}},
+				Messages: []PromptMessage{
+					{Role: PromptRoleSystem, Content: "You review {{ .lang }} code."},
+					{Role: PromptRoleUser, Content: "Review this change."},
+				},
+			},
+		},
+		{
+			name:    "undeclared argument",
+			spec:    PromptSpec{Arguments: []PromptArgument{{Name: "words"}}, Content: "Summarize {{ .topic }}."},
+			wantErr: `undeclared argument "topic"`,
+		},
+		{
+			name:    "undeclared root variable argument",
+			spec:    PromptSpec{Arguments: []PromptArgument{{Name: "a"}}, Content: "{{ with .a }}{{ $.b }}{{ end }}"},
+			wantErr: `undeclared argument "b"`,
+		},
+		{
+			name:    "template does not parse",
+			spec:    PromptSpec{Arguments: []PromptArgument{{Name: "name"}}, Content: "Hello {{ .name "},
+			wantErr: "spec.content",
+		},
+		{
+			name:    "nested template invocation",
+			spec:    PromptSpec{Arguments: []PromptArgument{{Name: "a"}}, Content: `{{ template "x" }}`},
+			wantErr: "spec.content",
+		},
+		{
+			name:    "content and messages are exclusive",
+			spec:    PromptSpec{Content: "a", Messages: []PromptMessage{{Role: PromptRoleUser, Content: "b"}}},
+			wantErr: "spec.messages",
+		},
+		{
+			name:    "unknown message role",
+			spec:    PromptSpec{Messages: []PromptMessage{{Role: "tool", Content: "b"}}},
+			wantErr: "spec.messages[0].role",
+		},
+		{
+			name:    "argument name must be template addressable",
+			spec:    PromptSpec{Arguments: []PromptArgument{{Name: "max-words"}}},
+			wantErr: "spec.arguments[0].name",
+		},
+		{
+			name:    "duplicate argument",
+			spec:    PromptSpec{Arguments: []PromptArgument{{Name: "a"}, {Name: "a"}}},
+			wantErr: `duplicate argument "a"`,
+		},
+		{
+			name:    "unknown argument type",
+			spec:    PromptSpec{Arguments: []PromptArgument{{Name: "a", Type: "date"}}},
+			wantErr: "spec.arguments[0].type",
+		},
+		{
+			name:    "default must parse as type",
+			spec:    PromptSpec{Arguments: []PromptArgument{{Name: "n", Type: PromptArgumentTypeInteger, Default: "many"}}},
+			wantErr: "spec.arguments[0].default",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Prompt{
+				TypeMeta: TypeMeta{APIVersion: GroupVersion, Kind: KindPrompt},
+				Metadata: ObjectMeta{Namespace: "default", Name: "my-prompt", Tag: "v1"},
+				Spec:     tt.spec,
+			}
+			err := p.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() expected error containing %q, got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want substring %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPromptRender(t *testing.T) {
+	spec := PromptSpec{
+		Arguments: []PromptArgument{
+			{Name: "topic", Required: true},
+			{Name: "words", Type: PromptArgumentTypeInteger, Default: "200"},
+			{Name: "bullets", Type: PromptArgumentTypeBoolean},
+		},
+		Content: "Summarize {{ .topic }} in under {{ .words }} words.{{ if .bullets }} Use bullet points.{{ end }}",
+	}
+
+	msgs, err := spec.Render(map[string]string{"topic": "the Q3 report"})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	want := []PromptMessage{{Role: PromptRoleUser, Content: "Summarize the Q3 report in under 200 words."}}
+	if len(msgs) != 1 || msgs[0] != want[0] {
+		t.Fatalf("Render = %+v, want %+v", msgs, want)
+	}
+
+	msgs, err = spec.Render(map[string]string{"topic": "x", "words": "50", "bullets": "true"})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if got := msgs[0].Content; got != "Summarize x in under 50 words. Use bullet points." {
+		t.Fatalf("Render content = %q", got)
+	}
+
+	_, err = spec.Render(map[string]string{"words": "lots", "extra": "1"})
+	var fe FieldErrors
+	if !errors.As(err, &fe) {
+		t.Fatalf("Render error = %v, want FieldErrors", err)
+	}
+	for _, want := range []string{"arguments.topic", "arguments.words", "arguments.extra"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Render error = %v, want mention of %s", err, want)
+		}
+	}
+}
+
+func TestPromptRender_Messages(t *testing.T) {
+	spec := PromptSpec{
+		Arguments: []PromptArgument{{Name: "lang", Default: "Go"}},
+		Messages: []PromptMessage{
+			{Role: PromptRoleSystem, Content: "You review {{ .lang }} code."},
+			{Role: PromptRoleUser, Content: "Review this change."},
+		},
+	}
+	msgs, err := spec.Render(nil)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if len(msgs) != 2 || msgs[0].Role != PromptRoleSystem || msgs[0].Content != "You review Go code." || msgs[1].Role != PromptRoleUser {
+		t.Fatalf("Render = %+v", msgs)
+	}
+}
+
+func TestPromptRender_BoundsOutput(t *testing.T) {
+	// Render bounds output even for a template validation would refuse,
+	// such as one stored before the range rules existed.
+	spec := PromptSpec{
+		Arguments: []PromptArgument{{Name: "a", Default: "x"}},
+		Content:   `{{ range 2000 }}{{ printf "%999s" $.a }}{{ end }}`,
+	}
+	if _, err := spec.Render(nil); !errors.Is(err, errPromptTooLarge) {
+		t.Fatalf("Render error = %v, want errPromptTooLarge", err)
+	}
+}
+
+func TestPromptRender_PlainTextWithoutArguments(t *testing.T) {
+	spec := PromptSpec{Content: "Reply with {{ name }} filled in."}
+	msgs, err := spec.Render(nil)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if len(msgs) != 1 || msgs[0].Content != spec.Content || msgs[0].Role != PromptRoleUser {
+		t.Fatalf("Render = %+v, want content verbatim", msgs)
+	}
+
+	spec = PromptSpec{
+		Arguments: []PromptArgument{{Name: "field", Default: "name"}},
+		Content:   `Reply with {{ "{{" }} {{ .field }} }} filled in.`,
+	}
+	msgs, err = spec.Render(nil)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if got := msgs[0].Content; got != "Reply with {{ name }} filled in." {
+		t.Fatalf("Render content = %q", got)
+	}
+}
@@ -0,0 +1,100 @@
+package v1alpha1
+
+// Role grants access to resources in its own namespace. A Role does nothing
+// until a RoleBinding in the same namespace binds it to subjects.
+type Role struct {
+	TypeMeta `json:",inline" yaml:",inline"`
+	Metadata ObjectMeta `json:"metadata" yaml:"metadata"`
+	Spec     RoleSpec   `json:"spec" yaml:"spec"`
+	Status   Status     `json:"status,omitzero" yaml:"status,omitempty"`
+}
+
+// ClusterRole grants access across namespaces: each rule lists the
+// namespaces it covers. ClusterRoles are cluster-scoped, so they always live
+// in DefaultNamespace; a RoleBinding in any namespace may bind one.
+type ClusterRole struct {
+	TypeMeta `json:",inline" yaml:",inline"`
+	Metadata ObjectMeta `json:"metadata" yaml:"metadata"`
+	Spec     RoleSpec   `json:"spec" yaml:"spec"`
+	Status   Status     `json:"status,omitzero" yaml:"status,omitempty"`
+}
+
+// RoleBinding grants the rules of one Role or ClusterRole to a set of
+// subjects. A bound Role applies in the binding's namespace; a bound
+// ClusterRole applies in the namespaces its rules list.
+type RoleBinding struct {
+	TypeMeta `json:",inline" yaml:",inline"`
+	Metadata ObjectMeta      `json:"metadata" yaml:"metadata"`
+	Spec     RoleBindingSpec `json:"spec" yaml:"spec"`
+	Status   Status          `json:"status,omitzero" yaml:"status,omitempty"`
+}
+
+func init() {
+	MustRegisterKind[*Role, RoleSpec](KindRole, WithMutableObjectStorage())
+	MustRegisterKind[*ClusterRole, RoleSpec](KindClusterRole, WithMutableObjectStorage())
+	MustRegisterKind[*RoleBinding, RoleBindingSpec](KindRoleBinding, WithMutableObjectStorage())
+}
+
+// RBAC verbs. They match the verbs the resource handlers authorize:
+// get and list for reads, apply for creates and updates, delete for
+// deletes. RBACWildcard in Verbs, Kinds, Namespaces or Names matches
+// everything.
+const (
+	RBACVerbGet    = "get"
+	RBACVerbList   = "list"
+	RBACVerbApply  = "apply"
+	RBACVerbDelete = "delete"
+	RBACWildcard   = "*"
+)
+
+// Subject kinds a RoleBinding can name.
+const (
+	SubjectKindUser  = "User"
+	SubjectKindGroup = "Group"
+)
+
+// RoleSpec is the declarative body shared by Role and ClusterRole.
+type RoleSpec struct {
+	Description string       `json:"description,omitempty" yaml:"description,omitempty"`
+	Rules       []PolicyRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// PolicyRule allows every combination of its verbs, kinds, namespaces and
+// names.
+type PolicyRule struct {
+	// Verbs are RBACVerb* values or RBACWildcard.
+	Verbs []string `json:"verbs" yaml:"verbs"`
+	// Kinds are canonical kind names (e.g. "Agent", "MCPServer") or
+	// RBACWildcard. Validation canonicalizes the case.
+	Kinds []string `json:"kinds" yaml:"kinds"`
+	// Namespaces lists the namespaces a ClusterRole rule covers; empty or
+	// RBACWildcard covers every namespace. Role rules must leave it empty:
+	// they cover the Role's own namespace.
+	Namespaces []string `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+	// Names restricts the rule to matching object names: an exact name, a
+	// prefix ending in "*" (e.g. "team-a-*"), or RBACWildcard. Empty covers
+	// every name.
+	Names []string `json:"names,omitempty" yaml:"names,omitempty"`
+}
+
+// RoleBindingSpec is the RoleBinding resource's declarative body.
+type RoleBindingSpec struct {
+	RoleRef  RoleRef   `json:"roleRef" yaml:"roleRef"`
+	Subjects []Subject `json:"subjects" yaml:"subjects"`
+}
+
+// RoleRef names the Role (in the binding's namespace) or ClusterRole a
+// RoleBinding grants.
+type RoleRef struct {
+	Kind string `json:"kind" yaml:"kind"`
+	Name string `json:"name" yaml:"name"`
+}
+
+// Subject is a user or group a RoleBinding grants its role to. Users are
+// matched by the authenticated identity's subject; groups by the groups the
+// identity carries, plus "system:authenticated" for every signed-in caller
+// and "system:unauthenticated" for anonymous ones.
+type Subject struct {
+	Kind string `json:"kind" yaml:"kind"`
+	Name string `json:"name" yaml:"name"`
+}
@@ -0,0 +1,107 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRoleValidate(t *testing.T) {
+	meta := ObjectMeta{Namespace: "team-a", Name: "publisher"}
+	tests := []struct {
+		name    string
+		rules   []PolicyRule
+		wantErr string
+	}{
+		{name: "valid", rules: []PolicyRule{{Verbs: []string{"get", "apply"}, Kinds: []string{"Agent", "*"}, Names: []string{"support-*", "exact"}}}},
+		{name: "no rules", rules: nil},
+		{name: "unknown verb", rules: []PolicyRule{{Verbs: []string{"publish"}, Kinds: []string{"Agent"}}}, wantErr: "spec.rules[0].verbs"},
+		{name: "no verbs", rules: []PolicyRule{{Kinds: []string{"Agent"}}}, wantErr: "spec.rules[0].verbs"},
+		{name: "unknown kind", rules: []PolicyRule{{Verbs: []string{"get"}, Kinds: []string{"Widget"}}}, wantErr: "spec.rules[0].kinds"},
+		{name: "namespaces on a Role", rules: []PolicyRule{{Verbs: []string{"get"}, Kinds: []string{"Agent"}, Namespaces: []string{"team-b"}}}, wantErr: "spec.rules[0].namespaces"},
+		{name: "inner wildcard", rules: []PolicyRule{{Verbs: []string{"get"}, Kinds: []string{"Agent"}, Names: []string{"a*b"}}}, wantErr: "spec.rules[0].names"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Role{Metadata: meta, Spec: RoleSpec{Rules: tt.rules}}).Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRoleValidate_CanonicalizesKinds(t *testing.T) {
+	role := &Role{
+		Metadata: ObjectMeta{Namespace: "default", Name: "reader"},
+		Spec:     RoleSpec{Rules: []PolicyRule{{Verbs: []string{"get"}, Kinds: []string{"mcpserver", "AGENT"}}}},
+	}
+	if err := role.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if got := role.Spec.Rules[0].Kinds; got[0] != KindMCPServer || got[1] != KindAgent {
+		t.Fatalf("kinds = %v, want canonical names", got)
+	}
+}
+
+func TestClusterRoleValidate(t *testing.T) {
+	rules := []PolicyRule{{Verbs: []string{"*"}, Kinds: []string{"*"}, Namespaces: []string{"team-a", "*"}}}
+	if err := (&ClusterRole{Metadata: ObjectMeta{Namespace: DefaultNamespace, Name: "admin"}, Spec: RoleSpec{Rules: rules}}).Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	err := (&ClusterRole{Metadata: ObjectMeta{Namespace: "team-a", Name: "admin"}, Spec: RoleSpec{Rules: rules}}).Validate()
+	if err == nil || !strings.Contains(err.Error(), "metadata.namespace") {
+		t.Fatalf("Validate error = %v, want metadata.namespace", err)
+	}
+}
+
+func TestRoleBindingValidate(t *testing.T) {
+	meta := ObjectMeta{Namespace: "team-a", Name: "publishers"}
+	tests := []struct {
+		name    string
+		spec    RoleBindingSpec
+		wantErr string
+	}{
+		{
+			name: "valid",
+			spec: RoleBindingSpec{
+				RoleRef:  RoleRef{Kind: KindClusterRole, Name: "viewer"},
+				Subjects: []Subject{{Kind: SubjectKindUser, Name: "alice@example.com"}, {Kind: SubjectKindGroup, Name: "system:authenticated"}},
+			},
+		},
+		{
+			name:    "bad role kind",
+			spec:    RoleBindingSpec{RoleRef: RoleRef{Kind: KindAgent, Name: "viewer"}, Subjects: []Subject{{Kind: SubjectKindUser, Name: "alice"}}},
+			wantErr: "spec.roleRef.kind",
+		},
+		{
+			name:    "no subjects",
+			spec:    RoleBindingSpec{RoleRef: RoleRef{Kind: KindRole, Name: "viewer"}},
+			wantErr: "spec.subjects",
+		},
+		{
+			name:    "bad subject kind",
+			spec:    RoleBindingSpec{RoleRef: RoleRef{Kind: KindRole, Name: "viewer"}, Subjects: []Subject{{Kind: "ServiceAccount", Name: "ci"}}},
+			wantErr: "spec.subjects[0].kind",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&RoleBinding{Metadata: meta, Spec: tt.spec}).Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
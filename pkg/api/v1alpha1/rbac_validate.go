@@ -0,0 +1,117 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// rbacVerbs is the set of verbs a PolicyRule may name besides RBACWildcard.
+var rbacVerbs = []string{RBACVerbGet, RBACVerbList, RBACVerbApply, RBACVerbDelete}
+
+// Validate runs Role's structural checks. Role rules cover the Role's own
+// namespace, so they must not list namespaces.
+func (r *Role) Validate() error {
+	var errs FieldErrors
+	errs = append(errs, ValidateObjectMeta(r.Metadata)...)
+	errs = append(errs, validatePolicyRules(r.Spec.Rules, false)...)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Validate runs ClusterRole's structural checks. ClusterRoles are
+// cluster-scoped and must live in DefaultNamespace.
+func (c *ClusterRole) Validate() error {
+	var errs FieldErrors
+	errs = append(errs, ValidateObjectMeta(c.Metadata)...)
+	if c.Metadata.Namespace != "" && c.Metadata.Namespace != DefaultNamespace {
+		errs.Append("metadata.namespace", fmt.Errorf("%w: ClusterRole is cluster-scoped and must be in namespace %q", ErrInvalidFormat, DefaultNamespace))
+	}
+	errs = append(errs, validatePolicyRules(c.Spec.Rules, true)...)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Validate runs RoleBinding's structural checks. The referenced role is
+// not required to exist yet.
+func (b *RoleBinding) Validate() error {
+	var errs FieldErrors
+	errs = append(errs, ValidateObjectMeta(b.Metadata)...)
+	switch b.Spec.RoleRef.Kind {
+	case KindRole, KindClusterRole:
+	case "":
+		errs.Append("spec.roleRef.kind", fmt.Errorf("%w", ErrRequiredField))
+	default:
+		errs.Append("spec.roleRef.kind", fmt.Errorf("%w: %q (expected %s or %s)", ErrInvalidRef, b.Spec.RoleRef.Kind, KindRole, KindClusterRole))
+	}
+	if err := validateNameField(b.Spec.RoleRef.Name); err != nil {
+		errs.Append("spec.roleRef.name", err)
+	}
+	if len(b.Spec.Subjects) == 0 {
+		errs.Append("spec.subjects", fmt.Errorf("%w", ErrRequiredField))
+	}
+	for i, subject := range b.Spec.Subjects {
+		path := "spec.subjects[" + strconv.Itoa(i) + "]"
+		if subject.Kind != SubjectKindUser && subject.Kind != SubjectKindGroup {
+			errs.Append(path+".kind", fmt.Errorf("%w: %q (expected %s or %s)", ErrInvalidFormat, subject.Kind, SubjectKindUser, SubjectKindGroup))
+		}
+		if strings.TrimSpace(subject.Name) == "" {
+			errs.Append(path+".name", fmt.Errorf("%w", ErrRequiredField))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// validatePolicyRules checks each rule and canonicalizes its kind names in
+// place, so evaluation can compare kinds with exact-match equality.
+func validatePolicyRules(rules []PolicyRule, allowNamespaces bool) FieldErrors {
+	var errs FieldErrors
+	for i := range rules {
+		rule := &rules[i]
+		path := "spec.rules[" + strconv.Itoa(i) + "]"
+		if len(rule.Verbs) == 0 {
+			errs.Append(path+".verbs", fmt.Errorf("%w", ErrRequiredField))
+		}
+		for _, verb := range rule.Verbs {
+			if verb != RBACWildcard && !slices.Contains(rbacVerbs, verb) {
+				errs.Append(path+".verbs", fmt.Errorf("%w: %q (expected one of %v or %q)", ErrInvalidFormat, verb, rbacVerbs, RBACWildcard))
+			}
+		}
+		if len(rule.Kinds) == 0 {
+			errs.Append(path+".kinds", fmt.Errorf("%w", ErrRequiredField))
+		}
+		for j, kind := range rule.Kinds {
+			if kind == RBACWildcard {
+				continue
+			}
+			descriptor, ok := KindDescriptorFor(kind)
+			if !ok {
+				errs.Append(path+".kinds", fmt.Errorf("%w: unknown kind %q", ErrInvalidFormat, kind))
+				continue
+			}
+			rule.Kinds[j] = descriptor.Kind
+		}
+		if len(rule.Namespaces) > 0 && !allowNamespaces {
+			errs.Append(path+".namespaces", fmt.Errorf("%w: a Role's rules apply to its own namespace; use a ClusterRole to span namespaces", ErrInvalidFormat))
+		}
+		for _, ns := range rule.Namespaces {
+			if ns != RBACWildcard && !namespaceRegex.MatchString(ns) {
+				errs.Append(path+".namespaces", fmt.Errorf("%w: %q", ErrInvalidFormat, ns))
+			}
+		}
+		for _, name := range rule.Names {
+			if name == "" || strings.Contains(strings.TrimSuffix(name, "*"), "*") {
+				errs.Append(path+".names", fmt.Errorf("%w: %q (expected a name, a prefix ending in \"*\", or %q)", ErrInvalidFormat, name, RBACWildcard))
+			}
+		}
+	}
+	return errs
+}
@@ -8,6 +8,10 @@ package v1alpha1
 // object" (the common case). Tag is optional: blank means "resolve to the
 // literal latest tag" for taggable artifacts or "resolve by namespace/name"
 // for mutable object kinds.
+//
+// On taggable artifacts Tag may also be a semver constraint ("^1.2",
+// "~0.4.1", ">=2 <3"). It resolves to the highest live tag that satisfies the
+// constraint each time the reference is followed; see IsTagConstraint.
 type ResourceRef struct {
 	Kind      string `json:"kind" yaml:"kind"`
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
@@ -29,3 +29,43 @@ func TestResourceRef_UsesTag(t *testing.T) {
 		require.Contains(t, string(out), "tag: stable")
 	})
 }
+
+func TestIsTagConstraint(t *testing.T) {
+	for _, tag := range []string{"^1.2", "~0.4.1", ">=2 <3", "1.2.*", "!=1.0.0", "^1 || ^2"} {
+		require.True(t, IsTagConstraint(tag), tag)
+	}
+	for _, tag := range []string{"", "latest", "v1.2.0", "1.2.0-rc.1", "stable"} {
+		require.False(t, IsTagConstraint(tag), tag)
+	}
+}
+
+func TestMatchTagConstraint(t *testing.T) {
+	tags := []string{"latest", "1.2.0", "v1.3.1", "1.10.0", "2.0.0", "2.1.0-rc.1", "0.4.1", "0.4.7", "0.5.0", "stable"}
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{"^1.2", "1.10.0"},
+		{"~1.3", "v1.3.1"},
+		{"~0.4.1", "0.4.7"},
+		{">=2 <3", "2.0.0"},
+		{">=2.1.0-rc.0 <3", "2.1.0-rc.1"},
+		{"^0.4 || ^1", "1.10.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			got, err := MatchTagConstraint(tt.constraint, tags)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := MatchTagConstraint("^3", tags)
+	require.ErrorIs(t, err, ErrNoMatchingTag)
+	_, err = MatchTagConstraint(">=banana", tags)
+	require.ErrorIs(t, err, ErrInvalidTag)
+
+	got, err := MatchTagConstraint("^1", []string{"v1.0.0", "1.0.0"})
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", got, "equal versions resolve deterministically")
+}
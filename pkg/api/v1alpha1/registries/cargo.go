@@ -0,0 +1,79 @@
+package registries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+var (
+	ErrMissingIdentifierForCargo = errors.New("crate name is required for Cargo packages")
+	ErrMissingVersionForCargo    = errors.New("crate version is required for Cargo packages")
+)
+
+// ValidateCargo validates that a crate version exists on a crates.io-style
+// registry and that its README claims the MCP server name as
+// "mcp-name: <serverName>".
+func ValidateCargo(ctx context.Context, origin v1alpha1.MCPPackageOrigin, serverName string) error {
+	if origin.Cargo == nil {
+		return fmt.Errorf("cargo validator called without origin.Cargo set")
+	}
+	if origin.Identifier == "" {
+		return ErrMissingIdentifierForCargo
+	}
+	if origin.Cargo.Version == "" {
+		return ErrMissingVersionForCargo
+	}
+
+	mirror := strings.TrimSuffix(origin.Cargo.Mirror, "/")
+	if mirror == "" {
+		mirror = DefaultURLCargo
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	// crates.io serves the rendered README per version; a 404 there means
+	// either the version or its README is missing, so probe the version
+	// first for a precise error.
+	base := fmt.Sprintf("%s/api/v1/crates/%s/%s", mirror, url.PathEscape(origin.Identifier), url.PathEscape(origin.Cargo.Version))
+	if _, err := cargoGet(ctx, client, base); err != nil {
+		return fmt.Errorf("Cargo crate '%s@%s' %w", origin.Identifier, origin.Cargo.Version, err)
+	}
+	readme, err := cargoGet(ctx, client, base+"/readme")
+	if err != nil {
+		return fmt.Errorf("README for Cargo crate '%s@%s' %w", origin.Identifier, origin.Cargo.Version, err)
+	}
+	if strings.Contains(readme, "mcp-name: "+serverName) {
+		return nil
+	}
+	return fmt.Errorf("Cargo crate '%s' ownership validation failed. The server name '%s' must appear as 'mcp-name: %s' in the crate README", origin.Identifier, serverName, serverName)
+}
+
+// cargoGet fetches requestURL. crates.io rejects requests without a
+// User-Agent, so one is always sent.
+func cargoGet(ctx context.Context, client *http.Client, requestURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "agent-registry-Validator/1.0")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("could not be fetched: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("not found (status: %d)", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxReadmeBytes))
+	if err != nil {
+		return "", fmt.Errorf("could not be read: %w", err)
+	}
+	return string(body), nil
+}
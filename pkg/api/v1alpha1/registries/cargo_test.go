@@ -0,0 +1,43 @@
+package registries_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1/registries"
+)
+
+func TestValidateCargo_Mirror(t *testing.T) {
+	const serverName = "io.github.acme/mcp"
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NotEmpty(t, r.Header.Get("User-Agent"), "crates.io rejects requests without a User-Agent")
+		switch r.URL.Path {
+		case "/api/v1/crates/acme-mcp/0.3.0":
+			_, _ = w.Write([]byte(`{"version":{"num":"0.3.0"}}`))
+		case "/api/v1/crates/acme-mcp/0.3.0/readme":
+			_, _ = w.Write([]byte("<p>mcp-name: " + serverName + "</p>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer mirror.Close()
+
+	origin := func(version string) v1alpha1.MCPPackageOrigin {
+		return v1alpha1.MCPPackageOrigin{
+			Type:       v1alpha1.MCPPackageOriginTypeCargo,
+			Identifier: "acme-mcp",
+			Cargo:      &v1alpha1.MCPPackageOriginCargo{Version: version, Mirror: mirror.URL, ServerName: serverName},
+		}
+	}
+	ctx := context.Background()
+
+	require.NoError(t, registries.ValidateCargo(ctx, origin("0.3.0"), serverName))
+	require.ErrorContains(t, registries.ValidateCargo(ctx, origin("9.9.9"), serverName), "not found (status: 404)")
+	require.ErrorContains(t, registries.ValidateCargo(ctx, origin("0.3.0"), "io.github.acme/other"), "ownership validation failed")
+	require.ErrorIs(t, registries.ValidateCargo(ctx, origin(""), serverName), registries.ErrMissingVersionForCargo)
+}
@@ -29,7 +29,13 @@ func Dispatcher(ctx context.Context, origin v1alpha1.MCPPackageOrigin, objectNam
 		return ValidatePyPI(ctx, origin, objectName)
 	case origin.OCI != nil:
 		return ValidateOCI(ctx, origin, objectName)
+	case origin.GitHubRelease != nil:
+		return ValidateGitHubRelease(ctx, origin, objectName)
+	case origin.Go != nil:
+		return ValidateGo(ctx, origin, objectName)
+	case origin.Cargo != nil:
+		return ValidateCargo(ctx, origin, objectName)
 	default:
-		return fmt.Errorf("MCPPackage origin: exactly one of npm/pypi/oci must be set (got Type=%q)", origin.Type)
+		return fmt.Errorf("MCPPackage origin: exactly one of npm/pypi/oci/githubRelease/go/cargo must be set (got Type=%q)", origin.Type)
 	}
 }
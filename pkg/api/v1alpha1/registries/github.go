@@ -0,0 +1,124 @@
+package registries
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+var (
+	ErrMissingIdentifierForGitHubRelease = errors.New("repository identifier is required for GitHub release packages")
+	ErrMissingVersionForGitHubRelease    = errors.New("release tag is required for GitHub release packages")
+	ErrMissingAssetForGitHubRelease      = errors.New("release asset is required for GitHub release packages")
+)
+
+// maxReadmeBytes bounds the README bodies the validators read.
+const maxReadmeBytes = 1 << 20
+
+// GitHubReleaseResponse is the subset of the GitHub releases API the
+// validator reads.
+type GitHubReleaseResponse struct {
+	Assets []struct {
+		Name string `json:"name"`
+		// Digest is "sha256:<hex>" on releases GitHub has computed it for.
+		Digest string `json:"digest"`
+	} `json:"assets"`
+}
+
+// ValidateGitHubRelease validates that a GitHub release publishes the
+// declared asset and that the repository README at the release tag claims
+// the MCP server name as "mcp-name: <serverName>". When GITHUB_TOKEN is set
+// it authenticates the API calls, which lifts the anonymous rate limit.
+func ValidateGitHubRelease(ctx context.Context, origin v1alpha1.MCPPackageOrigin, serverName string) error {
+	g := origin.GitHubRelease
+	if g == nil {
+		return fmt.Errorf("GitHub release validator called without origin.GitHubRelease set")
+	}
+	if origin.Identifier == "" {
+		return ErrMissingIdentifierForGitHubRelease
+	}
+	if g.Version == "" {
+		return ErrMissingVersionForGitHubRelease
+	}
+	if g.Asset == "" {
+		return ErrMissingAssetForGitHubRelease
+	}
+
+	api := githubAPIBase(g.Mirror)
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	var release GitHubReleaseResponse
+	releaseURL := fmt.Sprintf("%s/repos/%s/releases/tags/%s", api, origin.Identifier, url.PathEscape(g.Version))
+	if err := githubGet(ctx, client, releaseURL, "application/vnd.github+json", func(body io.Reader) error {
+		return json.NewDecoder(body).Decode(&release)
+	}); err != nil {
+		return fmt.Errorf("GitHub release '%s@%s': %w", origin.Identifier, g.Version, err)
+	}
+	found := false
+	for _, asset := range release.Assets {
+		if asset.Name != g.Asset {
+			continue
+		}
+		found = true
+		if g.SHA256 != "" && asset.Digest != "" && asset.Digest != "sha256:"+g.SHA256 {
+			return fmt.Errorf("GitHub release asset '%s' digest is %s, expected sha256:%s", g.Asset, asset.Digest, g.SHA256)
+		}
+	}
+	if !found {
+		return fmt.Errorf("GitHub release '%s@%s' has no asset named '%s'", origin.Identifier, g.Version, g.Asset)
+	}
+
+	var readme []byte
+	readmeURL := fmt.Sprintf("%s/repos/%s/readme?ref=%s", api, origin.Identifier, url.QueryEscape(g.Version))
+	if err := githubGet(ctx, client, readmeURL, "application/vnd.github.raw", func(body io.Reader) error {
+		var err error
+		readme, err = io.ReadAll(io.LimitReader(body, maxReadmeBytes))
+		return err
+	}); err != nil {
+		return fmt.Errorf("README for '%s@%s': %w", origin.Identifier, g.Version, err)
+	}
+	if strings.Contains(string(readme), "mcp-name: "+serverName) {
+		return nil
+	}
+	return fmt.Errorf("GitHub repository '%s' ownership validation failed. The server name '%s' must appear as 'mcp-name: %s' in the README at tag %s", origin.Identifier, serverName, serverName, g.Version)
+}
+
+// githubAPIBase maps a GitHub web base URL to its REST API base: the public
+// API for github.com, and the /api/v3 path GitHub Enterprise serves.
+func githubAPIBase(mirror string) string {
+	mirror = strings.TrimSuffix(mirror, "/")
+	if mirror == "" || mirror == DefaultURLGitHub {
+		return "https://api.github.com"
+	}
+	return mirror + "/api/v3"
+}
+
+func githubGet(ctx context.Context, client *http.Client, requestURL, accept string, decode func(io.Reader) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "agent-registry-Validator/1.0")
+	req.Header.Set("Accept", accept)
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch from GitHub: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("not found (status: %d)", resp.StatusCode)
+	}
+	return decode(resp.Body)
+}
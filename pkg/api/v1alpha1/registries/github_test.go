@@ -0,0 +1,76 @@
+package registries_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1/registries"
+)
+
+// TestValidateGitHubRelease_Mirror drives the validator against a GitHub
+// Enterprise-style mirror serving the releases and README APIs under
+// /api/v3.
+func TestValidateGitHubRelease_Mirror(t *testing.T) {
+	const (
+		serverName = "io.github.acme/mcp-server"
+		sum        = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	)
+	readme := "# mcp-server\n\nmcp-name: " + serverName + "\n"
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/repos/acme/mcp-server/releases/tags/v1.2.0":
+			_, _ = w.Write([]byte(`{"assets":[{"name":"mcp-server.tar.gz","digest":"sha256:` + sum + `"}]}`))
+		case "/api/v3/repos/acme/mcp-server/readme":
+			require.Equal(t, "v1.2.0", r.URL.Query().Get("ref"))
+			_, _ = w.Write([]byte(readme))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer mirror.Close()
+
+	origin := func(asset, digest string) v1alpha1.MCPPackageOrigin {
+		return v1alpha1.MCPPackageOrigin{
+			Type:       v1alpha1.MCPPackageOriginTypeGitHubRelease,
+			Identifier: "acme/mcp-server",
+			GitHubRelease: &v1alpha1.MCPPackageOriginGitHubRelease{
+				Version:    "v1.2.0",
+				Asset:      asset,
+				SHA256:     digest,
+				Mirror:     mirror.URL,
+				ServerName: serverName,
+			},
+		}
+	}
+	ctx := context.Background()
+
+	require.NoError(t, registries.ValidateGitHubRelease(ctx, origin("mcp-server.tar.gz", sum), serverName))
+
+	err := registries.ValidateGitHubRelease(ctx, origin("missing.tar.gz", ""), serverName)
+	require.ErrorContains(t, err, "has no asset named 'missing.tar.gz'")
+
+	err = registries.ValidateGitHubRelease(ctx, origin("mcp-server.tar.gz", "ff"+sum[2:]), serverName)
+	require.ErrorContains(t, err, "digest is sha256:"+sum)
+
+	err = registries.ValidateGitHubRelease(ctx, origin("mcp-server.tar.gz", ""), "io.github.acme/other")
+	require.ErrorContains(t, err, "ownership validation failed")
+}
+
+func TestValidateGitHubRelease_RequiredFields(t *testing.T) {
+	ctx := context.Background()
+	err := registries.ValidateGitHubRelease(ctx, v1alpha1.MCPPackageOrigin{
+		GitHubRelease: &v1alpha1.MCPPackageOriginGitHubRelease{Version: "v1", Asset: "a"},
+	}, "io.example/x")
+	require.ErrorIs(t, err, registries.ErrMissingIdentifierForGitHubRelease)
+
+	err = registries.ValidateGitHubRelease(ctx, v1alpha1.MCPPackageOrigin{
+		Identifier:    "acme/x",
+		GitHubRelease: &v1alpha1.MCPPackageOriginGitHubRelease{Version: "v1"},
+	}, "io.example/x")
+	require.ErrorIs(t, err, registries.ErrMissingAssetForGitHubRelease)
+}
@@ -0,0 +1,147 @@
+package registries
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/module"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+var (
+	ErrMissingIdentifierForGo = errors.New("package import path is required for Go packages")
+	ErrMissingVersionForGo    = errors.New("package version is required for Go packages")
+)
+
+// maxGoModuleZipBytes bounds the module zip the validator downloads to
+// find the README.
+const maxGoModuleZipBytes = 64 << 20
+
+// ValidateGo validates that a Go module proxy serves the module providing
+// the package's import path at the declared version, and that the module
+// claims the MCP server name as "mcp-name: <serverName>" in a README next
+// to the main package or at the module root.
+func ValidateGo(ctx context.Context, origin v1alpha1.MCPPackageOrigin, serverName string) error {
+	if origin.Go == nil {
+		return fmt.Errorf("go validator called without origin.Go set")
+	}
+	if origin.Identifier == "" {
+		return ErrMissingIdentifierForGo
+	}
+	if origin.Go.Version == "" {
+		return ErrMissingVersionForGo
+	}
+	version, err := module.EscapeVersion(origin.Go.Version)
+	if err != nil {
+		return fmt.Errorf("invalid Go module version '%s': %w", origin.Go.Version, err)
+	}
+
+	mirror := strings.TrimSuffix(origin.Go.Mirror, "/")
+	if mirror == "" {
+		mirror = DefaultURLGo
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	// The module path is the longest prefix of the import path the proxy
+	// knows at this version — the same search `go run pkg@version` does.
+	modulePath := ""
+	for candidate := origin.Identifier; ; candidate = path.Dir(candidate) {
+		escaped, err := module.EscapePath(candidate)
+		if err != nil {
+			return fmt.Errorf("invalid Go import path '%s': %w", origin.Identifier, err)
+		}
+		status, _, err := goProxyGet(ctx, client, fmt.Sprintf("%s/%s/@v/%s.info", mirror, escaped, version), 0)
+		if err != nil {
+			return err
+		}
+		if status == http.StatusOK {
+			modulePath = candidate
+			break
+		}
+		if status != http.StatusNotFound && status != http.StatusGone {
+			return fmt.Errorf("go module proxy lookup for '%s' failed (status: %d)", candidate, status)
+		}
+		if !strings.Contains(candidate, "/") {
+			break
+		}
+	}
+	if modulePath == "" {
+		return fmt.Errorf("go package '%s@%s' not found on module proxy", origin.Identifier, origin.Go.Version)
+	}
+
+	escaped, _ := module.EscapePath(modulePath)
+	status, body, err := goProxyGet(ctx, client, fmt.Sprintf("%s/%s/@v/%s.zip", mirror, escaped, version), maxGoModuleZipBytes)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("go module '%s@%s' zip not available (status: %d)", modulePath, origin.Go.Version, status)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return fmt.Errorf("failed to read go module zip: %w", err)
+	}
+
+	prefix := modulePath + "@" + origin.Go.Version + "/"
+	pkgDir := strings.TrimPrefix(strings.TrimPrefix(origin.Identifier, modulePath), "/")
+	marker := "mcp-name: " + serverName
+	for _, f := range zr.File {
+		rel, ok := strings.CutPrefix(f.Name, prefix)
+		if !ok {
+			continue
+		}
+		dir, base := path.Split(rel)
+		dir = strings.TrimSuffix(dir, "/")
+		if (dir != "" && dir != pkgDir) || !strings.HasPrefix(strings.ToLower(base), "readme") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("failed to read %s from go module zip: %w", rel, err)
+		}
+		readme, err := io.ReadAll(io.LimitReader(rc, maxReadmeBytes))
+		_ = rc.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read %s from go module zip: %w", rel, err)
+		}
+		if strings.Contains(string(readme), marker) {
+			return nil
+		}
+	}
+	return fmt.Errorf("go module '%s' ownership validation failed. The server name '%s' must appear as 'mcp-name: %s' in a README", modulePath, serverName, serverName)
+}
+
+// goProxyGet fetches a proxy URL, reading at most limit bytes of a 200
+// response (none when limit is 0).
+func goProxyGet(ctx context.Context, client *http.Client, requestURL string, limit int64) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "agent-registry-Validator/1.0")
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to fetch from go module proxy: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK || limit == 0 {
+		return resp.StatusCode, nil, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read from go module proxy: %w", err)
+	}
+	if int64(len(body)) > limit {
+		return 0, nil, fmt.Errorf("go module zip exceeds %d bytes", limit)
+	}
+	return resp.StatusCode, body, nil
+}
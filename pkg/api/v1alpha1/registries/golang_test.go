@@ -0,0 +1,74 @@
+package registries_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1/registries"
+)
+
+// TestValidateGo_Mirror serves a module proxy that knows
+// github.com/acme/mcp but not the github.com/acme/mcp/cmd/server package
+// path, so the validator must walk up to the module and find the README in
+// the package directory of its zip.
+func TestValidateGo_Mirror(t *testing.T) {
+	const serverName = "io.github.acme/mcp"
+	var zipBuf bytes.Buffer
+	zw := zip.NewWriter(&zipBuf)
+	for name, content := range map[string]string{
+		"github.com/acme/mcp@v0.4.1/go.mod":                   "module github.com/acme/mcp\n",
+		"github.com/acme/mcp@v0.4.1/cmd/server/README.md":     "mcp-name: " + serverName + "\n",
+		"github.com/acme/mcp@v0.4.1/internal/other/README.md": "mcp-name: io.github.acme/other\n",
+	} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	var probed []string
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probed = append(probed, r.URL.Path)
+		switch r.URL.Path {
+		case "/github.com/acme/mcp/@v/v0.4.1.info":
+			_, _ = w.Write([]byte(`{"Version":"v0.4.1"}`))
+		case "/github.com/acme/mcp/@v/v0.4.1.zip":
+			_, _ = w.Write(zipBuf.Bytes())
+		default:
+			http.Error(w, "not found", http.StatusGone)
+		}
+	}))
+	defer mirror.Close()
+
+	origin := func(identifier string) v1alpha1.MCPPackageOrigin {
+		return v1alpha1.MCPPackageOrigin{
+			Type:       v1alpha1.MCPPackageOriginTypeGo,
+			Identifier: identifier,
+			Go:         &v1alpha1.MCPPackageOriginGo{Version: "v0.4.1", Mirror: mirror.URL, ServerName: serverName},
+		}
+	}
+	ctx := context.Background()
+
+	require.NoError(t, registries.ValidateGo(ctx, origin("github.com/acme/mcp/cmd/server"), serverName))
+	require.Equal(t, []string{
+		"/github.com/acme/mcp/cmd/server/@v/v0.4.1.info",
+		"/github.com/acme/mcp/cmd/@v/v0.4.1.info",
+		"/github.com/acme/mcp/@v/v0.4.1.info",
+		"/github.com/acme/mcp/@v/v0.4.1.zip",
+	}, probed)
+
+	// The README of an unrelated package does not count.
+	err := registries.ValidateGo(ctx, origin("github.com/acme/mcp/cmd/server"), "io.github.acme/other")
+	require.ErrorContains(t, err, "ownership validation failed")
+
+	err = registries.ValidateGo(ctx, origin("example.com/unknown"), serverName)
+	require.ErrorContains(t, err, "not found on module proxy")
+}
@@ -42,8 +42,34 @@ var allowedOCIRegistries = map[string]bool{
 	// Google Artifact Registry (*.pkg.dev pattern handled in isAllowedRegistry)
 }
 
+// ImageVerifier checks the signatures and provenance attached to an OCI
+// image and reports what it found. An error rejects the package; a
+// verifier that only advises should report problems through
+// v1alpha1.AddWarning and return a nil error instead.
+type ImageVerifier interface {
+	VerifyImage(ctx context.Context, ref name.Reference) (*v1alpha1.MCPServerImage, error)
+}
+
+// NewDispatcher returns a RegistryValidatorFunc that behaves like
+// Dispatcher, except that OCI packages are also checked by verifier. The
+// image is verified at the digest whose labels ValidateOCI read, and the
+// result is recorded with v1alpha1.RecordVerifiedImage. A nil verifier
+// yields Dispatcher itself.
+func NewDispatcher(verifier ImageVerifier) v1alpha1.RegistryValidatorFunc {
+	if verifier == nil {
+		return Dispatcher
+	}
+	return func(ctx context.Context, origin v1alpha1.MCPPackageOrigin, objectName string) error {
+		if origin.OCI != nil {
+			return validateOCI(ctx, origin, objectName, verifier)
+		}
+		return Dispatcher(ctx, origin, objectName)
+	}
+}
+
 // ValidateOCI validates that an OCI image contains the correct MCP
-// server name annotation.
+// server name annotation. Use NewDispatcher to also verify the image's
+// signatures and provenance.
 //
 // Supported reference forms (Identifier must include an explicit tag or digest):
 //   - registry/namespace/image:tag
@@ -56,6 +82,10 @@ var allowedOCIRegistries = map[string]bool{
 //   - GitHub Container Registry (ghcr.io)
 //   - Google Artifact Registry (*.pkg.dev)
 func ValidateOCI(ctx context.Context, origin v1alpha1.MCPPackageOrigin, serverName string) error {
+	return validateOCI(ctx, origin, serverName, nil)
+}
+
+func validateOCI(ctx context.Context, origin v1alpha1.MCPPackageOrigin, serverName string, verifier ImageVerifier) error {
 	if origin.OCI == nil {
 		return fmt.Errorf("OCI validator called without origin.OCI set")
 	}
@@ -76,11 +106,12 @@ func ValidateOCI(ctx context.Context, origin v1alpha1.MCPPackageOrigin, serverNa
 	// reach them anonymously from outside the developer's machine. Skip
 	// allowlist enforcement and ownership validation for these — the
 	// allowlist + label check exist to gate the public catalogue, and
-	// private workflows pre-date that contract.
+	// private workflows pre-date that contract. Signatures are still
+	// verified: the verifier authenticates with the registry keychain.
 	registry := ref.Context().RegistryStr()
 	if isPrivateRegistry(registry) {
 		slog.Info("skipping OCI validation for private registry", "identifier", origin.Identifier, "registry", registry)
-		return nil
+		return verifyImage(ctx, verifier, ref)
 	}
 
 	if !isAllowedRegistry(registry) {
@@ -101,7 +132,7 @@ func ValidateOCI(ctx context.Context, origin v1alpha1.MCPPackageOrigin, serverNa
 			switch transportErr.StatusCode {
 			case http.StatusTooManyRequests:
 				slog.Info("skipping OCI validation due to rate limiting", "identifier", origin.Identifier)
-				return nil
+				return verifyImage(ctx, verifier, ref)
 			case http.StatusNotFound:
 				return fmt.Errorf("OCI image '%s' does not exist in the registry", origin.Identifier)
 			case http.StatusUnauthorized, http.StatusForbidden:
@@ -129,6 +160,27 @@ func ValidateOCI(ctx context.Context, origin v1alpha1.MCPPackageOrigin, serverNa
 		return fmt.Errorf("OCI image ownership validation failed. Expected annotation 'io.modelcontextprotocol.server.name' = '%s', got '%s'", serverName, mcpName)
 	}
 
+	if verifier == nil {
+		return nil
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return fmt.Errorf("failed to get image digest: %w", err)
+	}
+	return verifyImage(ctx, verifier, ref.Context().Digest(digest.String()))
+}
+
+// verifyImage runs verifier, when set, against ref and records the result
+// for the MCPServer being validated.
+func verifyImage(ctx context.Context, verifier ImageVerifier, ref name.Reference) error {
+	if verifier == nil {
+		return nil
+	}
+	image, err := verifier.VerifyImage(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("OCI image '%s' failed verification: %w", ref, err)
+	}
+	v1alpha1.RecordVerifiedImage(ctx, image)
 	return nil
 }
 
@@ -2,8 +2,10 @@ package registries_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -215,3 +217,37 @@ func TestValidateOCI_LabelMismatch(t *testing.T) {
 	assert.Contains(t, err.Error(), "ownership validation failed")
 	assert.Contains(t, err.Error(), "Expected annotation")
 }
+
+// stubImageVerifier records the references it is asked to verify.
+type stubImageVerifier struct {
+	image *v1alpha1.MCPServerImage
+	err   error
+	refs  []string
+}
+
+func (s *stubImageVerifier) VerifyImage(_ context.Context, ref name.Reference) (*v1alpha1.MCPServerImage, error) {
+	s.refs = append(s.refs, ref.String())
+	return s.image, s.err
+}
+
+func TestNewDispatcher_VerifiesOCIImages(t *testing.T) {
+	ctx := context.Background()
+	verifier := &stubImageVerifier{image: &v1alpha1.MCPServerImage{Digest: "sha256:abc", Signer: "release"}}
+	server := &v1alpha1.MCPServer{Spec: v1alpha1.MCPServerSpec{Source: &v1alpha1.MCPServerSource{Package: &v1alpha1.MCPPackage{
+		Origin: ociOrigin("localhost:5000/acme/fetch:1.0.0"),
+	}}}}
+	server.Status.Image = &v1alpha1.MCPServerImage{Digest: "sha256:from-manifest"}
+
+	require.NoError(t, server.ValidateRegistries(ctx, registries.NewDispatcher(verifier)))
+	assert.Equal(t, []string{"localhost:5000/acme/fetch:1.0.0"}, verifier.refs, "private registries skip the label check, not verification")
+	assert.Equal(t, verifier.image, server.Status.Image)
+
+	verifier.err = errors.New("no signature")
+	err := server.ValidateRegistries(ctx, registries.NewDispatcher(verifier))
+	require.ErrorContains(t, err, "failed verification")
+	assert.Nil(t, server.Status.Image)
+
+	server.Status.Image = &v1alpha1.MCPServerImage{Digest: "sha256:from-manifest"}
+	require.NoError(t, server.ValidateRegistries(ctx, registries.ValidateOCI))
+	assert.Nil(t, server.Status.Image, "a manifest cannot supply a verified image")
+}
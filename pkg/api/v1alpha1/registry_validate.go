@@ -6,7 +6,7 @@ import (
 
 // RegistryValidatorFunc validates a single package's origin against
 // its referenced external registry. Implementations fan out by which
-// sub-struct (Origin.NPM/PyPI/OCI) is non-nil to the appropriate
+// sub-struct (Origin.NPM/PyPI/OCI/GitHubRelease/Go/Cargo) is non-nil to the appropriate
 // per-registry validator. expectedServerName is the upstream-claimed
 // server identity declared on the origin's sub-struct (e.g.
 // origin.oci.serverName), passed through to ownership-annotation
@@ -17,13 +17,28 @@ import (
 // check.
 type RegistryValidatorFunc func(ctx context.Context, origin MCPPackageOrigin, expectedServerName string) error
 
+type verifiedImageKey struct{}
+
+// RecordVerifiedImage lets an OCI validator report what it verified about
+// the package image. MCPServer.ValidateRegistries stores it in
+// Status.Image; the call is a no-op anywhere else.
+func RecordVerifiedImage(ctx context.Context, image *MCPServerImage) {
+	if slot, ok := ctx.Value(verifiedImageKey{}).(**MCPServerImage); ok {
+		*slot = image
+	}
+}
+
 // ValidateRegistries on *MCPServer dispatches the bundled MCPPackage's
-// Origin to the caller-supplied per-registry validator.
+// Origin to the caller-supplied per-registry validator. Status.Image is
+// reset to whatever the validator recorded through RecordVerifiedImage,
+// so it never carries a value from the manifest.
 func (m *MCPServer) ValidateRegistries(ctx context.Context, v RegistryValidatorFunc) error {
+	m.Status.Image = nil
 	if v == nil || m.Spec.Source == nil || m.Spec.Source.Package == nil {
 		return nil
 	}
 	p := m.Spec.Source.Package
+	ctx = context.WithValue(ctx, verifiedImageKey{}, &m.Status.Image)
 	if err := v(ctx, p.Origin, serverNameFromOrigin(p.Origin)); err != nil {
 		var errs FieldErrors
 		errs.Append("spec.source.package.origin", err)
@@ -44,6 +59,12 @@ func serverNameFromOrigin(o MCPPackageOrigin) string {
 		return o.PyPI.ServerName
 	case o.OCI != nil:
 		return o.OCI.ServerName
+	case o.GitHubRelease != nil:
+		return o.GitHubRelease.ServerName
+	case o.Go != nil:
+		return o.Go.ServerName
+	case o.Cargo != nil:
+		return o.Cargo.ServerName
 	default:
 		return ""
 	}
@@ -254,7 +254,9 @@ func EncodeJSON(v any) ([]byte, error) { return json.Marshal(v) }
 //
 // Shared helper used by every surface that reads RawObject rows (HTTP
 // resource handler, MCP bridge, etc.) so every API surface hands back
-// an identically-shaped envelope.
+// an identically-shaped envelope. Kinds implementing Redactor come back
+// redacted; consumers that need sensitive material (secret resolution)
+// decode the raw spec themselves.
 func EnvelopeFromRaw[T Object](newObj func() T, raw *RawObject, kind string) (T, error) {
 	out := newObj()
 	out.SetTypeMeta(TypeMeta{APIVersion: GroupVersion, Kind: kind})
@@ -269,5 +271,6 @@ func EnvelopeFromRaw[T Object](newObj func() T, raw *RawObject, kind string) (T,
 			return out, fmt.Errorf("unmarshal spec: %w", err)
 		}
 	}
+	RedactObject(out)
 	return out, nil
 }
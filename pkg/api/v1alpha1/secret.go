@@ -0,0 +1,88 @@
+package v1alpha1
+
+import (
+	"maps"
+	"slices"
+)
+
+// Secret is the typed envelope for kind=Secret resources. A Secret holds key
+// material (provider API keys, CA bundles, tokens) that other kinds reference
+// through SecretKeyRef instead of embedding it in their own specs.
+//
+// Values are write-only through the API: the server envelope-encrypts
+// Spec.Data before the row is persisted and every read path (REST get/list,
+// the MCP get_* tools) returns the redacted form. Only the runtime adapters
+// see plaintext, and only while materializing a Deployment.
+type Secret struct {
+	TypeMeta `json:",inline" yaml:",inline"`
+	Metadata ObjectMeta `json:"metadata" yaml:"metadata"`
+	Spec     SecretSpec `json:"spec" yaml:"spec"`
+	Status   Status     `json:"status,omitzero" yaml:"status,omitempty"`
+}
+
+func init() {
+	MustRegisterKind[*Secret, SecretSpec](KindSecret, WithMutableObjectStorage())
+}
+
+// RedactedSecretValue replaces every Secret value on read. Re-applying a
+// Secret whose value is still the placeholder keeps the stored value for that
+// key, so `get -o yaml | edit | apply` round-trips without leaking or
+// clobbering material.
+const RedactedSecretValue = "<redacted>"
+
+// SecretSpec is the Secret resource's declarative body.
+type SecretSpec struct {
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// Data maps a key to its plaintext value on write. It is never persisted
+	// in plaintext; reads list every stored key with RedactedSecretValue.
+	Data map[string]string `json:"data,omitempty" yaml:"data,omitempty"`
+
+	// Encrypted is the server-owned at-rest form of Data. Clients must not
+	// set it; it is stripped from every read.
+	Encrypted *SecretEnvelope `json:"encrypted,omitempty" yaml:"encrypted,omitempty"`
+}
+
+// SecretEnvelope is the envelope-encrypted form of SecretSpec.Data. Each
+// Secret gets its own data key; the data key is sealed with the server-held
+// key identified by KeyID, and every value is sealed with the data key.
+// Binary fields are base64 (std encoding) of nonce||ciphertext.
+type SecretEnvelope struct {
+	// KeyID identifies the server key that wrapped DataKey so rotation can
+	// tell which rows still need re-encryption.
+	KeyID string `json:"keyId" yaml:"keyId"`
+	// DataKey is the per-Secret data key sealed with the server key.
+	DataKey string `json:"dataKey" yaml:"dataKey"`
+	// Values maps each Data key to its value sealed with the data key.
+	Values map[string]string `json:"values" yaml:"values"`
+}
+
+// Keys returns the sorted set of keys the Secret carries, whether in
+// plaintext Data or in the encrypted envelope.
+func (s *SecretSpec) Keys() []string {
+	keys := map[string]struct{}{}
+	for k := range s.Data {
+		keys[k] = struct{}{}
+	}
+	if s.Encrypted != nil {
+		for k := range s.Encrypted.Values {
+			keys[k] = struct{}{}
+		}
+	}
+	return slices.Sorted(maps.Keys(keys))
+}
+
+// Redact replaces every value with RedactedSecretValue and drops the
+// encrypted envelope, leaving only the key names visible.
+func (s *Secret) Redact() {
+	keys := s.Spec.Keys()
+	if len(keys) == 0 {
+		s.Spec.Data = nil
+	} else {
+		s.Spec.Data = make(map[string]string, len(keys))
+		for _, k := range keys {
+			s.Spec.Data[k] = RedactedSecretValue
+		}
+	}
+	s.Spec.Encrypted = nil
+}
@@ -0,0 +1,80 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSecretValidate(t *testing.T) {
+	meta := ObjectMeta{Namespace: "default", Name: "provider-key"}
+	tests := []struct {
+		name    string
+		spec    SecretSpec
+		wantErr string
+	}{
+		{name: "valid", spec: SecretSpec{Data: map[string]string{"apiKey": "sk-live", "ca.crt": "pem"}}},
+		{name: "empty", spec: SecretSpec{}},
+		{name: "bad key", spec: SecretSpec{Data: map[string]string{"api key": "x"}}, wantErr: "spec.data.api key"},
+		{
+			name:    "too large",
+			spec:    SecretSpec{Data: map[string]string{"blob": strings.Repeat("x", maxSecretDataBytes+1)}},
+			wantErr: "spec.data",
+		},
+		{
+			name:    "client-supplied envelope",
+			spec:    SecretSpec{Encrypted: &SecretEnvelope{KeyID: "k"}},
+			wantErr: "spec.encrypted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Secret{Metadata: meta, Spec: tt.spec}).Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEnvelopeFromRaw_RedactsSecret(t *testing.T) {
+	spec, err := json.Marshal(SecretSpec{
+		Description: "provider credentials",
+		Encrypted: &SecretEnvelope{
+			KeyID:   "k1",
+			DataKey: "wrapped",
+			Values:  map[string]string{"apiKey": "sealed-a", "org": "sealed-b"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := EnvelopeFromRaw(func() *Secret { return &Secret{} }, &RawObject{
+		Metadata: ObjectMeta{Namespace: "default", Name: "provider-key"},
+		Spec:     spec,
+	}, KindSecret)
+	if err != nil {
+		t.Fatalf("EnvelopeFromRaw: %v", err)
+	}
+	if got.Spec.Encrypted != nil {
+		t.Fatalf("envelope leaked: %+v", got.Spec.Encrypted)
+	}
+	if got.Spec.Description != "provider credentials" {
+		t.Fatalf("description = %q", got.Spec.Description)
+	}
+	want := map[string]string{"apiKey": RedactedSecretValue, "org": RedactedSecretValue}
+	if len(got.Spec.Data) != len(want) {
+		t.Fatalf("data = %+v, want %+v", got.Spec.Data, want)
+	}
+	for k, v := range want {
+		if got.Spec.Data[k] != v {
+			t.Fatalf("data[%s] = %q, want %q", k, got.Spec.Data[k], v)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// secretKeyRegex mirrors the Kubernetes Secret data-key rule so a registry
+// Secret key can be projected onto a cluster Secret without renaming.
+var secretKeyRegex = regexp.MustCompile(`^[-._a-zA-Z0-9]{1,253}$`)
+
+// maxSecretDataBytes caps the summed plaintext size of one Secret. Secrets
+// hold credentials, not payloads; large material belongs elsewhere.
+const maxSecretDataBytes = 1 << 20
+
+// Validate runs Secret's structural checks.
+//
+// Data keys follow the Kubernetes Secret key format. Encrypted is
+// server-owned: a manifest that carries it is rejected rather than trusted,
+// so ciphertext can only ever come from the server's own key.
+//
+// Secret is unversioned: rotating a credential replaces it in place and
+// every Deployment that references it re-reconciles.
+func (s *Secret) Validate() error {
+	var errs FieldErrors
+	errs = append(errs, ValidateObjectMeta(s.Metadata)...)
+	size := 0
+	for k, v := range s.Spec.Data {
+		if !secretKeyRegex.MatchString(k) {
+			errs.Append("spec.data."+k, fmt.Errorf("%w: key must match %s", ErrInvalidFormat, secretKeyRegex.String()))
+		}
+		size += len(v)
+	}
+	if size > maxSecretDataBytes {
+		errs.Append("spec.data", fmt.Errorf("%w: %d bytes exceeds the %d byte limit", ErrInvalidFormat, size, maxSecretDataBytes))
+	}
+	if s.Spec.Encrypted != nil {
+		errs.Append("spec.encrypted", fmt.Errorf("%w: server-managed field must not be set", ErrInvalidFormat))
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
@@ -19,18 +19,36 @@ type SkillSpec struct {
 	Source      *SkillSource `json:"source,omitempty" yaml:"source,omitempty"`
 }
 
-// SkillSource is the distribution origin of a skill. Currently just a
-// git repository where the skill content lives. Future distribution
-// channels (e.g. published artifact) would land here.
+// SkillSource is the distribution origin of a skill. Exactly one of
+// Repository, OCI, or Archive is set.
 type SkillSource struct {
+	// Repository is a git repository holding the skill directory.
 	Repository *Repository `json:"repository,omitempty" yaml:"repository,omitempty"`
+	// OCI is an OCI artifact holding the skill directory.
+	OCI *SkillSourceOCI `json:"oci,omitempty" yaml:"oci,omitempty"`
+	// Archive is a tarball uploaded to the registry.
+	Archive *SkillSourceArchive `json:"archive,omitempty" yaml:"archive,omitempty"`
+}
+
+// SkillSourceOCI is a digest-pinned OCI artifact reference, e.g.
+// "ghcr.io/org/skill@sha256:...". Bare/tag-only refs are rejected.
+type SkillSourceOCI struct {
+	Reference string `json:"reference" yaml:"reference"`
+}
+
+// SkillSourceArchive names a skill tarball previously uploaded with
+// `POST /v0/skill-archives`. Archives are content-addressed, so the digest
+// is both the identifier and the pin.
+type SkillSourceArchive struct {
+	// Digest is the upload's "sha256:<hex>" digest.
+	Digest string `json:"digest" yaml:"digest"`
 }
 
 // SkillStatus is the Skill observed-state subresource, written by the Skill
 // controller out of band of the API write. It embeds the shared Status
 // (conditions + observedGeneration) and records the controller's immutable pin
-// of the skill's git source — mirroring the Plugin resolve-and-pin model so a
-// harness deploy can materialize the skill from a fixed commit.
+// of the skill's source — mirroring the Plugin resolve-and-pin model so a
+// harness deploy can materialize the skill from a fixed commit or digest.
 //
 // Readiness: absence of Ready=True (or ResolvedSource==nil) means "not yet
 // resolved". The controller sets Ready=False/Progressing on first observe,
@@ -39,15 +57,19 @@ type SkillSource struct {
 type SkillStatus struct {
 	Status `json:",inline" yaml:",inline"`
 
-	// ResolvedSource is the controller's immutable pin of the skill's git
-	// source (the concrete commit the source ref resolved to).
+	// ResolvedSource is the controller's immutable pin of the skill's
+	// source (the concrete commit or digest the source resolved to).
 	ResolvedSource *SkillResolvedSource `json:"resolvedSource,omitempty" yaml:"resolvedSource,omitempty"`
 }
 
-// SkillResolvedSource records the concrete commit the Skill controller pinned
-// the skill's git source to. It is the reproducibility anchor: deploys
-// materialize from this pin, not from the (possibly moving) ref the user gave.
+// SkillResolvedSource records the concrete revision the Skill controller
+// pinned the skill's source to. Exactly one of Commit/Digest is set, matching
+// the source. It is the reproducibility anchor: deploys materialize from this
+// pin, not from the (possibly moving) ref the user gave.
 type SkillResolvedSource struct {
-	// Commit is the resolved full git commit SHA.
+	// Commit is the resolved full git commit SHA (source.repository).
 	Commit string `json:"commit,omitempty" yaml:"commit,omitempty"`
+	// Digest is the "sha256:<hex>" digest of the OCI manifest
+	// (source.oci) or of the uploaded tarball (source.archive).
+	Digest string `json:"digest,omitempty" yaml:"digest,omitempty"`
 }
@@ -1,5 +1,14 @@
 package v1alpha1
 
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// sha256DigestRegex matches a content digest in OCI form ("sha256:<64 hex>").
+var sha256DigestRegex = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
 func (s *Skill) Validate() error {
 	var errs FieldErrors
 	errs = append(errs, ValidateObjectMeta(s.Metadata)...)
@@ -14,9 +23,49 @@ func validateSkillSpec(s *SkillSpec) FieldErrors {
 	var errs FieldErrors
 	errs.Append("spec.title", validateTitle(s.Title))
 	if s.Source != nil {
-		for _, e := range validateRepository(s.Source.Repository) {
-			errs.Append("spec.source."+e.Path, e.Cause)
+		for _, e := range validateSkillSource(s.Source) {
+			path := "spec.source"
+			if e.Path != "" {
+				path += "." + e.Path
+			}
+			errs.Append(path, e.Cause)
+		}
+	}
+	return errs
+}
+
+// validateSkillSource requires exactly one distribution channel. OCI
+// references must be digest-pinned, like Plugin OCI sources, so a published
+// skill tag cannot drift.
+func validateSkillSource(s *SkillSource) FieldErrors {
+	var errs FieldErrors
+	set := 0
+	if s.Repository != nil {
+		set++
+		errs = append(errs, validateRepository(s.Repository)...)
+	}
+	if s.OCI != nil {
+		set++
+		ref := s.OCI.Reference
+		_, digest, pinned := strings.Cut(ref, "@")
+		switch {
+		case ref == "":
+			errs.Append("oci.reference", fmt.Errorf("%w", ErrRequiredField))
+		case !pinned || !sha256DigestRegex.MatchString(digest):
+			errs.Append("oci.reference", fmt.Errorf("%w: oci source must be digest-pinned (…@sha256:…)", ErrInvalidFormat))
 		}
 	}
+	if s.Archive != nil {
+		set++
+		switch {
+		case s.Archive.Digest == "":
+			errs.Append("archive.digest", fmt.Errorf("%w", ErrRequiredField))
+		case !sha256DigestRegex.MatchString(s.Archive.Digest):
+			errs.Append("archive.digest", fmt.Errorf("%w: must match %s", ErrInvalidFormat, sha256DigestRegex.String()))
+		}
+	}
+	if set > 1 {
+		errs.Append("", fmt.Errorf("%w: set exactly one of repository, oci, or archive", ErrInvalidFormat))
+	}
 	return errs
 }
@@ -0,0 +1,84 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSkillValidate_Source(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+
+	tests := []struct {
+		name    string
+		source  *SkillSource
+		wantErr string // substring; empty means valid
+	}{
+		{
+			name:   "no source",
+			source: nil,
+		},
+		{
+			name:   "git repository",
+			source: &SkillSource{Repository: &Repository{URL: "https://github.com/org/skills", Branch: "main"}},
+		},
+		{
+			name:   "oci digest",
+			source: &SkillSource{OCI: &SkillSourceOCI{Reference: "ghcr.io/org/skill@" + digest}},
+		},
+		{
+			name:   "uploaded archive",
+			source: &SkillSource{Archive: &SkillSourceArchive{Digest: digest}},
+		},
+		{
+			name:    "oci tag only",
+			source:  &SkillSource{OCI: &SkillSourceOCI{Reference: "ghcr.io/org/skill:1.0.0"}},
+			wantErr: "digest-pinned",
+		},
+		{
+			name:    "oci short digest",
+			source:  &SkillSource{OCI: &SkillSourceOCI{Reference: "ghcr.io/org/skill@sha256:abc"}},
+			wantErr: "digest-pinned",
+		},
+		{
+			name:    "oci missing reference",
+			source:  &SkillSource{OCI: &SkillSourceOCI{}},
+			wantErr: "spec.source.oci.reference",
+		},
+		{
+			name:    "archive bad digest",
+			source:  &SkillSource{Archive: &SkillSourceArchive{Digest: "md5:abc"}},
+			wantErr: "spec.source.archive.digest",
+		},
+		{
+			name: "two channels",
+			source: &SkillSource{
+				Repository: &Repository{URL: "https://github.com/org/skills"},
+				Archive:    &SkillSourceArchive{Digest: digest},
+			},
+			wantErr: "exactly one of repository, oci, or archive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Skill{
+				TypeMeta: TypeMeta{APIVersion: GroupVersion, Kind: KindSkill},
+				Metadata: ObjectMeta{Namespace: "default", Name: "my-skill", Tag: "v1"},
+				Spec:     SkillSpec{Title: "My Skill", Source: tt.source},
+			}
+			err := s.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected valid, got: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error %q does not contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -56,6 +57,58 @@ type Status struct {
 	// Use SetDetailsKey / GetDetailsKey to merge or read keys without clobbering
 	// other adapters' state.
 	Details json.RawMessage `json:"details,omitempty" yaml:"details,omitempty"`
+
+	// Lifecycle marks a published tag as deprecated or yanked. Only tagged
+	// artifacts carry it; nil means the tag is active. It is set through the
+	// tag's lifecycle subresource and, unlike the rest of Status, is stored
+	// apart from controller state, so it survives re-applies and rollbacks
+	// of the tag's content.
+	Lifecycle *TagLifecycle `json:"lifecycle,omitempty" yaml:"lifecycle,omitempty"`
+}
+
+// LifecycleState is the publication state of a tagged artifact.
+type LifecycleState string
+
+const (
+	// LifecycleActive is the default state. It is never stored: setting it
+	// clears the tag's lifecycle.
+	LifecycleActive LifecycleState = "Active"
+	// LifecycleDeprecated tags still resolve, but apply-time reference
+	// resolution warns about them.
+	LifecycleDeprecated LifecycleState = "Deprecated"
+	// LifecycleYanked tags stay readable for existing consumers, but new
+	// references to them are rejected and semver ranges skip them.
+	LifecycleYanked LifecycleState = "Yanked"
+)
+
+// TagLifecycle records why a tag was deprecated or yanked and which tag
+// consumers should move to.
+type TagLifecycle struct {
+	State       LifecycleState `json:"state" yaml:"state" enum:"Deprecated,Yanked"`
+	Reason      string         `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Replacement string         `json:"replacement,omitempty" yaml:"replacement,omitempty"`
+}
+
+// Describe renders the lifecycle for user-facing output about subject (for
+// example "MCPServer default/fetch:1.0.0"): "<subject> is deprecated:
+// <reason>; use <replacement>".
+func (l *TagLifecycle) Describe(subject string) string {
+	msg := subject + " is " + strings.ToLower(string(l.State))
+	if l.Reason != "" {
+		msg += ": " + l.Reason
+	}
+	if l.Replacement != "" {
+		msg += "; use " + l.Replacement
+	}
+	return msg
+}
+
+// LifecycleState returns the tag's state, LifecycleActive when none is set.
+func (s *Status) LifecycleState() LifecycleState {
+	if s.Lifecycle == nil || s.Lifecycle.State == "" {
+		return LifecycleActive
+	}
+	return s.Lifecycle.State
 }
 
 // SetDetailsKey merges value (as JSON) under key in s.Details. Other top-level keys
@@ -212,6 +265,7 @@ type statusStore struct {
 	ObservedGeneration int64            `json:"observedGeneration,omitempty"`
 	Conditions         []conditionStore `json:"conditions,omitempty"`
 	Details            json.RawMessage  `json:"details,omitempty"`
+	Lifecycle          *TagLifecycle    `json:"lifecycle,omitempty"`
 }
 
 // MarshalStatusForStorage serializes a Status to JSON suitable for
@@ -229,6 +283,7 @@ func MarshalStatusForStorage(s Status) ([]byte, error) {
 		ObservedGeneration: s.ObservedGeneration,
 		Conditions:         storeConds,
 		Details:            s.Details,
+		Lifecycle:          s.Lifecycle,
 	})
 }
 
@@ -277,6 +332,27 @@ func UnmarshalStatusFromStorage(data []byte, s *Status) error {
 		ObservedGeneration: w.ObservedGeneration,
 		Conditions:         conds,
 		Details:            w.Details,
+		Lifecycle:          w.Lifecycle,
 	}
 	return nil
 }
+
+// LifecycleFromStorage decodes just the lifecycle of a stored status
+// payload, for callers that hold a RawObject of any kind and do not need
+// the rest of its (possibly kind-specific) status. Returns nil when the
+// tag is active.
+func LifecycleFromStorage(data []byte) (*TagLifecycle, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var w struct {
+		Lifecycle *TagLifecycle `json:"lifecycle"`
+	}
+	if err := json.Unmarshal(data, &w); err != nil {
+		return nil, err
+	}
+	if w.Lifecycle == nil || w.Lifecycle.State == "" || w.Lifecycle.State == LifecycleActive {
+		return nil, nil
+	}
+	return w.Lifecycle, nil
+}
@@ -233,3 +233,45 @@ func TestStatus_IsConditionTrue(t *testing.T) {
 		t.Fatal("missing condition should not be true")
 	}
 }
+
+func TestStatus_LifecycleRoundTrip(t *testing.T) {
+	srv := &MCPServer{Status: MCPServerStatus{
+		Status:       Status{Lifecycle: &TagLifecycle{State: LifecycleYanked, Reason: "CVE-2026-1", Replacement: "1.0.1"}},
+		Capabilities: &MCPServerCapabilities{},
+	}}
+	raw, err := srv.MarshalStatus()
+	if err != nil {
+		t.Fatalf("MarshalStatus: %v", err)
+	}
+
+	var got MCPServer
+	if err := got.UnmarshalStatus(raw); err != nil {
+		t.Fatalf("UnmarshalStatus: %v", err)
+	}
+	if got.Status.LifecycleState() != LifecycleYanked || got.Status.Lifecycle.Replacement != "1.0.1" {
+		t.Fatalf("lifecycle lost in round trip: %+v", got.Status.Lifecycle)
+	}
+	if got.Status.Capabilities == nil {
+		t.Fatal("capabilities lost in round trip")
+	}
+
+	lifecycle, err := LifecycleFromStorage(raw)
+	if err != nil || lifecycle == nil || lifecycle.State != LifecycleYanked {
+		t.Fatalf("LifecycleFromStorage = %+v, %v", lifecycle, err)
+	}
+	if want := "MCPServer default/fetch:1.0.0 is yanked: CVE-2026-1; use 1.0.1"; lifecycle.Describe("MCPServer default/fetch:1.0.0") != want {
+		t.Fatalf("Describe = %q, want %q", lifecycle.Describe("MCPServer default/fetch:1.0.0"), want)
+	}
+}
+
+func TestStatus_LifecycleDefaultsToActive(t *testing.T) {
+	if got := (&Status{}).LifecycleState(); got != LifecycleActive {
+		t.Fatalf("LifecycleState() = %q, want Active", got)
+	}
+	for _, raw := range []string{``, `{}`, `{"lifecycle":{"state":"Active"}}`} {
+		lifecycle, err := LifecycleFromStorage([]byte(raw))
+		if err != nil || lifecycle != nil {
+			t.Fatalf("LifecycleFromStorage(%q) = %+v, %v; want nil", raw, lifecycle, err)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// ErrNoMatchingTag is returned by MatchTagConstraint when no tag satisfies
+// the constraint.
+var ErrNoMatchingTag = errors.New("no tag satisfies constraint")
+
+// tagConstraintChars are the operator characters that mark a ResourceRef tag
+// as a semver constraint. None of them can appear in a literal tag (see
+// tagRegex), so the two forms never overlap.
+const tagConstraintChars = "^~<>=!*,| "
+
+// IsTagConstraint reports whether tag is a semver constraint such as "^1.2",
+// "~0.4.1" or ">=2 <3" rather than a literal tag. Blank and literal tags
+// ("latest", "v1.2.0", "stable") are not constraints.
+func IsTagConstraint(tag string) bool {
+	return strings.ContainsAny(tag, tagConstraintChars)
+}
+
+// ValidateTagConstraint reports whether constraint parses as a semver range.
+func ValidateTagConstraint(constraint string) error {
+	if _, err := semver.NewConstraint(constraint); err != nil {
+		return fmt.Errorf("%w: semver constraint %q: %v", ErrInvalidTag, constraint, err)
+	}
+	return nil
+}
+
+// MatchTagConstraint returns the highest tag in tags that satisfies
+// constraint. Tags are compared as semver versions with an optional "v"
+// prefix; tags that are not versions ("latest", "stable") never match.
+// Pre-release tags only match constraints that name a pre-release, per the
+// semver range rules. When two tags parse to the same version ("1.2.0" and
+// "v1.2.0") the lexically smaller one wins so resolution is deterministic.
+func MatchTagConstraint(constraint string, tags []string) (string, error) {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return "", fmt.Errorf("%w: semver constraint %q: %v", ErrInvalidTag, constraint, err)
+	}
+	var (
+		best    string
+		bestVer *semver.Version
+	)
+	for _, tag := range tags {
+		v, err := semver.NewVersion(tag)
+		if err != nil || !c.Check(v) {
+			continue
+		}
+		if bestVer == nil {
+			best, bestVer = tag, v
+			continue
+		}
+		switch cmp := v.Compare(bestVer); {
+		case cmp > 0, cmp == 0 && tag < best:
+			best, bestVer = tag, v
+		}
+	}
+	if bestVer == nil {
+		return "", fmt.Errorf("%w %q", ErrNoMatchingTag, constraint)
+	}
+	return best, nil
+}
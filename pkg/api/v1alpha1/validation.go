@@ -25,6 +25,10 @@ var (
 	// referenced resource does not exist. Tests + callers identify
 	// dangling references via errors.Is(err, ErrDanglingRef).
 	ErrDanglingRef = errors.New("referenced resource not found")
+	// ErrYankedRef is returned by ResolverFunc implementations when the
+	// referenced tag has been yanked. Existing references keep working;
+	// new ones are rejected.
+	ErrYankedRef = errors.New("yanked reference")
 )
 
 // FieldError pins a validation failure to a dot-path inside the object.
@@ -82,6 +86,19 @@ type ResolverFunc func(ctx context.Context, ref ResourceRef) error
 // agentgateway upstream config.
 type GetterFunc func(ctx context.Context, ref ResourceRef) (Object, error)
 
+// Referrer is one object that references another, identified by its own
+// Kind/Namespace/Name/Tag plus the spec path holding the reference.
+type Referrer struct {
+	Object ResourceRef
+	Path   string
+}
+
+// ReferrersFunc lists every live object whose spec references ref. Tag
+// constraints and blank tags held by referrers are resolved before
+// matching, so a referrer pinned to "^1.0.0" is reported against whichever
+// tag that constraint currently selects.
+type ReferrersFunc func(ctx context.Context, ref ResourceRef) ([]Referrer, error)
+
 // -----------------------------------------------------------------------------
 // Format rules — regexes and constants shared across every kind's validator.
 // -----------------------------------------------------------------------------
@@ -176,6 +193,12 @@ func ValidateObjectMeta(m ObjectMeta) FieldErrors {
 	return errs
 }
 
+// ValidateTag reports whether tag is a valid literal tag. Exported for
+// endpoints that take a tag outside an object body, such as tag promotion.
+func ValidateTag(tag string) error {
+	return validateTag(tag)
+}
+
 func validateTag(tag string) error {
 	if tag == "" {
 		return fmt.Errorf("%w", ErrRequiredField)
@@ -255,10 +278,15 @@ func validateRef(r ResourceRef, allowedKinds ...string) FieldErrors {
 	if err := validateNameField(r.Name); err != nil {
 		errs.Append("name", err)
 	}
-	// Tag is optional on content refs — blank means "resolve to latest".
+	// Tag is optional on content refs — blank means "resolve to latest". A
+	// semver constraint resolves to the highest matching tag at reference time.
 	if r.Tag != "" {
 		if !IsTaggedArtifactKind(r.Kind) {
 			errs.Append("tag", fmt.Errorf("%w: kind %q does not support tag pinning", ErrInvalidRef, r.Kind))
+		} else if IsTagConstraint(r.Tag) {
+			if err := ValidateTagConstraint(r.Tag); err != nil {
+				errs.Append("tag", err)
+			}
 		} else if err := validateTag(r.Tag); err != nil {
 			errs.Append("tag", err)
 		}
@@ -222,6 +222,38 @@ func TestAgentResolveRefs_NilResolverIsNoOp(t *testing.T) {
 	require.NoError(t, a.ResolveRefs(context.Background(), nil))
 }
 
+func TestObjectRefs_ReportsPaths(t *testing.T) {
+	a := &Agent{
+		Metadata: ObjectMeta{Namespace: "team-a", Name: "a", Tag: "v1"},
+		Spec: AgentSpec{
+			MCPServers:   []ResourceRef{{Name: "tools", Tag: "^1.0.0"}},
+			Skills:       []ResourceRef{{Namespace: "shared", Name: "summarize"}},
+			Instructions: &ResourceRef{Name: "system"},
+		},
+	}
+	require.Equal(t, []FieldRef{
+		{Path: "spec.mcpServers[0]", Ref: ResourceRef{Kind: KindMCPServer, Namespace: "team-a", Name: "tools", Tag: "^1.0.0"}},
+		{Path: "spec.skills[0]", Ref: ResourceRef{Kind: KindSkill, Namespace: "shared", Name: "summarize"}},
+		{Path: "spec.instructions[0]", Ref: ResourceRef{Kind: KindPrompt, Namespace: "team-a", Name: "system"}},
+	}, ObjectRefs(context.Background(), a))
+
+	d := &Deployment{
+		Metadata: ObjectMeta{Namespace: "prod", Name: "bot"},
+		Spec: DeploymentSpec{
+			TargetRef:      ResourceRef{Kind: KindMCPServer, Name: "tools", Tag: "1.0.0"},
+			RuntimeRef:     ResourceRef{Kind: KindRuntime, Name: "local"},
+			DeploymentRefs: []DeploymentRef{{Name: "db"}},
+		},
+	}
+	require.Equal(t, []FieldRef{
+		{Path: "spec.targetRef", Ref: ResourceRef{Kind: KindMCPServer, Namespace: "prod", Name: "tools", Tag: "1.0.0"}},
+		{Path: "spec.runtimeRef", Ref: ResourceRef{Kind: KindRuntime, Namespace: "prod", Name: "local"}},
+		{Path: "spec.deploymentRefs[0]", Ref: ResourceRef{Kind: KindDeployment, Namespace: "prod", Name: "db"}},
+	}, ObjectRefs(context.Background(), d))
+
+	require.Empty(t, ObjectRefs(context.Background(), &Runtime{}), "kinds without refs hold none")
+}
+
 // -----------------------------------------------------------------------------
 // DeploymentSpec
 // -----------------------------------------------------------------------------
@@ -463,6 +495,21 @@ func TestDeploymentValidate_RejectsBadTargetRefTag(t *testing.T) {
 	require.Contains(t, paths, "spec.targetRef.tag")
 }
 
+func TestDeploymentValidate_TargetRefTagConstraint(t *testing.T) {
+	d := &Deployment{
+		Metadata: ObjectMeta{Namespace: "default", Name: "prod"},
+		Spec: DeploymentSpec{
+			TargetRef:  ResourceRef{Kind: KindAgent, Name: "alice", Tag: ">=1.2 <2"},
+			RuntimeRef: ResourceRef{Kind: KindRuntime, Name: "local"},
+		},
+	}
+	require.NoError(t, d.Validate())
+
+	d.Spec.TargetRef.Tag = "^not-a-version"
+	paths := failedFields(t, d.Validate())
+	require.Contains(t, paths, "spec.targetRef.tag")
+}
+
 func TestDeploymentResolveRefs_InheritsNamespace(t *testing.T) {
 	var seen []ResourceRef
 	resolver := func(ctx context.Context, ref ResourceRef) error {
@@ -1020,6 +1067,69 @@ func TestMCPServerValidate_OriginPolymorphism(t *testing.T) {
 	})
 }
 
+// TestMCPServerValidate_BinaryOrigins covers the github-release, go, and
+// cargo origins, whose fields feed a generated launch script.
+func TestMCPServerValidate_BinaryOrigins(t *testing.T) {
+	mk := func(o MCPPackageOrigin) *MCPServer {
+		return &MCPServer{
+			TypeMeta: TypeMeta{APIVersion: "ar.dev/v1alpha1", Kind: KindMCPServer},
+			Metadata: ObjectMeta{Namespace: "default", Name: "my-server"},
+			Spec: MCPServerSpec{Source: &MCPServerSource{Package: &MCPPackage{
+				Origin:    o,
+				Transport: MCPTransport{Type: "stdio"},
+			}}},
+		}
+	}
+	release := func(mut func(*MCPPackageOrigin)) MCPPackageOrigin {
+		o := MCPPackageOrigin{
+			Type:       MCPPackageOriginTypeGitHubRelease,
+			Identifier: "acme/mcp-server",
+			GitHubRelease: &MCPPackageOriginGitHubRelease{
+				Version:    "v1.2.0",
+				Asset:      "mcp-server_linux_amd64.tar.gz",
+				Binary:     "bin/mcp-server",
+				ServerName: "io.github.acme/mcp-server",
+			},
+		}
+		if mut != nil {
+			mut(&o)
+		}
+		return o
+	}
+
+	valid := []MCPPackageOrigin{
+		release(nil),
+		{Type: MCPPackageOriginTypeGo, Identifier: "github.com/acme/mcp/cmd/server", Go: &MCPPackageOriginGo{Version: "v0.4.1", ServerName: "io.github.acme/mcp"}},
+		{Type: MCPPackageOriginTypeCargo, Identifier: "acme-mcp", Cargo: &MCPPackageOriginCargo{Version: "0.3.0", ServerName: "io.github.acme/mcp"}},
+	}
+	for _, o := range valid {
+		require.NoError(t, mk(o).Validate(), "origin type %s", o.Type)
+	}
+
+	tests := []struct {
+		name   string
+		origin MCPPackageOrigin
+		expect string
+	}{
+		{"release identifier not owner/repo", release(func(o *MCPPackageOrigin) { o.Identifier = "acme" }), "spec.source.package.origin.identifier"},
+		{"release asset missing", release(func(o *MCPPackageOrigin) { o.GitHubRelease.Asset = "" }), "spec.source.package.origin.githubRelease.asset"},
+		{"release asset with path", release(func(o *MCPPackageOrigin) { o.GitHubRelease.Asset = "../x" }), "spec.source.package.origin.githubRelease.asset"},
+		{"release zip asset", release(func(o *MCPPackageOrigin) { o.GitHubRelease.Asset = "x.zip" }), "spec.source.package.origin.githubRelease.asset"},
+		{"release binary escapes", release(func(o *MCPPackageOrigin) { o.GitHubRelease.Binary = "../../bin/sh" }), "spec.source.package.origin.githubRelease.binary"},
+		{"release bad sha256", release(func(o *MCPPackageOrigin) { o.GitHubRelease.SHA256 = "abc" }), "spec.source.package.origin.githubRelease.sha256"},
+		{"release version with shell syntax", release(func(o *MCPPackageOrigin) { o.GitHubRelease.Version = "v1;reboot" }), "spec.source.package.origin.githubRelease.version"},
+		{"go version missing", MCPPackageOrigin{Type: MCPPackageOriginTypeGo, Identifier: "github.com/acme/mcp", Go: &MCPPackageOriginGo{ServerName: "io.github.acme/mcp"}}, "spec.source.package.origin.go.version"},
+		{"go identifier with version", MCPPackageOrigin{Type: MCPPackageOriginTypeGo, Identifier: "github.com/acme/mcp@v1", Go: &MCPPackageOriginGo{Version: "v1.0.0", ServerName: "io.github.acme/mcp"}}, "spec.source.package.origin.identifier"},
+		{"cargo bad crate", MCPPackageOrigin{Type: MCPPackageOriginTypeCargo, Identifier: "acme mcp", Cargo: &MCPPackageOriginCargo{Version: "0.3.0", ServerName: "io.github.acme/mcp"}}, "spec.source.package.origin.identifier"},
+		{"cargo serverName missing", MCPPackageOrigin{Type: MCPPackageOriginTypeCargo, Identifier: "acme-mcp", Cargo: &MCPPackageOriginCargo{Version: "0.3.0"}}, "spec.source.package.origin.cargo.serverName"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Contains(t, failedFields(t, mk(tc.origin).Validate()), tc.expect)
+		})
+	}
+}
+
 // TestMCPServerValidate_OriginTypeRequired asserts the field-path
 // rename from `registryType` to `origin.type` (required when Source.Package
 // is set).
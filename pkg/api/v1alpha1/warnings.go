@@ -0,0 +1,41 @@
+package v1alpha1
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+type warningsKey struct{}
+
+type warningSink struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+// WithWarnings returns a context that collects non-fatal apply-time
+// warnings (for example, a reference to a deprecated tag) and a function
+// that returns what has been collected so far. Duplicate messages are
+// recorded once.
+func WithWarnings(ctx context.Context) (context.Context, func() []string) {
+	sink := &warningSink{}
+	return context.WithValue(ctx, warningsKey{}, sink), func() []string {
+		sink.mu.Lock()
+		defer sink.mu.Unlock()
+		return slices.Clone(sink.msgs)
+	}
+}
+
+// AddWarning records msg on the collector installed by WithWarnings. It is
+// a no-op when ctx carries no collector.
+func AddWarning(ctx context.Context, msg string) {
+	sink, ok := ctx.Value(warningsKey{}).(*warningSink)
+	if !ok || msg == "" {
+		return
+	}
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	if !slices.Contains(sink.msgs, msg) {
+		sink.msgs = append(sink.msgs, msg)
+	}
+}
@@ -0,0 +1,22 @@
+package v1alpha1
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestWarnings_CollectsDistinctMessages(t *testing.T) {
+	AddWarning(context.Background(), "dropped: no collector installed")
+
+	ctx, warnings := WithWarnings(context.Background())
+	AddWarning(ctx, "tag 1.0.0 is deprecated")
+	AddWarning(ctx, "tag 1.0.0 is deprecated")
+	AddWarning(ctx, "")
+	AddWarning(ctx, "tag 2.0.0 is deprecated")
+
+	want := []string{"tag 1.0.0 is deprecated", "tag 2.0.0 is deprecated"}
+	if got := warnings(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("warnings() = %#v, want %#v", got, want)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/agentregistry-dev/agentregistry/internal/cli/configure"
 	clidaemon "github.com/agentregistry-dev/agentregistry/internal/cli/daemon"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/declarative"
+	"github.com/agentregistry-dev/agentregistry/internal/cli/login"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/scheme"
 	"github.com/agentregistry-dev/agentregistry/internal/version"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
@@ -37,9 +38,18 @@ func Root(cfg Config) *cobra.Command {
 	}
 	var registryURL string
 	var registryToken string
-	rt := cliruntime.New(cliruntime.Config{
+	// Without an embedder-supplied AuthProvider, commands use the session
+	// `arctl login` stored for the registry they target.
+	var rt cliruntime.Runtime
+	authProvider := cfg.Auth
+	var credentials *login.Store
+	if authProvider == nil {
+		credentials = login.NewStore(login.DefaultStorePath(cfg.Env))
+		authProvider = login.NewTokenProvider(credentials, func() string { return rt.RegistryTarget().BaseURL })
+	}
+	rt = cliruntime.New(cliruntime.Config{
 		Env:             cfg.Env,
-		Auth:            cfg.Auth,
+		Auth:            authProvider,
 		RegistryURL:     &registryURL,
 		RegistryToken:   &registryToken,
 		OnTokenResolved: cfg.OnTokenResolved,
@@ -69,7 +79,7 @@ func Root(cfg Config) *cobra.Command {
 
 	deps := cliruntime.Deps{
 		Runtime: rt,
-		Auth:    cfg.Auth,
+		Auth:    authProvider,
 		Kinds:   kinds,
 	}
 	root.AddCommand(configure.NewCommand(deps))
@@ -83,6 +93,22 @@ func Root(cfg Config) *cobra.Command {
 	root.AddCommand(declarative.NewRunCmd(deps))
 	root.AddCommand(declarative.NewPullCmd(deps))
 	root.AddCommand(declarative.NewWaitCmd(deps))
+	root.AddCommand(declarative.NewTagCmd(deps))
+	root.AddCommand(declarative.NewHistoryCmd(deps))
+	root.AddCommand(declarative.NewRollbackCmd(deps))
+	root.AddCommand(declarative.NewDeprecateCmd(deps))
+	root.AddCommand(declarative.NewYankCmd(deps))
+	root.AddCommand(declarative.NewGraphCmd(deps))
+	root.AddCommand(declarative.NewSearchCmd(deps))
+	root.AddCommand(declarative.NewAuthCmd(deps))
+	root.AddCommand(declarative.NewTokenCmd(deps))
+	root.AddCommand(declarative.NewNamespaceCmd(deps))
+	if credentials != nil {
+		root.AddCommand(login.NewLoginCmd(deps, credentials))
+		root.AddCommand(login.NewLogoutCmd(deps, credentials))
+	}
+	root.AddCommand(declarative.NewSignCmd(deps))
+	root.AddCommand(declarative.NewVerifyCmd(deps))
 	migrationSources := append([]migrate.Source{legacymigrate.OSSSource()}, cfg.ExtraMigrationSources...)
 	root.AddCommand(db.NewCommand(migrationSources...))
 
@@ -136,7 +162,10 @@ type Config struct {
 	Long    string
 	Version string
 
-	Env  cliruntime.Env
+	Env cliruntime.Env
+	// Auth resolves the registry token when neither --registry-token nor
+	// ARCTL_API_TOKEN is set. Nil uses the sessions stored by
+	// `arctl login`, which is only registered in that case.
 	Auth cliruntime.AuthProvider
 
 	ExtraCommands []*cobra.Command
@@ -167,7 +196,6 @@ func DefaultConfig() Config {
 		Long:     defaultLong,
 		Version:  version.Version,
 		Env:      cliruntime.OSEnv{},
-		Auth:     cliruntime.NoopAuthProvider{},
 		Disabled: map[string]bool{},
 	}
 }
@@ -188,9 +216,6 @@ func (c Config) withDefaults() Config {
 	if c.Env == nil {
 		c.Env = cliruntime.OSEnv{}
 	}
-	if c.Auth == nil {
-		c.Auth = cliruntime.NoopAuthProvider{}
-	}
 	if c.Disabled == nil {
 		c.Disabled = map[string]bool{}
 	}
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	dbmigrate "github.com/agentregistry-dev/agentregistry/pkg/cli/db/migrate"
+	cliruntime "github.com/agentregistry-dev/agentregistry/pkg/cli/runtime"
 )
 
 func TestRootDisabledCommandPathsPruneBuiltInsBeforeExtraCommands(t *testing.T) {
@@ -41,6 +42,20 @@ func TestRootDisabledCommandPathsPruneBuiltInsBeforeExtraCommands(t *testing.T)
 	}
 }
 
+func TestRootRegistersLoginOnlyForStoredSessions(t *testing.T) {
+	root := Root(DefaultConfig())
+	if childCommand(root, "login") == nil || childCommand(root, "logout") == nil {
+		t.Fatal("expected login and logout with the default auth provider")
+	}
+
+	cfg := DefaultConfig()
+	cfg.Auth = cliruntime.NoopAuthProvider{}
+	root = Root(cfg)
+	if got := childCommand(root, "login"); got != nil {
+		t.Fatalf("login registered alongside a custom auth provider: %#v", got)
+	}
+}
+
 func childCommand(parent *cobra.Command, name string) *cobra.Command {
 	for _, cmd := range parent.Commands() {
 		if cmd.Name() == name {
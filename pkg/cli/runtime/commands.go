@@ -2,17 +2,31 @@ package runtime
 
 const (
 	CommandApply      = "apply"
+	CommandAuth       = "auth"
 	CommandBuild      = "build"
 	CommandCompletion = "completion"
 	CommandConfigure  = "configure"
 	CommandDaemon     = "daemon"
 	CommandDB         = "db"
 	CommandDelete     = "delete"
+	CommandDeprecate  = "deprecate"
 	CommandGet        = "get"
+	CommandGraph      = "graph"
 	CommandHelp       = "help"
+	CommandHistory    = "history"
 	CommandInit       = "init"
+	CommandLogin      = "login"
+	CommandLogout     = "logout"
+	CommandNamespace  = "namespace"
 	CommandPull       = "pull"
+	CommandRollback   = "rollback"
 	CommandRun        = "run"
+	CommandSearch     = "search"
+	CommandSign       = "sign"
+	CommandTag        = "tag"
+	CommandToken      = "token"
+	CommandVerify     = "verify"
 	CommandVersion    = "version"
 	CommandWait       = "wait"
+	CommandYank       = "yank"
 )
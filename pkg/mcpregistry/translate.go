@@ -97,8 +97,9 @@ func versionOf(s *v1alpha1.MCPServer) string {
 	return defaultVersion
 }
 
-// packageVersionOf extracts the concrete package version. npm/pypi carry an
-// explicit version; oci encodes it in the identifier (":tag" or "@sha256:...").
+// packageVersionOf extracts the concrete package version. npm/pypi and the
+// binary origins carry an explicit version; oci encodes it in the identifier
+// (":tag" or "@sha256:...").
 func packageVersionOf(p *v1alpha1.MCPPackage) string {
 	switch p.Origin.Type {
 	case v1alpha1.MCPPackageOriginTypeNPM:
@@ -111,6 +112,18 @@ func packageVersionOf(p *v1alpha1.MCPPackage) string {
 		}
 	case v1alpha1.MCPPackageOriginTypeOCI:
 		return ociVersionFromIdentifier(p.Origin.Identifier)
+	case v1alpha1.MCPPackageOriginTypeGitHubRelease:
+		if p.Origin.GitHubRelease != nil {
+			return p.Origin.GitHubRelease.Version
+		}
+	case v1alpha1.MCPPackageOriginTypeGo:
+		if p.Origin.Go != nil {
+			return p.Origin.Go.Version
+		}
+	case v1alpha1.MCPPackageOriginTypeCargo:
+		if p.Origin.Cargo != nil {
+			return p.Origin.Cargo.Version
+		}
 	}
 	return ""
 }
@@ -199,6 +212,18 @@ func mirrorOf(o v1alpha1.MCPPackageOrigin) string {
 		if o.PyPI != nil {
 			return o.PyPI.Mirror
 		}
+	case v1alpha1.MCPPackageOriginTypeGitHubRelease:
+		if o.GitHubRelease != nil {
+			return o.GitHubRelease.Mirror
+		}
+	case v1alpha1.MCPPackageOriginTypeGo:
+		if o.Go != nil {
+			return o.Go.Mirror
+		}
+	case v1alpha1.MCPPackageOriginTypeCargo:
+		if o.Cargo != nil {
+			return o.Cargo.Mirror
+		}
 	}
 	return ""
 }
@@ -0,0 +1,114 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+)
+
+func TestNewAPIToken(t *testing.T) {
+	token, hash := auth.NewAPIToken()
+	assert.True(t, strings.HasPrefix(token, auth.APITokenPrefix))
+	assert.Equal(t, auth.HashAPIToken(token), hash)
+	other, _ := auth.NewAPIToken()
+	assert.NotEqual(t, token, other)
+}
+
+func TestAPITokenGroups(t *testing.T) {
+	assert.Equal(t, []string{auth.GroupServiceAccounts}, auth.APITokenGroups(auth.ServiceAccountSubjectPrefix+"ci"))
+	assert.Empty(t, auth.APITokenGroups("alice"), "user tokens carry no groups")
+}
+
+func TestValidateScope(t *testing.T) {
+	tests := []struct {
+		scope   auth.Permission
+		wantErr bool
+	}{
+		{scope: auth.Permission{Action: auth.PermissionActionRead, ResourcePattern: "*"}},
+		{scope: auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "team-a/*"}},
+		{scope: auth.Permission{Action: auth.PermissionActionDelete, ResourcePattern: "team-a/support-*"}},
+		{scope: auth.Permission{Action: auth.PermissionActionDeploy, ResourcePattern: "*/support-bot"}},
+		{scope: auth.Permission{Action: "admin", ResourcePattern: "*"}, wantErr: true},
+		{scope: auth.Permission{Action: auth.PermissionActionRead, ResourcePattern: "team-a"}, wantErr: true},
+		{scope: auth.Permission{Action: auth.PermissionActionRead, ResourcePattern: "team-*/x"}, wantErr: true},
+		{scope: auth.Permission{Action: auth.PermissionActionRead, ResourcePattern: "team-a/*-bot"}, wantErr: true},
+		{scope: auth.Permission{Action: auth.PermissionActionRead, ResourcePattern: "team-a/x/y"}, wantErr: true},
+		{scope: auth.Permission{Action: auth.PermissionActionRead, ResourcePattern: ""}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.scope.Action)+" "+tt.scope.ResourcePattern, func(t *testing.T) {
+			err := auth.ValidateScope(tt.scope)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAuthorizeScopes(t *testing.T) {
+	session := &auth.APITokenSession{Subject: "alice", TokenScopes: []auth.Permission{
+		{Action: auth.PermissionActionRead, ResourcePattern: "*"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "team-a/support-*"},
+	}}
+
+	tests := []struct {
+		name    string
+		session auth.Session
+		req     auth.AccessRequest
+		wantErr bool
+	}{
+		{name: "read anywhere", session: session, req: auth.AccessRequest{Verb: "get", Kind: v1alpha1.KindAgent, Namespace: "team-b", Name: "x"}},
+		{name: "list anywhere", session: session, req: auth.AccessRequest{Verb: "list", Kind: v1alpha1.KindSkill}},
+		{name: "publish in scope", session: session, req: auth.AccessRequest{Verb: "apply", Kind: v1alpha1.KindAgent, Namespace: "team-a", Name: "support-bot"}},
+		{name: "publish out of scope", session: session, req: auth.AccessRequest{Verb: "apply", Kind: v1alpha1.KindAgent, Namespace: "team-a", Name: "billing-bot"}, wantErr: true},
+		{name: "delete not scoped", session: session, req: auth.AccessRequest{Verb: "delete", Kind: v1alpha1.KindAgent, Namespace: "team-a", Name: "support-bot"}, wantErr: true},
+		{name: "unscoped sessions pass", session: userSession{subject: "alice"}, req: auth.AccessRequest{Verb: "delete", Kind: v1alpha1.KindAgent, Namespace: "team-a", Name: "x"}},
+		{name: "nil session passes", req: auth.AccessRequest{Verb: "delete", Kind: v1alpha1.KindAgent, Namespace: "team-a", Name: "x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := auth.AuthorizeScopes(tt.session, tt.req)
+			if tt.wantErr {
+				require.ErrorIs(t, err, auth.ErrForbidden)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestScopeListScope(t *testing.T) {
+	session := &auth.APITokenSession{Subject: "alice", TokenScopes: []auth.Permission{
+		{Action: auth.PermissionActionRead, ResourcePattern: "team-a/*"},
+		{Action: auth.PermissionActionRead, ResourcePattern: "*/shared-*"},
+		{Action: auth.PermissionActionDelete, ResourcePattern: "*"},
+	}}
+
+	scope, scoped := auth.ScopeListScope(session, v1alpha1.RBACVerbList, v1alpha1.KindAgent, "")
+	require.True(t, scoped)
+	assert.False(t, scope.All)
+	require.Len(t, scope.Grants, 2)
+	assert.Equal(t, []string{"team-a"}, scope.Grants[0].Namespaces)
+	assert.Nil(t, scope.Grants[1].Namespaces)
+	assert.Equal(t, []string{"shared-*"}, scope.Grants[1].Names)
+
+	scope, _ = auth.ScopeListScope(session, v1alpha1.RBACVerbList, v1alpha1.KindAgent, "team-a")
+	assert.True(t, scope.All)
+
+	_, scoped = auth.ScopeListScope(userSession{subject: "alice"}, v1alpha1.RBACVerbList, v1alpha1.KindAgent, "")
+	assert.False(t, scoped)
+}
+
+func TestRBACAuthzProvider_ScopedSessionIsNeverAdmin(t *testing.T) {
+	p := auth.NewRBACAuthzProvider(testPolicy(), []string{"root"})
+	session := &auth.APITokenSession{Subject: "root", TokenScopes: []auth.Permission{{Action: auth.PermissionActionRead, ResourcePattern: "*"}}}
+	assert.False(t, p.IsRegistryAdmin(t.Context(), session))
+	assert.True(t, p.IsRegistryAdmin(t.Context(), userSession{subject: "root"}))
+}
@@ -16,6 +16,11 @@ type Resource struct {
 }
 
 type User struct {
+	// Subject identifies the user to RBAC RoleBindings (e.g. an OIDC
+	// subject). Empty for anonymous and system sessions.
+	Subject string
+	// Groups are the groups RBAC RoleBindings may name for this user.
+	Groups      []string
 	Permissions []Permission
 }
 
@@ -32,6 +37,34 @@ type AuthnProvider interface {
 	Authenticate(ctx context.Context, reqHeaders func(name string) string, query url.Values) (Session, error)
 }
 
+// ChainAuthnProviders returns an AuthnProvider that accepts a request's
+// credentials when any of providers does, trying them in order. It fails
+// only when every provider that saw credentials rejected them, and
+// returns no session when none did.
+func ChainAuthnProviders(providers ...AuthnProvider) AuthnProvider {
+	return authnChain(providers)
+}
+
+type authnChain []AuthnProvider
+
+func (c authnChain) Authenticate(ctx context.Context, reqHeaders func(name string) string, query url.Values) (Session, error) {
+	var errs []error
+	for _, provider := range c {
+		session, err := provider.Authenticate(ctx, reqHeaders, query)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if session != nil {
+			return session, nil
+		}
+	}
+	if len(errs) > 0 {
+		return nil, huma.Error401Unauthorized("Invalid or expired token", errs...)
+	}
+	return nil, nil
+}
+
 // context utils
 
 type sessionKeyType struct{}
@@ -0,0 +1,288 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// DomainProofWellKnownPath is where an HTTP proof record is served,
+	// under https://DOMAIN.
+	DomainProofWellKnownPath = "/.well-known/mcp-registry-auth"
+	// domainProofVersion is the v= tag of a proof record. It matches the
+	// upstream MCP registry, so records published for it work here too.
+	domainProofVersion = "MCPv1"
+	// domainProofKeyType is the only k= tag accepted.
+	domainProofKeyType = "ed25519"
+	// domainProofMessageHeader opens every signed proof message, so a
+	// signature made for anything else never verifies as a proof.
+	domainProofMessageHeader = "agentregistry namespace claim v1"
+	// domainProofMaxSkew bounds how far a proof's timestamp may be from
+	// the registry's clock, so a captured proof cannot be replayed later.
+	domainProofMaxSkew = 15 * time.Second
+	// domainProofMaxRecordSize caps the HTTP proof response body.
+	domainProofMaxRecordSize = 4 << 10
+	// domainProofFetchTimeout bounds a single DNS lookup or HTTP fetch.
+	domainProofFetchTimeout = 10 * time.Second
+)
+
+// ErrInvalidProof is returned when a domain proof does not verify: no
+// record is published, none holds the signing key, or the signature or
+// timestamp is wrong.
+var ErrInvalidProof = errors.New("invalid domain proof")
+
+// TXTResolver looks up DNS TXT records. *net.Resolver implements it.
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// DomainProof is a claim to control Domain: Signature is the hex Ed25519
+// signature of DomainProofMessage(Domain, Timestamp) by a key published
+// for the domain through Method, MethodDNS or MethodHTTP.
+type DomainProof struct {
+	Method    Method
+	Domain    string
+	Timestamp string
+	Signature string
+}
+
+// DomainProofMessage returns the message a proof for domain signs at
+// timestamp (RFC 3339). It names the domain and the namespace it claims
+// as well as the time, so a captured proof cannot claim anything else.
+func DomainProofMessage(domain, timestamp string) ([]byte, error) {
+	namespace, err := NamespaceForDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Appendf(nil, "%s\ndomain=%s\nnamespace=%s\ntimestamp=%s\n", domainProofMessageHeader, domain, namespace, timestamp), nil
+}
+
+// DomainProofConfig configures a DomainProofVerifier. Zero fields take
+// the defaults, which reach the network.
+type DomainProofConfig struct {
+	// Resolver looks up DNS proof records. Defaults to net.DefaultResolver.
+	Resolver TXTResolver
+	// HTTPClient fetches HTTP proof records. Defaults to a client that
+	// does not follow redirects, so the record must be served by the
+	// domain itself, and only connects to public addresses, so a claim
+	// cannot make the registry probe its own network.
+	HTTPClient *http.Client
+	// Now is the clock timestamps are checked against. Defaults to
+	// time.Now.
+	Now func() time.Time
+}
+
+// DomainProofVerifier checks DNS and HTTP domain proofs, the
+// public/private key authentication of MethodDNS and MethodHTTP.
+//
+// The domain publishes an Ed25519 public key as a record of the form
+//
+//	v=MCPv1; k=ed25519; p=BASE64_PUBLIC_KEY
+//
+// in a TXT record on the domain (MethodDNS), or as the body of
+// https://DOMAIN/.well-known/mcp-registry-auth (MethodHTTP). Several
+// records may be published, e.g. while rotating keys.
+type DomainProofVerifier struct {
+	resolver   TXTResolver
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewDomainProofVerifier returns a verifier for cfg.
+func NewDomainProofVerifier(cfg DomainProofConfig) *DomainProofVerifier {
+	v := &DomainProofVerifier{resolver: cfg.Resolver, httpClient: cfg.HTTPClient, now: cfg.Now}
+	if v.resolver == nil {
+		v.resolver = net.DefaultResolver
+	}
+	if v.httpClient == nil {
+		v.httpClient = newDomainProofHTTPClient()
+	}
+	if v.now == nil {
+		v.now = time.Now
+	}
+	return v
+}
+
+// Verify checks proof and returns the published key that signed it.
+// Failures wrap ErrInvalidProof.
+func (v *DomainProofVerifier) Verify(ctx context.Context, proof DomainProof) (ed25519.PublicKey, error) {
+	message, err := DomainProofMessage(proof.Domain, proof.Timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+	ts, err := time.Parse(time.RFC3339, proof.Timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("%w: timestamp must be RFC 3339: %w", ErrInvalidProof, err)
+	}
+	if skew := v.now().Sub(ts).Abs(); skew > domainProofMaxSkew {
+		return nil, fmt.Errorf("%w: timestamp is %s from the registry's clock, more than %s", ErrInvalidProof, skew.Round(time.Second), domainProofMaxSkew)
+	}
+	sig, err := hex.DecodeString(proof.Signature)
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("%w: signature must be a hex Ed25519 signature", ErrInvalidProof)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, domainProofFetchTimeout)
+	defer cancel()
+	var records []string
+	switch proof.Method {
+	case MethodDNS:
+		records, err = v.resolver.LookupTXT(ctx, proof.Domain)
+	case MethodHTTP:
+		records, err = v.fetchHTTPRecords(ctx, proof.Domain)
+	default:
+		return nil, fmt.Errorf("%w: method must be %s or %s, got %q", ErrInvalidProof, MethodDNS, MethodHTTP, proof.Method)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading %s records of %s: %w", ErrInvalidProof, proof.Method, proof.Domain, err)
+	}
+
+	var published bool
+	for _, record := range records {
+		key, err := ParseDomainProofRecord(record)
+		if err != nil {
+			continue
+		}
+		published = true
+		if ed25519.Verify(key, message, sig) {
+			return key, nil
+		}
+	}
+	if !published {
+		return nil, fmt.Errorf("%w: %s publishes no %s proof record", ErrInvalidProof, proof.Domain, proof.Method)
+	}
+	return nil, fmt.Errorf("%w: no key published by %s verifies the signature", ErrInvalidProof, proof.Domain)
+}
+
+// fetchHTTPRecords reads the well-known proof file of domain, one record
+// per line.
+func (v *DomainProofVerifier) fetchHTTPRecords(ctx context.Context, domain string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+domain+DomainProofWellKnownPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", req.URL, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, domainProofMaxRecordSize))
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(string(body), "\n"), nil
+}
+
+// newDomainProofHTTPClient returns the default HTTP proof client. It
+// neither follows redirects nor uses a proxy, and its dialer refuses
+// every address that is not public.
+func newDomainProofHTTPClient() *http.Client {
+	dialer := &net.Dialer{Timeout: domainProofFetchTimeout, Control: refuseNonPublicAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Transport:     transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+}
+
+// sharedAddressSpace is 100.64.0.0/10 (RFC 6598), used for carrier-grade
+// NAT and by some clouds for metadata services.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// refuseNonPublicAddress is a net.Dialer Control hook. It runs after name
+// resolution, on the address actually dialed, so a domain cannot resolve
+// to a private address to reach the registry's network.
+func refuseNonPublicAddress(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	addr := addrPort.Addr().Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || sharedAddressSpace.Contains(addr) {
+		return fmt.Errorf("refusing to connect to non-public address %s", addr)
+	}
+	return nil
+}
+
+// ParseDomainProofRecord parses a "v=MCPv1; k=ed25519; p=..." record and
+// returns its public key.
+func ParseDomainProofRecord(record string) (ed25519.PublicKey, error) {
+	tags := map[string]string{}
+	for field := range strings.SplitSeq(record, ";") {
+		name, value, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok {
+			continue
+		}
+		tags[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	if tags["v"] != domainProofVersion {
+		return nil, fmt.Errorf("proof record: v must be %s", domainProofVersion)
+	}
+	if tags["k"] != domainProofKeyType {
+		return nil, fmt.Errorf("proof record: k must be %s, got %q", domainProofKeyType, tags["k"])
+	}
+	key, err := base64.StdEncoding.DecodeString(tags["p"])
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("proof record: p must be a base64 Ed25519 public key")
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// FormatDomainProofRecord returns the proof record publishing key.
+func FormatDomainProofRecord(key ed25519.PublicKey) string {
+	return fmt.Sprintf("v=%s; k=%s; p=%s", domainProofVersion, domainProofKeyType, base64.StdEncoding.EncodeToString(key))
+}
+
+// NamespaceForDomain returns the namespace a proof for domain owns: its
+// labels reversed, e.g. "com.acme" for "acme.com". The domain must be a
+// lowercase hostname of at least two labels, not an IP address, whose
+// reversed form is a valid namespace.
+func NamespaceForDomain(domain string) (string, error) {
+	if net.ParseIP(domain) != nil {
+		return "", fmt.Errorf("domain %q must be a hostname, not an IP address", domain)
+	}
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return "", fmt.Errorf("domain %q must have at least two labels", domain)
+	}
+	for _, label := range labels {
+		if !isDomainLabel(label) {
+			return "", fmt.Errorf("domain %q must be a lowercase hostname", domain)
+		}
+	}
+	slices.Reverse(labels)
+	namespace := strings.Join(labels, ".")
+	if len(namespace) > 63 {
+		return "", fmt.Errorf("domain %q is longer than a namespace allows (63 characters)", domain)
+	}
+	return namespace, nil
+}
+
+func isDomainLabel(label string) bool {
+	if label == "" || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for _, r := range label {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
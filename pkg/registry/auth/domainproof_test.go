@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTXTResolver serves TXT records from a map.
+type fakeTXTResolver map[string][]string
+
+func (r fakeTXTResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	records, ok := r[name]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return records, nil
+}
+
+// roundTripFunc serves HTTP requests in-process.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// wellKnownClient serves body as every domain's proof file, with status.
+func wellKnownClient(t *testing.T, status int, body string) *http.Client {
+	t.Helper()
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "https", req.URL.Scheme)
+		assert.Equal(t, DomainProofWellKnownPath, req.URL.Path)
+		return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: io.NopCloser(strings.NewReader(body))}, nil
+	})}
+}
+
+func newProofKey(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func signedProof(key ed25519.PrivateKey, method Method, domain string, at time.Time) DomainProof {
+	ts := at.UTC().Format(time.RFC3339)
+	proof := DomainProof{Method: method, Domain: domain, Timestamp: ts}
+	if message, err := DomainProofMessage(domain, ts); err == nil {
+		proof.Signature = hex.EncodeToString(ed25519.Sign(key, message))
+	} else {
+		proof.Signature = hex.EncodeToString(make([]byte, ed25519.SignatureSize))
+	}
+	return proof
+}
+
+func TestDomainProofVerifier_DNS(t *testing.T) {
+	now := time.Now()
+	key, other := newProofKey(t), newProofKey(t)
+	record := FormatDomainProofRecord(key.Public().(ed25519.PublicKey))
+	v := NewDomainProofVerifier(DomainProofConfig{
+		Resolver: fakeTXTResolver{
+			"acme.com":  {"google-site-verification=abc", record},
+			"other.com": {record},
+			"plain.com": {"v=spf1 -all"},
+		},
+		Now: func() time.Time { return now },
+	})
+	ctx := context.Background()
+
+	got, err := v.Verify(ctx, signedProof(key, MethodDNS, "acme.com", now.Add(-5*time.Second)))
+	require.NoError(t, err)
+	assert.Equal(t, key.Public(), got)
+
+	for name, proof := range map[string]DomainProof{
+		"unpublished key": signedProof(other, MethodDNS, "acme.com", now),
+		"stale timestamp": signedProof(key, MethodDNS, "acme.com", now.Add(-time.Minute)),
+		"no record":       signedProof(key, MethodDNS, "plain.com", now),
+		"lookup failure":  signedProof(key, MethodDNS, "missing.com", now),
+		"bad domain":      signedProof(key, MethodDNS, "localhost", now),
+		"unknown method":  signedProof(key, MethodOIDC, "acme.com", now),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := v.Verify(ctx, proof)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+	}
+
+	tampered := signedProof(key, MethodDNS, "acme.com", now)
+	tampered.Timestamp = now.Add(time.Second).UTC().Format(time.RFC3339)
+	_, err = v.Verify(ctx, tampered)
+	require.ErrorContains(t, err, "verifies the signature")
+
+	// A proof is bound to its domain: one made for other.com, which
+	// publishes the same key, does not claim acme.com.
+	replayed := signedProof(key, MethodDNS, "other.com", now)
+	replayed.Domain = "acme.com"
+	_, err = v.Verify(ctx, replayed)
+	require.ErrorContains(t, err, "verifies the signature")
+
+	// Nor does a bare signature of the timestamp.
+	bare := signedProof(key, MethodDNS, "acme.com", now)
+	bare.Signature = hex.EncodeToString(ed25519.Sign(key, []byte(bare.Timestamp)))
+	_, err = v.Verify(ctx, bare)
+	require.ErrorContains(t, err, "verifies the signature")
+}
+
+func TestDomainProofVerifier_HTTP(t *testing.T) {
+	now := time.Now()
+	key := newProofKey(t)
+	record := FormatDomainProofRecord(key.Public().(ed25519.PublicKey))
+	proof := signedProof(key, MethodHTTP, "acme.com", now)
+
+	v := NewDomainProofVerifier(DomainProofConfig{HTTPClient: wellKnownClient(t, http.StatusOK, "# rotated keys\n"+record+"\n"), Now: func() time.Time { return now }})
+	got, err := v.Verify(context.Background(), proof)
+	require.NoError(t, err)
+	assert.Equal(t, key.Public(), got)
+
+	v = NewDomainProofVerifier(DomainProofConfig{HTTPClient: wellKnownClient(t, http.StatusNotFound, ""), Now: func() time.Time { return now }})
+	_, err = v.Verify(context.Background(), proof)
+	require.ErrorIs(t, err, ErrInvalidProof)
+	require.ErrorContains(t, err, "Not Found")
+}
+
+func TestDomainProofHTTPClient_RefusesNonPublicAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("the proof client reached a loopback server")
+	}))
+	t.Cleanup(srv.Close)
+	_, err := newDomainProofHTTPClient().Get(srv.URL)
+	require.ErrorContains(t, err, "non-public address")
+
+	for _, address := range []string{
+		"127.0.0.1:443", "[::1]:443", "10.1.2.3:443", "172.16.0.1:443", "192.168.1.1:443",
+		"169.254.169.254:80", "[fe80::1]:443", "[fd00::1]:443", "100.100.100.200:80", "0.0.0.0:443",
+		"[::ffff:127.0.0.1]:443",
+	} {
+		assert.Error(t, refuseNonPublicAddress("tcp", address, nil), address)
+	}
+	for _, address := range []string{"93.184.215.14:443", "[2606:2800:21f:cb07:6820:80da:af6b:8b2c]:443"} {
+		assert.NoError(t, refuseNonPublicAddress("tcp", address, nil), address)
+	}
+}
+
+func TestParseDomainProofRecord(t *testing.T) {
+	key := newProofKey(t).Public().(ed25519.PublicKey)
+	got, err := ParseDomainProofRecord(FormatDomainProofRecord(key))
+	require.NoError(t, err)
+	assert.Equal(t, key, got)
+
+	for _, record := range []string{
+		"v=spf1 -all",
+		"v=MCPv1; k=ecdsap384; p=AAAA",
+		"v=MCPv1; k=ed25519; p=not-base64",
+		"v=MCPv1; k=ed25519; p=AAAA",
+	} {
+		_, err := ParseDomainProofRecord(record)
+		assert.Error(t, err, record)
+	}
+}
+
+func TestNamespaceForDomain(t *testing.T) {
+	for domain, want := range map[string]string{
+		"acme.com":          "com.acme",
+		"tools.acme.co.uk":  "uk.co.acme.tools",
+		"my-org.example.io": "io.example.my-org",
+	} {
+		got, err := NamespaceForDomain(domain)
+		require.NoError(t, err, domain)
+		assert.Equal(t, want, got)
+	}
+	for _, domain := range []string{"", "localhost", "Acme.com", "acme..com", "-acme.com", "10.0.0.1", "acme.com.", strings.Repeat("a", 60) + ".com"} {
+		_, err := NamespaceForDomain(domain)
+		assert.Error(t, err, domain)
+	}
+}
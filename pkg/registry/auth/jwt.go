@@ -146,8 +146,13 @@ type jwtSession struct {
 }
 
 func (s *jwtSession) Principal() Principal {
+	subject := s.claims.Subject
+	if subject == "" {
+		subject = s.claims.AuthMethodSubject
+	}
 	return Principal{
 		User: User{
+			Subject:     subject,
 			Permissions: s.claims.Permissions,
 		},
 	}
@@ -0,0 +1,434 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// oidcDiscoveryPath is appended to the issuer URL to find its
+	// provider metadata.
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+	// oidcKeysMaxAge bounds how long a fetched key set is trusted before
+	// it is refetched, so revoked keys stop verifying.
+	oidcKeysMaxAge = time.Hour
+	// oidcKeysMinRefresh rate-limits refetches triggered by tokens signed
+	// with an unknown key id, so forged kids cannot hammer the issuer.
+	oidcKeysMinRefresh = 10 * time.Second
+	// oidcLeeway tolerates clock skew between the issuer and the registry.
+	oidcLeeway = time.Minute
+	// oidcMaxDocumentSize caps discovery and JWKS response bodies.
+	oidcMaxDocumentSize = 1 << 20
+	// oidcFetchTimeout bounds a single discovery or JWKS request.
+	oidcFetchTimeout = 10 * time.Second
+)
+
+const (
+	// DefaultOIDCPrefix is the default UsernamePrefix and GroupsPrefix. It
+	// keeps the issuer's users and groups apart from the registry's own.
+	DefaultOIDCPrefix = "oidc:"
+	// NoOIDCPrefix, as a UsernamePrefix or GroupsPrefix, maps claims
+	// verbatim.
+	NoOIDCPrefix = "-"
+)
+
+// reservedIdentityPrefixes start subjects and groups the registry assigns
+// itself, such as system:authenticated and service-account subjects. An
+// OIDC token never maps onto one.
+var reservedIdentityPrefixes = []string{"system:", ServiceAccountSubjectPrefix}
+
+// oidcSigningMethods are the JWS algorithms accepted on OIDC tokens.
+// Symmetric algorithms are excluded: the registry only holds public keys.
+var oidcSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// OIDCConfig configures an OIDCProvider.
+type OIDCConfig struct {
+	// IssuerURL identifies the issuer. Its metadata is discovered at
+	// {IssuerURL}/.well-known/openid-configuration, and tokens must carry
+	// it verbatim as their iss claim.
+	IssuerURL string
+	// Audiences are the accepted aud values, typically client IDs. A
+	// token must name at least one of them.
+	Audiences []string
+	// UsernameClaim is the claim that becomes User.Subject. Defaults to
+	// "sub".
+	UsernameClaim string
+	// UsernamePrefix is prepended to the subject. Defaults to
+	// DefaultOIDCPrefix; NoOIDCPrefix maps it verbatim.
+	UsernamePrefix string
+	// GroupsClaim is the claim that becomes User.Groups: a string or a
+	// list of strings. When no claim has that exact name, dots descend
+	// into nested objects (e.g. "realm_access.roles"). Defaults to
+	// "groups"; a token without the claim has no groups.
+	GroupsClaim string
+	// GroupsPrefix is prepended to every group. Defaults to
+	// DefaultOIDCPrefix; NoOIDCPrefix maps groups verbatim.
+	GroupsPrefix string
+	// HTTPClient fetches the discovery and JWKS documents. Defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// OIDCProvider authenticates bearer tokens issued by an OpenID Connect
+// provider. Signing keys are discovered from the issuer's metadata and
+// JWKS document; they are refetched hourly, and sooner when a token names
+// a key id the cached set lacks, so issuer key rotation needs no restart.
+type OIDCProvider struct {
+	cfg    OIDCConfig
+	client *http.Client
+	now    func() time.Time
+
+	mu        sync.Mutex
+	jwksURI   string
+	keys      map[string]any
+	fetchedAt time.Time
+}
+
+var _ AuthnProvider = &OIDCProvider{}
+
+// NewOIDCProvider returns an OIDCProvider for cfg. Discovery happens
+// lazily on the first token, so an unreachable issuer does not keep the
+// registry from starting.
+func NewOIDCProvider(cfg OIDCConfig) (*OIDCProvider, error) {
+	if cfg.IssuerURL == "" {
+		return nil, errors.New("oidc: issuer URL is required")
+	}
+	if len(cfg.Audiences) == 0 {
+		return nil, errors.New("oidc: at least one audience is required")
+	}
+	if cfg.UsernameClaim == "" {
+		cfg.UsernameClaim = "sub"
+	}
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+	cfg.UsernamePrefix = oidcPrefix(cfg.UsernamePrefix)
+	cfg.GroupsPrefix = oidcPrefix(cfg.GroupsPrefix)
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &OIDCProvider{cfg: cfg, client: client, now: time.Now}, nil
+}
+
+type oidcSession struct {
+	subject string
+	groups  []string
+}
+
+func (s *oidcSession) Principal() Principal {
+	return Principal{User: User{Subject: s.subject, Groups: s.groups}}
+}
+
+// Authenticate validates the request's bearer token against the issuer.
+// It returns no session when the request carries no bearer token.
+func (p *OIDCProvider) Authenticate(ctx context.Context, reqHeaders func(name string) string, _ url.Values) (Session, error) {
+	const bearerPrefix = "Bearer "
+	authHeader := reqHeaders("Authorization")
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return nil, nil
+	}
+	session, err := p.ValidateToken(ctx, authHeader[len(bearerPrefix):])
+	if err != nil {
+		return nil, huma.Error401Unauthorized("Invalid or expired OIDC token", err)
+	}
+	return session, nil
+}
+
+// ValidateToken verifies tokenString's signature, issuer, audience and
+// expiry, and maps its claims onto a session. A subject or group that
+// starts with a reserved prefix, before or after prefixing, is rejected.
+func (p *OIDCProvider) ValidateToken(ctx context.Context, tokenString string) (Session, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(
+		tokenString,
+		claims,
+		func(token *jwt.Token) (any, error) {
+			kid, _ := token.Header["kid"].(string)
+			return p.verificationKey(ctx, kid)
+		},
+		jwt.WithValidMethods(oidcSigningMethods),
+		jwt.WithIssuer(p.cfg.IssuerURL),
+		jwt.WithAudience(p.cfg.Audiences...),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(oidcLeeway),
+		jwt.WithTimeFunc(p.now),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	subject, _ := claims[p.cfg.UsernameClaim].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("token has no %q claim", p.cfg.UsernameClaim)
+	}
+	if subject, err = mapOIDCIdentity(p.cfg.UsernamePrefix, subject); err != nil {
+		return nil, fmt.Errorf("claim %q: %w", p.cfg.UsernameClaim, err)
+	}
+	groups, err := claimStrings(claims, p.cfg.GroupsClaim)
+	if err != nil {
+		return nil, err
+	}
+	for i := range groups {
+		if groups[i], err = mapOIDCIdentity(p.cfg.GroupsPrefix, groups[i]); err != nil {
+			return nil, fmt.Errorf("claim %q: %w", p.cfg.GroupsClaim, err)
+		}
+	}
+	return &oidcSession{subject: subject, groups: groups}, nil
+}
+
+// oidcPrefix resolves a configured prefix: empty takes the default and
+// NoOIDCPrefix means none.
+func oidcPrefix(prefix string) string {
+	switch prefix {
+	case "":
+		return DefaultOIDCPrefix
+	case NoOIDCPrefix:
+		return ""
+	}
+	return prefix
+}
+
+// mapOIDCIdentity prefixes a subject or group claim, refusing values that
+// would impersonate an identity the registry reserves.
+func mapOIDCIdentity(prefix, value string) (string, error) {
+	mapped := prefix + value
+	for _, reserved := range reservedIdentityPrefixes {
+		if strings.HasPrefix(value, reserved) || strings.HasPrefix(mapped, reserved) {
+			return "", fmt.Errorf("%q uses the reserved prefix %q", value, reserved)
+		}
+	}
+	return mapped, nil
+}
+
+// claimStrings reads name as a string or list of strings, preferring a
+// claim with that exact name over a dotted path into nested objects.
+func claimStrings(claims jwt.MapClaims, name string) ([]string, error) {
+	value, ok := claims[name]
+	if !ok {
+		var node any = map[string]any(claims)
+		for part := range strings.SplitSeq(name, ".") {
+			obj, isObj := node.(map[string]any)
+			if !isObj {
+				return nil, nil
+			}
+			if node, ok = obj[part]; !ok {
+				return nil, nil
+			}
+		}
+		value = node
+	}
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			s, isString := item.(string)
+			if !isString {
+				return nil, fmt.Errorf("claim %q must hold strings, got %T", name, item)
+			}
+			out = append(out, s)
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("claim %q must be a string or a list of strings, got %T", name, value)
+}
+
+// verificationKey returns the key for kid, or every cached key when the
+// token names none. An unknown kid, or a key set past oidcKeysMaxAge,
+// triggers a refetch.
+func (p *OIDCProvider) verificationKey(ctx context.Context, kid string) (any, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	age := p.now().Sub(p.fetchedAt)
+	_, known := p.keys[kid]
+	stale := p.keys == nil || age >= oidcKeysMaxAge || (kid != "" && !known && age >= oidcKeysMinRefresh)
+	if stale {
+		if err := p.refreshKeys(ctx); err != nil {
+			if p.keys == nil {
+				return nil, err
+			}
+			// Keep verifying with the previous keys while the issuer is
+			// unreachable.
+			slog.Warn("oidc: refreshing signing keys failed", "issuer", p.cfg.IssuerURL, "error", err)
+		}
+	}
+
+	if kid != "" {
+		key, ok := p.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		return key, nil
+	}
+	set := jwt.VerificationKeySet{}
+	for _, key := range p.keys {
+		set.Keys = append(set.Keys, key)
+	}
+	if len(set.Keys) == 0 {
+		return nil, errors.New("issuer publishes no signing keys")
+	}
+	return set, nil
+}
+
+// refreshKeys discovers the JWKS URI (once) and refetches the key set.
+// Callers hold p.mu.
+func (p *OIDCProvider) refreshKeys(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, oidcFetchTimeout)
+	defer cancel()
+
+	if p.jwksURI == "" {
+		var metadata struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := p.getJSON(ctx, strings.TrimSuffix(p.cfg.IssuerURL, "/")+oidcDiscoveryPath, &metadata); err != nil {
+			return fmt.Errorf("discover issuer: %w", err)
+		}
+		if metadata.Issuer != p.cfg.IssuerURL {
+			return fmt.Errorf("discover issuer: metadata names issuer %q, want %q", metadata.Issuer, p.cfg.IssuerURL)
+		}
+		if metadata.JWKSURI == "" {
+			return errors.New("discover issuer: metadata has no jwks_uri")
+		}
+		p.jwksURI = metadata.JWKSURI
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.getJSON(ctx, p.jwksURI, &set); err != nil {
+		return fmt.Errorf("fetch signing keys: %w", err)
+	}
+	keys := make(map[string]any, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// One malformed or unsupported key must not hide the others.
+			slog.Warn("oidc: skipping signing key", "issuer", p.cfg.IssuerURL, "kid", jwk.Kid, "error", err)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	p.keys = keys
+	p.fetchedAt = p.now()
+	return nil
+}
+
+func (p *OIDCProvider) getJSON(ctx context.Context, target string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: unexpected status %s", target, resp.Status)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, oidcMaxDocumentSize)).Decode(v); err != nil {
+		return fmt.Errorf("GET %s: %w", target, err)
+	}
+	return nil
+}
+
+// jsonWebKey is the subset of an RFC 7517 JSON Web Key needed to verify
+// signatures.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// publicKey decodes k into the key type golang-jwt verifies with:
+// *rsa.PublicKey, *ecdsa.PublicKey or ed25519.PublicKey.
+func (k jsonWebKey) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("decode n: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("decode e: %w", err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 2 || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("decode x: %w", err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("decode y: %w", err)
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("invalid EC key coordinates")
+		}
+		point := append(append([]byte{4}, x...), y...)
+		return ecdsa.ParseUncompressedPublicKey(curve, point)
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("decode x: %w", err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
@@ -0,0 +1,322 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIssuer is an in-process OIDC issuer serving discovery and JWKS
+// documents for the keys it currently publishes.
+type fakeIssuer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	keys      map[string]crypto.Signer
+	jwksFetch int
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	t.Helper()
+	iss := &fakeIssuer{keys: map[string]crypto.Signer{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": iss.URL, "jwks_uri": iss.URL + "/keys"})
+	})
+	mux.HandleFunc("GET /keys", func(w http.ResponseWriter, _ *http.Request) {
+		iss.mu.Lock()
+		defer iss.mu.Unlock()
+		iss.jwksFetch++
+		var keys []map[string]string
+		for kid, signer := range iss.keys {
+			keys = append(keys, publicJWK(kid, signer.Public()))
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": keys})
+	})
+	iss.Server = httptest.NewServer(mux)
+	t.Cleanup(iss.Close)
+	return iss
+}
+
+func publicJWK(kid string, pub crypto.PublicKey) map[string]string {
+	enc := base64.RawURLEncoding.EncodeToString
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		return map[string]string{"kty": "RSA", "kid": kid, "use": "sig", "n": enc(k.N.Bytes()), "e": enc(big.NewInt(int64(k.E)).Bytes())}
+	case *ecdsa.PublicKey:
+		raw, _ := k.Bytes()
+		size := (len(raw) - 1) / 2
+		return map[string]string{"kty": "EC", "kid": kid, "crv": k.Curve.Params().Name, "x": enc(raw[1 : 1+size]), "y": enc(raw[1+size:])}
+	}
+	panic("unsupported key type")
+}
+
+func (iss *fakeIssuer) addKey(t *testing.T, kid string, signer crypto.Signer) {
+	t.Helper()
+	iss.mu.Lock()
+	defer iss.mu.Unlock()
+	iss.keys[kid] = signer
+}
+
+func (iss *fakeIssuer) removeKey(kid string) {
+	iss.mu.Lock()
+	defer iss.mu.Unlock()
+	delete(iss.keys, kid)
+}
+
+func (iss *fakeIssuer) fetches() int {
+	iss.mu.Lock()
+	defer iss.mu.Unlock()
+	return iss.jwksFetch
+}
+
+// sign issues a token signed by kid's key, with claims layered over
+// valid defaults for iss. A nil claim value drops the default.
+func (iss *fakeIssuer) sign(t *testing.T, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	iss.mu.Lock()
+	signer := iss.keys[kid]
+	iss.mu.Unlock()
+	if signer == nil {
+		// Sign with a key the issuer does not publish.
+		signer = rsaKey(t)
+	}
+	all := jwt.MapClaims{
+		"iss": iss.URL,
+		"aud": "registry",
+		"sub": "alice",
+		"exp": time.Now().Add(24 * time.Hour).Unix(),
+	}
+	for k, v := range claims {
+		if v == nil {
+			delete(all, k)
+			continue
+		}
+		all[k] = v
+	}
+	method := jwt.SigningMethod(jwt.SigningMethodRS256)
+	if _, ok := signer.(*ecdsa.PrivateKey); ok {
+		method = jwt.SigningMethodES256
+	}
+	token := jwt.NewWithClaims(method, all)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(signer)
+	require.NoError(t, err)
+	return signed
+}
+
+func rsaKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func newTestOIDCProvider(t *testing.T, iss *fakeIssuer, cfg OIDCConfig) *OIDCProvider {
+	t.Helper()
+	cfg.IssuerURL = iss.URL
+	if cfg.Audiences == nil {
+		cfg.Audiences = []string{"registry"}
+	}
+	p, err := NewOIDCProvider(cfg)
+	require.NoError(t, err)
+	return p
+}
+
+func bearer(token string) func(string) string {
+	return func(name string) string {
+		if name == "Authorization" {
+			return "Bearer " + token
+		}
+		return ""
+	}
+}
+
+func TestOIDCProvider_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	iss := newFakeIssuer(t)
+	iss.addKey(t, "rsa", rsaKey(t))
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	iss.addKey(t, "ec", ecKey)
+	p := newTestOIDCProvider(t, iss, OIDCConfig{Audiences: []string{"cli", "registry"}})
+
+	tests := []struct {
+		name    string
+		kid     string
+		claims  jwt.MapClaims
+		wantErr bool
+	}{
+		{name: "valid RSA token", kid: "rsa"},
+		{name: "valid EC token", kid: "ec"},
+		{name: "audience list naming an accepted audience", kid: "rsa", claims: jwt.MapClaims{"aud": []string{"other", "cli"}}},
+		{name: "wrong audience", kid: "rsa", claims: jwt.MapClaims{"aud": "other"}, wantErr: true},
+		{name: "missing audience", kid: "rsa", claims: jwt.MapClaims{"aud": nil}, wantErr: true},
+		{name: "wrong issuer", kid: "rsa", claims: jwt.MapClaims{"iss": "https://evil.example.com"}, wantErr: true},
+		{name: "expired", kid: "rsa", claims: jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()}, wantErr: true},
+		{name: "missing expiry", kid: "rsa", claims: jwt.MapClaims{"exp": nil}, wantErr: true},
+		{name: "missing subject", kid: "rsa", claims: jwt.MapClaims{"sub": nil}, wantErr: true},
+		{name: "unpublished key", kid: "unknown", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, err := p.Authenticate(ctx, bearer(iss.sign(t, tt.kid, tt.claims)), nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, session)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "oidc:alice", session.Principal().User.Subject)
+		})
+	}
+
+	t.Run("no bearer token", func(t *testing.T) {
+		session, err := p.Authenticate(ctx, func(string) string { return "" }, nil)
+		require.NoError(t, err)
+		assert.Nil(t, session)
+	})
+	t.Run("HMAC token is rejected", func(t *testing.T) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"iss": iss.URL, "aud": "registry", "sub": "alice", "exp": time.Now().Add(time.Hour).Unix()}).SignedString([]byte("secret"))
+		require.NoError(t, err)
+		_, err = p.Authenticate(ctx, bearer(token), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestOIDCProvider_ClaimMapping(t *testing.T) {
+	ctx := context.Background()
+	iss := newFakeIssuer(t)
+	iss.addKey(t, "k1", rsaKey(t))
+
+	tests := []struct {
+		name        string
+		cfg         OIDCConfig
+		claims      jwt.MapClaims
+		wantSubject string
+		wantGroups  []string
+		wantErr     bool
+	}{
+		{name: "default claims", claims: jwt.MapClaims{"groups": []string{"ops", "dev"}}, wantSubject: "oidc:alice", wantGroups: []string{"oidc:ops", "oidc:dev"}},
+		{name: "no groups claim", wantSubject: "oidc:alice"},
+		{name: "single string group", claims: jwt.MapClaims{"groups": "ops"}, wantSubject: "oidc:alice", wantGroups: []string{"oidc:ops"}},
+		{name: "custom prefixes", cfg: OIDCConfig{UsernamePrefix: "corp:", GroupsPrefix: "corp-group:"}, claims: jwt.MapClaims{"groups": []string{"ops"}}, wantSubject: "corp:alice", wantGroups: []string{"corp-group:ops"}},
+		{name: "no prefixes", cfg: OIDCConfig{UsernamePrefix: NoOIDCPrefix, GroupsPrefix: NoOIDCPrefix}, claims: jwt.MapClaims{"groups": []string{"ops"}}, wantSubject: "alice", wantGroups: []string{"ops"}},
+		{name: "custom username claim", cfg: OIDCConfig{UsernameClaim: "email"}, claims: jwt.MapClaims{"email": "alice@example.com"}, wantSubject: "oidc:alice@example.com"},
+		{name: "nested groups claim", cfg: OIDCConfig{GroupsClaim: "realm_access.roles"}, claims: jwt.MapClaims{"realm_access": map[string]any{"roles": []string{"admin"}}}, wantSubject: "oidc:alice", wantGroups: []string{"oidc:admin"}},
+		{name: "dotted claim name wins over nesting", cfg: OIDCConfig{GroupsClaim: "https://example.com/groups"}, claims: jwt.MapClaims{"https://example.com/groups": []string{"ops"}}, wantSubject: "oidc:alice", wantGroups: []string{"oidc:ops"}},
+		{name: "non-string group", claims: jwt.MapClaims{"groups": []any{"ops", 7}}, wantErr: true},
+		{name: "reserved group", claims: jwt.MapClaims{"groups": []string{"ops", "system:masters"}}, wantErr: true},
+		{name: "reserved subject", claims: jwt.MapClaims{"sub": "serviceaccount:team-a/ci"}, wantErr: true},
+		{name: "reserved unprefixed group", cfg: OIDCConfig{GroupsPrefix: NoOIDCPrefix}, claims: jwt.MapClaims{"groups": []string{"system:authenticated"}}, wantErr: true},
+		{name: "prefix that forms a reserved name", cfg: OIDCConfig{UsernamePrefix: "system:"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestOIDCProvider(t, iss, tt.cfg)
+			session, err := p.ValidateToken(ctx, iss.sign(t, "k1", tt.claims))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			user := session.Principal().User
+			assert.Equal(t, tt.wantSubject, user.Subject)
+			assert.Equal(t, tt.wantGroups, user.Groups)
+		})
+	}
+}
+
+func TestOIDCProvider_KeyRotation(t *testing.T) {
+	ctx := context.Background()
+	iss := newFakeIssuer(t)
+	iss.addKey(t, "old", rsaKey(t))
+	p := newTestOIDCProvider(t, iss, OIDCConfig{})
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	_, err := p.ValidateToken(ctx, iss.sign(t, "old", nil))
+	require.NoError(t, err)
+	require.Equal(t, 1, iss.fetches())
+
+	_, err = p.ValidateToken(ctx, iss.sign(t, "old", nil))
+	require.NoError(t, err)
+	assert.Equal(t, 1, iss.fetches(), "keys are cached")
+
+	iss.addKey(t, "new", rsaKey(t))
+	newToken := iss.sign(t, "new", nil)
+	_, err = p.ValidateToken(ctx, newToken)
+	require.Error(t, err, "an unknown kid does not refetch within the rate limit")
+	assert.Equal(t, 1, iss.fetches())
+
+	now = now.Add(oidcKeysMinRefresh)
+	_, err = p.ValidateToken(ctx, newToken)
+	require.NoError(t, err, "an unknown kid refetches the key set")
+	assert.Equal(t, 2, iss.fetches())
+
+	iss.removeKey("old")
+	now = now.Add(oidcKeysMaxAge)
+	_, err = p.ValidateToken(ctx, iss.sign(t, "new", nil))
+	require.NoError(t, err)
+	assert.Equal(t, 3, iss.fetches(), "the key set is refetched once it ages out")
+	_, err = p.ValidateToken(ctx, iss.sign(t, "old", nil))
+	require.Error(t, err, "a key the issuer stopped publishing no longer verifies")
+
+	iss.Close()
+	now = now.Add(oidcKeysMaxAge)
+	_, err = p.ValidateToken(ctx, iss.sign(t, "new", nil))
+	require.NoError(t, err, "cached keys keep verifying while the issuer is unreachable")
+}
+
+func TestNewOIDCProvider_Validation(t *testing.T) {
+	_, err := NewOIDCProvider(OIDCConfig{Audiences: []string{"registry"}})
+	require.Error(t, err)
+	_, err = NewOIDCProvider(OIDCConfig{IssuerURL: "https://issuer.example.com"})
+	require.Error(t, err)
+}
+
+func TestChainAuthnProviders(t *testing.T) {
+	ctx := context.Background()
+	iss := newFakeIssuer(t)
+	iss.addKey(t, "k1", rsaKey(t))
+	chain := ChainAuthnProviders(rejectAll{}, newTestOIDCProvider(t, iss, OIDCConfig{}))
+
+	session, err := chain.Authenticate(ctx, bearer(iss.sign(t, "k1", nil)), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "oidc:alice", session.Principal().User.Subject)
+
+	_, err = chain.Authenticate(ctx, bearer("garbage"), nil)
+	require.Error(t, err)
+
+	session, err = chain.Authenticate(ctx, func(string) string { return "" }, nil)
+	require.NoError(t, err)
+	assert.Nil(t, session)
+}
+
+// rejectAll rejects every bearer token, like a provider for another
+// token format.
+type rejectAll struct{}
+
+func (rejectAll) Authenticate(_ context.Context, reqHeaders func(string) string, _ url.Values) (Session, error) {
+	if reqHeaders("Authorization") == "" {
+		return nil, nil
+	}
+	return nil, errors.New("not my token")
+}
@@ -0,0 +1,535 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// Groups every caller belongs to under RBAC, so a RoleBinding can grant
+// access to all signed-in or all anonymous callers.
+const (
+	GroupAuthenticated   = "system:authenticated"
+	GroupUnauthenticated = "system:unauthenticated"
+)
+
+// adminGroupPrefix marks a group entry in the RBAC admin list; other
+// entries are user subjects.
+const adminGroupPrefix = "group:"
+
+// rbacPolicyMaxAge bounds how long a loaded policy is trusted when no
+// invalidation arrives, e.g. while the change listener reconnects.
+const rbacPolicyMaxAge = time.Minute
+
+// AccessRequest is one RBAC decision: may the caller perform Verb on the
+// Kind object Namespace/Name? Name is empty for lists, and Namespace is
+// empty for lists across every namespace.
+type AccessRequest struct {
+	Verb      string
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// RBACObjects is the live RBAC configuration.
+type RBACObjects struct {
+	Roles        []*v1alpha1.Role
+	ClusterRoles []*v1alpha1.ClusterRole
+	Bindings     []*v1alpha1.RoleBinding
+}
+
+// RBACSource loads the live Role, ClusterRole and RoleBinding objects.
+type RBACSource interface {
+	LoadRBAC(ctx context.Context) (RBACObjects, error)
+}
+
+// Grant is one PolicyRule scoped to the namespaces it applies in. Nil
+// Namespaces covers every namespace; empty Names covers every name.
+type Grant struct {
+	Verbs      []string
+	Kinds      []string
+	Namespaces []string
+	Names      []string
+}
+
+// ListScope is what a caller may list of one kind: everything when All is
+// set, otherwise the union of the Grants' namespace and name restrictions.
+// A zero ListScope allows nothing.
+type ListScope struct {
+	All    bool
+	Grants []Grant
+}
+
+// RBACAuthzProvider evaluates Role, ClusterRole and RoleBinding objects.
+// The policy is loaded from an RBACSource and cached until Invalidate is
+// called or it is rbacPolicyMaxAge old. Subjects in the admin list, and
+// system sessions, are allowed everything.
+type RBACAuthzProvider struct {
+	source      RBACSource
+	adminUsers  map[string]bool
+	adminGroups map[string]bool
+
+	mu       sync.Mutex
+	policy   *rbacPolicy
+	loadedAt time.Time
+}
+
+var _ AuthzProvider = &RBACAuthzProvider{}
+
+// NewRBACAuthzProvider creates an RBAC provider. Each admin is a user
+// subject, or "group:NAME" for a group; admins bootstrap the first Roles
+// and RoleBindings.
+func NewRBACAuthzProvider(source RBACSource, admins []string) *RBACAuthzProvider {
+	p := &RBACAuthzProvider{
+		source:      source,
+		adminUsers:  map[string]bool{},
+		adminGroups: map[string]bool{},
+	}
+	for _, admin := range admins {
+		admin = strings.TrimSpace(admin)
+		if group, ok := strings.CutPrefix(admin, adminGroupPrefix); ok {
+			p.adminGroups[group] = true
+		} else if admin != "" {
+			p.adminUsers[admin] = true
+		}
+	}
+	return p
+}
+
+// Invalidate drops the cached policy so the next decision reloads it.
+func (p *RBACAuthzProvider) Invalidate() {
+	p.mu.Lock()
+	p.policy = nil
+	p.mu.Unlock()
+}
+
+// Authorize decides req for session s. A denied anonymous caller gets
+// ErrUnauthenticated, a denied signed-in caller ErrForbidden.
+func (p *RBACAuthzProvider) Authorize(ctx context.Context, s Session, req AccessRequest) error {
+	id := identityOf(s)
+	if p.isAdmin(id) {
+		return nil
+	}
+	policy, err := p.load(ctx)
+	if err != nil {
+		return err
+	}
+	for _, grant := range policy.grantsFor(id) {
+		if grant.allows(req) {
+			return nil
+		}
+	}
+	return id.deny("%s %s", req.Verb, describeTarget(req.Kind, req.Namespace, req.Name))
+}
+
+// ListScope returns the rows of kind that s may see through verb (normally
+// "list") in namespace, or across every namespace when namespace is empty.
+func (p *RBACAuthzProvider) ListScope(ctx context.Context, s Session, verb, kind, namespace string) (ListScope, error) {
+	id := identityOf(s)
+	if p.isAdmin(id) {
+		return ListScope{All: true}, nil
+	}
+	policy, err := p.load(ctx)
+	if err != nil {
+		return ListScope{}, err
+	}
+	return listScopeOf(policy.grantsFor(id), verb, kind, namespace), nil
+}
+
+// listScopeOf returns the rows of kind that grants allow through verb in
+// namespace, or across every namespace when namespace is empty.
+func listScopeOf(grants []Grant, verb, kind, namespace string) ListScope {
+	var scope ListScope
+	for _, grant := range grants {
+		if !matches(grant.Verbs, verb) || !matches(grant.Kinds, kind) || (namespace != "" && !grant.coversNamespace(namespace)) {
+			continue
+		}
+		if (grant.Namespaces == nil || namespace != "") && allNames(grant.Names) {
+			return ListScope{All: true}
+		}
+		scope.Grants = append(scope.Grants, grant)
+	}
+	return scope
+}
+
+// AuthorizeGrant refuses writes of Roles, ClusterRoles and RoleBindings
+// that would give anyone access s does not hold itself, so write access to
+// RBAC objects cannot be turned into more access. A RoleBinding is checked
+// against the rules of the role it references, read fresh from the source
+// so a role applied just before its binding is seen. Other kinds pass.
+func (p *RBACAuthzProvider) AuthorizeGrant(ctx context.Context, s Session, obj v1alpha1.Object) error {
+	id := identityOf(s)
+	if p.isAdmin(id) {
+		return nil
+	}
+	var granted []Grant
+	switch o := obj.(type) {
+	case *v1alpha1.Role:
+		granted = roleGrants(o.Spec.Rules, o.Metadata.NamespaceOrDefault())
+	case *v1alpha1.ClusterRole:
+		granted = clusterRoleGrants(o.Spec.Rules)
+	case *v1alpha1.RoleBinding:
+		objs, err := p.source.LoadRBAC(ctx)
+		if err != nil {
+			return fmt.Errorf("load RBAC policy: %w", err)
+		}
+		rules, ok := newRoleIndex(objs).lookup(o)
+		if !ok {
+			return id.deny("bind %s: not found", describeTarget(o.Spec.RoleRef.Kind, roleRefNamespace(o), o.Spec.RoleRef.Name))
+		}
+		granted = rules
+	default:
+		return nil
+	}
+	policy, err := p.load(ctx)
+	if err != nil {
+		return err
+	}
+	held := policy.grantsFor(id)
+	for _, grant := range granted {
+		if atom, ok := firstUncovered(held, grant); !ok {
+			return id.deny("grant %s %s: it is not granted to you", atom.Verb, describeTarget(atom.Kind, atom.Namespace, atom.Name))
+		}
+	}
+	return nil
+}
+
+// Check maps the legacy permission model onto RBAC: read is get; publish,
+// edit and deploy are apply; delete is delete. The resource is evaluated
+// in the default namespace.
+func (p *RBACAuthzProvider) Check(ctx context.Context, s Session, verb PermissionAction, resource Resource) error {
+	kind, ok := permissionKinds[resource.Type]
+	if !ok {
+		if p.IsRegistryAdmin(ctx, s) {
+			return nil
+		}
+		return identityOf(s).deny("%s %s %q", verb, resource.Type, resource.Name)
+	}
+	return p.Authorize(ctx, s, AccessRequest{
+		Verb:      permissionVerbs[verb],
+		Kind:      kind,
+		Namespace: v1alpha1.DefaultNamespace,
+		Name:      resource.Name,
+	})
+}
+
+// IsRegistryAdmin reports whether s is allowed every verb on every kind in
+// every namespace. Scoped sessions never are.
+func (p *RBACAuthzProvider) IsRegistryAdmin(ctx context.Context, s Session) bool {
+	if _, scoped := s.(ScopedSession); scoped {
+		return false
+	}
+	id := identityOf(s)
+	if p.isAdmin(id) {
+		return true
+	}
+	policy, err := p.load(ctx)
+	if err != nil {
+		slog.Warn("RBAC policy unavailable", "error", err)
+		return false
+	}
+	for _, grant := range policy.grantsFor(id) {
+		if matches(grant.Verbs, v1alpha1.RBACWildcard) && matches(grant.Kinds, v1alpha1.RBACWildcard) && grant.Namespaces == nil && allNames(grant.Names) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RBACAuthzProvider) isAdmin(id rbacIdentity) bool {
+	if id.system || p.adminUsers[id.user] && id.user != "" {
+		return true
+	}
+	for _, group := range id.groups {
+		if p.adminGroups[group] {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RBACAuthzProvider) load(ctx context.Context) (*rbacPolicy, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.policy != nil && time.Since(p.loadedAt) < rbacPolicyMaxAge {
+		return p.policy, nil
+	}
+	objs, err := p.source.LoadRBAC(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load RBAC policy: %w", err)
+	}
+	p.policy = compileRBAC(objs)
+	p.loadedAt = time.Now()
+	return p.policy, nil
+}
+
+var permissionVerbs = map[PermissionAction]string{
+	PermissionActionRead:    v1alpha1.RBACVerbGet,
+	PermissionActionPublish: v1alpha1.RBACVerbApply,
+	PermissionActionEdit:    v1alpha1.RBACVerbApply,
+	PermissionActionDelete:  v1alpha1.RBACVerbDelete,
+	PermissionActionDeploy:  v1alpha1.RBACVerbApply,
+}
+
+var permissionKinds = map[PermissionArtifactType]string{
+	PermissionArtifactTypeAgent:   v1alpha1.KindAgent,
+	PermissionArtifactTypeSkill:   v1alpha1.KindSkill,
+	PermissionArtifactTypeServer:  v1alpha1.KindMCPServer,
+	PermissionArtifactTypePrompt:  v1alpha1.KindPrompt,
+	PermissionArtifactTypeRuntime: v1alpha1.KindRuntime,
+}
+
+// rbacIdentity is the part of a session RBAC evaluates.
+type rbacIdentity struct {
+	user          string
+	groups        []string
+	system        bool
+	authenticated bool
+}
+
+func identityOf(s Session) rbacIdentity {
+	if s == nil || IsPublicSession(s) {
+		return rbacIdentity{groups: []string{GroupUnauthenticated}}
+	}
+	if IsSystemSession(s) {
+		return rbacIdentity{system: true, authenticated: true}
+	}
+	user := s.Principal().User
+	return rbacIdentity{
+		user:          user.Subject,
+		groups:        append(slices.Clone(user.Groups), GroupAuthenticated),
+		authenticated: true,
+	}
+}
+
+// deny builds the error for a refused request.
+func (id rbacIdentity) deny(format string, args ...any) error {
+	action := fmt.Sprintf(format, args...)
+	if !id.authenticated {
+		return fmt.Errorf("%w: anonymous callers cannot %s", ErrUnauthenticated, action)
+	}
+	who := id.user
+	if who == "" {
+		who = "caller"
+	}
+	return fmt.Errorf("%w: %q cannot %s", ErrForbidden, who, action)
+}
+
+func describeTarget(kind, namespace, name string) string {
+	switch {
+	case name != "" && namespace != "":
+		return fmt.Sprintf("%s %s/%s", kind, namespace, name)
+	case name != "":
+		return fmt.Sprintf("%s %s", kind, name)
+	case namespace != "":
+		return fmt.Sprintf("%s in namespace %s", kind, namespace)
+	}
+	return kind
+}
+
+// rbacPolicy is a compiled RBACObjects: the grants each subject holds.
+type rbacPolicy struct {
+	users  map[string][]Grant
+	groups map[string][]Grant
+}
+
+func compileRBAC(objs RBACObjects) *rbacPolicy {
+	policy := &rbacPolicy{users: map[string][]Grant{}, groups: map[string][]Grant{}}
+	roles := newRoleIndex(objs)
+	for _, binding := range objs.Bindings {
+		grants, ok := roles.lookup(binding)
+		if !ok {
+			continue
+		}
+		for _, subject := range binding.Spec.Subjects {
+			switch subject.Kind {
+			case v1alpha1.SubjectKindUser:
+				policy.users[subject.Name] = append(policy.users[subject.Name], grants...)
+			case v1alpha1.SubjectKindGroup:
+				policy.groups[subject.Name] = append(policy.groups[subject.Name], grants...)
+			}
+		}
+	}
+	return policy
+}
+
+func (p *rbacPolicy) grantsFor(id rbacIdentity) []Grant {
+	var grants []Grant
+	if id.user != "" {
+		grants = append(grants, p.users[id.user]...)
+	}
+	for _, group := range id.groups {
+		grants = append(grants, p.groups[group]...)
+	}
+	return grants
+}
+
+// roleIndex resolves a RoleBinding's roleRef to grants.
+type roleIndex struct {
+	roles        map[string]*v1alpha1.Role
+	clusterRoles map[string]*v1alpha1.ClusterRole
+}
+
+func newRoleIndex(objs RBACObjects) roleIndex {
+	idx := roleIndex{roles: map[string]*v1alpha1.Role{}, clusterRoles: map[string]*v1alpha1.ClusterRole{}}
+	for _, role := range objs.Roles {
+		idx.roles[role.Metadata.NamespaceOrDefault()+"/"+role.Metadata.Name] = role
+	}
+	for _, role := range objs.ClusterRoles {
+		idx.clusterRoles[role.Metadata.Name] = role
+	}
+	return idx
+}
+
+func (idx roleIndex) lookup(binding *v1alpha1.RoleBinding) ([]Grant, bool) {
+	ref := binding.Spec.RoleRef
+	switch ref.Kind {
+	case v1alpha1.KindRole:
+		role, ok := idx.roles[roleRefNamespace(binding)+"/"+ref.Name]
+		if !ok {
+			return nil, false
+		}
+		return roleGrants(role.Spec.Rules, role.Metadata.NamespaceOrDefault()), true
+	case v1alpha1.KindClusterRole:
+		role, ok := idx.clusterRoles[ref.Name]
+		if !ok {
+			return nil, false
+		}
+		return clusterRoleGrants(role.Spec.Rules), true
+	}
+	return nil, false
+}
+
+// roleRefNamespace is the namespace binding's roleRef resolves in.
+func roleRefNamespace(binding *v1alpha1.RoleBinding) string {
+	if binding.Spec.RoleRef.Kind == v1alpha1.KindClusterRole {
+		return ""
+	}
+	return binding.Metadata.NamespaceOrDefault()
+}
+
+func roleGrants(rules []v1alpha1.PolicyRule, namespace string) []Grant {
+	grants := make([]Grant, 0, len(rules))
+	for _, rule := range rules {
+		grants = append(grants, Grant{Verbs: rule.Verbs, Kinds: canonicalKinds(rule.Kinds), Namespaces: []string{namespace}, Names: rule.Names})
+	}
+	return grants
+}
+
+func clusterRoleGrants(rules []v1alpha1.PolicyRule) []Grant {
+	grants := make([]Grant, 0, len(rules))
+	for _, rule := range rules {
+		var namespaces []string
+		if len(rule.Namespaces) > 0 && !slices.Contains(rule.Namespaces, v1alpha1.RBACWildcard) {
+			namespaces = rule.Namespaces
+		}
+		grants = append(grants, Grant{Verbs: rule.Verbs, Kinds: canonicalKinds(rule.Kinds), Namespaces: namespaces, Names: rule.Names})
+	}
+	return grants
+}
+
+// canonicalKinds returns kinds with known kind names in their canonical
+// case. Stored rules are canonical already; an object under apply is
+// authorized before validation canonicalizes it.
+func canonicalKinds(kinds []string) []string {
+	out := make([]string, len(kinds))
+	for i, kind := range kinds {
+		out[i] = kind
+		if descriptor, ok := v1alpha1.KindDescriptorFor(kind); ok {
+			out[i] = descriptor.Kind
+		}
+	}
+	return out
+}
+
+// allows reports whether g permits req. A list without a namespace needs
+// only the verb and kind: the list filter narrows the rows.
+func (g Grant) allows(req AccessRequest) bool {
+	if !matches(g.Verbs, req.Verb) || !matches(g.Kinds, req.Kind) {
+		return false
+	}
+	if req.Name == "" {
+		return req.Namespace == "" || g.coversNamespace(req.Namespace)
+	}
+	return g.coversNamespace(req.Namespace) && g.coversName(req.Name)
+}
+
+// coversNamespace reports whether g applies in namespace; "" (every
+// namespace) is covered only by an unrestricted grant.
+func (g Grant) coversNamespace(namespace string) bool {
+	return g.Namespaces == nil || namespace != "" && slices.Contains(g.Namespaces, namespace)
+}
+
+func (g Grant) coversName(name string) bool {
+	if allNames(g.Names) {
+		return true
+	}
+	for _, pattern := range g.Names {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(name, prefix) || pattern == name {
+			return true
+		}
+	}
+	return false
+}
+
+func matches(values []string, value string) bool {
+	return slices.Contains(values, v1alpha1.RBACWildcard) || slices.Contains(values, value)
+}
+
+func allNames(names []string) bool {
+	return len(names) == 0 || slices.Contains(names, v1alpha1.RBACWildcard)
+}
+
+// firstUncovered splits grant into single verb, kind, namespace and name
+// pattern requests and returns the first one no held grant covers. A
+// wildcard in grant is covered only by a wildcard.
+func firstUncovered(held []Grant, grant Grant) (AccessRequest, bool) {
+	namespaces := grant.Namespaces
+	if namespaces == nil {
+		namespaces = []string{""}
+	}
+	names := grant.Names
+	if allNames(names) {
+		names = []string{v1alpha1.RBACWildcard}
+	}
+	for _, verb := range grant.Verbs {
+		for _, kind := range grant.Kinds {
+			for _, namespace := range namespaces {
+				for _, name := range names {
+					atom := AccessRequest{Verb: verb, Kind: kind, Namespace: namespace, Name: name}
+					if !slices.ContainsFunc(held, func(h Grant) bool { return h.covers(atom) }) {
+						return atom, false
+					}
+				}
+			}
+		}
+	}
+	return AccessRequest{}, true
+}
+
+// covers reports whether g allows everything atom may match. atom.Name is
+// a name pattern; atom.Namespace "" stands for every namespace.
+func (g Grant) covers(atom AccessRequest) bool {
+	if !matches(g.Verbs, atom.Verb) || !matches(g.Kinds, atom.Kind) || !g.coversNamespace(atom.Namespace) {
+		return false
+	}
+	if allNames(g.Names) {
+		return true
+	}
+	if atom.Name == v1alpha1.RBACWildcard {
+		return false
+	}
+	literal := strings.TrimSuffix(atom.Name, "*")
+	for _, pattern := range g.Names {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(literal, prefix) || pattern == atom.Name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+)
+
+type fakeRBACSource struct {
+	objs  auth.RBACObjects
+	loads int
+}
+
+func (f *fakeRBACSource) LoadRBAC(context.Context) (auth.RBACObjects, error) {
+	f.loads++
+	return f.objs, nil
+}
+
+type userSession struct {
+	subject string
+	groups  []string
+}
+
+func (s userSession) Principal() auth.Principal {
+	return auth.Principal{User: auth.User{Subject: s.subject, Groups: s.groups}}
+}
+
+func role(namespace, name string, rules ...v1alpha1.PolicyRule) *v1alpha1.Role {
+	return &v1alpha1.Role{Metadata: v1alpha1.ObjectMeta{Namespace: namespace, Name: name}, Spec: v1alpha1.RoleSpec{Rules: rules}}
+}
+
+func clusterRole(name string, rules ...v1alpha1.PolicyRule) *v1alpha1.ClusterRole {
+	return &v1alpha1.ClusterRole{Metadata: v1alpha1.ObjectMeta{Namespace: v1alpha1.DefaultNamespace, Name: name}, Spec: v1alpha1.RoleSpec{Rules: rules}}
+}
+
+func binding(namespace, name, roleKind, roleName string, subjects ...v1alpha1.Subject) *v1alpha1.RoleBinding {
+	return &v1alpha1.RoleBinding{
+		Metadata: v1alpha1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:     v1alpha1.RoleBindingSpec{RoleRef: v1alpha1.RoleRef{Kind: roleKind, Name: roleName}, Subjects: subjects},
+	}
+}
+
+func user(name string) v1alpha1.Subject {
+	return v1alpha1.Subject{Kind: v1alpha1.SubjectKindUser, Name: name}
+}
+
+func group(name string) v1alpha1.Subject {
+	return v1alpha1.Subject{Kind: v1alpha1.SubjectKindGroup, Name: name}
+}
+
+func testPolicy() *fakeRBACSource {
+	return &fakeRBACSource{objs: auth.RBACObjects{
+		Roles: []*v1alpha1.Role{
+			role("team-a", "publisher", v1alpha1.PolicyRule{Verbs: []string{"get", "list", "apply"}, Kinds: []string{v1alpha1.KindAgent}, Names: []string{"support-*"}}),
+		},
+		ClusterRoles: []*v1alpha1.ClusterRole{
+			clusterRole("viewer", v1alpha1.PolicyRule{Verbs: []string{"get", "list"}, Kinds: []string{"*"}, Namespaces: []string{"public"}}),
+			clusterRole("admin", v1alpha1.PolicyRule{Verbs: []string{"*"}, Kinds: []string{"*"}}),
+		},
+		Bindings: []*v1alpha1.RoleBinding{
+			binding("team-a", "publishers", v1alpha1.KindRole, "publisher", user("alice")),
+			binding("default", "everyone-views", v1alpha1.KindClusterRole, "viewer", group(auth.GroupUnauthenticated), group(auth.GroupAuthenticated)),
+			binding("default", "ops-admins", v1alpha1.KindClusterRole, "admin", group("ops")),
+		},
+	}}
+}
+
+func TestRBACAuthzProvider_Authorize(t *testing.T) {
+	ctx := context.Background()
+	p := auth.NewRBACAuthzProvider(testPolicy(), []string{"root", "group:breakglass"})
+	alice := userSession{subject: "alice"}
+
+	tests := []struct {
+		name    string
+		session auth.Session
+		req     auth.AccessRequest
+		wantErr error
+	}{
+		{name: "role grants matching name", session: alice, req: auth.AccessRequest{Verb: "apply", Kind: v1alpha1.KindAgent, Namespace: "team-a", Name: "support-bot"}},
+		{name: "role does not grant other names", session: alice, req: auth.AccessRequest{Verb: "apply", Kind: v1alpha1.KindAgent, Namespace: "team-a", Name: "billing-bot"}, wantErr: auth.ErrForbidden},
+		{name: "role does not grant other namespaces", session: alice, req: auth.AccessRequest{Verb: "get", Kind: v1alpha1.KindAgent, Namespace: "team-b", Name: "support-bot"}, wantErr: auth.ErrForbidden},
+		{name: "role does not grant other verbs", session: alice, req: auth.AccessRequest{Verb: "delete", Kind: v1alpha1.KindAgent, Namespace: "team-a", Name: "support-bot"}, wantErr: auth.ErrForbidden},
+		{name: "list in a granted namespace", session: alice, req: auth.AccessRequest{Verb: "list", Kind: v1alpha1.KindAgent, Namespace: "team-a"}},
+		{name: "list across namespaces is filtered later", session: alice, req: auth.AccessRequest{Verb: "list", Kind: v1alpha1.KindAgent}},
+		{name: "anonymous reads public", session: &auth.PublicSession{}, req: auth.AccessRequest{Verb: "get", Kind: v1alpha1.KindSkill, Namespace: "public", Name: "x"}},
+		{name: "anonymous denied elsewhere", session: &auth.PublicSession{}, req: auth.AccessRequest{Verb: "get", Kind: v1alpha1.KindSkill, Namespace: "team-a", Name: "x"}, wantErr: auth.ErrUnauthenticated},
+		{name: "nil session is anonymous", session: nil, req: auth.AccessRequest{Verb: "apply", Kind: v1alpha1.KindSkill, Namespace: "public", Name: "x"}, wantErr: auth.ErrUnauthenticated},
+		{name: "group binding", session: userSession{subject: "bob", groups: []string{"ops"}}, req: auth.AccessRequest{Verb: "delete", Kind: v1alpha1.KindRoleBinding, Namespace: "team-z", Name: "x"}},
+		{name: "admin user", session: userSession{subject: "root"}, req: auth.AccessRequest{Verb: "delete", Kind: v1alpha1.KindAgent, Namespace: "team-z", Name: "x"}},
+		{name: "admin group", session: userSession{subject: "carol", groups: []string{"breakglass"}}, req: auth.AccessRequest{Verb: "delete", Kind: v1alpha1.KindAgent, Namespace: "team-z", Name: "x"}},
+		{name: "system session", session: &auth.SystemSession{}, req: auth.AccessRequest{Verb: "delete", Kind: v1alpha1.KindAgent, Namespace: "team-z", Name: "x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.Authorize(ctx, tt.session, tt.req)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRBACAuthzProvider_ListScope(t *testing.T) {
+	ctx := context.Background()
+	p := auth.NewRBACAuthzProvider(testPolicy(), nil)
+
+	scope, err := p.ListScope(ctx, userSession{subject: "alice"}, "list", v1alpha1.KindAgent, "")
+	require.NoError(t, err)
+	assert.False(t, scope.All)
+	require.Len(t, scope.Grants, 2)
+	assert.Equal(t, []string{"team-a"}, scope.Grants[0].Namespaces)
+	assert.Equal(t, []string{"support-*"}, scope.Grants[0].Names)
+	assert.Equal(t, []string{"public"}, scope.Grants[1].Namespaces)
+
+	scope, err = p.ListScope(ctx, userSession{subject: "alice"}, "list", v1alpha1.KindAgent, "public")
+	require.NoError(t, err)
+	assert.True(t, scope.All, "an unrestricted grant in the requested namespace covers the whole list")
+
+	scope, err = p.ListScope(ctx, &auth.PublicSession{}, "list", v1alpha1.KindAgent, "team-a")
+	require.NoError(t, err)
+	assert.False(t, scope.All)
+	assert.Empty(t, scope.Grants)
+
+	scope, err = p.ListScope(ctx, userSession{groups: []string{"ops"}}, "list", v1alpha1.KindAgent, "")
+	require.NoError(t, err)
+	assert.True(t, scope.All)
+}
+
+func TestRBACAuthzProvider_AuthorizeGrant(t *testing.T) {
+	ctx := context.Background()
+	source := testPolicy()
+	source.objs.Roles = append(source.objs.Roles, role("team-a", "everything", v1alpha1.PolicyRule{Verbs: []string{"*"}, Kinds: []string{"*"}}))
+	p := auth.NewRBACAuthzProvider(source, nil)
+	alice := userSession{subject: "alice"}
+
+	narrower := role("team-a", "narrow", v1alpha1.PolicyRule{Verbs: []string{"get"}, Kinds: []string{v1alpha1.KindAgent}, Names: []string{"support-bot-*"}})
+	require.NoError(t, p.AuthorizeGrant(ctx, alice, narrower))
+
+	lowercase := role("team-a", "lower", v1alpha1.PolicyRule{Verbs: []string{"get"}, Kinds: []string{"agent"}, Names: []string{"support-bot"}})
+	require.NoError(t, p.AuthorizeGrant(ctx, alice, lowercase), "kinds are compared canonically before validation")
+
+	wider := role("team-a", "wide", v1alpha1.PolicyRule{Verbs: []string{"get"}, Kinds: []string{v1alpha1.KindAgent}, Names: []string{"*"}})
+	require.ErrorIs(t, p.AuthorizeGrant(ctx, alice, wider), auth.ErrForbidden)
+
+	elsewhere := role("team-b", "narrow", v1alpha1.PolicyRule{Verbs: []string{"get"}, Kinds: []string{v1alpha1.KindAgent}, Names: []string{"support-bot"}})
+	require.ErrorIs(t, p.AuthorizeGrant(ctx, alice, elsewhere), auth.ErrForbidden)
+
+	require.NoError(t, p.AuthorizeGrant(ctx, alice, binding("team-a", "b", v1alpha1.KindRole, "publisher", user("dave"))))
+	require.ErrorIs(t, p.AuthorizeGrant(ctx, alice, binding("team-a", "b", v1alpha1.KindRole, "everything", user("dave"))), auth.ErrForbidden)
+	require.ErrorIs(t, p.AuthorizeGrant(ctx, alice, binding("team-a", "b", v1alpha1.KindRole, "missing", user("dave"))), auth.ErrForbidden)
+
+	ops := userSession{subject: "bob", groups: []string{"ops"}}
+	require.NoError(t, p.AuthorizeGrant(ctx, ops, binding("team-a", "b", v1alpha1.KindRole, "everything", user("dave"))))
+	require.NoError(t, p.AuthorizeGrant(ctx, alice, &v1alpha1.Agent{}), "non-RBAC kinds are not checked")
+}
+
+func TestRBACAuthzProvider_CheckAndAdmin(t *testing.T) {
+	ctx := context.Background()
+	source := testPolicy()
+	p := auth.NewRBACAuthzProvider(source, nil)
+
+	err := p.Check(ctx, userSession{subject: "alice"}, auth.PermissionActionPublish, auth.Resource{Type: auth.PermissionArtifactTypeAgent, Name: "support-bot"})
+	require.True(t, errors.Is(err, auth.ErrForbidden), "publish maps to apply in the default namespace: %v", err)
+	require.NoError(t, p.Check(ctx, userSession{groups: []string{"ops"}}, auth.PermissionActionDelete, auth.Resource{Type: auth.PermissionArtifactTypeServer, Name: "x"}))
+
+	assert.True(t, p.IsRegistryAdmin(ctx, userSession{groups: []string{"ops"}}))
+	assert.False(t, p.IsRegistryAdmin(ctx, userSession{subject: "alice"}))
+	assert.False(t, p.IsRegistryAdmin(ctx, &auth.PublicSession{}))
+
+	loads := source.loads
+	_ = p.IsRegistryAdmin(ctx, userSession{subject: "alice"})
+	assert.Equal(t, loads, source.loads, "policy is cached")
+	p.Invalidate()
+	_ = p.IsRegistryAdmin(ctx, userSession{subject: "alice"})
+	assert.Equal(t, loads+1, source.loads, "Invalidate forces a reload")
+}
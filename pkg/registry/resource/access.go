@@ -0,0 +1,94 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// AccessReviewConfig is the per-server configuration for the access
+// review endpoint.
+type AccessReviewConfig struct {
+	// BasePrefix is the HTTP route prefix shared with the generic resource
+	// handler (e.g. "/v0"). The endpoint mounts at
+	// "{BasePrefix}/auth/can-i".
+	BasePrefix string
+	// Kinds are the kinds the server serves.
+	Kinds []string
+	// Authorizers are the per-kind hooks the resource handlers consult;
+	// see Config.Authorize. Missing keys authorize-allow.
+	Authorizers map[string]func(ctx context.Context, in AuthorizeInput) error
+}
+
+type accessReviewInput struct {
+	Verb      string `query:"verb" required:"true" enum:"get,list,apply,delete" doc:"Verb to check."`
+	Kind      string `query:"kind" required:"true" doc:"Kind to check, case-insensitive; plurals accepted."`
+	Namespace string `query:"namespace" doc:"Namespace to check (default 'default'); 'all' checks a list across every namespace."`
+	Name      string `query:"name" doc:"Object name to check. Empty asks about the kind as a whole."`
+}
+
+type accessReviewOutput struct {
+	Body arv0.AccessReview
+}
+
+// RegisterAccessReview wires GET {BasePrefix}/auth/can-i, which answers
+// whether the caller may perform a verb by running the same Authorize
+// hook the resource handlers run. A denial is reported in the body, not
+// as an error status.
+func RegisterAccessReview(api huma.API, cfg AccessReviewConfig) {
+	huma.Register(api, huma.Operation{
+		OperationID: "can-i",
+		Method:      http.MethodGet,
+		Path:        cfg.BasePrefix + "/auth/can-i",
+		Summary:     "Check whether the caller may perform a verb",
+	}, func(ctx context.Context, in *accessReviewInput) (*accessReviewOutput, error) {
+		kind, ok := accessReviewKind(cfg.Kinds, in.Kind)
+		if !ok {
+			return nil, huma.Error400BadRequest("unknown kind " + in.Kind)
+		}
+		ns := resolveNamespace(in.Namespace, in.Verb == "list" && in.Name == "")
+		review := arv0.AccessReview{Verb: in.Verb, Kind: kind, Namespace: ns, Name: in.Name, Allowed: true}
+		authorize := cfg.Authorizers[kind]
+		if authorize == nil {
+			return &accessReviewOutput{Body: review}, nil
+		}
+		err := authorize(ctx, AuthorizeInput{Verb: in.Verb, Kind: kind, Namespace: ns, Name: in.Name})
+		var statusErr huma.StatusError
+		switch {
+		case err == nil:
+		case errors.As(err, &statusErr) && (statusErr.GetStatus() == http.StatusUnauthorized || statusErr.GetStatus() == http.StatusForbidden):
+			review.Allowed = false
+			review.Reason = denialReason(statusErr)
+		default:
+			return nil, err
+		}
+		return &accessReviewOutput{Body: review}, nil
+	})
+}
+
+func accessReviewKind(kinds []string, name string) (string, bool) {
+	i := slices.IndexFunc(kinds, func(kind string) bool {
+		return strings.EqualFold(kind, name) || strings.EqualFold(v1alpha1.PluralFor(kind), name)
+	})
+	if i < 0 {
+		return "", false
+	}
+	return kinds[i], true
+}
+
+// denialReason prefers the detail of a huma error model over its
+// "Title: detail" Error string.
+func denialReason(err huma.StatusError) string {
+	var model *huma.ErrorModel
+	if errors.As(err, &model) && model.Detail != "" {
+		return model.Detail
+	}
+	return err.Error()
+}
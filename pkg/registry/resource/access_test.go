@@ -0,0 +1,65 @@
+package resource_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/require"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+)
+
+func TestRegisterAccessReview(t *testing.T) {
+	var seen []resource.AuthorizeInput
+	_, api := humatest.New(t)
+	resource.RegisterAccessReview(api, resource.AccessReviewConfig{
+		BasePrefix: "/v0",
+		Kinds:      []string{v1alpha1.KindAgent, v1alpha1.KindSkill},
+		Authorizers: map[string]func(ctx context.Context, in resource.AuthorizeInput) error{
+			v1alpha1.KindAgent: func(_ context.Context, in resource.AuthorizeInput) error {
+				seen = append(seen, in)
+				switch in.Name {
+				case "secret":
+					return huma.Error403Forbidden("cannot get Agent default/secret")
+				case "broken":
+					return errors.New("policy unavailable")
+				}
+				return nil
+			},
+		},
+	})
+
+	review := func(query string, wantStatus int) arv0.AccessReview {
+		t.Helper()
+		resp := api.Get("/v0/auth/can-i?" + query)
+		require.Equal(t, wantStatus, resp.Code, resp.Body.String())
+		var out arv0.AccessReview
+		if wantStatus == http.StatusOK {
+			require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+		}
+		return out
+	}
+
+	got := review("verb=get&kind=agents&name=public", http.StatusOK)
+	require.Equal(t, arv0.AccessReview{Verb: "get", Kind: v1alpha1.KindAgent, Namespace: v1alpha1.DefaultNamespace, Name: "public", Allowed: true}, got)
+
+	got = review("verb=get&kind=Agent&name=secret", http.StatusOK)
+	require.False(t, got.Allowed)
+	require.Equal(t, "cannot get Agent default/secret", got.Reason)
+
+	got = review("verb=list&kind=agent&namespace=all", http.StatusOK)
+	require.True(t, got.Allowed)
+	require.Equal(t, resource.AuthorizeInput{Verb: "list", Kind: v1alpha1.KindAgent}, seen[len(seen)-1])
+
+	require.True(t, review("verb=delete&kind=skill&name=x", http.StatusOK).Allowed, "kinds without an authorizer are allowed")
+	review("verb=get&kind=agent&name=broken", http.StatusInternalServerError)
+	review("verb=get&kind=widget", http.StatusBadRequest)
+	review("verb=publish&kind=agent", http.StatusUnprocessableEntity)
+}
@@ -0,0 +1,157 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+type promoteTagInput struct {
+	Namespace string `query:"namespace" doc:"Namespace (internal; defaults to 'default')."`
+	Name      string `path:"name"`
+	Body      arv0.PromoteTagRequest
+}
+
+type promoteTagOutput struct {
+	Body arv0.PromoteTagResponse
+}
+
+type listAliasesOutput struct {
+	Body arv0.TagAliasListResponse
+}
+
+type deleteAliasInput struct {
+	Namespace string `query:"namespace" doc:"Namespace (internal; defaults to 'default')."`
+	Name      string `path:"name"`
+	Alias     string `path:"alias"`
+}
+
+// registerTagAliases wires the alias endpoints for a tagged-artifact kind:
+//
+//	POST   {itemPath}/promote          create or move an alias
+//	GET    {itemPath}/aliases          list aliases of one name
+//	DELETE {itemPath}/aliases/{alias}  delete one alias
+//
+// Must run before registerGetTagged so the literal "aliases" segment wins
+// over the `{tag}` capture, like registerListTags.
+func registerTagAliases(api huma.API, cfg Config, kind, itemPath string) {
+	huma.Register(api, huma.Operation{
+		OperationID: "promote-tag-" + strings.ToLower(kind),
+		Method:      http.MethodPost,
+		Path:        itemPath + "/promote",
+		Summary:     fmt.Sprintf("Point a %s tag alias at a concrete tag", kind),
+	}, func(ctx context.Context, in *promoteTagInput) (*promoteTagOutput, error) {
+		ns := resolveNamespace(in.Namespace, false)
+		name, err := unescapePath("name", in.Name)
+		if err != nil {
+			return nil, err
+		}
+		from, to := in.Body.From, in.Body.To
+		if err := v1alpha1.ValidateTag(from); err != nil {
+			return nil, huma.Error400BadRequest("from: " + err.Error())
+		}
+		if err := v1alpha1.ValidateTag(to); err != nil {
+			return nil, huma.Error400BadRequest("to: " + err.Error())
+		}
+		if cfg.Authorize != nil {
+			if err := cfg.Authorize(ctx, AuthorizeInput{Verb: "apply", Kind: kind, Namespace: ns, Name: name, Tag: to}); err != nil {
+				return nil, err
+			}
+		}
+		alias, outcome, err := cfg.Store.PromoteTag(ctx, ns, name, from, to)
+		switch {
+		case errors.Is(err, v1alpha1store.ErrTagConflict):
+			return nil, huma.Error409Conflict(err.Error())
+		case err != nil:
+			return nil, mapNotFound(err, kind, ns, name, from)
+		}
+		out := &promoteTagOutput{}
+		out.Body.TagAlias = tagAliasToWire(alias)
+		switch outcome {
+		case v1alpha1store.UpsertCreated:
+			out.Body.Status = arv0.ApplyStatusCreated
+		case v1alpha1store.UpsertReplaced:
+			out.Body.Status = arv0.ApplyStatusConfigured
+		default:
+			out.Body.Status = arv0.ApplyStatusUnchanged
+		}
+		return out, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-aliases-" + strings.ToLower(kind),
+		Method:      http.MethodGet,
+		Path:        itemPath + "/aliases",
+		Summary:     fmt.Sprintf("List the tag aliases of a %s", kind),
+	}, func(ctx context.Context, in *listTagsInput) (*listAliasesOutput, error) {
+		ns := resolveNamespace(in.Namespace, false)
+		name, err := unescapePath("name", in.Name)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Authorize != nil {
+			if err := cfg.Authorize(ctx, AuthorizeInput{Verb: "list", Kind: kind, Namespace: ns, Name: name}); err != nil {
+				return nil, err
+			}
+		}
+		aliases, err := cfg.Store.ListTagAliases(ctx, ns, name)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("list aliases "+kind, err)
+		}
+		out := &listAliasesOutput{}
+		out.Body.Items = make([]arv0.TagAlias, 0, len(aliases))
+		for _, a := range aliases {
+			out.Body.Items = append(out.Body.Items, tagAliasToWire(a))
+		}
+		return out, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-alias-" + strings.ToLower(kind),
+		Method:        http.MethodDelete,
+		Path:          itemPath + "/aliases/{alias}",
+		Summary:       fmt.Sprintf("Delete a %s tag alias", kind),
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, in *deleteAliasInput) (*deleteOutput, error) {
+		ns := resolveNamespace(in.Namespace, false)
+		name, err := unescapePath("name", in.Name)
+		if err != nil {
+			return nil, err
+		}
+		alias, err := unescapePath("alias", in.Alias)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Authorize != nil {
+			if err := cfg.Authorize(ctx, AuthorizeInput{Verb: "delete", Kind: kind, Namespace: ns, Name: name, Tag: alias}); err != nil {
+				return nil, err
+			}
+		}
+		if err := cfg.Store.DeleteTagAlias(ctx, ns, name, alias); err != nil {
+			if errors.Is(err, pkgdb.ErrNotFound) {
+				return nil, huma.Error404NotFound(fmt.Sprintf("%s %q/%q alias %q not found", kind, ns, name, alias))
+			}
+			return nil, huma.Error500InternalServerError("delete alias "+kind, err)
+		}
+		return &deleteOutput{}, nil
+	})
+}
+
+func tagAliasToWire(a v1alpha1store.TagAlias) arv0.TagAlias {
+	return arv0.TagAlias{
+		Namespace: a.Namespace,
+		Name:      a.Name,
+		Alias:     a.Alias,
+		Target:    a.Target,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
@@ -26,6 +26,11 @@ type ApplyConfig struct {
 	Stores map[string]*v1alpha1store.Store
 	// Resolver is forwarded to each decoded object's ResolveRefs.
 	Resolver v1alpha1.ResolverFunc
+	// Referrers, when set, makes DELETE refuse documents whose target is
+	// still referenced unless ?force=true or ?cascade=true is passed; see
+	// Config.Referrers. Cascades delete dependents through Stores and the
+	// per-kind hooks below.
+	Referrers v1alpha1.ReferrersFunc
 	// RegistryValidator is forwarded to each decoded object's
 	// ValidateRegistries. Nil skips external-registry validation.
 	RegistryValidator v1alpha1.RegistryValidatorFunc
@@ -90,6 +95,15 @@ type applyInput struct {
 	RawBody []byte `contentType:"application/yaml" doc:"Multi-document YAML stream of v1alpha1 resources."`
 }
 
+// deleteBatchInput is applyInput plus the referential-integrity flags
+// DELETE accepts.
+type deleteBatchInput struct {
+	DryRun  bool   `query:"dryRun" doc:"Run validation without mutating the store. Defaults to false."`
+	Force   bool   `query:"force" doc:"Delete even if other objects still reference a target."`
+	Cascade bool   `query:"cascade" doc:"Also delete every object that references a target, dependents first."`
+	RawBody []byte `contentType:"application/yaml" doc:"Multi-document YAML stream of v1alpha1 resources."`
+}
+
 type applyOutput struct {
 	Body arv0.ApplyResultsResponse
 }
@@ -103,7 +117,9 @@ type applyOutput struct {
 // DELETE: for each document, calls Store.Delete on the named resource. Tagged
 // artifacts use metadata.tag when supplied; omitted tag deletes all tags for
 // that namespace/name. Mutable objects delete by namespace/name. Validation
-// still runs so clients get the same error surface as apply.
+// still runs so clients get the same error surface as apply. With Referrers
+// set, a document whose target is still referenced fails unless ?force=true
+// or ?cascade=true is passed.
 //
 // Both endpoints always return 200 with a per-document Results slice;
 // document-level failures are surfaced as Status="failed" entries and
@@ -121,7 +137,9 @@ func RegisterApply(api huma.API, cfg ApplyConfig) {
 		Path:        cfg.BasePrefix + "/apply",
 		Summary:     "Apply a multi-doc YAML stream of v1alpha1 resources",
 	}, func(ctx context.Context, in *applyInput) (*applyOutput, error) {
-		return runApplyBatch(ctx, cfg, scheme, in, false), nil
+		return runApplyBatch(scheme, in.RawBody, func(obj v1alpha1.Object) arv0.ApplyResult {
+			return applyOne(ctx, cfg, obj, in.DryRun)
+		}), nil
 	})
 
 	huma.Register(api, huma.Operation{
@@ -129,14 +147,22 @@ func RegisterApply(api huma.API, cfg ApplyConfig) {
 		Method:      http.MethodDelete,
 		Path:        cfg.BasePrefix + "/apply",
 		Summary:     "Delete v1alpha1 resources identified by a multi-doc YAML stream",
-	}, func(ctx context.Context, in *applyInput) (*applyOutput, error) {
-		return runApplyBatch(ctx, cfg, scheme, in, true), nil
+	}, func(ctx context.Context, in *deleteBatchInput) (*applyOutput, error) {
+		mode := deleteMode{Force: in.Force, Cascade: in.Cascade}
+		if err := mode.validate(); err != nil {
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		return runApplyBatch(scheme, in.RawBody, func(obj v1alpha1.Object) arv0.ApplyResult {
+			return deleteOne(ctx, cfg, obj, in.DryRun, mode)
+		}), nil
 	})
 }
 
-func runApplyBatch(ctx context.Context, cfg ApplyConfig, scheme *v1alpha1.Scheme, in *applyInput, del bool) *applyOutput {
+// runApplyBatch decodes a multi-doc stream and runs each document through
+// each, collecting one result per document.
+func runApplyBatch(scheme *v1alpha1.Scheme, body []byte, each func(obj v1alpha1.Object) arv0.ApplyResult) *applyOutput {
 	out := &applyOutput{}
-	docs, err := scheme.DecodeMulti(in.RawBody)
+	docs, err := scheme.DecodeMulti(body)
 	if err != nil {
 		out.Body.Results = []arv0.ApplyResult{{
 			Status: arv0.ApplyStatusFailed,
@@ -154,11 +180,7 @@ func runApplyBatch(ctx context.Context, cfg ApplyConfig, scheme *v1alpha1.Scheme
 			})
 			continue
 		}
-		if del {
-			out.Body.Results = append(out.Body.Results, deleteOne(ctx, cfg, obj, in.DryRun))
-		} else {
-			out.Body.Results = append(out.Body.Results, applyOne(ctx, cfg, obj, in.DryRun))
-		}
+		out.Body.Results = append(out.Body.Results, each(obj))
 	}
 	return out
 }
@@ -174,7 +196,7 @@ func ApplyObject(ctx context.Context, cfg ApplyConfig, obj v1alpha1.Object, dryR
 // DeleteObject runs one already-decoded object through the same production
 // delete path used by DELETE /v0/apply.
 func DeleteObject(ctx context.Context, cfg ApplyConfig, obj v1alpha1.Object, dryRun bool) arv0.ApplyResult {
-	return deleteOne(ctx, cfg, obj, dryRun)
+	return deleteOne(ctx, cfg, obj, dryRun, deleteMode{})
 }
 
 // applyOne runs a single document through the shared apply pipeline.
@@ -191,6 +213,7 @@ func applyOne(ctx context.Context, cfg ApplyConfig, obj v1alpha1.Object, dryRun
 		return failResult(res, ae)
 	}
 
+	ctx, warnings := v1alpha1.WithWarnings(ctx)
 	admitted, ae := applyCore(ctx, store, obj, applyOpts{
 		Authorize:         batchAuthorize(cfg, obj.GetKind()),
 		Resolver:          cfg.Resolver,
@@ -211,6 +234,7 @@ func applyOne(ctx context.Context, cfg ApplyConfig, obj v1alpha1.Object, dryRun
 	}
 	res.Tag = admitted.Tag
 	res.Generation = admitted.Generation
+	res.Warnings = warnings()
 	return res
 }
 
@@ -224,7 +248,7 @@ func applyOne(ctx context.Context, cfg ApplyConfig, obj v1alpha1.Object, dryRun
 // deletes every tag for (namespace, name); setting metadata.tag deletes that
 // exact tag. Mutable-object rows keep their single-row delete since those rows
 // are control-plane state rather than append-only tags.
-func deleteOne(ctx context.Context, cfg ApplyConfig, obj v1alpha1.Object, dryRun bool) arv0.ApplyResult {
+func deleteOne(ctx context.Context, cfg ApplyConfig, obj v1alpha1.Object, dryRun bool, mode deleteMode) arv0.ApplyResult {
 	store, meta, ae := resolveBatchTarget(cfg, obj, "delete")
 	res := arv0.ApplyResult{
 		APIVersion: obj.GetAPIVersion(),
@@ -237,11 +261,17 @@ func deleteOne(ctx context.Context, cfg ApplyConfig, obj v1alpha1.Object, dryRun
 	}
 
 	admitted, ae := deleteCore(ctx, store, obj.GetKind(), meta.Namespace, meta.Name, meta.Tag, deleteOpts{
-		Authorize:       batchAuthorize(cfg, obj.GetKind()),
-		PostDelete:      cfg.PostDeletes[obj.GetKind()],
-		PreDeleteObject: obj,
-		DeleteAdmission: cfg.DeleteAdmission,
-		Source:          cfg.Source,
+		Authorize:           batchAuthorize(cfg, obj.GetKind()),
+		PostDelete:          cfg.PostDeletes[obj.GetKind()],
+		PreDeleteObject:     obj,
+		DeleteAdmission:     cfg.DeleteAdmission,
+		Source:              cfg.Source,
+		Referrers:           cfg.Referrers,
+		ReferrerAuthorizers: cfg.Authorizers,
+		Mode:                mode,
+		DeleteDependent: func(ctx context.Context, ref v1alpha1.ResourceRef, dryRun bool) error {
+			return batchDeleteDependent(ctx, cfg, ref, dryRun)
+		},
 	}, dryRun)
 	if ae != nil {
 		return failResult(res, ae)
@@ -298,6 +328,25 @@ func resolveBatchTarget(cfg ApplyConfig, obj v1alpha1.Object, verb string) (*v1a
 	return store, *meta, nil
 }
 
+// batchDeleteDependent deletes one dependent of a cascading batch delete
+// with the per-kind hooks of its own kind, or with dryRun only checks that
+// it could. A kind without an authorizer is refused when any are wired, as
+// in resolveBatchTarget.
+func batchDeleteDependent(ctx context.Context, cfg ApplyConfig, ref v1alpha1.ResourceRef, dryRun bool) error {
+	if len(cfg.Authorizers) > 0 && cfg.Authorizers[ref.Kind] == nil {
+		return fmt.Errorf("forbidden: no authorizer wired for kind %q", ref.Kind)
+	}
+	if ae := deleteDependent(ctx, cfg.Stores[ref.Kind], ref, deleteOpts{
+		Authorize:       batchAuthorize(cfg, ref.Kind),
+		PostDelete:      cfg.PostDeletes[ref.Kind],
+		DeleteAdmission: cfg.DeleteAdmission,
+		Source:          cfg.Source,
+	}, dryRun); ae != nil {
+		return ae
+	}
+	return nil
+}
+
 // batchAuthorize returns the per-kind authz callback wrapped to match
 // applyCore's Authorize signature. Returns nil when no authorizers are
 // wired (the OSS-default permissive path).
@@ -320,6 +369,8 @@ func failResult(res arv0.ApplyResult, ae *applyError) arv0.ApplyResult {
 		if ae.Terminating {
 			res.Error = fmt.Sprintf("object %s/%s is terminating; delete + re-apply once GC purges the row",
 				res.Namespace, res.Name)
+		} else if ae.Conflict {
+			res.Error = "conflict: " + ae.Err.Error()
 		} else {
 			res.Error = "upsert: " + ae.Err.Error()
 		}
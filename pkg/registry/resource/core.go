@@ -44,18 +44,23 @@ const (
 	stagePostUpsert applyStage = "post-upsert"
 	stageDelete     applyStage = "delete"
 	stagePostDelete applyStage = "post-delete"
+	stageDependents applyStage = "dependents"
 )
 
 // applyError is the typed error applyCore + deleteCore return.
 // Stage drives caller-side response shaping; Terminating distinguishes
 // the soft-delete-in-progress case from generic upsert failures so
-// callers can map it to 409 instead of 500. NotFound mirrors the same
-// for delete-against-missing-row.
+// callers can map it to 409 instead of 500. Conflict does the same for
+// a tag name already taken by a tag alias. NotFound mirrors the same
+// for delete-against-missing-row. Dependents lists the objects that
+// blocked a delete by still referencing its target.
 type applyError struct {
 	Stage       applyStage
 	Err         error
 	Terminating bool
+	Conflict    bool
 	NotFound    bool
+	Dependents  []v1alpha1.Referrer
 }
 
 func (e *applyError) Error() string {
@@ -104,7 +109,7 @@ func applyCore(
 	if err := v1alpha1.ValidateObject(obj); err != nil {
 		return types.AdmissionResult{}, &applyError{Stage: stageValidation, Err: err}
 	}
-	if err := v1alpha1.ResolveObjectRefs(ctx, obj, opts.Resolver); err != nil {
+	if err := v1alpha1.ResolveObjectRefs(ctx, obj, keepExistingRefs(store, obj, opts.Resolver)); err != nil {
 		return types.AdmissionResult{}, &applyError{Stage: stageRefs, Err: err}
 	}
 	if err := v1alpha1.ValidateObjectRegistries(ctx, obj, opts.RegistryValidator); err != nil {
@@ -147,6 +152,57 @@ func applyCore(
 	return result, nil
 }
 
+// keepExistingRefs wraps resolver so that a reference to a yanked tag which
+// the stored object already holds is downgraded to a warning: yanking stops
+// new consumers, it does not break re-applying an unchanged manifest. The
+// stored object is only loaded once a yanked reference turns up.
+func keepExistingRefs(store *v1alpha1store.Store, obj v1alpha1.Object, resolver v1alpha1.ResolverFunc) v1alpha1.ResolverFunc {
+	if resolver == nil || store == nil {
+		return resolver
+	}
+	var existing map[v1alpha1.ResourceRef]bool
+	return func(ctx context.Context, ref v1alpha1.ResourceRef) error {
+		err := resolver(ctx, ref)
+		if !errors.Is(err, v1alpha1.ErrYankedRef) {
+			return err
+		}
+		if existing == nil {
+			existing = storedRefs(ctx, store, obj)
+		}
+		if !existing[ref] {
+			return err
+		}
+		v1alpha1.AddWarning(ctx, err.Error())
+		return nil
+	}
+}
+
+// storedRefs returns the references held by the currently stored version of
+// obj, or an empty set when there is none. Refs are collected through
+// v1alpha1.ObjectRefs so they carry the same kind/namespace defaulting as the
+// incoming object's.
+func storedRefs(ctx context.Context, store *v1alpha1store.Store, obj v1alpha1.Object) map[v1alpha1.ResourceRef]bool {
+	refs := map[v1alpha1.ResourceRef]bool{}
+	meta := obj.GetMetadata()
+	raw, err := store.Get(ctx, meta.NamespaceOrDefault(), meta.Name, meta.Tag)
+	if err != nil {
+		return refs
+	}
+	_, newObj, ok := v1alpha1.Default.Lookup(obj.GetKind())
+	if !ok {
+		return refs
+	}
+	stored, ok := newObj().(v1alpha1.Object)
+	if !ok || stored.UnmarshalSpec(raw.Spec) != nil {
+		return refs
+	}
+	stored.SetMetadata(raw.Metadata)
+	for _, fr := range v1alpha1.ObjectRefs(ctx, stored) {
+		refs[fr.Ref] = true
+	}
+	return refs
+}
+
 // ProductionAdmission is the OSS admission implementation: dry-runs stop after
 // validation, and real writes upsert the object into the production store and
 // run the per-kind post-upsert hook.
@@ -169,6 +225,7 @@ func ProductionAdmission(ctx context.Context, in types.AdmissionInput) (types.Ad
 			Stage:       stageUpsert,
 			Err:         err,
 			Terminating: errors.Is(err, v1alpha1store.ErrTerminating),
+			Conflict:    errors.Is(err, v1alpha1store.ErrTagConflict),
 		}
 	}
 
@@ -207,17 +264,28 @@ func applyStatusFromUpsert(outcome v1alpha1store.UpsertOutcome) string {
 // passed to PostDelete; callers fill it from a fresh Store.Get
 // (handler.go DELETE) or from the decoded YAML body (apply.go batch
 // delete). When PostDelete is nil, PreDeleteObject is unused.
+//
+// Referrers turns on referential integrity: the delete is refused while
+// other objects reference the target, unless Mode forces it or cascades
+// to the dependents through DeleteDependent (see checkDependents and
+// cascadeDependents).
 type deleteOpts struct {
 	Authorize       func(ctx context.Context, in AuthorizeInput) error
 	PostDelete      func(ctx context.Context, obj v1alpha1.Object) error
 	PreDeleteObject v1alpha1.Object
 	DeleteAdmission types.DeleteAdmission
 	Source          string
+	Referrers       v1alpha1.ReferrersFunc
+	Mode            deleteMode
+	DeleteDependent func(ctx context.Context, ref v1alpha1.ResourceRef, dryRun bool) error
+	// ReferrerAuthorizers decide which dependents a refusal may name; see
+	// Config.ReferrerAuthorizers.
+	ReferrerAuthorizers map[string]func(ctx context.Context, in AuthorizeInput) error
 }
 
-// deleteCore runs Authorize → delete admission for a single resource.
-// Validation is intentionally skipped — deleting a row should not require
-// its spec to validate. The OSS default admission performs Store.DeleteByRef
+// deleteCore runs Authorize → dependents check → delete admission for a
+// single resource. Validation is intentionally skipped — deleting a row
+// should not require its spec to validate. The OSS default admission performs Store.DeleteByRef
 // + PostDelete; downstream implementations may stage or reject the delete.
 //
 // Returns NotFound=true on the missing-row case so callers can map it
@@ -247,25 +315,44 @@ func deleteCore(
 	if admission == nil {
 		admission = ProductionDeleteAdmission
 	}
-	result, err := admission(ctx, types.DeleteAdmissionInput{
-		Source:     source,
-		Verb:       "delete",
-		DryRun:     dryRun,
-		Kind:       kind,
-		Namespace:  namespace,
-		Name:       name,
-		Tag:        tag,
-		Object:     opts.PreDeleteObject,
-		Store:      store,
-		PostDelete: opts.PostDelete,
-	})
-	if err != nil {
-		if ae, ok := err.(*applyError); ok {
+	admit := func(dryRun bool) (types.DeleteAdmissionResult, *applyError) {
+		result, err := admission(ctx, types.DeleteAdmissionInput{
+			Source:     source,
+			Verb:       "delete",
+			DryRun:     dryRun,
+			Kind:       kind,
+			Namespace:  namespace,
+			Name:       name,
+			Tag:        tag,
+			Object:     opts.PreDeleteObject,
+			Store:      store,
+			PostDelete: opts.PostDelete,
+		})
+		if err != nil {
+			if ae, ok := err.(*applyError); ok {
+				return types.DeleteAdmissionResult{}, ae
+			}
+			return types.DeleteAdmissionResult{}, &applyError{Stage: stageAdmission, Err: err}
+		}
+		return result, nil
+	}
+
+	if opts.Referrers != nil && !opts.Mode.Force {
+		dependents, ae := checkDependents(ctx, store, kind, namespace, name, tag, opts)
+		if ae != nil {
 			return types.DeleteAdmissionResult{}, ae
 		}
-		return types.DeleteAdmissionResult{}, &applyError{Stage: stageAdmission, Err: err}
+		if len(dependents) > 0 {
+			admitTarget := func() *applyError {
+				_, ae := admit(true)
+				return ae
+			}
+			if ae := cascadeDependents(ctx, dependents, opts, dryRun, admitTarget); ae != nil {
+				return types.DeleteAdmissionResult{}, ae
+			}
+		}
 	}
-	return result, nil
+	return admit(dryRun)
 }
 
 // ProductionDeleteAdmission is the OSS delete admission implementation. It
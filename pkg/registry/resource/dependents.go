@@ -0,0 +1,262 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// errStillReferenced marks a delete refused because its target still has
+// dependents.
+var errStillReferenced = errors.New("still referenced")
+
+// deleteMode carries the ?force / ?cascade flags of a delete request.
+// Force skips the dependents check; Cascade deletes the dependents first.
+type deleteMode struct {
+	Force   bool
+	Cascade bool
+}
+
+func (m deleteMode) validate() error {
+	if m.Force && m.Cascade {
+		return errors.New("force and cascade are mutually exclusive")
+	}
+	return nil
+}
+
+// checkDependents refuses a delete whose target is still referenced. The
+// target is every tag the delete would remove: the exact tag, every live
+// tag when a tagged delete omits it, or the object itself for mutable
+// kinds. With Mode.Cascade the dependents — and theirs, transitively — are
+// returned instead, ordered so each comes before anything it references,
+// for cascadeDependents to delete. Dependents the caller may not read are
+// counted rather than named, and a cascade that would have to delete one
+// is refused with 403.
+func checkDependents(ctx context.Context, store *v1alpha1store.Store, kind, namespace, name, tag string, opts deleteOpts) ([]v1alpha1.Referrer, *applyError) {
+	targets, err := deleteTargets(ctx, store, kind, namespace, name, tag)
+	if err != nil {
+		return nil, &applyError{Stage: stageDependents, Err: err}
+	}
+	dependents, err := collectDependents(ctx, opts.Referrers, targets, opts.Mode.Cascade)
+	if err != nil {
+		return nil, &applyError{Stage: stageDependents, Err: err}
+	}
+	if len(dependents) == 0 {
+		return nil, nil
+	}
+	visible := make([]v1alpha1.Referrer, 0, len(dependents))
+	for _, d := range dependents {
+		if canReadRef(ctx, opts.ReferrerAuthorizers, d.Object) {
+			visible = append(visible, d)
+		}
+	}
+	hidden := len(dependents) - len(visible)
+	if !opts.Mode.Cascade {
+		labels := make([]string, 0, len(visible)+1)
+		for _, d := range visible {
+			labels = append(labels, refLabel(d.Object))
+		}
+		switch {
+		case hidden == 1:
+			labels = append(labels, "1 object you cannot read")
+		case hidden > 1:
+			labels = append(labels, fmt.Sprintf("%d objects you cannot read", hidden))
+		}
+		return nil, &applyError{
+			Stage:      stageDependents,
+			Err:        fmt.Errorf("%w by %s; delete with force to ignore them or cascade to delete them too", errStillReferenced, strings.Join(labels, ", ")),
+			Dependents: visible,
+		}
+	}
+	if hidden > 0 {
+		return nil, &applyError{Stage: stageDependents, Err: huma.Error403Forbidden("cascade would delete objects you cannot read")}
+	}
+	if opts.DeleteDependent == nil {
+		return nil, &applyError{Stage: stageDependents, Err: errors.New("cascading delete is not configured")}
+	}
+	return dependents, nil
+}
+
+// cascadeDependents deletes the dependents checkDependents returned. Every
+// dependent's delete is first run as a dry run, and then admitTarget runs
+// the target's own delete admission as one, so a cascade that any
+// authorizer or admission would refuse fails before anything is deleted.
+// A dry run stops after the preflight.
+func cascadeDependents(ctx context.Context, dependents []v1alpha1.Referrer, opts deleteOpts, dryRun bool, admitTarget func() *applyError) *applyError {
+	for _, d := range dependents {
+		if err := opts.DeleteDependent(ctx, d.Object, true); err != nil {
+			return &applyError{Stage: stageDependents, Err: fmt.Errorf("cascade delete %s: %w", refLabel(d.Object), err)}
+		}
+	}
+	if dryRun {
+		return nil
+	}
+	if ae := admitTarget(); ae != nil {
+		return ae
+	}
+	for _, d := range dependents {
+		if err := opts.DeleteDependent(ctx, d.Object, false); err != nil {
+			return &applyError{Stage: stageDependents, Err: fmt.Errorf("cascade delete %s: %w", refLabel(d.Object), err)}
+		}
+	}
+	return nil
+}
+
+// deleteTargets returns the concrete refs a delete of (kind, namespace,
+// name, tag) would remove. A missing exact tag yields none; the delete
+// itself reports the 404.
+func deleteTargets(ctx context.Context, store *v1alpha1store.Store, kind, namespace, name, tag string) ([]v1alpha1.ResourceRef, error) {
+	if store.Behavior() != v1alpha1store.TaggedArtifactStore {
+		return []v1alpha1.ResourceRef{{Kind: kind, Namespace: namespace, Name: name}}, nil
+	}
+	if tag != "" {
+		if _, err := store.Get(ctx, namespace, name, tag); err != nil {
+			if errors.Is(err, pkgdb.ErrNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return []v1alpha1.ResourceRef{{Kind: kind, Namespace: namespace, Name: name, Tag: tag}}, nil
+	}
+	rows, err := store.ListTags(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]v1alpha1.ResourceRef, 0, len(rows))
+	for _, row := range rows {
+		targets = append(targets, v1alpha1.ResourceRef{Kind: kind, Namespace: namespace, Name: name, Tag: row.Metadata.Tag})
+	}
+	return targets, nil
+}
+
+// collectDependents lists the objects referencing targets. With transitive
+// set it also follows their referrers, and orders the result so every
+// object comes after everything that references it — the order a cascade
+// must delete in.
+func collectDependents(ctx context.Context, referrers v1alpha1.ReferrersFunc, targets []v1alpha1.ResourceRef, transitive bool) ([]v1alpha1.Referrer, error) {
+	seen := make(map[v1alpha1.ResourceRef]bool, len(targets))
+	for _, t := range targets {
+		seen[t] = true
+	}
+	var out []v1alpha1.Referrer
+	var visit func(ref v1alpha1.ResourceRef) error
+	visit = func(ref v1alpha1.ResourceRef) error {
+		refs, err := referrers(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("list referrers of %s: %w", refLabel(ref), err)
+		}
+		for _, r := range refs {
+			if seen[r.Object] {
+				continue
+			}
+			seen[r.Object] = true
+			if transitive {
+				if err := visit(r.Object); err != nil {
+					return err
+				}
+			}
+			out = append(out, r)
+		}
+		return nil
+	}
+	for _, t := range targets {
+		if err := visit(t); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// deleteDependent deletes one object found by a cascading delete through
+// opts, which carries the per-kind hooks of ref's kind, or with dryRun
+// only checks that it could. The stored row is decoded so PostDelete and
+// delete admission see the real object. The dependent's own referrers are
+// not checked again: the cascade already ordered them ahead of it. An
+// object that is already gone counts as deleted.
+func deleteDependent(ctx context.Context, store *v1alpha1store.Store, ref v1alpha1.ResourceRef, opts deleteOpts, dryRun bool) *applyError {
+	if store == nil {
+		return &applyError{Stage: stageDelete, Err: fmt.Errorf("unknown or unconfigured kind %q", ref.Kind)}
+	}
+	raw, err := store.GetByRef(ctx, ref.Namespace, ref.Name, ref.Tag)
+	if errors.Is(err, pkgdb.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return &applyError{Stage: stageDelete, Err: err}
+	}
+	_, newObj, ok := v1alpha1.Default.Lookup(ref.Kind)
+	if !ok {
+		return &applyError{Stage: stageDelete, Err: fmt.Errorf("unknown kind %q in scheme", ref.Kind)}
+	}
+	obj, err := v1alpha1.EnvelopeFromRaw(func() v1alpha1.Object { return newObj().(v1alpha1.Object) }, raw, ref.Kind)
+	if err != nil {
+		return &applyError{Stage: stageDelete, Err: fmt.Errorf("decode %s: %w", ref.Kind, err)}
+	}
+	opts.PreDeleteObject = obj
+	opts.Referrers = nil
+	_, ae := deleteCore(ctx, store, ref.Kind, ref.Namespace, ref.Name, ref.Tag, opts, dryRun)
+	if ae != nil && ae.NotFound {
+		return nil
+	}
+	return ae
+}
+
+// DependentDeleter returns a Config.DeleteDependent that deletes each
+// dependent of a cascading delete through its own kind's Config, as
+// returned by configFor: that kind's Authorize, DeleteAdmission and
+// PostDelete all apply.
+func DependentDeleter(configFor func(kind string) (Config, bool)) func(ctx context.Context, ref v1alpha1.ResourceRef, dryRun bool) error {
+	return func(ctx context.Context, ref v1alpha1.ResourceRef, dryRun bool) error {
+		cfg, ok := configFor(ref.Kind)
+		if !ok {
+			return fmt.Errorf("unknown or unconfigured kind %q", ref.Kind)
+		}
+		if ae := deleteDependent(ctx, cfg.Store, ref, deleteOpts{
+			Authorize:       cfg.Authorize,
+			PostDelete:      cfg.PostDelete,
+			DeleteAdmission: cfg.DeleteAdmission,
+		}, dryRun); ae != nil {
+			return mapApplyErrorToHuma(ae, ref.Kind, ref.Namespace, ref.Name, ref.Tag)
+		}
+		return nil
+	}
+}
+
+// dependentsError maps a stageDependents failure to its HTTP error: 409
+// listing each dependent the caller may read when the target is still
+// referenced, otherwise the cascade's own failure.
+func dependentsError(ae *applyError, kind string) error {
+	if errors.Is(ae.Err, errStillReferenced) {
+		details := make([]error, 0, len(ae.Dependents))
+		for _, d := range ae.Dependents {
+			details = append(details, &huma.ErrorDetail{
+				Message:  "referenced by " + refLabel(d.Object),
+				Location: d.Path,
+				Value:    d.Object,
+			})
+		}
+		return huma.Error409Conflict(ae.Err.Error(), details...)
+	}
+	var statusErr huma.StatusError
+	if errors.As(ae.Err, &statusErr) {
+		return huma.NewError(statusErr.GetStatus(), ae.Err.Error())
+	}
+	return huma.Error500InternalServerError(kind+" dependents", ae.Err)
+}
+
+// refLabel renders ref as "Kind namespace/name:tag", omitting the tag for
+// mutable kinds.
+func refLabel(ref v1alpha1.ResourceRef) string {
+	label := ref.Kind + " " + ref.Namespace + "/" + ref.Name
+	if ref.Tag != "" {
+		label += ":" + ref.Tag
+	}
+	return label
+}
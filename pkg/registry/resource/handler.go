@@ -6,13 +6,26 @@
 // `?namespace=all` widens list scope to every namespace):
 //
 //	GET    {basePrefix}/{pluralKind}?namespace={ns}                   list
+//	GET    {basePrefix}/{pluralKind}?watch=true&resourceVersion={rv}  watch the list as Server-Sent Events (see Config.Watch)
 //	GET    {basePrefix}/{pluralKind}/{name}?namespace={ns}            get latest
 //	GET    {basePrefix}/{pluralKind}/{name}/tags?namespace={ns}      list tags of one (tagged content kinds only)
-//	GET    {basePrefix}/{pluralKind}/{name}/{tag}?namespace={ns}     get exact tag (tagged content kinds only)
+//	GET    {basePrefix}/{pluralKind}/{name}/aliases?namespace={ns}   list tag aliases of one (tagged content kinds only)
+//	POST   {basePrefix}/{pluralKind}/{name}/promote?namespace={ns}   create or move a tag alias (tagged content kinds only)
+//	DELETE {basePrefix}/{pluralKind}/{name}/aliases/{alias}?namespace={ns} delete a tag alias (tagged content kinds only)
+//	GET    {basePrefix}/{pluralKind}/{name}/{tag}?namespace={ns}     get by tag, alias or semver range (tagged content kinds only)
+//	GET    {basePrefix}/{pluralKind}/{name}/{tag}/revisions?namespace={ns} list revisions of one tag (tagged content kinds only)
+//	POST   {basePrefix}/{pluralKind}/{name}/{tag}/rollback?namespace={ns}  restore an earlier revision (tagged content kinds only)
+//	PUT    {basePrefix}/{pluralKind}/{name}/{tag}/lifecycle?namespace={ns} deprecate, yank or reactivate one tag (tagged content kinds only)
+//	GET    {basePrefix}/{pluralKind}/{name}/{tag}/referrers?namespace={ns} list objects referencing one tag (tagged content kinds only)
+//	GET    {basePrefix}/{pluralKind}/{name}/referrers?namespace={ns} list objects referencing a mutable object
 //	PUT    {basePrefix}/{pluralKind}/{name}?namespace={ns}           apply mutable object (Provider/Deployment/config)
 //	DELETE {basePrefix}/{pluralKind}/{name}?namespace={ns}           delete mutable object
 //	DELETE {basePrefix}/{pluralKind}/{name}/{tag}?namespace={ns}     delete exact tag (tagged content kinds only)
 //
+// When Config.Referrers is set, both DELETE routes refuse with 409 while
+// other objects still reference the target; ?force=true deletes anyway and
+// ?cascade=true deletes the dependents first.
+//
 // Direct PUT is registered only for mutable object stores. Content-registry
 // artifact kinds (Agent, MCPServer, Model, Plugin, Skill, Prompt) use
 // metadata.tag and are
@@ -78,6 +91,20 @@ type Config struct {
 	// as 400 errors. Leave nil to skip registry validation (tests,
 	// offline imports, air-gapped servers).
 	RegistryValidator v1alpha1.RegistryValidatorFunc
+	// Referrers is optional; when set, Register exposes a GET .../referrers
+	// route listing the objects whose refs point at this kind's objects,
+	// and DELETE refuses with 409 while the target is still referenced
+	// unless the caller passes ?force=true or ?cascade=true.
+	Referrers v1alpha1.ReferrersFunc
+	// DeleteDependent deletes one dependent of a ?cascade=true delete,
+	// through that dependent's own kind (see DependentDeleter), or with
+	// dryRun only checks that it could. Nil rejects cascading deletes.
+	DeleteDependent func(ctx context.Context, ref v1alpha1.ResourceRef, dryRun bool) error
+	// ReferrerAuthorizers are every kind's authorizers, keyed by kind. The
+	// referrers route and the 409 of a refused delete name a referrer only
+	// when its own kind's authorizer allows a "get" of it; the rest are
+	// counted, not named. Nil names every referrer.
+	ReferrerAuthorizers map[string]func(ctx context.Context, in AuthorizeInput) error
 
 	// PostUpsert is optional; when set, the apply handler invokes it
 	// after a successful Upsert + read-back so the kind can drive
@@ -120,6 +147,12 @@ type Config struct {
 	// write and surface to the caller.
 	Prepare func(ctx context.Context, obj v1alpha1.Object) error
 
+	// Admission optionally owns the final write of a tag rollback, which
+	// re-applies the restored revision like any other apply. Nil uses
+	// ProductionAdmission, which upserts into the configured Store and runs
+	// PostUpsert.
+	Admission types.Admission
+
 	// DeleteAdmission optionally owns the final delete after authz. Nil uses
 	// ProductionDeleteAdmission, which deletes from the configured Store and
 	// runs PostDelete.
@@ -182,6 +215,11 @@ type Config struct {
 	// the caller can still force inclusion but never exclusion when
 	// the kind has opted in.
 	IncludeTerminatingByDefault bool
+
+	// Watch is optional; when set, the list route serves ?watch=true as a
+	// Server-Sent Events stream of the list's changes (see serveWatch).
+	// Nil rejects watch requests with 400.
+	Watch *WatchSource
 }
 
 // AuthorizeInput is the context passed to Config.Authorize on every handler
@@ -199,10 +237,13 @@ type AuthorizeInput struct {
 	Namespace string
 	// Name is empty for list verbs.
 	Name string
-	// Tag is populated for exact tagged content resource operations.
+	// Tag is populated for exact tagged content resource operations and
+	// carries the alias for tag-alias promote and delete.
 	// Batch delete leaves Tag empty when deleting every tag for a name.
 	Tag string
-	// Object is non-nil only when Verb == "apply"; it carries the decoded
+	// Object is non-nil only when Verb == "apply" (and nil for tag-alias
+	// promotion and tag rollback, which authorize as "apply" on the alias
+	// or tag); it carries the decoded
 	// request body post-validation-stamping (path identity already merged
 	// into metadata), so the hook can inspect labels / annotations / spec
 	// in authz decisions.
@@ -256,11 +297,15 @@ type deleteInput struct {
 	Namespace string `query:"namespace" doc:"Namespace (internal; defaults to 'default')."`
 	Name      string `path:"name"`
 	Tag       string `path:"tag"`
+	Force     bool   `query:"force" doc:"Delete even if other objects still reference the target."`
+	Cascade   bool   `query:"cascade" doc:"Also delete every object that references the target, dependents first."`
 }
 
 type deleteMutableInput struct {
 	Namespace string `query:"namespace" doc:"Namespace (internal; defaults to 'default')."`
 	Name      string `path:"name"`
+	Force     bool   `query:"force" doc:"Delete even if other objects still reference the target."`
+	Cascade   bool   `query:"cascade" doc:"Also delete every object that references the target, dependents first."`
 }
 
 // ListInput defines the common list query parameters used by Huma route inputs.
@@ -268,15 +313,22 @@ type deleteMutableInput struct {
 type ListInput struct {
 	// Namespace scopes the list. Empty / missing → "default";
 	// literal "all" → cross-namespace.
-	Namespace  string `query:"namespace" doc:"Namespace (defaults to 'default'; 'all' lists across all namespaces)."`
-	Limit      int    `query:"limit" doc:"Max items to return (default 50)." default:"50"`
-	Cursor     string `query:"cursor" doc:"Opaque pagination cursor."`
-	Labels     string `query:"labels" doc:"Label selector: key=value,key2=value2."`
-	Tag        string `query:"tag" doc:"Restrict the result set to one tag value (tagged artifact kinds only)."`
-	LatestOnly bool   `query:"latestOnly" doc:"Only return the literal latest tag per (namespace, name). Equivalent to tag=latest for tagged kinds."`
+	Namespace string `query:"namespace" doc:"Namespace (defaults to 'default'; 'all' lists across all namespaces)."`
+	Limit     int    `query:"limit" doc:"Max items to return (default 50)." default:"50"`
+	Cursor    string `query:"cursor" doc:"Opaque pagination cursor."`
+	Labels    string `query:"labels" doc:"Label selector: comma-separated key=value, key!=value, key in (a,b), key notin (a,b), key, !key terms, all of which must match."`
+	// FieldSelector filters on metadata, spec and status fields; see
+	// parseFieldSelector for the supported paths.
+	FieldSelector string `query:"fieldSelector" doc:"Field selector: comma-separated path=value or path!=value terms over metadata.name, metadata.namespace, metadata.tag, status.conditions[Type] or any spec./status. path."`
+	Tag           string `query:"tag" doc:"Restrict the result set to one tag value (tagged artifact kinds only)."`
+	LatestOnly    bool   `query:"latestOnly" doc:"Only return the literal latest tag per (namespace, name). Equivalent to tag=latest for tagged kinds."`
 	// IncludeTerminating surfaces soft-deleted rows (deletionTimestamp != nil)
 	// which are hidden by default.
 	IncludeTerminating bool `query:"includeTerminating" doc:"Include rows with a deletionTimestamp."`
+	// Watch and ResourceVersion are served by the watch middleware before
+	// the list handler runs; they are declared here for the OpenAPI spec.
+	Watch           bool  `query:"watch" doc:"Stream changes to the list as Server-Sent Events (ADDED, MODIFIED, DELETED) instead of returning a page. limit and cursor are ignored."`
+	ResourceVersion int64 `query:"resourceVersion" minimum:"0" doc:"With watch=true, resume after this revision instead of listing current objects first. 410 Gone when the revision is no longer retained."`
 }
 
 type listInput = ListInput
@@ -333,6 +385,9 @@ func Register[T v1alpha1.Object](api huma.API, cfg Config, newObj func() T) {
 		Path:        listPath,
 		Summary:     fmt.Sprintf("List %s (scoped by ?namespace)", kind),
 	}
+	if cfg.Watch != nil {
+		listOperation.Middlewares = huma.Middlewares{watchMiddleware(api, cfg, newObj)}
+	}
 	if cfg.EnableOriginFilter {
 		huma.Register(api, listOperation, func(ctx context.Context, in *listWithOriginInput) (*listOutput[T], error) {
 			return handleList(ctx, cfg, newObj, in.ListInput, in.Origin)
@@ -384,14 +439,23 @@ func Register[T v1alpha1.Object](api huma.API, cfg Config, newObj func() T) {
 	// (routes match in registration order).
 	if v1alpha1.IsTaggedArtifactKind(kind) {
 		registerListTags(api, cfg, newObj, kind, itemPath)
+		registerTagAliases(api, cfg, kind, itemPath)
 	}
 
 	if v1alpha1.IsTaggedArtifactKind(kind) {
 		registerGetTagged(api, cfg, newObj, kind, itemTagPath)
 		registerDeleteTagged(api, cfg, newObj, kind, itemTagPath)
+		registerTagRevisions(api, cfg, newObj, kind, itemTagPath)
+		registerTagLifecycle(api, cfg, kind, itemTagPath)
+		if cfg.Referrers != nil {
+			registerReferrers(api, cfg, kind, itemTagPath)
+		}
 	} else {
 		registerApplyMutable(api, cfg, newObj, kind, itemPath)
 		registerDeleteMutable(api, cfg, newObj, kind, itemPath)
+		if cfg.Referrers != nil {
+			registerReferrers(api, cfg, kind, itemPath)
+		}
 	}
 }
 
@@ -400,7 +464,7 @@ func registerGetTagged[T v1alpha1.Object](api huma.API, cfg Config, newObj func(
 		OperationID: "get-" + strings.ToLower(kind),
 		Method:      http.MethodGet,
 		Path:        itemTagPath,
-		Summary:     fmt.Sprintf("Get a %s by name and tag", kind),
+		Summary:     fmt.Sprintf("Get a %s by name and tag, tag alias or semver range", kind),
 	}, func(ctx context.Context, in *getInput) (*bodyOutput[T], error) {
 		ns := resolveNamespace(in.Namespace, false)
 		name, err := unescapePath("name", in.Name)
@@ -416,7 +480,10 @@ func registerGetTagged[T v1alpha1.Object](api huma.API, cfg Config, newObj func(
 				return nil, err
 			}
 		}
-		row, err := cfg.Store.Get(ctx, ns, name, tag)
+		row, err := cfg.Store.GetByRef(ctx, ns, name, tag)
+		if errors.Is(err, pkgdb.ErrInvalidInput) {
+			return nil, huma.Error400BadRequest(err.Error())
+		}
 		if err != nil {
 			return nil, mapNotFound(err, kind, ns, name, tag)
 		}
@@ -557,7 +624,7 @@ func registerDelete[T v1alpha1.Object](api huma.API, cfg Config, newObj func() T
 			if err != nil {
 				return nil, err
 			}
-			return runDelete(ctx, cfg, newObj, kind, ns, name, tag)
+			return runDelete(ctx, cfg, newObj, kind, ns, name, tag, deleteMode{Force: in.Force, Cascade: in.Cascade})
 		})
 		return
 	}
@@ -567,11 +634,14 @@ func registerDelete[T v1alpha1.Object](api huma.API, cfg Config, newObj func() T
 		if err != nil {
 			return nil, err
 		}
-		return runDeleteLatest(ctx, cfg, newObj, kind, ns, name)
+		return runDeleteLatest(ctx, cfg, newObj, kind, ns, name, deleteMode{Force: in.Force, Cascade: in.Cascade})
 	})
 }
 
-func runDeleteLatest[T v1alpha1.Object](ctx context.Context, cfg Config, newObj func() T, kind, ns, name string) (*deleteOutput, error) {
+func runDeleteLatest[T v1alpha1.Object](ctx context.Context, cfg Config, newObj func() T, kind, ns, name string, mode deleteMode) (*deleteOutput, error) {
+	if err := mode.validate(); err != nil {
+		return nil, huma.Error400BadRequest(err.Error())
+	}
 	// Use the terminating-aware lookup so a repeated DELETE on a row that's
 	// already mid-teardown stays idempotent. Without this the second call
 	// 404s the moment deletion_timestamp lands (GetLatest filters those
@@ -589,7 +659,13 @@ func runDeleteLatest[T v1alpha1.Object](ctx context.Context, cfg Config, newObj
 	if err != nil {
 		return nil, huma.Error500InternalServerError("decode "+kind, err)
 	}
-	dopts := deleteOpts{Authorize: cfg.Authorize}
+	dopts := deleteOpts{
+		Authorize:           cfg.Authorize,
+		Referrers:           cfg.Referrers,
+		ReferrerAuthorizers: cfg.ReferrerAuthorizers,
+		Mode:                mode,
+		DeleteDependent:     cfg.DeleteDependent,
+	}
 	if cfg.PostDelete != nil {
 		dopts.PostDelete = cfg.PostDelete
 	}
@@ -614,7 +690,10 @@ func getLatestForRead(ctx context.Context, cfg Config, ns, name string) (*v1alph
 	return cfg.Store.GetLatest(ctx, ns, name)
 }
 
-func runDelete[T v1alpha1.Object](ctx context.Context, cfg Config, newObj func() T, kind, ns, name, tag string) (*deleteOutput, error) {
+func runDelete[T v1alpha1.Object](ctx context.Context, cfg Config, newObj func() T, kind, ns, name, tag string, mode deleteMode) (*deleteOutput, error) {
+	if err := mode.validate(); err != nil {
+		return nil, huma.Error400BadRequest(err.Error())
+	}
 	var preDelete v1alpha1.Object
 	if cfg.PostDelete != nil {
 		row, err := cfg.Store.Get(ctx, ns, name, tag)
@@ -629,8 +708,12 @@ func runDelete[T v1alpha1.Object](ctx context.Context, cfg Config, newObj func()
 	}
 
 	dopts := deleteOpts{
-		Authorize:       cfg.Authorize,
-		PreDeleteObject: preDelete,
+		Authorize:           cfg.Authorize,
+		PreDeleteObject:     preDelete,
+		Referrers:           cfg.Referrers,
+		ReferrerAuthorizers: cfg.ReferrerAuthorizers,
+		Mode:                mode,
+		DeleteDependent:     cfg.DeleteDependent,
 	}
 	if cfg.PostDelete != nil {
 		dopts.PostDelete = cfg.PostDelete
@@ -659,6 +742,14 @@ func mapApplyErrorToHuma(ae *applyError, kind, ns, name, tag string) error {
 		return huma.Error400BadRequest("registries: " + ae.Err.Error())
 	case stageAdmission:
 		return ae.Err
+	case stagePrepare:
+		// Prepare hooks are server-side transforms; only input they reject
+		// as malformed (for example a Secret placeholder with nothing
+		// stored behind it) is the caller's fault.
+		if errors.Is(ae.Err, v1alpha1.ErrInvalidFormat) {
+			return huma.Error400BadRequest("prepare: " + ae.Err.Error())
+		}
+		return huma.Error500InternalServerError(kind+" prepare", ae.Err)
 	case stageMarshal:
 		return huma.Error400BadRequest("marshal spec: " + ae.Err.Error())
 	case stageUpsert:
@@ -667,6 +758,9 @@ func mapApplyErrorToHuma(ae *applyError, kind, ns, name, tag string) error {
 				"%s %s/%s/%s is terminating; delete + re-apply once GC purges the row",
 				kind, ns, name, tag))
 		}
+		if ae.Conflict {
+			return huma.Error409Conflict(ae.Err.Error())
+		}
 		return huma.Error500InternalServerError("upsert "+kind, ae.Err)
 	case stagePostUpsert:
 		return huma.Error500InternalServerError(kind+" post-upsert", ae.Err)
@@ -677,6 +771,8 @@ func mapApplyErrorToHuma(ae *applyError, kind, ns, name, tag string) error {
 		return huma.Error500InternalServerError("delete "+kind, ae.Err)
 	case stagePostDelete:
 		return huma.Error500InternalServerError(kind+" post-delete", ae.Err)
+	case stageDependents:
+		return dependentsError(ae, kind)
 	}
 	return huma.Error500InternalServerError(kind+" "+string(ae.Stage), ae.Err)
 }
@@ -688,6 +784,7 @@ func mapApplyErrorToHuma(ae *applyError, kind, ns, name, tag string) error {
 type listParams struct {
 	Namespace          string
 	Labels             string
+	FieldSelector      string
 	Limit              int
 	Cursor             string
 	Tag                string
@@ -699,6 +796,9 @@ type listParams struct {
 func handleList[T v1alpha1.Object](
 	ctx context.Context, cfg Config, newObj func() T, in listInput, origin string,
 ) (*listOutput[T], error) {
+	if in.Watch {
+		return nil, huma.Error400BadRequest("watch is not supported for " + cfg.Kind)
+	}
 	ns := resolveNamespace(in.Namespace, true)
 	if cfg.Authorize != nil {
 		if err := cfg.Authorize(ctx, AuthorizeInput{Verb: "list", Kind: cfg.Kind, Namespace: ns}); err != nil {
@@ -708,6 +808,7 @@ func handleList[T v1alpha1.Object](
 	return runList(ctx, cfg, newObj, listParams{
 		Namespace:          ns,
 		Labels:             in.Labels,
+		FieldSelector:      in.FieldSelector,
 		Limit:              in.Limit,
 		Cursor:             in.Cursor,
 		Tag:                in.Tag,
@@ -722,10 +823,38 @@ func handleList[T v1alpha1.Object](
 func runList[T v1alpha1.Object](
 	ctx context.Context, cfg Config, newObj func() T, p listParams,
 ) (*listOutput[T], error) {
+	opts, err := listOptsFor(ctx, cfg, p)
+	if err != nil {
+		return nil, err
+	}
+	rows, nextCursor, err := cfg.Store.List(ctx, opts)
+	if err != nil {
+		if errors.Is(err, v1alpha1store.ErrInvalidCursor) {
+			return nil, huma.Error400BadRequest("invalid cursor")
+		}
+		return nil, huma.Error500InternalServerError("list "+cfg.Kind, err)
+	}
+	items := make([]T, 0, len(rows))
+	for _, row := range rows {
+		obj, err := v1alpha1.EnvelopeFromRaw(newObj, row, cfg.Kind)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("decode "+cfg.Kind, err)
+		}
+		items = append(items, obj)
+	}
+	out := &listOutput[T]{}
+	out.Body.Items = items
+	out.Body.NextCursor = nextCursor
+	return out, nil
+}
+
+// listOptsFor translates p into Store ListOpts, consulting cfg.ListFilter
+// and parsing the label and field selectors. Errors are huma errors.
+func listOptsFor(ctx context.Context, cfg Config, p listParams) (v1alpha1store.ListOpts, error) {
 	switch p.Origin {
 	case "", "managed", "discovered":
 	default:
-		return nil, huma.Error400BadRequest("invalid origin filter: expected managed or discovered")
+		return v1alpha1store.ListOpts{}, huma.Error400BadRequest("invalid origin filter: expected managed or discovered")
 	}
 
 	opts := v1alpha1store.ListOpts{
@@ -736,41 +865,34 @@ func runList[T v1alpha1.Object](
 		LatestOnly:         p.LatestOnly,
 		IncludeTerminating: p.IncludeTerminating || cfg.IncludeTerminatingByDefault,
 	}
-	if p.Labels != "" {
-		selector, err := parseLabelSelector(p.Labels)
-		if err != nil {
-			return nil, huma.Error400BadRequest("invalid labels selector: " + err.Error())
-		}
-		opts.LabelSelector = selector
-	}
 	if cfg.ListFilter != nil {
 		extra, extraArgs, err := cfg.ListFilter(ctx, AuthorizeInput{Verb: "list", Kind: cfg.Kind, Namespace: p.Namespace})
 		if err != nil {
-			return nil, err
+			return v1alpha1store.ListOpts{}, err
 		}
 		opts.ExtraWhere = extra
 		opts.ExtraArgs = extraArgs
 	}
-	applyOriginFilter(&opts, p.Origin)
-	rows, nextCursor, err := cfg.Store.List(ctx, opts)
-	if err != nil {
-		if errors.Is(err, v1alpha1store.ErrInvalidCursor) {
-			return nil, huma.Error400BadRequest("invalid cursor")
+	if p.Labels != "" {
+		selector, err := parseLabelSelector(p.Labels)
+		if err == nil {
+			err = applyLabelSelector(&opts, selector)
+		}
+		if err != nil {
+			return v1alpha1store.ListOpts{}, huma.Error400BadRequest("invalid labels selector: " + err.Error())
 		}
-		return nil, huma.Error500InternalServerError("list "+cfg.Kind, err)
 	}
-	items := make([]T, 0, len(rows))
-	for _, row := range rows {
-		obj, err := v1alpha1.EnvelopeFromRaw(newObj, row, cfg.Kind)
+	if p.FieldSelector != "" {
+		selector, err := parseFieldSelector(p.FieldSelector)
+		if err == nil {
+			err = applyFieldSelector(&opts, selector, cfg.Store.Behavior() == v1alpha1store.TaggedArtifactStore)
+		}
 		if err != nil {
-			return nil, huma.Error500InternalServerError("decode "+cfg.Kind, err)
+			return v1alpha1store.ListOpts{}, huma.Error400BadRequest("invalid field selector: " + err.Error())
 		}
-		items = append(items, obj)
 	}
-	out := &listOutput[T]{}
-	out.Body.Items = items
-	out.Body.NextCursor = nextCursor
-	return out, nil
+	applyOriginFilter(&opts, p.Origin)
+	return opts, nil
 }
 
 func applyOriginFilter(opts *v1alpha1store.ListOpts, origin string) {
@@ -795,9 +917,16 @@ func applyOriginFilter(opts *v1alpha1store.ListOpts, origin string) {
 	appendExtraWhere(opts, predicate, originSelector)
 }
 
-func appendExtraWhere(opts *v1alpha1store.ListOpts, predicateFormat string, arg any) {
-	opts.ExtraArgs = append(opts.ExtraArgs, arg)
-	predicate := fmt.Sprintf(predicateFormat, len(opts.ExtraArgs))
+// appendExtraWhere ANDs one predicate onto opts.ExtraWhere. predicateFormat
+// carries one `$%d` per arg, filled in order with the arg's placeholder
+// number.
+func appendExtraWhere(opts *v1alpha1store.ListOpts, predicateFormat string, args ...any) {
+	placeholders := make([]any, 0, len(args))
+	for _, arg := range args {
+		opts.ExtraArgs = append(opts.ExtraArgs, arg)
+		placeholders = append(placeholders, len(opts.ExtraArgs))
+	}
+	predicate := fmt.Sprintf(predicateFormat, placeholders...)
 	if opts.ExtraWhere == "" {
 		opts.ExtraWhere = predicate
 		return
@@ -816,27 +945,3 @@ func mapNotFound(err error, kind, namespace, name, tag string) error {
 	}
 	return huma.Error500InternalServerError("fetch "+kind, err)
 }
-
-// parseLabelSelector decodes "key=value,key2=value2" into a map. Values
-// may contain `=` (split is on the first `=` only); values with `,` are
-// not supported and would split mid-pair.
-func parseLabelSelector(s string) (map[string]string, error) {
-	out := make(map[string]string)
-	for pair := range strings.SplitSeq(s, ",") {
-		pair = strings.TrimSpace(pair)
-		if pair == "" {
-			continue
-		}
-		eq := strings.IndexByte(pair, '=')
-		if eq <= 0 {
-			return nil, fmt.Errorf("label %q must be key=value", pair)
-		}
-		key := strings.TrimSpace(pair[:eq])
-		val := strings.TrimSpace(pair[eq+1:])
-		if key == "" {
-			return nil, fmt.Errorf("label %q has empty key", pair)
-		}
-		out[key] = val
-	}
-	return out, nil
-}
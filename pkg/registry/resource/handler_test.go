@@ -16,8 +16,10 @@ import (
 	"github.com/danielgtaylor/huma/v2/humatest"
 	"github.com/stretchr/testify/require"
 
+	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
 	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
 	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
 	"github.com/agentregistry-dev/agentregistry/pkg/types"
@@ -423,6 +425,171 @@ func TestResourceRegister_AgentListTags(t *testing.T) {
 	require.Empty(t, empty.Items)
 }
 
+func TestResourceRegister_AgentTagAliases(t *testing.T) {
+	pool := v1alpha1store.NewTestPool(t)
+	store := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents",
+		v1alpha1store.WithKind(v1alpha1.KindAgent), v1alpha1store.WithTagAliases(v1alpha1store.TestSchema()))
+
+	_, api := humatest.New(t)
+	registerAgent(api, store)
+
+	for _, tag := range []string{"1.0.0", "1.1.0"} {
+		_, err := store.Upsert(t.Context(), &v1alpha1.Agent{
+			TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindAgent},
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "foo", Tag: tag},
+			Spec:     v1alpha1.AgentSpec{Title: tag},
+		})
+		require.NoError(t, err)
+	}
+
+	promote := func(from, to string) (int, arv0.PromoteTagResponse) {
+		resp := api.Post("/v0/agents/foo/promote", map[string]string{"from": from, "to": to})
+		var out arv0.PromoteTagResponse
+		if resp.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+		}
+		return resp.Code, out
+	}
+
+	code, out := promote("1.0.0", "stable")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, arv0.ApplyStatusCreated, out.Status)
+	require.Equal(t, "1.0.0", out.Target)
+
+	resp := api.Get("/v0/agents/foo/stable")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var got v1alpha1.Agent
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &got))
+	require.Equal(t, "1.0.0", got.Metadata.Tag, "alias GET must return the concrete target row")
+
+	code, out = promote("1.1.0", "stable")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, arv0.ApplyStatusConfigured, out.Status)
+	code, out = promote("stable", "prod")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "1.1.0", out.Target, "promoting from an alias copies its target")
+
+	code, _ = promote("1.0.0", "1.1.0")
+	require.Equal(t, http.StatusConflict, code, "alias must not shadow a concrete tag")
+	code, _ = promote("9.9.9", "stable")
+	require.Equal(t, http.StatusNotFound, code)
+
+	resp = api.Get("/v0/agents/foo/aliases")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var list arv0.TagAliasListResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &list))
+	require.Len(t, list.Items, 2)
+	require.Equal(t, "prod", list.Items[0].Alias)
+	require.Equal(t, "stable", list.Items[1].Alias)
+
+	// Applying a concrete tag over an alias name is a conflict.
+	res := applyAgentYAML(t, api, `apiVersion: ar.dev/v1alpha1
+kind: Agent
+metadata:
+  name: foo
+  tag: stable
+spec:
+  title: shadow
+`)
+	require.Equal(t, arv0.ApplyStatusFailed, res.Status)
+	require.Contains(t, res.Error, "conflict")
+
+	resp = api.Delete("/v0/agents/foo/aliases/prod")
+	require.Equal(t, http.StatusNoContent, resp.Code, resp.Body.String())
+	resp = api.Delete("/v0/agents/foo/aliases/prod")
+	require.Equal(t, http.StatusNotFound, resp.Code, resp.Body.String())
+}
+
+func TestResourceRegister_AgentTagRevisions(t *testing.T) {
+	pool := v1alpha1store.NewTestPool(t)
+	store := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents",
+		v1alpha1store.WithKind(v1alpha1.KindAgent), v1alpha1store.WithTagHistory(v1alpha1store.TestSchema()))
+
+	_, api := humatest.New(t)
+	registerAgent(api, store)
+
+	for _, title := range []string{"first", "second"} {
+		_, err := store.Upsert(t.Context(), &v1alpha1.Agent{
+			TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindAgent},
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "foo", Tag: "1.0.0"},
+			Spec:     v1alpha1.AgentSpec{Title: title},
+		})
+		require.NoError(t, err)
+	}
+
+	resp := api.Get("/v0/agents/foo/1.0.0/revisions")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var list arv0.TagRevisionListResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &list))
+	require.Len(t, list.Items, 2)
+	require.Equal(t, int64(2), list.Items[0].Generation)
+	require.True(t, list.Items[0].Current)
+	require.Equal(t, int64(1), list.Items[1].Generation)
+
+	resp = api.Post("/v0/agents/foo/1.0.0/rollback", map[string]int64{"generation": 1})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var rolled arv0.RollbackTagResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &rolled))
+	require.Equal(t, arv0.ApplyStatusConfigured, rolled.Status)
+	require.Equal(t, int64(3), rolled.Generation)
+
+	resp = api.Get("/v0/agents/foo/1.0.0")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var got v1alpha1.Agent
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &got))
+	require.Equal(t, "first", got.Spec.Title)
+
+	resp = api.Post("/v0/agents/foo/1.0.0/rollback", map[string]int64{"generation": 42})
+	require.Equal(t, http.StatusNotFound, resp.Code, resp.Body.String())
+	resp = api.Get("/v0/agents/foo/9.9.9/revisions")
+	require.Equal(t, http.StatusNotFound, resp.Code, resp.Body.String())
+}
+
+func TestResourceRegister_RollbackRunsAdmission(t *testing.T) {
+	pool := v1alpha1store.NewTestPool(t)
+	store := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents",
+		v1alpha1store.WithKind(v1alpha1.KindAgent), v1alpha1store.WithTagHistory(v1alpha1store.TestSchema()))
+
+	// The admission stands in for a policy that changed after the first
+	// revision was applied, e.g. a trust policy that no longer accepts its
+	// signature.
+	_, api := humatest.New(t)
+	resource.Register[*v1alpha1.Agent](api, resource.Config{
+		Kind:       v1alpha1.KindAgent,
+		BasePrefix: "/v0",
+		Store:      store,
+		Admission: func(ctx context.Context, in types.AdmissionInput) (types.AdmissionResult, error) {
+			if in.Object.(*v1alpha1.Agent).Spec.Title == "first" {
+				return types.AdmissionResult{}, huma.Error403Forbidden("first is no longer admitted")
+			}
+			return resource.ProductionAdmission(ctx, in)
+		},
+	}, func() *v1alpha1.Agent { return &v1alpha1.Agent{} })
+
+	for _, title := range []string{"first", "second"} {
+		_, err := store.Upsert(t.Context(), &v1alpha1.Agent{
+			TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindAgent},
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "foo", Tag: "1.0.0"},
+			Spec:     v1alpha1.AgentSpec{Title: title},
+		})
+		require.NoError(t, err)
+	}
+
+	resp := api.Post("/v0/agents/foo/1.0.0/rollback", map[string]int64{"generation": 1})
+	require.Equal(t, http.StatusForbidden, resp.Code, resp.Body.String())
+	resp = api.Get("/v0/agents/foo/1.0.0")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var got v1alpha1.Agent
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &got))
+	require.Equal(t, "second", got.Spec.Title, "a refused rollback leaves the tag untouched")
+
+	resp = api.Post("/v0/agents/foo/1.0.0/rollback", map[string]int64{"generation": 2})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var rolled arv0.RollbackTagResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &rolled))
+	require.Equal(t, arv0.ApplyStatusUnchanged, rolled.Status)
+}
+
 func TestResourceRegister_AgentListRejectsInvalidCursor(t *testing.T) {
 	pool := v1alpha1store.NewTestPool(t)
 	store := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents")
@@ -530,6 +697,89 @@ func TestResourceRegister_ListFilter(t *testing.T) {
 	}
 }
 
+// TestResourceRegister_ListSelectors runs set-based label selectors and
+// field selectors against Postgres so the compiled predicates are
+// exercised end to end.
+func TestResourceRegister_ListSelectors(t *testing.T) {
+	ctx := t.Context()
+	pool := v1alpha1store.NewTestPool(t)
+	store := v1alpha1store.NewMutableObjectStore(pool, v1alpha1store.TestSchema(), "deployments", v1alpha1store.WithKind(v1alpha1.KindDeployment))
+	for _, d := range []struct {
+		name    string
+		labels  map[string]string
+		runtime string
+		ready   string
+	}{
+		{"web-prod", map[string]string{"app": "web", "env": "prod"}, "prod", "True"},
+		{"web-dev", map[string]string{"app": "web", "env": "dev", "canary": "yes"}, "dev", "False"},
+		{"api-prod", map[string]string{"app": "api", "env": "prod"}, "prod", "False"},
+		{"batch", nil, "prod", ""},
+	} {
+		_, err := store.Upsert(ctx, &v1alpha1.Deployment{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: d.name, Labels: d.labels},
+			Spec: v1alpha1.DeploymentSpec{
+				TargetRef:  v1alpha1.ResourceRef{Kind: v1alpha1.KindAgent, Name: "bot", Tag: "1.0.0"},
+				RuntimeRef: v1alpha1.ResourceRef{Kind: v1alpha1.KindRuntime, Name: d.runtime},
+			},
+		})
+		require.NoError(t, err)
+		if d.ready == "" {
+			continue
+		}
+		require.NoError(t, store.PatchStatus(ctx, "default", d.name, "", func(json.RawMessage) (json.RawMessage, error) {
+			return json.RawMessage(`{"conditions":[{"type":"Ready","status":"` + d.ready + `"}]}`), nil
+		}))
+	}
+
+	_, api := humatest.New(t)
+	resource.Register[*v1alpha1.Deployment](api, resource.Config{
+		Kind:       v1alpha1.KindDeployment,
+		BasePrefix: "/v0",
+		Store:      store,
+	}, func() *v1alpha1.Deployment { return &v1alpha1.Deployment{} })
+
+	list := func(query url.Values) []string {
+		t.Helper()
+		resp := api.Get("/v0/deployments?" + query.Encode())
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+		var out struct {
+			Items []v1alpha1.Deployment `json:"items"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+		names := make([]string, 0, len(out.Items))
+		for _, d := range out.Items {
+			names = append(names, d.Metadata.Name)
+		}
+		return names
+	}
+
+	for selector, want := range map[string][]string{
+		"app=web":                  {"web-dev", "web-prod"},
+		"app=web,env!=prod":        {"web-dev"},
+		"env!=prod":                {"batch", "web-dev"},
+		"app in (api, web),canary": {"web-dev"},
+		"app notin (web)":          {"api-prod", "batch"},
+		"!app":                     {"batch"},
+	} {
+		require.ElementsMatch(t, want, list(url.Values{"labels": {selector}}), selector)
+	}
+	for selector, want := range map[string][]string{
+		"spec.runtimeRef.name=prod":                      {"api-prod", "batch", "web-prod"},
+		"spec.runtimeRef.name=prod,metadata.name!=batch": {"api-prod", "web-prod"},
+		"status.conditions[Ready]=False":                 {"api-prod", "web-dev"},
+		"status.conditions[Ready]!=True":                 {"api-prod", "batch", "web-dev"},
+	} {
+		require.ElementsMatch(t, want, list(url.Values{"fieldSelector": {selector}}), selector)
+	}
+	require.ElementsMatch(t, []string{"api-prod"}, list(url.Values{
+		"labels":        {"env=prod"},
+		"fieldSelector": {"status.conditions[Ready]=False"},
+	}))
+
+	require.Equal(t, http.StatusBadRequest, api.Get("/v0/deployments?labels="+url.QueryEscape("app in (web")).Code)
+	require.Equal(t, http.StatusBadRequest, api.Get("/v0/deployments?fieldSelector="+url.QueryEscape("metadata.tag=1.0.0")).Code)
+}
+
 // TestResourceRegister_PutNotRegisteredForContentKinds pins the
 // post-redesign contract: direct PUT on the per-kind item URL is no
 // longer registered for content-registry kinds (Agent, MCPServer,
@@ -663,6 +913,445 @@ spec:
 	require.Contains(t, out.Results[0].Error, "spec.mcpServers[1]")
 }
 
+func TestResourceRegister_TagLifecycle(t *testing.T) {
+	pool := v1alpha1store.NewTestPool(t)
+	stores := map[string]*v1alpha1store.Store{
+		v1alpha1.KindAgent:     v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents", v1alpha1store.WithKind(v1alpha1.KindAgent)),
+		v1alpha1.KindMCPServer: v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "mcp_servers", v1alpha1store.WithKind(v1alpha1.KindMCPServer), v1alpha1store.WithTagLifecycle()),
+	}
+	for _, tag := range []string{"1.0.0", "1.1.0"} {
+		_, err := stores[v1alpha1.KindMCPServer].Upsert(t.Context(), &v1alpha1.MCPServer{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "tools", Tag: tag},
+			Spec:     v1alpha1.MCPServerSpec{Title: "Tools " + tag},
+		})
+		require.NoError(t, err)
+	}
+
+	_, api := humatest.New(t)
+	resource.Register[*v1alpha1.MCPServer](api, resource.Config{
+		Kind:       v1alpha1.KindMCPServer,
+		BasePrefix: "/v0",
+		Store:      stores[v1alpha1.KindMCPServer],
+	}, func() *v1alpha1.MCPServer { return &v1alpha1.MCPServer{} })
+	resource.RegisterApply(api, resource.ApplyConfig{
+		BasePrefix: "/v0",
+		Stores:     stores,
+		Resolver:   internaldb.NewResolver(stores),
+	})
+
+	setLifecycle := func(tag string, body arv0.SetTagLifecycleRequest) int {
+		return api.Put("/v0/mcpservers/tools/"+tag+"/lifecycle", body).Code
+	}
+	applyAgent := func(name, ref string) arv0.ApplyResult {
+		return applyAgentYAML(t, api, fmt.Sprintf(`apiVersion: ar.dev/v1alpha1
+kind: Agent
+metadata:
+  name: %s
+  tag: 1.0.0
+spec:
+  mcpServers:
+    - kind: MCPServer
+      name: tools
+      tag: %q
+`, name, ref))
+	}
+
+	require.Equal(t, http.StatusOK, setLifecycle("1.0.0", arv0.SetTagLifecycleRequest{State: "Deprecated", Reason: "old", Replacement: "1.1.0"}))
+	resp := api.Get("/v0/mcpservers/tools/1.0.0")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var got v1alpha1.MCPServer
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &got))
+	require.Equal(t, v1alpha1.LifecycleDeprecated, got.Status.LifecycleState())
+	require.Equal(t, "1.1.0", got.Status.Lifecycle.Replacement)
+
+	res := applyAgent("pinned", "1.0.0")
+	require.Equal(t, arv0.ApplyStatusCreated, res.Status, res.Error)
+	require.Len(t, res.Warnings, 1)
+	require.Contains(t, res.Warnings[0], "is deprecated: old; use 1.1.0")
+
+	require.Equal(t, http.StatusOK, setLifecycle("1.0.0", arv0.SetTagLifecycleRequest{State: "Yanked", Reason: "CVE-2026-1"}))
+	res = applyAgent("fresh", "1.0.0")
+	require.Equal(t, arv0.ApplyStatusFailed, res.Status)
+	require.Contains(t, res.Error, "yanked")
+	res = applyAgentYAML(t, api, `apiVersion: ar.dev/v1alpha1
+kind: MCPServer
+metadata:
+  name: tools
+  tag: 1.0.0
+spec:
+  title: Tools 1.0.0, patched
+`)
+	require.Equal(t, arv0.ApplyStatusConfigured, res.Status, res.Error)
+	res = applyAgent("fresh", "1.0.0")
+	require.Equal(t, arv0.ApplyStatusFailed, res.Status, "re-applying a yanked tag's content keeps it yanked")
+	require.Contains(t, res.Error, "yanked")
+	res = applyAgent("pinned", "1.0.0")
+	require.Equal(t, arv0.ApplyStatusUnchanged, res.Status, "re-applying an existing reference to a yanked tag is allowed: %s", res.Error)
+	require.NotEmpty(t, res.Warnings)
+	res = applyAgent("ranged", "^1.0")
+	require.NotEqual(t, arv0.ApplyStatusFailed, res.Status, "semver ranges skip yanked tags: %s", res.Error)
+	require.Empty(t, res.Warnings)
+
+	require.Equal(t, http.StatusBadRequest, setLifecycle("1.1.0", arv0.SetTagLifecycleRequest{State: "Deprecated", Replacement: "1.0.0"}), "a yanked tag is no replacement")
+	require.Equal(t, http.StatusBadRequest, setLifecycle("1.1.0", arv0.SetTagLifecycleRequest{State: "Deprecated", Replacement: "1.1.0"}))
+	require.Equal(t, http.StatusNotFound, setLifecycle("9.9.9", arv0.SetTagLifecycleRequest{State: "Deprecated"}))
+
+	require.Equal(t, http.StatusOK, setLifecycle("1.0.0", arv0.SetTagLifecycleRequest{State: "Active"}))
+	res = applyAgent("fresh", "1.0.0")
+	require.Equal(t, arv0.ApplyStatusCreated, res.Status, res.Error)
+	require.Empty(t, res.Warnings)
+}
+
+func TestResourceRegister_Referrers(t *testing.T) {
+	ctx := t.Context()
+	pool := v1alpha1store.NewTestPool(t)
+	stores := map[string]*v1alpha1store.Store{
+		v1alpha1.KindAgent:      v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents", v1alpha1store.WithKind(v1alpha1.KindAgent)),
+		v1alpha1.KindMCPServer:  v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "mcp_servers", v1alpha1store.WithKind(v1alpha1.KindMCPServer)),
+		v1alpha1.KindRuntime:    v1alpha1store.NewMutableObjectStore(pool, v1alpha1store.TestSchema(), "runtimes", v1alpha1store.WithKind(v1alpha1.KindRuntime)),
+		v1alpha1.KindDeployment: v1alpha1store.NewMutableObjectStore(pool, v1alpha1store.TestSchema(), "deployments", v1alpha1store.WithKind(v1alpha1.KindDeployment)),
+	}
+	for _, tag := range []string{"1.0.0", "1.1.0"} {
+		_, err := stores[v1alpha1.KindMCPServer].Upsert(ctx, &v1alpha1.MCPServer{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "tools", Tag: tag},
+			Spec:     v1alpha1.MCPServerSpec{Title: "Tools " + tag},
+		})
+		require.NoError(t, err)
+	}
+	_, err := stores[v1alpha1.KindRuntime].Upsert(ctx, &v1alpha1.Runtime{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "local"},
+		Spec:     v1alpha1.RuntimeSpec{Type: "Local"},
+	})
+	require.NoError(t, err)
+	for name, ref := range map[string]string{"pinned": "1.0.0", "ranged": "^1.0.0"} {
+		_, err := stores[v1alpha1.KindAgent].Upsert(ctx, &v1alpha1.Agent{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: name, Tag: "1.0.0"},
+			Spec:     v1alpha1.AgentSpec{MCPServers: []v1alpha1.ResourceRef{{Kind: v1alpha1.KindMCPServer, Name: "tools", Tag: ref}}},
+		})
+		require.NoError(t, err)
+	}
+	_, err = stores[v1alpha1.KindDeployment].Upsert(ctx, &v1alpha1.Deployment{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "tools-prod"},
+		Spec: v1alpha1.DeploymentSpec{
+			TargetRef:  v1alpha1.ResourceRef{Kind: v1alpha1.KindMCPServer, Name: "tools", Tag: "1.0.0"},
+			RuntimeRef: v1alpha1.ResourceRef{Kind: v1alpha1.KindRuntime, Name: "local"},
+		},
+	})
+	require.NoError(t, err)
+
+	_, api := humatest.New(t)
+	referrers := internaldb.NewReferrers(stores)
+	resource.Register[*v1alpha1.MCPServer](api, resource.Config{
+		Kind:       v1alpha1.KindMCPServer,
+		BasePrefix: "/v0",
+		Store:      stores[v1alpha1.KindMCPServer],
+		Referrers:  referrers,
+	}, func() *v1alpha1.MCPServer { return &v1alpha1.MCPServer{} })
+	resource.Register[*v1alpha1.Runtime](api, resource.Config{
+		Kind:       v1alpha1.KindRuntime,
+		BasePrefix: "/v0",
+		Store:      stores[v1alpha1.KindRuntime],
+		Referrers:  referrers,
+	}, func() *v1alpha1.Runtime { return &v1alpha1.Runtime{} })
+
+	list := func(path string) arv0.ReferrerListResponse {
+		t.Helper()
+		resp := api.Get(path)
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+		var out arv0.ReferrerListResponse
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+		return out
+	}
+
+	got := list("/v0/mcpservers/tools/1.0.0/referrers")
+	require.Equal(t, []arv0.Referrer{
+		{Kind: v1alpha1.KindAgent, Namespace: "default", Name: "pinned", Tag: "1.0.0", Path: "spec.mcpServers[0]"},
+		{Kind: v1alpha1.KindDeployment, Namespace: "default", Name: "tools-prod", Path: "spec.targetRef"},
+	}, got.Items)
+
+	// The range resolves to the highest tag, and the request's own tag may
+	// be a range too.
+	got = list("/v0/mcpservers/tools/" + url.PathEscape("^1.1") + "/referrers")
+	require.Equal(t, "1.1.0", got.Tag)
+	require.Equal(t, []arv0.Referrer{
+		{Kind: v1alpha1.KindAgent, Namespace: "default", Name: "ranged", Tag: "1.0.0", Path: "spec.mcpServers[0]"},
+	}, got.Items)
+
+	got = list("/v0/runtimes/local/referrers")
+	require.Equal(t, []arv0.Referrer{
+		{Kind: v1alpha1.KindDeployment, Namespace: "default", Name: "tools-prod", Path: "spec.runtimeRef"},
+	}, got.Items)
+
+	require.Equal(t, http.StatusNotFound, api.Get("/v0/mcpservers/tools/9.9.9/referrers").Code)
+	require.Equal(t, http.StatusNotFound, api.Get("/v0/runtimes/missing/referrers").Code)
+}
+
+// TestResourceRegister_DeleteRefusesReferencedTarget covers delete
+// protection: a referenced MCPServer tag is refused with 409 and its
+// dependents listed, ?force deletes it anyway, and ?cascade deletes the
+// Deployment → Agent chain first. DELETE /v0/apply follows the same rules.
+func TestResourceRegister_DeleteRefusesReferencedTarget(t *testing.T) {
+	ctx := t.Context()
+	pool := v1alpha1store.NewTestPool(t)
+	stores := map[string]*v1alpha1store.Store{
+		v1alpha1.KindAgent:      v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents", v1alpha1store.WithKind(v1alpha1.KindAgent)),
+		v1alpha1.KindMCPServer:  v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "mcp_servers", v1alpha1store.WithKind(v1alpha1.KindMCPServer)),
+		v1alpha1.KindDeployment: v1alpha1store.NewMutableObjectStore(pool, v1alpha1store.TestSchema(), "deployments", v1alpha1store.WithKind(v1alpha1.KindDeployment)),
+	}
+	for _, tag := range []string{"1.0.0", "2.0.0", "3.0.0"} {
+		_, err := stores[v1alpha1.KindMCPServer].Upsert(ctx, &v1alpha1.MCPServer{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "tools", Tag: tag},
+			Spec:     v1alpha1.MCPServerSpec{Title: "Tools " + tag},
+		})
+		require.NoError(t, err)
+	}
+	for name, tag := range map[string]string{"bot": "1.0.0", "helper": "2.0.0", "batch": "3.0.0"} {
+		_, err := stores[v1alpha1.KindAgent].Upsert(ctx, &v1alpha1.Agent{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: name, Tag: "1.0.0"},
+			Spec:     v1alpha1.AgentSpec{MCPServers: []v1alpha1.ResourceRef{{Kind: v1alpha1.KindMCPServer, Name: "tools", Tag: tag}}},
+		})
+		require.NoError(t, err)
+	}
+	_, err := stores[v1alpha1.KindDeployment].Upsert(ctx, &v1alpha1.Deployment{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "bot-prod"},
+		Spec:     v1alpha1.DeploymentSpec{TargetRef: v1alpha1.ResourceRef{Kind: v1alpha1.KindAgent, Name: "bot", Tag: "1.0.0"}},
+	})
+	require.NoError(t, err)
+
+	_, api := humatest.New(t)
+	referrers := internaldb.NewReferrers(stores)
+	var deleted []string
+	var cfgFor func(kind string) (resource.Config, bool)
+	cfgFor = func(kind string) (resource.Config, bool) {
+		return resource.Config{
+			Kind:       kind,
+			BasePrefix: "/v0",
+			Store:      stores[kind],
+			Referrers:  referrers,
+			PostDelete: func(_ context.Context, obj v1alpha1.Object) error {
+				deleted = append(deleted, kind+"/"+obj.GetMetadata().Name)
+				return nil
+			},
+			DeleteDependent: resource.DependentDeleter(cfgFor),
+		}, true
+	}
+	mcpCfg, _ := cfgFor(v1alpha1.KindMCPServer)
+	resource.Register[*v1alpha1.MCPServer](api, mcpCfg, func() *v1alpha1.MCPServer { return &v1alpha1.MCPServer{} })
+	resource.RegisterApply(api, resource.ApplyConfig{
+		BasePrefix: "/v0",
+		Stores:     stores,
+		Referrers:  referrers,
+	})
+
+	resp := api.Delete("/v0/mcpservers/tools/1.0.0")
+	require.Equal(t, http.StatusConflict, resp.Code, resp.Body.String())
+	var conflict huma.ErrorModel
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &conflict))
+	require.Len(t, conflict.Errors, 1)
+	require.Equal(t, "spec.mcpServers[0]", conflict.Errors[0].Location)
+	require.Contains(t, conflict.Errors[0].Message, "Agent default/bot:1.0.0")
+	_, err = stores[v1alpha1.KindMCPServer].Get(ctx, "default", "tools", "1.0.0")
+	require.NoError(t, err, "a refused delete keeps the tag")
+
+	require.Equal(t, http.StatusBadRequest, api.Delete("/v0/mcpservers/tools/1.0.0?force=true&cascade=true").Code)
+
+	require.Empty(t, deleted)
+
+	// Cascade deletes the Deployment before the Agent it runs, and both
+	// before the MCPServer tag.
+	resp = api.Delete("/v0/mcpservers/tools/1.0.0?cascade=true")
+	require.Equal(t, http.StatusNoContent, resp.Code, resp.Body.String())
+	require.Equal(t, []string{"Deployment/bot-prod", "Agent/bot", "MCPServer/tools"}, deleted)
+	_, err = stores[v1alpha1.KindAgent].Get(ctx, "default", "bot", "1.0.0")
+	require.ErrorIs(t, err, pkgdb.ErrNotFound)
+
+	// Force leaves the dependent Agent behind with a dangling ref.
+	resp = api.Delete("/v0/mcpservers/tools/2.0.0?force=true")
+	require.Equal(t, http.StatusNoContent, resp.Code, resp.Body.String())
+	_, err = stores[v1alpha1.KindAgent].Get(ctx, "default", "helper", "1.0.0")
+	require.NoError(t, err)
+
+	batch := `apiVersion: ar.dev/v1alpha1
+kind: MCPServer
+metadata:
+  namespace: default
+  name: tools
+  tag: 3.0.0
+spec:
+  title: Tools 3.0.0
+`
+	deleteBatch := func(query string) arv0.ApplyResult {
+		t.Helper()
+		resp := api.Do(http.MethodDelete, "/v0/apply"+query, "Content-Type: application/yaml", strings.NewReader(batch))
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+		var out arv0.ApplyResultsResponse
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &out))
+		require.Len(t, out.Results, 1)
+		return out.Results[0]
+	}
+	res := deleteBatch("")
+	require.Equal(t, arv0.ApplyStatusFailed, res.Status)
+	require.Contains(t, res.Error, "Agent default/batch:1.0.0")
+	res = deleteBatch("?cascade=true")
+	require.Equal(t, arv0.ApplyStatusDeleted, res.Status, res.Error)
+	_, err = stores[v1alpha1.KindAgent].Get(ctx, "default", "batch", "1.0.0")
+	require.ErrorIs(t, err, pkgdb.ErrNotFound)
+}
+
+// TestResourceRegister_ReferrersHideUnreadable covers authz on reverse
+// lookups: the referrers route and a refused delete name only referrers the
+// caller may get, and a cascade that would delete a hidden one is refused.
+func TestResourceRegister_ReferrersHideUnreadable(t *testing.T) {
+	ctx := t.Context()
+	pool := v1alpha1store.NewTestPool(t)
+	stores := map[string]*v1alpha1store.Store{
+		v1alpha1.KindAgent:     v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents", v1alpha1store.WithKind(v1alpha1.KindAgent)),
+		v1alpha1.KindMCPServer: v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "mcp_servers", v1alpha1store.WithKind(v1alpha1.KindMCPServer)),
+	}
+	_, err := stores[v1alpha1.KindMCPServer].Upsert(ctx, &v1alpha1.MCPServer{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "tools", Tag: "1.0.0"},
+		Spec:     v1alpha1.MCPServerSpec{Title: "Tools"},
+	})
+	require.NoError(t, err)
+	for _, name := range []string{"bot", "secret"} {
+		_, err := stores[v1alpha1.KindAgent].Upsert(ctx, &v1alpha1.Agent{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: name, Tag: "1.0.0"},
+			Spec:     v1alpha1.AgentSpec{MCPServers: []v1alpha1.ResourceRef{{Kind: v1alpha1.KindMCPServer, Name: "tools", Tag: "1.0.0"}}},
+		})
+		require.NoError(t, err)
+	}
+
+	authorizers := map[string]func(context.Context, resource.AuthorizeInput) error{
+		v1alpha1.KindMCPServer: func(context.Context, resource.AuthorizeInput) error { return nil },
+		v1alpha1.KindAgent: func(_ context.Context, in resource.AuthorizeInput) error {
+			if in.Name == "secret" {
+				return huma.Error403Forbidden("forbidden")
+			}
+			return nil
+		},
+	}
+	_, api := humatest.New(t)
+	referrers := internaldb.NewReferrers(stores)
+	var cfgFor func(kind string) (resource.Config, bool)
+	cfgFor = func(kind string) (resource.Config, bool) {
+		return resource.Config{
+			Kind:                kind,
+			BasePrefix:          "/v0",
+			Store:               stores[kind],
+			Referrers:           referrers,
+			Authorize:           authorizers[kind],
+			ReferrerAuthorizers: authorizers,
+			DeleteDependent:     resource.DependentDeleter(cfgFor),
+		}, true
+	}
+	mcpCfg, _ := cfgFor(v1alpha1.KindMCPServer)
+	resource.Register[*v1alpha1.MCPServer](api, mcpCfg, func() *v1alpha1.MCPServer { return &v1alpha1.MCPServer{} })
+
+	resp := api.Get("/v0/mcpservers/tools/1.0.0/referrers")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	var list arv0.ReferrerListResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &list))
+	require.Equal(t, []arv0.Referrer{
+		{Kind: v1alpha1.KindAgent, Namespace: "default", Name: "bot", Tag: "1.0.0", Path: "spec.mcpServers[0]"},
+	}, list.Items)
+
+	resp = api.Delete("/v0/mcpservers/tools/1.0.0")
+	require.Equal(t, http.StatusConflict, resp.Code, resp.Body.String())
+	var conflict huma.ErrorModel
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &conflict))
+	require.Len(t, conflict.Errors, 1)
+	require.Contains(t, conflict.Errors[0].Message, "Agent default/bot:1.0.0")
+	require.Contains(t, conflict.Detail, "1 object you cannot read")
+	require.NotContains(t, resp.Body.String(), "secret")
+
+	resp = api.Delete("/v0/mcpservers/tools/1.0.0?cascade=true")
+	require.Equal(t, http.StatusForbidden, resp.Code, resp.Body.String())
+	_, err = stores[v1alpha1.KindAgent].Get(ctx, "default", "bot", "1.0.0")
+	require.NoError(t, err, "a refused cascade deletes nothing")
+}
+
+// TestResourceRegister_CascadeIsAllOrNothing pins the cascade preflight: a
+// dependent whose delete is denied, or a target whose delete admission
+// refuses, fails the cascade before any dependent is deleted.
+func TestResourceRegister_CascadeIsAllOrNothing(t *testing.T) {
+	ctx := t.Context()
+	pool := v1alpha1store.NewTestPool(t)
+	stores := map[string]*v1alpha1store.Store{
+		v1alpha1.KindAgent:     v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents", v1alpha1store.WithKind(v1alpha1.KindAgent)),
+		v1alpha1.KindMCPServer: v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "mcp_servers", v1alpha1store.WithKind(v1alpha1.KindMCPServer)),
+	}
+	_, err := stores[v1alpha1.KindMCPServer].Upsert(ctx, &v1alpha1.MCPServer{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "tools", Tag: "1.0.0"},
+		Spec:     v1alpha1.MCPServerSpec{Title: "Tools"},
+	})
+	require.NoError(t, err)
+	for _, name := range []string{"bot", "locked"} {
+		_, err := stores[v1alpha1.KindAgent].Upsert(ctx, &v1alpha1.Agent{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: name, Tag: "1.0.0"},
+			Spec:     v1alpha1.AgentSpec{MCPServers: []v1alpha1.ResourceRef{{Kind: v1alpha1.KindMCPServer, Name: "tools", Tag: "1.0.0"}}},
+		})
+		require.NoError(t, err)
+	}
+
+	lockedDeletable, targetAdmitted := false, false
+	_, api := humatest.New(t)
+	referrers := internaldb.NewReferrers(stores)
+	var cfgFor func(kind string) (resource.Config, bool)
+	cfgFor = func(kind string) (resource.Config, bool) {
+		cfg := resource.Config{
+			Kind:            kind,
+			BasePrefix:      "/v0",
+			Store:           stores[kind],
+			Referrers:       referrers,
+			DeleteDependent: resource.DependentDeleter(cfgFor),
+		}
+		switch kind {
+		case v1alpha1.KindAgent:
+			cfg.Authorize = func(_ context.Context, in resource.AuthorizeInput) error {
+				if in.Verb == "delete" && in.Name == "locked" && !lockedDeletable {
+					return huma.Error403Forbidden("forbidden")
+				}
+				return nil
+			}
+		case v1alpha1.KindMCPServer:
+			cfg.DeleteAdmission = func(ctx context.Context, in types.DeleteAdmissionInput) (types.DeleteAdmissionResult, error) {
+				if !targetAdmitted {
+					return types.DeleteAdmissionResult{}, huma.Error422UnprocessableEntity("held")
+				}
+				return resource.ProductionDeleteAdmission(ctx, in)
+			}
+		}
+		return cfg, true
+	}
+	mcpCfg, _ := cfgFor(v1alpha1.KindMCPServer)
+	resource.Register[*v1alpha1.MCPServer](api, mcpCfg, func() *v1alpha1.MCPServer { return &v1alpha1.MCPServer{} })
+
+	agentsLeft := func() int {
+		t.Helper()
+		n := 0
+		for _, name := range []string{"bot", "locked"} {
+			if _, err := stores[v1alpha1.KindAgent].Get(ctx, "default", name, "1.0.0"); err == nil {
+				n++
+			}
+		}
+		return n
+	}
+
+	resp := api.Delete("/v0/mcpservers/tools/1.0.0?cascade=true")
+	require.Equal(t, http.StatusForbidden, resp.Code, resp.Body.String())
+	require.Equal(t, 2, agentsLeft(), "a dependent that may not be deleted stops the whole cascade")
+
+	lockedDeletable = true
+	resp = api.Delete("/v0/mcpservers/tools/1.0.0?cascade=true")
+	require.Equal(t, http.StatusUnprocessableEntity, resp.Code, resp.Body.String())
+	require.Equal(t, 2, agentsLeft(), "a target its admission refuses stops the whole cascade")
+
+	targetAdmitted = true
+	resp = api.Delete("/v0/mcpservers/tools/1.0.0?cascade=true")
+	require.Equal(t, http.StatusNoContent, resp.Code, resp.Body.String())
+	require.Zero(t, agentsLeft())
+}
+
 // TestResourceRegister_DeleteHardDeletesFinalizerFree pins the K8s
 // fast-path: rows with no finalizers hard-delete synchronously on
 // DELETE. Without it, "DELETE then apply same tag" hits
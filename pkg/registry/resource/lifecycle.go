@@ -0,0 +1,101 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+type setTagLifecycleInput struct {
+	Namespace string `query:"namespace" doc:"Namespace (internal; defaults to 'default')."`
+	Name      string `path:"name"`
+	Tag       string `path:"tag"`
+	Body      arv0.SetTagLifecycleRequest
+}
+
+type tagLifecycleOutput struct {
+	Body arv0.TagLifecycleResponse
+}
+
+// registerTagLifecycle wires the lifecycle subresource for a tagged-artifact
+// kind:
+//
+//	PUT {itemTagPath}/lifecycle  deprecate, yank or reactivate one tag
+//
+// The lifecycle is stored beside the tag's content (see
+// Store.SetTagLifecycle) and read back as status.lifecycle: setting it
+// neither bumps the tag's generation nor records a revision, and
+// re-applying or rolling back the tag's content keeps it.
+func registerTagLifecycle(api huma.API, cfg Config, kind, itemTagPath string) {
+	huma.Register(api, huma.Operation{
+		OperationID: "set-lifecycle-" + strings.ToLower(kind),
+		Method:      http.MethodPut,
+		Path:        itemTagPath + "/lifecycle",
+		Summary:     fmt.Sprintf("Deprecate, yank or reactivate a %s tag", kind),
+	}, func(ctx context.Context, in *setTagLifecycleInput) (*tagLifecycleOutput, error) {
+		ns := resolveNamespace(in.Namespace, false)
+		name, err := unescapePath("name", in.Name)
+		if err != nil {
+			return nil, err
+		}
+		tag, err := unescapePath("tag", in.Tag)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Authorize != nil {
+			if err := cfg.Authorize(ctx, AuthorizeInput{Verb: "apply", Kind: kind, Namespace: ns, Name: name, Tag: tag}); err != nil {
+				return nil, err
+			}
+		}
+
+		state := v1alpha1.LifecycleState(in.Body.State)
+		var lifecycle *v1alpha1.TagLifecycle
+		switch state {
+		case v1alpha1.LifecycleActive:
+			if in.Body.Reason != "" || in.Body.Replacement != "" {
+				return nil, huma.Error400BadRequest("reason and replacement are only allowed on Deprecated or Yanked tags")
+			}
+		case v1alpha1.LifecycleDeprecated, v1alpha1.LifecycleYanked:
+			lifecycle = &v1alpha1.TagLifecycle{State: state, Reason: in.Body.Reason, Replacement: in.Body.Replacement}
+		default:
+			return nil, huma.Error400BadRequest(fmt.Sprintf("unknown lifecycle state %q", in.Body.State))
+		}
+
+		if _, err := cfg.Store.Get(ctx, ns, name, tag); err != nil {
+			return nil, mapNotFound(err, kind, ns, name, tag)
+		}
+		if replacement := in.Body.Replacement; replacement != "" {
+			if replacement == tag {
+				return nil, huma.Error400BadRequest("a tag cannot be its own replacement")
+			}
+			row, err := cfg.Store.Get(ctx, ns, name, replacement)
+			if err != nil {
+				return nil, mapNotFound(err, kind, ns, name, replacement)
+			}
+			current, err := v1alpha1.LifecycleFromStorage(row.Status)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("decode "+kind+" lifecycle", err)
+			}
+			if current != nil && current.State == v1alpha1.LifecycleYanked {
+				return nil, huma.Error400BadRequest(fmt.Sprintf("replacement tag %q is yanked", replacement))
+			}
+		}
+
+		if err := cfg.Store.SetTagLifecycle(ctx, ns, name, tag, lifecycle); err != nil {
+			return nil, mapNotFound(err, kind, ns, name, tag)
+		}
+		out := &tagLifecycleOutput{}
+		out.Body = arv0.TagLifecycleResponse{Namespace: ns, Name: name, Tag: tag, State: string(state)}
+		if lifecycle != nil {
+			out.Body.Reason = lifecycle.Reason
+			out.Body.Replacement = lifecycle.Replacement
+		}
+		return out, nil
+	})
+}
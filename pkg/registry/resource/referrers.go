@@ -0,0 +1,123 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+type referrerListOutput struct {
+	Body arv0.ReferrerListResponse
+}
+
+// registerReferrers wires the reverse-dependency subresource:
+//
+//	GET {itemTagPath}/referrers  objects referencing one tag (tagged kinds)
+//	GET {itemPath}/referrers     objects referencing one object (mutable kinds)
+//
+// The tag segment accepts anything GET {itemTagPath} does (exact tag,
+// alias, semver range); referrers are reported against the concrete tag it
+// resolves to. Only registered when Config.Referrers is set; authorized
+// as a "get" of the target. Referrers the caller may not get are left out
+// (see Config.ReferrerAuthorizers).
+func registerReferrers(api huma.API, cfg Config, kind, path string) {
+	op := huma.Operation{
+		OperationID: "list-referrers-" + strings.ToLower(kind),
+		Method:      http.MethodGet,
+		Path:        path + "/referrers",
+		Summary:     fmt.Sprintf("List objects that reference a %s", kind),
+	}
+	if !v1alpha1.IsTaggedArtifactKind(kind) {
+		huma.Register(api, op, func(ctx context.Context, in *getLatestInput) (*referrerListOutput, error) {
+			ns := resolveNamespace(in.Namespace, false)
+			name, err := unescapePath("name", in.Name)
+			if err != nil {
+				return nil, err
+			}
+			if cfg.Authorize != nil {
+				if err := cfg.Authorize(ctx, AuthorizeInput{Verb: "get", Kind: kind, Namespace: ns, Name: name}); err != nil {
+					return nil, err
+				}
+			}
+			if _, err := cfg.Store.GetLatest(ctx, ns, name); err != nil {
+				return nil, mapNotFound(err, kind, ns, name, "")
+			}
+			return listReferrers(ctx, cfg, v1alpha1.ResourceRef{Kind: kind, Namespace: ns, Name: name})
+		})
+		return
+	}
+	huma.Register(api, op, func(ctx context.Context, in *getInput) (*referrerListOutput, error) {
+		ns := resolveNamespace(in.Namespace, false)
+		name, err := unescapePath("name", in.Name)
+		if err != nil {
+			return nil, err
+		}
+		tag, err := unescapePath("tag", in.Tag)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Authorize != nil {
+			if err := cfg.Authorize(ctx, AuthorizeInput{Verb: "get", Kind: kind, Namespace: ns, Name: name, Tag: tag}); err != nil {
+				return nil, err
+			}
+		}
+		row, err := cfg.Store.GetByRef(ctx, ns, name, tag)
+		if errors.Is(err, pkgdb.ErrInvalidInput) {
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		if err != nil {
+			return nil, mapNotFound(err, kind, ns, name, tag)
+		}
+		return listReferrers(ctx, cfg, v1alpha1.ResourceRef{Kind: kind, Namespace: ns, Name: name, Tag: row.Metadata.Tag})
+	})
+}
+
+func listReferrers(ctx context.Context, cfg Config, target v1alpha1.ResourceRef) (*referrerListOutput, error) {
+	refs, err := cfg.Referrers(ctx, target)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("list "+target.Kind+" referrers", err)
+	}
+	out := &referrerListOutput{}
+	out.Body = arv0.ReferrerListResponse{
+		Kind:      target.Kind,
+		Namespace: target.Namespace,
+		Name:      target.Name,
+		Tag:       target.Tag,
+		Items:     make([]arv0.Referrer, 0, len(refs)),
+	}
+	for _, r := range refs {
+		if !canReadRef(ctx, cfg.ReferrerAuthorizers, r.Object) {
+			continue
+		}
+		out.Body.Items = append(out.Body.Items, arv0.Referrer{
+			Kind:      r.Object.Kind,
+			Namespace: r.Object.Namespace,
+			Name:      r.Object.Name,
+			Tag:       r.Object.Tag,
+			Path:      r.Path,
+		})
+	}
+	return out, nil
+}
+
+// canReadRef reports whether authorizers allow the caller a "get" of ref.
+// Nil authorizers allow everything; otherwise a kind without one is
+// unreadable, as the batch apply treats a kind without an authorizer.
+func canReadRef(ctx context.Context, authorizers map[string]func(ctx context.Context, in AuthorizeInput) error, ref v1alpha1.ResourceRef) bool {
+	if len(authorizers) == 0 {
+		return true
+	}
+	authorize := authorizers[ref.Kind]
+	if authorize == nil {
+		return false
+	}
+	return authorize(ctx, AuthorizeInput{Verb: "get", Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name, Tag: ref.Tag}) == nil
+}
@@ -0,0 +1,150 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+type listRevisionsOutput struct {
+	Body arv0.TagRevisionListResponse
+}
+
+type rollbackTagInput struct {
+	Namespace string `query:"namespace" doc:"Namespace (internal; defaults to 'default')."`
+	Name      string `path:"name"`
+	Tag       string `path:"tag"`
+	Body      arv0.RollbackTagRequest
+}
+
+type rollbackTagOutput struct {
+	Body arv0.RollbackTagResponse
+}
+
+// registerTagRevisions wires the history endpoints for a tagged-artifact
+// kind:
+//
+//	GET  {itemTagPath}/revisions  list every revision of one tag
+//	POST {itemTagPath}/rollback   restore an earlier revision
+//
+// A rollback re-applies the revision's labels, annotations and spec through
+// applyCore with the kind's Authorize and Admission, so it passes the same
+// validation, reference, registry and admission checks as an apply.
+func registerTagRevisions[T v1alpha1.Object](api huma.API, cfg Config, newObj func() T, kind, itemTagPath string) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-revisions-" + strings.ToLower(kind),
+		Method:      http.MethodGet,
+		Path:        itemTagPath + "/revisions",
+		Summary:     fmt.Sprintf("List the revisions of a %s tag", kind),
+	}, func(ctx context.Context, in *getInput) (*listRevisionsOutput, error) {
+		ns := resolveNamespace(in.Namespace, false)
+		name, err := unescapePath("name", in.Name)
+		if err != nil {
+			return nil, err
+		}
+		tag, err := unescapePath("tag", in.Tag)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Authorize != nil {
+			if err := cfg.Authorize(ctx, AuthorizeInput{Verb: "get", Kind: kind, Namespace: ns, Name: name, Tag: tag}); err != nil {
+				return nil, err
+			}
+		}
+		revisions, err := cfg.Store.ListTagRevisions(ctx, ns, name, tag)
+		if err != nil {
+			return nil, mapNotFound(err, kind, ns, name, tag)
+		}
+		out := &listRevisionsOutput{}
+		out.Body.Items = make([]arv0.TagRevision, 0, len(revisions))
+		for _, r := range revisions {
+			out.Body.Items = append(out.Body.Items, tagRevisionToWire(r))
+		}
+		return out, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "rollback-" + strings.ToLower(kind),
+		Method:      http.MethodPost,
+		Path:        itemTagPath + "/rollback",
+		Summary:     fmt.Sprintf("Restore an earlier revision of a %s tag", kind),
+	}, func(ctx context.Context, in *rollbackTagInput) (*rollbackTagOutput, error) {
+		ns := resolveNamespace(in.Namespace, false)
+		name, err := unescapePath("name", in.Name)
+		if err != nil {
+			return nil, err
+		}
+		tag, err := unescapePath("tag", in.Tag)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Authorize != nil {
+			if err := cfg.Authorize(ctx, AuthorizeInput{Verb: "apply", Kind: kind, Namespace: ns, Name: name, Tag: tag}); err != nil {
+				return nil, err
+			}
+		}
+		rev, err := cfg.Store.GetTagRevision(ctx, ns, name, tag, in.Body.Generation)
+		switch {
+		case errors.Is(err, v1alpha1store.ErrTerminating):
+			return nil, huma.Error409Conflict(err.Error())
+		case err != nil:
+			return nil, mapNotFound(err, kind, ns, name, tag)
+		}
+		obj := newObj()
+		obj.SetTypeMeta(rev.TypeMeta)
+		if err := obj.UnmarshalSpec(rev.Spec); err != nil {
+			return nil, huma.Error500InternalServerError("decode "+kind+" revision", err)
+		}
+		obj.SetMetadata(v1alpha1.ObjectMeta{
+			Namespace:   ns,
+			Name:        name,
+			Tag:         tag,
+			Labels:      rev.Metadata.Labels,
+			Annotations: rev.Metadata.Annotations,
+		})
+
+		// The restored content is applied like any other manifest, so a
+		// revision that would no longer be accepted (a ref since yanked or
+		// deleted, a signature the trust policy now rejects) is refused.
+		admitted, ae := applyCore(ctx, cfg.Store, obj, applyOpts{
+			Authorize:         cfg.Authorize,
+			Resolver:          cfg.Resolver,
+			RegistryValidator: cfg.RegistryValidator,
+			PostUpsert:        cfg.PostUpsert,
+			InitialFinalizers: cfg.InitialFinalizers,
+			Admission:         cfg.Admission,
+			Prepare:           cfg.Prepare,
+		}, false)
+		if ae != nil {
+			return nil, mapApplyErrorToHuma(ae, kind, ns, name, tag)
+		}
+		out := &rollbackTagOutput{}
+		out.Body = arv0.RollbackTagResponse{Namespace: ns, Name: name, Tag: admitted.Tag, Generation: admitted.Generation, Status: admitted.Status}
+		if out.Body.Status == "" {
+			out.Body.Status = arv0.ApplyStatusUnchanged
+		}
+		return out, nil
+	})
+}
+
+func tagRevisionToWire(r v1alpha1store.TagRevision) arv0.TagRevision {
+	return arv0.TagRevision{
+		Namespace:   r.Namespace,
+		Name:        r.Name,
+		Tag:         r.Tag,
+		UID:         r.UID,
+		Generation:  r.Generation,
+		ContentHash: r.ContentHash,
+		Current:     r.Current,
+		AppliedAt:   r.AppliedAt,
+		ReplacedAt:  r.ReplacedAt,
+	}
+}
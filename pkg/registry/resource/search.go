@@ -0,0 +1,171 @@
+package resource
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// SearchConfig is the per-server configuration for the cross-kind search
+// endpoint. Stores without a search index (see
+// v1alpha1store.Store.Searchable) are ignored.
+type SearchConfig struct {
+	// BasePrefix is the HTTP route prefix shared with the generic resource
+	// handler (e.g. "/v0"). The endpoint mounts at "{BasePrefix}/search".
+	BasePrefix string
+	// Stores maps Kind to its Store.
+	Stores map[string]*v1alpha1store.Store
+	// Authorizers gates each kind with a Verb="list" AuthorizeInput, the
+	// same hook the kind's list endpoint consults. Missing keys
+	// authorize-allow.
+	Authorizers map[string]func(ctx context.Context, in AuthorizeInput) error
+	// ListFilters scopes each kind's rows exactly as on its list endpoint;
+	// see Config.ListFilter.
+	ListFilters map[string]func(ctx context.Context, in AuthorizeInput) (extraWhere string, extraArgs []any, err error)
+}
+
+// SearchRequest is one cross-kind search. Kinds holds kind names or
+// plurals, case-insensitive; empty searches every searchable kind the
+// caller may list. Namespace "" or "all" searches every namespace.
+type SearchRequest struct {
+	Query     string
+	Kinds     []string
+	Namespace string
+	Limit     int
+}
+
+type searchInput struct {
+	Query     string `query:"q" required:"true" minLength:"1" doc:"Search text. Words are ANDed; \"quoted phrases\" match in order, 'or' separates alternatives and a leading '-' excludes a word."`
+	Kinds     string `query:"kinds" doc:"Comma-separated kinds to search (e.g. 'mcpserver,agent'; plurals accepted). Defaults to every kind the caller may list."`
+	Namespace string `query:"namespace" doc:"Restrict results to one namespace. Defaults to every namespace."`
+	Limit     int    `query:"limit" minimum:"0" maximum:"100" doc:"Max results to return (default 20)."`
+}
+
+type searchOutput struct {
+	Body arv0.SearchResponse
+}
+
+// RegisterSearch wires GET {BasePrefix}/search, a ranked full-text search
+// over name, title, description, labels, MCP tool metadata and plugin
+// inventory across kinds.
+func RegisterSearch(api huma.API, cfg SearchConfig) {
+	huma.Register(api, huma.Operation{
+		OperationID: "search",
+		Method:      http.MethodGet,
+		Path:        cfg.BasePrefix + "/search",
+		Summary:     "Search resources across kinds",
+	}, func(ctx context.Context, in *searchInput) (*searchOutput, error) {
+		var kinds []string
+		for k := range strings.SplitSeq(in.Kinds, ",") {
+			if k = strings.TrimSpace(k); k != "" {
+				kinds = append(kinds, k)
+			}
+		}
+		items, err := Search(ctx, cfg, SearchRequest{Query: in.Query, Kinds: kinds, Namespace: in.Namespace, Limit: in.Limit})
+		if err != nil {
+			return nil, err
+		}
+		out := &searchOutput{}
+		out.Body = arv0.SearchResponse{Query: in.Query, Items: items}
+		return out, nil
+	})
+}
+
+// Search runs req against cfg.Stores as the caller on ctx. Each kind is
+// authorized as a list: a denied kind the caller named explicitly fails
+// the request, while denied kinds are silently skipped when Kinds is
+// empty. Errors are huma errors.
+func Search(ctx context.Context, cfg SearchConfig, req SearchRequest) ([]arv0.SearchResult, error) {
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, huma.Error400BadRequest("search query is required")
+	}
+	ns := req.Namespace
+	if ns == namespaceAll {
+		ns = ""
+	}
+	kinds, err := searchKinds(cfg.Stores, req.Kinds)
+	if err != nil {
+		return nil, err
+	}
+	scopes := make([]v1alpha1store.SearchScope, 0, len(kinds))
+	for _, kind := range kinds {
+		in := AuthorizeInput{Verb: "list", Kind: kind, Namespace: ns}
+		if authorize := cfg.Authorizers[kind]; authorize != nil {
+			if err := authorize(ctx, in); err != nil {
+				if len(req.Kinds) == 0 {
+					continue
+				}
+				return nil, err
+			}
+		}
+		scope := v1alpha1store.SearchScope{Store: cfg.Stores[kind]}
+		if filter := cfg.ListFilters[kind]; filter != nil {
+			if scope.ExtraWhere, scope.ExtraArgs, err = filter(ctx, in); err != nil {
+				return nil, err
+			}
+		}
+		scopes = append(scopes, scope)
+	}
+	hits, err := v1alpha1store.Search(ctx, scopes, v1alpha1store.SearchOpts{Query: req.Query, Namespace: ns, Limit: req.Limit})
+	if err != nil {
+		return nil, huma.Error500InternalServerError("search", err)
+	}
+	items := make([]arv0.SearchResult, 0, len(hits))
+	for _, h := range hits {
+		items = append(items, arv0.SearchResult{
+			Kind:        h.Kind,
+			Namespace:   h.Namespace,
+			Name:        h.Name,
+			Tag:         h.Tag,
+			Title:       h.Title,
+			Description: h.Description,
+			Highlight:   h.Highlight,
+			Score:       h.Rank,
+		})
+	}
+	return items, nil
+}
+
+// searchKinds resolves requested kind names or plurals against the
+// searchable stores, in a stable order. Empty requested means every
+// searchable kind.
+func searchKinds(stores map[string]*v1alpha1store.Store, requested []string) ([]string, error) {
+	byName := map[string]string{}
+	for kind, store := range stores {
+		if !store.Searchable() {
+			continue
+		}
+		byName[strings.ToLower(kind)] = kind
+		byName[strings.ToLower(v1alpha1.PluralFor(kind))] = kind
+	}
+	seen := map[string]bool{}
+	var kinds []string
+	if len(requested) == 0 {
+		for _, kind := range byName {
+			if !seen[kind] {
+				seen[kind] = true
+				kinds = append(kinds, kind)
+			}
+		}
+		sort.Strings(kinds)
+		return kinds, nil
+	}
+	for _, name := range requested {
+		kind, ok := byName[strings.ToLower(name)]
+		if !ok {
+			return nil, huma.Error400BadRequest("kind " + name + " is not searchable")
+		}
+		if !seen[kind] {
+			seen[kind] = true
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds, nil
+}
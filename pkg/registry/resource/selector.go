@@ -0,0 +1,278 @@
+package resource
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// selectorOp is the operator of one label or field selector requirement.
+type selectorOp string
+
+const (
+	opEquals       selectorOp = "="
+	opNotEquals    selectorOp = "!="
+	opIn           selectorOp = "in"
+	opNotIn        selectorOp = "notin"
+	opExists       selectorOp = "exists"
+	opDoesNotExist selectorOp = "!"
+)
+
+// labelRequirement is one comma-separated term of a label selector.
+// Values holds one entry for = / !=, the set for in / notin, and nothing
+// for the existence operators.
+type labelRequirement struct {
+	Key    string
+	Op     selectorOp
+	Values []string
+}
+
+// fieldRequirement is one comma-separated term of a field selector.
+// Only = (or ==) and != are supported, as in Kubernetes.
+type fieldRequirement struct {
+	Path  string
+	Op    selectorOp
+	Value string
+}
+
+var (
+	labelKeyPattern  = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+	setTermPattern   = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\(([^()]*)\)$`)
+	pathSegPattern   = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+	conditionPattern = regexp.MustCompile(`^status\.conditions\[([A-Za-z0-9_.-]+)\]$`)
+)
+
+// parseLabelSelector decodes the Kubernetes label selector syntax:
+//
+//	key=value, key==value, key!=value,
+//	key in (v1,v2), key notin (v1,v2), key, !key
+//
+// Terms are comma-separated and ANDed; commas inside an in / notin set
+// belong to the set. Equality values may contain `=` (the split is on
+// the first operator) but not `,`.
+func parseLabelSelector(s string) ([]labelRequirement, error) {
+	terms, err := splitSelectorTerms(s)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]labelRequirement, 0, len(terms))
+	for _, term := range terms {
+		req, err := parseLabelTerm(term)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, req)
+	}
+	return out, nil
+}
+
+func parseLabelTerm(term string) (labelRequirement, error) {
+	if m := setTermPattern.FindStringSubmatch(term); m != nil {
+		req := labelRequirement{Key: m[1], Op: selectorOp(m[2])}
+		for v := range strings.SplitSeq(m[3], ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				req.Values = append(req.Values, v)
+			}
+		}
+		if len(req.Values) == 0 {
+			return labelRequirement{}, fmt.Errorf("label %q: %s needs at least one value", term, req.Op)
+		}
+		return req, validateLabelKey(term, req.Key)
+	}
+	for _, op := range []string{"!=", "==", "="} {
+		i := strings.Index(term, op)
+		if i < 0 {
+			continue
+		}
+		req := labelRequirement{Key: strings.TrimSpace(term[:i]), Op: opEquals, Values: []string{strings.TrimSpace(term[i+len(op):])}}
+		if op == "!=" {
+			req.Op = opNotEquals
+		}
+		return req, validateLabelKey(term, req.Key)
+	}
+	if key, ok := strings.CutPrefix(term, "!"); ok {
+		req := labelRequirement{Key: strings.TrimSpace(key), Op: opDoesNotExist}
+		return req, validateLabelKey(term, req.Key)
+	}
+	return labelRequirement{Key: term, Op: opExists}, validateLabelKey(term, term)
+}
+
+func validateLabelKey(term, key string) error {
+	if key == "" {
+		return fmt.Errorf("label %q has empty key", term)
+	}
+	if !labelKeyPattern.MatchString(key) {
+		return fmt.Errorf("label %q: invalid key %q", term, key)
+	}
+	return nil
+}
+
+// parseFieldSelector decodes "path=value,path!=value". Supported paths
+// are metadata.name, metadata.namespace, metadata.tag (tagged kinds),
+// status.conditions[Type] (matched against that condition's status) and
+// any dotted path under spec or status, compared as text.
+func parseFieldSelector(s string) ([]fieldRequirement, error) {
+	terms, err := splitSelectorTerms(s)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]fieldRequirement, 0, len(terms))
+	for _, term := range terms {
+		var req fieldRequirement
+		for _, op := range []string{"!=", "==", "="} {
+			if i := strings.Index(term, op); i >= 0 {
+				req = fieldRequirement{Path: strings.TrimSpace(term[:i]), Op: opEquals, Value: strings.TrimSpace(term[i+len(op):])}
+				if op == "!=" {
+					req.Op = opNotEquals
+				}
+				break
+			}
+		}
+		if req.Op == "" {
+			return nil, fmt.Errorf("field %q must be path=value or path!=value", term)
+		}
+		if req.Path == "" {
+			return nil, fmt.Errorf("field %q has empty path", term)
+		}
+		out = append(out, req)
+	}
+	return out, nil
+}
+
+// splitSelectorTerms splits s on the commas that are not inside an
+// in / notin set, dropping empty terms.
+func splitSelectorTerms(s string) ([]string, error) {
+	var (
+		terms []string
+		depth int
+		start int
+	)
+	flush := func(end int) {
+		if term := strings.TrimSpace(s[start:end]); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, errors.New("unbalanced parentheses")
+			}
+		case ',':
+			if depth == 0 {
+				flush(i)
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, errors.New("unbalanced parentheses")
+	}
+	flush(len(s))
+	return terms, nil
+}
+
+// applyLabelSelector compiles reqs into opts. The first equality per key
+// uses ListOpts.LabelSelector so it can hit the labels GIN index; every
+// other term becomes a parameterized ExtraWhere predicate. As in
+// Kubernetes, != and notin also match objects without the label.
+func applyLabelSelector(opts *v1alpha1store.ListOpts, reqs []labelRequirement) error {
+	for _, req := range reqs {
+		switch req.Op {
+		case opEquals:
+			if _, dup := opts.LabelSelector[req.Key]; !dup {
+				if opts.LabelSelector == nil {
+					opts.LabelSelector = map[string]string{}
+				}
+				opts.LabelSelector[req.Key] = req.Values[0]
+				continue
+			}
+			sel, err := json.Marshal(map[string]string{req.Key: req.Values[0]})
+			if err != nil {
+				return err
+			}
+			appendExtraWhere(opts, "labels @> $%d::jsonb", sel)
+		case opNotEquals:
+			sel, err := json.Marshal(map[string]string{req.Key: req.Values[0]})
+			if err != nil {
+				return err
+			}
+			appendExtraWhere(opts, "NOT (labels @> $%d::jsonb)", sel)
+		case opIn:
+			appendExtraWhere(opts, "(labels ->> $%d) = ANY($%d::text[])", req.Key, req.Values)
+		case opNotIn:
+			appendExtraWhere(opts, "NOT COALESCE((labels ->> $%d) = ANY($%d::text[]), false)", req.Key, req.Values)
+		case opExists:
+			appendExtraWhere(opts, "(labels ->> $%d) IS NOT NULL", req.Key)
+		case opDoesNotExist:
+			appendExtraWhere(opts, "(labels ->> $%d) IS NULL", req.Key)
+		default:
+			return fmt.Errorf("unsupported label operator %q", req.Op)
+		}
+	}
+	return nil
+}
+
+// applyFieldSelector compiles reqs into parameterized ExtraWhere
+// predicates. Column names come from a fixed set; every user-supplied
+// path segment and value is a bind parameter. tagged reports whether the
+// store has a tag column. A spec / status path that is missing on a row
+// matches != but never =.
+func applyFieldSelector(opts *v1alpha1store.ListOpts, reqs []fieldRequirement, tagged bool) error {
+	for _, req := range reqs {
+		eq, neq := "%s = $%%d", "%s <> $%%d"
+		switch {
+		case req.Path == "metadata.name":
+			appendFieldPredicate(opts, req, fmt.Sprintf(eq, "name"), fmt.Sprintf(neq, "name"), req.Value)
+		case req.Path == "metadata.namespace":
+			appendFieldPredicate(opts, req, fmt.Sprintf(eq, "namespace"), fmt.Sprintf(neq, "namespace"), req.Value)
+		case req.Path == "metadata.tag":
+			if !tagged {
+				return fmt.Errorf("field %q is only supported on tagged kinds", req.Path)
+			}
+			appendFieldPredicate(opts, req, fmt.Sprintf(eq, "tag"), fmt.Sprintf(neq, "tag"), req.Value)
+		case conditionPattern.MatchString(req.Path):
+			condType := conditionPattern.FindStringSubmatch(req.Path)[1]
+			sel, err := json.Marshal([]map[string]string{{"type": condType, "status": req.Value}})
+			if err != nil {
+				return err
+			}
+			contains := "COALESCE(status -> 'conditions', '[]'::jsonb) @> $%d::jsonb"
+			appendFieldPredicate(opts, req, contains, "NOT ("+contains+")", sel)
+		default:
+			column, rest, _ := strings.Cut(req.Path, ".")
+			if column != "spec" && column != "status" {
+				return fmt.Errorf("field %q: path must start with metadata., spec. or status.", req.Path)
+			}
+			segments := strings.Split(rest, ".")
+			for _, seg := range segments {
+				if !pathSegPattern.MatchString(seg) {
+					return fmt.Errorf("field %q: invalid path segment %q", req.Path, seg)
+				}
+			}
+			if req.Op == opEquals {
+				appendExtraWhere(opts, "("+column+" #>> $%d::text[]) = $%d", segments, req.Value)
+			} else {
+				appendExtraWhere(opts, "("+column+" #>> $%d::text[]) IS DISTINCT FROM $%d", segments, req.Value)
+			}
+		}
+	}
+	return nil
+}
+
+// appendFieldPredicate appends eq or neq, each with one placeholder,
+// according to req.Op.
+func appendFieldPredicate(opts *v1alpha1store.ListOpts, req fieldRequirement, eq, neq string, arg any) {
+	if req.Op == opEquals {
+		appendExtraWhere(opts, eq, arg)
+		return
+	}
+	appendExtraWhere(opts, neq, arg)
+}
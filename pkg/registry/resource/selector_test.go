@@ -0,0 +1,67 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	got, err := parseLabelSelector("app=web, tier==front,env!=prod,team in (a, b),zone notin (x),canary,!legacy,url=a=b")
+	require.NoError(t, err)
+	require.Equal(t, []labelRequirement{
+		{Key: "app", Op: opEquals, Values: []string{"web"}},
+		{Key: "tier", Op: opEquals, Values: []string{"front"}},
+		{Key: "env", Op: opNotEquals, Values: []string{"prod"}},
+		{Key: "team", Op: opIn, Values: []string{"a", "b"}},
+		{Key: "zone", Op: opNotIn, Values: []string{"x"}},
+		{Key: "canary", Op: opExists},
+		{Key: "legacy", Op: opDoesNotExist},
+		{Key: "url", Op: opEquals, Values: []string{"a=b"}},
+	}, got)
+
+	for _, bad := range []string{"=web", "team in ()", "team in (a", "bad key", "!"} {
+		_, err := parseLabelSelector(bad)
+		require.Error(t, err, bad)
+	}
+}
+
+func TestApplyLabelSelector(t *testing.T) {
+	reqs, err := parseLabelSelector("app=web,app=api,env!=prod,team in (a,b),zone notin (x),canary,!legacy")
+	require.NoError(t, err)
+	opts := v1alpha1store.ListOpts{ExtraWhere: "owner = $1", ExtraArgs: []any{"alice"}}
+	require.NoError(t, applyLabelSelector(&opts, reqs))
+
+	require.Equal(t, map[string]string{"app": "web"}, opts.LabelSelector)
+	require.Equal(t, "((((((owner = $1) AND (labels @> $2::jsonb)) AND (NOT (labels @> $3::jsonb))) AND "+
+		"((labels ->> $4) = ANY($5::text[]))) AND (NOT COALESCE((labels ->> $6) = ANY($7::text[]), false))) AND "+
+		"((labels ->> $8) IS NOT NULL)) AND ((labels ->> $9) IS NULL)", opts.ExtraWhere)
+	require.Equal(t, []any{
+		"alice", []byte(`{"app":"api"}`), []byte(`{"env":"prod"}`),
+		"team", []string{"a", "b"}, "zone", []string{"x"}, "canary", "legacy",
+	}, opts.ExtraArgs)
+}
+
+func TestApplyFieldSelector(t *testing.T) {
+	reqs, err := parseFieldSelector("metadata.name=bot,spec.runtimeRef.name=prod,status.conditions[Ready]!=True,status.phase!=Failed")
+	require.NoError(t, err)
+	var opts v1alpha1store.ListOpts
+	require.NoError(t, applyFieldSelector(&opts, reqs, false))
+	require.Equal(t, "(((name = $1) AND ((spec #>> $2::text[]) = $3)) AND "+
+		"(NOT (COALESCE(status -> 'conditions', '[]'::jsonb) @> $4::jsonb))) AND "+
+		"((status #>> $5::text[]) IS DISTINCT FROM $6)", opts.ExtraWhere)
+	require.Equal(t, []any{
+		"bot", []string{"runtimeRef", "name"}, "prod",
+		[]byte(`[{"status":"True","type":"Ready"}]`), []string{"phase"}, "Failed",
+	}, opts.ExtraArgs)
+
+	for _, bad := range []string{"metadata.tag=1.0.0", "spec.a;drop=1", "labels.app=web", "spec.name"} {
+		reqs, err := parseFieldSelector(bad)
+		if err == nil {
+			err = applyFieldSelector(&v1alpha1store.ListOpts{}, reqs, false)
+		}
+		require.Error(t, err, bad)
+	}
+}
@@ -0,0 +1,402 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// WatchSource backs ?watch=true on list routes. Events is the durable
+// control-plane event log streams replay; Notifier wakes them when it
+// grows and carries the live status changes the log does not record.
+type WatchSource struct {
+	Events   *v1alpha1store.ControlPlaneEventStore
+	Notifier *v1alpha1store.ChangeNotifier
+}
+
+const (
+	// watchHeartbeatInterval bounds how long an idle stream goes without
+	// a write, so proxies keep it open and a missed notification is
+	// caught up on by the next replay.
+	watchHeartbeatInterval = 30 * time.Second
+	watchPageSize          = 500
+)
+
+// watchMiddleware diverts ?watch=true requests on a list route to
+// serveWatch. The list handler's typed output cannot carry a stream, so
+// the watch is served before huma parses the list input; every other
+// request passes through untouched.
+func watchMiddleware[T v1alpha1.Object](api huma.API, cfg Config, newObj func() T) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		if watch, _ := strconv.ParseBool(ctx.Query("watch")); !watch {
+			next(ctx)
+			return
+		}
+		serveWatch(api, ctx, cfg, newObj)
+	}
+}
+
+// serveWatch streams changes to a list as Server-Sent Events, one
+// arv0.WatchEvent per event.
+//
+// Without a resourceVersion the stream first sends every object the list
+// currently returns as ADDED, then a BOOKMARK. With one it replays
+// control_plane_events after that revision instead, or answers 410 Gone
+// when RetentionPruner has already pruned part of the gap. Both then tail
+// the event log.
+//
+// Delivery is at least once: an object changed while the initial listing
+// runs may be reported again. Status-only writes do not enter the event
+// log, so they are reported live as MODIFIED but are not replayed on
+// resume. An object that stops matching the list's filters is reported as
+// DELETED; DELETED objects carry identity only.
+func serveWatch[T v1alpha1.Object](api huma.API, hctx huma.Context, cfg Config, newObj func() T) {
+	ctx := hctx.Context()
+	p, resourceVersion, err := watchParams(hctx, cfg)
+	if err == nil && cfg.Authorize != nil {
+		err = cfg.Authorize(ctx, AuthorizeInput{Verb: "list", Kind: cfg.Kind, Namespace: p.Namespace})
+	}
+	var opts v1alpha1store.ListOpts
+	if err == nil {
+		opts, err = listOptsFor(ctx, cfg, p)
+	}
+	if err != nil {
+		writeWatchError(api, hctx, err)
+		return
+	}
+
+	notifications, unsubscribe := cfg.Watch.Notifier.Subscribe()
+	defer unsubscribe()
+	w := &watchStream[T]{
+		cfg:     cfg,
+		newObj:  newObj,
+		opts:    opts,
+		tagged:  cfg.Store.Behavior() == v1alpha1store.TaggedArtifactStore,
+		out:     hctx.BodyWriter(),
+		rv:      resourceVersion,
+		resumed: resourceVersion > 0,
+		seen:    map[watchKey]bool{},
+	}
+	if w.resumed {
+		err = w.checkRetained(ctx)
+	} else {
+		w.rv, err = cfg.Watch.Events.CurrentRevision(ctx)
+	}
+	if err != nil {
+		writeWatchError(api, hctx, err)
+		return
+	}
+
+	hctx.SetHeader("Content-Type", "text/event-stream")
+	hctx.SetHeader("Cache-Control", "no-cache")
+	hctx.SetHeader("X-Accel-Buffering", "no")
+	hctx.SetStatus(http.StatusOK)
+	if err := w.flush(); err != nil {
+		return
+	}
+	if !w.resumed {
+		if err := w.sendInitial(ctx); err != nil {
+			w.fail(err)
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(watchHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case n := <-notifications:
+			switch n.Channel {
+			case v1alpha1store.ControlPlaneNotifyChannel:
+				err = w.replay(ctx)
+			case cfg.Store.StatusChannel():
+				err = w.statusChanged(ctx, n.Payload)
+			}
+		case <-heartbeat.C:
+			if err = w.replay(ctx); err == nil {
+				err = w.write(": heartbeat\n\n")
+			}
+		}
+		if err != nil {
+			w.fail(err)
+			return
+		}
+	}
+}
+
+// watchParams reads the list and watch query parameters of a watch
+// request; huma has not parsed them yet. The Last-Event-ID header an
+// EventSource sends on reconnect stands in for a missing resourceVersion.
+func watchParams(ctx huma.Context, cfg Config) (listParams, int64, error) {
+	p := listParams{
+		Namespace:     resolveNamespace(ctx.Query("namespace"), true),
+		Labels:        ctx.Query("labels"),
+		FieldSelector: ctx.Query("fieldSelector"),
+		Tag:           ctx.Query("tag"),
+	}
+	if cfg.EnableOriginFilter {
+		p.Origin = ctx.Query("origin")
+	}
+	for name, dst := range map[string]*bool{"latestOnly": &p.LatestOnly, "includeTerminating": &p.IncludeTerminating} {
+		if raw := ctx.Query(name); raw != "" {
+			v, err := strconv.ParseBool(raw)
+			if err != nil {
+				return listParams{}, 0, huma.Error400BadRequest("invalid " + name + ": expected a boolean")
+			}
+			*dst = v
+		}
+	}
+	raw := ctx.Query("resourceVersion")
+	if raw == "" {
+		raw = ctx.Header("Last-Event-ID")
+	}
+	var rv int64
+	if raw != "" {
+		var err error
+		if rv, err = strconv.ParseInt(raw, 10, 64); err != nil || rv < 0 {
+			return listParams{}, 0, huma.Error400BadRequest("invalid resourceVersion: expected a non-negative integer")
+		}
+	}
+	return p, rv, nil
+}
+
+// writeWatchError answers a watch request that failed before the stream
+// started with a regular huma error response.
+func writeWatchError(api huma.API, ctx huma.Context, err error) {
+	var statusErr huma.StatusError
+	if !errors.As(err, &statusErr) {
+		statusErr = huma.Error500InternalServerError("watch", err)
+	}
+	_ = huma.WriteErr(api, ctx, statusErr.GetStatus(), statusErr.Error())
+}
+
+type watchKey struct {
+	namespace, name, tag string
+}
+
+// watchStream is the state of one watch: the list filters, the revision
+// the stream has reached and the objects it has reported as present.
+type watchStream[T v1alpha1.Object] struct {
+	cfg     Config
+	newObj  func() T
+	opts    v1alpha1store.ListOpts
+	tagged  bool
+	out     io.Writer
+	rv      int64
+	resumed bool
+	seen    map[watchKey]bool
+}
+
+// checkRetained fails with 410 when events after w.rv have been pruned,
+// using the same gap rule as the Deployment controller's Sync.
+func (w *watchStream[T]) checkRetained(ctx context.Context) error {
+	oldest, ok, err := w.cfg.Watch.Events.OldestRevision(ctx)
+	if err != nil {
+		return huma.Error500InternalServerError("watch "+w.cfg.Kind, err)
+	}
+	if ok && w.rv < oldest-1 {
+		return huma.Error410Gone(fmt.Sprintf("resourceVersion %d is too old; the oldest retained revision is %d", w.rv, oldest))
+	}
+	return nil
+}
+
+// sendInitial reports every object the list currently returns as ADDED,
+// followed by a BOOKMARK.
+func (w *watchStream[T]) sendInitial(ctx context.Context) error {
+	opts := w.opts
+	opts.Limit = watchPageSize
+	for {
+		rows, next, err := w.cfg.Store.List(ctx, opts)
+		if err != nil {
+			return huma.Error500InternalServerError("list "+w.cfg.Kind, err)
+		}
+		for _, row := range rows {
+			if err := w.sendObject(arv0.WatchEventAdded, row); err != nil {
+				return err
+			}
+		}
+		if next == "" {
+			break
+		}
+		opts.Cursor = next
+	}
+	return w.send(arv0.WatchEvent{Type: arv0.WatchEventBookmark, ResourceVersion: w.rv})
+}
+
+// replay reports the events recorded for the kind after w.rv.
+func (w *watchStream[T]) replay(ctx context.Context) error {
+	for {
+		if err := w.checkRetained(ctx); err != nil {
+			return err
+		}
+		events, err := w.cfg.Watch.Events.ListAfter(ctx, w.rv, watchPageSize)
+		if err != nil {
+			return huma.Error500InternalServerError("watch "+w.cfg.Kind, err)
+		}
+		for _, event := range events {
+			w.rv = event.Revision
+			if event.Key.Kind != w.cfg.Kind {
+				continue
+			}
+			key := watchKey{namespace: event.Key.Namespace, name: event.Key.Name, tag: event.Key.Tag}
+			if event.Operation == "delete" {
+				err = w.deleted(key, event.UID)
+			} else {
+				err = w.changed(ctx, key, event.Operation == "insert")
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if len(events) < watchPageSize {
+			return nil
+		}
+	}
+}
+
+// statusChanged reports a status write announced on the kind's status
+// channel. Inserts and deletes also reach the event log and are left to
+// replay.
+func (w *watchStream[T]) statusChanged(ctx context.Context, payload string) error {
+	var change struct {
+		Op        string `json:"op"`
+		Namespace string `json:"namespace"`
+		Name      string `json:"name"`
+		Tag       string `json:"tag"`
+	}
+	if err := json.Unmarshal([]byte(payload), &change); err != nil || change.Op != "UPDATE" {
+		return nil
+	}
+	return w.changed(ctx, watchKey{namespace: change.Namespace, name: change.Name, tag: change.Tag}, false)
+}
+
+// changed re-reads key through the list's filters and reports it as
+// ADDED or MODIFIED, or as DELETED when a reported object stopped
+// matching. Unreported objects that do not match are skipped, which also
+// drops the events tag aliases record under the alias name.
+func (w *watchStream[T]) changed(ctx context.Context, key watchKey, inserted bool) error {
+	if w.opts.Namespace != "" && key.namespace != w.opts.Namespace {
+		return nil
+	}
+	opts := w.opts
+	opts.Namespace = key.namespace
+	opts.Cursor = ""
+	opts.Limit = 1
+	opts.ExtraArgs = slices.Clone(opts.ExtraArgs)
+	appendExtraWhere(&opts, "name = $%d", key.name)
+	if w.tagged {
+		appendExtraWhere(&opts, "tag = $%d", key.tag)
+	}
+	rows, _, err := w.cfg.Store.List(ctx, opts)
+	if err != nil {
+		return huma.Error500InternalServerError("watch "+w.cfg.Kind, err)
+	}
+	if len(rows) == 0 {
+		if !w.seen[key] {
+			return nil
+		}
+		return w.deleted(key, "")
+	}
+	eventType := arv0.WatchEventModified
+	if !w.seen[key] && (!w.resumed || inserted) {
+		eventType = arv0.WatchEventAdded
+	}
+	return w.sendObject(eventType, rows[0])
+}
+
+// deleted reports key as DELETED. A resumed stream has not listed the
+// objects the client holds, so it reports every delete the list's
+// namespace covers; otherwise only objects it reported before.
+func (w *watchStream[T]) deleted(key watchKey, uid string) error {
+	if w.opts.Namespace != "" && key.namespace != w.opts.Namespace {
+		return nil
+	}
+	if !w.seen[key] && (!w.resumed || w.filtered()) {
+		return nil
+	}
+	delete(w.seen, key)
+	obj, err := json.Marshal(v1alpha1.RawObject{
+		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: w.cfg.Kind},
+		Metadata: v1alpha1.ObjectMeta{Namespace: key.namespace, Name: key.name, Tag: key.tag, UID: uid},
+	})
+	if err != nil {
+		return huma.Error500InternalServerError("encode "+w.cfg.Kind, err)
+	}
+	return w.send(arv0.WatchEvent{Type: arv0.WatchEventDeleted, ResourceVersion: w.rv, Object: obj})
+}
+
+// filtered reports whether the list narrows the namespace's objects, in
+// which case a delete of an object the stream never reported may lie
+// outside it.
+func (w *watchStream[T]) filtered() bool {
+	return w.opts.ExtraWhere != "" || w.opts.Tag != "" || w.opts.LatestOnly
+}
+
+func (w *watchStream[T]) sendObject(eventType string, row *v1alpha1.RawObject) error {
+	obj, err := v1alpha1.EnvelopeFromRaw(w.newObj, row, w.cfg.Kind)
+	if err != nil {
+		return huma.Error500InternalServerError("decode "+w.cfg.Kind, err)
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return huma.Error500InternalServerError("encode "+w.cfg.Kind, err)
+	}
+	w.seen[watchKey{namespace: row.Metadata.Namespace, name: row.Metadata.Name, tag: row.Metadata.Tag}] = true
+	return w.send(arv0.WatchEvent{Type: eventType, ResourceVersion: w.rv, Object: data})
+}
+
+// fail ends the stream with an ERROR event describing err.
+func (w *watchStream[T]) fail(err error) {
+	var statusErr huma.StatusError
+	if !errors.As(err, &statusErr) {
+		statusErr = huma.Error500InternalServerError("watch "+w.cfg.Kind, err)
+	}
+	_ = w.send(arv0.WatchEvent{
+		Type:            arv0.WatchEventError,
+		ResourceVersion: w.rv,
+		Status:          statusErr.GetStatus(),
+		Message:         statusErr.Error(),
+	})
+}
+
+// send writes one event; its id is the resourceVersion, so an
+// EventSource resumes from it on reconnect.
+func (w *watchStream[T]) send(event arv0.WatchEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return w.write(fmt.Sprintf("event: %s\nid: %d\ndata: %s\n\n", event.Type, event.ResourceVersion, data))
+}
+
+func (w *watchStream[T]) write(s string) error {
+	if _, err := io.WriteString(w.out, s); err != nil {
+		return err
+	}
+	return w.flush()
+}
+
+func (w *watchStream[T]) flush() error {
+	rw, ok := w.out.(http.ResponseWriter)
+	if !ok {
+		return nil
+	}
+	if err := http.NewResponseController(rw).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
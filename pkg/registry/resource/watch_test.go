@@ -0,0 +1,167 @@
+//go:build integration
+
+package resource_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/require"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+// openWatch starts a watch on path and returns its decoded events.
+func openWatch(t *testing.T, srv *httptest.Server, path string) <-chan arv0.WatchEvent {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+path, nil)
+	require.NoError(t, err)
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := make(chan arv0.WatchEvent, 64)
+	go func() {
+		defer resp.Body.Close()
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(nil, 1<<20)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+			if !ok {
+				continue
+			}
+			var event arv0.WatchEvent
+			if json.Unmarshal([]byte(data), &event) == nil {
+				events <- event
+			}
+		}
+	}()
+	return events
+}
+
+// nextEvent returns the next event and its decoded object, if any.
+func nextEvent(t *testing.T, events <-chan arv0.WatchEvent) (arv0.WatchEvent, v1alpha1.RawObject) {
+	t.Helper()
+	select {
+	case event := <-events:
+		var obj v1alpha1.RawObject
+		if len(event.Object) > 0 {
+			require.NoError(t, json.Unmarshal(event.Object, &obj))
+		}
+		return event, obj
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for a watch event")
+	}
+	return arv0.WatchEvent{}, v1alpha1.RawObject{}
+}
+
+func requireEvent(t *testing.T, events <-chan arv0.WatchEvent, eventType, name string) arv0.WatchEvent {
+	t.Helper()
+	event, obj := nextEvent(t, events)
+	require.Equal(t, eventType, event.Type, "event %+v", event)
+	require.Equal(t, name, obj.Metadata.Name)
+	return event
+}
+
+func TestResourceRegister_Watch(t *testing.T) {
+	ctx := context.Background()
+	pool := v1alpha1store.NewTestPool(t)
+	store := v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents", v1alpha1store.WithKind(v1alpha1.KindAgent))
+	events := v1alpha1store.NewControlPlaneEventStore(pool, v1alpha1store.TestSchema())
+	_, api := humatest.New(t)
+	resource.Register[*v1alpha1.Agent](api, resource.Config{
+		Kind:       v1alpha1.KindAgent,
+		BasePrefix: "/v0",
+		Store:      store,
+		Watch: &resource.WatchSource{
+			Events:   events,
+			Notifier: v1alpha1store.NewChangeNotifier(pool, store.StatusChannel()),
+		},
+	}, func() *v1alpha1.Agent { return &v1alpha1.Agent{} })
+	srv := httptest.NewServer(api.Adapter())
+	t.Cleanup(srv.Close)
+
+	upsert := func(name, description string) {
+		t.Helper()
+		_, err := store.Upsert(ctx, &v1alpha1.Agent{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: name, Tag: "1"},
+			Spec:     v1alpha1.AgentSpec{Description: description},
+		})
+		require.NoError(t, err)
+	}
+	upsert("alpha", "first")
+
+	// Without a resourceVersion the stream lists current objects first.
+	stream := openWatch(t, srv, "/v0/agents?watch=true")
+	requireEvent(t, stream, arv0.WatchEventAdded, "alpha")
+	bookmark, _ := nextEvent(t, stream)
+	require.Equal(t, arv0.WatchEventBookmark, bookmark.Type)
+	start := bookmark.ResourceVersion
+	// A label selector narrows the stream; bravo never matches.
+	filtered := openWatch(t, srv, "/v0/agents?watch=true&labels=team%3Da")
+	bookmark, _ = nextEvent(t, filtered)
+	require.Equal(t, arv0.WatchEventBookmark, bookmark.Type)
+
+	upsert("bravo", "second")
+	requireEvent(t, stream, arv0.WatchEventAdded, "bravo")
+	upsert("alpha", "changed")
+	event := requireEvent(t, stream, arv0.WatchEventModified, "alpha")
+	require.Greater(t, event.ResourceVersion, start)
+	require.NoError(t, store.PatchStatus(ctx, "default", "alpha", "1", func(json.RawMessage) (json.RawMessage, error) {
+		return json.RawMessage(`{"observedGeneration":1}`), nil
+	}))
+	requireEvent(t, stream, arv0.WatchEventModified, "alpha")
+	require.NoError(t, store.Delete(ctx, "default", "bravo", "1"))
+	requireEvent(t, stream, arv0.WatchEventDeleted, "bravo")
+
+	_, err := store.Upsert(ctx, &v1alpha1.Agent{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "charlie", Tag: "1", Labels: map[string]string{"team": "a"}},
+	})
+	require.NoError(t, err)
+	requireEvent(t, filtered, arv0.WatchEventAdded, "charlie")
+
+	// Resuming replays the event log after the revision; the status-only
+	// write is not in it.
+	resumed := openWatch(t, srv, "/v0/agents?watch=true&resourceVersion="+strconv.FormatInt(start, 10))
+	requireEvent(t, resumed, arv0.WatchEventAdded, "bravo")
+	requireEvent(t, resumed, arv0.WatchEventModified, "alpha")
+	requireEvent(t, resumed, arv0.WatchEventDeleted, "bravo")
+	requireEvent(t, resumed, arv0.WatchEventAdded, "charlie")
+
+	// Once the gap is pruned the revision is gone.
+	current, err := events.CurrentRevision(ctx)
+	require.NoError(t, err)
+	_, err = events.PruneBefore(ctx, time.Time{}, current, 0)
+	require.NoError(t, err)
+	resp, err := srv.Client().Get(srv.URL + "/v0/agents?watch=true&resourceVersion=" + strconv.FormatInt(start, 10))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusGone, resp.StatusCode)
+
+	resp, err = srv.Client().Get(srv.URL + "/v0/agents?watch=true&resourceVersion=abc")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestResourceRegister_WatchUnsupportedWithoutSource(t *testing.T) {
+	pool := v1alpha1store.NewTestPool(t)
+	_, api := humatest.New(t)
+	registerAgent(api, v1alpha1store.NewStore(pool, v1alpha1store.TestSchema(), "agents"))
+	resp := api.Get("/v0/agents?watch=true")
+	require.Equal(t, http.StatusBadRequest, resp.Code, resp.Body.String())
+}
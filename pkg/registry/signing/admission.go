@@ -0,0 +1,121 @@
+package signing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+// ErrNotVerified is returned by RequireVerified for an artifact whose
+// status does not record a verified signature.
+var ErrNotVerified = errors.New("signature not verified")
+
+// Admission wraps next so every tagged-artifact apply is checked against
+// policy first. A signature that fails verification rejects the apply,
+// dry-runs included; unsigned artifacts are admitted. Once next has
+// written the row, the outcome is recorded as the VerifiedCondition on the
+// row's status, so Deployments can require verified targets.
+func Admission(policy *TrustPolicy, next types.Admission) types.Admission {
+	return func(ctx context.Context, in types.AdmissionInput) (types.AdmissionResult, error) {
+		if !v1alpha1.IsTaggedArtifactKind(in.Kind) {
+			return next(ctx, in)
+		}
+		verification, err := policy.Verify(in.Object)
+		if err != nil {
+			return types.AdmissionResult{}, huma.Error400BadRequest("signature: " + err.Error())
+		}
+		result, err := next(ctx, in)
+		if err != nil || in.DryRun {
+			return result, err
+		}
+		store, ok := in.Store.(*v1alpha1store.Store)
+		if !ok || store == nil {
+			return result, nil
+		}
+		tag := result.Tag
+		if tag == "" {
+			tag = in.Tag
+		}
+		condition := v1alpha1.Condition{
+			Type:               VerifiedCondition,
+			Status:             verification.Status,
+			Reason:             verification.Reason,
+			Message:            verification.Message,
+			ObservedGeneration: result.Generation,
+		}
+		err = store.PatchStatus(ctx, in.Namespace, in.Name, tag, func(current json.RawMessage) (json.RawMessage, error) {
+			return setCondition(current, condition)
+		})
+		// A downstream admission may have staged the write instead of
+		// storing it; there is no row to record the outcome on yet.
+		if err != nil && !errors.Is(err, pkgdb.ErrNotFound) {
+			return types.AdmissionResult{}, fmt.Errorf("record signature verification: %w", err)
+		}
+		return result, nil
+	}
+}
+
+// setCondition sets c on a stored status payload. Kind-specific top-level
+// keys (MCPServer capabilities, Skill resolvedSource, ...) are carried over
+// untouched, since this runs for every tagged kind without knowing its
+// status shape.
+func setCondition(current json.RawMessage, c v1alpha1.Condition) (json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	if len(current) > 0 {
+		if err := json.Unmarshal(current, &fields); err != nil {
+			return nil, err
+		}
+	}
+	var status v1alpha1.Status
+	if err := v1alpha1.UnmarshalStatusFromStorage(current, &status); err != nil {
+		return nil, err
+	}
+	status.SetCondition(c)
+	base, err := v1alpha1.MarshalStatusForStorage(status)
+	if err != nil {
+		return nil, err
+	}
+	updated := map[string]json.RawMessage{}
+	if err := json.Unmarshal(base, &updated); err != nil {
+		return nil, err
+	}
+	maps.Copy(fields, updated)
+	return json.Marshal(fields)
+}
+
+// RequireVerified returns ErrNotVerified unless obj's status records a
+// verified signature for its current generation.
+func RequireVerified(obj v1alpha1.Object) error {
+	meta := obj.GetMetadata()
+	raw, err := obj.MarshalStatus()
+	if err != nil {
+		return err
+	}
+	var status v1alpha1.Status
+	if err := v1alpha1.UnmarshalStatusFromStorage(raw, &status); err != nil {
+		return err
+	}
+	ref := fmt.Sprintf("%s %s/%s", obj.GetKind(), meta.NamespaceOrDefault(), meta.Name)
+	if meta.Tag != "" {
+		ref += ":" + meta.Tag
+	}
+	c := status.GetCondition(VerifiedCondition)
+	switch {
+	case c == nil:
+		return fmt.Errorf("%w: %s has no signature verification recorded", ErrNotVerified, ref)
+	case c.Status != v1alpha1.ConditionTrue:
+		return fmt.Errorf("%w: %s: %s", ErrNotVerified, ref, c.Message)
+	case c.ObservedGeneration != meta.Generation:
+		return fmt.Errorf("%w: %s: verification is for generation %d, current is %d", ErrNotVerified, ref, c.ObservedGeneration, meta.Generation)
+	}
+	return nil
+}
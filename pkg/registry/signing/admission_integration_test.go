@@ -0,0 +1,153 @@
+//go:build integration
+
+package signing_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/require"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/resource"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/signing"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+func TestAdmission_RecordsVerificationInStatus(t *testing.T) {
+	ctx := context.Background()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	policy, err := signing.ParseTrustPolicy([]byte(`namespaces:
+  default:
+    - name: release
+      publicKey: "`+pemLine(der)+`"
+`), "")
+	require.NoError(t, err)
+
+	store := v1alpha1store.NewStore(v1alpha1store.NewTestPool(t), v1alpha1store.TestSchema(), "agents",
+		v1alpha1store.WithKind(v1alpha1.KindAgent))
+	admit := signing.Admission(policy, resource.ProductionAdmission)
+	apply := func(agent *v1alpha1.Agent) (types.AdmissionResult, error) {
+		return admit(ctx, types.AdmissionInput{
+			Verb: "apply", Kind: v1alpha1.KindAgent,
+			Namespace: "default", Name: agent.Metadata.Name, Tag: agent.Metadata.Tag,
+			Object: agent, Store: store,
+		})
+	}
+	condition := func(tag string) *v1alpha1.Condition {
+		raw, err := store.Get(ctx, "default", "acme-bot", tag)
+		require.NoError(t, err)
+		agent, err := v1alpha1.EnvelopeFromRaw(func() *v1alpha1.Agent { return &v1alpha1.Agent{} }, raw, v1alpha1.KindAgent)
+		require.NoError(t, err)
+		c := agent.Status.GetCondition(signing.VerifiedCondition)
+		require.NotNil(t, c)
+		require.Equal(t, agent.Metadata.Generation, c.ObservedGeneration)
+		return c
+	}
+	newAgent := func(tag, title string) *v1alpha1.Agent {
+		return &v1alpha1.Agent{
+			TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindAgent},
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "acme-bot", Tag: tag},
+			Spec:     v1alpha1.AgentSpec{Title: title},
+		}
+	}
+
+	signed := newAgent("1.0.0", "summarizer")
+	require.NoError(t, signing.Sign(signed, key, "release"))
+	res, err := apply(signed)
+	require.NoError(t, err)
+	require.Equal(t, arv0.ApplyStatusCreated, res.Status)
+	c := condition("1.0.0")
+	require.Equal(t, v1alpha1.ConditionTrue, c.Status)
+	require.Equal(t, signing.ReasonVerified, c.Reason)
+
+	_, err = apply(newAgent("1.1.0", "unsigned"))
+	require.NoError(t, err, "unsigned artifacts are admitted")
+	c = condition("1.1.0")
+	require.Equal(t, v1alpha1.ConditionFalse, c.Status)
+	require.Equal(t, signing.ReasonUnsigned, c.Reason)
+
+	tampered := newAgent("1.0.0", "summarizer")
+	require.NoError(t, signing.Sign(tampered, key, "release"))
+	tampered.Spec.Title = "tampered"
+	_, err = apply(tampered)
+	require.Error(t, err)
+	require.Equal(t, v1alpha1.ConditionTrue, condition("1.0.0").Status, "a rejected apply leaves the stored tag alone")
+}
+
+func TestAdmission_RollbackReverifiesSignature(t *testing.T) {
+	ctx := context.Background()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	policy, err := signing.ParseTrustPolicy([]byte(`namespaces:
+  default:
+    - name: release
+      publicKey: "`+pemLine(der)+`"
+`), "")
+	require.NoError(t, err)
+
+	store := v1alpha1store.NewStore(v1alpha1store.NewTestPool(t), v1alpha1store.TestSchema(), "agents",
+		v1alpha1store.WithKind(v1alpha1.KindAgent), v1alpha1store.WithTagHistory(v1alpha1store.TestSchema()))
+	admit := signing.Admission(policy, resource.ProductionAdmission)
+	_, api := humatest.New(t)
+	resource.Register[*v1alpha1.Agent](api, resource.Config{
+		Kind:       v1alpha1.KindAgent,
+		BasePrefix: "/v0",
+		Store:      store,
+		Admission:  admit,
+	}, func() *v1alpha1.Agent { return &v1alpha1.Agent{} })
+
+	for _, title := range []string{"first", "second"} {
+		agent := &v1alpha1.Agent{
+			TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindAgent},
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "acme-bot", Tag: "1.0.0"},
+			Spec:     v1alpha1.AgentSpec{Title: title},
+		}
+		require.NoError(t, signing.Sign(agent, key, "release"))
+		_, err := admit(ctx, types.AdmissionInput{
+			Verb: "apply", Kind: v1alpha1.KindAgent,
+			Namespace: "default", Name: "acme-bot", Tag: "1.0.0",
+			Object: agent, Store: store,
+		})
+		require.NoError(t, err)
+	}
+
+	resp := api.Post("/v0/agents/acme-bot/1.0.0/rollback", map[string]int64{"generation": 1})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	raw, err := store.Get(ctx, "default", "acme-bot", "1.0.0")
+	require.NoError(t, err)
+	agent, err := v1alpha1.EnvelopeFromRaw(func() *v1alpha1.Agent { return &v1alpha1.Agent{} }, raw, v1alpha1.KindAgent)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), agent.Metadata.Generation)
+	require.Equal(t, "first", agent.Spec.Title)
+	require.NoError(t, signing.RequireVerified(agent), "the restored revision is verified at its new generation")
+}
+
+// pemLine renders a PKIX public key as a PEM block with escaped newlines,
+// for embedding in a double-quoted YAML scalar.
+func pemLine(der []byte) string {
+	out := ""
+	for _, b := range pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}) {
+		if b == '\n' {
+			out += `\n`
+			continue
+		}
+		out += string(b)
+	}
+	return out
+}
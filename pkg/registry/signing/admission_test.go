@@ -0,0 +1,147 @@
+package signing
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+func TestParseTrustPolicy(t *testing.T) {
+	keys := testKeys(t)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "org.pub"), []byte(publicKeyPEM(t, keys["ed25519"])), 0o600))
+
+	policy, err := ParseTrustPolicy([]byte(`
+namespaces:
+  "*":
+    - name: org
+      publicKeyFile: org.pub
+  team-a:
+    - name: team-a
+      publicKey: |
+`+indent(publicKeyPEM(t, keys["ecdsa"]))), dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{"*", "team-a"}, policy.Namespaces())
+
+	names := func(ks []TrustedKey) []string {
+		var out []string
+		for _, k := range ks {
+			out = append(out, k.Name)
+		}
+		return out
+	}
+	require.Equal(t, []string{"team-a", "org"}, names(policy.Keys("team-a")))
+	require.Equal(t, []string{"org"}, names(policy.Keys("team-b")))
+	require.Empty(t, (*TrustPolicy)(nil).Keys("team-a"))
+
+	for _, bad := range []string{
+		"namespaces:\n  a:\n    - publicKey: x\n",
+		"namespaces:\n  a:\n    - name: k\n",
+		"namespaces:\n  a:\n    - name: k\n      publicKey: not-pem\n",
+		"namespaces:\n  a:\n    - name: k\n      publicKeyFile: missing.pub\n",
+		"nmespaces: {}\n",
+	} {
+		_, err := ParseTrustPolicy([]byte(bad), dir)
+		require.Error(t, err, bad)
+	}
+}
+
+func indent(s string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(s, "\n"), "\n") {
+		b.WriteString("        " + line + "\n")
+	}
+	return b.String()
+}
+
+func testPolicy(namespace string, key TrustedKey) *TrustPolicy {
+	return &TrustPolicy{keys: map[string][]TrustedKey{namespace: {key}}}
+}
+
+func TestTrustPolicy_Verify(t *testing.T) {
+	keys := testKeys(t)
+	trusted := TrustedKey{Name: "release", Key: keys["ecdsa"].Public()}
+	policy := testPolicy(AnyNamespace, trusted)
+
+	v, err := policy.Verify(testAgent("summarizer"))
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1.ConditionFalse, v.Status)
+	require.Equal(t, ReasonUnsigned, v.Reason)
+
+	signed := testAgent("summarizer")
+	require.NoError(t, Sign(signed, keys["ecdsa"], ""))
+	v, err = policy.Verify(signed)
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1.ConditionTrue, v.Status)
+	require.Equal(t, "release", v.Key)
+
+	v, err = testPolicy("other", trusted).Verify(signed)
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1.ConditionUnknown, v.Status)
+	require.Equal(t, ReasonNoTrustedKeys, v.Reason)
+
+	wrongKey := testAgent("summarizer")
+	require.NoError(t, Sign(wrongKey, keys["rsa"], ""))
+	_, err = policy.Verify(wrongKey)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	wrongName := testAgent("summarizer")
+	require.NoError(t, Sign(wrongName, keys["ecdsa"], "someone-else"))
+	_, err = policy.Verify(wrongName)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestAdmission_RejectsBadSignatureOnDryRun(t *testing.T) {
+	keys := testKeys(t)
+	policy := testPolicy(AnyNamespace, TrustedKey{Name: "release", Key: keys["ecdsa"].Public()})
+	var calls int
+	next := func(_ context.Context, in types.AdmissionInput) (types.AdmissionResult, error) {
+		calls++
+		return types.AdmissionResult{Status: arv0.ApplyStatusDryRun, Tag: in.Tag}, nil
+	}
+	admit := Admission(policy, next)
+
+	obj := testAgent("summarizer")
+	require.NoError(t, Sign(obj, keys["ed25519"], ""))
+	_, err := admit(context.Background(), types.AdmissionInput{DryRun: true, Kind: v1alpha1.KindAgent, Object: obj})
+	require.ErrorContains(t, err, "signature")
+	require.Zero(t, calls)
+
+	require.NoError(t, Sign(obj, keys["ecdsa"], ""))
+	res, err := admit(context.Background(), types.AdmissionInput{DryRun: true, Kind: v1alpha1.KindAgent, Object: obj, Tag: "1.0.0"})
+	require.NoError(t, err)
+	require.Equal(t, arv0.ApplyStatusDryRun, res.Status)
+	require.Equal(t, 1, calls)
+
+	runtime := &v1alpha1.Runtime{Metadata: v1alpha1.ObjectMeta{Name: "local", Annotations: map[string]string{SignatureAnnotation: "garbage"}}}
+	_, err = admit(context.Background(), types.AdmissionInput{DryRun: true, Kind: v1alpha1.KindRuntime, Object: runtime})
+	require.NoError(t, err, "mutable kinds are not checked")
+}
+
+func TestSetCondition_KeepsKindSpecificStatus(t *testing.T) {
+	current := json.RawMessage(`{"capabilities":{"tools":[{"name":"fetch"}]},"conditions":[{"type":"Introspected","status":"True"}]}`)
+	out, err := setCondition(current, v1alpha1.Condition{Type: VerifiedCondition, Status: v1alpha1.ConditionTrue, ObservedGeneration: 3})
+	require.NoError(t, err)
+
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(out, &fields))
+	require.JSONEq(t, `{"tools":[{"name":"fetch"}]}`, string(fields["capabilities"]))
+
+	agent := &v1alpha1.Agent{Metadata: v1alpha1.ObjectMeta{Name: "acme-bot", Generation: 3}}
+	require.NoError(t, agent.UnmarshalStatus(out))
+	require.True(t, agent.Status.IsConditionTrue("Introspected"))
+	require.NoError(t, RequireVerified(agent))
+
+	agent.Metadata.Generation = 4
+	require.ErrorIs(t, RequireVerified(agent), ErrNotVerified, "verification of an older generation does not count")
+	require.ErrorIs(t, RequireVerified(&v1alpha1.Agent{}), ErrNotVerified)
+}
@@ -0,0 +1,406 @@
+package signing
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+	artypes "github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+// Media types and annotations of the artifacts cosign attaches to an
+// image, whether pushed under the legacy sha256-<hex>.sig/.att tags or as
+// OCI 1.1 referrers.
+const (
+	cosignSignatureMediaType  = "application/vnd.dev.cosign.simplesigning.v1+json"
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	dsseEnvelopeMediaType     = "application/vnd.dsse.envelope.v1+json"
+	inTotoPayloadType         = "application/vnd.in-toto+json"
+	slsaProvenancePrefix      = "https://slsa.dev/provenance/"
+	imageVerificationTimeout  = 30 * time.Second
+	// maxArtifactBytes bounds a signature payload or attestation read
+	// from a registry; provenance documents are small.
+	maxArtifactBytes = 4 << 20
+)
+
+var (
+	// ErrImageNotSigned is returned when signatures are required and the
+	// image carries none from a trusted key.
+	ErrImageNotSigned = errors.New("image has no signature from a trusted key")
+	// ErrImageNoProvenance is returned when provenance is required and the
+	// image carries no SLSA provenance attestation from a trusted key.
+	ErrImageNoProvenance = errors.New("image has no provenance attestation from a trusted key")
+)
+
+// ImageVerifier looks up the cosign signatures and in-toto attestations
+// stored alongside an OCI image and checks them against Keys. Both the
+// legacy tag scheme (sha256-<hex>.sig / .att) and the OCI referrers API
+// are consulted. It implements registries.ImageVerifier.
+type ImageVerifier struct {
+	Keys []TrustedKey
+	// RequireSignature rejects images without a signature from one of Keys.
+	RequireSignature bool
+	// RequireProvenance rejects images without a SLSA provenance
+	// attestation signed by one of Keys.
+	RequireProvenance bool
+	// RemoteOptions are passed to every registry call; tests point them at
+	// an in-memory registry. When empty, registry credentials come from
+	// the default keychain, as for `docker pull`.
+	RemoteOptions []remote.Option
+}
+
+// VerifyImage is Verify as the OCI registry validator calls it. With
+// neither RequireSignature nor RequireProvenance set, nothing is enforced:
+// a failed lookup or a missing signature becomes an apply warning, and the
+// image is recorded only when the lookup succeeded.
+func (v *ImageVerifier) VerifyImage(ctx context.Context, ref name.Reference) (*v1alpha1.MCPServerImage, error) {
+	image, err := v.Verify(ctx, ref)
+	if v.RequireSignature || v.RequireProvenance {
+		return image, err
+	}
+	if err != nil {
+		v1alpha1.AddWarning(ctx, "image verification: "+err.Error())
+		return nil, nil
+	}
+	if image.Signer == "" {
+		v1alpha1.AddWarning(ctx, fmt.Sprintf("image verification: %v: %s", ErrImageNotSigned, ref))
+	}
+	return image, nil
+}
+
+// Verify resolves ref to a manifest digest and reports the trusted signer
+// and provenance builder found for it. Signatures and attestations from
+// keys outside Keys are ignored rather than treated as failures: a
+// publisher may sign with several keys, only one of which is trusted here.
+func (v *ImageVerifier) Verify(ctx context.Context, ref name.Reference) (*v1alpha1.MCPServerImage, error) {
+	identifier := ref.String()
+	ctx, cancel := context.WithTimeout(ctx, imageVerificationTimeout)
+	defer cancel()
+	opts := v.remoteOptions(ctx)
+
+	desc, err := remote.Head(ref, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", identifier, err)
+	}
+	digest := ref.Context().Digest(desc.Digest.String())
+	out := &v1alpha1.MCPServerImage{Digest: desc.Digest.String()}
+
+	artifacts, err := v.artifacts(digest, opts)
+	if err != nil {
+		return nil, fmt.Errorf("list signatures for %s: %w", identifier, err)
+	}
+	for _, img := range artifacts {
+		if err := v.inspect(img, desc.Digest, out); err != nil {
+			return nil, fmt.Errorf("read signatures for %s: %w", identifier, err)
+		}
+	}
+
+	if v.RequireSignature && out.Signer == "" {
+		return nil, fmt.Errorf("%w: %s", ErrImageNotSigned, identifier)
+	}
+	if v.RequireProvenance && out.ProvenanceBuilder == "" {
+		return nil, fmt.Errorf("%w: %s", ErrImageNoProvenance, identifier)
+	}
+	return out, nil
+}
+
+// LoadImageKeys reads a comma-separated list of PEM public key files into
+// trusted keys, each named after its file without the extension. An empty
+// list returns nil.
+func LoadImageKeys(paths string) ([]TrustedKey, error) {
+	var keys []TrustedKey
+	for _, path := range strings.Split(paths, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ParsePublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		base := filepath.Base(path)
+		keys = append(keys, TrustedKey{Name: strings.TrimSuffix(base, filepath.Ext(base)), Key: key})
+	}
+	return keys, nil
+}
+
+func (v *ImageVerifier) remoteOptions(ctx context.Context) []remote.Option {
+	opts := []remote.Option{remote.WithContext(ctx)}
+	if len(v.RemoteOptions) == 0 {
+		return append(opts, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	}
+	return append(opts, v.RemoteOptions...)
+}
+
+// artifacts returns the signature and attestation manifests attached to
+// digest. A missing legacy tag just means nothing was pushed there.
+func (v *ImageVerifier) artifacts(digest name.Digest, opts []remote.Option) ([]v1.Image, error) {
+	var out []v1.Image
+	legacy := strings.Replace(digest.DigestStr(), ":", "-", 1)
+	for _, suffix := range []string{".sig", ".att"} {
+		img, err := remote.Image(digest.Context().Tag(legacy+suffix), opts...)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, img)
+	}
+
+	index, err := remote.Referrers(digest, opts...)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range manifest.Manifests {
+		img, err := remote.Image(digest.Context().Digest(d.Digest.String()), opts...)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, img)
+	}
+	return out, nil
+}
+
+func isNotFound(err error) bool {
+	var terr *transport.Error
+	return errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound
+}
+
+// inspect records in out the first trusted signature and provenance
+// attestation among img's layers that cover subject.
+func (v *ImageVerifier) inspect(img v1.Image, subject v1.Hash, out *v1alpha1.MCPServerImage) error {
+	manifest, err := img.Manifest()
+	if err != nil {
+		return err
+	}
+	for _, layer := range manifest.Layers {
+		switch layer.MediaType {
+		case cosignSignatureMediaType:
+			if out.Signer != "" {
+				continue
+			}
+			payload, err := readLayer(img, layer.Digest)
+			if err != nil {
+				return err
+			}
+			out.Signer = v.verifySimpleSigning(payload, layer.Annotations[cosignSignatureAnnotation], subject)
+		case dsseEnvelopeMediaType:
+			if out.ProvenanceBuilder != "" {
+				continue
+			}
+			envelope, err := readLayer(img, layer.Digest)
+			if err != nil {
+				return err
+			}
+			out.ProvenanceBuilder = v.verifyProvenance(envelope, subject)
+		}
+	}
+	return nil
+}
+
+func readLayer(img v1.Image, digest v1.Hash) ([]byte, error) {
+	layer, err := img.LayerByDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(io.LimitReader(rc, maxArtifactBytes))
+}
+
+// simpleSigning is the subset of the cosign "simple signing" payload that
+// binds a signature to an image.
+type simpleSigning struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// verifySimpleSigning returns the name of the trusted key that produced
+// sig over payload, provided payload names subject. Empty otherwise.
+func (v *ImageVerifier) verifySimpleSigning(payload []byte, sig string, subject v1.Hash) string {
+	var body simpleSigning
+	if err := json.Unmarshal(payload, &body); err != nil || body.Critical.Image.DockerManifestDigest != subject.String() {
+		return ""
+	}
+	raw, err := base64.StdEncoding.DecodeString(sig)
+	if err != nil {
+		return ""
+	}
+	return v.signer(payload, raw)
+}
+
+// dsseEnvelope is a DSSE envelope as cosign attaches attestations.
+type dsseEnvelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+	Signatures  []struct {
+		Sig string `json:"sig"`
+	} `json:"signatures"`
+}
+
+// inTotoStatement is the subset of an in-toto statement needed to match
+// its subject and read the SLSA builder (v0.2 predicate.builder.id or v1
+// predicate.runDetails.builder.id).
+type inTotoStatement struct {
+	PredicateType string `json:"predicateType"`
+	Subject       []struct {
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+	Predicate struct {
+		Builder struct {
+			ID string `json:"id"`
+		} `json:"builder"`
+		RunDetails struct {
+			Builder struct {
+				ID string `json:"id"`
+			} `json:"builder"`
+		} `json:"runDetails"`
+	} `json:"predicate"`
+}
+
+// verifyProvenance returns the builder ID of a SLSA provenance statement
+// about subject inside a DSSE envelope signed by a trusted key. Empty
+// when the envelope is anything else.
+func (v *ImageVerifier) verifyProvenance(data []byte, subject v1.Hash) string {
+	var envelope dsseEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil || envelope.PayloadType != inTotoPayloadType {
+		return ""
+	}
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return ""
+	}
+	pae := dssePAE(envelope.PayloadType, payload)
+	signed := false
+	for _, s := range envelope.Signatures {
+		raw, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err == nil && v.signer(pae, raw) != "" {
+			signed = true
+			break
+		}
+	}
+	if !signed {
+		return ""
+	}
+
+	var statement inTotoStatement
+	if err := json.Unmarshal(payload, &statement); err != nil || !strings.HasPrefix(statement.PredicateType, slsaProvenancePrefix) {
+		return ""
+	}
+	covers := false
+	for _, s := range statement.Subject {
+		if s.Digest[subject.Algorithm] == subject.Hex {
+			covers = true
+			break
+		}
+	}
+	if !covers {
+		return ""
+	}
+	if id := statement.Predicate.RunDetails.Builder.ID; id != "" {
+		return id
+	}
+	return statement.Predicate.Builder.ID
+}
+
+// dssePAE is the DSSE pre-authentication encoding the signature covers.
+func dssePAE(payloadType string, payload []byte) []byte {
+	return fmt.Appendf(nil, "DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload)
+}
+
+// signer returns the name of the first key in Keys that verifies sig over
+// payload, or "" when none does.
+func (v *ImageVerifier) signer(payload, sig []byte) string {
+	for _, k := range v.Keys {
+		if VerifyPayload(k.Key, payload, sig) == nil {
+			return k.Name
+		}
+	}
+	return ""
+}
+
+// ImageAdmission wraps next so the image the OCI registry validator
+// verified for an MCPServer (see registries.NewDispatcher) is recorded in
+// MCPServerStatus.Image once next has written the row. A server whose
+// package is not a verified OCI image has the field cleared, so a runtime
+// never deploys a digest recorded for earlier content.
+func ImageAdmission(next artypes.Admission) artypes.Admission {
+	return func(ctx context.Context, in artypes.AdmissionInput) (artypes.AdmissionResult, error) {
+		srv, ok := in.Object.(*v1alpha1.MCPServer)
+		if in.Kind != v1alpha1.KindMCPServer || !ok {
+			return next(ctx, in)
+		}
+		result, err := next(ctx, in)
+		if err != nil || in.DryRun {
+			return result, err
+		}
+		store, ok := in.Store.(*v1alpha1store.Store)
+		if !ok || store == nil {
+			return result, nil
+		}
+		tag := result.Tag
+		if tag == "" {
+			tag = in.Tag
+		}
+		err = store.PatchStatus(ctx, in.Namespace, in.Name, tag, func(current json.RawMessage) (json.RawMessage, error) {
+			return setStatusImage(current, srv.Status.Image)
+		})
+		if err != nil && !errors.Is(err, pkgdb.ErrNotFound) {
+			return artypes.AdmissionResult{}, fmt.Errorf("record image verification: %w", err)
+		}
+		return result, nil
+	}
+}
+
+// setStatusImage sets the image key on a stored status payload, leaving
+// conditions and every other key as they are. A nil image removes the key.
+func setStatusImage(current json.RawMessage, image *v1alpha1.MCPServerImage) (json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	if len(current) > 0 {
+		if err := json.Unmarshal(current, &fields); err != nil {
+			return nil, err
+		}
+	}
+	if image == nil {
+		delete(fields, "image")
+		return json.Marshal(fields)
+	}
+	raw, err := json.Marshal(image)
+	if err != nil {
+		return nil, err
+	}
+	fields["image"] = raw
+	return json.Marshal(fields)
+}
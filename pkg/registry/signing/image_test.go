@@ -0,0 +1,209 @@
+package signing
+
+import (
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/require"
+
+	arv0 "github.com/agentregistry-dev/agentregistry/pkg/api/v0"
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	artypes "github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+// pushTestImage starts an in-memory registry, pushes a random image to it
+// and returns the image reference and digest.
+func pushTestImage(t *testing.T) (name.Reference, v1.Hash) {
+	t.Helper()
+	srv := httptest.NewServer(registry.New(registry.WithReferrersSupport(true), registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(srv.Close)
+	ref, err := name.ParseReference(strings.TrimPrefix(srv.URL, "http://") + "/acme/fetch:1.0.0")
+	require.NoError(t, err)
+	img, err := random.Image(256, 1)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	return ref, digest
+}
+
+// pushCosignSignature attaches a cosign-style signature for digest under
+// the legacy sha256-<hex>.sig tag.
+func pushCosignSignature(t *testing.T, ref name.Reference, digest v1.Hash, key crypto.Signer) {
+	t.Helper()
+	payload := fmt.Appendf(nil, `{"critical":{"identity":{"docker-reference":%q},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`,
+		ref.Context().String(), digest.String())
+	sig, err := SignPayload(key, payload)
+	require.NoError(t, err)
+	img, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:       static.NewLayer(payload, cosignSignatureMediaType),
+		Annotations: map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sig)},
+	})
+	require.NoError(t, err)
+	tag := ref.Context().Tag(strings.Replace(digest.String(), ":", "-", 1) + ".sig")
+	require.NoError(t, remote.Write(tag, img))
+}
+
+// pushProvenance attaches a DSSE-wrapped SLSA v1 provenance statement for
+// digest as an OCI referrer.
+func pushProvenance(t *testing.T, ref name.Reference, digest v1.Hash, key crypto.Signer, builder string) {
+	t.Helper()
+	statement := fmt.Appendf(nil, `{"_type":"https://in-toto.io/Statement/v1","predicateType":"https://slsa.dev/provenance/v1","subject":[{"name":%q,"digest":{"sha256":%q}}],"predicate":{"runDetails":{"builder":{"id":%q}}}}`,
+		ref.Context().String(), digest.Hex, builder)
+	sig, err := SignPayload(key, dssePAE(inTotoPayloadType, statement))
+	require.NoError(t, err)
+	envelope, err := json.Marshal(map[string]any{
+		"payloadType": inTotoPayloadType,
+		"payload":     base64.StdEncoding.EncodeToString(statement),
+		"signatures":  []map[string]string{{"sig": base64.StdEncoding.EncodeToString(sig)}},
+	})
+	require.NoError(t, err)
+	img, err := mutate.Append(empty.Image, mutate.Addendum{Layer: static.NewLayer(envelope, dsseEnvelopeMediaType)})
+	require.NoError(t, err)
+	subject := mutate.Subject(img, v1.Descriptor{MediaType: types.OCIManifestSchema1, Digest: digest}).(v1.Image)
+	attDigest, err := subject.Digest()
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref.Context().Digest(attDigest.String()), subject))
+}
+
+func TestImageVerifier_SignatureAndProvenance(t *testing.T) {
+	keys := testKeys(t)
+	ref, digest := pushTestImage(t)
+	pushCosignSignature(t, ref, digest, keys["ecdsa"])
+	pushProvenance(t, ref, digest, keys["ed25519"], "https://github.com/actions/runner")
+
+	verifier := &ImageVerifier{
+		Keys: []TrustedKey{
+			{Name: "release", Key: keys["ecdsa"].Public()},
+			{Name: "ci", Key: keys["ed25519"].Public()},
+		},
+		RequireSignature:  true,
+		RequireProvenance: true,
+	}
+	got, err := verifier.Verify(context.Background(), ref)
+	require.NoError(t, err)
+	require.Equal(t, &v1alpha1.MCPServerImage{
+		Digest:            digest.String(),
+		Signer:            "release",
+		ProvenanceBuilder: "https://github.com/actions/runner",
+	}, got)
+
+	// The same image verified only against the provenance key: the
+	// signature is from an untrusted key, so it is not recorded.
+	verifier = &ImageVerifier{Keys: []TrustedKey{{Name: "ci", Key: keys["ed25519"].Public()}}}
+	got, err = verifier.Verify(context.Background(), ref)
+	require.NoError(t, err)
+	require.Empty(t, got.Signer)
+	require.Equal(t, "https://github.com/actions/runner", got.ProvenanceBuilder)
+
+	verifier.RequireSignature = true
+	_, err = verifier.Verify(context.Background(), ref)
+	require.ErrorIs(t, err, ErrImageNotSigned)
+}
+
+func TestImageVerifier_UnsignedImage(t *testing.T) {
+	keys := testKeys(t)
+	ref, digest := pushTestImage(t)
+	verifier := &ImageVerifier{Keys: []TrustedKey{{Name: "release", Key: keys["ecdsa"].Public()}}}
+
+	got, err := verifier.Verify(context.Background(), ref)
+	require.NoError(t, err)
+	require.Equal(t, &v1alpha1.MCPServerImage{Digest: digest.String()}, got)
+
+	verifier.RequireProvenance = true
+	_, err = verifier.Verify(context.Background(), ref)
+	require.ErrorIs(t, err, ErrImageNoProvenance)
+
+	_, err = verifier.Verify(context.Background(), ref.Context().Tag("missing"))
+	require.Error(t, err)
+}
+
+func TestImageVerifier_SignatureForOtherDigest(t *testing.T) {
+	keys := testKeys(t)
+	digest, err := v1.NewHash("sha256:" + strings.Repeat("1", 64))
+	require.NoError(t, err)
+	other, err := v1.NewHash("sha256:" + strings.Repeat("0", 64))
+	require.NoError(t, err)
+	// A signature stored under this image's tag but naming another digest
+	// does not count for this image.
+	payload := fmt.Appendf(nil, `{"critical":{"image":{"docker-manifest-digest":%q}}}`, other.String())
+	sig, err := SignPayload(keys["ecdsa"], payload)
+	require.NoError(t, err)
+	verifier := &ImageVerifier{Keys: []TrustedKey{{Name: "release", Key: keys["ecdsa"].Public()}}}
+	require.Empty(t, verifier.verifySimpleSigning(payload, base64.StdEncoding.EncodeToString(sig), digest))
+}
+
+func TestImageVerifier_WarnsUnlessRequired(t *testing.T) {
+	keys := testKeys(t)
+	ref, digest := pushTestImage(t)
+	verifier := &ImageVerifier{Keys: []TrustedKey{{Name: "release", Key: keys["ecdsa"].Public()}}}
+
+	ctx, warnings := v1alpha1.WithWarnings(context.Background())
+	got, err := verifier.VerifyImage(ctx, ref)
+	require.NoError(t, err)
+	require.Equal(t, &v1alpha1.MCPServerImage{Digest: digest.String()}, got)
+	require.Len(t, warnings(), 1)
+	require.Contains(t, warnings()[0], ErrImageNotSigned.Error())
+
+	ctx, warnings = v1alpha1.WithWarnings(context.Background())
+	got, err = verifier.VerifyImage(ctx, ref.Context().Tag("missing"))
+	require.NoError(t, err, "a failed lookup only warns when nothing is required")
+	require.Nil(t, got)
+	require.Len(t, warnings(), 1)
+
+	verifier.RequireSignature = true
+	_, err = verifier.VerifyImage(context.Background(), ref)
+	require.ErrorIs(t, err, ErrImageNotSigned)
+}
+
+func TestImageAdmission_SkipsDryRunAndOtherKinds(t *testing.T) {
+	var calls int
+	next := func(_ context.Context, in artypes.AdmissionInput) (artypes.AdmissionResult, error) {
+		calls++
+		return artypes.AdmissionResult{Status: arv0.ApplyStatusDryRun, Tag: in.Tag}, nil
+	}
+	admit := ImageAdmission(next)
+
+	server := &v1alpha1.MCPServer{Status: v1alpha1.MCPServerStatus{Image: &v1alpha1.MCPServerImage{Digest: "sha256:abc"}}}
+	_, err := admit(context.Background(), artypes.AdmissionInput{DryRun: true, Kind: v1alpha1.KindMCPServer, Object: server})
+	require.NoError(t, err, "a dry run records nothing")
+	_, err = admit(context.Background(), artypes.AdmissionInput{Kind: v1alpha1.KindSkill, Object: &v1alpha1.Skill{}})
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+}
+
+func TestSetStatusImage_KeepsConditions(t *testing.T) {
+	current := json.RawMessage(`{"conditions":[{"type":"SignatureVerified","status":"True"}],"capabilities":{"tools":[]}}`)
+	out, err := setStatusImage(current, &v1alpha1.MCPServerImage{Digest: "sha256:abc", Signer: "release"})
+	require.NoError(t, err)
+
+	srv := &v1alpha1.MCPServer{}
+	require.NoError(t, srv.UnmarshalStatus(out))
+	require.True(t, srv.Status.IsConditionTrue(VerifiedCondition))
+	require.NotNil(t, srv.Status.Capabilities)
+	require.Equal(t, "release", srv.Status.Image.Signer)
+
+	out, err = setStatusImage(out, nil)
+	require.NoError(t, err)
+	srv = &v1alpha1.MCPServer{}
+	require.NoError(t, srv.UnmarshalStatus(out))
+	require.Nil(t, srv.Status.Image)
+	require.True(t, srv.Status.IsConditionTrue(VerifiedCondition))
+}
@@ -0,0 +1,144 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// PEM block types of the encrypted private keys `cosign generate-key-pair`
+// writes; older cosign releases use the COSIGN spelling.
+const (
+	sigstorePrivateKeyPEMType = "ENCRYPTED SIGSTORE PRIVATE KEY"
+	cosignPrivateKeyPEMType   = "ENCRYPTED COSIGN PRIVATE KEY"
+)
+
+// ErrPasswordRequired is returned by ParsePrivateKey for an encrypted
+// cosign key when no password is supplied.
+var ErrPasswordRequired = errors.New("private key is encrypted; a password is required")
+
+// ParsePublicKey decodes a PEM "PUBLIC KEY" block (PKIX, the format of
+// cosign.pub) holding an ECDSA, Ed25519 or RSA key.
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("public key: no PEM block found")
+	}
+	if block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("public key: unexpected PEM block %q", block.Type)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("public key: %w", err)
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("public key: %w: %T", ErrUnsupportedKey, key)
+	}
+}
+
+// ParsePrivateKey decodes a PEM private key: an encrypted cosign key
+// (decrypted with password), or an unencrypted PKCS #8, SEC 1 EC or
+// PKCS #1 RSA key.
+func ParsePrivateKey(data, password []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("private key: no PEM block found")
+	}
+	der := block.Bytes
+	switch block.Type {
+	case sigstorePrivateKeyPEMType, cosignPrivateKeyPEMType:
+		if len(password) == 0 {
+			return nil, ErrPasswordRequired
+		}
+		var err error
+		if der, err = decryptCosignKey(block.Bytes, password); err != nil {
+			return nil, err
+		}
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(der)
+		if err != nil {
+			return nil, fmt.Errorf("private key: %w", err)
+		}
+		return key, nil
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(der)
+		if err != nil {
+			return nil, fmt.Errorf("private key: %w", err)
+		}
+		return key, nil
+	case "PRIVATE KEY":
+	default:
+		return nil, fmt.Errorf("private key: unexpected PEM block %q", block.Type)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("private key: %w", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("private key: %w: %T", ErrUnsupportedKey, key)
+	}
+	switch signer.Public().(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+		return signer, nil
+	default:
+		return nil, fmt.Errorf("private key: %w: %T", ErrUnsupportedKey, key)
+	}
+}
+
+// encryptedKey is the JSON body of an encrypted cosign private key: a
+// PKCS #8 key sealed with NaCl secretbox under an scrypt-derived key.
+type encryptedKey struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce []byte `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func decryptCosignKey(data, password []byte) ([]byte, error) {
+	var enc encryptedKey
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return nil, fmt.Errorf("private key: decode encrypted key: %w", err)
+	}
+	if enc.KDF.Name != "scrypt" || enc.Cipher.Name != "nacl/secretbox" {
+		return nil, fmt.Errorf("private key: unsupported encryption %s/%s", enc.KDF.Name, enc.Cipher.Name)
+	}
+	var nonce [24]byte
+	if len(enc.Cipher.Nonce) != len(nonce) {
+		return nil, errors.New("private key: malformed secretbox nonce")
+	}
+	copy(nonce[:], enc.Cipher.Nonce)
+	derived, err := scrypt.Key(password, enc.KDF.Salt, enc.KDF.Params.N, enc.KDF.Params.R, enc.KDF.Params.P, 32)
+	if err != nil {
+		return nil, fmt.Errorf("private key: derive key: %w", err)
+	}
+	var secret [32]byte
+	copy(secret[:], derived)
+	der, ok := secretbox.Open(nil, enc.Ciphertext, &nonce, &secret)
+	if !ok {
+		return nil, errors.New("private key: decryption failed (wrong password?)")
+	}
+	return der, nil
+}
@@ -0,0 +1,44 @@
+package signing
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+// NamespaceOwners looks up the verified owner of a namespace: the key its
+// artifacts must be signed with and the domain that proved it. ok is
+// false for an unclaimed namespace. v1alpha1store.NamespaceOwnerStore
+// implements it.
+type NamespaceOwners interface {
+	OwnerKey(ctx context.Context, namespace string) (key crypto.PublicKey, domain string, ok bool, err error)
+}
+
+// OwnershipAdmission wraps next so tagged artifacts applied in a namespace
+// with a verified owner must be signed with the owner's key, dry-runs
+// included. Artifacts in unclaimed namespaces, and other kinds, pass
+// through to next.
+func OwnershipAdmission(owners NamespaceOwners, next types.Admission) types.Admission {
+	return func(ctx context.Context, in types.AdmissionInput) (types.AdmissionResult, error) {
+		if !v1alpha1.IsTaggedArtifactKind(in.Kind) {
+			return next(ctx, in)
+		}
+		namespace := in.Object.GetMetadata().NamespaceOrDefault()
+		key, domain, ok, err := owners.OwnerKey(ctx, namespace)
+		if err != nil {
+			return types.AdmissionResult{}, fmt.Errorf("load owner of namespace %s: %w", namespace, err)
+		}
+		if !ok {
+			return next(ctx, in)
+		}
+		if err := Verify(in.Object, key); err != nil {
+			return types.AdmissionResult{}, huma.Error403Forbidden(fmt.Sprintf("namespace %s is owned by %s; artifacts published in it must be signed with the key %s publishes: %v", namespace, domain, domain, err))
+		}
+		return next(ctx, in)
+	}
+}
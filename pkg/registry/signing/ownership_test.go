@@ -0,0 +1,70 @@
+package signing
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+)
+
+// fakeOwners maps namespaces to their owners' keys.
+type fakeOwners map[string]crypto.PublicKey
+
+func (o fakeOwners) OwnerKey(_ context.Context, namespace string) (crypto.PublicKey, string, bool, error) {
+	if namespace == "broken" {
+		return nil, "", false, errors.New("database unavailable")
+	}
+	key, ok := o[namespace]
+	return key, "acme.com", ok, nil
+}
+
+func TestOwnershipAdmission(t *testing.T) {
+	keys := testKeys(t)
+	var calls int
+	next := func(context.Context, types.AdmissionInput) (types.AdmissionResult, error) {
+		calls++
+		return types.AdmissionResult{}, nil
+	}
+	admit := OwnershipAdmission(fakeOwners{"com.acme": keys["ed25519"].Public()}, next)
+	apply := func(obj v1alpha1.Object) error {
+		_, err := admit(context.Background(), types.AdmissionInput{DryRun: true, Kind: obj.GetKind(), Object: obj})
+		return err
+	}
+	inNamespace := func(namespace string) *v1alpha1.Agent {
+		obj := testAgent("summarizer")
+		obj.Metadata.Namespace = namespace
+		return obj
+	}
+
+	require.NoError(t, apply(inNamespace("team-a")), "unclaimed namespaces are not checked")
+	require.Equal(t, 1, calls)
+
+	unsigned := inNamespace("com.acme")
+	err := apply(unsigned)
+	var status huma.StatusError
+	require.ErrorAs(t, err, &status)
+	require.Equal(t, http.StatusForbidden, status.GetStatus())
+	require.ErrorContains(t, err, "owned by acme.com")
+
+	wrongKey := inNamespace("com.acme")
+	require.NoError(t, Sign(wrongKey, keys["ecdsa"], ""))
+	require.Error(t, apply(wrongKey))
+	require.Equal(t, 1, calls)
+
+	signed := inNamespace("com.acme")
+	require.NoError(t, Sign(signed, keys["ed25519"], ""))
+	require.NoError(t, apply(signed))
+	require.Equal(t, 2, calls)
+
+	runtime := &v1alpha1.Runtime{TypeMeta: v1alpha1.TypeMeta{Kind: v1alpha1.KindRuntime}, Metadata: v1alpha1.ObjectMeta{Namespace: "com.acme", Name: "local"}}
+	require.NoError(t, apply(runtime), "mutable kinds are not checked")
+
+	require.ErrorContains(t, apply(inNamespace("broken")), "database unavailable")
+}
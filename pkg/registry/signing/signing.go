@@ -0,0 +1,187 @@
+// Package signing signs tagged registry artifacts and verifies their
+// signatures.
+//
+// A signature covers the artifact's identity — kind, namespace, name and
+// tag — and its canonical content hash: the v1alpha1store.ContentHash
+// digest of its spec, labels and annotations, with the signature
+// annotations themselves left out. The payload is the single line
+//
+//	<kind> <namespace>/<name>:<tag> <lowercase hex digest>
+//
+// so a signature produced here is the same one `cosign sign-blob --key`
+// produces over a file holding that line, and `cosign verify-blob`
+// accepts it. Binding the identity keeps a signature from being replayed
+// onto another name, tag or namespace that carries the same content.
+//
+// The signature travels with the artifact as metadata annotations:
+//
+//	metadata:
+//	  annotations:
+//	    agentregistry.solo.io/signature: MEUCIQ...   # base64 signature
+//	    agentregistry.solo.io/signature-key: release # optional key name
+//
+// ImageVerifier checks the OCI images MCP server packages ship, against
+// the cosign signatures and SLSA provenance attestations stored next to
+// them in their registry.
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+const (
+	// SignatureAnnotation carries the base64-encoded signature.
+	SignatureAnnotation = "agentregistry.solo.io/signature"
+	// SignatureKeyAnnotation optionally names the key that produced the
+	// signature, so verification can pick it without trying every
+	// trusted key.
+	SignatureKeyAnnotation = "agentregistry.solo.io/signature-key"
+
+	// VerifiedCondition is the status condition that records the outcome
+	// of verifying an artifact's signature on apply.
+	VerifiedCondition = "SignatureVerified"
+)
+
+var (
+	// ErrInvalidSignature is returned when a signature is malformed or
+	// does not verify against the key it is checked with.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrUnsupportedKey is returned for key types other than ECDSA,
+	// Ed25519 and RSA.
+	ErrUnsupportedKey = errors.New("unsupported key type")
+)
+
+// Payload returns the bytes a signature over obj covers: its kind,
+// namespace, name and tag, and the hex ContentHash of obj with the
+// signature annotations removed. An empty namespace or tag is signed as
+// the default apply fills in.
+func Payload(obj v1alpha1.Object) ([]byte, error) {
+	kind := obj.GetKind()
+	if kind == "" {
+		return nil, errors.New("kind is required to sign an artifact")
+	}
+	meta := *obj.GetMetadata()
+	if meta.Annotations != nil {
+		meta.Annotations = maps.Clone(meta.Annotations)
+		delete(meta.Annotations, SignatureAnnotation)
+		delete(meta.Annotations, SignatureKeyAnnotation)
+	}
+	spec, err := obj.MarshalSpec()
+	if err != nil {
+		return nil, fmt.Errorf("marshal spec: %w", err)
+	}
+	hash, err := v1alpha1store.ContentHash(&meta, spec)
+	if err != nil {
+		return nil, fmt.Errorf("content hash: %w", err)
+	}
+	tag := meta.Tag
+	if tag == "" {
+		tag = v1alpha1store.DefaultTag()
+	}
+	return fmt.Appendf(nil, "%s %s/%s:%s %s", kind, meta.NamespaceOrDefault(), meta.Name, tag, hash), nil
+}
+
+// Signature returns the decoded signature and key name recorded on obj.
+// ok is false when obj carries no signature; a signature annotation that
+// is not valid base64 returns ErrInvalidSignature.
+func Signature(obj v1alpha1.Object) (sig []byte, keyName string, ok bool, err error) {
+	annotations := obj.GetMetadata().Annotations
+	encoded, ok := annotations[SignatureAnnotation]
+	if !ok {
+		return nil, "", false, nil
+	}
+	sig, err = base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sig) == 0 {
+		return nil, "", true, fmt.Errorf("%w: %s is not a base64 signature", ErrInvalidSignature, SignatureAnnotation)
+	}
+	return sig, annotations[SignatureKeyAnnotation], true, nil
+}
+
+// Sign signs obj with key and records the signature in its annotations,
+// replacing any earlier signature. keyName is recorded alongside it when
+// non-empty.
+func Sign(obj v1alpha1.Object, key crypto.Signer, keyName string) error {
+	payload, err := Payload(obj)
+	if err != nil {
+		return err
+	}
+	sig, err := SignPayload(key, payload)
+	if err != nil {
+		return err
+	}
+	meta := obj.GetMetadata()
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[SignatureAnnotation] = base64.StdEncoding.EncodeToString(sig)
+	if keyName != "" {
+		meta.Annotations[SignatureKeyAnnotation] = keyName
+	} else {
+		delete(meta.Annotations, SignatureKeyAnnotation)
+	}
+	obj.SetMetadata(*meta)
+	return nil
+}
+
+// Verify checks the signature recorded on obj against key.
+func Verify(obj v1alpha1.Object, key crypto.PublicKey) error {
+	sig, _, ok, err := Signature(obj)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s annotation is not set", ErrInvalidSignature, SignatureAnnotation)
+	}
+	payload, err := Payload(obj)
+	if err != nil {
+		return err
+	}
+	return VerifyPayload(key, payload, sig)
+}
+
+// SignPayload signs payload the way cosign signs a blob: ECDSA and RSA
+// (PKCS #1 v1.5) over its SHA-256 digest, Ed25519 over the payload itself.
+func SignPayload(key crypto.Signer, payload []byte) ([]byte, error) {
+	switch key.Public().(type) {
+	case ed25519.PublicKey:
+		return key.Sign(rand.Reader, payload, crypto.Hash(0))
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+		digest := sha256.Sum256(payload)
+		return key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, key.Public())
+	}
+}
+
+// VerifyPayload is the inverse of SignPayload.
+func VerifyPayload(key crypto.PublicKey, payload, sig []byte) error {
+	var ok bool
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(k, payload, sig)
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(payload)
+		ok = ecdsa.VerifyASN1(k, digest[:], sig)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(payload)
+		ok = rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig) == nil
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedKey, key)
+	}
+	if !ok {
+		return fmt.Errorf("%w: signature does not match content", ErrInvalidSignature)
+	}
+	return nil
+}
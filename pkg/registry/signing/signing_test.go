@@ -0,0 +1,166 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/v1alpha1store"
+)
+
+func testAgent(title string) *v1alpha1.Agent {
+	return &v1alpha1.Agent{
+		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: v1alpha1.KindAgent},
+		Metadata: v1alpha1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "acme-bot",
+			Tag:         "1.0.0",
+			Labels:      map[string]string{"team": "a"},
+			Annotations: map[string]string{"owner": "team-a"},
+		},
+		Spec: v1alpha1.AgentSpec{Title: title},
+	}
+}
+
+func testKeys(t *testing.T) map[string]crypto.Signer {
+	t.Helper()
+	ec, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, ed, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rs, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return map[string]crypto.Signer{"ecdsa": ec, "ed25519": ed, "rsa": rs}
+}
+
+func publicKeyPEM(t *testing.T, key crypto.Signer) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestSignVerify_RoundTrip(t *testing.T) {
+	for name, key := range testKeys(t) {
+		t.Run(name, func(t *testing.T) {
+			obj := testAgent("summarizer")
+			require.NoError(t, Sign(obj, key, "release"))
+			require.Equal(t, "release", obj.Metadata.Annotations[SignatureKeyAnnotation])
+			require.NoError(t, Verify(obj, key.Public()))
+
+			obj.Spec.Title = "tampered"
+			require.ErrorIs(t, Verify(obj, key.Public()), ErrInvalidSignature)
+		})
+	}
+}
+
+func TestPayload_ExcludesSignatureAnnotations(t *testing.T) {
+	obj := testAgent("summarizer")
+	spec, err := obj.MarshalSpec()
+	require.NoError(t, err)
+	want, err := v1alpha1store.ContentHash(&obj.Metadata, spec)
+	require.NoError(t, err)
+
+	require.NoError(t, Sign(obj, testKeys(t)["ecdsa"], "release"))
+	got, err := Payload(obj)
+	require.NoError(t, err)
+	require.Equal(t, "Agent default/acme-bot:1.0.0 "+want, string(got))
+	require.Contains(t, obj.Metadata.Annotations, SignatureAnnotation, "Payload must not strip the caller's annotations")
+}
+
+func TestVerify_SignatureIsBoundToIdentity(t *testing.T) {
+	key := testKeys(t)["ed25519"]
+	for name, move := range map[string]func(*v1alpha1.Agent){
+		"namespace": func(a *v1alpha1.Agent) { a.Metadata.Namespace = "team-b" },
+		"name":      func(a *v1alpha1.Agent) { a.Metadata.Name = "other-bot" },
+		"tag":       func(a *v1alpha1.Agent) { a.Metadata.Tag = "2.0.0" },
+		"kind":      func(a *v1alpha1.Agent) { a.Kind = v1alpha1.KindSkill },
+	} {
+		t.Run(name, func(t *testing.T) {
+			obj := testAgent("summarizer")
+			require.NoError(t, Sign(obj, key, ""))
+			move(obj)
+			require.ErrorIs(t, Verify(obj, key.Public()), ErrInvalidSignature)
+		})
+	}
+
+	unqualified := testAgent("summarizer")
+	unqualified.Metadata.Namespace, unqualified.Metadata.Tag = "", ""
+	require.NoError(t, Sign(unqualified, key, ""))
+	applied := testAgent("summarizer")
+	applied.Metadata.Tag = v1alpha1store.DefaultTag()
+	applied.Metadata.Annotations = unqualified.Metadata.Annotations
+	require.NoError(t, Verify(applied, key.Public()), "a manifest signed without namespace or tag verifies once apply defaults them")
+}
+
+func TestSignature_Malformed(t *testing.T) {
+	obj := testAgent("summarizer")
+	_, _, ok, err := Signature(obj)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	obj.Metadata.Annotations[SignatureAnnotation] = "not base64!"
+	_, _, ok, err = Signature(obj)
+	require.True(t, ok)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestParsePrivateKey_EncryptedCosignKey(t *testing.T) {
+	key := testKeys(t)["ecdsa"]
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	var enc encryptedKey
+	enc.KDF.Name = "scrypt"
+	enc.KDF.Params.N, enc.KDF.Params.R, enc.KDF.Params.P = 1024, 8, 1
+	enc.KDF.Salt = []byte("0123456789abcdef")
+	enc.Cipher.Name = "nacl/secretbox"
+	enc.Cipher.Nonce = make([]byte, 24)
+	derived, err := scrypt.Key([]byte("hunter2"), enc.KDF.Salt, 1024, 8, 1, 32)
+	require.NoError(t, err)
+	var secret [32]byte
+	var nonce [24]byte
+	copy(secret[:], derived)
+	enc.Ciphertext = secretbox.Seal(nil, der, &nonce, &secret)
+	body, err := json.Marshal(enc)
+	require.NoError(t, err)
+	data := pem.EncodeToMemory(&pem.Block{Type: sigstorePrivateKeyPEMType, Bytes: body})
+
+	_, err = ParsePrivateKey(data, nil)
+	require.ErrorIs(t, err, ErrPasswordRequired)
+	_, err = ParsePrivateKey(data, []byte("wrong"))
+	require.Error(t, err)
+
+	got, err := ParsePrivateKey(data, []byte("hunter2"))
+	require.NoError(t, err)
+	require.True(t, key.Public().(*ecdsa.PublicKey).Equal(got.Public()))
+}
+
+func TestParsePrivateKey_Unencrypted(t *testing.T) {
+	for name, key := range testKeys(t) {
+		t.Run(name, func(t *testing.T) {
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			require.NoError(t, err)
+			got, err := ParsePrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil)
+			require.NoError(t, err)
+
+			pub, err := ParsePublicKey([]byte(publicKeyPEM(t, got)))
+			require.NoError(t, err)
+			sig, err := SignPayload(got, []byte("payload"))
+			require.NoError(t, err)
+			require.NoError(t, VerifyPayload(pub, []byte("payload"), sig))
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package signing
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+// AnyNamespace is the trust-policy key whose public keys are trusted in
+// every namespace, in addition to the namespace's own keys.
+const AnyNamespace = "*"
+
+// TrustedKey is one public key a TrustPolicy accepts signatures from.
+type TrustedKey struct {
+	Name string
+	Key  crypto.PublicKey
+}
+
+// TrustPolicy lists the public keys trusted to sign artifacts, per
+// namespace. A nil *TrustPolicy trusts no keys.
+type TrustPolicy struct {
+	keys map[string][]TrustedKey
+}
+
+// trustPolicyFile is the on-disk shape of a TrustPolicy:
+//
+//	namespaces:
+//	  "*":
+//	    - name: org-release
+//	      publicKeyFile: keys/org-release.pub
+//	  team-a:
+//	    - name: team-a
+//	      publicKey: |
+//	        -----BEGIN PUBLIC KEY-----
+//	        ...
+type trustPolicyFile struct {
+	Namespaces map[string][]struct {
+		Name          string `json:"name"`
+		PublicKey     string `json:"publicKey,omitempty"`
+		PublicKeyFile string `json:"publicKeyFile,omitempty"`
+	} `json:"namespaces"`
+}
+
+// LoadTrustPolicy reads a trust policy from a YAML file. An empty path
+// returns a nil policy: signatures are not checked.
+func LoadTrustPolicy(path string) (*TrustPolicy, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTrustPolicy(data, filepath.Dir(path))
+}
+
+// ParseTrustPolicy parses the YAML form of a trust policy. Relative
+// publicKeyFile paths resolve against baseDir.
+func ParseTrustPolicy(data []byte, baseDir string) (*TrustPolicy, error) {
+	var file trustPolicyFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("parse trust policy: %w", err)
+	}
+	policy := &TrustPolicy{keys: map[string][]TrustedKey{}}
+	for namespace, entries := range file.Namespaces {
+		if namespace == "" {
+			return nil, errors.New("trust policy: empty namespace")
+		}
+		seen := map[string]bool{}
+		for i, entry := range entries {
+			if entry.Name == "" {
+				return nil, fmt.Errorf("trust policy: %s key %d: name is required", namespace, i)
+			}
+			if seen[entry.Name] {
+				return nil, fmt.Errorf("trust policy: %s: duplicate key %q", namespace, entry.Name)
+			}
+			seen[entry.Name] = true
+			pemBytes := []byte(entry.PublicKey)
+			switch {
+			case entry.PublicKey != "" && entry.PublicKeyFile != "":
+				return nil, fmt.Errorf("trust policy: %s key %q: set publicKey or publicKeyFile, not both", namespace, entry.Name)
+			case entry.PublicKeyFile != "":
+				path := entry.PublicKeyFile
+				if !filepath.IsAbs(path) {
+					path = filepath.Join(baseDir, path)
+				}
+				var err error
+				if pemBytes, err = os.ReadFile(path); err != nil {
+					return nil, fmt.Errorf("trust policy: %s key %q: %w", namespace, entry.Name, err)
+				}
+			case entry.PublicKey == "":
+				return nil, fmt.Errorf("trust policy: %s key %q: publicKey or publicKeyFile is required", namespace, entry.Name)
+			}
+			key, err := ParsePublicKey(pemBytes)
+			if err != nil {
+				return nil, fmt.Errorf("trust policy: %s key %q: %w", namespace, entry.Name, err)
+			}
+			policy.keys[namespace] = append(policy.keys[namespace], TrustedKey{Name: entry.Name, Key: key})
+		}
+	}
+	return policy, nil
+}
+
+// Keys returns the keys trusted in namespace: its own keys, then the
+// keys trusted in every namespace.
+func (p *TrustPolicy) Keys(namespace string) []TrustedKey {
+	if p == nil {
+		return nil
+	}
+	out := append([]TrustedKey(nil), p.keys[namespace]...)
+	if namespace != AnyNamespace {
+		out = append(out, p.keys[AnyNamespace]...)
+	}
+	return out
+}
+
+// Namespaces returns the namespaces with keys configured, sorted.
+func (p *TrustPolicy) Namespaces() []string {
+	if p == nil {
+		return nil
+	}
+	out := make([]string, 0, len(p.keys))
+	for ns := range p.keys {
+		out = append(out, ns)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Verification reasons recorded on the VerifiedCondition.
+const (
+	ReasonVerified      = "Verified"
+	ReasonUnsigned      = "Unsigned"
+	ReasonNoTrustedKeys = "NoTrustedKeys"
+)
+
+// Verification is the outcome of checking an artifact against a
+// TrustPolicy, shaped as the VerifiedCondition it is recorded as. Key
+// names the trusted key that verified the signature.
+type Verification struct {
+	Status  v1alpha1.ConditionStatus
+	Reason  string
+	Message string
+	Key     string
+}
+
+// Verify checks the signature on obj against the keys trusted in obj's
+// namespace. An unsigned artifact, or a signed one in a namespace with no
+// trusted keys, is not an error: the returned Verification records it. A
+// signature that is malformed, or that no trusted key verifies, returns
+// ErrInvalidSignature.
+func (p *TrustPolicy) Verify(obj v1alpha1.Object) (Verification, error) {
+	sig, keyName, ok, err := Signature(obj)
+	if err != nil {
+		return Verification{}, err
+	}
+	if !ok {
+		return Verification{Status: v1alpha1.ConditionFalse, Reason: ReasonUnsigned, Message: "artifact is not signed"}, nil
+	}
+	namespace := obj.GetMetadata().NamespaceOrDefault()
+	keys := p.Keys(namespace)
+	if len(keys) == 0 {
+		return Verification{
+			Status:  v1alpha1.ConditionUnknown,
+			Reason:  ReasonNoTrustedKeys,
+			Message: fmt.Sprintf("no keys are trusted in namespace %q", namespace),
+		}, nil
+	}
+	payload, err := Payload(obj)
+	if err != nil {
+		return Verification{}, err
+	}
+	for _, k := range keys {
+		if keyName != "" && k.Name != keyName {
+			continue
+		}
+		if VerifyPayload(k.Key, payload, sig) == nil {
+			return Verification{
+				Status:  v1alpha1.ConditionTrue,
+				Reason:  ReasonVerified,
+				Message: fmt.Sprintf("signature verified with key %q", k.Name),
+				Key:     k.Name,
+			}, nil
+		}
+	}
+	if keyName != "" {
+		return Verification{}, fmt.Errorf("%w: key %q is not trusted in namespace %q or does not match the content", ErrInvalidSignature, keyName, namespace)
+	}
+	return Verification{}, fmt.Errorf("%w: no key trusted in namespace %q verifies the content", ErrInvalidSignature, namespace)
+}
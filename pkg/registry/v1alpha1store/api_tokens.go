@@ -0,0 +1,190 @@
+package v1alpha1store
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// apiTokenTouchInterval rate-limits last-used updates, so a busy token
+// does not write on every request.
+const apiTokenTouchInterval = time.Minute
+
+// APIToken is one API token's metadata. The token itself is never stored,
+// only its hash.
+type APIToken struct {
+	ID   string
+	Name string
+	// Owner is the subject of the user who created the token.
+	Owner string
+	// Subject is the identity the token authenticates as.
+	Subject    string
+	Scopes     []auth.Permission
+	ExpiresAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// Active reports whether the token still authenticates at now.
+func (t APIToken) Active(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// APITokenStore persists API tokens.
+type APITokenStore struct {
+	pool      *pgxpool.Pool
+	qualified string
+}
+
+// NewAPITokenStore constructs an API token store.
+func NewAPITokenStore(pool *pgxpool.Pool, schema pkgdb.Schema) *APITokenStore {
+	return &APITokenStore{
+		pool:      pool,
+		qualified: schema.Qualify("api_tokens"),
+	}
+}
+
+const apiTokenColumns = `id, name, owner, subject, scopes, expires_at, last_used_at, revoked_at, created_at`
+
+// Create stores token under hash, assigning its ID and CreatedAt.
+func (s *APITokenStore) Create(ctx context.Context, token APIToken, hash string) (APIToken, error) {
+	if s == nil || s.pool == nil {
+		return APIToken{}, errors.New("v1alpha1 store: API token store has nil pool")
+	}
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	token.ID = hex.EncodeToString(id)
+	scopes, err := json.Marshal(token.Scopes)
+	if err != nil {
+		return APIToken{}, fmt.Errorf("encode API token scopes: %w", err)
+	}
+	row := s.pool.QueryRow(ctx, `
+		INSERT INTO `+s.qualified+` (id, token_hash, name, owner, subject, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+apiTokenColumns,
+		token.ID, hash, token.Name, token.Owner, token.Subject, scopes, token.ExpiresAt)
+	out, err := scanAPIToken(row)
+	if err != nil {
+		return APIToken{}, fmt.Errorf("store API token: %w", err)
+	}
+	return out, nil
+}
+
+// Get returns the token with id, or pkgdb.ErrNotFound.
+func (s *APITokenStore) Get(ctx context.Context, id string) (APIToken, error) {
+	if s == nil || s.pool == nil {
+		return APIToken{}, errors.New("v1alpha1 store: API token store has nil pool")
+	}
+	out, err := scanAPIToken(s.pool.QueryRow(ctx, `SELECT `+apiTokenColumns+` FROM `+s.qualified+` WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return APIToken{}, pkgdb.ErrNotFound
+	}
+	if err != nil {
+		return APIToken{}, fmt.Errorf("load API token %s: %w", id, err)
+	}
+	return out, nil
+}
+
+// GetByHash returns the token stored under hash, revoked or not, or
+// pkgdb.ErrNotFound.
+func (s *APITokenStore) GetByHash(ctx context.Context, hash string) (APIToken, error) {
+	if s == nil || s.pool == nil {
+		return APIToken{}, errors.New("v1alpha1 store: API token store has nil pool")
+	}
+	out, err := scanAPIToken(s.pool.QueryRow(ctx, `SELECT `+apiTokenColumns+` FROM `+s.qualified+` WHERE token_hash = $1`, hash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return APIToken{}, pkgdb.ErrNotFound
+	}
+	if err != nil {
+		return APIToken{}, fmt.Errorf("load API token: %w", err)
+	}
+	return out, nil
+}
+
+// List returns the tokens owner created, newest first, or every token when
+// owner is empty.
+func (s *APITokenStore) List(ctx context.Context, owner string) ([]APIToken, error) {
+	if s == nil || s.pool == nil {
+		return nil, errors.New("v1alpha1 store: API token store has nil pool")
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+apiTokenColumns+` FROM `+s.qualified+`
+		WHERE $1 = '' OR owner = $1
+		ORDER BY created_at DESC, id`, owner)
+	if err != nil {
+		return nil, fmt.Errorf("list API tokens: %w", err)
+	}
+	defer rows.Close()
+	var out []APIToken
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("list API tokens: %w", err)
+		}
+		out = append(out, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list API tokens: %w", err)
+	}
+	return out, nil
+}
+
+// Revoke marks the token with id revoked and returns it. Revoking a
+// revoked token keeps its original revocation time. Returns
+// pkgdb.ErrNotFound for an unknown id.
+func (s *APITokenStore) Revoke(ctx context.Context, id string) (APIToken, error) {
+	if s == nil || s.pool == nil {
+		return APIToken{}, errors.New("v1alpha1 store: API token store has nil pool")
+	}
+	out, err := scanAPIToken(s.pool.QueryRow(ctx, `
+		UPDATE `+s.qualified+` SET revoked_at = COALESCE(revoked_at, now())
+		WHERE id = $1
+		RETURNING `+apiTokenColumns, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return APIToken{}, pkgdb.ErrNotFound
+	}
+	if err != nil {
+		return APIToken{}, fmt.Errorf("revoke API token %s: %w", id, err)
+	}
+	return out, nil
+}
+
+// Touch records that the token with id was just used. Updates within
+// apiTokenTouchInterval of the previous one are skipped.
+func (s *APITokenStore) Touch(ctx context.Context, id string) error {
+	if s == nil || s.pool == nil {
+		return errors.New("v1alpha1 store: API token store has nil pool")
+	}
+	if _, err := s.pool.Exec(ctx, `
+		UPDATE `+s.qualified+` SET last_used_at = now()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < now() - make_interval(secs => $2))`,
+		id, apiTokenTouchInterval.Seconds()); err != nil {
+		return fmt.Errorf("record API token use %s: %w", id, err)
+	}
+	return nil
+}
+
+func scanAPIToken(row pgx.Row) (APIToken, error) {
+	var (
+		out    APIToken
+		scopes []byte
+	)
+	if err := row.Scan(&out.ID, &out.Name, &out.Owner, &out.Subject, &scopes, &out.ExpiresAt, &out.LastUsedAt, &out.RevokedAt, &out.CreatedAt); err != nil {
+		return APIToken{}, err
+	}
+	if err := json.Unmarshal(scopes, &out.Scopes); err != nil {
+		return APIToken{}, fmt.Errorf("decode API token scopes: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,73 @@
+//go:build integration
+
+package v1alpha1store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+func TestAPITokenStore_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	pool := NewTestPool(t)
+	tokens := NewAPITokenStore(pool, TestSchema())
+
+	expires := time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+	_, hash := auth.NewAPIToken()
+	created, err := tokens.Create(ctx, APIToken{
+		Name:      "ci",
+		Owner:     "alice",
+		Subject:   "alice",
+		Scopes:    []auth.Permission{{Action: auth.PermissionActionRead, ResourcePattern: "team-a/*"}},
+		ExpiresAt: expires,
+	}, hash)
+	require.NoError(t, err)
+	require.NotEmpty(t, created.ID)
+	require.True(t, created.Active(time.Now()))
+	require.False(t, created.Active(expires))
+
+	got, err := tokens.GetByHash(ctx, hash)
+	require.NoError(t, err)
+	require.Equal(t, created.ID, got.ID)
+	require.Equal(t, created.Scopes, got.Scopes)
+	require.Nil(t, got.LastUsedAt)
+
+	_, otherHash := auth.NewAPIToken()
+	_, err = tokens.Create(ctx, APIToken{Name: "bot", Owner: "bob", Subject: auth.ServiceAccountSubjectPrefix + "bot", Scopes: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "*"}}, ExpiresAt: expires}, otherHash)
+	require.NoError(t, err)
+
+	mine, err := tokens.List(ctx, "alice")
+	require.NoError(t, err)
+	require.Len(t, mine, 1)
+	all, err := tokens.List(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+
+	require.NoError(t, tokens.Touch(ctx, created.ID))
+	touched, err := tokens.Get(ctx, created.ID)
+	require.NoError(t, err)
+	require.NotNil(t, touched.LastUsedAt)
+	require.NoError(t, tokens.Touch(ctx, created.ID))
+	again, err := tokens.Get(ctx, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, touched.LastUsedAt, again.LastUsedAt, "touches within the interval are skipped")
+
+	revoked, err := tokens.Revoke(ctx, created.ID)
+	require.NoError(t, err)
+	require.NotNil(t, revoked.RevokedAt)
+	require.False(t, revoked.Active(time.Now()))
+	twice, err := tokens.Revoke(ctx, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, revoked.RevokedAt, twice.RevokedAt, "revoking again keeps the first revocation time")
+
+	_, err = tokens.Revoke(ctx, "missing")
+	require.ErrorIs(t, err, pkgdb.ErrNotFound)
+	_, err = tokens.GetByHash(ctx, auth.HashAPIToken("arpat_missing"))
+	require.ErrorIs(t, err, pkgdb.ErrNotFound)
+}
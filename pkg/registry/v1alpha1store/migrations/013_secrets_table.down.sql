@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS secrets_control_plane_event ON secrets;
+DROP TRIGGER IF EXISTS secrets_notify_status ON secrets;
+DROP TRIGGER IF EXISTS secrets_set_updated_at ON secrets;
+DROP TABLE IF EXISTS secrets;
@@ -0,0 +1,36 @@
+-- Secrets: registry-held key material referenced by SecretKeyRef (Model
+-- auth/TLS). A mutable-object kind keyed by (namespace, name): rotation
+-- replaces the value in place. spec.data is never persisted; the server writes
+-- the envelope-encrypted form to spec.encrypted before the row lands here.
+-- Wires the standard updated-at, status-notify, and control-plane event
+-- triggers used by mutable resources.
+
+CREATE TABLE IF NOT EXISTS secrets (
+    namespace character varying(255) NOT NULL,
+    name character varying(255) NOT NULL,
+    uid uuid DEFAULT gen_random_uuid() NOT NULL,
+    generation bigint DEFAULT 1 NOT NULL,
+    labels jsonb DEFAULT '{}'::jsonb NOT NULL,
+    annotations jsonb DEFAULT '{}'::jsonb NOT NULL,
+    spec jsonb NOT NULL,
+    status jsonb DEFAULT '{}'::jsonb NOT NULL,
+    deletion_timestamp timestamp with time zone,
+    finalizers jsonb DEFAULT '[]'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (namespace, name)
+);
+
+CREATE INDEX IF NOT EXISTS secrets_labels_gin ON secrets USING gin (labels);
+CREATE INDEX IF NOT EXISTS secrets_terminating ON secrets USING btree (deletion_timestamp) WHERE (deletion_timestamp IS NOT NULL);
+CREATE INDEX IF NOT EXISTS secrets_updated_at_desc ON secrets USING btree (updated_at DESC);
+
+CREATE OR REPLACE TRIGGER secrets_set_updated_at
+    BEFORE UPDATE ON secrets
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE OR REPLACE TRIGGER secrets_notify_status
+    AFTER INSERT OR UPDATE OR DELETE ON secrets
+    FOR EACH ROW EXECUTE FUNCTION notify_status_change('secrets_status');
+CREATE OR REPLACE TRIGGER secrets_control_plane_event
+    AFTER INSERT OR UPDATE OR DELETE ON secrets
+    FOR EACH ROW EXECUTE FUNCTION record_control_plane_event('Secret');
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS skill_archives;
@@ -0,0 +1,12 @@
+-- Skill archives: content-addressed skill tarballs uploaded through
+-- POST /v0/skill-archives and referenced by Skill spec.source.archive.digest.
+-- Rows are immutable; the digest is the sha256 of content, so re-uploading
+-- the same bytes is a no-op.
+
+CREATE TABLE IF NOT EXISTS skill_archives (
+    digest character varying(80) NOT NULL,
+    size bigint NOT NULL,
+    content bytea NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (digest)
+);
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS tag_aliases_control_plane_event ON tag_aliases;
+DROP TRIGGER IF EXISTS tag_aliases_set_updated_at ON tag_aliases;
+DROP FUNCTION IF EXISTS record_tag_alias_event();
+DROP TABLE IF EXISTS tag_aliases;
@@ -0,0 +1,77 @@
+-- Tag aliases: movable tag names (e.g. `stable`, `prod`) that point at a
+-- concrete tag of a tagged-artifact row. Keyed by (kind, namespace, name,
+-- alias); an alias never shares its name with a concrete tag of the same
+-- object. Moving an alias bumps generation and appends a control_plane_events
+-- row under the target kind so Deployments referencing the alias roll out.
+
+CREATE TABLE IF NOT EXISTS tag_aliases (
+    kind text NOT NULL,
+    namespace character varying(255) NOT NULL,
+    name character varying(255) NOT NULL,
+    alias character varying(255) NOT NULL,
+    target_tag character varying(255) NOT NULL,
+    uid uuid DEFAULT gen_random_uuid() NOT NULL,
+    generation bigint DEFAULT 1 NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (kind, namespace, name, alias)
+);
+
+CREATE OR REPLACE FUNCTION record_tag_alias_event()
+RETURNS TRIGGER AS $$
+DECLARE
+    event_op TEXT;
+    event_revision BIGINT;
+    row_data tag_aliases%ROWTYPE;
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        IF NEW.target_tag = OLD.target_tag THEN
+            RETURN NEW;
+        END IF;
+        event_op := 'update';
+        row_data := NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        event_op := 'delete';
+        row_data := OLD;
+    ELSE
+        event_op := 'insert';
+        row_data := NEW;
+    END IF;
+
+    INSERT INTO control_plane_events (
+        kind,
+        namespace,
+        name,
+        tag,
+        uid,
+        generation,
+        op
+    ) VALUES (
+        row_data.kind,
+        row_data.namespace,
+        row_data.name,
+        row_data.alias,
+        row_data.uid,
+        row_data.generation,
+        event_op
+    )
+    RETURNING revision INTO event_revision;
+
+    PERFORM pg_notify(
+        'v1alpha1_control_plane_changed',
+        json_build_object('revision', event_revision)::text
+    );
+
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER tag_aliases_set_updated_at
+    BEFORE UPDATE ON tag_aliases
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE OR REPLACE TRIGGER tag_aliases_control_plane_event
+    AFTER INSERT OR UPDATE OR DELETE ON tag_aliases
+    FOR EACH ROW EXECUTE FUNCTION record_tag_alias_event();
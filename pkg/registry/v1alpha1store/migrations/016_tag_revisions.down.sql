@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS tag_revisions_ref;
+DROP TABLE IF EXISTS tag_revisions;
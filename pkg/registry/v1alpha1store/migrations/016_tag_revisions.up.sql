@@ -0,0 +1,22 @@
+-- Tag revisions: the content a tagged-artifact row held before each
+-- in-place replacement. Keyed by the row's (uid, generation), so a tag
+-- that is deleted and re-applied (new uid) starts a fresh history. The
+-- live row is always the newest revision and is not copied here.
+
+CREATE TABLE IF NOT EXISTS tag_revisions (
+    uid uuid NOT NULL,
+    generation bigint NOT NULL,
+    kind text NOT NULL,
+    namespace character varying(255) NOT NULL,
+    name character varying(255) NOT NULL,
+    tag character varying(255) NOT NULL,
+    labels jsonb DEFAULT '{}'::jsonb NOT NULL,
+    annotations jsonb DEFAULT '{}'::jsonb NOT NULL,
+    spec jsonb NOT NULL,
+    content_hash character(64) NOT NULL,
+    applied_at timestamp with time zone NOT NULL,
+    replaced_at timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (uid, generation)
+);
+
+CREATE INDEX IF NOT EXISTS tag_revisions_ref ON tag_revisions USING btree (kind, namespace, name, tag, generation DESC);
@@ -0,0 +1,21 @@
+DROP INDEX IF EXISTS agents_search_vector;
+DROP INDEX IF EXISTS mcp_servers_search_vector;
+DROP INDEX IF EXISTS skills_search_vector;
+DROP INDEX IF EXISTS prompts_search_vector;
+DROP INDEX IF EXISTS plugins_search_vector;
+DROP INDEX IF EXISTS models_search_vector;
+DROP INDEX IF EXISTS runtimes_search_vector;
+DROP INDEX IF EXISTS deployments_search_vector;
+
+ALTER TABLE agents      DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE mcp_servers DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE skills      DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE prompts     DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE plugins     DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE models      DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE runtimes    DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE deployments DROP COLUMN IF EXISTS search_vector;
+
+DROP FUNCTION IF EXISTS search_document(text, jsonb, jsonb, jsonb);
+DROP FUNCTION IF EXISTS search_extras(jsonb, jsonb);
+DROP FUNCTION IF EXISTS search_json_array(jsonb);
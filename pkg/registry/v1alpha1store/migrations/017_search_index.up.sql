@@ -0,0 +1,58 @@
+-- Full-text search across registry kinds. Each searchable table carries a
+-- generated search_vector column, so the index is maintained by every
+-- upsert without application code or triggers:
+--
+--   A: name, spec.title
+--   B: spec.description
+--   C: search_extras — label keys and values, MCP tool names, titles and
+--      descriptions (status.capabilities.tools), and plugin inventory
+--      entries (status.inventory)
+--
+-- The functions use SQL-standard bodies so their references are bound when
+-- they are created and they do not depend on the caller's search_path.
+-- Secrets are deliberately not indexed.
+
+CREATE OR REPLACE FUNCTION search_json_array(doc jsonb) RETURNS jsonb
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    RETURN CASE WHEN jsonb_typeof(doc) = 'array' THEN doc ELSE '[]'::jsonb END;
+
+CREATE OR REPLACE FUNCTION search_extras(labels jsonb, status jsonb) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    RETURN concat_ws(' ',
+        (SELECT string_agg(l.key || ' ' || l.value, ' ')
+           FROM jsonb_each_text(CASE WHEN jsonb_typeof(labels) = 'object' THEN labels ELSE '{}'::jsonb END) AS l),
+        (SELECT string_agg(concat_ws(' ', t.item ->> 'name', t.item ->> 'title', t.item ->> 'description'), ' ')
+           FROM jsonb_array_elements(search_json_array(status #> '{capabilities,tools}')) AS t(item)),
+        (SELECT string_agg(concat_ws(' ', s.item ->> 'name', s.item ->> 'description'), ' ')
+           FROM jsonb_array_elements(search_json_array(status #> '{inventory,skills}')) AS s(item)),
+        (SELECT string_agg(e.item, ' ')
+           FROM jsonb_array_elements_text(
+                    search_json_array(status #> '{inventory,commands}')
+                 || search_json_array(status #> '{inventory,agents}')
+                 || search_json_array(status #> '{inventory,mcpServers}')
+                 || search_json_array(status #> '{inventory,executables}')) AS e(item))
+    );
+
+CREATE OR REPLACE FUNCTION search_document(name text, labels jsonb, spec jsonb, status jsonb) RETURNS tsvector
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    RETURN setweight(to_tsvector('english', concat_ws(' ', name, spec ->> 'title')), 'A')
+        || setweight(to_tsvector('english', coalesce(spec ->> 'description', '')), 'B')
+        || setweight(to_tsvector('english', search_extras(labels, status)), 'C');
+
+ALTER TABLE agents      ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (search_document(name, labels, spec, status)) STORED;
+ALTER TABLE mcp_servers ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (search_document(name, labels, spec, status)) STORED;
+ALTER TABLE skills      ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (search_document(name, labels, spec, status)) STORED;
+ALTER TABLE prompts     ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (search_document(name, labels, spec, status)) STORED;
+ALTER TABLE plugins     ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (search_document(name, labels, spec, status)) STORED;
+ALTER TABLE models      ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (search_document(name, labels, spec, status)) STORED;
+ALTER TABLE runtimes    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (search_document(name, labels, spec, status)) STORED;
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (search_document(name, labels, spec, status)) STORED;
+
+CREATE INDEX IF NOT EXISTS agents_search_vector      ON agents      USING gin (search_vector);
+CREATE INDEX IF NOT EXISTS mcp_servers_search_vector ON mcp_servers USING gin (search_vector);
+CREATE INDEX IF NOT EXISTS skills_search_vector      ON skills      USING gin (search_vector);
+CREATE INDEX IF NOT EXISTS prompts_search_vector     ON prompts     USING gin (search_vector);
+CREATE INDEX IF NOT EXISTS plugins_search_vector     ON plugins     USING gin (search_vector);
+CREATE INDEX IF NOT EXISTS models_search_vector      ON models      USING gin (search_vector);
+CREATE INDEX IF NOT EXISTS runtimes_search_vector    ON runtimes    USING gin (search_vector);
+CREATE INDEX IF NOT EXISTS deployments_search_vector ON deployments USING gin (search_vector);
@@ -0,0 +1,14 @@
+DROP TRIGGER IF EXISTS role_bindings_control_plane_event ON role_bindings;
+DROP TRIGGER IF EXISTS role_bindings_notify_status ON role_bindings;
+DROP TRIGGER IF EXISTS role_bindings_set_updated_at ON role_bindings;
+DROP TABLE IF EXISTS role_bindings;
+
+DROP TRIGGER IF EXISTS cluster_roles_control_plane_event ON cluster_roles;
+DROP TRIGGER IF EXISTS cluster_roles_notify_status ON cluster_roles;
+DROP TRIGGER IF EXISTS cluster_roles_set_updated_at ON cluster_roles;
+DROP TABLE IF EXISTS cluster_roles;
+
+DROP TRIGGER IF EXISTS roles_control_plane_event ON roles;
+DROP TRIGGER IF EXISTS roles_notify_status ON roles;
+DROP TRIGGER IF EXISTS roles_set_updated_at ON roles;
+DROP TABLE IF EXISTS roles;
@@ -0,0 +1,96 @@
+-- RBAC: Role, ClusterRole and RoleBinding, the objects the RBAC authz
+-- provider evaluates. Mutable-object kinds keyed by (namespace, name);
+-- ClusterRoles live in the default namespace. Wires the standard
+-- updated-at, status-notify, and control-plane event triggers used by
+-- mutable resources; the provider drops its cached policy on the status
+-- notifications.
+
+CREATE TABLE IF NOT EXISTS roles (
+    namespace character varying(255) NOT NULL,
+    name character varying(255) NOT NULL,
+    uid uuid DEFAULT gen_random_uuid() NOT NULL,
+    generation bigint DEFAULT 1 NOT NULL,
+    labels jsonb DEFAULT '{}'::jsonb NOT NULL,
+    annotations jsonb DEFAULT '{}'::jsonb NOT NULL,
+    spec jsonb NOT NULL,
+    status jsonb DEFAULT '{}'::jsonb NOT NULL,
+    deletion_timestamp timestamp with time zone,
+    finalizers jsonb DEFAULT '[]'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (namespace, name)
+);
+
+CREATE INDEX IF NOT EXISTS roles_labels_gin ON roles USING gin (labels);
+CREATE INDEX IF NOT EXISTS roles_terminating ON roles USING btree (deletion_timestamp) WHERE (deletion_timestamp IS NOT NULL);
+CREATE INDEX IF NOT EXISTS roles_updated_at_desc ON roles USING btree (updated_at DESC);
+
+CREATE OR REPLACE TRIGGER roles_set_updated_at
+    BEFORE UPDATE ON roles
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE OR REPLACE TRIGGER roles_notify_status
+    AFTER INSERT OR UPDATE OR DELETE ON roles
+    FOR EACH ROW EXECUTE FUNCTION notify_status_change('roles_status');
+CREATE OR REPLACE TRIGGER roles_control_plane_event
+    AFTER INSERT OR UPDATE OR DELETE ON roles
+    FOR EACH ROW EXECUTE FUNCTION record_control_plane_event('Role');
+
+CREATE TABLE IF NOT EXISTS cluster_roles (
+    namespace character varying(255) NOT NULL,
+    name character varying(255) NOT NULL,
+    uid uuid DEFAULT gen_random_uuid() NOT NULL,
+    generation bigint DEFAULT 1 NOT NULL,
+    labels jsonb DEFAULT '{}'::jsonb NOT NULL,
+    annotations jsonb DEFAULT '{}'::jsonb NOT NULL,
+    spec jsonb NOT NULL,
+    status jsonb DEFAULT '{}'::jsonb NOT NULL,
+    deletion_timestamp timestamp with time zone,
+    finalizers jsonb DEFAULT '[]'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (namespace, name)
+);
+
+CREATE INDEX IF NOT EXISTS cluster_roles_labels_gin ON cluster_roles USING gin (labels);
+CREATE INDEX IF NOT EXISTS cluster_roles_terminating ON cluster_roles USING btree (deletion_timestamp) WHERE (deletion_timestamp IS NOT NULL);
+CREATE INDEX IF NOT EXISTS cluster_roles_updated_at_desc ON cluster_roles USING btree (updated_at DESC);
+
+CREATE OR REPLACE TRIGGER cluster_roles_set_updated_at
+    BEFORE UPDATE ON cluster_roles
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE OR REPLACE TRIGGER cluster_roles_notify_status
+    AFTER INSERT OR UPDATE OR DELETE ON cluster_roles
+    FOR EACH ROW EXECUTE FUNCTION notify_status_change('cluster_roles_status');
+CREATE OR REPLACE TRIGGER cluster_roles_control_plane_event
+    AFTER INSERT OR UPDATE OR DELETE ON cluster_roles
+    FOR EACH ROW EXECUTE FUNCTION record_control_plane_event('ClusterRole');
+
+CREATE TABLE IF NOT EXISTS role_bindings (
+    namespace character varying(255) NOT NULL,
+    name character varying(255) NOT NULL,
+    uid uuid DEFAULT gen_random_uuid() NOT NULL,
+    generation bigint DEFAULT 1 NOT NULL,
+    labels jsonb DEFAULT '{}'::jsonb NOT NULL,
+    annotations jsonb DEFAULT '{}'::jsonb NOT NULL,
+    spec jsonb NOT NULL,
+    status jsonb DEFAULT '{}'::jsonb NOT NULL,
+    deletion_timestamp timestamp with time zone,
+    finalizers jsonb DEFAULT '[]'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (namespace, name)
+);
+
+CREATE INDEX IF NOT EXISTS role_bindings_labels_gin ON role_bindings USING gin (labels);
+CREATE INDEX IF NOT EXISTS role_bindings_terminating ON role_bindings USING btree (deletion_timestamp) WHERE (deletion_timestamp IS NOT NULL);
+CREATE INDEX IF NOT EXISTS role_bindings_updated_at_desc ON role_bindings USING btree (updated_at DESC);
+
+CREATE OR REPLACE TRIGGER role_bindings_set_updated_at
+    BEFORE UPDATE ON role_bindings
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE OR REPLACE TRIGGER role_bindings_notify_status
+    AFTER INSERT OR UPDATE OR DELETE ON role_bindings
+    FOR EACH ROW EXECUTE FUNCTION notify_status_change('role_bindings_status');
+CREATE OR REPLACE TRIGGER role_bindings_control_plane_event
+    AFTER INSERT OR UPDATE OR DELETE ON role_bindings
+    FOR EACH ROW EXECUTE FUNCTION record_control_plane_event('RoleBinding');
@@ -0,0 +1,23 @@
+-- API tokens: long-lived bearer credentials for automation, managed through
+-- /v0/tokens. Only the SHA-256 of a token is stored. A token acts as its
+-- subject (the creator, or a service account) with the groups snapshotted
+-- at creation, narrowed to its scopes. Revoked rows are kept so listings
+-- show when a token stopped working.
+
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id character varying(64) NOT NULL,
+    token_hash character varying(64) NOT NULL,
+    name character varying(255) NOT NULL,
+    owner character varying(255) NOT NULL,
+    subject character varying(255) NOT NULL,
+    groups jsonb DEFAULT '[]'::jsonb NOT NULL,
+    scopes jsonb NOT NULL,
+    expires_at timestamp with time zone,
+    last_used_at timestamp with time zone,
+    revoked_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS api_tokens_token_hash ON api_tokens USING btree (token_hash);
+CREATE INDEX IF NOT EXISTS api_tokens_owner ON api_tokens USING btree (owner);
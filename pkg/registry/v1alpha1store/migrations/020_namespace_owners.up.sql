@@ -0,0 +1,17 @@
+-- Namespace owners: namespaces claimed by proving control of a domain
+-- through a DNS TXT record or an HTTPS well-known file, managed through
+-- /v0/namespace-owners. The namespace is the domain reversed (acme.com
+-- owns com.acme). Tagged artifacts applied in an owned namespace must be
+-- signed with the Ed25519 key that proved ownership; proving again with
+-- another published key replaces it.
+
+CREATE TABLE IF NOT EXISTS namespace_owners (
+    namespace character varying(63) NOT NULL,
+    domain character varying(255) NOT NULL,
+    method character varying(16) NOT NULL,
+    public_key bytea NOT NULL,
+    verified_by character varying(255) DEFAULT ''::character varying NOT NULL,
+    verified_at timestamp with time zone DEFAULT now() NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (namespace)
+);
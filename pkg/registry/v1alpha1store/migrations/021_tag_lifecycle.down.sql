@@ -0,0 +1,55 @@
+-- Move tag lifecycles back under status.lifecycle and restore the
+-- status-only change notification.
+
+CREATE OR REPLACE FUNCTION notify_status_change() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+DECLARE
+    channel TEXT := TG_ARGV[0];
+    payload JSON;
+    op TEXT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        op := 'INSERT';
+    ELSIF TG_OP = 'DELETE' THEN
+        op := 'DELETE';
+        payload := json_build_object(
+            'op', op,
+            'namespace', OLD.namespace,
+            'name', OLD.name,
+            'tag', to_jsonb(OLD)->>'tag');
+        PERFORM pg_notify(channel, payload::text);
+        RETURN OLD;
+    ELSE
+        op := 'UPDATE';
+        IF NEW.status::text = OLD.status::text THEN
+            RETURN NEW;
+        END IF;
+    END IF;
+    payload := json_build_object(
+        'op', op,
+        'namespace', NEW.namespace,
+        'name', NEW.name,
+        'tag', to_jsonb(NEW)->>'tag');
+    PERFORM pg_notify(channel, payload::text);
+    RETURN NEW;
+END;
+$$;
+
+UPDATE agents SET status = status || jsonb_build_object('lifecycle', lifecycle) WHERE lifecycle IS NOT NULL;
+ALTER TABLE agents DROP COLUMN IF EXISTS lifecycle;
+
+UPDATE mcp_servers SET status = status || jsonb_build_object('lifecycle', lifecycle) WHERE lifecycle IS NOT NULL;
+ALTER TABLE mcp_servers DROP COLUMN IF EXISTS lifecycle;
+
+UPDATE skills SET status = status || jsonb_build_object('lifecycle', lifecycle) WHERE lifecycle IS NOT NULL;
+ALTER TABLE skills DROP COLUMN IF EXISTS lifecycle;
+
+UPDATE prompts SET status = status || jsonb_build_object('lifecycle', lifecycle) WHERE lifecycle IS NOT NULL;
+ALTER TABLE prompts DROP COLUMN IF EXISTS lifecycle;
+
+UPDATE plugins SET status = status || jsonb_build_object('lifecycle', lifecycle) WHERE lifecycle IS NOT NULL;
+ALTER TABLE plugins DROP COLUMN IF EXISTS lifecycle;
+
+UPDATE models SET status = status || jsonb_build_object('lifecycle', lifecycle) WHERE lifecycle IS NOT NULL;
+ALTER TABLE models DROP COLUMN IF EXISTS lifecycle;
@@ -0,0 +1,61 @@
+-- Tag lifecycle: whether a published tag is deprecated or yanked. It was
+-- kept under status.lifecycle, but status is controller-owned and reset
+-- whenever a tag's content is replaced, so a re-apply silently reactivated
+-- a yanked tag. The lifecycle now has its own column that content writes
+-- never touch; reads still surface it as status.lifecycle.
+
+ALTER TABLE agents ADD COLUMN IF NOT EXISTS lifecycle jsonb;
+UPDATE agents SET lifecycle = status->'lifecycle', status = status - 'lifecycle' WHERE status->'lifecycle' IS NOT NULL;
+
+ALTER TABLE mcp_servers ADD COLUMN IF NOT EXISTS lifecycle jsonb;
+UPDATE mcp_servers SET lifecycle = status->'lifecycle', status = status - 'lifecycle' WHERE status->'lifecycle' IS NOT NULL;
+
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS lifecycle jsonb;
+UPDATE skills SET lifecycle = status->'lifecycle', status = status - 'lifecycle' WHERE status->'lifecycle' IS NOT NULL;
+
+ALTER TABLE prompts ADD COLUMN IF NOT EXISTS lifecycle jsonb;
+UPDATE prompts SET lifecycle = status->'lifecycle', status = status - 'lifecycle' WHERE status->'lifecycle' IS NOT NULL;
+
+ALTER TABLE plugins ADD COLUMN IF NOT EXISTS lifecycle jsonb;
+UPDATE plugins SET lifecycle = status->'lifecycle', status = status - 'lifecycle' WHERE status->'lifecycle' IS NOT NULL;
+
+ALTER TABLE models ADD COLUMN IF NOT EXISTS lifecycle jsonb;
+UPDATE models SET lifecycle = status->'lifecycle', status = status - 'lifecycle' WHERE status->'lifecycle' IS NOT NULL;
+
+-- Lifecycle changes notify status listeners like status changes do, so
+-- Deployments targeting the tag re-reconcile.
+CREATE OR REPLACE FUNCTION notify_status_change() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+DECLARE
+    channel TEXT := TG_ARGV[0];
+    payload JSON;
+    op TEXT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        op := 'INSERT';
+    ELSIF TG_OP = 'DELETE' THEN
+        op := 'DELETE';
+        payload := json_build_object(
+            'op', op,
+            'namespace', OLD.namespace,
+            'name', OLD.name,
+            'tag', to_jsonb(OLD)->>'tag');
+        PERFORM pg_notify(channel, payload::text);
+        RETURN OLD;
+    ELSE
+        op := 'UPDATE';
+        IF NEW.status::text = OLD.status::text
+           AND (to_jsonb(NEW)->'lifecycle') IS NOT DISTINCT FROM (to_jsonb(OLD)->'lifecycle') THEN
+            RETURN NEW;
+        END IF;
+    END IF;
+    payload := json_build_object(
+        'op', op,
+        'namespace', NEW.namespace,
+        'name', NEW.name,
+        'tag', to_jsonb(NEW)->>'tag');
+    PERFORM pg_notify(channel, payload::text);
+    RETURN NEW;
+END;
+$$;
@@ -0,0 +1,6 @@
+-- Allow API tokens without an expiry again and restore the groups column.
+-- Groups dropped by the up migration are not recovered; restored rows act
+-- with no groups.
+
+ALTER TABLE api_tokens ALTER COLUMN expires_at DROP NOT NULL;
+ALTER TABLE api_tokens ADD COLUMN IF NOT EXISTS groups jsonb DEFAULT '[]'::jsonb NOT NULL;
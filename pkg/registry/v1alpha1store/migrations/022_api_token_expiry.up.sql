@@ -0,0 +1,11 @@
+-- API tokens no longer snapshot their creator's groups: a token kept the
+-- groups it was issued with after its owner left them. Tokens now act only
+-- as their subject, and service-account membership is derived from the
+-- subject when the token is used. Every token must also expire; tokens
+-- issued without an expiry stop working 30 days after this migration, so
+-- their holders have time to reissue them.
+
+ALTER TABLE api_tokens DROP COLUMN IF EXISTS groups;
+
+UPDATE api_tokens SET expires_at = now() + interval '30 days' WHERE expires_at IS NULL;
+ALTER TABLE api_tokens ALTER COLUMN expires_at SET NOT NULL;
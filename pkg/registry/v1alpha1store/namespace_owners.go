@@ -0,0 +1,154 @@
+package v1alpha1store
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// NamespaceOwner records a verified claim on Namespace: the holder of
+// PublicKey proved control of Domain, of which Namespace is the reversed
+// form, through Method (auth.MethodDNS or auth.MethodHTTP).
+type NamespaceOwner struct {
+	Namespace string
+	Domain    string
+	Method    auth.Method
+	PublicKey ed25519.PublicKey
+	// VerifiedBy is the subject of the caller who submitted the latest
+	// proof, empty when it was submitted anonymously.
+	VerifiedBy string
+	VerifiedAt time.Time
+	CreatedAt  time.Time
+}
+
+// NamespaceOwnerStore persists namespace owners.
+type NamespaceOwnerStore struct {
+	pool      *pgxpool.Pool
+	qualified string
+}
+
+// NewNamespaceOwnerStore constructs a namespace owner store.
+func NewNamespaceOwnerStore(pool *pgxpool.Pool, schema pkgdb.Schema) *NamespaceOwnerStore {
+	return &NamespaceOwnerStore{
+		pool:      pool,
+		qualified: schema.Qualify("namespace_owners"),
+	}
+}
+
+const namespaceOwnerColumns = `namespace, domain, method, public_key, verified_by, verified_at, created_at`
+
+// Put records a verified proof for owner.Namespace, replacing the key,
+// method and verifier of an earlier one while keeping its CreatedAt.
+func (s *NamespaceOwnerStore) Put(ctx context.Context, owner NamespaceOwner) (NamespaceOwner, error) {
+	if s == nil || s.pool == nil {
+		return NamespaceOwner{}, errors.New("v1alpha1 store: namespace owner store has nil pool")
+	}
+	out, err := scanNamespaceOwner(s.pool.QueryRow(ctx, `
+		INSERT INTO `+s.qualified+` (namespace, domain, method, public_key, verified_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (namespace) DO UPDATE SET
+			domain = EXCLUDED.domain,
+			method = EXCLUDED.method,
+			public_key = EXCLUDED.public_key,
+			verified_by = EXCLUDED.verified_by,
+			verified_at = now()
+		RETURNING `+namespaceOwnerColumns,
+		owner.Namespace, owner.Domain, string(owner.Method), []byte(owner.PublicKey), owner.VerifiedBy))
+	if err != nil {
+		return NamespaceOwner{}, fmt.Errorf("store namespace owner %s: %w", owner.Namespace, err)
+	}
+	return out, nil
+}
+
+// Get returns the owner of namespace, or pkgdb.ErrNotFound when it is
+// unclaimed.
+func (s *NamespaceOwnerStore) Get(ctx context.Context, namespace string) (NamespaceOwner, error) {
+	if s == nil || s.pool == nil {
+		return NamespaceOwner{}, errors.New("v1alpha1 store: namespace owner store has nil pool")
+	}
+	out, err := scanNamespaceOwner(s.pool.QueryRow(ctx, `SELECT `+namespaceOwnerColumns+` FROM `+s.qualified+` WHERE namespace = $1`, namespace))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return NamespaceOwner{}, pkgdb.ErrNotFound
+	}
+	if err != nil {
+		return NamespaceOwner{}, fmt.Errorf("load namespace owner %s: %w", namespace, err)
+	}
+	return out, nil
+}
+
+// List returns every namespace owner, ordered by namespace.
+func (s *NamespaceOwnerStore) List(ctx context.Context) ([]NamespaceOwner, error) {
+	if s == nil || s.pool == nil {
+		return nil, errors.New("v1alpha1 store: namespace owner store has nil pool")
+	}
+	rows, err := s.pool.Query(ctx, `SELECT `+namespaceOwnerColumns+` FROM `+s.qualified+` ORDER BY namespace`)
+	if err != nil {
+		return nil, fmt.Errorf("list namespace owners: %w", err)
+	}
+	defer rows.Close()
+	var out []NamespaceOwner
+	for rows.Next() {
+		owner, err := scanNamespaceOwner(rows)
+		if err != nil {
+			return nil, fmt.Errorf("list namespace owners: %w", err)
+		}
+		out = append(out, owner)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list namespace owners: %w", err)
+	}
+	return out, nil
+}
+
+// Delete releases namespace. Returns pkgdb.ErrNotFound when it is
+// unclaimed.
+func (s *NamespaceOwnerStore) Delete(ctx context.Context, namespace string) error {
+	if s == nil || s.pool == nil {
+		return errors.New("v1alpha1 store: namespace owner store has nil pool")
+	}
+	tag, err := s.pool.Exec(ctx, `DELETE FROM `+s.qualified+` WHERE namespace = $1`, namespace)
+	if err != nil {
+		return fmt.Errorf("delete namespace owner %s: %w", namespace, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pkgdb.ErrNotFound
+	}
+	return nil
+}
+
+// OwnerKey returns the key artifacts in namespace must be signed with,
+// and false when namespace is unclaimed. It satisfies
+// signing.NamespaceOwners.
+func (s *NamespaceOwnerStore) OwnerKey(ctx context.Context, namespace string) (crypto.PublicKey, string, bool, error) {
+	owner, err := s.Get(ctx, namespace)
+	if errors.Is(err, pkgdb.ErrNotFound) {
+		return nil, "", false, nil
+	}
+	if err != nil {
+		return nil, "", false, err
+	}
+	return owner.PublicKey, owner.Domain, true, nil
+}
+
+func scanNamespaceOwner(row pgx.Row) (NamespaceOwner, error) {
+	var (
+		out    NamespaceOwner
+		method string
+		key    []byte
+	)
+	if err := row.Scan(&out.Namespace, &out.Domain, &method, &key, &out.VerifiedBy, &out.VerifiedAt, &out.CreatedAt); err != nil {
+		return NamespaceOwner{}, err
+	}
+	out.Method = auth.Method(method)
+	out.PublicKey = ed25519.PublicKey(key)
+	return out, nil
+}
@@ -0,0 +1,58 @@
+//go:build integration
+
+package v1alpha1store
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+func TestNamespaceOwnerStore_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	pool := NewTestPool(t)
+	owners := NewNamespaceOwnerStore(pool, TestSchema())
+
+	_, _, ok, err := owners.OwnerKey(ctx, "com.acme")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	first, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	created, err := owners.Put(ctx, NamespaceOwner{Namespace: "com.acme", Domain: "acme.com", Method: auth.MethodDNS, PublicKey: first})
+	require.NoError(t, err)
+	require.Equal(t, auth.MethodDNS, created.Method)
+	require.Equal(t, first, created.PublicKey)
+
+	// Proving again with another key replaces it.
+	second, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	updated, err := owners.Put(ctx, NamespaceOwner{Namespace: "com.acme", Domain: "acme.com", Method: auth.MethodHTTP, PublicKey: second, VerifiedBy: "alice"})
+	require.NoError(t, err)
+	require.Equal(t, created.CreatedAt, updated.CreatedAt)
+	require.Equal(t, "alice", updated.VerifiedBy)
+
+	key, domain, ok, err := owners.OwnerKey(ctx, "com.acme")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "acme.com", domain)
+	require.Equal(t, second, key)
+
+	_, err = owners.Put(ctx, NamespaceOwner{Namespace: "io.example", Domain: "example.io", Method: auth.MethodDNS, PublicKey: first})
+	require.NoError(t, err)
+	all, err := owners.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	require.Equal(t, "com.acme", all[0].Namespace)
+
+	require.NoError(t, owners.Delete(ctx, "com.acme"))
+	require.ErrorIs(t, owners.Delete(ctx, "com.acme"), pkgdb.ErrNotFound)
+	_, err = owners.Get(ctx, "com.acme")
+	require.ErrorIs(t, err, pkgdb.ErrNotFound)
+}
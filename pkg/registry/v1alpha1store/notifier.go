@@ -0,0 +1,129 @@
+package v1alpha1store
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	notificationBuffer     = 64
+	notifierReconnectDelay = time.Second
+)
+
+// Notification is one Postgres NOTIFY delivered by a ChangeNotifier.
+type Notification struct {
+	Channel string
+	Payload string
+}
+
+// ChangeNotifier shares one LISTEN connection among every in-process
+// subscriber, so long-lived watchers cost no pooled connection each. The
+// connection is opened on the first Subscribe and released when the last
+// subscriber leaves.
+//
+// Delivery is best effort: a subscriber that falls behind its buffer
+// misses notifications, and notifications sent while the connection is
+// re-established are lost. After every reconnect subscribers receive a
+// synthetic ControlPlaneNotifyChannel notification, so watchers replaying
+// control_plane_events catch up on what happened in between.
+type ChangeNotifier struct {
+	pool     *pgxpool.Pool
+	channels []string
+
+	mu     sync.Mutex
+	subs   map[chan Notification]struct{}
+	cancel context.CancelFunc
+}
+
+// NewChangeNotifier constructs a notifier listening on
+// ControlPlaneNotifyChannel plus channels, e.g. Store.StatusChannel values.
+func NewChangeNotifier(pool *pgxpool.Pool, channels ...string) *ChangeNotifier {
+	return &ChangeNotifier{
+		pool:     pool,
+		channels: append([]string{ControlPlaneNotifyChannel}, channels...),
+		subs:     map[chan Notification]struct{}{},
+	}
+}
+
+// Subscribe registers a subscriber. Call the returned func to unsubscribe;
+// the channel is never closed.
+func (n *ChangeNotifier) Subscribe() (<-chan Notification, func()) {
+	ch := make(chan Notification, notificationBuffer)
+	n.mu.Lock()
+	n.subs[ch] = struct{}{}
+	if n.cancel == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		n.cancel = cancel
+		go n.run(ctx)
+	}
+	n.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			n.mu.Lock()
+			defer n.mu.Unlock()
+			delete(n.subs, ch)
+			if len(n.subs) == 0 && n.cancel != nil {
+				n.cancel()
+				n.cancel = nil
+			}
+		})
+	}
+}
+
+func (n *ChangeNotifier) run(ctx context.Context) {
+	for {
+		_ = n.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		timer := time.NewTimer(notifierReconnectDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (n *ChangeNotifier) listen(ctx context.Context) error {
+	conn, err := n.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire LISTEN connection: %w", err)
+	}
+	defer conn.Release()
+	for _, channel := range n.channels {
+		if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+			return fmt.Errorf("listen on %s: %w", channel, err)
+		}
+	}
+	n.broadcast(Notification{Channel: ControlPlaneNotifyChannel})
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			// The session still holds the LISTENs; drop it rather than
+			// hand it back to the pool.
+			_ = conn.Conn().Close(context.Background())
+			return fmt.Errorf("wait for notification: %w", err)
+		}
+		n.broadcast(Notification{Channel: notification.Channel, Payload: notification.Payload})
+	}
+}
+
+func (n *ChangeNotifier) broadcast(notification Notification) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for ch := range n.subs {
+		select {
+		case ch <- notification:
+		default:
+		}
+	}
+}
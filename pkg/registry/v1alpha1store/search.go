@@ -0,0 +1,161 @@
+package v1alpha1store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// DefaultSearchLimit is the number of hits Search returns when
+	// SearchOpts.Limit is zero.
+	DefaultSearchLimit = 20
+	// MaxSearchLimit caps SearchOpts.Limit.
+	MaxSearchLimit = 100
+)
+
+// SearchScope is one Store taking part in a cross-kind Search, with an
+// optional caller-supplied predicate narrowing its rows. ExtraWhere and
+// ExtraArgs follow the ListOpts.ExtraWhere rules: placeholders numbered
+// from $1, rebased by Search, count equal to len(ExtraArgs).
+type SearchScope struct {
+	Store      *Store
+	ExtraWhere string
+	ExtraArgs  []any
+}
+
+// SearchOpts controls Search.
+type SearchOpts struct {
+	// Query is free text in websearch syntax: bare words are ANDed,
+	// "quoted phrases" match in order, `or` separates alternatives and
+	// a leading `-` excludes a word. Required.
+	Query string
+	// Namespace narrows hits to one namespace. Empty searches every
+	// namespace.
+	Namespace string
+	// Limit caps the number of hits. Zero means DefaultSearchLimit;
+	// values above MaxSearchLimit are clamped.
+	Limit int
+}
+
+// SearchHit is one ranked Search result. Tagged kinds return the
+// best-matching tag of each name, preferring "latest" on ties; Tag is
+// empty for mutable kinds. Highlight is a short excerpt with the matched
+// terms wrapped in ** markers; it is empty when only the name matched.
+type SearchHit struct {
+	Kind        string
+	Namespace   string
+	Name        string
+	Tag         string
+	Title       string
+	Description string
+	Rank        float32
+	Highlight   string
+}
+
+// Searchable reports whether the Store's table carries a search index
+// (see WithSearchIndex) and its kind is known, the two preconditions for
+// taking part in Search.
+func (s *Store) Searchable() bool {
+	return s != nil && s.searchExtras != "" && s.kind != ""
+}
+
+// Search runs one full-text query across every scope and returns the
+// hits ranked by relevance, best first. Matching uses each table's
+// generated search_vector: name and title weigh most, then description,
+// then labels, MCP tool metadata and plugin inventory. Terminating rows
+// are never returned.
+func Search(ctx context.Context, scopes []SearchScope, opts SearchOpts) ([]SearchHit, error) {
+	if strings.TrimSpace(opts.Query) == "" {
+		return nil, errors.New("v1alpha1 store: search query is required")
+	}
+	limit := opts.Limit
+	switch {
+	case limit <= 0:
+		limit = DefaultSearchLimit
+	case limit > MaxSearchLimit:
+		limit = MaxSearchLimit
+	}
+	if len(scopes) == 0 {
+		return nil, nil
+	}
+
+	args := []any{opts.Query}
+	selects := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		s := scope.Store
+		if !s.Searchable() {
+			return nil, fmt.Errorf("v1alpha1 store: table %q is not searchable", s.table)
+		}
+		args = append(args, s.kind)
+		kindArg := len(args)
+		where := []string{"search_vector @@ q.query", "deletion_timestamp IS NULL"}
+		if opts.Namespace != "" {
+			args = append(args, opts.Namespace)
+			where = append(where, fmt.Sprintf("namespace = $%d", len(args)))
+		}
+		if scope.ExtraWhere != "" || len(scope.ExtraArgs) > 0 {
+			placeholders := countDistinctPlaceholders(scope.ExtraWhere)
+			if placeholders != len(scope.ExtraArgs) {
+				return nil, fmt.Errorf("%w: fragment references %d distinct placeholder(s) but %d arg(s) supplied",
+					ErrInvalidExtraWhere, placeholders, len(scope.ExtraArgs))
+			}
+			args = append(args, scope.ExtraArgs...)
+			if scope.ExtraWhere != "" {
+				where = append(where, "("+rebaseSQLPlaceholders(scope.ExtraWhere, len(args)-len(scope.ExtraArgs))+")")
+			}
+		}
+		tag, order := "tag", "(tag = 'latest') DESC, updated_at DESC"
+		if s.behavior != TaggedArtifactStore {
+			tag, order = "''::text", "updated_at DESC"
+		}
+		selects = append(selects, fmt.Sprintf(`
+			(SELECT DISTINCT ON (namespace, name)
+			        $%d::text AS kind, namespace, name, %s AS tag,
+			        COALESCE(spec ->> 'title', '') AS title,
+			        COALESCE(spec ->> 'description', '') AS description,
+			        %s(labels, status) AS extras,
+			        ts_rank_cd(search_vector, q.query) AS rank
+			 FROM %s, q
+			 WHERE %s
+			 ORDER BY namespace, name, rank DESC, %s)`,
+			kindArg, tag, s.searchExtras, s.qualified, strings.Join(where, " AND "), order))
+	}
+	args = append(args, limit)
+	query := fmt.Sprintf(`
+		WITH q AS (SELECT websearch_to_tsquery('english', $1) AS query),
+		hits AS (%s
+		)
+		SELECT top.kind, top.namespace, top.name, top.tag, top.title, top.description, top.rank,
+		       ts_headline('english', concat_ws(' ', top.title, top.description, top.extras), q.query,
+		                   'StartSel=**, StopSel=**, MaxFragments=2, MinWords=5, MaxWords=20, FragmentDelimiter=" ... "')
+		FROM (SELECT * FROM hits ORDER BY rank DESC, kind, namespace, name LIMIT $%d) AS top, q
+		ORDER BY top.rank DESC, top.kind, top.namespace, top.name`,
+		strings.Join(selects, "\n\t\t\tUNION ALL"), len(args))
+
+	rows, err := scopes[0].Store.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]SearchHit, 0, limit)
+	for rows.Next() {
+		var hit SearchHit
+		if err := rows.Scan(&hit.Kind, &hit.Namespace, &hit.Name, &hit.Tag, &hit.Title, &hit.Description, &hit.Rank, &hit.Highlight); err != nil {
+			return nil, fmt.Errorf("search: scan: %w", err)
+		}
+		// ts_headline falls back to the leading words of the document
+		// when no term matched it (a name-only hit); that is not a
+		// highlight.
+		if !strings.Contains(hit.Highlight, "**") {
+			hit.Highlight = ""
+		}
+		out = append(out, hit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,105 @@
+//go:build integration
+
+package v1alpha1store
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+)
+
+func TestSearch_RanksAcrossKinds(t *testing.T) {
+	ctx := context.Background()
+	stores := NewStores(NewTestPool(t), TestSchemaRegistry())
+	require.False(t, stores[v1alpha1.KindSecret].Searchable())
+
+	_, err := stores[v1alpha1.KindMCPServer].Upsert(ctx, &v1alpha1.MCPServer{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "tickets", Tag: "1.0.0"},
+		Spec:     v1alpha1.MCPServerSpec{Title: "Ticket tools", Description: "Issue tracker access"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, stores[v1alpha1.KindMCPServer].PatchStatus(ctx, "default", "tickets", "1.0.0", func(json.RawMessage) (json.RawMessage, error) {
+		return json.Marshal(v1alpha1.MCPServerStatus{Capabilities: &v1alpha1.MCPServerCapabilities{
+			Tools: []v1alpha1.MCPToolInfo{{Name: "create_jira_issue", Description: "Create a Jira issue"}},
+		}})
+	}))
+	_, err = stores[v1alpha1.KindAgent].Upsert(ctx, &v1alpha1.Agent{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "team-a", Name: "jira-triage"},
+		Spec:     v1alpha1.AgentSpec{Description: "Triages Jira tickets"},
+	})
+	require.NoError(t, err)
+	_, err = stores[v1alpha1.KindSkill].Upsert(ctx, &v1alpha1.Skill{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "pdf", Labels: map[string]string{"vendor": "jira"}},
+		Spec:     v1alpha1.SkillSpec{Description: "Read PDF files"},
+	})
+	require.NoError(t, err)
+	_, err = stores[v1alpha1.KindSkill].Upsert(ctx, &v1alpha1.Skill{
+		Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "unrelated"},
+		Spec:     v1alpha1.SkillSpec{Description: "Nothing to see"},
+	})
+	require.NoError(t, err)
+
+	scopes := []SearchScope{{Store: stores[v1alpha1.KindAgent]}, {Store: stores[v1alpha1.KindMCPServer]}, {Store: stores[v1alpha1.KindSkill]}}
+	hits, err := Search(ctx, scopes, SearchOpts{Query: "jira"})
+	require.NoError(t, err)
+	require.Len(t, hits, 3)
+	// A name match outranks tool metadata and labels.
+	require.Equal(t, "jira-triage", hits[0].Name)
+	require.Equal(t, v1alpha1.KindAgent, hits[0].Kind)
+	require.Equal(t, "latest", hits[0].Tag)
+	byName := map[string]SearchHit{}
+	for _, h := range hits {
+		byName[h.Name] = h
+	}
+	require.Equal(t, "1.0.0", byName["tickets"].Tag)
+	require.Equal(t, "Ticket tools", byName["tickets"].Title)
+	require.Contains(t, strings.ToLower(byName["tickets"].Highlight), "**jira**")
+	require.Contains(t, byName["pdf"].Highlight, "**jira**")
+
+	hits, err = Search(ctx, scopes, SearchOpts{Query: "jira", Namespace: "team-a"})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+
+	hits, err = Search(ctx, []SearchScope{{Store: stores[v1alpha1.KindSkill], ExtraWhere: "name <> $1", ExtraArgs: []any{"pdf"}}}, SearchOpts{Query: "jira"})
+	require.NoError(t, err)
+	require.Empty(t, hits)
+
+	hits, err = Search(ctx, scopes, SearchOpts{Query: "jira", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+
+	_, err = Search(ctx, scopes, SearchOpts{Query: " "})
+	require.Error(t, err)
+	_, err = Search(ctx, []SearchScope{{Store: stores[v1alpha1.KindSecret]}}, SearchOpts{Query: "jira"})
+	require.Error(t, err)
+}
+
+func TestSearch_OneHitPerName(t *testing.T) {
+	ctx := context.Background()
+	stores := NewStores(NewTestPool(t), TestSchemaRegistry())
+	store := stores[v1alpha1.KindSkill]
+
+	for tag, desc := range map[string]string{"1.0.0": "Files", "2.0.0": "Jira export files", "latest": "Files"} {
+		_, err := store.Upsert(ctx, &v1alpha1.Skill{
+			Metadata: v1alpha1.ObjectMeta{Namespace: "default", Name: "export", Tag: tag},
+			Spec:     v1alpha1.SkillSpec{Description: desc},
+		})
+		require.NoError(t, err)
+	}
+
+	hits, err := Search(ctx, []SearchScope{{Store: store}}, SearchOpts{Query: "jira"})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	require.Equal(t, "2.0.0", hits[0].Tag)
+
+	// Equal ranks prefer the latest tag.
+	hits, err = Search(ctx, []SearchScope{{Store: store}}, SearchOpts{Query: "files"})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	require.Equal(t, "latest", hits[0].Tag)
+}
@@ -0,0 +1,99 @@
+package v1alpha1store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// SkillArchive is one uploaded skill tarball. Content is only populated by
+// Get; Put and Stat leave it nil.
+type SkillArchive struct {
+	Digest    string
+	Size      int64
+	CreatedAt time.Time
+	Content   []byte
+}
+
+// SkillArchiveStore persists content-addressed skill tarballs referenced by
+// Skill spec.source.archive. Rows are never updated: the digest is derived
+// from the bytes, so a re-upload of identical content resolves to the
+// existing row.
+type SkillArchiveStore struct {
+	pool      *pgxpool.Pool
+	qualified string
+}
+
+// NewSkillArchiveStore constructs a skill archive store.
+func NewSkillArchiveStore(pool *pgxpool.Pool, schema pkgdb.Schema) *SkillArchiveStore {
+	return &SkillArchiveStore{
+		pool:      pool,
+		qualified: schema.Qualify("skill_archives"),
+	}
+}
+
+// SkillArchiveDigest returns the "sha256:<hex>" digest content is stored
+// under.
+func SkillArchiveDigest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Put stores content and returns its metadata. Storing bytes that are
+// already present is not an error.
+func (s *SkillArchiveStore) Put(ctx context.Context, content []byte) (SkillArchive, error) {
+	if s == nil || s.pool == nil {
+		return SkillArchive{}, errors.New("v1alpha1 store: skill archive store has nil pool")
+	}
+	digest := SkillArchiveDigest(content)
+	if _, err := s.pool.Exec(ctx, `
+		INSERT INTO `+s.qualified+` (digest, size, content)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (digest) DO NOTHING`, digest, len(content), content); err != nil {
+		return SkillArchive{}, fmt.Errorf("store skill archive: %w", err)
+	}
+	return s.Stat(ctx, digest)
+}
+
+// Stat returns an archive's metadata without its content, or
+// pkgdb.ErrNotFound.
+func (s *SkillArchiveStore) Stat(ctx context.Context, digest string) (SkillArchive, error) {
+	if s == nil || s.pool == nil {
+		return SkillArchive{}, errors.New("v1alpha1 store: skill archive store has nil pool")
+	}
+	out := SkillArchive{Digest: digest}
+	err := s.pool.QueryRow(ctx, `SELECT size, created_at FROM `+s.qualified+` WHERE digest = $1`, digest).
+		Scan(&out.Size, &out.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return SkillArchive{}, pkgdb.ErrNotFound
+	}
+	if err != nil {
+		return SkillArchive{}, fmt.Errorf("load skill archive %s: %w", digest, err)
+	}
+	return out, nil
+}
+
+// Get returns an archive including its content, or pkgdb.ErrNotFound.
+func (s *SkillArchiveStore) Get(ctx context.Context, digest string) (SkillArchive, error) {
+	if s == nil || s.pool == nil {
+		return SkillArchive{}, errors.New("v1alpha1 store: skill archive store has nil pool")
+	}
+	out := SkillArchive{Digest: digest}
+	err := s.pool.QueryRow(ctx, `SELECT size, created_at, content FROM `+s.qualified+` WHERE digest = $1`, digest).
+		Scan(&out.Size, &out.CreatedAt, &out.Content)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return SkillArchive{}, pkgdb.ErrNotFound
+	}
+	if err != nil {
+		return SkillArchive{}, fmt.Errorf("load skill archive %s: %w", digest, err)
+	}
+	return out, nil
+}
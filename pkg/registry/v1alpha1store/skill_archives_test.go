@@ -0,0 +1,36 @@
+//go:build integration
+
+package v1alpha1store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+func TestSkillArchiveStore_PutGet(t *testing.T) {
+	ctx := context.Background()
+	pool := NewTestPool(t)
+	archives := NewSkillArchiveStore(pool, TestSchema())
+
+	content := []byte("not really a tarball")
+	put, err := archives.Put(ctx, content)
+	require.NoError(t, err)
+	require.Equal(t, SkillArchiveDigest(content), put.Digest)
+	require.Equal(t, int64(len(content)), put.Size)
+	require.Nil(t, put.Content)
+
+	again, err := archives.Put(ctx, content)
+	require.NoError(t, err, "re-uploading identical bytes is a no-op")
+	require.Equal(t, put.CreatedAt, again.CreatedAt)
+
+	got, err := archives.Get(ctx, put.Digest)
+	require.NoError(t, err)
+	require.Equal(t, content, got.Content)
+
+	_, err = archives.Stat(ctx, SkillArchiveDigest([]byte("missing")))
+	require.ErrorIs(t, err, pkgdb.ErrNotFound)
+}
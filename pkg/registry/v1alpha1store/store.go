@@ -45,7 +45,8 @@ const (
 //     Storage key is (namespace, name, tag). Users may supply the tag
 //     declaratively; missing tags are filled with the literal "latest".
 //     Re-applying the same tag replaces the prior row atomically when the
-//     content changes. Used for agents, mcp_servers, skills, and prompts.
+//     content changes; WithTagHistory keeps the replaced content so it can
+//     be listed and rolled back to. Used for agents, mcp_servers, skills, and prompts.
 //
 //   - MutableObjectStore (produced by NewMutableObjectStore). Storage key is
 //     (namespace, name). Used for Runtime/Deployment and additional
@@ -71,6 +72,21 @@ type Store struct {
 	// Store is correct even on a connection whose search_path points at a
 	// different schema (e.g. an extension's).
 	qualified string
+	// aliases is the qualified tag_aliases table reference, or "" when
+	// the Store has no alias support (see WithTagAliases).
+	aliases string
+	// revisions is the qualified tag_revisions table reference, or ""
+	// when replaced content is not kept (see WithTagHistory).
+	revisions string
+	// lifecycle reports whether the table carries the lifecycle column
+	// (see WithTagLifecycle).
+	lifecycle bool
+	// searchExtras is the qualified search_extras function reference, or
+	// "" when the table has no search_vector column (see WithSearchIndex).
+	searchExtras string
+	// immutable protects matching tags from content replacement; nil
+	// leaves every tag replaceable.
+	immutable *ImmutableTagPolicy
 	behavior  StoreBehavior
 	kind      string
 	auditor   types.Auditor
@@ -86,6 +102,12 @@ func (s *Store) Behavior() StoreBehavior {
 	return s.behavior
 }
 
+// StatusChannel names the NOTIFY channel the table's notify_status_change
+// trigger publishes status changes on (e.g. "agents_status").
+func (s *Store) StatusChannel() string {
+	return s.table + "_status"
+}
+
 // StoreOption configures an optional Store behaviour at construction
 // time. Options compose; later options override earlier ones for the
 // same field.
@@ -113,6 +135,45 @@ func WithKind(kind string) StoreOption {
 	return func(s *Store) { s.kind = kind }
 }
 
+// WithTagAliases enables movable tag aliases (see PromoteTag) backed by
+// the tag_aliases table in schema. Aliases are keyed by the Store's kind,
+// so the option only takes effect together with WithKind. NewStores sets
+// it for every tagged built-in kind.
+func WithTagAliases(schema pkgdb.Schema) StoreOption {
+	return func(s *Store) { s.aliases = schema.Qualify("tag_aliases") }
+}
+
+// WithTagHistory keeps the content every in-place tag replacement
+// overwrites in the tag_revisions table in schema, enabling
+// ListTagRevisions and GetTagRevision. Like WithTagAliases, revisions are
+// keyed by the Store's kind. NewStores sets it for every tagged built-in
+// kind.
+func WithTagHistory(schema pkgdb.Schema) StoreOption {
+	return func(s *Store) { s.revisions = schema.Qualify("tag_revisions") }
+}
+
+// WithTagLifecycle marks the Store's table as carrying the lifecycle
+// column (migration 021_tag_lifecycle), enabling SetTagLifecycle.
+// NewStores sets it for every tagged built-in kind.
+func WithTagLifecycle() StoreOption {
+	return func(s *Store) { s.lifecycle = true }
+}
+
+// WithSearchIndex marks the Store's table as carrying the generated
+// search_vector column (migration 017_search_index), making it eligible
+// for Search. The helper functions live in schema. NewStores sets it for
+// every built-in kind except Secret.
+func WithSearchIndex(schema pkgdb.Schema) StoreOption {
+	return func(s *Store) { s.searchExtras = schema.Qualify("search_extras") }
+}
+
+// WithImmutableTags rejects re-applies that would change the content of a
+// tag the policy protects, and moves of a protected alias, with
+// ErrImmutableTag. Rules match on the Store's kind (see WithKind).
+func WithImmutableTags(policy *ImmutableTagPolicy) StoreOption {
+	return func(s *Store) { s.immutable = policy }
+}
+
 // NewStore constructs a tagged-artifact Store bound to a single table
 // (e.g. "agents") in schema. The table must exist; NewStore does not
 // validate it. Queries qualify the table with schema explicitly, so the
@@ -373,6 +434,17 @@ func (s *Store) upsertTagged(ctx context.Context, meta *v1alpha1.ObjectMeta, spe
 		}
 
 		if !found {
+			// A concrete tag cannot shadow an alias of the same name:
+			// references to it would silently switch from the alias
+			// target to the new row.
+			isAlias, err := s.aliasExists(ctx, tx, meta.Namespace, meta.Name, meta.Tag)
+			if err != nil {
+				return err
+			}
+			if isAlias {
+				return fmt.Errorf("%w: %s/%s tag %q is an alias; move it with promote or delete the alias first",
+					ErrTagConflict, meta.Namespace, meta.Name, meta.Tag)
+			}
 			var uid string
 			if err := tx.QueryRow(ctx,
 				fmt.Sprintf(`
@@ -390,17 +462,15 @@ func (s *Store) upsertTagged(ctx context.Context, meta *v1alpha1.ObjectMeta, spe
 			result = UpsertResult{Tag: meta.Tag, UID: existingUID, Generation: existingGeneration, Outcome: UpsertNoOp}
 			return nil
 		}
+		if s.immutable.Immutable(s.kind, meta.Namespace, meta.Tag) {
+			return fmt.Errorf("%w: %s/%s tag %q was already applied with different content; apply a new tag instead",
+				ErrImmutableTag, meta.Namespace, meta.Name, meta.Tag)
+		}
 
 		nextGeneration := existingGeneration + 1
-		var uid string
-		if err := tx.QueryRow(ctx,
-			fmt.Sprintf(`
-						UPDATE %s
-						SET labels=$4, annotations=$5, spec=$6, content_hash=$7, generation=$8, status='{}'::jsonb, deletion_timestamp=NULL
-						WHERE namespace=$1 AND name=$2 AND tag=$3
-						RETURNING uid::text`, s.qualified),
-			meta.Namespace, meta.Name, meta.Tag, incomingLabelsJSON, incomingAnnotationsJSON, []byte(specJSON), incomingHash, nextGeneration).Scan(&uid); err != nil {
-			return fmt.Errorf("replace tag: %w", err)
+		uid, err := s.replaceTag(ctx, tx, meta.Namespace, meta.Name, meta.Tag, incomingLabelsJSON, incomingAnnotationsJSON, specJSON, incomingHash, nextGeneration)
+		if err != nil {
+			return err
 		}
 		result = UpsertResult{Tag: meta.Tag, UID: uid, Generation: nextGeneration, Outcome: UpsertReplaced}
 		return nil
@@ -411,6 +481,27 @@ func (s *Store) upsertTagged(ctx context.Context, meta *v1alpha1.ObjectMeta, spe
 	return result, nil
 }
 
+// replaceTag overwrites the content of an existing tag row in place,
+// first copying the outgoing content into tag_revisions when the Store
+// keeps history. The caller (upsertTagged) holds the (namespace, name)
+// advisory lock.
+func (s *Store) replaceTag(ctx context.Context, tx pgx.Tx, namespace, name, tag string, labelsJSON, annotationsJSON []byte, specJSON json.RawMessage, hash string, generation int64) (string, error) {
+	if err := s.recordTagRevision(ctx, tx, namespace, name, tag); err != nil {
+		return "", err
+	}
+	var uid string
+	if err := tx.QueryRow(ctx,
+		fmt.Sprintf(`
+			UPDATE %s
+			SET labels=$4, annotations=$5, spec=$6, content_hash=$7, generation=$8, status='{}'::jsonb, deletion_timestamp=NULL
+			WHERE namespace=$1 AND name=$2 AND tag=$3
+			RETURNING uid::text`, s.qualified),
+		namespace, name, tag, labelsJSON, annotationsJSON, []byte(specJSON), hash, generation).Scan(&uid); err != nil {
+		return "", fmt.Errorf("replace tag: %w", err)
+	}
+	return uid, nil
+}
+
 // upsertMutable implements in-place semantics for mutable-object tables.
 func (s *Store) upsertMutable(ctx context.Context, meta *v1alpha1.ObjectMeta, specJSON json.RawMessage, opts UpsertOpts) (UpsertResult, error) {
 	labelsJSON, err := canonicalJSONMap(meta.Labels)
@@ -568,6 +659,11 @@ func (s *Store) ApplyPatch(ctx context.Context, namespace, name, tag string, pat
 			if err != nil {
 				return err
 			}
+			if s.lifecycle {
+				if newJSON, err = withoutLifecycle(newJSON); err != nil {
+					return err
+				}
+			}
 			if !equalSpecJSON(statusJSON, newJSON) {
 				args = append(args, newJSON)
 				setClauses = append(setClauses, fmt.Sprintf("status=$%d", len(args)))
@@ -740,7 +836,10 @@ func (s *Store) Get(ctx context.Context, namespace, name, tag string) (*v1alpha1
 // GetByRef resolves the public reference shape shared by v1alpha1 resources.
 // Blank tag means the current live row: literal "latest" for tagged artifacts,
 // namespace/name for mutable objects. Non-empty tag selects a tagged artifact
-// row and is invalid for mutable-object stores.
+// row and is invalid for mutable-object stores; a semver constraint tag
+// ("^1.2") selects the highest live tag that satisfies it (see ResolveTag),
+// and a tag alias ("stable") selects the concrete tag it points at (see
+// PromoteTag). The returned row carries the concrete tag it resolved to.
 func (s *Store) GetByRef(ctx context.Context, namespace, name, tag string) (*v1alpha1.RawObject, error) {
 	if tag == "" {
 		return s.GetLatest(ctx, namespace, name)
@@ -748,7 +847,56 @@ func (s *Store) GetByRef(ctx context.Context, namespace, name, tag string) (*v1a
 	if s.behavior == MutableObjectStore {
 		return nil, errors.New("v1alpha1 store: tag pinning is not supported on mutable-object stores")
 	}
-	return s.Get(ctx, namespace, name, tag)
+	if v1alpha1.IsTagConstraint(tag) {
+		resolved, err := s.ResolveTag(ctx, namespace, name, tag)
+		if err != nil {
+			return nil, err
+		}
+		tag = resolved
+	}
+	row, err := s.Get(ctx, namespace, name, tag)
+	if !errors.Is(err, pkgdb.ErrNotFound) || s.aliasesEnabled() != nil {
+		return row, err
+	}
+	alias, aliasErr := s.GetTagAlias(ctx, namespace, name, tag)
+	if aliasErr != nil {
+		if errors.Is(aliasErr, pkgdb.ErrNotFound) {
+			return nil, err
+		}
+		return nil, aliasErr
+	}
+	return s.Get(ctx, namespace, name, alias.Target)
+}
+
+// ResolveTag resolves a semver constraint against the live tags of
+// (namespace, name) and returns the highest matching tag. Yanked tags are
+// never selected by a constraint. Returns pkgdb.ErrNotFound when no live
+// tag satisfies it, so an unsatisfiable reference reads as dangling, and
+// pkgdb.ErrInvalidInput when constraint does not parse.
+func (s *Store) ResolveTag(ctx context.Context, namespace, name, constraint string) (string, error) {
+	rows, err := s.ListTags(ctx, namespace, name)
+	if err != nil {
+		return "", err
+	}
+	tags := make([]string, 0, len(rows))
+	for _, row := range rows {
+		lifecycle, err := v1alpha1.LifecycleFromStorage(row.Status)
+		if err != nil {
+			return "", fmt.Errorf("decode %s/%s:%s lifecycle: %w", namespace, name, row.Metadata.Tag, err)
+		}
+		if lifecycle != nil && lifecycle.State == v1alpha1.LifecycleYanked {
+			continue
+		}
+		tags = append(tags, row.Metadata.Tag)
+	}
+	tag, err := v1alpha1.MatchTagConstraint(constraint, tags)
+	switch {
+	case errors.Is(err, v1alpha1.ErrNoMatchingTag):
+		return "", fmt.Errorf("%w: %s/%s: %v", pkgdb.ErrNotFound, namespace, name, err)
+	case err != nil:
+		return "", fmt.Errorf("%w: %v", pkgdb.ErrInvalidInput, err)
+	}
+	return tag, nil
 }
 
 // GetLatest returns the literal "latest" live tag for (namespace, name) on
@@ -807,8 +955,7 @@ func (s *Store) Delete(ctx context.Context, namespace, name, tag string) error {
 		if tag == "" {
 			return errors.New("v1alpha1 store: tag is required")
 		}
-		args := []any{namespace, name, tag}
-		return s.deleteTagged(ctx, args)
+		return s.deleteTagged(ctx, namespace, name, tag)
 	}
 	return s.deleteMutable(ctx, namespace, name)
 }
@@ -871,8 +1018,8 @@ func (s *Store) ListTags(ctx context.Context, namespace, name string) ([]*v1alph
 	return out, nil
 }
 
-// DeleteAllTags hard-deletes every tag row for (namespace, name)
-// on a tagged-artifact table. This is the contract of the
+// DeleteAllTags hard-deletes every tag row, tag alias and tag revision for
+// (namespace, name) on a tagged-artifact table. This is the contract of the
 // batch DELETE endpoint when metadata.tag is omitted; callers delete a
 // single tag by including metadata.tag. Returns pkgdb.ErrNotFound
 // when no row exists for (namespace, name).
@@ -887,21 +1034,31 @@ func (s *Store) DeleteAllTags(ctx context.Context, namespace, name string) error
 	if namespace == "" || name == "" {
 		return errors.New("v1alpha1 store: namespace and name are required")
 	}
-	cmdTag, err := s.pool.Exec(ctx,
-		fmt.Sprintf(`
-			DELETE FROM %s
-			WHERE namespace=$1 AND name=$2`, s.qualified),
-		namespace, name)
-	if err != nil {
-		return fmt.Errorf("delete all tags: %w", err)
-	}
-	if cmdTag.RowsAffected() == 0 {
-		return pkgdb.ErrNotFound
-	}
-	return nil
+	return runInTx(ctx, s.pool, func(tx pgx.Tx) error {
+		cmdTag, err := tx.Exec(ctx,
+			fmt.Sprintf(`
+				DELETE FROM %s
+				WHERE namespace=$1 AND name=$2`, s.qualified),
+			namespace, name)
+		if err != nil {
+			return fmt.Errorf("delete all tags: %w", err)
+		}
+		if cmdTag.RowsAffected() == 0 {
+			return pkgdb.ErrNotFound
+		}
+		if s.aliasesEnabled() == nil {
+			if _, err := tx.Exec(ctx, `
+				DELETE FROM `+s.aliases+`
+				WHERE kind=$1 AND namespace=$2 AND name=$3`, s.kind, namespace, name); err != nil {
+				return fmt.Errorf("delete tag aliases: %w", err)
+			}
+		}
+		return s.deleteTagRevisions(ctx, tx, namespace, name, "")
+	})
 }
 
-func (s *Store) deleteTagged(ctx context.Context, args []any) error {
+func (s *Store) deleteTagged(ctx context.Context, namespace, name, tag string) error {
+	args := []any{namespace, name, tag}
 	return runInTx(ctx, s.pool, func(tx pgx.Tx) error {
 		var deletionTS pgtype.Timestamptz
 		err := tx.QueryRow(ctx,
@@ -927,7 +1084,7 @@ func (s *Store) deleteTagged(ctx context.Context, args []any) error {
 			args...); err != nil {
 			return fmt.Errorf("hard delete: %w", err)
 		}
-		return nil
+		return s.deleteTagRevisions(ctx, tx, namespace, name, tag)
 	})
 }
 
@@ -1270,7 +1427,7 @@ func (s *Store) FindReferrers(ctx context.Context, pathJSON json.RawMessage, opt
 // column layout stays uniform.
 func (s *Store) selectColumns() string {
 	if s.behavior == TaggedArtifactStore {
-		return `namespace, name, tag, uid::text, generation, labels, annotations, spec, status,
+		return `namespace, name, tag, uid::text, generation, labels, annotations, spec, ` + s.statusColumn() + `,
 		       deletion_timestamp, '[]'::jsonb AS finalizers, created_at, updated_at`
 	}
 	return `namespace, name, ''::text AS tag, uid::text, generation, labels, annotations, spec, status,
@@ -4,6 +4,7 @@ package v1alpha1store_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"sync"
@@ -206,6 +207,37 @@ func TestUpsert_IdempotentAcrossRestarts(t *testing.T) {
 	require.Equal(t, v1alpha1store.UpsertNoOp, res2.Outcome)
 }
 
+func TestUpsert_ImmutableTagRejectsChangedContent(t *testing.T) {
+	policy, err := v1alpha1store.ParseImmutableTagPolicy("semver")
+	require.NoError(t, err)
+	store := v1alpha1store.NewStore(v1alpha1store.NewTestPool(t), v1alpha1store.TestSchema(), "agents",
+		v1alpha1store.WithKind(v1alpha1.KindAgent), v1alpha1store.WithImmutableTags(policy))
+	ctx := context.Background()
+
+	_, err = store.Upsert(ctx, taggedAgentObj("foo", "1.0.0", "model-a", nil))
+	require.NoError(t, err)
+
+	res, err := store.Upsert(ctx, taggedAgentObj("foo", "1.0.0", "model-a", nil))
+	require.NoError(t, err, "re-applying identical content stays a no-op")
+	require.Equal(t, v1alpha1store.UpsertNoOp, res.Outcome)
+
+	_, err = store.Upsert(ctx, taggedAgentObj("foo", "1.0.0", "model-b", nil))
+	require.ErrorIs(t, err, v1alpha1store.ErrImmutableTag)
+	require.ErrorIs(t, err, v1alpha1store.ErrTagConflict)
+
+	got, err := store.GetByRef(ctx, "default", "foo", "1.0.0")
+	require.NoError(t, err)
+	var spec v1alpha1.AgentSpec
+	require.NoError(t, json.Unmarshal(got.Spec, &spec))
+	require.Equal(t, "model-a", spec.Title, "rejected apply must not touch the stored row")
+
+	_, err = store.Upsert(ctx, agentObj("foo", "model-a", nil))
+	require.NoError(t, err)
+	res, err = store.Upsert(ctx, agentObj("foo", "model-b", nil))
+	require.NoError(t, err, "latest stays mutable under a semver policy")
+	require.Equal(t, v1alpha1store.UpsertReplaced, res.Outcome)
+}
+
 func setupAgentStoreWithAuditor(t *testing.T, a types.Auditor) *v1alpha1store.Store {
 	t.Helper()
 	pool := v1alpha1store.NewTestPool(t)
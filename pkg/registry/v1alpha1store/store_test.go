@@ -122,6 +122,45 @@ func TestStore_GetByRefResolvesBlankTagToLatest(t *testing.T) {
 	require.Equal(t, "stable", stable.Metadata.Tag)
 }
 
+func TestStore_GetByRefResolvesTagConstraint(t *testing.T) {
+	pool := NewTestPool(t)
+	store := NewStore(pool, TestSchema(), testTable)
+	ctx := context.Background()
+
+	for _, tag := range []string{"1.2.0", "1.4.1", "2.0.0", "stable"} {
+		_, err := store.Upsert(ctx, &v1alpha1.Agent{
+			Metadata: v1alpha1.ObjectMeta{Namespace: testNS, Name: "foo", Tag: tag},
+			Spec:     v1alpha1.AgentSpec{Title: tag},
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := store.GetByRef(ctx, testNS, "foo", "^1.2")
+	require.NoError(t, err)
+	require.Equal(t, "1.4.1", got.Metadata.Tag)
+
+	got, err = store.GetByRef(ctx, testNS, "foo", ">=2 <3")
+	require.NoError(t, err)
+	require.Equal(t, "2.0.0", got.Metadata.Tag)
+
+	_, err = store.GetByRef(ctx, testNS, "foo", "^3")
+	require.ErrorIs(t, err, pkgdb.ErrNotFound)
+
+	_, err = store.ResolveTag(ctx, testNS, "foo", ">=banana")
+	require.ErrorIs(t, err, pkgdb.ErrInvalidInput)
+
+	// A yanked tag is skipped by ranges but stays reachable when pinned.
+	require.NoError(t, store.PatchStatus(ctx, testNS, "foo", "1.4.1", v1alpha1.StatusPatcher(func(s *v1alpha1.Status) {
+		s.Lifecycle = &v1alpha1.TagLifecycle{State: v1alpha1.LifecycleYanked}
+	})))
+	got, err = store.GetByRef(ctx, testNS, "foo", "^1.2")
+	require.NoError(t, err)
+	require.Equal(t, "1.2.0", got.Metadata.Tag)
+	got, err = store.GetByRef(ctx, testNS, "foo", "1.4.1")
+	require.NoError(t, err)
+	require.Equal(t, "1.4.1", got.Metadata.Tag)
+}
+
 func TestStore_GetByRefMutableRejectsTag(t *testing.T) {
 	pool := NewTestPool(t)
 	runtimes := NewMutableObjectStore(pool, TestSchema(), "runtimes")
@@ -13,14 +13,27 @@ import (
 // come from v1alpha1.KindDescriptor so the registration record remains the
 // single source of per-kind metadata.
 var builtInKinds = map[string]struct{}{
-	v1alpha1.KindAgent:      {},
-	v1alpha1.KindMCPServer:  {},
-	v1alpha1.KindSkill:      {},
-	v1alpha1.KindPlugin:     {},
-	v1alpha1.KindPrompt:     {},
-	v1alpha1.KindRuntime:    {},
-	v1alpha1.KindModel:      {},
-	v1alpha1.KindDeployment: {},
+	v1alpha1.KindAgent:       {},
+	v1alpha1.KindMCPServer:   {},
+	v1alpha1.KindSkill:       {},
+	v1alpha1.KindPlugin:      {},
+	v1alpha1.KindPrompt:      {},
+	v1alpha1.KindRuntime:     {},
+	v1alpha1.KindModel:       {},
+	v1alpha1.KindDeployment:  {},
+	v1alpha1.KindSecret:      {},
+	v1alpha1.KindRole:        {},
+	v1alpha1.KindClusterRole: {},
+	v1alpha1.KindRoleBinding: {},
+}
+
+// unsearchedKinds are the built-in kinds whose tables have no
+// search_vector column.
+var unsearchedKinds = map[string]struct{}{
+	v1alpha1.KindSecret:      {},
+	v1alpha1.KindRole:        {},
+	v1alpha1.KindClusterRole: {},
+	v1alpha1.KindRoleBinding: {},
 }
 
 // NewStores builds one *Store per OSS built-in v1alpha1 Kind, bound to its
@@ -58,11 +71,16 @@ func NewStores(pool *pgxpool.Pool, schemas *pkgdb.SchemaRegistry, opts ...StoreO
 		// Caller-supplied opts win (they appear after WithKind in the
 		// option chain).
 		kindOpts := append([]StoreOption{WithKind(kind)}, opts...)
+		// Secrets and RBAC objects are never full-text indexed; every
+		// other built-in table carries search_vector.
+		if _, ok := unsearchedKinds[kind]; !ok {
+			kindOpts = append([]StoreOption{WithSearchIndex(ossSchema)}, kindOpts...)
+		}
 		if descriptor.Storage == v1alpha1.KindStorageMutableObject {
 			out[kind] = NewMutableObjectStore(pool, ossSchema, table, kindOpts...)
 			continue
 		}
-		out[kind] = NewStore(pool, ossSchema, table, kindOpts...)
+		out[kind] = NewStore(pool, ossSchema, table, append([]StoreOption{WithTagAliases(ossSchema), WithTagHistory(ossSchema), WithTagLifecycle()}, kindOpts...)...)
 	}
 	for kind := range builtInKinds {
 		if _, ok := out[kind]; !ok {
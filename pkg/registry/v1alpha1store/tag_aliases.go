@@ -0,0 +1,252 @@
+package v1alpha1store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// ErrTagConflict is returned when a tag name is already taken by the other
+// tag form: PromoteTag onto a name that is a concrete tag, or an apply of a
+// concrete tag whose name is an alias. Callers map it to 409.
+var ErrTagConflict = errors.New("v1alpha1 store: tag conflict")
+
+// TagAlias is a movable tag (e.g. "stable") that points at a concrete tag
+// of one tagged-artifact object. Generation bumps each time the alias moves.
+type TagAlias struct {
+	Namespace  string
+	Name       string
+	Alias      string
+	Target     string
+	UID        string
+	Generation int64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (s *Store) aliasesEnabled() error {
+	if s.behavior != TaggedArtifactStore {
+		return errors.New("v1alpha1 store: tag aliases are not supported on mutable-object stores")
+	}
+	if s.aliases == "" || s.kind == "" {
+		return errors.New("v1alpha1 store: tag aliases are not enabled on this store")
+	}
+	return nil
+}
+
+// PromoteTag points alias at the concrete tag from resolves to, creating
+// the alias or moving it. from may itself be an alias, in which case the
+// new alias copies its current target. Returns pkgdb.ErrNotFound when from
+// names neither a live tag nor an alias, and ErrTagConflict when alias is
+// already a concrete tag of (namespace, name). Re-promoting to the same
+// target is a no-op that leaves generation unchanged; moving an alias the
+// Store's ImmutableTagPolicy protects fails with ErrImmutableTag.
+func (s *Store) PromoteTag(ctx context.Context, namespace, name, from, alias string) (TagAlias, UpsertOutcome, error) {
+	if err := s.aliasesEnabled(); err != nil {
+		return TagAlias{}, 0, err
+	}
+	if namespace == "" || name == "" || from == "" || alias == "" {
+		return TagAlias{}, 0, errors.New("v1alpha1 store: namespace, name, from and alias are required")
+	}
+	if alias == DefaultTag() {
+		return TagAlias{}, 0, fmt.Errorf("%w: %q is reserved for the default tag", ErrTagConflict, alias)
+	}
+
+	var (
+		out     TagAlias
+		outcome UpsertOutcome
+	)
+	err := runInTx(ctx, s.pool, func(tx pgx.Tx) error {
+		// Same per-(namespace, name) lock as upsertTagged, so an apply of a
+		// concrete tag and a promote onto the same name cannot both win.
+		key := s.advisoryLockKey(s.table, namespace, name)
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, key); err != nil {
+			return fmt.Errorf("advisory lock: %w", err)
+		}
+
+		concrete, err := s.liveTagExists(ctx, tx, namespace, name, alias)
+		if err != nil {
+			return err
+		}
+		if concrete {
+			return fmt.Errorf("%w: %s/%s already has a concrete tag %q", ErrTagConflict, namespace, name, alias)
+		}
+
+		target := from
+		found, err := s.liveTagExists(ctx, tx, namespace, name, from)
+		if err != nil {
+			return err
+		}
+		if !found {
+			existing, err := s.loadTagAlias(ctx, tx, namespace, name, from)
+			if err != nil {
+				return err
+			}
+			target = existing.Target
+			if found, err = s.liveTagExists(ctx, tx, namespace, name, target); err != nil {
+				return err
+			}
+			if !found {
+				return fmt.Errorf("%w: alias %q points at missing tag %q", pkgdb.ErrNotFound, from, target)
+			}
+		}
+
+		current, err := s.loadTagAlias(ctx, tx, namespace, name, alias)
+		switch {
+		case errors.Is(err, pkgdb.ErrNotFound):
+			out, err = scanTagAlias(tx.QueryRow(ctx, `
+				INSERT INTO `+s.aliases+` (kind, namespace, name, alias, target_tag)
+				VALUES ($1, $2, $3, $4, $5)
+				RETURNING `+tagAliasColumns, s.kind, namespace, name, alias, target))
+			if err != nil {
+				return fmt.Errorf("insert tag alias: %w", err)
+			}
+			outcome = UpsertCreated
+			return nil
+		case err != nil:
+			return err
+		}
+		if current.Target == target {
+			out, outcome = current, UpsertNoOp
+			return nil
+		}
+		if s.immutable.Immutable(s.kind, namespace, alias) {
+			return fmt.Errorf("%w: alias %s/%s:%s already points at %q", ErrImmutableTag, namespace, name, alias, current.Target)
+		}
+		out, err = scanTagAlias(tx.QueryRow(ctx, `
+			UPDATE `+s.aliases+`
+			SET target_tag=$5, generation=generation+1
+			WHERE kind=$1 AND namespace=$2 AND name=$3 AND alias=$4
+			RETURNING `+tagAliasColumns, s.kind, namespace, name, alias, target))
+		if err != nil {
+			return fmt.Errorf("move tag alias: %w", err)
+		}
+		outcome = UpsertReplaced
+		return nil
+	})
+	if err != nil {
+		return TagAlias{}, 0, err
+	}
+	return out, outcome, nil
+}
+
+// GetTagAlias returns one alias of (namespace, name), or pkgdb.ErrNotFound.
+func (s *Store) GetTagAlias(ctx context.Context, namespace, name, alias string) (TagAlias, error) {
+	if err := s.aliasesEnabled(); err != nil {
+		return TagAlias{}, err
+	}
+	return s.loadTagAlias(ctx, s.pool, namespace, name, alias)
+}
+
+// ListTagAliases returns every alias of (namespace, name) ordered by alias
+// name. Returns an empty slice (no error) when there are none.
+func (s *Store) ListTagAliases(ctx context.Context, namespace, name string) ([]TagAlias, error) {
+	if err := s.aliasesEnabled(); err != nil {
+		return nil, err
+	}
+	if namespace == "" || name == "" {
+		return nil, errors.New("v1alpha1 store: namespace and name are required")
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+tagAliasColumns+`
+		FROM `+s.aliases+`
+		WHERE kind=$1 AND namespace=$2 AND name=$3
+		ORDER BY alias`, s.kind, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("list tag aliases: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]TagAlias, 0, 2)
+	for rows.Next() {
+		a, err := scanTagAlias(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan tag alias: %w", err)
+		}
+		out = append(out, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteTagAlias removes one alias. The concrete tag it pointed at is left
+// untouched. Returns pkgdb.ErrNotFound when the alias does not exist.
+func (s *Store) DeleteTagAlias(ctx context.Context, namespace, name, alias string) error {
+	if err := s.aliasesEnabled(); err != nil {
+		return err
+	}
+	cmdTag, err := s.pool.Exec(ctx, `
+		DELETE FROM `+s.aliases+`
+		WHERE kind=$1 AND namespace=$2 AND name=$3 AND alias=$4`, s.kind, namespace, name, alias)
+	if err != nil {
+		return fmt.Errorf("delete tag alias: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return pkgdb.ErrNotFound
+	}
+	return nil
+}
+
+// tagAliasColumns is the column list scanTagAlias expects.
+const tagAliasColumns = `namespace, name, alias, target_tag, uid::text, generation, created_at, updated_at`
+
+func scanTagAlias(row pgx.Row) (TagAlias, error) {
+	var a TagAlias
+	err := row.Scan(&a.Namespace, &a.Name, &a.Alias, &a.Target, &a.UID, &a.Generation, &a.CreatedAt, &a.UpdatedAt)
+	return a, err
+}
+
+// queryRower is the QueryRow subset shared by *pgxpool.Pool and pgx.Tx.
+type queryRower interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func (s *Store) loadTagAlias(ctx context.Context, q queryRower, namespace, name, alias string) (TagAlias, error) {
+	a, err := scanTagAlias(q.QueryRow(ctx, `
+		SELECT `+tagAliasColumns+`
+		FROM `+s.aliases+`
+		WHERE kind=$1 AND namespace=$2 AND name=$3 AND alias=$4`, s.kind, namespace, name, alias))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return TagAlias{}, pkgdb.ErrNotFound
+	}
+	if err != nil {
+		return TagAlias{}, fmt.Errorf("load tag alias: %w", err)
+	}
+	return a, nil
+}
+
+// aliasExists reports whether tag is an alias of (namespace, name). Always
+// false on stores without alias support.
+func (s *Store) aliasExists(ctx context.Context, q queryRower, namespace, name, tag string) (bool, error) {
+	if s.aliasesEnabled() != nil {
+		return false, nil
+	}
+	var exists bool
+	if err := q.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM `+s.aliases+`
+			WHERE kind=$1 AND namespace=$2 AND name=$3 AND alias=$4
+		)`, s.kind, namespace, name, tag).Scan(&exists); err != nil {
+		return false, fmt.Errorf("check tag alias: %w", err)
+	}
+	return exists, nil
+}
+
+func (s *Store) liveTagExists(ctx context.Context, q queryRower, namespace, name, tag string) (bool, error) {
+	var exists bool
+	if err := q.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM `+s.qualified+`
+			WHERE namespace=$1 AND name=$2 AND tag=$3 AND deletion_timestamp IS NULL
+		)`, namespace, name, tag).Scan(&exists); err != nil {
+		return false, fmt.Errorf("check tag: %w", err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,131 @@
+//go:build integration
+
+package v1alpha1store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+func newAliasTestStore(t *testing.T) *Store {
+	t.Helper()
+	store := NewStore(NewTestPool(t), TestSchema(), testTable, WithKind(v1alpha1.KindAgent), WithTagAliases(TestSchema()))
+	for _, tag := range []string{"1.0.0", "1.1.0"} {
+		_, err := store.Upsert(context.Background(), &v1alpha1.Agent{
+			Metadata: v1alpha1.ObjectMeta{Namespace: testNS, Name: "foo", Tag: tag},
+			Spec:     v1alpha1.AgentSpec{Title: tag},
+		})
+		require.NoError(t, err)
+	}
+	return store
+}
+
+func TestStore_PromoteTag(t *testing.T) {
+	ctx := context.Background()
+	store := newAliasTestStore(t)
+
+	alias, outcome, err := store.PromoteTag(ctx, testNS, "foo", "1.0.0", "stable")
+	require.NoError(t, err)
+	require.Equal(t, UpsertCreated, outcome)
+	require.Equal(t, "1.0.0", alias.Target)
+	require.Equal(t, int64(1), alias.Generation)
+
+	got, err := store.GetByRef(ctx, testNS, "foo", "stable")
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", got.Metadata.Tag, "GetByRef must follow the alias to its target")
+
+	_, outcome, err = store.PromoteTag(ctx, testNS, "foo", "1.0.0", "stable")
+	require.NoError(t, err)
+	require.Equal(t, UpsertNoOp, outcome)
+
+	moved, outcome, err := store.PromoteTag(ctx, testNS, "foo", "1.1.0", "stable")
+	require.NoError(t, err)
+	require.Equal(t, UpsertReplaced, outcome)
+	require.Equal(t, int64(2), moved.Generation)
+	require.Equal(t, alias.UID, moved.UID)
+
+	copied, _, err := store.PromoteTag(ctx, testNS, "foo", "stable", "prod")
+	require.NoError(t, err)
+	require.Equal(t, "1.1.0", copied.Target, "promoting from an alias copies its current target")
+
+	_, _, err = store.PromoteTag(ctx, testNS, "foo", "1.0.0", "1.1.0")
+	require.ErrorIs(t, err, ErrTagConflict)
+	_, _, err = store.PromoteTag(ctx, testNS, "foo", "1.0.0", DefaultTag())
+	require.ErrorIs(t, err, ErrTagConflict)
+	_, _, err = store.PromoteTag(ctx, testNS, "foo", "9.9.9", "stable")
+	require.ErrorIs(t, err, pkgdb.ErrNotFound)
+
+	list, err := store.ListTagAliases(ctx, testNS, "foo")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "prod", list[0].Alias)
+	require.Equal(t, "stable", list[1].Alias)
+}
+
+func TestStore_UpsertRejectsTagShadowingAlias(t *testing.T) {
+	ctx := context.Background()
+	store := newAliasTestStore(t)
+
+	_, _, err := store.PromoteTag(ctx, testNS, "foo", "1.0.0", "stable")
+	require.NoError(t, err)
+
+	_, err = store.Upsert(ctx, &v1alpha1.Agent{
+		Metadata: v1alpha1.ObjectMeta{Namespace: testNS, Name: "foo", Tag: "stable"},
+		Spec:     v1alpha1.AgentSpec{Title: "shadow"},
+	})
+	require.ErrorIs(t, err, ErrTagConflict)
+
+	require.NoError(t, store.DeleteTagAlias(ctx, testNS, "foo", "stable"))
+	require.ErrorIs(t, store.DeleteTagAlias(ctx, testNS, "foo", "stable"), pkgdb.ErrNotFound)
+	_, err = store.Upsert(ctx, &v1alpha1.Agent{
+		Metadata: v1alpha1.ObjectMeta{Namespace: testNS, Name: "foo", Tag: "stable"},
+		Spec:     v1alpha1.AgentSpec{Title: "stable"},
+	})
+	require.NoError(t, err, "the name is free again once the alias is gone")
+}
+
+func TestStore_DeleteAllTagsRemovesAliases(t *testing.T) {
+	ctx := context.Background()
+	store := newAliasTestStore(t)
+
+	_, _, err := store.PromoteTag(ctx, testNS, "foo", "1.0.0", "stable")
+	require.NoError(t, err)
+	require.NoError(t, store.DeleteAllTags(ctx, testNS, "foo"))
+
+	list, err := store.ListTagAliases(ctx, testNS, "foo")
+	require.NoError(t, err)
+	require.Empty(t, list)
+}
+
+func TestStore_PromoteTagRecordsControlPlaneEvent(t *testing.T) {
+	ctx := context.Background()
+	store := newAliasTestStore(t)
+	events := NewControlPlaneEventStore(store.pool, TestSchema())
+
+	_, _, err := store.PromoteTag(ctx, testNS, "foo", "1.0.0", "stable")
+	require.NoError(t, err)
+	rev, err := events.CurrentRevision(ctx)
+	require.NoError(t, err)
+
+	// A no-op re-promote must not wake controllers.
+	_, _, err = store.PromoteTag(ctx, testNS, "foo", "1.0.0", "stable")
+	require.NoError(t, err)
+	got, err := events.ListAfter(ctx, rev, 10)
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	moved, _, err := store.PromoteTag(ctx, testNS, "foo", "1.1.0", "stable")
+	require.NoError(t, err)
+	got, err = events.ListAfter(ctx, rev, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, ResourceKey{Kind: v1alpha1.KindAgent, Namespace: testNS, Name: "foo", Tag: "stable"}, got[0].Key)
+	require.Equal(t, "update", got[0].Operation)
+	require.Equal(t, moved.UID, got[0].UID)
+	require.Equal(t, moved.Generation, got[0].Generation)
+}
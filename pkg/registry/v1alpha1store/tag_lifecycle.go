@@ -0,0 +1,86 @@
+package v1alpha1store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// lifecycleKey is the status key reads surface a tag's lifecycle under.
+const lifecycleKey = "lifecycle"
+
+func (s *Store) lifecycleEnabled() error {
+	if s.behavior != TaggedArtifactStore {
+		return errors.New("v1alpha1 store: tag lifecycle is not supported on mutable-object stores")
+	}
+	if !s.lifecycle {
+		return errors.New("v1alpha1 store: tag lifecycle is not enabled on this store")
+	}
+	return nil
+}
+
+// SetTagLifecycle records lifecycle as the publication state of one live
+// tag; nil reactivates it. The lifecycle is kept in its own column, apart
+// from the controller-owned status, so replacing the tag's content (a
+// re-apply or a rollback) keeps it. Reads surface it as status.lifecycle.
+// Returns pkgdb.ErrNotFound when the tag does not exist.
+func (s *Store) SetTagLifecycle(ctx context.Context, namespace, name, tag string, lifecycle *v1alpha1.TagLifecycle) error {
+	if err := s.lifecycleEnabled(); err != nil {
+		return err
+	}
+	if namespace == "" || name == "" || tag == "" {
+		return errors.New("v1alpha1 store: namespace, name and tag are required")
+	}
+	var value any
+	if lifecycle != nil && lifecycle.State != v1alpha1.LifecycleActive {
+		raw, err := json.Marshal(lifecycle)
+		if err != nil {
+			return fmt.Errorf("v1alpha1 store: marshal lifecycle: %w", err)
+		}
+		value = string(raw)
+	}
+	res, err := s.pool.Exec(ctx, `
+		UPDATE `+s.qualified+`
+		SET lifecycle=$4::jsonb
+		WHERE namespace=$1 AND name=$2 AND tag=$3 AND deletion_timestamp IS NULL`,
+		namespace, name, tag, value)
+	if err != nil {
+		return fmt.Errorf("set tag lifecycle: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return pkgdb.ErrNotFound
+	}
+	return nil
+}
+
+// statusColumn is the select expression for a row's status. With the
+// lifecycle column enabled it overlays the lifecycle onto status, so
+// every reader sees it at status.lifecycle.
+func (s *Store) statusColumn() string {
+	if s.lifecycle {
+		return `CASE WHEN lifecycle IS NULL THEN status ELSE status || jsonb_build_object('` + lifecycleKey + `', lifecycle) END AS status`
+	}
+	return "status"
+}
+
+// withoutLifecycle drops the lifecycle key from a status payload about to
+// be stored: the column owns it, and a status round-tripped through a
+// read would otherwise keep a stale copy.
+func withoutLifecycle(status []byte) ([]byte, error) {
+	if len(status) == 0 {
+		return status, nil
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(status, &fields); err != nil {
+		return nil, fmt.Errorf("decode status: %w", err)
+	}
+	if _, ok := fields[lifecycleKey]; !ok {
+		return status, nil
+	}
+	delete(fields, lifecycleKey)
+	return json.Marshal(fields)
+}
@@ -0,0 +1,54 @@
+//go:build integration
+
+package v1alpha1store
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+func agentLifecycle(t *testing.T, store *Store, tag string) *v1alpha1.TagLifecycle {
+	t.Helper()
+	row, err := store.Get(context.Background(), testNS, "foo", tag)
+	require.NoError(t, err)
+	lifecycle, err := v1alpha1.LifecycleFromStorage(row.Status)
+	require.NoError(t, err)
+	return lifecycle
+}
+
+func TestStore_TagLifecycleSurvivesContentReplacement(t *testing.T) {
+	ctx := context.Background()
+	store := newHistoryTestStore(t, WithTagLifecycle())
+
+	applyAgentTitle(t, store, "1.0.0", "a")
+	yanked := &v1alpha1.TagLifecycle{State: v1alpha1.LifecycleYanked, Reason: "CVE-2026-1"}
+	require.NoError(t, store.SetTagLifecycle(ctx, testNS, "foo", "1.0.0", yanked))
+	require.Equal(t, yanked, agentLifecycle(t, store, "1.0.0"))
+
+	res := applyAgentTitle(t, store, "1.0.0", "b")
+	require.Equal(t, UpsertReplaced, res.Outcome)
+	require.Equal(t, yanked, agentLifecycle(t, store, "1.0.0"), "a re-apply keeps the yank")
+
+	// A status round-tripped through a read must not store a copy of the
+	// lifecycle that would outlive a reactivation.
+	require.NoError(t, store.PatchStatus(ctx, testNS, "foo", "1.0.0", v1alpha1.StatusPatcher(func(s *v1alpha1.Status) {
+		s.Lifecycle = yanked
+		s.ObservedGeneration = res.Generation
+	})))
+	var raw []byte
+	require.NoError(t, store.pool.QueryRow(ctx, `SELECT status FROM `+store.qualified+` WHERE name='foo' AND tag='1.0.0'`).Scan(&raw))
+	var stored map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(raw, &stored))
+	require.NotContains(t, stored, "lifecycle")
+
+	require.NoError(t, store.SetTagLifecycle(ctx, testNS, "foo", "1.0.0", nil))
+	require.Nil(t, agentLifecycle(t, store, "1.0.0"))
+
+	require.ErrorIs(t, store.SetTagLifecycle(ctx, testNS, "foo", "9.9.9", yanked), pkgdb.ErrNotFound)
+}
@@ -0,0 +1,106 @@
+package v1alpha1store
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// ErrImmutableTag is returned when an apply would replace the content of a
+// tag the ImmutableTagPolicy protects, or move an alias it protects. It
+// wraps ErrTagConflict so callers map it to 409 the same way.
+var ErrImmutableTag = fmt.Errorf("%w: tag is immutable", ErrTagConflict)
+
+// semverTagPattern is the ImmutableTagRule pattern that matches full semver
+// tags ("1.2.3", "v1.2.3-rc.1") and nothing else.
+const semverTagPattern = "semver"
+
+// ImmutableTagRule protects the tags of one namespace and kind that match
+// Pattern. Namespace and Kind are "*" to match any; Kind compares
+// case-insensitively. Pattern is "semver" or a path.Match glob ("release-*").
+type ImmutableTagRule struct {
+	Namespace string
+	Kind      string
+	Pattern   string
+}
+
+func (r ImmutableTagRule) matches(kind, namespace, tag string) bool {
+	if r.Namespace != "*" && r.Namespace != namespace {
+		return false
+	}
+	if r.Kind != "*" && !strings.EqualFold(r.Kind, kind) {
+		return false
+	}
+	if r.Pattern == semverTagPattern {
+		return isFullSemverTag(tag)
+	}
+	ok, _ := path.Match(r.Pattern, tag)
+	return ok
+}
+
+// isFullSemverTag reports whether tag is MAJOR.MINOR.PATCH with optional
+// pre-release/build and an optional "v" prefix. Partial versions ("1",
+// "1.2") are conventionally floating tags and do not match.
+func isFullSemverTag(tag string) bool {
+	_, err := semver.StrictNewVersion(strings.TrimPrefix(tag, "v"))
+	return err == nil
+}
+
+// ImmutableTagPolicy decides which tags cannot be replaced once applied.
+// A nil policy protects nothing. The default tag ("latest") is never
+// protected: every untagged apply writes it.
+type ImmutableTagPolicy struct {
+	rules []ImmutableTagRule
+}
+
+// ParseImmutableTagPolicy parses a comma-separated rule list, each rule
+// being [NAMESPACE/][KIND:]PATTERN:
+//
+//	semver                      full semver tags of every kind, every namespace
+//	prod/semver                 semver tags in namespace prod
+//	*/MCPServer:semver          semver MCPServer tags in every namespace
+//	prod/Agent:release-*        Agent tags starting "release-" in prod
+//
+// An empty spec returns a nil policy.
+func ParseImmutableTagPolicy(spec string) (*ImmutableTagPolicy, error) {
+	var rules []ImmutableTagRule
+	for raw := range strings.SplitSeq(spec, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		rule := ImmutableTagRule{Namespace: "*", Kind: "*", Pattern: raw}
+		if ns, rest, ok := strings.Cut(rule.Pattern, "/"); ok {
+			rule.Namespace, rule.Pattern = ns, rest
+		}
+		if kind, rest, ok := strings.Cut(rule.Pattern, ":"); ok {
+			rule.Kind, rule.Pattern = kind, rest
+		}
+		if rule.Namespace == "" || rule.Kind == "" || rule.Pattern == "" {
+			return nil, fmt.Errorf("immutable tag rule %q: want [NAMESPACE/][KIND:]PATTERN", raw)
+		}
+		if _, err := path.Match(rule.Pattern, ""); err != nil {
+			return nil, fmt.Errorf("immutable tag rule %q: %w", raw, err)
+		}
+		rules = append(rules, rule)
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	return &ImmutableTagPolicy{rules: rules}, nil
+}
+
+// Immutable reports whether tag of (kind, namespace) is protected.
+func (p *ImmutableTagPolicy) Immutable(kind, namespace, tag string) bool {
+	if p == nil || tag == DefaultTag() {
+		return false
+	}
+	for _, r := range p.rules {
+		if r.matches(kind, namespace, tag) {
+			return true
+		}
+	}
+	return false
+}
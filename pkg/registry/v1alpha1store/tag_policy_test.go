@@ -0,0 +1,41 @@
+package v1alpha1store
+
+import "testing"
+
+func TestParseImmutableTagPolicy(t *testing.T) {
+	p, err := ParseImmutableTagPolicy(" semver , prod/Agent:release-*, */mcpserver:v* ")
+	if err != nil {
+		t.Fatalf("ParseImmutableTagPolicy: %v", err)
+	}
+	tests := []struct {
+		kind, namespace, tag string
+		want                 bool
+	}{
+		{"Agent", "default", "1.2.3", true},
+		{"Skill", "team-a", "v2.0.0-rc.1", true},
+		{"Agent", "default", "1.2", false},
+		{"Agent", "default", "latest", false},
+		{"Agent", "default", "stable", false},
+		{"Agent", "prod", "release-7", true},
+		{"Agent", "default", "release-7", false},
+		{"Skill", "prod", "release-7", false},
+		{"MCPServer", "default", "v-next", true},
+	}
+	for _, tt := range tests {
+		if got := p.Immutable(tt.kind, tt.namespace, tt.tag); got != tt.want {
+			t.Errorf("Immutable(%q, %q, %q) = %v, want %v", tt.kind, tt.namespace, tt.tag, got, tt.want)
+		}
+	}
+
+	if p, err := ParseImmutableTagPolicy(""); err != nil || p != nil {
+		t.Fatalf("empty spec = (%v, %v), want (nil, nil)", p, err)
+	}
+	if (*ImmutableTagPolicy)(nil).Immutable("Agent", "default", "1.0.0") {
+		t.Fatal("nil policy must protect nothing")
+	}
+	for _, bad := range []string{"prod/", "/semver", "Agent:", "[", "prod/Agent:["} {
+		if _, err := ParseImmutableTagPolicy(bad); err == nil {
+			t.Errorf("ParseImmutableTagPolicy(%q): expected an error", bad)
+		}
+	}
+}
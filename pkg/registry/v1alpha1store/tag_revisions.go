@@ -0,0 +1,202 @@
+package v1alpha1store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// TagRevision is one generation of a tag's content. The live row is the
+// current revision; every earlier generation is a copy kept when an apply
+// or rollback replaced it. AppliedAt is when the content was written;
+// ReplacedAt is zero for the current revision.
+type TagRevision struct {
+	Namespace   string
+	Name        string
+	Tag         string
+	UID         string
+	Generation  int64
+	ContentHash string
+	Current     bool
+	AppliedAt   time.Time
+	ReplacedAt  time.Time
+}
+
+func (s *Store) historyEnabled() error {
+	if s.behavior != TaggedArtifactStore {
+		return errors.New("v1alpha1 store: tag history is not supported on mutable-object stores")
+	}
+	if s.revisions == "" || s.kind == "" {
+		return errors.New("v1alpha1 store: tag history is not enabled on this store")
+	}
+	return nil
+}
+
+// ListTagRevisions returns every revision of one live tag, newest
+// generation first; the first entry is the current content. Returns
+// pkgdb.ErrNotFound when the tag does not exist.
+func (s *Store) ListTagRevisions(ctx context.Context, namespace, name, tag string) ([]TagRevision, error) {
+	if err := s.historyEnabled(); err != nil {
+		return nil, err
+	}
+	if namespace == "" || name == "" || tag == "" {
+		return nil, errors.New("v1alpha1 store: namespace, name and tag are required")
+	}
+	current := TagRevision{Namespace: namespace, Name: name, Tag: tag, Current: true}
+	err := s.pool.QueryRow(ctx, `
+		SELECT uid::text, generation, content_hash, created_at
+		FROM `+s.qualified+`
+		WHERE namespace=$1 AND name=$2 AND tag=$3 AND deletion_timestamp IS NULL`,
+		namespace, name, tag).Scan(&current.UID, &current.Generation, &current.ContentHash, &current.AppliedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, pkgdb.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load tag: %w", err)
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+tagRevisionColumns+`
+		FROM `+s.revisions+`
+		WHERE uid=$1 AND generation < $2
+		ORDER BY generation DESC`, current.UID, current.Generation)
+	if err != nil {
+		return nil, fmt.Errorf("list tag revisions: %w", err)
+	}
+	defer rows.Close()
+
+	out := []TagRevision{current}
+	for rows.Next() {
+		r, err := scanTagRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan tag revision: %w", err)
+		}
+		out = append(out, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// The current content was written when the newest kept revision was
+	// replaced; with no history it is as old as the row.
+	if len(out) > 1 {
+		out[0].AppliedAt = out[1].ReplacedAt
+	}
+	return out, nil
+}
+
+// GetTagRevision returns the content of one generation of a live tag:
+// its labels, annotations and spec, with metadata.generation set to the
+// requested generation. The current generation is read from the live row;
+// earlier ones from the kept revisions. Status is never returned, since a
+// revision only records content. Rollback re-applies the returned object
+// through the regular apply path, so it is validated and admitted like any
+// other apply. Returns pkgdb.ErrNotFound when the tag or the revision does
+// not exist, and ErrTerminating when the tag is being deleted.
+func (s *Store) GetTagRevision(ctx context.Context, namespace, name, tag string, generation int64) (*v1alpha1.RawObject, error) {
+	if err := s.historyEnabled(); err != nil {
+		return nil, err
+	}
+	if namespace == "" || name == "" || tag == "" {
+		return nil, errors.New("v1alpha1 store: namespace, name and tag are required")
+	}
+	current, err := s.Get(ctx, namespace, name, tag)
+	if err != nil {
+		return nil, err
+	}
+	if current.Metadata.DeletionTimestamp != nil {
+		return nil, ErrTerminating
+	}
+	out := &v1alpha1.RawObject{
+		TypeMeta: v1alpha1.TypeMeta{APIVersion: v1alpha1.GroupVersion, Kind: s.kind},
+		Metadata: v1alpha1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Tag:         tag,
+			UID:         current.Metadata.UID,
+			Generation:  generation,
+			Labels:      current.Metadata.Labels,
+			Annotations: current.Metadata.Annotations,
+		},
+		Spec: current.Spec,
+	}
+	if generation == current.Metadata.Generation {
+		return out, nil
+	}
+
+	var labelsJSON, annotationsJSON, specJSON []byte
+	err = s.pool.QueryRow(ctx, `
+		SELECT labels, annotations, spec
+		FROM `+s.revisions+`
+		WHERE uid=$1 AND generation=$2`, current.Metadata.UID, generation).Scan(&labelsJSON, &annotationsJSON, &specJSON)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return nil, fmt.Errorf("%w: %s/%s:%s has no revision %d", pkgdb.ErrNotFound, namespace, name, tag, generation)
+	case err != nil:
+		return nil, fmt.Errorf("load tag revision: %w", err)
+	}
+	out.Metadata.Labels, out.Metadata.Annotations = nil, nil
+	if err := unmarshalStringMap(labelsJSON, &out.Metadata.Labels); err != nil {
+		return nil, fmt.Errorf("decode labels: %w", err)
+	}
+	if err := unmarshalStringMap(annotationsJSON, &out.Metadata.Annotations); err != nil {
+		return nil, fmt.Errorf("decode annotations: %w", err)
+	}
+	out.Spec = specJSON
+	return out, nil
+}
+
+func unmarshalStringMap(data []byte, out *map[string]string) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// recordTagRevision copies the live content of (namespace, name, tag) into
+// tag_revisions ahead of an in-place replacement. A no-op on stores
+// without history.
+func (s *Store) recordTagRevision(ctx context.Context, tx pgx.Tx, namespace, name, tag string) error {
+	if s.historyEnabled() != nil {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO `+s.revisions+` (uid, generation, kind, namespace, name, tag, labels, annotations, spec, content_hash, applied_at)
+		SELECT t.uid, t.generation, $1, t.namespace, t.name, t.tag, t.labels, t.annotations, t.spec, t.content_hash,
+		       COALESCE((SELECT max(r.replaced_at) FROM `+s.revisions+` r WHERE r.uid = t.uid), t.created_at)
+		FROM `+s.qualified+` t
+		WHERE t.namespace=$2 AND t.name=$3 AND t.tag=$4
+		ON CONFLICT (uid, generation) DO NOTHING`, s.kind, namespace, name, tag); err != nil {
+		return fmt.Errorf("record tag revision: %w", err)
+	}
+	return nil
+}
+
+// deleteTagRevisions drops the history of one tag, or of every tag of
+// (namespace, name) when tag is empty. A no-op on stores without history.
+func (s *Store) deleteTagRevisions(ctx context.Context, tx pgx.Tx, namespace, name, tag string) error {
+	if s.historyEnabled() != nil {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM `+s.revisions+`
+		WHERE kind=$1 AND namespace=$2 AND name=$3 AND ($4 = '' OR tag=$4)`, s.kind, namespace, name, tag); err != nil {
+		return fmt.Errorf("delete tag revisions: %w", err)
+	}
+	return nil
+}
+
+// tagRevisionColumns is the column list scanTagRevision expects.
+const tagRevisionColumns = `namespace, name, tag, uid::text, generation, content_hash, applied_at, replaced_at`
+
+func scanTagRevision(row pgx.Row) (TagRevision, error) {
+	var r TagRevision
+	err := row.Scan(&r.Namespace, &r.Name, &r.Tag, &r.UID, &r.Generation, &r.ContentHash, &r.AppliedAt, &r.ReplacedAt)
+	return r, err
+}
@@ -0,0 +1,126 @@
+//go:build integration
+
+package v1alpha1store
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/api/v1alpha1"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+func newHistoryTestStore(t *testing.T, opts ...StoreOption) *Store {
+	t.Helper()
+	opts = append([]StoreOption{WithKind(v1alpha1.KindAgent), WithTagHistory(TestSchema())}, opts...)
+	return NewStore(NewTestPool(t), TestSchema(), testTable, opts...)
+}
+
+func applyAgentTitle(t *testing.T, store *Store, tag, title string) UpsertResult {
+	t.Helper()
+	res, err := store.Upsert(context.Background(), &v1alpha1.Agent{
+		Metadata: v1alpha1.ObjectMeta{Namespace: testNS, Name: "foo", Tag: tag},
+		Spec:     v1alpha1.AgentSpec{Title: title},
+	})
+	require.NoError(t, err)
+	return res
+}
+
+func agentTitle(t *testing.T, store *Store, tag string) string {
+	t.Helper()
+	row, err := store.Get(context.Background(), testNS, "foo", tag)
+	require.NoError(t, err)
+	var spec v1alpha1.AgentSpec
+	require.NoError(t, json.Unmarshal(row.Spec, &spec))
+	return spec.Title
+}
+
+func TestStore_ListTagRevisions(t *testing.T) {
+	ctx := context.Background()
+	store := newHistoryTestStore(t)
+
+	applyAgentTitle(t, store, "1.0.0", "a")
+	applyAgentTitle(t, store, "1.0.0", "a") // no-op: nothing to keep
+	applyAgentTitle(t, store, "1.0.0", "b")
+	applyAgentTitle(t, store, "1.0.0", "c")
+
+	revs, err := store.ListTagRevisions(ctx, testNS, "foo", "1.0.0")
+	require.NoError(t, err)
+	require.Len(t, revs, 3)
+	require.Equal(t, []int64{3, 2, 1}, []int64{revs[0].Generation, revs[1].Generation, revs[2].Generation})
+	require.True(t, revs[0].Current)
+	require.True(t, revs[0].ReplacedAt.IsZero())
+	require.False(t, revs[1].Current)
+	require.False(t, revs[1].ReplacedAt.IsZero())
+	require.Equal(t, revs[0].UID, revs[2].UID)
+	require.NotEqual(t, revs[0].ContentHash, revs[1].ContentHash)
+	require.False(t, revs[0].AppliedAt.Before(revs[1].AppliedAt))
+
+	_, err = store.ListTagRevisions(ctx, testNS, "foo", "9.9.9")
+	require.ErrorIs(t, err, pkgdb.ErrNotFound)
+}
+
+func TestStore_GetTagRevision(t *testing.T) {
+	ctx := context.Background()
+	store := newHistoryTestStore(t)
+
+	applyAgentTitle(t, store, "1.0.0", "a")
+	applyAgentTitle(t, store, "1.0.0", "b")
+
+	rev, err := store.GetTagRevision(ctx, testNS, "foo", "1.0.0", 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rev.Metadata.Generation)
+	require.Equal(t, v1alpha1.KindAgent, rev.Kind)
+	require.Empty(t, rev.Status)
+	var spec v1alpha1.AgentSpec
+	require.NoError(t, json.Unmarshal(rev.Spec, &spec))
+	require.Equal(t, "a", spec.Title)
+
+	current, err := store.GetTagRevision(ctx, testNS, "foo", "1.0.0", 2)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(current.Spec, &spec))
+	require.Equal(t, "b", spec.Title, "the current generation is read from the live row")
+
+	_, err = store.GetTagRevision(ctx, testNS, "foo", "1.0.0", 42)
+	require.ErrorIs(t, err, pkgdb.ErrNotFound)
+	_, err = store.GetTagRevision(ctx, testNS, "foo", "2.0.0", 1)
+	require.ErrorIs(t, err, pkgdb.ErrNotFound)
+}
+
+func TestStore_ReapplyingARevisionKeepsHistory(t *testing.T) {
+	ctx := context.Background()
+	store := newHistoryTestStore(t)
+
+	applyAgentTitle(t, store, "1.0.0", "a")
+	applyAgentTitle(t, store, "1.0.0", "b")
+
+	res := applyAgentTitle(t, store, "1.0.0", "a")
+	require.Equal(t, UpsertReplaced, res.Outcome)
+	require.Equal(t, int64(3), res.Generation)
+
+	revs, err := store.ListTagRevisions(ctx, testNS, "foo", "1.0.0")
+	require.NoError(t, err)
+	require.Len(t, revs, 3, "the rolled-back content is kept as a revision too")
+	require.Equal(t, revs[0].ContentHash, revs[2].ContentHash)
+}
+
+func TestStore_DeleteTagDropsRevisions(t *testing.T) {
+	ctx := context.Background()
+	store := newHistoryTestStore(t)
+
+	applyAgentTitle(t, store, "1.0.0", "a")
+	applyAgentTitle(t, store, "1.0.0", "b")
+	require.NoError(t, store.Delete(ctx, testNS, "foo", "1.0.0"))
+
+	applyAgentTitle(t, store, "1.0.0", "c")
+	revs, err := store.ListTagRevisions(ctx, testNS, "foo", "1.0.0")
+	require.NoError(t, err)
+	require.Len(t, revs, 1, "a re-created tag starts a fresh history")
+
+	var kept int
+	require.NoError(t, store.pool.QueryRow(ctx, `SELECT count(*) FROM `+store.revisions).Scan(&kept))
+	require.Zero(t, kept)
+}
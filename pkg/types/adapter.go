@@ -103,8 +103,21 @@ type ApplyInput struct {
 	// check) — for example, resolving a Deployment's effective ModelRef or
 	// walking AgentSpec.MCPServers to build agentgateway upstream config.
 	Getter v1alpha1.GetterFunc
+
+	// Secrets resolves SecretKeyRefs (for example a Model's auth.secretRef)
+	// to plaintext. Adapters call it only while materializing the workload
+	// and must not persist the result in status or annotations. Nil means
+	// the build wires no secret store; refs that need it fail the apply.
+	Secrets SecretResolverFunc
 }
 
+// SecretResolverFunc returns the plaintext value a SecretKeyRef names, read on
+// behalf of an object in namespace. Blank ref.Namespace inherits namespace; a
+// ref into any other namespace is refused. A missing Secret or key returns an error
+// wrapping v1alpha1.ErrDanglingRef so the controller blocks the Deployment on
+// the reference instead of retrying.
+type SecretResolverFunc func(ctx context.Context, namespace string, ref v1alpha1.SecretKeyRef) (string, error)
+
 // ApplyResult captures the status + annotation deltas the reconciler
 // should persist after Apply.
 type ApplyResult struct {
@@ -260,6 +260,12 @@ func defaultApplyDependencies(ctx context.Context, in ApplyInput) ([]v1alpha1.Ob
 		if err != nil {
 			return nil, err
 		}
+		if model, ok := deps[len(deps)-1].(*v1alpha1.Model); ok {
+			deps, err = appendModelSecrets(ctx, deps, in.Getter, model)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 	if !ok || agent == nil {
 		return deps, nil
@@ -288,6 +294,18 @@ func defaultApplyDependencies(ctx context.Context, in ApplyInput) ([]v1alpha1.Ob
 	return deps, nil
 }
 
+// appendModelSecrets adds the Secrets a Model reads so rotating a credential
+// changes the fingerprint. The Getter returns the stored (sealed) spec, and
+// every rotation re-seals, so the ciphertext stands in for the plaintext.
+func appendModelSecrets(ctx context.Context, deps []v1alpha1.Object, getter v1alpha1.GetterFunc, model *v1alpha1.Model) ([]v1alpha1.Object, error) {
+	secretRefs := model.Spec.SecretKeyRefs()
+	refs := make([]v1alpha1.ResourceRef, 0, len(secretRefs))
+	for _, ref := range secretRefs {
+		refs = append(refs, v1alpha1.ResourceRef{Kind: v1alpha1.KindSecret, Namespace: ref.Namespace, Name: ref.Name})
+	}
+	return appendResolvedRefs(ctx, deps, getter, model.Metadata.NamespaceOrDefault(), refs, v1alpha1.KindSecret, "model secret refs")
+}
+
 func hasHarnessCompositionRefs(deployment *v1alpha1.Deployment, agent *v1alpha1.Agent) bool {
 	return deploymentSelectsHarness(deployment) && agent != nil &&
 		(len(agent.Spec.Plugins) > 0 || len(agent.Spec.Skills) > 0 || agent.Spec.Instructions != nil)
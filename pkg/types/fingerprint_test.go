@@ -141,6 +141,57 @@ func TestDefaultApplyFingerprintResultIncludesModelDependency(t *testing.T) {
 	}
 }
 
+func TestDefaultApplyFingerprintResultIncludesModelSecretDependency(t *testing.T) {
+	in := testApplyInput()
+	in.Deployment.Metadata.Namespace = "team-a"
+	in.Deployment.Spec.ModelRef = &v1alpha1.ModelRef{Name: "openai"}
+
+	sealed := "ciphertext-1"
+	in.Getter = func(_ context.Context, ref v1alpha1.ResourceRef) (v1alpha1.Object, error) {
+		switch ref.Kind {
+		case v1alpha1.KindModel:
+			model := testModel("team-a", "openai", "latest", "gpt-4o")
+			model.Spec.Auth = &v1alpha1.ModelAuthConfig{
+				Strategy:  v1alpha1.ModelAuthStrategySecretRef,
+				SecretRef: &v1alpha1.SecretKeyRef{Name: "provider-key", Key: "apiKey"},
+			}
+			return model, nil
+		case v1alpha1.KindSecret:
+			if ref.Namespace != "team-a" || ref.Name != "provider-key" {
+				t.Fatalf("secret ref = %+v, want team-a/provider-key inherited from the Model", ref)
+			}
+			return &v1alpha1.Secret{
+				TypeMeta: v1alpha1.TypeMeta{Kind: v1alpha1.KindSecret},
+				Metadata: v1alpha1.ObjectMeta{Namespace: "team-a", Name: "provider-key", UID: "secret-uid", Generation: 1},
+				Spec: v1alpha1.SecretSpec{Encrypted: &v1alpha1.SecretEnvelope{
+					KeyID:  "k",
+					Values: map[string]string{"apiKey": sealed},
+				}},
+			}, nil
+		default:
+			t.Fatalf("unexpected ref %+v", ref)
+			return nil, nil
+		}
+	}
+
+	first, err := DefaultApplyFingerprintResult(context.Background(), in, ApplyFingerprintOptions{AdapterType: "test"})
+	if err != nil {
+		t.Fatalf("DefaultApplyFingerprintResult: %v", err)
+	}
+	if len(first.Dependencies) != 2 || first.Dependencies[1].Kind != v1alpha1.KindSecret {
+		t.Fatalf("dependencies = %+v, want Model then Secret", first.Dependencies)
+	}
+
+	sealed = "ciphertext-2"
+	second, err := DefaultApplyFingerprintResult(context.Background(), in, ApplyFingerprintOptions{AdapterType: "test"})
+	if err != nil {
+		t.Fatalf("DefaultApplyFingerprintResult after Secret rotation: %v", err)
+	}
+	if second.Fingerprint == first.Fingerprint {
+		t.Fatalf("fingerprint did not change after Secret rotation: %s", second.Fingerprint)
+	}
+}
+
 func TestDefaultApplyFingerprintResultIncludesDefaultHarnessModel(t *testing.T) {
 	in := testApplyInput()
 	in.Deployment.Metadata.Namespace = "team-a"
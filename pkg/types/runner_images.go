@@ -6,4 +6,9 @@ package types
 const (
 	DefaultNPMRunnerImage  = "node:24-alpine3.21"
 	DefaultPyPIRunnerImage = "ghcr.io/astral-sh/uv:debian"
+	// DefaultGitHubReleaseRunnerImage needs curl, tar, and sha256sum to
+	// fetch and unpack a release asset, and glibc to run it.
+	DefaultGitHubReleaseRunnerImage = "buildpack-deps:bookworm-curl"
+	DefaultGoRunnerImage            = "golang:1.26"
+	DefaultCargoRunnerImage         = "rust:1"
 )
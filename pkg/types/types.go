@@ -149,6 +149,7 @@ type DeleteAdmissionResult struct {
 type ResourceRouteContext struct {
 	Stores            map[string]any
 	Resolver          v1alpha1.ResolverFunc
+	Referrers         v1alpha1.ReferrersFunc
 	RegistryValidator v1alpha1.RegistryValidatorFunc
 	Apply             func(ctx context.Context, obj v1alpha1.Object, dryRun bool) v0.ApplyResult
 	Delete            func(ctx context.Context, obj v1alpha1.Object, dryRun bool) v0.ApplyResult
@@ -351,7 +352,9 @@ type AppOptions struct {
 	// precedence over the UI handler.
 	UIHandler http.Handler
 
-	// AuthnProvider is an optional authentication provider.
+	// AuthnProvider is an optional authentication provider. It replaces
+	// the built-in JWT and OIDC providers; API tokens are still checked
+	// ahead of it.
 	AuthnProvider auth.AuthnProvider
 
 	// AuthzProvider is an optional authorization provider.
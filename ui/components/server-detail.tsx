@@ -20,6 +20,7 @@ import {
 } from "@/components/ui/tooltip"
 import { RuntimeArgumentsTable } from "@/components/server-detail/runtime-arguments-table"
 import { EnvironmentVariablesTable } from "@/components/server-detail/environment-variables-table"
+import { CapabilitiesTable } from "@/components/server-detail/capabilities-table"
 import {
   Package,
   Calendar,
@@ -47,7 +48,7 @@ export function ServerDetail({ server, onServerCopied }: ServerDetailProps) {
 
   const allTags = server.allTags || [server]
 
-  const { server: serverData, _meta } = selectedTag
+  const { server: serverData, _meta, capabilities } = selectedTag
   const official = _meta?.['io.modelcontextprotocol.registry/official']
 
   const publisherProvided = serverData._meta?.['io.modelcontextprotocol.registry/publisher-provided'] as Record<string, unknown> | undefined
@@ -158,6 +159,9 @@ export function ServerDetail({ server, onServerCopied }: ServerDetailProps) {
               {serverData.source?.package && (
                 <TabsTrigger value="packages">Package</TabsTrigger>
               )}
+              {capabilities && (
+                <TabsTrigger value="capabilities">Tools</TabsTrigger>
+              )}
               <TabsTrigger value="raw">Raw</TabsTrigger>
             </TabsList>
 
@@ -317,6 +321,10 @@ export function ServerDetail({ server, onServerCopied }: ServerDetailProps) {
               })()}
             </TabsContent>
 
+            <TabsContent value="capabilities">
+              {capabilities && <CapabilitiesTable capabilities={capabilities} />}
+            </TabsContent>
+
             <TabsContent value="raw">
               <div className="rounded-lg border p-4">
                 <div className="flex items-center justify-between mb-3">
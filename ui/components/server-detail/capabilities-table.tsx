@@ -0,0 +1,102 @@
+"use client"
+
+import { Badge } from "@/components/ui/badge"
+import { FileText, MessageSquare, Wrench } from "lucide-react"
+import type { McpServerCapabilities } from "@/lib/api/types.gen"
+
+interface CapabilitiesTableProps {
+  capabilities: McpServerCapabilities
+}
+
+export function CapabilitiesTable({ capabilities }: CapabilitiesTableProps) {
+  const tools = capabilities.tools ?? []
+  const prompts = capabilities.prompts ?? []
+  const resources = [
+    ...(capabilities.resources ?? []).map((r) => ({ uri: r.uri, name: r.name, description: r.description })),
+    ...(capabilities.resourceTemplates ?? []).map((r) => ({ uri: r.uriTemplate, name: r.name, description: r.description })),
+  ]
+
+  return (
+    <div className="space-y-6">
+      {capabilities.serverInfo && (
+        <div className="flex flex-wrap gap-2 text-xs">
+          <Badge variant="outline">{capabilities.serverInfo.name} {capabilities.serverInfo.version}</Badge>
+          {capabilities.protocolVersion && (
+            <Badge variant="secondary">MCP {capabilities.protocolVersion}</Badge>
+          )}
+        </div>
+      )}
+
+      <section>
+        <h5 className="text-sm font-semibold mb-3 flex items-center gap-2">
+          <Wrench className="h-4 w-4 text-primary" />
+          Tools ({tools.length})
+        </h5>
+        {tools.length === 0 ? (
+          <p className="text-xs text-muted-foreground">No tools</p>
+        ) : (
+          <div className="space-y-3">
+            {tools.map((tool) => (
+              <details key={tool.name} className="border rounded-lg p-3">
+                <summary className="cursor-pointer flex items-center gap-2">
+                  <code className="text-xs font-mono font-semibold">{tool.name}</code>
+                  {tool.annotations?.readOnlyHint && (
+                    <Badge variant="secondary" className="text-[10px] h-4">read-only</Badge>
+                  )}
+                  {tool.annotations?.destructiveHint !== false && !tool.annotations?.readOnlyHint && (
+                    <Badge variant="destructive" className="text-[10px] h-4">may modify</Badge>
+                  )}
+                  {tool.description && (
+                    <span className="text-xs text-muted-foreground truncate">{tool.description}</span>
+                  )}
+                </summary>
+                {tool.inputSchema != null && (
+                  <pre className="bg-muted p-3 mt-3 rounded-md overflow-x-auto text-xs leading-relaxed">
+                    {JSON.stringify(tool.inputSchema, null, 2)}
+                  </pre>
+                )}
+              </details>
+            ))}
+          </div>
+        )}
+      </section>
+
+      {prompts.length > 0 && (
+        <section>
+          <h5 className="text-sm font-semibold mb-3 flex items-center gap-2">
+            <MessageSquare className="h-4 w-4 text-primary" />
+            Prompts ({prompts.length})
+          </h5>
+          <ul className="space-y-2 text-xs">
+            {prompts.map((prompt) => (
+              <li key={prompt.name}>
+                <code className="font-mono font-semibold">{prompt.name}</code>
+                {(prompt.arguments ?? []).length > 0 && (
+                  <span className="text-muted-foreground"> ({(prompt.arguments ?? []).map((a) => a.required ? a.name : `${a.name}?`).join(", ")})</span>
+                )}
+                {prompt.description && <span className="text-muted-foreground"> — {prompt.description}</span>}
+              </li>
+            ))}
+          </ul>
+        </section>
+      )}
+
+      {resources.length > 0 && (
+        <section>
+          <h5 className="text-sm font-semibold mb-3 flex items-center gap-2">
+            <FileText className="h-4 w-4 text-primary" />
+            Resources ({resources.length})
+          </h5>
+          <ul className="space-y-2 text-xs">
+            {resources.map((resource) => (
+              <li key={resource.uri}>
+                <code className="font-mono">{resource.uri}</code>
+                {resource.description && <span className="text-muted-foreground"> — {resource.description}</span>}
+              </li>
+            ))}
+          </ul>
+        </section>
+      )}
+    </div>
+  )
+}
@@ -4,6 +4,37 @@ export type ClientOptions = {
     baseUrl: `${string}://${string}` | (string & {});
 };
 
+export type ApiToken = {
+    createdAt: string;
+    expiresAt: string;
+    id: string;
+    lastUsedAt?: string;
+    name: string;
+    /**
+     * Subject of the user who created the token.
+     */
+    owner: string;
+    revokedAt?: string;
+    scopes: Array<TokenScope> | null;
+    /**
+     * Identity the token authenticates as: the owner, or serviceaccount:NAME.
+     */
+    subject: string;
+};
+
+export type ApiTokenList = {
+    tokens: Array<ApiToken> | null;
+};
+
+export type AccessReview = {
+    allowed: boolean;
+    kind: string;
+    name?: string;
+    namespace?: string;
+    reason?: string;
+    verb: string;
+};
+
 export type Agent = {
     apiVersion: string;
     kind: string;
@@ -36,12 +67,55 @@ export type ApplyResult = {
     namespace?: string;
     status: string;
     tag?: string;
+    warnings?: Array<string> | null;
 };
 
 export type ApplyResultsResponse = {
     results: Array<ApplyResult> | null;
 };
 
+export type ArchiveInfo = {
+    /**
+     * sha256 digest to reference from spec.source.archive.digest.
+     */
+    digest: string;
+    /**
+     * Compressed size in bytes.
+     */
+    size: number;
+};
+
+export type AuthConfig = {
+    oidc?: OidcLoginConfig;
+};
+
+export type ClaimNamespaceRequest = {
+    /**
+     * Domain to prove control of, e.g. acme.com. Proving it claims the namespace com.acme.
+     */
+    domain: string;
+    /**
+     * Where the domain publishes its key: a TXT record (dns) or https://DOMAIN/.well-known/mcp-registry-auth (http). Either holds v=MCPv1; k=ed25519; p=BASE64_PUBLIC_KEY.
+     */
+    method: 'dns' | 'http';
+    /**
+     * Hex Ed25519 signature, by the published key, of the lines 'agentregistry namespace claim v1', 'domain=DOMAIN', 'namespace=NAMESPACE' and 'timestamp=TIMESTAMP', each ending in a newline.
+     */
+    signature: string;
+    /**
+     * Current time in RFC 3339 format. Must be within 15 seconds of the registry's clock.
+     */
+    timestamp: string;
+};
+
+export type ClusterRole = {
+    apiVersion: string;
+    kind: string;
+    metadata: ObjectMeta;
+    spec: RoleSpec;
+    status?: Status;
+};
+
 export type CommandEntry = {
     allowedTools?: Array<string> | null;
     argumentHint?: string;
@@ -66,6 +140,33 @@ export type Condition = {
     type: string;
 };
 
+export type CreateApiTokenRequest = {
+    /**
+     * When the token stops working: in the future and at most a year away.
+     */
+    expiresAt: string;
+    /**
+     * Name to recognize the token by.
+     */
+    name: string;
+    /**
+     * What the token may do. Scopes narrow what its subject is granted; they never widen it.
+     */
+    scopes: Array<TokenScope> | null;
+    /**
+     * Issue the token to this service account instead of the caller. Requires registry admin.
+     */
+    serviceAccount?: string;
+};
+
+export type CreateApiTokenResponse = {
+    apiToken: ApiToken;
+    /**
+     * The API token. It is shown only once.
+     */
+    token: string;
+};
+
 export type Deployment = {
     apiVersion: string;
     kind: string;
@@ -92,6 +193,10 @@ export type DeploymentSpec = {
     };
     harness?: DeploymentHarness;
     modelRef?: ModelRef;
+    promptArguments?: {
+        [key: string]: string;
+    };
+    requireSignedTarget?: boolean;
     runtimeConfig?: {
         [key: string]: unknown;
     };
@@ -231,6 +336,11 @@ export type ListOutputAgentBody = {
     nextCursor?: string;
 };
 
+export type ListOutputClusterRoleBody = {
+    items: Array<ClusterRole> | null;
+    nextCursor?: string;
+};
+
 export type ListOutputDeploymentBody = {
     items: Array<Deployment> | null;
     nextCursor?: string;
@@ -256,6 +366,16 @@ export type ListOutputPromptBody = {
     nextCursor?: string;
 };
 
+export type ListOutputRoleBindingBody = {
+    items: Array<RoleBinding> | null;
+    nextCursor?: string;
+};
+
+export type ListOutputRoleBody = {
+    items: Array<Role> | null;
+    nextCursor?: string;
+};
+
 export type ListOutputRuntimeBody = {
     items: Array<Runtime> | null;
     nextCursor?: string;
@@ -272,6 +392,12 @@ export type McpArgument = {
     value?: string;
 };
 
+export type McpImplementation = {
+    name: string;
+    title?: string;
+    version?: string;
+};
+
 export type McpKeyValueInput = {
     isRequired?: boolean;
     name: string;
@@ -291,6 +417,9 @@ export type McpPackageLaunch = {
 };
 
 export type McpPackageOrigin = {
+    cargo?: McpPackageOriginCargo;
+    githubRelease?: McpPackageOriginGitHubRelease;
+    go?: McpPackageOriginGo;
     identifier: string;
     npm?: McpPackageOriginNpm;
     oci?: McpPackageOriginOci;
@@ -298,6 +427,28 @@ export type McpPackageOrigin = {
     type: string;
 };
 
+export type McpPackageOriginCargo = {
+    binary?: string;
+    mirror?: string;
+    serverName: string;
+    version: string;
+};
+
+export type McpPackageOriginGitHubRelease = {
+    asset: string;
+    binary?: string;
+    mirror?: string;
+    serverName: string;
+    sha256?: string;
+    version: string;
+};
+
+export type McpPackageOriginGo = {
+    mirror?: string;
+    serverName: string;
+    version: string;
+};
+
 export type McpPackageOriginNpm = {
     mirror?: string;
     serverName: string;
@@ -314,18 +465,57 @@ export type McpPackageOriginPyPi = {
     version: string;
 };
 
+export type McpPromptArgumentInfo = {
+    description?: string;
+    name: string;
+    required?: boolean;
+};
+
+export type McpPromptInfo = {
+    arguments?: Array<McpPromptArgumentInfo> | null;
+    description?: string;
+    name: string;
+    title?: string;
+};
+
 export type McpRemote = {
     headers?: Array<HttpHeader> | null;
     type: string;
     url: string;
 };
 
+export type McpResourceInfo = {
+    description?: string;
+    mimeType?: string;
+    name: string;
+    title?: string;
+    uri: string;
+};
+
+export type McpResourceTemplateInfo = {
+    description?: string;
+    mimeType?: string;
+    name: string;
+    title?: string;
+    uriTemplate: string;
+};
+
 export type McpServer = {
     apiVersion: string;
     kind: string;
     metadata: ObjectMeta;
     spec: McpServerSpec;
-    status?: Status;
+    status?: McpServerStatus;
+};
+
+export type McpServerCapabilities = {
+    instructions?: string;
+    prompts?: Array<McpPromptInfo> | null;
+    protocolVersion?: string;
+    resourceTemplates?: Array<McpResourceTemplateInfo> | null;
+    resources?: Array<McpResourceInfo> | null;
+    serverInfo?: McpImplementation;
+    tools?: Array<McpToolInfo> | null;
 };
 
 export type McpServerEntry = {
@@ -343,6 +533,12 @@ export type McpServerEntry = {
     url?: string;
 };
 
+export type McpServerImage = {
+    digest: string;
+    provenanceBuilder?: string;
+    signer?: string;
+};
+
 export type McpServerOAuth = {
     authServerMetadataUrl?: string;
     callbackPort?: number;
@@ -362,6 +558,14 @@ export type McpServerSpec = {
     title?: string;
 };
 
+export type McpServerStatus = {
+    capabilities?: McpServerCapabilities;
+    conditions?: Array<Condition> | null;
+    details?: unknown;
+    image?: McpServerImage;
+    lifecycle?: TagLifecycle;
+};
+
 export type McpServersField = {
     Path: string;
     Raw: unknown;
@@ -370,6 +574,22 @@ export type McpServersField = {
     };
 };
 
+export type McpToolAnnotations = {
+    destructiveHint?: boolean;
+    idempotentHint?: boolean;
+    openWorldHint?: boolean;
+    readOnlyHint?: boolean;
+};
+
+export type McpToolInfo = {
+    annotations?: McpToolAnnotations;
+    description?: string;
+    inputSchema?: unknown;
+    name: string;
+    outputSchema?: unknown;
+    title?: string;
+};
+
 export type McpTransport = {
     path?: string;
     port?: number;
@@ -390,7 +610,10 @@ export type ModelAuthConfig = {
 };
 
 export type ModelEndpointConfig = {
+    apiVersion?: string;
     baseUrl?: string;
+    deployment?: string;
+    project?: string;
     region?: string;
     tls?: ModelTlsConfig;
 };
@@ -406,7 +629,7 @@ export type ModelSpec = {
     description?: string;
     endpoint?: ModelEndpointConfig;
     model: string;
-    provider: 'bedrock';
+    provider: 'bedrock' | 'openai' | 'anthropic' | 'azure-openai' | 'vertex' | 'openai-compatible';
     title?: string;
 };
 
@@ -427,6 +650,50 @@ export type MonitorsField = {
     Path: string;
 };
 
+export type NamespaceOwner = {
+    createdAt: string;
+    domain: string;
+    /**
+     * How ownership was proven: a DNS TXT record on the domain, or https://DOMAIN/.well-known/mcp-registry-auth.
+     */
+    method: 'dns' | 'http';
+    /**
+     * Owned namespace: the domain reversed, e.g. com.acme for acme.com.
+     */
+    namespace: string;
+    /**
+     * Base64 Ed25519 public key that proved ownership. Artifacts published in the namespace must be signed with it.
+     */
+    publicKey: string;
+    /**
+     * When ownership was last proven.
+     */
+    verifiedAt: string;
+    /**
+     * Subject of the caller who submitted the latest proof.
+     */
+    verifiedBy?: string;
+};
+
+export type NamespaceOwnerList = {
+    owners: Array<NamespaceOwner> | null;
+};
+
+export type OidcLoginConfig = {
+    /**
+     * Public OAuth client ID for CLI sign-in.
+     */
+    clientId: string;
+    /**
+     * OpenID Connect issuer URL.
+     */
+    issuer: string;
+    /**
+     * Scopes to request.
+     */
+    scopes: Array<string> | null;
+};
+
 export type ObjectMeta = {
     annotations?: {
         [key: string]: string;
@@ -576,6 +843,7 @@ export type PluginStatus = {
     conditions?: Array<Condition> | null;
     details?: unknown;
     inventory?: PluginInventory;
+    lifecycle?: TagLifecycle;
     manifest?: PluginManifest;
     resolvedSource?: PluginResolvedSource;
 };
@@ -592,6 +860,33 @@ export type PluginUserConfigField = {
     type: string;
 };
 
+export type PolicyRule = {
+    kinds: Array<string> | null;
+    names?: Array<string> | null;
+    namespaces?: Array<string> | null;
+    verbs: Array<string> | null;
+};
+
+export type PromoteTagRequest = {
+    /**
+     * Concrete tag or existing alias to promote.
+     */
+    from: string;
+    /**
+     * Alias to create or move (e.g. stable).
+     */
+    to: string;
+};
+
+export type PromoteTagResponse = {
+    alias: string;
+    name: string;
+    namespace: string;
+    status: string;
+    target: string;
+    updatedAt: string;
+};
+
 export type Prompt = {
     apiVersion: string;
     kind: string;
@@ -600,9 +895,40 @@ export type Prompt = {
     status?: Status;
 };
 
+export type PromptArgument = {
+    default?: string;
+    description?: string;
+    name: string;
+    required?: boolean;
+    type?: 'string' | 'number' | 'integer' | 'boolean';
+};
+
+export type PromptMessage = {
+    content: string;
+    role: 'system' | 'user' | 'assistant';
+};
+
 export type PromptSpec = {
+    arguments?: Array<PromptArgument> | null;
     content?: string;
     description?: string;
+    messages?: Array<PromptMessage> | null;
+};
+
+export type Referrer = {
+    kind: string;
+    name: string;
+    namespace: string;
+    path?: string;
+    tag?: string;
+};
+
+export type ReferrerListResponse = {
+    items: Array<Referrer> | null;
+    kind: string;
+    name: string;
+    namespace: string;
+    tag?: string;
 };
 
 export type Repository = {
@@ -623,6 +949,52 @@ export type ResponseMeta = {
     'io.modelcontextprotocol.registry/official'?: OfficialMeta;
 };
 
+export type Role = {
+    apiVersion: string;
+    kind: string;
+    metadata: ObjectMeta;
+    spec: RoleSpec;
+    status?: Status;
+};
+
+export type RoleBinding = {
+    apiVersion: string;
+    kind: string;
+    metadata: ObjectMeta;
+    spec: RoleBindingSpec;
+    status?: Status;
+};
+
+export type RoleBindingSpec = {
+    roleRef: RoleRef;
+    subjects: Array<Subject> | null;
+};
+
+export type RoleRef = {
+    kind: string;
+    name: string;
+};
+
+export type RoleSpec = {
+    description?: string;
+    rules?: Array<PolicyRule> | null;
+};
+
+export type RollbackTagRequest = {
+    /**
+     * Generation whose content becomes current again.
+     */
+    generation: number;
+};
+
+export type RollbackTagResponse = {
+    generation: number;
+    name: string;
+    namespace: string;
+    status: string;
+    tag: string;
+};
+
 export type Runtime = {
     apiVersion: string;
     kind: string;
@@ -639,6 +1011,22 @@ export type RuntimeSpec = {
     type: string;
 };
 
+export type SearchResponse = {
+    items: Array<SearchResult> | null;
+    query: string;
+};
+
+export type SearchResult = {
+    description?: string;
+    highlight?: string;
+    kind: string;
+    name: string;
+    namespace: string;
+    score: number;
+    tag?: string;
+    title?: string;
+};
+
 export type SecretKeyRef = {
     key?: string;
     name: string;
@@ -705,6 +1093,21 @@ export type ServerTransport = {
     url?: string;
 };
 
+export type SetTagLifecycleRequest = {
+    /**
+     * Why the tag was deprecated or yanked.
+     */
+    reason?: string;
+    /**
+     * Existing tag consumers should move to.
+     */
+    replacement?: string;
+    /**
+     * Lifecycle state of the tag.
+     */
+    state: 'Active' | 'Deprecated' | 'Yanked';
+};
+
 export type Skill = {
     apiVersion: string;
     kind: string;
@@ -715,12 +1118,23 @@ export type Skill = {
 
 export type SkillResolvedSource = {
     commit?: string;
+    digest?: string;
 };
 
 export type SkillSource = {
+    archive?: SkillSourceArchive;
+    oci?: SkillSourceOci;
     repository?: Repository;
 };
 
+export type SkillSourceArchive = {
+    digest: string;
+};
+
+export type SkillSourceOci = {
+    reference: string;
+};
+
 export type SkillSpec = {
     description?: string;
     source?: SkillSource;
@@ -730,12 +1144,73 @@ export type SkillSpec = {
 export type SkillStatus = {
     conditions?: Array<Condition> | null;
     details?: unknown;
+    lifecycle?: TagLifecycle;
     resolvedSource?: SkillResolvedSource;
 };
 
 export type Status = {
     conditions?: Array<Condition> | null;
     details?: unknown;
+    lifecycle?: TagLifecycle;
+};
+
+export type Subject = {
+    kind: string;
+    name: string;
+};
+
+export type TagAlias = {
+    alias: string;
+    name: string;
+    namespace: string;
+    target: string;
+    updatedAt: string;
+};
+
+export type TagAliasListResponse = {
+    items: Array<TagAlias> | null;
+};
+
+export type TagLifecycle = {
+    reason?: string;
+    replacement?: string;
+    state: 'Deprecated' | 'Yanked';
+};
+
+export type TagLifecycleResponse = {
+    name: string;
+    namespace: string;
+    reason?: string;
+    replacement?: string;
+    state: string;
+    tag: string;
+};
+
+export type TagRevision = {
+    appliedAt: string;
+    contentHash: string;
+    current?: boolean;
+    generation: number;
+    name: string;
+    namespace: string;
+    replacedAt?: string;
+    tag: string;
+    uid: string;
+};
+
+export type TagRevisionListResponse = {
+    items: Array<TagRevision> | null;
+};
+
+export type TokenScope = {
+    /**
+     * Action the scope allows: read (get and list), publish, edit or deploy (apply), or delete.
+     */
+    action: 'read' | 'publish' | 'edit' | 'deploy' | 'delete';
+    /**
+     * Resources the scope covers: "*", or NAMESPACE/NAME where NAMESPACE may be "*" and NAME may end in "*" (e.g. team-a/support-*).
+     */
+    resource: string;
 };
 
 export type VersionBody = {
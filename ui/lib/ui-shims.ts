@@ -23,6 +23,7 @@ import type {
   AgentSpec,
   Deployment,
   McpServer,
+  McpServerCapabilities,
   McpServerSpec,
   Prompt,
   PromptSpec,
@@ -76,6 +77,9 @@ type LegacyInner<Spec, Extras = object> = Spec & {
 export interface ServerResponse {
   server: LegacyInner<McpServerSpec>
   _meta?: Record<string, any>
+  // What the MCPServer controller observed the server expose, when
+  // introspection is enabled and has succeeded for this tag.
+  capabilities?: McpServerCapabilities
 }
 
 export interface SkillResponse {
@@ -115,7 +119,11 @@ function inner<Spec extends object>(
 }
 
 export function toServerResponse(m: McpServer): ServerResponse {
-  return { server: inner(m.metadata, m.spec), _meta: m.metadata.annotations ?? {} }
+  return {
+    server: inner(m.metadata, m.spec),
+    _meta: m.metadata.annotations ?? {},
+    capabilities: m.status?.capabilities,
+  }
 }
 
 export function toSkillResponse(s: Skill): SkillResponse {